@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var experimentalAuditCmd = cli.Command{
+	Name:  "audit",
+	Short: "CDS Experimental audit commands",
+}
+
+func experimentalAudit() *cobra.Command {
+	return cli.NewCommand(experimentalAuditCmd, nil, []*cobra.Command{
+		cli.NewListCommand(auditSearchCmd, auditSearchFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var auditSearchCmd = cli.Command{
+	Name:  "search",
+	Short: "Search the audits of v2 entities, most recent first. Without project, maintainer role is required",
+	Example: `cdsctl X audit search --project MYPROJECT --entity-type variableset-item --since 24h
+cdsctl X audit search --user john --since 2024-01-01T00:00:00Z --until 2024-02-01T00:00:00Z
+cdsctl X audit search --project MYPROJECT --entity-type vcs --entity-name github --format json`,
+	Flags: []cli.Flag{
+		{Name: "project", Type: cli.FlagString, Usage: "Project key"},
+		{Name: "user", Type: cli.FlagString, Usage: "Username of the actor"},
+		{Name: "consumer", Type: cli.FlagString, Usage: "Consumer ID of the actor"},
+		{Name: "entity-type", Type: cli.FlagString, Usage: "Entity type: project, vcs, repository, variableset, variableset-item, integration, notification, key, permission, region..."},
+		{Name: "entity-name", Type: cli.FlagString, Usage: "Entity name"},
+		{Name: "event-type", Type: cli.FlagString, Usage: "Event type, e.g. VariableSetItemUpdated"},
+		{Name: "since", Type: cli.FlagString, Usage: "RFC3339 date or duration relative to now, e.g. 24h"},
+		{Name: "until", Type: cli.FlagString, Usage: "RFC3339 date or duration relative to now, e.g. 1h"},
+		{Name: "limit", Type: cli.FlagString, Usage: "Max number of audits, default is 50"},
+		{Name: "offset", Type: cli.FlagString, Usage: "Number of audits to skip"},
+	},
+}
+
+func auditSearchFunc(v cli.Values) (cli.ListResult, error) {
+	params := map[string]string{
+		"user":        v.GetString("user"),
+		"consumer":    v.GetString("consumer"),
+		"entity_type": v.GetString("entity-type"),
+		"entity_name": v.GetString("entity-name"),
+		"event_type":  v.GetString("event-type"),
+		"since":       v.GetString("since"),
+		"until":       v.GetString("until"),
+		"limit":       v.GetString("limit"),
+		"offset":      v.GetString("offset"),
+	}
+	var mods []cdsclient.RequestModifier
+	for k, value := range params {
+		if value != "" {
+			mods = append(mods, cdsclient.WithQueryParameter(k, value))
+		}
+	}
+
+	if projectKey := v.GetString("project"); projectKey != "" {
+		audits, err := client.ProjectAuditV2List(context.Background(), projectKey, mods...)
+		return cli.AsListResult(audits), err
+	}
+	audits, err := client.AuditV2List(context.Background(), mods...)
+	return cli.AsListResult(audits), err
+}
@@ -20,6 +20,8 @@ func experimentalOrganization() *cobra.Command {
 		cli.NewGetCommand(organizationGetCmd, organizationGetFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(organizationListCmd, organizationListFunc, nil, withAllCommandModifiers()...),
 		cli.NewDeleteCommand(organizationDeleteCmd, organizationDeleteFunc, nil, withAllCommandModifiers()...),
+		organizationPolicy(),
+		organizationConcurrency(),
 	})
 }
 
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var policyFlags = []cli.Flag{
+	{Name: "description", Type: cli.FlagString},
+	{Name: "language", Type: cli.FlagString, Usage: "Language of the rule: expression | rego"},
+	{Name: "rule", Type: cli.FlagString, Usage: "Expression raising the outcome when it is true"},
+	{Name: "file", Type: cli.FlagString, Usage: "File containing the rule, such as a Rego module"},
+	{Name: "outcome", Type: cli.FlagString, Usage: "Outcome of an expression rule: deny | warn"},
+	{Name: "message", Type: cli.FlagString, Usage: "Message of an expression rule"},
+}
+
+// setPolicyFromFlags overrides the policy with the given flags
+func setPolicyFromFlags(v cli.Values, p *sdk.Policy) error {
+	if v.GetString("description") != "" {
+		p.Description = v.GetString("description")
+	}
+	if v.GetString("language") != "" {
+		p.Language = sdk.PolicyLanguage(v.GetString("language"))
+	}
+	if v.GetString("rule") != "" {
+		p.Rule = v.GetString("rule")
+	}
+	if v.GetString("file") != "" {
+		btes, err := os.ReadFile(v.GetString("file"))
+		if err != nil {
+			return cli.WrapError(err, "unable to read file %s", v.GetString("file"))
+		}
+		p.Rule = string(btes)
+	}
+	if v.GetString("outcome") != "" {
+		p.Outcome = sdk.PolicyOutcome(v.GetString("outcome"))
+	}
+	if v.GetString("message") != "" {
+		p.Message = v.GetString("message")
+	}
+	return nil
+}
+
+var projectPolicyCmd = cli.Command{
+	Name:    "policy",
+	Aliases: []string{"policies"},
+	Short:   "Manage policies checked on the as-code entities of a CDS project",
+}
+
+func projectPolicy() *cobra.Command {
+	return cli.NewCommand(projectPolicyCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectPolicyListCmd, projectPolicyListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectPolicyShowCmd, projectPolicyShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectPolicyCreateCmd, projectPolicyCreateFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectPolicyUpdateCmd, projectPolicyUpdateFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectPolicyDeleteCmd, projectPolicyDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectPolicyListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List all policies in the given project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "with-organization", Type: cli.FlagBool, Usage: "Also list the policies of the project organization"},
+	},
+	Mcp: true,
+}
+
+func projectPolicyListFunc(v cli.Values) (cli.ListResult, error) {
+	ps, err := client.ProjectPolicyList(context.Background(), v.GetString(_ProjectKey), v.GetBool("with-organization"))
+	return cli.AsListResult(ps), err
+}
+
+var projectPolicyShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Get the given policy",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectPolicyShowFunc(v cli.Values) (interface{}, error) {
+	return client.ProjectPolicyGet(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+}
+
+var projectPolicyCreateCmd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"create"},
+	Short:   "Create a new policy inside the given project",
+	Example: `cdsctl X project policy add MY-PROJECT prod-gate Workflow --language expression --rule "contains(entity.vars, 'prod') && !contains(toJSON(entity.gates), 'reviewers')" --outcome deny --message "prod workflows must have a gate with reviewers"
+cdsctl X project policy add MY-PROJECT library-models Workflow --language rego --file ./models.rego`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+		{Name: "entity-type"},
+	},
+	Flags: policyFlags,
+}
+
+func projectPolicyCreateFunc(v cli.Values) error {
+	p := sdk.Policy{
+		Name:       v.GetString("name"),
+		EntityType: v.GetString("entity-type"),
+		Language:   sdk.PolicyLanguageExpression,
+	}
+	if err := setPolicyFromFlags(v, &p); err != nil {
+		return err
+	}
+	return client.ProjectPolicyCreate(context.Background(), v.GetString(_ProjectKey), &p)
+}
+
+var projectPolicyUpdateCmd = cli.Command{
+	Name:    "update",
+	Aliases: []string{"up"},
+	Short:   "Update the given policy inside the given project",
+	Example: "cdsctl X project policy update MY-PROJECT library-models --file ./models.rego",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+	Flags: policyFlags,
+}
+
+func projectPolicyUpdateFunc(v cli.Values) error {
+	p, err := client.ProjectPolicyGet(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	if err := setPolicyFromFlags(v, p); err != nil {
+		return err
+	}
+	return client.ProjectPolicyUpdate(context.Background(), v.GetString(_ProjectKey), p)
+}
+
+var projectPolicyDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete a policy on a project",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "name"},
+	},
+}
+
+func projectPolicyDeleteFunc(v cli.Values) error {
+	return client.ProjectPolicyDelete(context.Background(), v.GetString(_ProjectKey), v.GetString("name"))
+}
+
+var organizationPolicyCmd = cli.Command{
+	Name:    "policy",
+	Aliases: []string{"policies"},
+	Short:   "Manage policies checked on the as-code entities of all projects of an organization",
+}
+
+func organizationPolicy() *cobra.Command {
+	return cli.NewCommand(organizationPolicyCmd, nil, []*cobra.Command{
+		cli.NewListCommand(organizationPolicyListCmd, organizationPolicyListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(organizationPolicyShowCmd, organizationPolicyShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(organizationPolicyCreateCmd, organizationPolicyCreateFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(organizationPolicyUpdateCmd, organizationPolicyUpdateFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(organizationPolicyDeleteCmd, organizationPolicyDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var organizationPolicyListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List all policies of the given organization",
+	Args: []cli.Arg{
+		{Name: "organizationIdentifier"},
+	},
+}
+
+func organizationPolicyListFunc(v cli.Values) (cli.ListResult, error) {
+	ps, err := client.OrganizationPolicyList(context.Background(), v.GetString("organizationIdentifier"))
+	return cli.AsListResult(ps), err
+}
+
+var organizationPolicyShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Get the given organization policy",
+	Args: []cli.Arg{
+		{Name: "organizationIdentifier"},
+		{Name: "name"},
+	},
+}
+
+func organizationPolicyShowFunc(v cli.Values) (interface{}, error) {
+	return client.OrganizationPolicyGet(context.Background(), v.GetString("organizationIdentifier"), v.GetString("name"))
+}
+
+var organizationPolicyCreateCmd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"create"},
+	Short:   "Create a new policy on the given organization",
+	Example: "cdsctl X organization policy add my-organization library-models Workflow --language rego --file ./models.rego",
+	Args: []cli.Arg{
+		{Name: "organizationIdentifier"},
+		{Name: "name"},
+		{Name: "entity-type"},
+	},
+	Flags: policyFlags,
+}
+
+func organizationPolicyCreateFunc(v cli.Values) error {
+	p := sdk.Policy{
+		Name:       v.GetString("name"),
+		EntityType: v.GetString("entity-type"),
+		Language:   sdk.PolicyLanguageExpression,
+	}
+	if err := setPolicyFromFlags(v, &p); err != nil {
+		return err
+	}
+	return client.OrganizationPolicyCreate(context.Background(), v.GetString("organizationIdentifier"), &p)
+}
+
+var organizationPolicyUpdateCmd = cli.Command{
+	Name:    "update",
+	Aliases: []string{"up"},
+	Short:   "Update the given organization policy",
+	Example: "cdsctl X organization policy update my-organization library-models --file ./models.rego",
+	Args: []cli.Arg{
+		{Name: "organizationIdentifier"},
+		{Name: "name"},
+	},
+	Flags: policyFlags,
+}
+
+func organizationPolicyUpdateFunc(v cli.Values) error {
+	p, err := client.OrganizationPolicyGet(context.Background(), v.GetString("organizationIdentifier"), v.GetString("name"))
+	if err != nil {
+		return err
+	}
+	if err := setPolicyFromFlags(v, p); err != nil {
+		return err
+	}
+	return client.OrganizationPolicyUpdate(context.Background(), v.GetString("organizationIdentifier"), p)
+}
+
+var organizationPolicyDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete a policy on an organization",
+	Args: []cli.Arg{
+		{Name: "organizationIdentifier"},
+		{Name: "name"},
+	},
+}
+
+func organizationPolicyDeleteFunc(v cli.Values) error {
+	return client.OrganizationPolicyDelete(context.Background(), v.GetString("organizationIdentifier"), v.GetString("name"))
+}
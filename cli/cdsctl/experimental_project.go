@@ -19,11 +19,16 @@ func experimentalProject() *cobra.Command {
 		cli.NewCommand(projectDeleteRunCmd, projectDeleteRunCmdFunc, nil, withAllCommandModifiers()...),
 		projectRepository(),
 		projectRepositoryAnalysis(),
+		projectRepositoryMergeQueue(),
 		projectNotification(),
 		projectVariableSet(),
 		projectConcurrency(),
+		projectPolicy(),
 		projectWebHooks(),
 		projectRetention(),
+		cli.NewCommand(projectArchiveKeyCmd, projectArchiveKeyFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(projectExportCmd, projectExportFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(projectImportCmd, projectImportFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
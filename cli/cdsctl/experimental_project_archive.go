@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var projectArchiveKeyCmd = cli.Command{
+	Name:  "archive-key",
+	Short: "Show the public key of the CDS instance, to add in the trusted instances of the instances exchanging project archives with it",
+}
+
+func projectArchiveKeyFunc(_ cli.Values) error {
+	key, err := client.ProjectArchiveKey(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Print(key)
+	return nil
+}
+
+var projectExportCmd = cli.Command{
+	Name:  "export",
+	Short: "Export the configuration of a project in a signed archive",
+	Long: `Export variable sets, vcs servers, repositories, integrations, notifications, concurrencies, run filters, run retention,
+keys and permissions of the project. Secrets are encrypted for the target CDS instance, that must be declared in the trusted
+instances of the API configuration. Without target, the archive can only be imported on the current instance.`,
+	Example: `cdsctl X project export MYPROJ --target production > myproj.json`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "target", Type: cli.FlagString, Usage: "Name of the trusted CDS instance where the archive will be imported"},
+		{Name: "output", ShortHand: "o", Type: cli.FlagString, Usage: "Write the archive in the given file instead of the standard output"},
+	},
+}
+
+func projectExportFunc(v cli.Values) error {
+	archive, err := client.ProjectExport(context.Background(), v.GetString(_ProjectKey), sdk.ProjectExportRequest{
+		Target: v.GetString("target"),
+	})
+	if err != nil {
+		return err
+	}
+	btes, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return cli.WrapError(err, "unable to marshal archive")
+	}
+	if v.GetString("output") == "" {
+		fmt.Println(string(btes))
+		return nil
+	}
+	if err := os.WriteFile(v.GetString("output"), btes, 0600); err != nil {
+		return cli.WrapError(err, "unable to write file %s", v.GetString("output"))
+	}
+	return nil
+}
+
+var projectImportCmd = cli.Command{
+	Name:    "import",
+	Short:   "Import a signed project archive in an existing project",
+	Long:    "Existing entities are skipped, use --force to update them with the content of the archive.",
+	Example: `cdsctl X project import MYPROJ myproj.json --force`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "filename"},
+	},
+	Flags: []cli.Flag{
+		{Name: "force", Type: cli.FlagBool, Usage: "Update existing entities"},
+	},
+}
+
+func projectImportFunc(v cli.Values) (cli.ListResult, error) {
+	btes, err := os.ReadFile(v.GetString("filename"))
+	if err != nil {
+		return nil, cli.WrapError(err, "unable to open file %s", v.GetString("filename"))
+	}
+	var archive sdk.SignedProjectArchive
+	if err := json.Unmarshal(btes, &archive); err != nil {
+		return nil, cli.WrapError(err, "unable to parse file %s", v.GetString("filename"))
+	}
+	report, err := client.ProjectImport(context.Background(), v.GetString(_ProjectKey), archive,
+		cdsclient.WithQueryParameter("force", strconv.FormatBool(v.GetBool("force"))))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(report), nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var projectMergeQueueCmd = cli.Command{
+	Name:    "mergequeue",
+	Aliases: []string{"mq"},
+	Short:   "Manage the merge queue of a repository",
+}
+
+func projectRepositoryMergeQueue() *cobra.Command {
+	return cli.NewCommand(projectMergeQueueCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectMergeQueueListCmd, projectMergeQueueListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(projectMergeQueueAddCmd, projectMergeQueueAddFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(projectMergeQueueDeleteCmd, projectMergeQueueDeleteFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectMergeQueueListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the pull requests of the merge queue",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "vcs-name"},
+		{Name: "repository-name"},
+	},
+}
+
+func projectMergeQueueListFunc(v cli.Values) (cli.ListResult, error) {
+	entries, err := client.ProjectRepositoryMergeQueueList(context.Background(), v.GetString(_ProjectKey), v.GetString("vcs-name"), v.GetString("repository-name"))
+	if err != nil {
+		return nil, err
+	}
+	type CliEntry struct {
+		ID          string `cli:"id" json:"id"`
+		PullRequest int64  `cli:"pull_request" json:"pull_request"`
+		Title       string `cli:"title" json:"title"`
+		BaseBranch  string `cli:"base_branch" json:"base_branch"`
+		Status      string `cli:"status" json:"status"`
+		Error       string `cli:"error" json:"error"`
+	}
+	res := make([]CliEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, CliEntry{
+			ID:          e.ID,
+			PullRequest: e.PullRequestID,
+			Title:       e.PullRequestTitle,
+			BaseBranch:  e.BaseBranch,
+			Status:      string(e.Status),
+			Error:       e.Error,
+		})
+	}
+	return cli.AsListResult(res), nil
+}
+
+var projectMergeQueueAddCmd = cli.Command{
+	Name:    "add",
+	Aliases: []string{"enqueue"},
+	Short:   "Add a pull request in the merge queue",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "vcs-name"},
+		{Name: "repository-name"},
+		{Name: "pull-request-id"},
+	},
+}
+
+func projectMergeQueueAddFunc(v cli.Values) (interface{}, error) {
+	prID, err := strconv.ParseInt(v.GetString("pull-request-id"), 10, 64)
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pull request id %q", v.GetString("pull-request-id"))
+	}
+	return client.ProjectRepositoryMergeQueueAdd(context.Background(), v.GetString(_ProjectKey), v.GetString("vcs-name"), v.GetString("repository-name"), prID)
+}
+
+var projectMergeQueueDeleteCmd = cli.Command{
+	Name:    "delete",
+	Aliases: []string{"remove", "rm"},
+	Short:   "Remove a queued pull request from the merge queue",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "vcs-name"},
+		{Name: "repository-name"},
+		{Name: "entry-id"},
+	},
+}
+
+func projectMergeQueueDeleteFunc(v cli.Values) error {
+	return client.ProjectRepositoryMergeQueueDelete(context.Background(), v.GetString(_ProjectKey), v.GetString("vcs-name"), v.GetString("repository-name"), v.GetString("entry-id"))
+}
@@ -28,6 +28,8 @@ func experimentalRbac() *cobra.Command {
 		cli.NewListCommand(rbacListCmd, rbacListFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(rbacUserCmd, rbacUserPermissionFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(rbacGroupCmd, rbacGroupPermissionFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(rbacWorkflowCheckCmd, rbacWorkflowCheckFunc, nil, withAllCommandModifiers()...),
+		rbacGrant(),
 	})
 }
 
@@ -183,3 +185,40 @@ func rbacGroupPermissionFunc(v cli.Values) error {
 	fmt.Printf("%s", string(result))
 	return nil
 }
+
+var rbacWorkflowCheckCmd = cli.Command{
+	Name:    "workflow-check",
+	Aliases: []string{},
+	Short:   "Explain which permissions grant or deny a role on a workflow",
+	Example: "cdsctl X rbac workflow-check <project_key> <vcs_name> <repository_name> <workflow_name> stop --ref refs/heads/main",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "vcs_name"},
+		{Name: "repository_name"},
+		{Name: "workflow_name"},
+		{Name: "role"},
+	},
+	Flags: []cli.Flag{
+		{Name: "ref", Type: cli.FlagString, Usage: "Git ref of the workflow run"},
+		{Name: "username", Type: cli.FlagString, Usage: "Check the permissions of another user"},
+	},
+	Mcp: true,
+}
+
+func rbacWorkflowCheckFunc(v cli.Values) error {
+	check, err := client.RBACWorkflowCheck(context.Background(), v.GetString("proj_key"), sdk.RBACWorkflowCheckRequest{
+		Role:       v.GetString("role"),
+		VCSServer:  v.GetString("vcs_name"),
+		Repository: v.GetString("repository_name"),
+		Workflow:   v.GetString("workflow_name"),
+		Ref:        v.GetString("ref"),
+		Username:   v.GetString("username"),
+	})
+	if err != nil {
+		return err
+	}
+	result, _ := yaml.Marshal(check)
+	fmt.Printf("%s", string(result))
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var rbacGrantCmd = cli.Command{
+	Name:    "grant",
+	Aliases: []string{"grants"},
+	Short:   "Manage temporary permissions requested by users and approved by the approver group",
+}
+
+func rbacGrant() *cobra.Command {
+	return cli.NewCommand(rbacGrantCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(rbacGrantRequestCmd, rbacGrantRequestFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(rbacGrantListCmd, rbacGrantListFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantShowCmd, rbacGrantShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(rbacGrantAuditCmd, rbacGrantAuditFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantReviewCmd("approve", "Approve a temporary permission request"), rbacGrantReviewFunc("approve"), nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantReviewCmd("reject", "Reject a temporary permission request"), rbacGrantReviewFunc("reject"), nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(rbacGrantReviewCmd("revoke", "Revoke a temporary permission before its expiration"), rbacGrantReviewFunc("revoke"), nil, withAllCommandModifiers()...),
+	})
+}
+
+var rbacGrantRequestCmd = cli.Command{
+	Name:  "request",
+	Short: "Request a temporary permission, scope is one of project, variableset or workflow",
+	Example: `cdsctl X rbac grant request project MYPROJECT manage 4h "Incident #42: fix production variables"
+cdsctl X rbac grant request variableset MYPROJECT manage-item 2h "Incident #42" --target prod-secrets
+cdsctl X rbac grant request workflow MYPROJECT trigger 1h "Incident #42" --target github/my/repo/deploy-*`,
+	Args: []cli.Arg{
+		{Name: "scope"},
+		{Name: "proj_key"},
+		{Name: "role"},
+		{Name: "duration"},
+		{Name: "justification"},
+	},
+	Flags: []cli.Flag{
+		{Name: "target", Type: cli.FlagString, Usage: "Variable set name or workflow pattern <vcs>/<repository>/<workflow>"},
+	},
+}
+
+func rbacGrantRequestFunc(v cli.Values) (interface{}, error) {
+	return client.RBACGrantRequest(context.Background(), sdk.RBACGrantRequest{
+		Scope:         v.GetString("scope"),
+		ProjectKey:    v.GetString("proj_key"),
+		Target:        v.GetString("target"),
+		Role:          v.GetString("role"),
+		Duration:      v.GetString("duration"),
+		Justification: v.GetString("justification"),
+	})
+}
+
+var rbacGrantListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List temporary permissions. Approvers see all requests, other users see their own requests",
+	Example: "cdsctl X rbac grant list --status requested,approved",
+	Flags: []cli.Flag{
+		{Name: "status", Type: cli.FlagString, Usage: "Comma separated list of status: requested, approved, rejected, revoked, expired"},
+	},
+}
+
+func rbacGrantListFunc(v cli.Values) (cli.ListResult, error) {
+	var status []string
+	if v.GetString("status") != "" {
+		status = strings.Split(v.GetString("status"), ",")
+	}
+	grants, err := client.RBACGrantList(context.Background(), status...)
+	return cli.AsListResult(grants), err
+}
+
+var rbacGrantShowCmd = cli.Command{
+	Name:    "show",
+	Aliases: []string{"get"},
+	Short:   "Show a temporary permission",
+	Args: []cli.Arg{
+		{Name: "grant_id"},
+	},
+}
+
+func rbacGrantShowFunc(v cli.Values) (interface{}, error) {
+	return client.RBACGrantGet(context.Background(), v.GetString("grant_id"))
+}
+
+var rbacGrantAuditCmd = cli.Command{
+	Name:  "audit",
+	Short: "Show the request, the reviews and all the uses of a temporary permission",
+	Args: []cli.Arg{
+		{Name: "grant_id"},
+	},
+}
+
+func rbacGrantAuditFunc(v cli.Values) (cli.ListResult, error) {
+	audits, err := client.RBACGrantAudits(context.Background(), v.GetString("grant_id"))
+	return cli.AsListResult(audits), err
+}
+
+func rbacGrantReviewCmd(action string, short string) cli.Command {
+	return cli.Command{
+		Name:  action,
+		Short: short,
+		Args: []cli.Arg{
+			{Name: "grant_id"},
+		},
+		Flags: []cli.Flag{
+			{Name: "comment", Type: cli.FlagString},
+		},
+	}
+}
+
+func rbacGrantReviewFunc(action string) cli.RunGetFunc {
+	return func(v cli.Values) (interface{}, error) {
+		return client.RBACGrantReview(context.Background(), v.GetString("grant_id"), action, sdk.RBACGrantReview{
+			Comment: v.GetString("comment"),
+		})
+	}
+}
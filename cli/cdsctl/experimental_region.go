@@ -20,6 +20,7 @@ func experimentalRegion() *cobra.Command {
 		cli.NewGetCommand(regionGetCmd, regionGetFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(regionListCmd, regionListFunc, nil, withAllCommandModifiers()...),
 		cli.NewDeleteCommand(regionDeleteCmd, regionDeleteFunc, nil, withAllCommandModifiers()...),
+		regionConcurrency(),
 	})
 }
 
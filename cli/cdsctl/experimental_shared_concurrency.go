@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+func organizationConcurrency() *cobra.Command {
+	return sharedConcurrency(sdk.V2RunConcurrencyScopeOrganization, "organizationIdentifier", "my-organization")
+}
+
+func regionConcurrency() *cobra.Command {
+	return sharedConcurrency(sdk.V2RunConcurrencyScopeRegion, "regionIdentifier", "my-region")
+}
+
+// sharedConcurrency returns the commands managing the concurrencies of an organization or a region
+func sharedConcurrency(scope sdk.V2RunJobConcurrencyScope, identifierArg string, exampleIdentifier string) *cobra.Command {
+	prefix := sdk.SharedConcurrencyOrganizationPrefix
+	if scope == sdk.V2RunConcurrencyScopeRegion {
+		prefix = sdk.SharedConcurrencyRegionPrefix
+	}
+
+	concurrencyCmd := cli.Command{
+		Name:    "concurrency",
+		Aliases: []string{"concurrencies", "lock", "locks"},
+		Short:   fmt.Sprintf("Manage locks shared by all projects of an %s, used in workflows with 'concurrency: %s<name>'", scope, prefix),
+	}
+
+	listCmd := cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Short:   fmt.Sprintf("List all concurrency rules of the given %s", scope),
+		Args: []cli.Arg{
+			{Name: identifierArg},
+		},
+	}
+	listFunc := func(v cli.Values) (cli.ListResult, error) {
+		scs, err := client.SharedConcurrencyList(context.Background(), scope, v.GetString(identifierArg))
+		return cli.AsListResult(scs), err
+	}
+
+	showCmd := cli.Command{
+		Name:    "show",
+		Aliases: []string{"get"},
+		Short:   "Get the given concurrency rule",
+		Args: []cli.Arg{
+			{Name: identifierArg},
+			{Name: "name"},
+		},
+	}
+	showFunc := func(v cli.Values) (interface{}, error) {
+		return client.SharedConcurrencyGet(context.Background(), scope, v.GetString(identifierArg), v.GetString("name"))
+	}
+
+	runsCmd := cli.Command{
+		Name:    "run",
+		Aliases: []string{"runs"},
+		Short:   "List the workflow runs / jobs holding and waiting for the concurrency",
+		Args: []cli.Arg{
+			{Name: identifierArg},
+			{Name: "name"},
+		},
+	}
+	runsFunc := func(v cli.Values) (cli.ListResult, error) {
+		runObjects, err := client.SharedConcurrencyListRuns(context.Background(), scope, v.GetString(identifierArg), v.GetString("name"))
+		return cli.AsListResult(runObjects), err
+	}
+
+	createCmd := cli.Command{
+		Name:    "add",
+		Aliases: []string{"create"},
+		Short:   fmt.Sprintf("Create a new concurrency on the given %s", scope),
+		Example: fmt.Sprintf("cdsctl X %s concurrency add %s db-cluster-1 \"Migrations on db cluster 1\" --pool 1 --timeout 30m", scope, exampleIdentifier),
+		Args: []cli.Arg{
+			{Name: identifierArg},
+			{Name: "name"},
+			{Name: "description"},
+		},
+		Flags: []cli.Flag{
+			{Name: "pool", Type: cli.FlagString, Default: "1"},
+			{Name: "order", Type: cli.FlagString, Default: string(sdk.ConcurrencyOrderOldestFirst)},
+			{Name: "cancel-in-progress", Type: cli.FlagBool, Default: "false"},
+			{Name: "if", Type: cli.FlagString},
+			{Name: "timeout", Type: cli.FlagString, Usage: "Maximum waiting duration to acquire the lock (ex: 30m)"},
+		},
+	}
+	createFunc := func(v cli.Values) error {
+		sc := sdk.SharedConcurrency{
+			Name:             v.GetString("name"),
+			Description:      v.GetString("description"),
+			Order:            sdk.ConcurrencyOrder(v.GetString("order")),
+			CancelInProgress: v.GetBool("cancel-in-progress"),
+			If:               v.GetString("if"),
+			Timeout:          v.GetString("timeout"),
+		}
+		if v.GetString("pool") != "" {
+			pool, err := strconv.ParseInt(v.GetString("pool"), 10, 64)
+			if err != nil {
+				return err
+			}
+			sc.Pool = pool
+		}
+		return client.SharedConcurrencyCreate(context.Background(), scope, v.GetString(identifierArg), &sc)
+	}
+
+	updateCmd := cli.Command{
+		Name:    "update",
+		Aliases: []string{"up"},
+		Short:   fmt.Sprintf("Update the given concurrency of the given %s", scope),
+		Example: fmt.Sprintf("cdsctl X %s concurrency update %s db-cluster-1 --pool 2 --timeout 1h", scope, exampleIdentifier),
+		Args: []cli.Arg{
+			{Name: identifierArg},
+			{Name: "name"},
+		},
+		Flags: []cli.Flag{
+			{Name: "description", Type: cli.FlagString},
+			{Name: "pool", Type: cli.FlagString},
+			{Name: "order", Type: cli.FlagString},
+			{Name: "if", Type: cli.FlagString},
+			{Name: "timeout", Type: cli.FlagString, Usage: "Maximum waiting duration to acquire the lock (ex: 30m)"},
+		},
+	}
+	updateFunc := func(v cli.Values) error {
+		sc, err := client.SharedConcurrencyGet(context.Background(), scope, v.GetString(identifierArg), v.GetString("name"))
+		if err != nil {
+			return err
+		}
+		if v.GetString("description") != "" {
+			sc.Description = v.GetString("description")
+		}
+		if v.GetString("order") != "" {
+			sc.Order = sdk.ConcurrencyOrder(v.GetString("order"))
+		}
+		if v.GetString("if") != "" {
+			sc.If = v.GetString("if")
+		}
+		if v.GetString("timeout") != "" {
+			sc.Timeout = v.GetString("timeout")
+		}
+		if v.GetString("pool") != "" {
+			pool, err := strconv.ParseInt(v.GetString("pool"), 10, 64)
+			if err != nil {
+				return err
+			}
+			sc.Pool = pool
+		}
+		return client.SharedConcurrencyUpdate(context.Background(), scope, v.GetString(identifierArg), sc)
+	}
+
+	deleteCmd := cli.Command{
+		Name:    "delete",
+		Aliases: []string{"rm", "remove"},
+		Short:   fmt.Sprintf("Delete a concurrency of the given %s", scope),
+		Args: []cli.Arg{
+			{Name: identifierArg},
+			{Name: "name"},
+		},
+	}
+	deleteFunc := func(v cli.Values) error {
+		return client.SharedConcurrencyDelete(context.Background(), scope, v.GetString(identifierArg), v.GetString("name"))
+	}
+
+	releaseCmd := cli.Command{
+		Name:  "release",
+		Short: "Force release the concurrency: current holders keep running but the next waiting runs are unlocked",
+		Args: []cli.Arg{
+			{Name: identifierArg},
+			{Name: "name"},
+		},
+	}
+	releaseFunc := func(v cli.Values) (cli.ListResult, error) {
+		runObjects, err := client.SharedConcurrencyRelease(context.Background(), scope, v.GetString(identifierArg), v.GetString("name"))
+		return cli.AsListResult(runObjects), err
+	}
+
+	return cli.NewCommand(concurrencyCmd, nil, []*cobra.Command{
+		cli.NewListCommand(listCmd, listFunc, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(showCmd, showFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(runsCmd, runsFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(createCmd, createFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(updateCmd, updateFunc, nil, withAllCommandModifiers()...),
+		cli.NewDeleteCommand(deleteCmd, deleteFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(releaseCmd, releaseFunc, nil, withAllCommandModifiers()...),
+	})
+}
@@ -20,6 +20,7 @@ var experimentalWorkflowTemplateCmd = cli.Command{
 func experimentalWorkflowTemplate() *cobra.Command {
 	return cli.NewCommand(experimentalWorkflowTemplateCmd, nil, []*cobra.Command{
 		cli.NewGetCommand(templateGenerateWorkflowCmd, templateGenerateWorkflowFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(templateV2InstancesCmd, templateV2InstancesFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -74,3 +75,20 @@ func templateGenerateWorkflowFunc(v cli.Values) (interface{}, error) {
 
 	return resp, nil
 }
+
+var templateV2InstancesCmd = cli.Command{
+	Name:    "instances",
+	Short:   "List the workflows generated from templates in the given project",
+	Example: "cdsctl experimental template instances MY-PROJECT --template MY-PROJECT/github/my-org/my-repo/my-template",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Flags: []cli.Flag{
+		{Name: "template", Type: cli.FlagString, Usage: "Filter on a template: <project>/<vcs>/<repository>/<template>"},
+	},
+}
+
+func templateV2InstancesFunc(v cli.Values) (cli.ListResult, error) {
+	instances, err := client.TemplateV2InstanceList(context.Background(), v.GetString(_ProjectKey), v.GetString("template"))
+	return cli.AsListResult(instances), err
+}
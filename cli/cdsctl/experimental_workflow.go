@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/cli/cdsctl/internal/lint"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/cdsclient"
 )
@@ -31,62 +32,178 @@ func experimentalWorkflow() *cobra.Command {
 		cli.NewGetCommand(workflowRunStatusCmd, workflowRunStatusFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowRunStopCmd, workflowRunStopFunc, nil, withAllCommandModifiers()...),
 		cli.NewCommand(workflowLintCmd, workflowLintFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowExecCmd, workflowExecFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowRunSearchCmd, workflowRunSearchFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowScheduleCmd, workflowScheduleFunc, nil, withAllCommandModifiers()...),
 		experimentalWorkflowRunLogs(),
 		experimentalWorkflowJob(),
 		experimentalWorkflowResult(),
 		experimentalWorkflowVersion(),
+		experimentalWorkflowTests(),
+		experimentalWorkflowCoverage(),
 	})
 }
 
 var workflowLintCmd = cli.Command{
-	Name:    "lint",
-	Short:   "Lint workflow files",
-	Example: "cdsctl experimental workflow lint .cds/workflows",
-	Ctx:     []cli.Arg{},
+	Name:  "lint",
+	Short: "Lint workflow files",
+	Long: `Lint workflow files with the CDS API.
+
+With --offline, all the entities of the repository (actions, worker models, workflow templates and workflows) are checked without any call to the API.
+References to actions, worker models, regions and variable sets that are not defined in the repository are checked against a cached index of the entities available on CDS, refreshed with --refresh-index.
+Use --format sarif to get a report that can be uploaded to code scanning tools.`,
+	Example: `cdsctl experimental workflow lint .cds/workflows
+cdsctl experimental workflow lint --offline .
+cdsctl experimental workflow lint --offline --refresh-index --project MYPROJ --format sarif . > cds.sarif`,
+	Ctx: []cli.Arg{},
 	Args: []cli.Arg{
 		{Name: "cds_workflow_directory"},
 	},
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagBool,
+			Name:  "offline",
+			Usage: "Lint all the entities of the repository without calling the API",
+		},
+		{
+			Type:    cli.FlagString,
+			Name:    "format",
+			Usage:   "Output format: text or sarif",
+			Default: "text",
+		},
+		{
+			Type:  cli.FlagString,
+			Name:  "index",
+			Usage: "Path of the cached index of remote entities used by the offline mode",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "refresh-index",
+			Usage: "Refresh the cached index of remote entities from the API, then lint offline",
+		},
+		{
+			Type:  cli.FlagString,
+			Name:  "project",
+			Usage: "Project key used to index variable sets when refreshing the index",
+		},
+	},
 }
 
 func workflowLintFunc(v cli.Values) error {
-	files, err := os.ReadDir(v.GetString("cds_workflow_directory"))
+	format := v.GetString("format")
+	if format != "text" && format != "sarif" {
+		return cli.NewError("invalid format %q: expected text or sarif", format)
+	}
+
+	var results []lint.Result
+	var err error
+	if v.GetBool("offline") || v.GetBool("refresh-index") {
+		results, err = workflowLintOffline(v)
+	} else {
+		results, err = workflowLintOnline(v)
+	}
 	if err != nil {
 		return err
 	}
 
-	hasErrors := false
+	if format == "sarif" {
+		if err := lint.WriteSARIF(os.Stdout, results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			fmt.Println(r.String())
+		}
+		if len(results) == 0 {
+			fmt.Println("No problem found")
+		}
+	}
+	if lint.HasErrors(results) {
+		cli.OSExit(1)
+	}
+	return nil
+}
+
+func workflowLintOnline(v cli.Values) ([]lint.Result, error) {
+	dir := strings.TrimSuffix(v.GetString("cds_workflow_directory"), "/")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []lint.Result
 	for _, f := range files {
-		bts, err := os.ReadFile(fmt.Sprintf("%s/%s", strings.TrimSuffix(v.GetString("cds_workflow_directory"), "/"), f.Name()))
+		if f.IsDir() {
+			continue
+		}
+		path := fmt.Sprintf("%s/%s", dir, f.Name())
+		bts, err := os.ReadFile(path)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		var wf sdk.V2Workflow
 		if err := yaml.Unmarshal(bts, &wf); err != nil {
-			fmt.Printf("File %s: unable to unmarshal yaml: %v\n", f.Name(), err)
-			hasErrors = true
+			results = append(results, lint.Result{Rule: lint.RuleParse, Level: lint.LevelError, File: path, Message: fmt.Sprintf("unable to unmarshal yaml: %v", err)})
 			continue
 		}
 		resp, err := client.EntityLint(context.Background(), sdk.EntityTypeWorkflow, wf)
 		if err != nil {
-			fmt.Printf("File %s: unable to check file: %v\n", f.Name(), err)
-			hasErrors = true
-			continue
-		}
-		if len(resp.Messages) == 0 {
-			fmt.Printf("File %s: workflow OK\n", f.Name())
+			results = append(results, lint.Result{Rule: lint.RuleAPI, Level: lint.LevelError, File: path, Message: fmt.Sprintf("unable to check file: %v", err)})
 			continue
 		}
-		fmt.Printf("File %s: %d errors found\n", f.Name(), len(resp.Messages))
-		for _, e := range resp.Messages {
-			fmt.Printf("    %s\n", e)
+		for _, m := range resp.Messages {
+			results = append(results, lint.Result{Rule: lint.RuleAPI, Level: lint.LevelError, File: path, Message: m})
 		}
-		hasErrors = true
 	}
-	if hasErrors {
-		cli.OSExit(1)
+	return results, nil
+}
+
+func workflowLintOffline(v cli.Values) ([]lint.Result, error) {
+	ctx := context.Background()
+	root, err := lint.RepositoryRoot(v.GetString("cds_workflow_directory"))
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	indexPath := v.GetString("index")
+	if indexPath == "" {
+		indexPath = lint.DefaultIndexPath()
+	}
+	var index *lint.Index
+	if v.GetBool("refresh-index") {
+		if client == nil {
+			return nil, cli.NewError("unable to refresh the index: cdsctl is not configured")
+		}
+		schema, err := client.UserGetSchemaV2(ctx, sdk.EntityTypeWorkflow)
+		if err != nil {
+			return nil, err
+		}
+		index, err = lint.NewIndexFromSchema(schema)
+		if err != nil {
+			return nil, err
+		}
+		if projectKey := v.GetString("project"); projectKey != "" {
+			index.ProjectKey = projectKey
+			varsets, err := client.ProjectVariableSetList(ctx, projectKey)
+			if err != nil {
+				return nil, err
+			}
+			for _, vs := range varsets {
+				index.VariableSets = append(index.VariableSets, vs.Name)
+			}
+		}
+		if err := index.Save(indexPath); err != nil {
+			return nil, err
+		}
+	} else {
+		index, err = lint.LoadIndex(indexPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l := lint.Linter{Dir: root, Index: index}
+	return l.Lint(ctx)
 }
 
 var workflowRunInfosListCmd = cli.Command{
@@ -437,6 +554,11 @@ var workflowRunCmd = cli.Command{
 		{
 			Name: "inputs-file",
 		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "follow",
+			Usage: "Display job status transitions and logs until the end of the run, exit with code 1 if the run failed and 2 if it was stopped or cancelled",
+		},
 	},
 }
 
@@ -508,12 +630,19 @@ func workflowRunFunc(v cli.Values) (interface{}, error) {
 		}
 		if event.Status == sdk.HookEventStatusDone {
 			if len(event.WorkflowHooks) == 1 {
-				return run{
+				r := run{
 					Workflow:  wkfName,
 					RunNumber: event.WorkflowHooks[0].RunNumber,
 					RunID:     event.WorkflowHooks[0].RunID,
 					UIUrl:     fmt.Sprintf("%s/project/%s/run/%s", runResp.UIUrl, projKey, event.WorkflowHooks[0].RunID),
-				}, nil
+				}
+				if v.GetBool("follow") {
+					fmt.Printf("Workflow %s #%d started: %s\n", wkfName, r.RunNumber, r.UIUrl)
+					if err := workflowRunFollow(projKey, r.RunID); err != nil {
+						return nil, err
+					}
+				}
+				return r, nil
 			}
 			return nil, fmt.Errorf("workflow did not start")
 		}
@@ -530,11 +659,29 @@ func workflowRunFunc(v cli.Values) (interface{}, error) {
 	}
 }
 
+func workflowRunFollow(projKey, runID string) error {
+	follower, err := newWorkflowRunFollower(projKey, runID, os.Stdout)
+	if err != nil {
+		return err
+	}
+	follower.withStatus = true
+	wr, err := follower.Follow(context.Background())
+	if err != nil {
+		return err
+	}
+	return workflowRunResultError(wr)
+}
+
 var workflowRestartCmd = cli.Command{
-	Name:    "restart",
-	Short:   "Restart workflow failed jobs",
-	Example: "cdsctl workflow restart <proj_key> <workflow_run_id>",
-	Ctx:     []cli.Arg{},
+	Name:  "restart",
+	Short: "Restart workflow failed jobs",
+	Long: `Restart workflow failed jobs.
+
+With --debug, the restarted jobs pause when they fail instead of releasing their worker,
+use "cdsctl experimental workflow jobs attach" to open a shell on the worker and resume the job.`,
+	Example: `cdsctl workflow restart <proj_key> <workflow_run_id>
+cdsctl workflow restart --debug <proj_key> <workflow_run_id>`,
+	Ctx: []cli.Arg{},
 	Args: []cli.Arg{
 		{Name: "proj_key"},
 		{Name: "workflow_run_id"},
@@ -546,6 +693,11 @@ var workflowRestartCmd = cli.Command{
 		{
 			Name: "inputs-file",
 		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "debug",
+			Usage: "Restart the jobs in debug mode",
+		},
 	},
 }
 
@@ -553,13 +705,17 @@ func workflowRestartFunc(v cli.Values) error {
 	projKey := v.GetString("proj_key")
 	workflowRunID := v.GetString("workflow_run_id")
 	if v.GetString("inputs") == "" && v.GetString("inputs-file") == "" {
-		run, err := client.WorkflowV2Restart(context.Background(), projKey, workflowRunID)
+		var mods []cdsclient.RequestModifier
+		if v.GetBool("debug") {
+			mods = append(mods, cdsclient.WithQueryParameter("debug", "true"))
+		}
+		run, err := client.WorkflowV2Restart(context.Background(), projKey, workflowRunID, mods...)
 		if err != nil {
 			return err
 		}
 		fmt.Printf("Worflow %s #%d.%d restarted", run.WorkflowName, run.RunNumber, run.RunAttempt)
 	} else {
-		payload := sdk.V2WorkflowRunTriggerJobsRequest{}
+		payload := sdk.V2WorkflowRunTriggerJobsRequest{Debug: v.GetBool("debug")}
 		if v.GetString("inputs") != "" {
 			var inputs sdk.V2WorkflowRunJobInputs
 			if err := json.Unmarshal([]byte(v.GetString("inputs")), &inputs); err != nil {
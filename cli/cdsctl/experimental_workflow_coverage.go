@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var experimentalWorkflowCoverageCmd = cli.Command{
+	Name:  "coverage",
+	Short: "CDS Experimental workflow coverage commands",
+	Long: `Coverage reports uploaded as run results (Cobertura, LCOV, JaCoCo and Go cover profiles) are merged at the end of each run.
+
+On pull requests, the diff coverage is the coverage of the lines changed by the pull request.`,
+}
+
+func experimentalWorkflowCoverage() *cobra.Command {
+	return cli.NewCommand(experimentalWorkflowCoverageCmd, nil, []*cobra.Command{
+		cli.NewGetCommand(workflowRunCoverageShowCmd, workflowRunCoverageShowFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowCoverageTrendCmd, workflowCoverageTrendFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowRunCoverageShowCmd = cli.Command{
+	Name:    "show",
+	Short:   "Show the coverage of a workflow run",
+	Example: "cdsctl experimental workflow coverage show <proj_key> <workflow_run_id>",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+	},
+	Flags: []cli.Flag{
+		{Name: "attempt", Usage: "Run attempt, the last one by default"},
+	},
+	Mcp: true,
+}
+
+func workflowRunCoverageShowFunc(v cli.Values) (interface{}, error) {
+	var mods []cdsclient.RequestModifier
+	if attempt := v.GetString("attempt"); attempt != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("attempt", attempt))
+	}
+	return client.WorkflowV2RunCoverage(context.Background(), v.GetString("proj_key"), v.GetString("workflow_run_id"), mods...)
+}
+
+var workflowCoverageTrendCmd = cli.Command{
+	Name:    "trend",
+	Short:   "List the last coverages of a workflow on a branch",
+	Example: "cdsctl experimental workflow coverage trend <proj_key> <vcs_identifier> <repository_identifier> <workflow_name> refs/heads/master --limit 10",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "vcs_identifier"},
+		{Name: "repository_identifier"},
+		{Name: "workflow_name"},
+		{Name: "ref"},
+	},
+	Flags: []cli.Flag{
+		{Name: "limit", Usage: "Maximum number of runs", Default: "20"},
+	},
+	Mcp: true,
+}
+
+func workflowCoverageTrendFunc(v cli.Values) (cli.ListResult, error) {
+	trend, err := client.WorkflowV2CoverageTrend(context.Background(), v.GetString("proj_key"), v.GetString("vcs_identifier"), v.GetString("repository_identifier"), v.GetString("workflow_name"),
+		v.GetString("ref"), cdsclient.WithQueryParameter("limit", v.GetString("limit")))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(trend), nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	repo "github.com/fsamin/go-repo"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/engine/worker/pkg/localrunner"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowExecCmd = cli.Command{
+	Name:  "exec",
+	Short: "Run a workflow on the local host",
+	Long: `Run the jobs of a workflow file on the local host, without pushing it to CDS.
+
+The command must be run from the root directory of the repository: actions, job templates and worker models are read from its .cds directory. Only the script and checkout plugins are available: the checkout step uses the local workspace.
+By default script steps are run as local processes. Use --container to run them in a docker container using the image of the job worker model.`,
+	Example: `cdsctl experimental workflow exec .cds/workflows/build.yml
+cdsctl experimental workflow exec .cds/workflows/build.yml --job tests --input version=1.0.0 --secret my-varset.token=xxx
+cdsctl experimental workflow exec .cds/workflows/build.yml --container`,
+	Ctx: []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "workflow_file"},
+	},
+	Flags: []cli.Flag{
+		{
+			Type:      cli.FlagArray,
+			Name:      "job",
+			ShortHand: "j",
+			Usage:     "Run only the given job. Can be repeated",
+		},
+		{
+			Type:      cli.FlagArray,
+			Name:      "input",
+			ShortHand: "i",
+			Usage:     "Set a workflow input like --input key=value",
+		},
+		{
+			Type:      cli.FlagArray,
+			Name:      "secret",
+			ShortHand: "s",
+			Usage:     "Set a variable set item like --secret my-varset.my-item=value. Values are hidden in logs",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "container",
+			Usage: "Run script steps in a docker container using the image of the job worker model",
+		},
+		{
+			Type:  cli.FlagString,
+			Name:  "image",
+			Usage: "Docker image used to run script steps, overriding the worker models",
+		},
+		{
+			Type:  cli.FlagString,
+			Name:  "project",
+			Usage: "Project key set in the cds context",
+		},
+	},
+}
+
+func workflowExecFunc(v cli.Values) error {
+	ctx := context.Background()
+
+	// Worker logs are only useful to debug the local runner
+	if !cli.Verbose {
+		logrus.SetOutput(io.Discard)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return cli.WrapError(err, "unable to get current directory")
+	}
+
+	opts := localrunner.Options{
+		Directory:  dir,
+		Jobs:       v.GetStringArray("job"),
+		Inputs:     make(map[string]interface{}),
+		Secrets:    make(map[string]string),
+		Container:  v.GetBool("container") || v.GetString("image") != "",
+		Image:      v.GetString("image"),
+		ProjectKey: v.GetString("project"),
+		Output:     os.Stdout,
+	}
+	for _, i := range v.GetStringArray("input") {
+		key, value, found := strings.Cut(i, "=")
+		if !found {
+			return cli.NewError("invalid input %q: expected key=value", i)
+		}
+		opts.Inputs[key] = value
+	}
+	for _, s := range v.GetStringArray("secret") {
+		key, value, found := strings.Cut(s, "=")
+		if !found {
+			return cli.NewError("invalid secret %q: expected my-varset.my-item=value", s)
+		}
+		opts.Secrets[key] = value
+	}
+
+	// Fill the git context from the current repository if any
+	if r, err := repo.New(ctx, dir); err == nil {
+		if branch, err := r.CurrentBranch(ctx); err == nil {
+			opts.Git.Ref = sdk.GitRefBranchPrefix + branch
+			opts.Git.RefName = branch
+			opts.Git.RefType = sdk.GitRefTypeBranch
+		}
+		if c, err := r.LatestCommit(ctx, repo.CommitOption{}); err == nil {
+			opts.Git.Sha = c.LongHash
+			opts.Git.ShaShort = c.Hash
+			opts.Git.Author = c.Author
+			opts.Git.AuthorEmail = c.AuthorEmail
+			opts.Git.CommitMessage = c.Subject
+		}
+		if fetchURL, err := r.FetchURL(ctx); err == nil {
+			opts.Git.RepositoryURL = fetchURL
+		}
+		if name, err := r.Name(ctx); err == nil {
+			opts.Git.Repository = name
+		}
+	}
+
+	wf, err := localrunner.ReadWorkflow(ctx, opts.Directory, v.GetString("workflow_file"))
+	if err != nil {
+		return cli.WrapError(err, "unable to read workflow")
+	}
+
+	results, err := localrunner.Run(ctx, *wf, opts)
+	if err != nil {
+		return cli.WrapError(err, "unable to run workflow %s", wf.Name)
+	}
+
+	fmt.Println()
+	failed := 0
+	for _, res := range results {
+		name := res.JobID
+		if res.Matrix != "" {
+			name += "(" + res.Matrix + ")"
+		}
+		fmt.Printf("%s: %s\n", name, res.Status)
+		if res.Status == sdk.V2WorkflowRunJobStatusFail || res.Status == sdk.V2WorkflowRunJobStatusStopped {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return cli.NewError("%d job(s) failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+// Delay to wait for the last log lines of a job once it is terminated
+const workflowRunFollowerGracePeriod = 2 * time.Second
+
+// workflowRunFollower prints the status transitions and the step logs of the jobs of a workflow run until it ends.
+type workflowRunFollower struct {
+	projKey    string
+	runID      string
+	job        string
+	step       string
+	withStatus bool
+	out        io.Writer
+	cdnURL     string
+
+	wg          sync.WaitGroup
+	mutex       sync.Mutex
+	jobs        map[string]*followedJob
+	currentStep string
+}
+
+type followedJob struct {
+	runJob    sdk.V2WorkflowRunJob
+	streaming bool
+	finished  bool
+	stop      context.CancelFunc
+	stopped   chan struct{}
+
+	// Step names indexed by log api ref hash
+	steps map[string]string
+	// Next line number to print indexed by log api ref hash
+	nextLines map[string]int64
+}
+
+type workflowRunLogLine struct {
+	Number     int64  `json:"number"`
+	Value      string `json:"value"`
+	ApiRefHash string `json:"api_ref_hash"`
+}
+
+func newWorkflowRunFollower(projKey, runID string, out io.Writer) (*workflowRunFollower, error) {
+	cdnURL, err := client.CDNURL()
+	if err != nil {
+		return nil, err
+	}
+	return &workflowRunFollower{
+		projKey: projKey,
+		runID:   runID,
+		out:     out,
+		cdnURL:  cdnURL,
+		jobs:    make(map[string]*followedJob),
+	}, nil
+}
+
+// Follow returns the workflow run once it is terminated.
+func (f *workflowRunFollower) Follow(ctx context.Context) (*sdk.V2WorkflowRun, error) {
+	for {
+		run, err := client.WorkflowV2RunStatus(ctx, f.projKey, f.runID)
+		if err != nil {
+			return nil, err
+		}
+		runJobs, err := client.WorkflowV2RunJobs(ctx, f.projKey, f.runID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rj := range runJobs {
+			if f.job != "" && rj.JobID != f.job {
+				continue
+			}
+			f.handleRunJob(ctx, rj)
+		}
+
+		if run.Status.IsTerminated() {
+			f.wg.Wait()
+			return run, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func (f *workflowRunFollower) handleRunJob(ctx context.Context, rj sdk.V2WorkflowRunJob) {
+	fj, has := f.jobs[rj.ID]
+	if !has {
+		fj = &followedJob{
+			steps:     make(map[string]string),
+			nextLines: make(map[string]int64),
+		}
+		f.jobs[rj.ID] = fj
+	}
+	if !has || fj.runJob.Status != rj.Status {
+		f.printStatus(rj)
+	}
+	f.mutex.Lock()
+	fj.runJob = rj
+	f.mutex.Unlock()
+
+	if fj.finished {
+		return
+	}
+
+	if rj.Status == sdk.V2WorkflowRunJobStatusBuilding && !fj.streaming {
+		fj.streaming = true
+		fj.stopped = make(chan struct{})
+		streamCtx, cancel := context.WithCancel(ctx)
+		fj.stop = cancel
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			defer close(fj.stopped)
+			if err := f.streamJob(streamCtx, fj, rj); err != nil && streamCtx.Err() == nil {
+				f.printf("%s\n", cli.Red("unable to stream logs of job %s: %v", rj.JobID, err))
+			}
+		}()
+	}
+
+	if rj.Status.IsTerminated() {
+		fj.finished = true
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			if fj.streaming {
+				time.Sleep(workflowRunFollowerGracePeriod)
+				fj.stop()
+				<-fj.stopped
+			}
+			// Download the lines that were not streamed
+			if err := f.downloadStepLogs(ctx, fj, rj, func(string) bool { return true }); err != nil {
+				f.printf("%s\n", cli.Red("unable to download logs of job %s: %v", rj.JobID, err))
+			}
+		}()
+	}
+}
+
+func (f *workflowRunFollower) streamJob(ctx context.Context, fj *followedJob, rj sdk.V2WorkflowRunJob) error {
+	// The websocket only sends the lines of the running step and of the next ones,
+	// so the logs of the steps that are already ended are downloaded first
+	if err := f.downloadStepLogs(ctx, fj, rj, func(stepName string) bool {
+		s, has := rj.StepsStatus[stepName]
+		return has && !s.Ended.IsZero()
+	}); err != nil {
+		return err
+	}
+
+	filter, err := json.Marshal(sdk.CDNStreamFilter{JobRunID: rj.ID})
+	if err != nil {
+		return cli.WrapError(err, "unable to marshal streamFilter")
+	}
+	chanMsgToSend := make(chan json.RawMessage, 1)
+	chanMsgReceived := make(chan json.RawMessage)
+	chanErrorReceived := make(chan error)
+
+	goRoutines := sdk.NewGoRoutines(ctx)
+	goRoutines.Exec(ctx, "workflowRunFollower-"+rj.ID, func(ctx context.Context) {
+		for ctx.Err() == nil {
+			// The filter is sent again for each new connection
+			select {
+			case chanMsgToSend <- filter:
+			default:
+			}
+			if err := client.RequestWebsocket(ctx, goRoutines, fmt.Sprintf("%s/item/stream", f.cdnURL), chanMsgToSend, chanMsgReceived, chanErrorReceived); err != nil && ctx.Err() == nil {
+				select {
+				case chanErrorReceived <- err:
+				case <-ctx.Done():
+				}
+			}
+			time.Sleep(1 * time.Second)
+		}
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-chanErrorReceived:
+			if cli.Verbose {
+				f.printf("%s\n", cli.Yellow("%v", err))
+			}
+		case m := <-chanMsgReceived:
+			var line workflowRunLogLine
+			if err := json.Unmarshal(m, &line); err != nil {
+				return cli.WrapError(err, "unable to read log line")
+			}
+			f.printLine(ctx, fj, line)
+		}
+	}
+}
+
+// refreshSteps loads the log links of a job to know which step a log line belongs to.
+func (f *workflowRunFollower) refreshSteps(ctx context.Context, fj *followedJob, rj sdk.V2WorkflowRunJob) ([]sdk.CDNLogLink, []string, error) {
+	links, err := client.WorkflowV2RunJobLogLinks(ctx, f.projKey, f.runID, rj.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	stepNames := workflowRunJobLogStepNames(rj)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i, l := range links.Data {
+		if i < len(stepNames) {
+			fj.steps[l.APIRef] = stepNames[i]
+		}
+	}
+	return links.Data, stepNames, nil
+}
+
+func (f *workflowRunFollower) downloadStepLogs(ctx context.Context, fj *followedJob, rj sdk.V2WorkflowRunJob, selectStep func(string) bool) error {
+	links, stepNames, err := f.refreshSteps(ctx, fj, rj)
+	if err != nil {
+		return err
+	}
+	for i, link := range links {
+		if i >= len(stepNames) {
+			break
+		}
+		stepName := stepNames[i]
+		if !f.matchStep(stepName) || !selectStep(stepName) {
+			continue
+		}
+		data, err := client.WorkflowLogDownload(ctx, link)
+		if err != nil {
+			if strings.Contains(err.Error(), "resource not found") {
+				continue
+			}
+			return err
+		}
+		lines := strings.SplitAfter(string(data), "\n")
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		f.mutex.Lock()
+		next := fj.nextLines[link.APIRef]
+		if int64(len(lines)) > next {
+			fj.nextLines[link.APIRef] = int64(len(lines))
+			f.printStepHeader(rj, stepName, link.APIRef)
+			for _, l := range lines[next:] {
+				fmt.Fprintln(f.out, strings.TrimSuffix(l, "\n"))
+			}
+		}
+		f.mutex.Unlock()
+	}
+	return nil
+}
+
+func (f *workflowRunFollower) printLine(ctx context.Context, fj *followedJob, line workflowRunLogLine) {
+	f.mutex.Lock()
+	stepName, has := fj.steps[line.ApiRefHash]
+	rj := fj.runJob
+	f.mutex.Unlock()
+
+	// A line of a step that has just started
+	if !has {
+		if rj, err := client.WorkflowV2RunJob(ctx, f.projKey, f.runID, rj.ID); err == nil {
+			_, _, _ = f.refreshSteps(ctx, fj, *rj)
+		}
+		f.mutex.Lock()
+		stepName, has = fj.steps[line.ApiRefHash]
+		f.mutex.Unlock()
+		if !has {
+			stepName = line.ApiRefHash
+		}
+	}
+	if !f.matchStep(stepName) {
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if line.Number < fj.nextLines[line.ApiRefHash] {
+		return
+	}
+	fj.nextLines[line.ApiRefHash] = line.Number + 1
+	f.printStepHeader(rj, stepName, line.ApiRefHash)
+	fmt.Fprintln(f.out, strings.TrimSuffix(line.Value, "\n"))
+}
+
+// printStepHeader must be called with the lock held.
+func (f *workflowRunFollower) printStepHeader(rj sdk.V2WorkflowRunJob, stepName, apiRefHash string) {
+	if f.currentStep == apiRefHash {
+		return
+	}
+	f.currentStep = apiRefHash
+	fmt.Fprintf(f.out, "%s %s %s\n", cli.Arrow, cli.Cyan("%s", workflowRunJobName(rj)), cli.Magenta("%s", stepName))
+}
+
+func (f *workflowRunFollower) printStatus(rj sdk.V2WorkflowRunJob) {
+	if !f.withStatus {
+		return
+	}
+	var status string
+	switch rj.Status {
+	case sdk.V2WorkflowRunJobStatusSuccess:
+		status = cli.OKChar + " " + cli.Green("%s", rj.Status)
+	case sdk.V2WorkflowRunJobStatusFail, sdk.V2WorkflowRunJobStatusStopped, sdk.V2WorkflowRunJobStatusCancelled:
+		status = cli.KOChar + " " + cli.Red("%s", rj.Status)
+	case sdk.V2WorkflowRunJobStatusBuilding:
+		status = cli.BuildingChar + " " + cli.Blue("%s", rj.Status)
+	default:
+		status = cli.Yellow("%s", rj.Status)
+	}
+	f.printf("[%s] %s %s\n", time.Now().Format("15:04:05"), cli.Cyan("%s", workflowRunJobName(rj)), status)
+	// Next log line needs a new header
+	f.mutex.Lock()
+	f.currentStep = ""
+	f.mutex.Unlock()
+}
+
+func (f *workflowRunFollower) printf(format string, args ...interface{}) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	fmt.Fprintf(f.out, format, args...)
+}
+
+func (f *workflowRunFollower) matchStep(stepName string) bool {
+	return f.step == "" || stepName == f.step || stepName == "Post-"+f.step
+}
+
+// workflowRunJobLogStepNames returns the step names in the order of the log links of a job.
+func workflowRunJobLogStepNames(rj sdk.V2WorkflowRunJob) []string {
+	steps := make([]string, 0)
+	for i, s := range rj.Job.Steps {
+		stepName := sdk.GetJobStepName(s.ID, i)
+		if _, ok := rj.StepsStatus[stepName]; ok {
+			steps = append(steps, stepName)
+		}
+	}
+	for i := len(rj.Job.Steps) - 1; i >= 0; i-- {
+		stepName := sdk.GetJobStepName(rj.Job.Steps[i].ID, i)
+		if _, ok := rj.StepsStatus["Post-"+stepName]; ok {
+			steps = append(steps, "Post-"+stepName)
+		}
+	}
+	return steps
+}
+
+func workflowRunJobName(rj sdk.V2WorkflowRunJob) string {
+	if len(rj.Matrix) == 0 {
+		return rj.JobID
+	}
+	values := make([]string, 0, len(rj.Matrix))
+	for k, v := range rj.Matrix {
+		values = append(values, fmt.Sprintf("%s:%s", k, v))
+	}
+	sort.Strings(values)
+	return fmt.Sprintf("%s (%s)", rj.JobID, strings.Join(values, ", "))
+}
+
+// workflowRunResultError returns an error with an exit code that reflects the status of a terminated workflow run.
+func workflowRunResultError(run *sdk.V2WorkflowRun) error {
+	switch run.Status {
+	case sdk.V2WorkflowRunStatusSuccess, sdk.V2WorkflowRunStatusSkipped:
+		return nil
+	case sdk.V2WorkflowRunStatusFail:
+		return &cli.Error{Code: 1, Err: fmt.Errorf("workflow %s #%d.%d ended with status %s", run.WorkflowName, run.RunNumber, run.RunAttempt, run.Status)}
+	default:
+		return &cli.Error{Code: 2, Err: fmt.Errorf("workflow %s #%d.%d ended with status %s", run.WorkflowName, run.RunNumber, run.RunAttempt, run.Status)}
+	}
+}
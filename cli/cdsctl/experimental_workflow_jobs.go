@@ -25,6 +25,7 @@ func experimentalWorkflowJob() *cobra.Command {
 		cli.NewCommand(workflowRunStartJobCmd, workflowRunStartJobFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowRunJobInfoCmd, workflowRunJobInfoFunc, nil, withAllCommandModifiers()...),
 		cli.NewListCommand(workflowRunJobListRetriesCmd, workflowRunJobListRetriesFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRunJobAttachCmd, workflowRunJobAttachFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
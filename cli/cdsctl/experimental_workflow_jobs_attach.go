@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowRunJobAttachCmd = cli.Command{
+	Name:  "attach",
+	Short: "Open a shell on the worker of a job paused in debug mode",
+	Long: `Open a shell on the worker of a job paused in debug mode.
+
+The job must have been restarted with "cdsctl experimental workflow restart --debug" and be paused,
+after a failure or on a "worker breakpoint" command. The shell runs in the job workspace with the job environment.
+
+Type "~c" on a new line to resume the job, "~." to detach without resuming it.
+With --continue, the job is resumed without opening a shell.`,
+	Example: `cdsctl experimental workflow jobs attach <proj_key> <workflow_run_id> <run_job_id>
+cdsctl experimental workflow jobs attach --continue <proj_key> <workflow_run_id> <run_job_id>`,
+	Ctx: []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+		{Name: "run_job_id"},
+	},
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagBool,
+			Name:  "continue",
+			Usage: "Resume the job without opening a shell",
+		},
+	},
+}
+
+func workflowRunJobAttachFunc(v cli.Values) error {
+	projKey := v.GetString("proj_key")
+	workflowRunID := v.GetString("workflow_run_id")
+	jobRunID := v.GetString("run_job_id")
+	if !sdk.IsValidUUID(jobRunID) {
+		return fmt.Errorf("run_job_id must be a valid UUID")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chanMsgToSend := make(chan json.RawMessage, 10)
+	chanMsgReceived := make(chan json.RawMessage, 10)
+	chanErrorReceived := make(chan error, 1)
+	send := func(m sdk.V2JobDebugMessage) {
+		btes, _ := json.Marshal(m)
+		select {
+		case chanMsgToSend <- btes:
+		case <-ctx.Done():
+		}
+	}
+
+	goRoutines := sdk.NewGoRoutines(ctx)
+	goRoutines.Exec(ctx, "workflowRunJobAttach-"+jobRunID, func(ctx context.Context) {
+		err := client.WorkflowV2RunJobDebugSession(ctx, goRoutines, projKey, workflowRunID, jobRunID, chanMsgToSend, chanMsgReceived, chanErrorReceived)
+		if err == nil {
+			err = fmt.Errorf("debug session closed")
+		}
+		select {
+		case chanErrorReceived <- err:
+		case <-ctx.Done():
+		}
+	})
+
+	if v.GetBool("continue") {
+		send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeContinue})
+	} else {
+		fmt.Println(cli.Yellow("Attached to job %s, type ~c to resume the job, ~. to detach", jobRunID))
+		goRoutines.Exec(ctx, "workflowRunJobAttach-stdin", func(ctx context.Context) {
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				switch line := scanner.Text(); line {
+				case "~c":
+					send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeContinue})
+				case "~.":
+					cancel()
+					return
+				default:
+					send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeInput, Data: line + "\n"})
+				}
+			}
+			// Detach on end of input
+			cancel()
+		})
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println(cli.Yellow("Detached, the job is still paused"))
+			return nil
+		case err := <-chanErrorReceived:
+			if ctx.Err() != nil {
+				continue
+			}
+			return cli.WrapError(err, "debug session error")
+		case m := <-chanMsgReceived:
+			var msg sdk.V2JobDebugMessage
+			if err := json.Unmarshal(m, &msg); err != nil {
+				return cli.WrapError(err, "unable to read debug session message")
+			}
+			switch msg.Type {
+			case sdk.V2JobDebugMessageTypeOutput:
+				fmt.Print(msg.Data)
+			case sdk.V2JobDebugMessageTypePaused:
+				fmt.Println(cli.Yellow("Job paused: %s", msg.Data))
+			case sdk.V2JobDebugMessageTypeExit:
+				fmt.Println(cli.Yellow("Shell exited, type a command to start a new one or ~c to resume the job"))
+			case sdk.V2JobDebugMessageTypeContinue:
+				if msg.Username != "" {
+					fmt.Println(cli.Green("Job resumed by %s", msg.Username))
+				} else {
+					fmt.Println(cli.Green("Job resumed: %s", msg.Data))
+				}
+				return nil
+			}
+		}
+	}
+}
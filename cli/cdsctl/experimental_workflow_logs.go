@@ -22,6 +22,7 @@ var experimentalWorkflowRunJobsCmd = cli.Command{
 func experimentalWorkflowRunLogs() *cobra.Command {
 	return cli.NewCommand(experimentalWorkflowRunJobsCmd, nil, []*cobra.Command{
 		cli.NewCommand(workflowRunJobLogsDownloadCmd, workflowRunJobLogsDownloadFunc, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowRunJobLogsFollowCmd, workflowRunJobLogsFollowFunc, nil, withAllCommandModifiers()...),
 	})
 }
 
@@ -107,6 +108,52 @@ func workflowRunJobLogsDownloadFunc(v cli.Values) error {
 	return nil
 }
 
+var workflowRunJobLogsFollowCmd = cli.Command{
+	Name:  "follow",
+	Short: "Follow the logs of a workflow run until it ends",
+	Long: `Print the step logs of the jobs of a workflow run while they are running, until the end of the run.
+
+The command exits with code 1 if the workflow run failed and with code 2 if it was stopped or cancelled.`,
+	Example: `cdsctl experimental workflow logs follow <proj_key> <workflow_run_id>
+cdsctl experimental workflow logs follow <proj_key> <workflow_run_id> --job build --step compile`,
+	Ctx: []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "job",
+			Usage: "Only follow the given job",
+		},
+		{
+			Name:  "step",
+			Usage: "Only follow the given step",
+		},
+		{
+			Type:  cli.FlagBool,
+			Name:  "status",
+			Usage: "Also display job status transitions",
+		},
+	},
+}
+
+func workflowRunJobLogsFollowFunc(v cli.Values) error {
+	follower, err := newWorkflowRunFollower(v.GetString("proj_key"), v.GetString("workflow_run_id"), os.Stdout)
+	if err != nil {
+		return err
+	}
+	follower.job = v.GetString("job")
+	follower.step = v.GetString("step")
+	follower.withStatus = v.GetBool("status")
+
+	run, err := follower.Follow(context.Background())
+	if err != nil {
+		return err
+	}
+	return workflowRunResultError(run)
+}
+
 func getFileName(rj sdk.V2WorkflowRunJob, name string) string {
 	return fmt.Sprintf("%s-%d-%d-%s-%s", rj.WorkflowName, rj.RunNumber, rj.RunAttempt, rj.JobID, name)
 }
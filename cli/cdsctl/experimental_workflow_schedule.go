@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var workflowScheduleCmd = cli.Command{
+	Name:    "schedule",
+	Aliases: []string{"schedules"},
+	Short:   "List the next executions of the workflow schedulers",
+	Long:    "List the next executions of the schedulers of a workflow, the blackouts excluded. The jitter is not applied to the displayed times.",
+	Example: "cdsctl experimental workflow schedule <proj_key> <vcs_identifier> <repository_identifier> <workflow_name> --count 10",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "vcs_identifier"},
+		{Name: "repository_identifier"},
+		{Name: "workflow_name"},
+	},
+	Flags: []cli.Flag{
+		{Name: "count", Usage: "Number of executions", Default: "5"},
+	},
+	Mcp: true,
+}
+
+func workflowScheduleFunc(v cli.Values) (cli.ListResult, error) {
+	executions, err := client.WorkflowV2ScheduleList(context.Background(), v.GetString("proj_key"), v.GetString("vcs_identifier"), v.GetString("repository_identifier"), v.GetString("workflow_name"),
+		cdsclient.WithQueryParameter("count", v.GetString("count")))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(executions), nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+var experimentalWorkflowTestsCmd = cli.Command{
+	Name:    "tests",
+	Short:   "CDS Experimental workflow tests commands",
+	Aliases: []string{"test"},
+	Long: `Tests reports uploaded as run results (JUnit, TAP, Go test2json and TRX) are indexed by test case,
+to follow the duration and the status of each test across the runs of a workflow.
+
+A test is flaky when it both passed and failed on the same commit.`,
+}
+
+func experimentalWorkflowTests() *cobra.Command {
+	return cli.NewCommand(experimentalWorkflowTestsCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowRunTestsListCmd, workflowRunTestsListFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestsSlowestCmd, workflowTestsSlowestFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestsFlakyCmd, workflowTestsFlakyFunc, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestsHistoryCmd, workflowTestsHistoryFunc, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowTestsLimitFlag = cli.Flag{Name: "limit", Usage: "Maximum number of tests", Default: "20"}
+
+var workflowRunTestsListCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Short:   "List the tests of a workflow run",
+	Example: "cdsctl experimental workflow tests list <proj_key> <workflow_run_id>",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "workflow_run_id"},
+	},
+	Flags: []cli.Flag{
+		{Name: "attempt", Usage: "Run attempt, the last one by default"},
+	},
+	Mcp: true,
+}
+
+func workflowRunTestsListFunc(v cli.Values) (cli.ListResult, error) {
+	var mods []cdsclient.RequestModifier
+	if attempt := v.GetString("attempt"); attempt != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("attempt", attempt))
+	}
+	testCases, err := client.WorkflowV2RunTestCaseList(context.Background(), v.GetString("proj_key"), v.GetString("workflow_run_id"), mods...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(testCases), nil
+}
+
+var workflowTestsSlowestCmd = cli.Command{
+	Name:    "slowest",
+	Short:   "List the slowest tests of a workflow",
+	Example: "cdsctl experimental workflow tests slowest <proj_key> <vcs_identifier> <repository_identifier> <workflow_name> --limit 10",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "vcs_identifier"},
+		{Name: "repository_identifier"},
+		{Name: "workflow_name"},
+	},
+	Flags: []cli.Flag{workflowTestsLimitFlag},
+	Mcp:   true,
+}
+
+func workflowTestsSlowestFunc(v cli.Values) (cli.ListResult, error) {
+	stats, err := client.WorkflowV2SlowestTestCases(context.Background(), v.GetString("proj_key"), v.GetString("vcs_identifier"), v.GetString("repository_identifier"), v.GetString("workflow_name"),
+		cdsclient.WithQueryParameter("limit", v.GetString("limit")))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(stats), nil
+}
+
+var workflowTestsFlakyCmd = cli.Command{
+	Name:    "flaky",
+	Short:   "List the flaky tests of a workflow",
+	Long:    "List the tests of a workflow that both passed and failed on the same commit, the flakiest first.",
+	Example: "cdsctl experimental workflow tests flaky <proj_key> <vcs_identifier> <repository_identifier> <workflow_name>",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "vcs_identifier"},
+		{Name: "repository_identifier"},
+		{Name: "workflow_name"},
+	},
+	Flags: []cli.Flag{workflowTestsLimitFlag},
+	Mcp:   true,
+}
+
+func workflowTestsFlakyFunc(v cli.Values) (cli.ListResult, error) {
+	stats, err := client.WorkflowV2FlakyTestCases(context.Background(), v.GetString("proj_key"), v.GetString("vcs_identifier"), v.GetString("repository_identifier"), v.GetString("workflow_name"),
+		cdsclient.WithQueryParameter("limit", v.GetString("limit")))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(stats), nil
+}
+
+var workflowTestsHistoryCmd = cli.Command{
+	Name:    "history",
+	Short:   "Show the last executions of a test",
+	Example: "cdsctl experimental workflow tests history <proj_key> <vcs_identifier> <repository_identifier> <workflow_name> <suite> <test_name> --ref refs/heads/master",
+	Ctx:     []cli.Arg{},
+	Args: []cli.Arg{
+		{Name: "proj_key"},
+		{Name: "vcs_identifier"},
+		{Name: "repository_identifier"},
+		{Name: "workflow_name"},
+		{Name: "suite"},
+		{Name: "test_name"},
+	},
+	Flags: []cli.Flag{
+		{Name: "ref", Usage: "Only the executions on this git ref, all branches by default"},
+		workflowTestsLimitFlag,
+	},
+	Mcp: true,
+}
+
+func workflowTestsHistoryFunc(v cli.Values) (cli.ListResult, error) {
+	mods := []cdsclient.RequestModifier{cdsclient.WithQueryParameter("limit", v.GetString("limit"))}
+	if ref := v.GetString("ref"); ref != "" {
+		mods = append(mods, cdsclient.WithQueryParameter("ref", ref))
+	}
+	history, err := client.WorkflowV2TestCaseHistory(context.Background(), v.GetString("proj_key"), v.GetString("vcs_identifier"), v.GetString("repository_identifier"), v.GetString("workflow_name"),
+		v.GetString("suite"), v.GetString("test_name"), mods...)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(history), nil
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Index lists the entities available on a CDS instance. It is used to check references to
+// entities that are not defined in the linted repository.
+type Index struct {
+	UpdatedAt    time.Time `json:"updated_at"`
+	ProjectKey   string    `json:"project_key,omitempty"`
+	Actions      []string  `json:"actions"`
+	WorkerModels []string  `json:"worker_models"`
+	Regions      []string  `json:"regions"`
+	VariableSets []string  `json:"variable_sets,omitempty"`
+}
+
+// DefaultIndexPath returns the path of the cached index in the user cache directory.
+func DefaultIndexPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "cds", "lint-index.json")
+}
+
+// LoadIndex reads a cached index. It returns nil without error if the file doesn't exist.
+func LoadIndex(path string) (*Index, error) {
+	bts, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var idx Index
+	if err := json.Unmarshal(bts, &idx); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read index %s: %v", path, err)
+	}
+	return &idx, nil
+}
+
+// Save writes the index to the given path.
+func (i Index) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return sdk.WithStack(err)
+	}
+	bts, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(os.WriteFile(path, bts, 0600))
+}
+
+// NewIndexFromSchema builds an index from the workflow JSON schema returned by the API, which
+// enumerates the actions, worker models and regions available to the current user.
+func NewIndexFromSchema(workflowSchema []byte) (*Index, error) {
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]struct {
+				Enum  []string `json:"enum"`
+				AnyOf []struct {
+					Enum []string `json:"enum"`
+				} `json:"anyOf"`
+			} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(workflowSchema, &schema); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read workflow schema: %v", err)
+	}
+	idx := Index{UpdatedAt: time.Now()}
+	for _, anyOf := range schema.Defs["ActionStep"].Properties["uses"].AnyOf {
+		for _, name := range anyOf.Enum {
+			idx.Actions = append(idx.Actions, strings.TrimPrefix(name, "actions/"))
+		}
+	}
+	for _, anyOf := range schema.Defs["V2Job"].Properties["runs-on"].AnyOf {
+		idx.WorkerModels = append(idx.WorkerModels, anyOf.Enum...)
+	}
+	idx.Regions = schema.Defs["V2Job"].Properties["region"].Enum
+	return &idx, nil
+}
+
+// hasEntity checks if a reference to a remote entity matches an entity of the index. Project,
+// vcs and repository may be omitted in references, so the reference must match the end of the
+// complete name of an entity.
+func hasEntity(names []string, ref string) bool {
+	refPath, refVersion, _ := strings.Cut(ref, "@")
+	refParts := strings.Split(refPath, "/")
+	for _, n := range names {
+		path, version, _ := strings.Cut(n, "@")
+		if refVersion != "" && version != "" && refVersion != version {
+			continue
+		}
+		if path == refPath {
+			return true
+		}
+		parts := strings.Split(path, "/")
+		if len(parts) < len(refParts) {
+			continue
+		}
+		if strings.Join(parts[len(parts)-len(refParts):], "/") == refPath && len(refParts) > 2 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,525 @@
+// Package lint checks the entities defined in the .cds directory of a repository without any
+// call to the CDS API. References to entities of other repositories are checked against a cached
+// index of the entities available on the CDS instance.
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rockbears/yaml"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Level of a lint result, using SARIF levels.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+// Rules reported by the linter.
+const (
+	RuleParse     = "parse"
+	RuleName      = "name"
+	RuleSchema    = "schema"
+	RuleUses      = "uses"
+	RuleRunsOn    = "runs-on"
+	RuleFrom      = "from"
+	RuleNeeds     = "needs"
+	RuleRegion    = "region"
+	RuleVars      = "vars"
+	RuleAPI       = "api"
+	cdsDirectory  = ".cds"
+	expressionTag = "${{"
+)
+
+var ruleDescriptions = map[string]string{
+	RuleParse:  "File must be a valid YAML entity definition",
+	RuleName:   "Entity names must be valid and unique by type",
+	RuleSchema: "Entity must be valid against its JSON schema and static checks",
+	RuleUses:   "Actions used by steps must exist",
+	RuleRunsOn: "Worker models used by jobs must exist",
+	RuleFrom:   "Templates used by workflows and jobs must exist and receive their required parameters",
+	RuleNeeds:  "Job dependencies must not contain cycles",
+	RuleRegion: "Regions used by jobs must exist",
+	RuleVars:   "Variable sets must exist and be declared before use",
+	RuleAPI:    "Entity must be valid for the CDS API",
+}
+
+// Result is a problem found in a file.
+type Result struct {
+	Rule    string `json:"rule" cli:"rule"`
+	Level   Level  `json:"level" cli:"level"`
+	File    string `json:"file" cli:"file"`
+	Line    int    `json:"line,omitempty" cli:"line"`
+	Message string `json:"message" cli:"message"`
+}
+
+func (r Result) String() string {
+	if r.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s [%s]", r.File, r.Line, r.Level, r.Message, r.Rule)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", r.File, r.Level, r.Message, r.Rule)
+}
+
+// HasErrors returns true if one of the results is an error.
+func HasErrors(results []Result) bool {
+	for _, r := range results {
+		if r.Level == LevelError {
+			return true
+		}
+	}
+	return false
+}
+
+type file struct {
+	path    string // relative to the repository root
+	content []byte
+}
+
+type entity[T sdk.Lintable] struct {
+	file   file
+	object T
+}
+
+// Linter checks all the entities of a repository.
+type Linter struct {
+	// Dir is the root directory of the repository.
+	Dir string
+	// Index is used to check references to remote entities. They are not checked if nil.
+	Index *Index
+
+	actions      []entity[sdk.V2Action]
+	workerModels []entity[sdk.V2WorkerModel]
+	templates    []entity[sdk.V2WorkflowTemplate]
+	workflows    []entity[sdk.V2Workflow]
+	results      []Result
+}
+
+// RepositoryRoot returns the root directory of the repository that contains the given directory,
+// looking for a parent directory that contains a .cds directory.
+func RepositoryRoot(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	for d := abs; ; d = filepath.Dir(d) {
+		if filepath.Base(d) == cdsDirectory {
+			return filepath.Dir(d), nil
+		}
+		if fi, err := os.Stat(filepath.Join(d, cdsDirectory)); err == nil && fi.IsDir() {
+			return d, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find a %s directory from %s", cdsDirectory, dir)
+		}
+	}
+}
+
+// Lint loads and checks all the entities of the repository.
+func (l *Linter) Lint(ctx context.Context) ([]Result, error) {
+	l.results = nil
+	var err error
+	if l.actions, err = loadEntities[sdk.V2Action](l, ".cds/actions"); err != nil {
+		return nil, err
+	}
+	if l.workerModels, err = loadEntities[sdk.V2WorkerModel](l, ".cds/worker-models"); err != nil {
+		return nil, err
+	}
+	if l.templates, err = loadEntities[sdk.V2WorkflowTemplate](l, ".cds/workflow-templates"); err != nil {
+		return nil, err
+	}
+	if l.workflows, err = loadEntities[sdk.V2Workflow](l, ".cds/workflows"); err != nil {
+		return nil, err
+	}
+
+	for _, a := range l.actions {
+		l.checkSteps(a.file, a.object.Runs.Steps)
+	}
+	for _, w := range l.workflows {
+		l.checkWorkflow(w.file, w.object)
+	}
+	for _, t := range l.templates {
+		l.checkTemplate(ctx, t.file, t.object)
+	}
+
+	sort.SliceStable(l.results, func(i, j int) bool {
+		if l.results[i].File != l.results[j].File {
+			return l.results[i].File < l.results[j].File
+		}
+		if l.results[i].Line != l.results[j].Line {
+			return l.results[i].Line < l.results[j].Line
+		}
+		return l.results[i].Message < l.results[j].Message
+	})
+	return l.results, nil
+}
+
+func (l *Linter) add(f file, rule string, level Level, needle string, format string, args ...interface{}) {
+	l.results = append(l.results, Result{
+		Rule:    rule,
+		Level:   level,
+		File:    f.path,
+		Line:    findLine(f.content, needle),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+var yamlLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+// loadEntities reads all the entity files of a directory, then runs static and name checks.
+func loadEntities[T sdk.Lintable](l *Linter, dir string) ([]entity[T], error) {
+	files, err := os.ReadDir(filepath.Join(l.Dir, dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	namePattern := regexp.MustCompile(sdk.EntityNamePattern)
+	entities := make([]entity[T], 0)
+	names := make(map[string]string)
+	for _, fi := range files {
+		if fi.IsDir() || !(strings.HasSuffix(fi.Name(), ".yml") || strings.HasSuffix(fi.Name(), ".yaml")) {
+			continue
+		}
+		f := file{path: dir + "/" + fi.Name()}
+		f.content, err = os.ReadFile(filepath.Join(l.Dir, f.path))
+		if err != nil {
+			return nil, sdk.WithStack(err)
+		}
+
+		var objects []T
+		if err := yaml.UnmarshalMultipleDocuments(f.content, &objects); err != nil {
+			r := Result{Rule: RuleParse, Level: LevelError, File: f.path, Message: err.Error()}
+			if m := yamlLineRegexp.FindStringSubmatch(err.Error()); len(m) == 2 {
+				r.Line, _ = strconv.Atoi(m[1])
+			}
+			l.results = append(l.results, r)
+			continue
+		}
+		for _, o := range objects {
+			name := o.GetName()
+			if !namePattern.MatchString(name) {
+				l.add(f, RuleName, LevelError, "name:", "name %q doesn't match %s", name, sdk.EntityNamePattern)
+			}
+			if other, has := names[name]; has {
+				l.add(f, RuleName, LevelError, "name:", "name %s is already used in %s", name, other)
+			}
+			names[name] = f.path
+			for _, e := range o.Lint() {
+				msg := sdk.ExtractHTTPError(e).From
+				if msg == "" {
+					msg = e.Error()
+				}
+				l.add(f, RuleSchema, LevelError, "", "%s", msg)
+			}
+			entities = append(entities, entity[T]{file: f, object: o})
+		}
+	}
+	return entities, nil
+}
+
+func (l *Linter) checkWorkflow(f file, w sdk.V2Workflow) {
+	if w.From != "" {
+		l.checkTemplateReference(f, w.From, w.Parameters)
+		return
+	}
+	l.checkVariableSets(f, w.VariableSets)
+
+	for jobID, j := range w.Jobs {
+		if j.From != "" {
+			l.checkTemplateReference(f, j.From, j.Parameters)
+			continue
+		}
+		if j.RunsOn.Model != "" && !strings.Contains(j.RunsOn.Model, expressionTag) && len(j.Steps) > 0 {
+			l.checkReference(f, RuleRunsOn, "worker model", j.RunsOn.Model, ".cds/worker-models/", l.workerModelNames(), indexNames(l.Index, func(i *Index) []string { return i.WorkerModels }))
+		}
+		if j.Region != "" && !strings.Contains(j.Region, expressionTag) && l.Index != nil && len(l.Index.Regions) > 0 {
+			if !hasEntity(l.Index.Regions, j.Region) {
+				l.add(f, RuleRegion, LevelWarning, j.Region, "job %s: region %s not found in the index updated at %s", jobID, j.Region, l.Index.UpdatedAt.Format("2006-01-02 15:04"))
+			}
+		}
+		l.checkVariableSets(f, j.VariableSets)
+		l.checkSteps(f, j.Steps)
+
+		// Variable sets used in expressions must be declared on the workflow or on the job
+		declared := append(append([]string{}, w.VariableSets...), j.VariableSets...)
+		for _, set := range usedVariableSets(j) {
+			if !stringSliceContains(declared, set) && !anyExpression(declared) {
+				l.add(f, RuleVars, LevelError, "vars."+set, "job %s: variable set %s is used but not declared in vars", jobID, set)
+			}
+		}
+	}
+
+	if cycle := needsCycle(w); len(cycle) > 0 {
+		l.add(f, RuleNeeds, LevelError, "needs:", "jobs dependencies contain a cycle: %s", strings.Join(cycle, " -> "))
+	}
+}
+
+func (l *Linter) checkTemplate(ctx context.Context, f file, t sdk.V2WorkflowTemplate) {
+	// Generate the workflow with default parameters to check the spec
+	params := make(map[string]string)
+	for _, p := range t.Parameters {
+		params[p.Key] = "value"
+		if p.Default != nil {
+			params[p.Key] = *p.Default
+		}
+	}
+	wf := sdk.V2Workflow{Name: t.Name, Parameters: params}
+	if _, err := t.Resolve(ctx, &wf); err != nil {
+		l.add(f, RuleSchema, LevelWarning, "spec:", "template %s: unable to generate a workflow with default parameters: %v", t.Name, err)
+		return
+	}
+	for _, j := range wf.Jobs {
+		if j.From != "" {
+			l.checkTemplateReference(f, j.From, j.Parameters)
+			continue
+		}
+		if j.RunsOn.Model != "" && !strings.Contains(j.RunsOn.Model, expressionTag) && len(j.Steps) > 0 {
+			l.checkReference(f, RuleRunsOn, "worker model", j.RunsOn.Model, ".cds/worker-models/", l.workerModelNames(), indexNames(l.Index, func(i *Index) []string { return i.WorkerModels }))
+		}
+		l.checkSteps(f, j.Steps)
+	}
+}
+
+func (l *Linter) checkSteps(f file, steps []sdk.ActionStep) {
+	for _, s := range steps {
+		if s.Uses == "" || strings.Contains(s.Uses, expressionTag) {
+			continue
+		}
+		name := strings.TrimPrefix(s.Uses, "actions/")
+		// Plugins
+		if strings.HasPrefix(s.Uses, "actions/") && !strings.Contains(name, "/") {
+			if l.Index != nil && !stringSliceContains(l.Index.Actions, name) {
+				l.add(f, RuleUses, LevelWarning, s.Uses, "action %s not found in the index updated at %s", s.Uses, l.Index.UpdatedAt.Format("2006-01-02 15:04"))
+			}
+			continue
+		}
+		if strings.HasPrefix(s.Uses, ".cds/") {
+			name = s.Uses
+		}
+		l.checkReference(f, RuleUses, "action", name, ".cds/actions/", l.actionNames(), indexNames(l.Index, func(i *Index) []string { return i.Actions }))
+	}
+}
+
+func (l *Linter) checkTemplateReference(f file, name string, params map[string]string) {
+	if strings.Contains(name, expressionTag) {
+		return
+	}
+	// Templates are not listed in the index, only local templates can be checked
+	var tpl *sdk.V2WorkflowTemplate
+	for i := range l.templates {
+		t := l.templates[i]
+		if t.file.path == name || t.object.Name == strings.Split(name, "@")[0] {
+			tpl = &l.templates[i].object
+			break
+		}
+	}
+	if tpl == nil {
+		if strings.HasPrefix(name, ".cds/") || !strings.Contains(name, "/") {
+			l.add(f, RuleFrom, LevelError, name, "workflow template %s not found in the repository", name)
+		}
+		return
+	}
+	for _, p := range tpl.Parameters {
+		if v, has := params[p.Key]; p.Required && (!has || len(v) == 0) {
+			l.add(f, RuleFrom, LevelError, name, "required template parameter %q of template %s is missing or empty", p.Key, tpl.Name)
+		}
+	}
+}
+
+// checkReference checks a reference to an entity: a path in the repository, an entity of the
+// repository or an entity from another repository.
+func (l *Linter) checkReference(f file, rule, entityType, name, localDir string, localNames map[string]string, remoteNames []string) {
+	path := strings.Split(name, "@")[0]
+	if strings.HasPrefix(path, localDir) {
+		if !mapHasValue(localNames, path) {
+			l.add(f, rule, LevelError, name, "%s %s not found in the repository", entityType, path)
+		}
+		return
+	}
+	if !strings.Contains(path, "/") {
+		if _, has := localNames[path]; !has {
+			l.add(f, rule, LevelError, name, "%s %s not found in the repository", entityType, path)
+		}
+		return
+	}
+	if l.Index == nil {
+		return
+	}
+	if !hasEntity(remoteNames, name) {
+		l.add(f, rule, LevelWarning, name, "%s %s not found in the index updated at %s", entityType, name, l.Index.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+}
+
+func (l *Linter) checkVariableSets(f file, sets []string) {
+	if l.Index == nil || len(l.Index.VariableSets) == 0 {
+		return
+	}
+	for _, s := range sets {
+		if strings.Contains(s, expressionTag) {
+			continue
+		}
+		if !stringSliceContains(l.Index.VariableSets, s) {
+			l.add(f, RuleVars, LevelWarning, s, "variable set %s not found in project %s", s, l.Index.ProjectKey)
+		}
+	}
+}
+
+func (l *Linter) actionNames() map[string]string {
+	names := make(map[string]string, len(l.actions))
+	for _, a := range l.actions {
+		names[a.object.Name] = a.file.path
+	}
+	return names
+}
+
+func (l *Linter) workerModelNames() map[string]string {
+	names := make(map[string]string, len(l.workerModels))
+	for _, wm := range l.workerModels {
+		names[wm.object.Name] = wm.file.path
+	}
+	return names
+}
+
+func indexNames(i *Index, f func(i *Index) []string) []string {
+	if i == nil {
+		return nil
+	}
+	return f(i)
+}
+
+var varsExpressionRegexp = regexp.MustCompile(`vars\.([a-zA-Z0-9_-]+)`)
+
+// usedVariableSets returns the variable sets used in the expressions of a job.
+func usedVariableSets(j sdk.V2Job) []string {
+	values := []string{j.If}
+	for _, v := range j.Env {
+		values = append(values, v)
+	}
+	for _, s := range j.Steps {
+		values = append(values, s.If, s.Run)
+		for _, v := range s.Env {
+			values = append(values, v)
+		}
+		for _, v := range s.With {
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+	}
+	sets := make([]string, 0)
+	for _, v := range values {
+		if !strings.Contains(v, expressionTag) {
+			continue
+		}
+		for _, m := range varsExpressionRegexp.FindAllStringSubmatch(v, -1) {
+			if !stringSliceContains(sets, m[1]) {
+				sets = append(sets, m[1])
+			}
+		}
+	}
+	sort.Strings(sets)
+	return sets
+}
+
+// needsCycle returns the jobs of a dependency cycle if any.
+func needsCycle(w sdk.V2Workflow) []string {
+	jobIDs := make([]string, 0, len(w.Jobs))
+	for jobID := range w.Jobs {
+		jobIDs = append(jobIDs, jobID)
+	}
+	sort.Strings(jobIDs)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(jobID string) []string
+	visit = func(jobID string) []string {
+		switch state[jobID] {
+		case visiting:
+			for i := range path {
+				if path[i] == jobID {
+					return append(append([]string{}, path[i:]...), jobID)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[jobID] = visiting
+		path = append(path, jobID)
+		for _, n := range w.Jobs[jobID].Needs {
+			if _, has := w.Jobs[n]; !has || n == jobID {
+				continue // already reported by the workflow static checks
+			}
+			if cycle := visit(n); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[jobID] = visited
+		return nil
+	}
+	for _, jobID := range jobIDs {
+		if cycle := visit(jobID); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// findLine returns the first line that contains the given text, or 0 if not found.
+func findLine(content []byte, needle string) int {
+	if needle == "" {
+		return 0
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for i := 1; scanner.Scan(); i++ {
+		if strings.Contains(scanner.Text(), needle) {
+			return i
+		}
+	}
+	return 0
+}
+
+func stringSliceContains(s []string, v string) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
+
+func anyExpression(s []string) bool {
+	for i := range s {
+		if strings.Contains(s[i], expressionTag) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapHasValue(m map[string]string, v string) bool {
+	for _, value := range m {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func writeFile(t *testing.T, dir, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, ".cds/actions/build.yml", `name: build
+runs:
+  steps:
+  - uses: actions/unknown-plugin
+  - uses: actions/checkout
+`)
+	writeFile(t, dir, ".cds/worker-models/docker-debian.yml", `name: docker-debian
+type: docker
+osarch: linux/amd64
+spec:
+  image: debian:12
+`)
+	writeFile(t, dir, ".cds/workflow-templates/tpl.yml", `name: tpl
+parameters:
+- key: name
+  required: true
+spec: |-
+  jobs:
+    [[.params.name]]:
+      runs-on: .cds/worker-models/docker-debian.yml
+      steps:
+      - run: echo hello
+`)
+	writeFile(t, dir, ".cds/workflows/main.yml", `name: main
+vars: [my-vars]
+jobs:
+  build:
+    runs-on: docker-debian
+    steps:
+    - uses: actions/build
+    - uses: .cds/actions/missing.yml
+  test:
+    needs: [deploy]
+    runs-on: my-org/my-repo/unknown-model
+    region: moon
+    steps:
+    - uses: my/remote/repo/action@main
+    - run: echo ${{ vars.other-vars.token }}
+  deploy:
+    needs: [test]
+    runs-on: MYPROJ/github/ovh/models/docker-debian
+    steps:
+    - run: echo deploy
+`)
+	writeFile(t, dir, ".cds/workflows/from-template.yml", `name: from-template
+from: tpl
+`)
+	writeFile(t, dir, ".cds/workflows/invalid.yml", `name: invalid
+jobs:
+  build: [
+`)
+
+	// Without index, remote references are not checked
+	l := Linter{Dir: dir}
+	results, err := l.Lint(context.TODO())
+	require.NoError(t, err)
+	for _, r := range results {
+		t.Log(r)
+	}
+	require.True(t, HasErrors(results))
+	require.Contains(t, results, Result{Rule: RuleUses, Level: LevelError, File: ".cds/workflows/main.yml", Line: 8, Message: "action .cds/actions/missing.yml not found in the repository"})
+	require.Contains(t, results, Result{Rule: RuleNeeds, Level: LevelError, File: ".cds/workflows/main.yml", Line: 10, Message: "jobs dependencies contain a cycle: deploy -> test -> deploy"})
+	require.Contains(t, results, Result{Rule: RuleVars, Level: LevelError, File: ".cds/workflows/main.yml", Line: 15, Message: "job test: variable set other-vars is used but not declared in vars"})
+	require.Contains(t, results, Result{Rule: RuleFrom, Level: LevelError, File: ".cds/workflows/from-template.yml", Line: 2, Message: "required template parameter \"name\" of template tpl is missing or empty"})
+	for _, r := range results {
+		require.NotEqual(t, LevelWarning, r.Level, r.String())
+		if r.File == ".cds/workflows/invalid.yml" {
+			require.Equal(t, RuleParse, r.Rule)
+		}
+	}
+
+	// With an index
+	l.Index = &Index{
+		UpdatedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		ProjectKey:   "MYPROJ",
+		Actions:      []string{"checkout", "MYPROJ/github/my/remote/repo/action@main"},
+		WorkerModels: []string{"MYPROJ/github/ovh/models/docker-debian"},
+		Regions:      []string{"eu-west"},
+		VariableSets: []string{"other-vars"},
+	}
+	results, err = l.Lint(context.TODO())
+	require.NoError(t, err)
+	require.Contains(t, results, Result{Rule: RuleUses, Level: LevelWarning, File: ".cds/actions/build.yml", Line: 4, Message: "action actions/unknown-plugin not found in the index updated at 2024-01-01 00:00"})
+	require.Contains(t, results, Result{Rule: RuleRunsOn, Level: LevelWarning, File: ".cds/workflows/main.yml", Line: 11, Message: "worker model my-org/my-repo/unknown-model not found in the index updated at 2024-01-01 00:00"})
+	require.Contains(t, results, Result{Rule: RuleRegion, Level: LevelWarning, File: ".cds/workflows/main.yml", Line: 12, Message: "job test: region moon not found in the index updated at 2024-01-01 00:00"})
+	require.Contains(t, results, Result{Rule: RuleVars, Level: LevelWarning, File: ".cds/workflows/main.yml", Line: 2, Message: "variable set my-vars not found in project MYPROJ"})
+	for _, r := range results {
+		require.NotContains(t, r.Message, "my/remote/repo/action")
+		require.NotContains(t, r.Message, "MYPROJ/github/ovh/models/docker-debian")
+	}
+
+	// SARIF output
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, results))
+	var sarif map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &sarif))
+	require.Equal(t, "2.1.0", sarif["version"])
+	runs := sarif["runs"].([]interface{})
+	require.Len(t, runs[0].(map[string]interface{})["results"], len(results))
+}
+
+func TestRepositoryRoot(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".cds", "workflows"), 0755))
+
+	root, err := RepositoryRoot(filepath.Join(dir, ".cds", "workflows"))
+	require.NoError(t, err)
+	require.Equal(t, dir, root)
+
+	root, err = RepositoryRoot(dir)
+	require.NoError(t, err)
+	require.Equal(t, dir, root)
+}
+
+func TestNewIndexFromSchema(t *testing.T) {
+	schema, err := sdk.GetWorkflowJsonSchema([]string{"checkout", "PROJ/github/ovh/repo/act@main"}, []string{"eu-west"}, []string{"library/default-container"}).MarshalJSON()
+	require.NoError(t, err)
+	idx, err := NewIndexFromSchema(schema)
+	require.NoError(t, err)
+	require.Equal(t, []string{"checkout", "PROJ/github/ovh/repo/act@main"}, idx.Actions)
+	require.Equal(t, []string{"library/default-container"}, idx.WorkerModels)
+	require.Equal(t, []string{"eu-west"}, idx.Regions)
+}
@@ -0,0 +1,285 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/rockbears/yaml"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// document is a text document opened in the client.
+type document struct {
+	uri        string
+	path       string
+	entityType string
+	lines      []string
+
+	// Last version of the entities that were successfully parsed, to keep completion working
+	// while the document is being edited.
+	workflow *sdk.V2Workflow
+	action   *sdk.V2Action
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{uri: uri, path: uriToPath(uri)}
+	d.entityType = entityTypeFromPath(d.path)
+	d.setText(text)
+	return d
+}
+
+func (d *document) setText(text string) {
+	d.lines = strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	switch d.entityType {
+	case sdk.EntityTypeWorkflow:
+		var w sdk.V2Workflow
+		if err := yaml.Unmarshal([]byte(text), &w); err == nil {
+			d.workflow = &w
+		}
+	case sdk.EntityTypeAction:
+		var a sdk.V2Action
+		if err := yaml.Unmarshal([]byte(text), &a); err == nil {
+			d.action = &a
+		}
+	}
+}
+
+func (d *document) line(n int) string {
+	if n < 0 || n >= len(d.lines) {
+		return ""
+	}
+	return d.lines[n]
+}
+
+// Positions sent by clients count characters in UTF-16 code units, the document is handled with
+// byte offsets. bytePosition converts a position received from the client, and lspRange converts
+// a range of the document before it is sent to the client.
+
+func (d *document) bytePosition(pos Position) Position {
+	l := d.line(pos.Line)
+	units := 0
+	for i, r := range l {
+		if units >= pos.Character {
+			return Position{Line: pos.Line, Character: i}
+		}
+		units += utf16.RuneLen(r)
+	}
+	return Position{Line: pos.Line, Character: len(l)}
+}
+
+func (d *document) lspPosition(pos Position) Position {
+	l := d.line(pos.Line)
+	units := 0
+	for _, r := range l[:min(pos.Character, len(l))] {
+		units += utf16.RuneLen(r)
+	}
+	return Position{Line: pos.Line, Character: units}
+}
+
+func (d *document) lspRange(r Range) Range {
+	return Range{Start: d.lspPosition(r.Start), End: d.lspPosition(r.End)}
+}
+
+// beforeCursor returns the text of the line before the given position.
+func (d *document) beforeCursor(pos Position) string {
+	l := d.line(pos.Line)
+	if pos.Character < len(l) {
+		return l[:pos.Character]
+	}
+	return l
+}
+
+// yamlLine is the parsed structure of a line of a YAML document, only block style is supported.
+type yamlLine struct {
+	indent  int    // indentation of the line
+	content int    // indentation of the content, after the list item marker if any
+	item    bool   // the line starts a list item
+	key     string // key of a mapping entry
+	value   string // value of a mapping entry
+	hasKey  bool
+	empty   bool // blank or comment line
+}
+
+var yamlKeyRegexp = regexp.MustCompile(`^(["']?)([^"'#:\s][^"'#:]*?)(["']?)\s*:(\s+|$)(.*)$`)
+
+func parseYAMLLine(s string) yamlLine {
+	trimmed := strings.TrimLeft(s, " ")
+	l := yamlLine{indent: len(s) - len(trimmed)}
+	l.content = l.indent
+	if strings.TrimSpace(trimmed) == "" || strings.HasPrefix(trimmed, "#") {
+		l.empty = true
+		return l
+	}
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		l.item = true
+		rest := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+		l.content = len(s) - len(rest)
+		trimmed = rest
+	}
+	if m := yamlKeyRegexp.FindStringSubmatch(trimmed); m != nil {
+		l.hasKey = true
+		l.key = m[2]
+		l.value = strings.TrimSpace(m[5])
+	}
+	return l
+}
+
+// keyPath returns the path of the YAML node that contains the given line. List items are
+// represented by a "[]" segment. If the cursor is after the key of the current line, the
+// key is returned as the last element of the path and inValue is true.
+func (d *document) keyPath(pos Position) (path []string, inValue bool) {
+	cur := parseYAMLLine(d.line(pos.Line))
+	before := d.beforeCursor(pos)
+
+	var reversed []string
+	limit := cur.content
+	if cur.empty {
+		limit = len(before) - len(strings.TrimLeft(before, " "))
+		if strings.TrimSpace(before) != "" {
+			limit = pos.Character
+		}
+	}
+	if cur.hasKey && strings.Contains(before[min(cur.content, len(before)):], ":") {
+		reversed = append(reversed, cur.key)
+		inValue = true
+	}
+
+	// When the current line starts a list item, look for the key that holds the list
+	inList := false
+	listIndent := 0
+	if cur.item {
+		reversed = append(reversed, "[]")
+		inList = true
+		listIndent = cur.indent
+	}
+
+	for i := pos.Line - 1; i >= 0; i-- {
+		l := parseYAMLLine(d.line(i))
+		if l.empty {
+			continue
+		}
+		if inList {
+			// Skip sibling items and their content
+			if l.content > listIndent || (l.item && l.indent == listIndent) {
+				continue
+			}
+			if !l.hasKey {
+				break
+			}
+		} else {
+			if l.content >= limit {
+				if l.item && l.content == limit && l.indent < limit {
+					// Beginning of the list item that contains the current line
+					reversed = append(reversed, "[]")
+					inList = true
+					listIndent = l.indent
+				}
+				continue
+			}
+			if !l.hasKey {
+				break
+			}
+		}
+		reversed = append(reversed, l.key)
+		limit = l.content
+		inList = false
+		if l.item {
+			reversed = append(reversed, "[]")
+			inList = true
+			listIndent = l.indent
+		}
+		if limit == 0 && !inList {
+			break
+		}
+	}
+
+	path = make([]string, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		path = append(path, reversed[i])
+	}
+	return path, inValue
+}
+
+// wordAt returns the word at the given position and its range. Words contain letters, digits,
+// and the characters used in entity names and paths.
+func (d *document) wordAt(pos Position) (string, Range) {
+	l := d.line(pos.Line)
+	isWordChar := func(c byte) bool {
+		return c == '-' || c == '_' || c == '.' || c == '/' || c == '@' || c == '*' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+	start, end := pos.Character, pos.Character
+	if start > len(l) {
+		start, end = len(l), len(l)
+	}
+	for start > 0 && isWordChar(l[start-1]) {
+		start--
+	}
+	for end < len(l) && isWordChar(l[end]) {
+		end++
+	}
+	return l[start:end], Range{Start: Position{Line: pos.Line, Character: start}, End: Position{Line: pos.Line, Character: end}}
+}
+
+var expressionPathRegexp = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_-]*(?:\.(?:[a-zA-Z0-9_*-]*))*)$`)
+
+// expressionAt returns the context path being typed if the position is inside an expression.
+// The last element of the path is the prefix of the segment being typed.
+func (d *document) expressionAt(pos Position) (path []string, ok bool) {
+	before := d.beforeCursor(pos)
+	start := strings.LastIndex(before, "${{")
+	if start < 0 || strings.Contains(before[start:], "}}") {
+		return nil, false
+	}
+	expr := before[start+3:]
+	// Nothing to complete inside a string literal
+	if strings.Count(expr, "'")%2 == 1 {
+		return nil, true
+	}
+	m := expressionPathRegexp.FindString(expr)
+	if m == "" {
+		return []string{""}, true
+	}
+	// A dot must follow an identifier, not an operator
+	if idx := len(expr) - len(m); idx > 0 && expr[idx-1] == '.' {
+		return nil, true
+	}
+	return strings.Split(m, "."), true
+}
+
+// expressionRange is the position of an expression in a document.
+type expressionRange struct {
+	value string
+	rng   Range
+}
+
+var expressionRegexp = regexp.MustCompile(`\${{.*?}}`)
+
+// expressions returns all the expressions of the document, with the position of unclosed ones.
+func (d *document) expressions() (exprs []expressionRange, unclosed []Range) {
+	for i, l := range d.lines {
+		for _, loc := range expressionRegexp.FindAllStringIndex(l, -1) {
+			exprs = append(exprs, expressionRange{
+				value: l[loc[0]:loc[1]],
+				rng:   Range{Start: Position{Line: i, Character: loc[0]}, End: Position{Line: i, Character: loc[1]}},
+			})
+		}
+		rest := expressionRegexp.ReplaceAllStringFunc(l, func(s string) string { return strings.Repeat(" ", len(s)) })
+		if idx := strings.Index(rest, "${{"); idx >= 0 {
+			unclosed = append(unclosed, Range{Start: Position{Line: i, Character: idx}, End: Position{Line: i, Character: len(l)}})
+		}
+	}
+	return exprs, unclosed
+}
+
+// find returns the position of the first occurrence of a text in the document.
+func (d *document) find(needle string) (Range, bool) {
+	for i, l := range d.lines {
+		if idx := strings.Index(l, needle); idx >= 0 {
+			return Range{Start: Position{Line: i, Character: idx}, End: Position{Line: i, Character: idx + len(needle)}}, true
+		}
+	}
+	return Range{}, false
+}
@@ -0,0 +1,204 @@
+package lsp
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sguiheux/jsonschema"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// schemaNode is a node of a JSON schema, unmarshalled as a generic map to follow references.
+type schemaNode map[string]interface{}
+
+// schema navigates into a JSON schema generated by the sdk.
+type schema struct {
+	root schemaNode
+	defs map[string]interface{}
+}
+
+func newSchema(s *jsonschema.Schema) (*schema, error) {
+	bts, err := s.MarshalJSON()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var root schemaNode
+	if err := json.Unmarshal(bts, &root); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defs, _ := root["$defs"].(map[string]interface{})
+	return &schema{root: root, defs: defs}, nil
+}
+
+// resolve follows the $ref of a node.
+func (s *schema) resolve(n schemaNode) schemaNode {
+	for i := 0; n != nil && i < 16; i++ {
+		ref, ok := n["$ref"].(string)
+		if !ok {
+			return n
+		}
+		def, _ := s.defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+		n = def
+	}
+	return n
+}
+
+// alternatives returns the node and the nodes of its oneOf and anyOf keywords.
+func (s *schema) alternatives(n schemaNode) []schemaNode {
+	n = s.resolve(n)
+	if n == nil {
+		return nil
+	}
+	res := []schemaNode{n}
+	for _, k := range []string{"oneOf", "anyOf"} {
+		alts, _ := n[k].([]interface{})
+		for _, a := range alts {
+			if m, ok := a.(map[string]interface{}); ok {
+				res = append(res, s.alternatives(m)...)
+			}
+		}
+	}
+	return res
+}
+
+// child returns the schema of a key of an object, or of the items of an array if key is "[]".
+func (s *schema) child(n schemaNode, key string) schemaNode {
+	for _, alt := range s.alternatives(n) {
+		if key == "[]" {
+			if items, ok := alt["items"].(map[string]interface{}); ok {
+				return s.resolve(items)
+			}
+			continue
+		}
+		if props, ok := alt["properties"].(map[string]interface{}); ok {
+			if p, ok := props[key].(map[string]interface{}); ok {
+				return s.resolve(p)
+			}
+		}
+		// Maps such as jobs or env are described with patterns, any key matches
+		if patterns, ok := alt["patternProperties"].(map[string]interface{}); ok {
+			for _, p := range patterns {
+				if m, ok := p.(map[string]interface{}); ok {
+					return s.resolve(m)
+				}
+			}
+		}
+		if p, ok := alt["additionalProperties"].(map[string]interface{}); ok {
+			return s.resolve(p)
+		}
+	}
+	return nil
+}
+
+// lookup returns the schema at the given path, starting from the root.
+func (s *schema) lookup(path []string) schemaNode {
+	n := s.resolve(s.root)
+	for _, k := range path {
+		if n = s.child(n, k); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+type schemaProperty struct {
+	name        string
+	description string
+}
+
+// properties returns the named properties of an object, sorted by name.
+func (s *schema) properties(n schemaNode) []schemaProperty {
+	var res []schemaProperty
+	seen := make(map[string]bool)
+	for _, alt := range s.alternatives(n) {
+		props, _ := alt["properties"].(map[string]interface{})
+		for name, p := range props {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			m, _ := p.(map[string]interface{})
+			res = append(res, schemaProperty{name: name, description: s.description(m)})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+	return res
+}
+
+// description returns the description of a node, or of the node it references.
+func (s *schema) description(n schemaNode) string {
+	if n == nil {
+		return ""
+	}
+	if d, ok := n["description"].(string); ok && d != "" {
+		return d
+	}
+	if r := s.resolve(n); r != nil {
+		d, _ := r["description"].(string)
+		return d
+	}
+	return ""
+}
+
+// examples returns the examples of a node formatted as strings.
+func (s *schema) examples(n schemaNode) []string {
+	exs, _ := n["examples"].([]interface{})
+	res := make([]string, 0, len(exs))
+	for _, e := range exs {
+		switch v := e.(type) {
+		case string:
+			res = append(res, v)
+		default:
+			bts, _ := json.Marshal(v)
+			res = append(res, string(bts))
+		}
+	}
+	return res
+}
+
+// entityTypeFromPath returns the type of the entity defined in a file of the .cds directory.
+func entityTypeFromPath(path string) string {
+	dir := filepath.ToSlash(filepath.Dir(path))
+	switch {
+	case strings.HasSuffix(dir, ".cds/workflows"):
+		return sdk.EntityTypeWorkflow
+	case strings.HasSuffix(dir, ".cds/actions"):
+		return sdk.EntityTypeAction
+	case strings.HasSuffix(dir, ".cds/worker-models"):
+		return sdk.EntityTypeWorkerModel
+	case strings.HasSuffix(dir, ".cds/workflow-templates"):
+		return sdk.EntityTypeWorkflowTemplate
+	}
+	return ""
+}
+
+// schemas holds the schemas of all entity types and of the expression contexts.
+type schemas struct {
+	entities map[string]*schema
+	contexts *schema
+}
+
+func loadSchemas() (*schemas, error) {
+	res := schemas{entities: make(map[string]*schema)}
+	for t, s := range map[string]*jsonschema.Schema{
+		sdk.EntityTypeWorkflow:         sdk.GetWorkflowJsonSchema(nil, nil, nil),
+		sdk.EntityTypeAction:           sdk.GetActionJsonSchema(nil),
+		sdk.EntityTypeWorkerModel:      sdk.GetWorkerModelJsonSchema(),
+		sdk.EntityTypeWorkflowTemplate: sdk.GetWorkflowTemplateJsonSchema(),
+	} {
+		sc, err := newSchema(s)
+		if err != nil {
+			return nil, err
+		}
+		res.entities[t] = sc
+	}
+	sc, err := newSchema(sdk.GetWorkflowRunJobsContextJsonSchema())
+	if err != nil {
+		return nil, err
+	}
+	res.contexts = sc
+	return &res, nil
+}
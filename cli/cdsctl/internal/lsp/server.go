@@ -0,0 +1,718 @@
+// Package lsp implements a language server for the CDS as-code files stored in the .cds
+// directory of repositories. It provides diagnostics, completion, hover documentation and
+// go-to-definition to any editor that supports the language server protocol.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rockbears/yaml"
+
+	"github.com/ovh/cds/cli/cdsctl/internal/lint"
+	"github.com/ovh/cds/sdk"
+)
+
+const serverName = "cdsctl"
+
+// Server is a language server that communicates over a stream, usually the standard input
+// and output of the process started by the editor.
+type Server struct {
+	// Index is used by the linter to check references to remote entities. It may be nil.
+	Index *lint.Index
+
+	conn     *conn
+	schemas  *schemas
+	docs     map[string]*document
+	lintDiag map[string][]Diagnostic
+}
+
+// NewServer returns a server that uses the JSON schemas of the sdk.
+func NewServer(index *lint.Index) (*Server, error) {
+	sc, err := loadSchemas()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		Index:    index,
+		schemas:  sc,
+		docs:     make(map[string]*document),
+		lintDiag: make(map[string][]Diagnostic),
+	}, nil
+}
+
+// Serve reads requests from r and writes responses to w until the client asks to exit or
+// the input is closed.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+	for {
+		m, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if m.Method == "exit" {
+			return nil
+		}
+		result, rerr := s.handle(ctx, m)
+		if m.ID == nil {
+			// Notifications don't have responses
+			continue
+		}
+		resp := message{ID: m.ID, Error: rerr}
+		if rerr == nil {
+			resp.Result = result
+			if result == nil {
+				resp.Result = json.RawMessage("null")
+			}
+		}
+		if err := s.conn.write(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, m *message) (interface{}, *responseError) {
+	switch m.Method {
+	case "initialize":
+		var res InitializeResult
+		res.Capabilities = ServerCapabilities{
+			TextDocumentSync:   textDocumentSyncFull,
+			CompletionProvider: CompletionOptions{TriggerCharacters: []string{".", " "}},
+			HoverProvider:      true,
+			DefinitionProvider: true,
+		}
+		res.ServerInfo.Name = serverName
+		res.ServerInfo.Version = sdk.VERSION
+		return res, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var p DidOpenTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		d := newDocument(p.TextDocument.URI, p.TextDocument.Text)
+		s.docs[d.uri] = d
+		s.lint(ctx, d)
+		return nil, s.publishDiagnostics(ctx, d)
+	case "textDocument/didChange":
+		var p DidChangeTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		d, ok := s.docs[p.TextDocument.URI]
+		if !ok || len(p.ContentChanges) == 0 {
+			return nil, nil
+		}
+		d.setText(p.ContentChanges[len(p.ContentChanges)-1].Text)
+		return nil, s.publishDiagnostics(ctx, d)
+	case "textDocument/didSave":
+		var p DidSaveTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		d, ok := s.docs[p.TextDocument.URI]
+		if !ok {
+			return nil, nil
+		}
+		if p.Text != nil {
+			d.setText(*p.Text)
+		}
+		s.lint(ctx, d)
+		return nil, s.publishDiagnostics(ctx, d)
+	case "textDocument/didClose":
+		var p DidCloseTextDocumentParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.docs, p.TextDocument.URI)
+		delete(s.lintDiag, p.TextDocument.URI)
+		return nil, nil
+	case "textDocument/completion", "textDocument/hover", "textDocument/definition":
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(m.Params, &p); err != nil {
+			return nil, invalidParams(err)
+		}
+		d, ok := s.docs[p.TextDocument.URI]
+		if !ok {
+			return nil, nil
+		}
+		pos := d.bytePosition(p.Position)
+		switch m.Method {
+		case "textDocument/completion":
+			return CompletionList{Items: s.completion(d, pos)}, nil
+		case "textDocument/hover":
+			if h := s.hover(d, pos); h != nil {
+				if h.Range != nil {
+					rng := d.lspRange(*h.Range)
+					h.Range = &rng
+				}
+				return h, nil
+			}
+			return nil, nil
+		default:
+			if l := s.definition(d, pos); l != nil {
+				if l.URI == d.uri {
+					l.Range = d.lspRange(l.Range)
+				}
+				return l, nil
+			}
+			return nil, nil
+		}
+	}
+	if m.ID != nil {
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %s not supported", m.Method)}
+	}
+	return nil, nil
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+func (s *Server) publishDiagnostics(ctx context.Context, d *document) *responseError {
+	diags := append(s.expressionDiagnostics(ctx, d), s.lintDiag[d.uri]...)
+	if diags == nil {
+		diags = []Diagnostic{}
+	}
+	for i := range diags {
+		diags[i].Range = d.lspRange(diags[i].Range)
+	}
+	if err := s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: d.uri, Diagnostics: diags}); err != nil {
+		return &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return nil
+}
+
+// lint runs the linter on the repository that contains the document. The linter reads files
+// from the disk so it is only run when documents are opened or saved.
+func (s *Server) lint(ctx context.Context, d *document) {
+	delete(s.lintDiag, d.uri)
+	if d.entityType == "" {
+		return
+	}
+	root, err := lint.RepositoryRoot(filepath.Dir(d.path))
+	if err != nil {
+		return
+	}
+	rel, err := filepath.Rel(root, d.path)
+	if err != nil {
+		return
+	}
+	l := lint.Linter{Dir: root, Index: s.Index}
+	results, err := l.Lint(ctx)
+	if err != nil {
+		s.lintDiag[d.uri] = []Diagnostic{{Severity: SeverityError, Source: serverName, Message: err.Error()}}
+		return
+	}
+	for _, r := range results {
+		if r.File != filepath.ToSlash(rel) {
+			continue
+		}
+		line := max(r.Line-1, 0)
+		severity := SeverityInformation
+		switch r.Level {
+		case lint.LevelError:
+			severity = SeverityError
+		case lint.LevelWarning:
+			severity = SeverityWarning
+		}
+		s.lintDiag[d.uri] = append(s.lintDiag[d.uri], Diagnostic{
+			Range:    Range{Start: Position{Line: line}, End: Position{Line: line, Character: len(d.line(line))}},
+			Severity: severity,
+			Code:     r.Rule,
+			Source:   serverName,
+			Message:  r.Message,
+		})
+	}
+}
+
+// expressionDiagnostics checks the syntax of the expressions of the document, and the
+// contexts and functions they use.
+func (s *Server) expressionDiagnostics(ctx context.Context, d *document) []Diagnostic {
+	var diags []Diagnostic
+	exprs, unclosed := d.expressions()
+	for _, r := range unclosed {
+		diags = append(diags, Diagnostic{Range: r, Severity: SeverityError, Source: serverName, Message: "expression is not closed, missing }}"})
+	}
+
+	roots := make(map[string]bool)
+	for _, p := range s.schemas.contexts.properties(s.schemas.contexts.lookup(nil)) {
+		roots[p.name] = true
+	}
+
+	parser := sdk.NewActionParser(nil, sdk.DefaultFuncs)
+	for _, e := range exprs {
+		if err := parser.Validate(ctx, e.value); err != nil {
+			msg := err.Error()
+			if httpErr := sdk.ExtractHTTPError(err); httpErr.From != "" {
+				msg = httpErr.From
+			}
+			diags = append(diags, Diagnostic{Range: e.rng, Severity: SeverityError, Source: serverName, Message: "invalid expression: " + msg})
+			continue
+		}
+		for _, id := range expressionIdentifiers(e.value) {
+			rng := Range{
+				Start: Position{Line: e.rng.Start.Line, Character: e.rng.Start.Character + id.offset},
+				End:   Position{Line: e.rng.Start.Line, Character: e.rng.Start.Character + id.offset + len(id.name)},
+			}
+			switch {
+			case id.function:
+				if _, ok := sdk.DefaultFuncs[id.name]; !ok {
+					diags = append(diags, Diagnostic{Range: rng, Severity: SeverityError, Source: serverName, Message: fmt.Sprintf("unknown function %s", id.name)})
+				}
+			case !roots[id.name]:
+				diags = append(diags, Diagnostic{Range: rng, Severity: SeverityWarning, Source: serverName, Message: fmt.Sprintf("unknown context %s", id.name)})
+			}
+		}
+	}
+	return diags
+}
+
+type expressionIdentifier struct {
+	name     string
+	offset   int
+	function bool
+}
+
+// expressionIdentifiers returns the context names and function names used in an expression,
+// ignoring string literals and the properties of contexts.
+func expressionIdentifiers(expr string) []expressionIdentifier {
+	isIDStart := func(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+	isIDChar := func(c byte) bool { return isIDStart(c) || c == '-' || (c >= '0' && c <= '9') }
+
+	var res []expressionIdentifier
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		if c == '\'' {
+			// Skip string literals, quotes are escaped by doubling them
+			for i++; i < len(expr); i++ {
+				if expr[i] == '\'' {
+					if i+1 < len(expr) && expr[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			continue
+		}
+		if !isIDStart(c) || (i > 0 && (expr[i-1] == '.' || isIDChar(expr[i-1]))) {
+			continue
+		}
+		start := i
+		for i < len(expr) && isIDChar(expr[i]) {
+			i++
+		}
+		name := expr[start:i]
+		rest := strings.TrimLeft(expr[i:], " ")
+		i--
+		switch name {
+		case "true", "false", "null":
+			continue
+		}
+		res = append(res, expressionIdentifier{name: name, offset: start, function: strings.HasPrefix(rest, "(")})
+	}
+	return res
+}
+
+// currentJob returns the identifier of the job that contains the position in a workflow.
+func (d *document) currentJob(pos Position) string {
+	path, _ := d.keyPath(pos)
+	if len(path) >= 2 && path[0] == "jobs" {
+		return path[1]
+	}
+	return ""
+}
+
+func (s *Server) completion(d *document, pos Position) []CompletionItem {
+	var items []CompletionItem
+	if path, ok := d.expressionAt(pos); ok {
+		if len(path) == 0 {
+			return []CompletionItem{}
+		}
+		items = s.expressionCompletion(d, pos, path[:len(path)-1])
+		return filterCompletion(items, path[len(path)-1])
+	}
+
+	sc, ok := s.schemas.entities[d.entityType]
+	if !ok {
+		return []CompletionItem{}
+	}
+	path, inValue := d.keyPath(pos)
+	if inValue {
+		key := path[len(path)-1]
+		word, _ := d.wordAt(pos)
+		return filterCompletion(s.valueCompletion(d, pos, key), word)
+	}
+	for _, p := range sc.properties(sc.lookup(path)) {
+		items = append(items, CompletionItem{Label: p.name, Kind: CompletionKindProperty, Documentation: p.description, InsertText: p.name + ": "})
+	}
+	word, _ := d.wordAt(pos)
+	return filterCompletion(items, word)
+}
+
+// expressionCompletion returns the items that can follow the given context path.
+func (s *Server) expressionCompletion(d *document, pos Position, parents []string) []CompletionItem {
+	var items []CompletionItem
+	sc := s.schemas.contexts
+	if len(parents) == 0 {
+		for _, p := range sc.properties(sc.lookup(nil)) {
+			items = append(items, CompletionItem{Label: p.name, Kind: CompletionKindModule, Documentation: p.description})
+		}
+		for name := range sdk.DefaultFuncs {
+			items = append(items, CompletionItem{Label: name, Kind: CompletionKindFunction, Detail: "function", InsertText: name + "("})
+		}
+		return items
+	}
+
+	job := d.currentJob(pos)
+	keys := func(names []string, kind CompletionItemKind, detail string) []CompletionItem {
+		sort.Strings(names)
+		res := make([]CompletionItem, 0, len(names))
+		for _, n := range names {
+			res = append(res, CompletionItem{Label: n, Kind: kind, Detail: detail})
+		}
+		return res
+	}
+
+	switch parents[0] {
+	case "jobs", "needs":
+		if d.workflow == nil {
+			break
+		}
+		switch {
+		case len(parents) == 1 && parents[0] == "jobs":
+			return keys(mapKeys(d.workflow.Jobs), CompletionKindField, "job")
+		case len(parents) == 1:
+			if j, ok := d.workflow.Jobs[job]; ok {
+				return keys(append([]string{}, j.Needs...), CompletionKindField, "job")
+			}
+			return nil
+		case len(parents) == 3 && parents[2] == "outputs":
+			if j, ok := d.workflow.Jobs[parents[1]]; ok {
+				return keys(mapKeys(j.Outputs), CompletionKindVariable, "output of job "+parents[1])
+			}
+			return nil
+		}
+	case "steps":
+		if len(parents) == 1 {
+			var steps []sdk.ActionStep
+			if d.workflow != nil {
+				steps = d.workflow.Jobs[job].Steps
+			} else if d.action != nil {
+				steps = d.action.Runs.Steps
+			}
+			var ids []string
+			for _, st := range steps {
+				if st.ID != "" {
+					ids = append(ids, st.ID)
+				}
+			}
+			return keys(ids, CompletionKindField, "step")
+		}
+	case "matrix":
+		if len(parents) == 1 && d.workflow != nil {
+			if j, ok := d.workflow.Jobs[job]; ok && j.Strategy != nil {
+				return keys(mapKeys(j.Strategy.Matrix), CompletionKindVariable, "matrix variable")
+			}
+			return nil
+		}
+	case "env":
+		if len(parents) == 1 && d.workflow != nil {
+			names := mapKeys(d.workflow.Env)
+			for k := range d.workflow.Jobs[job].Env {
+				if !sdk.IsInArray(k, names) {
+					names = append(names, k)
+				}
+			}
+			return keys(names, CompletionKindVariable, "environment variable")
+		}
+	case "inputs":
+		if len(parents) == 1 && d.action != nil {
+			items := make([]CompletionItem, 0, len(d.action.Inputs))
+			for _, n := range mapKeys(d.action.Inputs) {
+				items = append(items, CompletionItem{Label: n, Kind: CompletionKindVariable, Detail: "input", Documentation: d.action.Inputs[n].Description})
+			}
+			return items
+		}
+	case "vars":
+		if len(parents) == 1 && s.Index != nil {
+			return keys(append([]string{}, s.Index.VariableSets...), CompletionKindModule, "variable set")
+		}
+	}
+
+	for _, p := range sc.properties(sc.lookup(parents)) {
+		items = append(items, CompletionItem{Label: p.name, Kind: CompletionKindField, Documentation: p.description})
+	}
+	return items
+}
+
+// valueCompletion returns the items for the value of a key that references other entities.
+func (s *Server) valueCompletion(d *document, pos Position, key string) []CompletionItem {
+	var dir string
+	switch key {
+	case "uses":
+		dir = ".cds/actions"
+	case "runs-on":
+		dir = ".cds/worker-models"
+	case "from":
+		dir = ".cds/workflow-templates"
+	case "needs":
+		if d.workflow == nil {
+			return []CompletionItem{}
+		}
+		var items []CompletionItem
+		job := d.currentJob(pos)
+		for _, n := range mapKeys(d.workflow.Jobs) {
+			if n != job {
+				items = append(items, CompletionItem{Label: n, Kind: CompletionKindField, Detail: "job"})
+			}
+		}
+		return items
+	default:
+		return []CompletionItem{}
+	}
+
+	root, err := lint.RepositoryRoot(filepath.Dir(d.path))
+	if err != nil {
+		return []CompletionItem{}
+	}
+	var items []CompletionItem
+	for _, f := range entityFiles(root, dir) {
+		rel := filepath.ToSlash(filepath.Join(dir, filepath.Base(f.path)))
+		items = append(items, CompletionItem{Label: rel, Kind: CompletionKindModule, Detail: f.name})
+	}
+	if key == "uses" && s.Index != nil {
+		for _, a := range s.Index.Actions {
+			items = append(items, CompletionItem{Label: "actions/" + a, Kind: CompletionKindModule, Detail: "action"})
+		}
+	}
+	if key == "runs-on" && s.Index != nil {
+		for _, m := range s.Index.WorkerModels {
+			items = append(items, CompletionItem{Label: m, Kind: CompletionKindModule, Detail: "worker model"})
+		}
+	}
+	return items
+}
+
+func filterCompletion(items []CompletionItem, prefix string) []CompletionItem {
+	res := make([]CompletionItem, 0, len(items))
+	for _, i := range items {
+		if strings.HasPrefix(i.Label, prefix) {
+			res = append(res, i)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Label < res[j].Label })
+	return res
+}
+
+func (s *Server) hover(d *document, pos Position) *Hover {
+	if _, ok := d.expressionAt(pos); ok {
+		word, rng := d.wordAt(pos)
+		// Only describe the path up to the hovered segment
+		end := pos.Character - rng.Start.Character
+		if idx := strings.Index(word[min(end, len(word)):], "."); idx >= 0 {
+			word = word[:end+idx]
+		}
+		if _, ok := sdk.DefaultFuncs[word]; ok {
+			return &Hover{Contents: MarkupContent{Kind: "markdown", Value: fmt.Sprintf("`%s()` function", word)}, Range: &rng}
+		}
+		sc := s.schemas.contexts
+		n := sc.lookup(strings.Split(word, "."))
+		if desc := sc.description(n); desc != "" {
+			return &Hover{Contents: MarkupContent{Kind: "markdown", Value: fmt.Sprintf("**%s**\n\n%s", word, desc)}, Range: &rng}
+		}
+		return nil
+	}
+
+	sc, ok := s.schemas.entities[d.entityType]
+	if !ok {
+		return nil
+	}
+	l := parseYAMLLine(d.line(pos.Line))
+	if !l.hasKey {
+		return nil
+	}
+	keyStart := strings.Index(d.line(pos.Line)[l.content:], l.key) + l.content
+	if pos.Character < keyStart || pos.Character > keyStart+len(l.key) {
+		// Hover on a reference to a local action
+		if l.key == "uses" {
+			return s.actionHover(d, l.value)
+		}
+		return nil
+	}
+	path, _ := d.keyPath(Position{Line: pos.Line, Character: keyStart})
+	n := sc.lookup(append(path, l.key))
+	desc := sc.description(n)
+	if desc == "" {
+		return nil
+	}
+	value := fmt.Sprintf("**%s**\n\n%s", l.key, desc)
+	if exs := sc.examples(n); len(exs) > 0 {
+		value += "\n\nExample: `" + exs[0] + "`"
+	}
+	rng := Range{Start: Position{Line: pos.Line, Character: keyStart}, End: Position{Line: pos.Line, Character: keyStart + len(l.key)}}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: value}, Range: &rng}
+}
+
+// actionHover describes a local action referenced by a step.
+func (s *Server) actionHover(d *document, ref string) *Hover {
+	loc := s.entityLocation(d, ".cds/actions", ref)
+	if loc == nil {
+		return nil
+	}
+	bts, err := os.ReadFile(uriToPath(loc.URI))
+	if err != nil {
+		return nil
+	}
+	var a sdk.V2Action
+	if err := yaml.Unmarshal(bts, &a); err != nil {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**", a.Name)
+	if a.Description != "" {
+		fmt.Fprintf(&b, "\n\n%s", a.Description)
+	}
+	if len(a.Inputs) > 0 {
+		b.WriteString("\n\nInputs:")
+		for _, n := range mapKeys(a.Inputs) {
+			fmt.Fprintf(&b, "\n- `%s`", n)
+			if desc := a.Inputs[n].Description; desc != "" {
+				fmt.Fprintf(&b, ": %s", desc)
+			}
+		}
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}}
+}
+
+func (s *Server) definition(d *document, pos Position) *Location {
+	word, _ := d.wordAt(pos)
+	if _, ok := d.expressionAt(pos); ok {
+		// jobs.<id> and needs.<id> reference jobs of the workflow
+		parts := strings.Split(word, ".")
+		if len(parts) >= 2 && (parts[0] == "jobs" || parts[0] == "needs") {
+			return d.jobLocation(parts[1])
+		}
+		return nil
+	}
+
+	path, inValue := d.keyPath(pos)
+	if !inValue && !(len(path) > 0 && path[len(path)-1] == "[]") {
+		return nil
+	}
+	key := ""
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] != "[]" {
+			key = path[i]
+			break
+		}
+	}
+	switch key {
+	case "uses":
+		return s.entityLocation(d, ".cds/actions", word)
+	case "runs-on":
+		return s.entityLocation(d, ".cds/worker-models", word)
+	case "from":
+		return s.entityLocation(d, ".cds/workflow-templates", word)
+	case "needs":
+		return d.jobLocation(word)
+	}
+	return nil
+}
+
+// jobLocation returns the location of the definition of a job in a workflow.
+func (d *document) jobLocation(job string) *Location {
+	for i := range d.lines {
+		l := parseYAMLLine(d.lines[i])
+		if !l.hasKey || l.key != job {
+			continue
+		}
+		if path, _ := d.keyPath(Position{Line: i}); len(path) == 1 && path[0] == "jobs" {
+			rng := Range{Start: Position{Line: i, Character: l.content}, End: Position{Line: i, Character: l.content + len(job)}}
+			return &Location{URI: d.uri, Range: rng}
+		}
+	}
+	return nil
+}
+
+// entityLocation resolves a reference to an entity of the repository: either the path of its
+// file or the name of an entity of the same repository. Plugins and entities of other
+// repositories can't be resolved locally.
+func (s *Server) entityLocation(d *document, dir, ref string) *Location {
+	ref = strings.Trim(ref, `"'`)
+	if ref == "" || strings.Contains(ref, "${{") {
+		return nil
+	}
+	root, err := lint.RepositoryRoot(filepath.Dir(d.path))
+	if err != nil {
+		return nil
+	}
+	if strings.HasPrefix(ref, ".cds/") {
+		p := filepath.Join(root, filepath.FromSlash(ref))
+		if _, err := os.Stat(p); err != nil {
+			return nil
+		}
+		return &Location{URI: pathToURI(p)}
+	}
+	if strings.Contains(ref, "/") || strings.Contains(ref, "@") {
+		return nil
+	}
+	for _, f := range entityFiles(root, dir) {
+		if f.name == ref {
+			return &Location{URI: pathToURI(f.path), Range: Range{Start: Position{Line: f.line}, End: Position{Line: f.line}}}
+		}
+	}
+	return nil
+}
+
+type entityFile struct {
+	path string
+	name string
+	line int
+}
+
+// entityFiles returns the names of the entities defined in a directory of the repository.
+func entityFiles(root, dir string) []entityFile {
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+	if err != nil {
+		return nil
+	}
+	var res []entityFile
+	for _, e := range entries {
+		if e.IsDir() || !(strings.HasSuffix(e.Name(), ".yml") || strings.HasSuffix(e.Name(), ".yaml")) {
+			continue
+		}
+		p := filepath.Join(root, filepath.FromSlash(dir), e.Name())
+		bts, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		for i, line := range strings.Split(string(bts), "\n") {
+			l := parseYAMLLine(line)
+			if l.hasKey && l.indent == 0 && l.key == "name" {
+				res = append(res, entityFile{path: p, name: strings.Trim(l.value, `"'`), line: i})
+			}
+		}
+	}
+	return res
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
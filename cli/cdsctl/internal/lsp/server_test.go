@@ -0,0 +1,269 @@
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testWorkflow = `name: main
+env:
+  GLOBAL: value
+jobs:
+  build:
+    runs-on: .cds/worker-models/docker.yml
+    outputs:
+      artifact:
+        value: ${{ steps.compile.outputs.path }}
+    steps:
+    - uses: greet
+      with:
+        who: world
+    - id: compile
+      run: echo ${{ git. }}
+  test:
+    needs: [build]
+    runs-on: docker
+    strategy:
+      matrix:
+        os: [linux, windows]
+    steps:
+    - run: echo ${{ jobs.build.outputs. }}
+    - run: echo ${{ needs.build.result == 'Success' && unknownFunc() }}
+    - run: echo ${{ git.ref_name == }}
+    - run: echo ${{ matrix. }} ${{ foo.bar }}
+`
+
+func writeFile(t *testing.T, dir, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+}
+
+func encode(t *testing.T, id int, method string, params interface{}) string {
+	m := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if id > 0 {
+		m["id"] = id
+	}
+	bts, err := json.Marshal(m)
+	require.NoError(t, err)
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(bts), bts)
+}
+
+type testResponse struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+func decode(t *testing.T, out []byte) []testResponse {
+	var res []testResponse
+	c := newConn(bytes.NewReader(out), nil)
+	for {
+		m, err := c.read()
+		if err != nil {
+			break
+		}
+		bts, err := json.Marshal(m)
+		require.NoError(t, err)
+		var r testResponse
+		require.NoError(t, json.Unmarshal(bts, &r))
+		res = append(res, r)
+	}
+	return res
+}
+
+func positionOf(t *testing.T, text, needle string) map[string]int {
+	for i, l := range strings.Split(text, "\n") {
+		if idx := strings.Index(l, needle); idx >= 0 {
+			return map[string]int{"line": i, "character": idx + len(needle)}
+		}
+	}
+	t.Fatalf("%q not found", needle)
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, ".cds/actions/greet.yml", `name: greet
+description: Say hello
+inputs:
+  who:
+    description: Who to greet
+runs:
+  steps:
+  - run: echo hello ${{ inputs.who }}
+`)
+	writeFile(t, dir, ".cds/worker-models/docker.yml", `name: docker
+type: docker
+osarch: linux/amd64
+spec:
+  image: debian:12
+`)
+	writeFile(t, dir, ".cds/workflows/main.yml", testWorkflow)
+	uri := pathToURI(filepath.Join(dir, ".cds/workflows/main.yml"))
+	doc := map[string]interface{}{"uri": uri}
+
+	var in strings.Builder
+	in.WriteString(encode(t, 1, "initialize", map[string]interface{}{"rootUri": pathToURI(dir)}))
+	in.WriteString(encode(t, 0, "initialized", map[string]interface{}{}))
+	in.WriteString(encode(t, 0, "textDocument/didOpen", map[string]interface{}{"textDocument": map[string]interface{}{"uri": uri, "languageId": "yaml", "version": 1, "text": testWorkflow}}))
+	in.WriteString(encode(t, 2, "textDocument/completion", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "${{ git.")}))
+	in.WriteString(encode(t, 3, "textDocument/completion", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "${{ jobs.build.outputs.")}))
+	in.WriteString(encode(t, 4, "textDocument/completion", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "${{ matrix.")}))
+	in.WriteString(encode(t, 5, "textDocument/completion", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "${{ steps.")}))
+	in.WriteString(encode(t, 6, "textDocument/definition", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "uses: gre")}))
+	in.WriteString(encode(t, 7, "textDocument/definition", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "runs-on: .cds/worker")}))
+	in.WriteString(encode(t, 8, "textDocument/definition", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "needs: [bu")}))
+	in.WriteString(encode(t, 9, "textDocument/hover", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "    runs-")}))
+	in.WriteString(encode(t, 10, "textDocument/hover", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "uses: gr")}))
+	in.WriteString(encode(t, 11, "textDocument/hover", map[string]interface{}{"textDocument": doc, "position": positionOf(t, testWorkflow, "${{ git.ref_na")}))
+	in.WriteString(encode(t, 12, "unknown/method", map[string]interface{}{}))
+	in.WriteString(encode(t, 13, "shutdown", nil))
+	in.WriteString(encode(t, 0, "exit", nil))
+
+	s, err := NewServer(nil)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, s.Serve(context.TODO(), strings.NewReader(in.String()), &out))
+
+	responses := make(map[int]testResponse)
+	var diags PublishDiagnosticsParams
+	for _, r := range decode(t, out.Bytes()) {
+		if r.Method == "textDocument/publishDiagnostics" {
+			require.NoError(t, json.Unmarshal(r.Params, &diags))
+			continue
+		}
+		responses[r.ID] = r
+	}
+	require.Len(t, responses, 13)
+
+	var init InitializeResult
+	require.NoError(t, json.Unmarshal(responses[1].Result, &init))
+	require.True(t, init.Capabilities.HoverProvider)
+	require.Equal(t, "cdsctl", init.ServerInfo.Name)
+
+	labels := func(id int) []string {
+		var list CompletionList
+		require.NoError(t, json.Unmarshal(responses[id].Result, &list))
+		var res []string
+		for _, i := range list.Items {
+			res = append(res, i.Label)
+		}
+		return res
+	}
+	require.Contains(t, labels(2), "ref_name")
+	require.Contains(t, labels(2), "sha")
+	require.Equal(t, []string{"artifact"}, labels(3))
+	require.Equal(t, []string{"os"}, labels(4))
+	require.Equal(t, []string{"compile"}, labels(5))
+
+	location := func(id int) Location {
+		var l Location
+		require.NoError(t, json.Unmarshal(responses[id].Result, &l), string(responses[id].Result))
+		return l
+	}
+	require.Equal(t, pathToURI(filepath.Join(dir, ".cds/actions/greet.yml")), location(6).URI)
+	require.Equal(t, pathToURI(filepath.Join(dir, ".cds/worker-models/docker.yml")), location(7).URI)
+	require.Equal(t, uri, location(8).URI)
+	require.Equal(t, 4, location(8).Range.Start.Line)
+
+	hover := func(id int) string {
+		var h Hover
+		require.NoError(t, json.Unmarshal(responses[id].Result, &h), string(responses[id].Result))
+		return h.Contents.Value
+	}
+	require.Contains(t, hover(9), "**runs-on**")
+	require.Contains(t, hover(10), "Say hello")
+	require.Contains(t, hover(10), "`who`: Who to greet")
+	require.Contains(t, hover(11), "**git.ref_name**")
+
+	require.NotNil(t, responses[12].Error)
+	require.Equal(t, codeMethodNotFound, responses[12].Error.Code)
+	require.Contains(t, out.String(), `{"jsonrpc":"2.0","id":13,"result":null}`)
+
+	require.Equal(t, uri, diags.URI)
+	var messages []string
+	for _, d := range diags.Diagnostics {
+		messages = append(messages, fmt.Sprintf("%d:%s", d.Range.Start.Line, d.Message))
+	}
+	t.Log(messages)
+	require.Contains(t, messages, "23:unknown function unknownFunc")
+	require.Contains(t, messages, "25:unknown context foo")
+	var invalid bool
+	for _, m := range messages {
+		if strings.HasPrefix(m, "24:invalid expression") {
+			invalid = true
+		}
+	}
+	require.True(t, invalid)
+}
+
+func TestKeyPath(t *testing.T) {
+	d := newDocument("file:///repo/.cds/workflows/main.yml", testWorkflow)
+	tests := []struct {
+		needle  string
+		path    []string
+		inValue bool
+	}{
+		{needle: "  GLO", path: []string{"env"}},
+		{needle: "runs-on: .cds", path: []string{"jobs", "build", "runs-on"}, inValue: true},
+		{needle: "uses: gr", path: []string{"jobs", "build", "steps", "[]", "uses"}, inValue: true},
+		{needle: "        who", path: []string{"jobs", "build", "steps", "[]", "with"}},
+		{needle: "  run: echo ${{ git", path: []string{"jobs", "build", "steps", "[]", "run"}, inValue: true},
+		{needle: "        os", path: []string{"jobs", "test", "strategy", "matrix"}},
+		{needle: "    - run: echo ${{ matrix", path: []string{"jobs", "test", "steps", "[]", "run"}, inValue: true},
+	}
+	for _, tt := range tests {
+		p := positionOf(t, testWorkflow, tt.needle)
+		path, inValue := d.keyPath(Position{Line: p["line"], Character: p["character"]})
+		require.Equal(t, tt.path, path, tt.needle)
+		require.Equal(t, tt.inValue, inValue, tt.needle)
+	}
+}
+
+func TestExpressionIdentifiers(t *testing.T) {
+	ids := expressionIdentifiers("${{ contains(git.branch, 'feat.x') && vars.my-set.key != null || 'it''s' == true }}")
+	require.Equal(t, []expressionIdentifier{
+		{name: "contains", offset: 4, function: true},
+		{name: "git", offset: 13},
+		{name: "vars", offset: 38},
+	}, ids)
+}
+
+func TestPositionEncoding(t *testing.T) {
+	// é is 2 bytes and 1 UTF-16 code unit, 🚀 is 4 bytes and 2 UTF-16 code units
+	d := newDocument("file:///repo/.cds/workflows/main.yml", "name: main\njobs:\n  build:\n    steps:\n    - run: echo \"é🚀\" ${{ git.ref_name }}\n")
+	line := d.line(4)
+	exprStart := strings.Index(line, "${{")
+
+	// The client counts 3 characters less than the bytes before the expression
+	pos := d.bytePosition(Position{Line: 4, Character: exprStart - 3 + len("${{ git.ref")})
+	require.Equal(t, exprStart+len("${{ git.ref"), pos.Character)
+	word, rng := d.wordAt(pos)
+	require.Equal(t, "git.ref_name", word)
+	require.Equal(t, Range{
+		Start: Position{Line: 4, Character: exprStart - 3 + len("${{ ")},
+		End:   Position{Line: 4, Character: exprStart - 3 + len("${{ git.ref_name")},
+	}, d.lspRange(rng))
+
+	require.Equal(t, Position{Line: 4, Character: len(line)}, d.bytePosition(Position{Line: 4, Character: 1000}))
+	require.Equal(t, Position{Line: 4, Character: len(line) - 3}, d.lspPosition(Position{Line: 4, Character: len(line)}))
+}
+
+func TestURIToPath(t *testing.T) {
+	require.Equal(t, filepath.FromSlash("/home/me/my repo/.cds/workflows/main.yml"), uriToPath("file:///home/me/my%20repo/.cds/workflows/main.yml"))
+	require.Equal(t, filepath.FromSlash("c:/repo/.cds/workflows/main.yml"), uriToPath("file:///c%3A/repo/.cds/workflows/main.yml"))
+	require.Equal(t, "file:///home/me/my%20repo/main.yml", pathToURI(filepath.FromSlash("/home/me/my repo/main.yml")))
+
+	p := filepath.Join(t.TempDir(), "my repo#1", "main.yml")
+	require.Equal(t, p, uriToPath(pathToURI(p)))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/cli/cdsctl/internal/lint"
+	"github.com/ovh/cds/cli/cdsctl/internal/lsp"
+)
+
+var lspCmd = cli.Command{
+	Name:  "lsp",
+	Short: "Start a language server for CDS as-code files",
+	Long: `Start a language server for the files of the .cds directory, that communicates with the editor over the standard input and output.
+
+It provides completion of YAML keys and expression contexts (git., cds., jobs.<job>.outputs...), expression diagnostics, hover documentation and go-to-definition for the uses, from and runs-on references.
+
+References to entities that are not defined in the repository are checked with the cached index of the lint command, refresh it with "cdsctl experimental workflow lint --offline --refresh-index".`,
+	Example: `Neovim:
+  vim.lsp.start({ name = "cdsctl", cmd = { "cdsctl", "lsp" }, root_dir = vim.fs.root(0, ".cds") })
+
+Emacs (eglot):
+  (add-to-list 'eglot-server-programs '(yaml-mode . ("cdsctl" "lsp")))`,
+	Flags: []cli.Flag{
+		{
+			Type:  cli.FlagString,
+			Name:  "index",
+			Usage: "Path of the cached index of remote entities",
+		},
+	},
+}
+
+func lspCommand() *cobra.Command {
+	return cli.NewCommand(lspCmd, lspRun, nil, cli.CommandWithoutExtraFlags)
+}
+
+func lspRun(v cli.Values) error {
+	// The standard output is used by the protocol
+	logrus.SetOutput(io.Discard)
+
+	indexPath := v.GetString("index")
+	if indexPath == "" {
+		indexPath = lint.DefaultIndexPath()
+	}
+	index, err := lint.LoadIndex(indexPath)
+	if err != nil {
+		return err
+	}
+
+	server, err := lsp.NewServer(index)
+	if err != nil {
+		return err
+	}
+	return server.Serve(context.Background(), os.Stdin, os.Stdout)
+}
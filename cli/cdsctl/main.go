@@ -31,6 +31,7 @@ func main() {
 		group(),
 		health(),
 		login(),
+		lspCommand(),
 		mcpCommands(),
 		reset(),
 		signup(),
@@ -75,10 +76,17 @@ func rootFromSubCommands(cmds []*cobra.Command) *cobra.Command {
 			cmd.Name() == "reset-password" ||
 			cmd.Name() == "confirm" ||
 			cmd.Name() == "version" ||
+			cmd.Name() == "exec" ||
+			cmd.Name() == "lsp" ||
 			cmd.Name() == "doc" || strings.HasPrefix(cmd.Use, "doc ") || (cmd.Run == nil && cmd.RunE == nil) {
 			return
 		}
 
+		// Offline commands don't need any configuration
+		if offline, _ := cmd.Flags().GetBool("offline"); offline {
+			return
+		}
+
 		// In non-interactive mode, only print the error without the full help text
 		noInteractive, _ := cmd.Root().PersistentFlags().GetBool("no-interactive")
 		if noInteractive {
@@ -228,7 +228,7 @@ func (p *addRunResultPlugin) perform(ctx context.Context, resultType sdk.V2Workf
 			return true, err
 		}
 	case sdk.V2WorkflowRunResultTypeCoverage:
-		if err := performGeneric(&runResult, fileInfo, sdk.V2WorkflowRunResultTypeCoverage, fileName); err != nil {
+		if err := performCoverage(ctx, &p.Common, fileInfo, &runResult, jobCtx.Integrations.ArtifactManager, repository, path, fileName); err != nil {
 			return true, err
 		}
 	case sdk.V2WorkflowRunResultTypeGeneric:
@@ -489,27 +489,7 @@ func performDocker(ctx context.Context, c *actionplugin.Common, runResult *sdk.V
 }
 
 func performTests(ctx context.Context, c *actionplugin.Common, fileInfo grpcplugins.ArtifactoryFileInfo, runResult *sdk.V2WorkflowRunResult, jobCtx sdk.JobIntegrationsContext, repository, path string) (int, error) {
-	// download file
-	downloadURI := fmt.Sprintf("%s%s/%s", jobCtx.Get(sdk.ArtifactoryConfigURL), repository, strings.TrimPrefix(path, "/"))
-	req, err := http.NewRequestWithContext(ctx, "GET", downloadURI, nil)
-	if err != nil {
-		return 0, sdk.WrapError(err, "unable to create request to retrieve file "+path)
-	}
-
-	rtToken := jobCtx.Get(sdk.ArtifactoryConfigToken)
-	req.Header.Set("Authorization", "Bearer "+rtToken)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return 0, sdk.WrapError(err, "unable to get test file: %s", path)
-	}
-
-	if resp.StatusCode > 200 {
-		return 0, sdk.Errorf("unable to download file %s (HTTP %d)", downloadURI, resp.StatusCode)
-	}
-	defer resp.Body.Close()
-
-	bts, err := io.ReadAll(resp.Body)
+	bts, err := downloadFile(ctx, c, jobCtx, repository, path)
 	if err != nil {
 		return 0, err
 	}
@@ -526,6 +506,40 @@ func performTests(ctx context.Context, c *actionplugin.Common, fileInfo grpcplug
 	return nbKo, nil
 }
 
+func performCoverage(ctx context.Context, c *actionplugin.Common, fileInfo *grpcplugins.ArtifactoryFileInfo, runResult *sdk.V2WorkflowRunResult, jobCtx sdk.JobIntegrationsContext, repository, path, fileName string) error {
+	if err := performGeneric(runResult, fileInfo, sdk.V2WorkflowRunResultTypeCoverage, fileName); err != nil {
+		return err
+	}
+	bts, err := downloadFile(ctx, c, jobCtx, repository, path)
+	if err != nil {
+		return err
+	}
+	grpcplugins.SetRunResultCoverageReport(c, runResult, path, bts)
+	return nil
+}
+
+func downloadFile(ctx context.Context, c *actionplugin.Common, jobCtx sdk.JobIntegrationsContext, repository, path string) ([]byte, error) {
+	downloadURI := fmt.Sprintf("%s%s/%s", jobCtx.Get(sdk.ArtifactoryConfigURL), repository, strings.TrimPrefix(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadURI, nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create request to retrieve file %s", path)
+	}
+
+	rtToken := jobCtx.Get(sdk.ArtifactoryConfigToken)
+	req.Header.Set("Authorization", "Bearer "+rtToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get file: %s", path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode > 200 {
+		return nil, sdk.Errorf("unable to download file %s (HTTP %d)", downloadURI, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
 func performDebian(runResult *sdk.V2WorkflowRunResult, fileInfo *grpcplugins.ArtifactoryFileInfo, props map[string][]string) error {
 	size, err := strconv.ParseInt(fileInfo.Size, 10, 64)
 	if err != nil {
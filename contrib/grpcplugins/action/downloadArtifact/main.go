@@ -46,9 +46,9 @@ func (p *runActionDownloadArtifactlugin) Stream(q *actionplugin.ActionQuery, str
 
 	name := q.GetOptions()["name"]
 	path := q.GetOptions()["path"]
-	_ = path
+	run := q.GetOptions()["run"]
 
-	if err := p.perform(ctx, name, path); err != nil {
+	if err := p.perform(ctx, name, path, run); err != nil {
 		res.Status = sdk.StatusFail
 		res.Details = err.Error()
 	}
@@ -56,10 +56,13 @@ func (p *runActionDownloadArtifactlugin) Stream(q *actionplugin.ActionQuery, str
 	return stream.Send(res)
 }
 
-func (actPlugin *runActionDownloadArtifactlugin) perform(ctx context.Context, name, path string) error {
+func (actPlugin *runActionDownloadArtifactlugin) perform(ctx context.Context, name, path, run string) error {
 	if name == "" {
 		grpcplugins.Log(&actPlugin.Common, "No artifact name specified, downloading all artifacts")
 	}
+	if run != "" {
+		grpcplugins.Logf(&actPlugin.Common, "Downloading artifacts from workflow run %q", run)
+	}
 
 	workDirs, err := grpcplugins.GetWorkerDirectories(ctx, &actPlugin.Common)
 	if err != nil {
@@ -69,7 +72,7 @@ func (actPlugin *runActionDownloadArtifactlugin) perform(ctx context.Context, na
 		return errors.New("unable to retrieve worker directories")
 	}
 
-	response, err := grpcplugins.GetV2RunResults(ctx, &actPlugin.Common, workerruntime.V2FilterRunResult{Pattern: name, Type: []sdk.V2WorkflowRunResultType{sdk.V2WorkflowRunResultTypeCoverage, sdk.V2WorkflowRunResultTypeGeneric}})
+	response, err := grpcplugins.GetV2RunResults(ctx, &actPlugin.Common, workerruntime.V2FilterRunResult{Pattern: name, Type: []sdk.V2WorkflowRunResultType{sdk.V2WorkflowRunResultTypeCoverage, sdk.V2WorkflowRunResultTypeGeneric}, Run: run})
 	if err != nil {
 		return err
 	}
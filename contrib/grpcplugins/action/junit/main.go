@@ -25,7 +25,7 @@ func (actPlugin *junitPlugin) Manifest(_ context.Context, _ *empty.Empty) (*acti
 	return &actionplugin.ActionPluginManifest{
 		Name:        "junit",
 		Author:      "Steven GUIHEUX <steven.guiheux@ovhcloud.com>",
-		Description: `This action upload and parse a junit report`,
+		Description: `This action upload and parse a tests report (JUnit, TAP, Go test2json or TRX)`,
 		Version:     sdk.VERSION,
 	}, nil
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -103,6 +104,15 @@ func (actPlugin *runActionUploadArtifactPlugin) perform(ctx context.Context, cwd
 				},
 			},
 		}
+		if runResultType == sdk.V2WorkflowRunResultTypeCoverage {
+			// Paths of the results are relative to the directory of the glob, that may not be the working directory
+			bts, err := fs.ReadFile(fileResults.DirFS, r.Path)
+			if err != nil {
+				grpcplugins.Warnf(&actPlugin.Common, "Unable to read coverage report %q: %v", r.Path, err)
+			} else {
+				grpcplugins.SetRunResultCoverageReport(&actPlugin.Common, runResultRequest.RunResult, r.Path, bts)
+			}
+		}
 		runResults[r.Path] = &runResultRequest
 	}
 
@@ -3,7 +3,6 @@ package grpcplugins
 import (
 	"context"
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
@@ -258,19 +257,13 @@ func ComputeRunResultDebianDetail(name string, size int64, md5, sha1, sha256 str
 }
 
 func ComputeRunResultTestsDetail(c *actionplugin.Common, filePath string, fileContent []byte, size int64, md5, sha1, sha256 string) (*sdk.V2WorkflowRunResultDetail, int, error) {
-	var ftests sdk.JUnitTestsSuites
-	if err := xml.Unmarshal(fileContent, &ftests); err != nil {
-		// Check if file contains testsuite only (and no testsuites)
-		var s sdk.JUnitTestSuite
-		if err := xml.Unmarshal([]byte(fileContent), &s); err != nil {
-			Error(c, fmt.Sprintf("Unable to unmarshal junit file %q: %v.", filePath, err))
-			return nil, 0, errors.New("unable to read file " + filePath)
-		}
-
-		if s.Name != "" {
-			ftests.TestSuites = append(ftests.TestSuites, s)
-		}
+	_, fileName := filepath.Split(filePath)
+	ftests, format, err := sdk.ParseTestsReport(strings.TrimSuffix(fileName, filepath.Ext(fileName)), fileContent)
+	if err != nil {
+		Error(c, fmt.Sprintf("Unable to read tests report %q: %v.", filePath, err))
+		return nil, 0, errors.New("unable to read file " + filePath)
 	}
+	Logf(c, "Tests report %q read as %s", filePath, format)
 
 	reportLogs := computeTestsReasons(ftests)
 	for _, l := range reportLogs {
@@ -280,7 +273,6 @@ func ComputeRunResultTestsDetail(c *actionplugin.Common, filePath string, fileCo
 	ftests = ftests.Trim()
 	stats := ftests.ComputeStats()
 
-	_, fileName := filepath.Split(filePath)
 	perm := os.FileMode(0755)
 
 	// Create run result at status "pending"
@@ -298,6 +290,18 @@ func ComputeRunResultTestsDetail(c *actionplugin.Common, filePath string, fileCo
 
 }
 
+// SetRunResultCoverageReport attaches the content of a coverage report to the run result, the report is parsed by the API.
+// A report bigger than sdk.CoverageReportMaxSize is uploaded as an opaque artifact.
+func SetRunResultCoverageReport(c *actionplugin.Common, runResult *sdk.V2WorkflowRunResult, filePath string, fileContent []byte) {
+	if len(fileContent) > sdk.CoverageReportMaxSize {
+		Warnf(c, "Coverage report %q is bigger than %d bytes, it will not be read", filePath, sdk.CoverageReportMaxSize)
+		return
+	}
+	detail := runResult.Detail.Data.(sdk.V2WorkflowRunResultGenericDetail)
+	detail.CoverageReportContent = string(fileContent)
+	runResult.Detail.Data = detail
+}
+
 func computeTestsReasons(s sdk.JUnitTestsSuites) []string {
 	reasons := []string{fmt.Sprintf("JUnit parser: %d testsuite(s)", len(s.TestSuites))}
 	for _, ts := range s.TestSuites {
@@ -132,6 +132,10 @@ type Configuration struct {
 			SigninEnabled bool   `toml:"signinEnabled" default:"false" json:"signinEnabled"`
 			Organization  string `toml:"organization" default:"default" comment:"Organization assigned to user created by openid authentication" json:"organization"`
 		} `toml:"oidc" json:"oidc" comment:"#######\n CDS <-> Open ID Connect Auth. Documentation on https://ovh.github.io/cds/docs/integrations/openid-connect/ \n######"`
+		TemporaryGrant struct {
+			ApproverGroup string `toml:"approverGroup" default:"" comment:"Members of this group can approve temporary permission requests. Let empty to disable temporary permissions." json:"approverGroup"`
+			MaxDuration   string `toml:"maxDuration" default:"24h" comment:"Maximum duration of a temporary permission" json:"maxDuration"`
+		} `toml:"temporaryGrant" json:"temporaryGrant" comment:"#######\n Temporary permissions requested by users and approved by the approver group \n######"`
 	} `toml:"auth" comment:"##############################\n CDS Authentication Settings# \n#############################" json:"auth"`
 	Drivers struct {
 		LDAP struct {
@@ -265,11 +269,17 @@ type Configuration struct {
 		AnalysisRetention int64  `toml:"analysisRetention" comment:"Number of analysis to keep" json:"analysisRetention" commented:"true" default:"250"`
 	} `toml:"entity" comment:"######################\n 'Entity' global configuration \n######################" json:"entity"`
 	Project struct {
-		CreationDisabled           bool   `toml:"creationDisabled" comment:"Disable project creation for CDS non admin users." json:"creationDisabled" default:"false" commented:"true"`
-		InfoCreationDisabled       string `toml:"infoCreationDisabled" comment:"Optional message to display if project creation is disabled." json:"infoCreationDisabled" default:"" commented:"true"`
-		VCSManagementDisabled      bool   `toml:"vcsManagementDisabled" comment:"Disable VCS management on project for CDS non admin users." json:"vcsManagementDisabled" default:"false" commented:"true"`
-		GPGKeyEmailAddressTemplate string `toml:"gpgKeyEmailAddressTemplate" comment:"Template for GPG Keys email address" json:"gpgKeyEmailAddressTemplate" default:"noreply+cds-{{.ProjectKey}}-{{.KeyName}}@localhost.local" commented:"true"`
+		CreationDisabled           bool              `toml:"creationDisabled" comment:"Disable project creation for CDS non admin users." json:"creationDisabled" default:"false" commented:"true"`
+		InfoCreationDisabled       string            `toml:"infoCreationDisabled" comment:"Optional message to display if project creation is disabled." json:"infoCreationDisabled" default:"" commented:"true"`
+		VCSManagementDisabled      bool              `toml:"vcsManagementDisabled" comment:"Disable VCS management on project for CDS non admin users." json:"vcsManagementDisabled" default:"false" commented:"true"`
+		GPGKeyEmailAddressTemplate string            `toml:"gpgKeyEmailAddressTemplate" comment:"Template for GPG Keys email address" json:"gpgKeyEmailAddressTemplate" default:"noreply+cds-{{.ProjectKey}}-{{.KeyName}}@localhost.local" commented:"true"`
+		ArchiveRSAPrivateKey       string            `toml:"archiveRSAPrivateKey" comment:"The RSA Private Key used to sign the project archives exported by this instance and to decrypt the secrets of the imported ones.\nProject export and import are disabled if empty." json:"-" commented:"true"`
+		ArchiveTrustedInstances    map[string]string `toml:"archiveTrustedInstances" comment:"Public keys of the CDS instances allowed to exchange project archives with this instance, by instance name.\nGet the key of an instance with 'cdsctl experimental project archive-key'" json:"-" commented:"true"`
 	} `toml:"project" comment:"######################\n 'Project' global configuration \n######################" json:"project"`
+	Audit struct {
+		Retention       int64 `toml:"retention" comment:"Retention of the audits of v2 entities (in days), set to 0 to keep them forever" json:"retention" default:"365"`
+		PurgeScheduling int64 `toml:"purgeScheduling" comment:"Time in minutes between 2 purges of the audits of v2 entities" json:"purgeScheduling" default:"60"`
+	} `toml:"audit" comment:"######################\n 'Audit' configuration of v2 entities \n######################" json:"audit"`
 	EventBus event.Config `toml:"events" comment:"######################\n Event bus configuration \n######################" json:"events" mapstructure:"events"`
 	VCS      struct {
 		GPGKeys map[string][]GPGKey `toml:"gpgKeys" comment:"map of public gpg keys from vcs server" json:"gpgKeys"`
@@ -354,6 +364,13 @@ type API struct {
 		RunResultToSynchronized    *stats.Int64Measure
 		RunResultSynchronized      *stats.Int64Measure
 		RunResultSynchronizedError *stats.Int64Measure
+		V2StepDuration             *stats.Float64Measure
+		V2StepCPU                  *stats.Float64Measure
+		V2StepMemoryPeak           *stats.Int64Measure
+		V2StepDiskRead             *stats.Int64Measure
+		V2StepDiskWrite            *stats.Int64Measure
+		V2StepNetworkReceived      *stats.Int64Measure
+		V2StepNetworkSent          *stats.Int64Measure
 	}
 	workflowRunCraftChan            chan string
 	workflowRunTriggerChan          chan sdk.V2WorkflowRunEnqueue
@@ -448,6 +465,38 @@ func (a *API) CheckConfiguration(config interface{}) error {
 		return errors.New("invalid given authentication rsa private key")
 	}
 
+	if aConfig.Auth.TemporaryGrant.MaxDuration != "" {
+		if _, err := time.ParseDuration(aConfig.Auth.TemporaryGrant.MaxDuration); err != nil {
+			return fmt.Errorf("invalid temporary grant max duration %q: %v", aConfig.Auth.TemporaryGrant.MaxDuration, err)
+		}
+	}
+
+	if aConfig.Project.ArchiveRSAPrivateKey != "" {
+		if _, err := jws.NewPrivateKeyFromPEM([]byte(aConfig.Project.ArchiveRSAPrivateKey)); err != nil {
+			return fmt.Errorf("invalid project archive private key: %v", err)
+		}
+	}
+	for name, k := range aConfig.Project.ArchiveTrustedInstances {
+		if _, err := jws.NewPublicKeyFromPEM([]byte(k)); err != nil {
+			return fmt.Errorf("invalid public key for project archive trusted instance %q: %v", name, err)
+		}
+	}
+
+	sinkNames := make(map[string]struct{}, len(aConfig.EventBus.Sinks))
+	for _, s := range aConfig.EventBus.Sinks {
+		if err := s.Check(); err != nil {
+			return err
+		}
+		if _, has := sinkNames[s.Name]; has {
+			return fmt.Errorf("invalid event sink %q: duplicate name", s.Name)
+		}
+		sinkNames[s.Name] = struct{}{}
+	}
+
+	if aConfig.Audit.Retention < 0 {
+		return fmt.Errorf("invalid audit retention %d, it must be positive", aConfig.Audit.Retention)
+	}
+
 	if len(aConfig.Auth.AllowedOrganizations) == 0 {
 		return errors.New("you must allow at least one organization in field 'allowedOrganizations'")
 	}
@@ -535,6 +584,9 @@ func (a *API) Serve(ctx context.Context) error {
 	if a.Config.WorkflowV2.VersionRetention == 0 {
 		a.Config.WorkflowV2.VersionRetention = 25
 	}
+	if a.Config.Audit.PurgeScheduling <= 0 {
+		a.Config.Audit.PurgeScheduling = 60
+	}
 	entityRetention, err := time.ParseDuration(a.Config.Entity.Retention)
 	if err != nil {
 		return sdk.WrapError(err, "wrong entity retention %s, bad format.", a.Config.Entity.Retention)
@@ -759,6 +811,9 @@ func (a *API) Serve(ctx context.Context) error {
 	migrate.Add(ctx, sdk.Migration{Name: "MigrationRunWithActions", Release: "0.56.0", Blocker: false, Automatic: true, ExecFunc: func(ctx context.Context) error {
 		return migrate.MigrationRunWithActions(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper)())
 	}})
+	migrate.Add(ctx, sdk.Migration{Name: "MigrateRBACWorkflowApprove", Release: "0.56.0", Blocker: true, Automatic: true, ExecFunc: func(ctx context.Context) error {
+		return migrate.MigrateRBACWorkflowApprove(ctx, a.DBConnectionFactory.GetDBMap(gorpmapping.Mapper)())
+	}})
 
 	isFreshInstall, err := version.IsFreshInstall(a.mustDB())
 	if err != nil {
@@ -921,6 +976,11 @@ func (a *API) Serve(ctx context.Context) error {
 		event.DequeueEvent(ctx, a.mustDB())
 	})
 
+	if err := event_v2.InitializeSinks(ctx, a.Config.EventBus.Sinks, a.Config.URL.API); err != nil {
+		return sdk.WrapError(err, "unable to initialize event sinks")
+	}
+	event_v2.StartSinks(ctx, a.Cache, a.GoRoutines)
+
 	a.GoRoutines.RunWithRestart(ctx, "event_v2.dequeue", func(ctx context.Context) {
 		event_v2.Dequeue(ctx, a.mustDB(), a.Cache, a.GoRoutines, a.Config.URL.UI)
 	})
@@ -998,6 +1058,9 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.RunWithRestart(ctx, "api.TriggerBlockedWorkflowRuns", func(ctx context.Context) {
 		a.TriggerBlockedWorkflowRuns(ctx)
 	})
+	a.GoRoutines.RunWithRestart(ctx, "api.StopSharedConcurrencyTimeouts", func(ctx context.Context) {
+		a.StopSharedConcurrencyTimeouts(ctx)
+	})
 	a.GoRoutines.RunWithRestart(ctx, "api.CancelAbandonnedRunResults", func(ctx context.Context) {
 		a.CancelAbandonnedRunResults(ctx)
 	})
@@ -1005,6 +1068,9 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.RunWithRestart(ctx, "api.repositoryAnalysisPoller", func(ctx context.Context) {
 		a.repositoryAnalysisPoller(ctx, 5*time.Second)
 	})
+	a.GoRoutines.RunWithRestart(ctx, "api.mergeQueueProcessor", func(ctx context.Context) {
+		a.mergeQueueProcessor(ctx, 10*time.Second)
+	})
 	a.GoRoutines.RunWithRestart(ctx, "api.cleanRepositoryAnalysis", func(ctx context.Context) {
 		a.cleanRepositoryAnalysis(ctx, 1*time.Hour)
 	})
@@ -1024,6 +1090,12 @@ func (a *API) Serve(ctx context.Context) error {
 	a.GoRoutines.RunWithRestart(ctx, "worker.DisabledDeadWorkers", func(ctx context.Context) {
 		DisabledDeadWorkers(ctx, a.Cache, a.mustDB)
 	})
+	a.GoRoutines.RunWithRestart(ctx, "rbac.ExpireGrants", func(ctx context.Context) {
+		a.expireRBACGrants(ctx)
+	})
+	a.GoRoutines.RunWithRestart(ctx, "audit_v2.Purge", func(ctx context.Context) {
+		a.purgeAuditsV2(ctx)
+	})
 	if a.Config.Secrets.SnapshotRetentionDelay > 0 {
 		a.GoRoutines.RunWithRestart(ctx, "workflow.CleanSecretsSnapshot", func(ctx context.Context) {
 			a.cleanWorkflowRunSecrets(ctx)
@@ -1131,6 +1203,7 @@ func (a *API) Serve(ctx context.Context) error {
 		a.DBConnectionFactory.Close() // nolint
 		event.Publish(ctx, sdk.EventEngine{Message: "shutdown"}, nil)
 		event.Close(ctx)
+		event_v2.CloseSinks()
 	}()
 
 	event.Publish(ctx, sdk.EventEngine{Message: fmt.Sprintf("started - listen on %d", a.Config.HTTP.Port)}, nil)
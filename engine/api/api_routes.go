@@ -459,7 +459,9 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/hooks/event/signKey", Scope(sdk.AuthConsumerScopeHooks), r.POSTv2(api.postHookEventRetrieveSignKeyHandler))
 	r.Handle("/v2/hooks/event/signKey/{uuid}", Scope(sdk.AuthConsumerScopeHooks), r.GETv2(api.getRetrieveSignKeyOperationHandler))
 	r.Handle("/v2/hooks/event/user", Scope(sdk.AuthConsumerScopeHooks), r.POSTv2(api.postRetrieveEventUserHandler))
+	r.Handle("/v2/hooks/repositories/polling", Scope(sdk.AuthConsumerScopeHooks), r.GETv2(api.getHooksPollingRepositoriesHandler))
 	r.Handle("/v2/hooks/repositories/{vcsServer}/{repositoryName}", Scope(sdk.AuthConsumerScopeHooks), r.GETv2(api.getHooksRepositoriesHandler))
+	r.Handle("/v2/hooks/project/{projectKey}/vcs/{vcsServer}/repository/{repositoryName}/refs", Scope(sdk.AuthConsumerScopeHooks), r.GETv2(api.getHooksRepositoryRefsHandler))
 	r.Handle("/v2/hooks/{projectKey}/vcs/{vcsServer}/repository/{repositoryName}/insight/{commit}/{insightKey}", Scope(sdk.AuthConsumerScopeHooks), r.POSTv2(api.postInsightReportHandler))
 	r.Handle("/v2/hooks/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/run", Scope(sdk.AuthConsumerScopeHooks), r.POSTv2(api.postWorkflowRunFromHookV2Handler))
 
@@ -467,14 +469,32 @@ func (api *API) InitRouter() {
 
 	r.Handle("/v2/organization", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postOrganizationHandler), r.GETv2(api.getOrganizationsHandler))
 	r.Handle("/v2/organization/{organizationIdentifier}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getOrganizationHandler), r.DELETEv2(api.deleteOrganizationHandler))
+	r.Handle("/v2/organization/{organizationIdentifier}/concurrency", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getSharedConcurrenciesHandler), r.POSTv2(api.postSharedConcurrencyHandler))
+	r.Handle("/v2/organization/{organizationIdentifier}/concurrency/{concurrencyName}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getSharedConcurrencyHandler), r.PUTv2(api.putSharedConcurrencyHandler), r.DELETEv2(api.deleteSharedConcurrencyHandler))
+	r.Handle("/v2/organization/{organizationIdentifier}/concurrency/{concurrencyName}/runs", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getSharedConcurrencyRunsHandler))
+	r.Handle("/v2/organization/{organizationIdentifier}/concurrency/{concurrencyName}/release", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postSharedConcurrencyReleaseHandler))
+	r.Handle("/v2/organization/{organizationIdentifier}/policy", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getOrganizationPoliciesHandler), r.POSTv2(api.postOrganizationPolicyHandler))
+	r.Handle("/v2/organization/{organizationIdentifier}/policy/{policyName}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getOrganizationPolicyHandler), r.PUTv2(api.putOrganizationPolicyHandler), r.DELETEv2(api.deleteOrganizationPolicyHandler))
+
+	r.Handle("/v2/audit", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getAuditsV2Handler))
 
 	r.Handle("/v2/rbac", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getPermissionsHandler))
 	r.Handle("/v2/rbac/import", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postImportRBACHandler))
+	r.Handle("/v2/rbac/grant", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getRBACGrantsHandler), r.POSTv2(api.postRBACGrantHandler))
+	r.Handle("/v2/rbac/grant/{grantID}", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getRBACGrantHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/audit", Scope(sdk.AuthConsumerScopeUser), r.GETv2(api.getRBACGrantAuditsHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/approve", Scope(sdk.AuthConsumerScopeUser), r.POSTv2(api.postRBACGrantApproveHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/reject", Scope(sdk.AuthConsumerScopeUser), r.POSTv2(api.postRBACGrantRejectHandler))
+	r.Handle("/v2/rbac/grant/{grantID}/revoke", Scope(sdk.AuthConsumerScopeUser), r.POSTv2(api.postRBACGrantRevokeHandler))
 	r.Handle("/v2/rbac/{rbacIdentifier}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getRBACHandler), r.DELETEv2(api.deleteRBACHandler))
 	r.Handle("/v2/rbac/access/project/session/check", ScopeNone(), r.POSTv2(api.getCheckSessionProjectAccessHandler))
 
 	r.Handle("/v2/region", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postRegionHandler), r.GETv2(api.getRegionsHandler))
 	r.Handle("/v2/region/{regionIdentifier}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getRegionHandler), r.DELETEv2(api.deleteRegionHandler))
+	r.Handle("/v2/region/{regionIdentifier}/concurrency", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getSharedConcurrenciesHandler), r.POSTv2(api.postSharedConcurrencyHandler))
+	r.Handle("/v2/region/{regionIdentifier}/concurrency/{concurrencyName}", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getSharedConcurrencyHandler), r.PUTv2(api.putSharedConcurrencyHandler), r.DELETEv2(api.deleteSharedConcurrencyHandler))
+	r.Handle("/v2/region/{regionIdentifier}/concurrency/{concurrencyName}/runs", Scope(sdk.AuthConsumerScopeAdmin), r.GETv2(api.getSharedConcurrencyRunsHandler))
+	r.Handle("/v2/region/{regionIdentifier}/concurrency/{concurrencyName}/release", Scope(sdk.AuthConsumerScopeAdmin), r.POSTv2(api.postSharedConcurrencyReleaseHandler))
 
 	r.Handle("/v2/migrate/project/{projectKey}/variableset/item", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postMigrateProjectVariableHandler))
 	r.Handle("/v2/migrate/project/{projectKey}/variableset/application", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postMigrateApplicationVariableToVariableSetHandler))
@@ -482,11 +502,18 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/migrate/project/{projectKey}/variableset/environment", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postMigrateEnvironmentVariableToVariableSetHandler))
 	r.Handle("/v2/migrate/project/{projectKey}/variableset/ascode", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postMigrateAsCodeVariableToVariableSetItemHandler))
 	r.Handle("/v2/project", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectsV2Handler))
+	r.Handle("/v2/project/archive/key", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectArchiveKeyHandler))
 	r.Handle("/v2/project/{projectKey}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectV2Handler), r.PUTv2(api.updateProjectV2Handler), r.DELETEv2(api.deleteProjectV2Handler))
+	r.Handle("/v2/project/{projectKey}/export", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postProjectExportHandler))
+	r.Handle("/v2/project/{projectKey}/import", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postProjectImportHandler))
+	r.Handle("/v2/project/{projectKey}/audit", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectAuditsV2Handler))
 
 	r.Handle("/v2/project/{projectKey}/concurrency", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectConcurrenciesHandler), r.POSTv2(api.postProjectConcurrencyHandler))
 	r.Handle("/v2/project/{projectKey}/concurrency/{concurrencyName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectConcurrencyHandler), r.PUTv2(api.putProjectConcurrencyHandler), r.DELETEv2(api.deleteProjectConcurrencyHandler))
 	r.Handle("/v2/project/{projectKey}/concurrency/{concurrencyName}/runs", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectConcurrencyRunsHandler))
+	r.Handle("/v2/project/{projectKey}/policy", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectPoliciesHandler), r.POSTv2(api.postProjectPolicyHandler))
+	r.Handle("/v2/project/{projectKey}/policy/{policyName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectPolicyHandler), r.PUTv2(api.putProjectPolicyHandler), r.DELETEv2(api.deleteProjectPolicyHandler))
+	r.Handle("/v2/project/{projectKey}/template/instance", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowTemplateInstancesHandler))
 	r.Handle("/v2/project/{projectKey}/hook", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectHooksHandler), r.POSTv2(api.postProjectHookHandler))
 	r.Handle("/v2/project/{projectKey}/hook/{uuid}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectHookHandler), r.DELETEv2(api.deleteProjectHookHandler))
 
@@ -522,10 +549,17 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/entities/{entityType}/{entityName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectEntityHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/events", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectRepositoryEventsHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/events/{eventID}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectRepositoryEventHandler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/mergequeue", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getMergeQueueHandler), r.POSTv2(api.postMergeQueueEntryHandler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/mergequeue/{entryID}", Scope(sdk.AuthConsumerScopeProject), r.DELETEv2(api.deleteMergeQueueEntryHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workermodel", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkerModelsV2Handler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workermodel/{workerModelName}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkerModelV2Handler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/run", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postWorkflowRunV2Handler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/test/flaky", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowFlakyTestCasesHandler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/coverage", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowCoverageTrendHandler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/test/history", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowTestCaseHistoryHandler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/test/slowest", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowSlowestTestCasesHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/version", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowVersionsHandler))
+	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/schedule", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowSchedulesHandler))
 	r.Handle("/v2/project/{projectKey}/vcs/{vcsIdentifier}/repository/{repositoryIdentifier}/workflow/{workflow}/version/{version}", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getWorkflowVersionHandler), r.DELETEv2(api.deleteWorkflowVersionHandler))
 	r.Handle("/v2/project/{projectKey}/run", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunsSearchV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/filter", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunsFiltersV2Handler))
@@ -536,15 +570,19 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/project/{projectKey}/run-filter", Scope(sdk.AuthConsumerScopeProject), r.GETv2(api.getProjectRunFiltersHandler), r.POSTv2(api.postProjectRunFilterHandler))
 	r.Handle("/v2/project/{projectKey}/run-filter/{filterName}", Scope(sdk.AuthConsumerScopeProject), r.PUTv2(api.putProjectRunFilterHandler), r.DELETEv2(api.deleteProjectRunFilterHandler))
 	r.Handle("/v2/project/{projectKey}/run/retention/schema", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunRetentionSchemaHandler))
+	r.Handle("/v2/project/{projectKey}/rbac/workflow/check", Scope(sdk.AuthConsumerScopeProject), r.POSTv2(api.postWorkflowRBACCheckHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunV2Handler), r.DELETEv2(api.deleteWorkflowRunV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/restart", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postRestartWorkflowRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/infos", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunInfoV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postStopWorkflowRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobsV2Handler), r.POSTv2(api.postStartJobWorkflowRunHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/result", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunResultsV2Handler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/test", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunTestCasesV2Handler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/coverage", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunCoverageV2Handler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/retry", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobRetryHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/infos", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobInfosHandler))
+	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/debug", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobDebugSessionHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobIdentifier}/run", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postRunJobHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobIdentifier}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTv2(api.postStopJobHandler))
 	r.Handle("/v2/project/{projectKey}/run/{workflowRunID}/job/{jobRunID}/logs/links", Scope(sdk.AuthConsumerScopeRun), r.GETv2(api.getWorkflowRunJobLogsLinksV2Handler))
@@ -569,7 +607,11 @@ func (api *API) InitRouter() {
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/result", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobResultHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunResultsHandler), r.POSTv2(api.postJobRunResultHandler), r.PUTv2(api.putJobRunResultHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult/synchronize", Scope(sdk.AuthConsumerScopeRunExecution), r.PUTv2(api.putJobRunResultSynchronizeHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult/external", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobExternalRunResultsHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult/external/{workflowRunID}/access", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobExternalRunResultAccessHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/runresult/{runResultID}", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunResultHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/annotations", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTv2(api.postJobRunAnnotationsHandler))
+	r.Handle("/v2/queue/{regionName}/job/{runJobID}/debug", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobRunDebugSessionHandler))
 	r.Handle("/v2/queue/{regionName}/job/{runJobID}/cache/{cacheKey}/link", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getCacheLinkHandler))
 
 	r.Handle("/v2/queue/{regionName}", Scope(sdk.AuthConsumerScopeRunExecution), r.GETv2(api.getJobsQueuedRegionalizedHandler))
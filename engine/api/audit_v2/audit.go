@@ -0,0 +1,77 @@
+package audit_v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+// Change describes a change on an audited entity. Before is nil for a creation and After is nil for a deletion.
+type Change struct {
+	EventType  sdk.EventType
+	ProjectKey string
+	EntityName string
+	Before     interface{}
+	After      interface{}
+	UserID     string
+	Username   string
+}
+
+// Record stores the audit of a change. It must be called in the transaction of the change,
+// with the version of the entity loaded from the database before the change.
+// Changes on entities that are not audited are ignored.
+func Record(ctx context.Context, db gorp.SqlExecutor, c Change) error {
+	entityType := c.EventType.AuditEntityType()
+	if entityType == "" {
+		return nil
+	}
+
+	before, err := newAuditData(c.Before)
+	if err != nil {
+		return sdk.WrapError(err, "unable to compute audit for %s %s", entityType, c.EntityName)
+	}
+	var after sdk.AuditV2Data
+	if !c.EventType.IsDeletion() {
+		after, err = newAuditData(c.After)
+		if err != nil {
+			return sdk.WrapError(err, "unable to compute audit for %s %s", entityType, c.EntityName)
+		}
+	}
+
+	consumerID, _ := ctx.Value(cdslog.AuthConsumerID).(string)
+	ipAddress, _ := ctx.Value(cdslog.IPAddress).(string)
+
+	a := sdk.AuditV2{
+		AuditCommon: sdk.AuditCommon{
+			EventType:   string(c.EventType),
+			Created:     time.Now(),
+			TriggeredBy: c.Username,
+		},
+		UserID:     c.UserID,
+		ConsumerID: consumerID,
+		IPAddress:  ipAddress,
+		ProjectKey: c.ProjectKey,
+		EntityType: entityType,
+		EntityName: c.EntityName,
+		Before:     before,
+		After:      after,
+		Diff:       sdk.ComputeAuditV2Diff(before, after),
+	}
+	return Insert(db, &a)
+}
+
+func newAuditData(v interface{}) (sdk.AuditV2Data, error) {
+	if v == nil {
+		return nil, nil
+	}
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return sdk.NewAuditV2Data(bts)
+}
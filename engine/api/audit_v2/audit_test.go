@@ -0,0 +1,79 @@
+package audit_v2_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/audit_v2"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+func TestRecordAndLoadAudits(t *testing.T) {
+	db, _ := test.SetupPG(t)
+	ctx := context.WithValue(context.TODO(), cdslog.IPAddress, "127.0.0.1")
+
+	projectKey := sdk.RandomString(10)
+	userID := sdk.UUID()
+	newChange := func(eventType sdk.EventType, before, after *sdk.ProjectVariableSetItem) audit_v2.Change {
+		return audit_v2.Change{
+			EventType:  eventType,
+			ProjectKey: projectKey,
+			EntityName: "vs/password",
+			Before:     before,
+			After:      after,
+			UserID:     userID,
+			Username:   "john",
+		}
+	}
+
+	created := sdk.ProjectVariableSetItem{Name: "password", Type: sdk.ProjectVariableTypeSecret, Value: "secret-1"}
+	updated := created
+	updated.Value = "secret-2"
+	updated.Type = sdk.ProjectVariableTypeString
+	require.NoError(t, audit_v2.Record(ctx, db, newChange(sdk.EventVariableSetItemCreated, nil, &created)))
+	require.NoError(t, audit_v2.Record(ctx, db, newChange(sdk.EventVariableSetItemUpdated, &created, &updated)))
+	require.NoError(t, audit_v2.Record(ctx, db, newChange(sdk.EventVariableSetItemDeleted, &updated, nil)))
+	// Run events are not audited
+	require.NoError(t, audit_v2.Record(ctx, db, audit_v2.Change{EventType: sdk.EventRunEnded, ProjectKey: projectKey}))
+
+	audits, err := audit_v2.LoadAll(ctx, db, sdk.AuditV2Filter{ProjectKey: projectKey})
+	require.NoError(t, err)
+	require.Len(t, audits, 3)
+
+	deletedAudit, updatedAudit, createdAudit := audits[0], audits[1], audits[2]
+	require.Equal(t, string(sdk.EventVariableSetItemCreated), createdAudit.EventType)
+	require.Equal(t, sdk.AuditV2EntityVariableSetItem, createdAudit.EntityType)
+	require.Equal(t, "vs/password", createdAudit.EntityName)
+	require.Equal(t, "john", createdAudit.TriggeredBy)
+	require.Equal(t, userID, createdAudit.UserID)
+	require.Equal(t, "127.0.0.1", createdAudit.IPAddress)
+	require.Nil(t, createdAudit.Before)
+	require.Equal(t, sdk.PasswordPlaceholder, createdAudit.After["value"])
+
+	require.Equal(t, sdk.PasswordPlaceholder, updatedAudit.Before["value"])
+	require.Equal(t, "secret-2", updatedAudit.After["value"])
+	require.Contains(t, updatedAudit.Diff, sdk.AuditV2Change{Path: "type", Before: "secret", After: "string"})
+
+	require.Equal(t, "secret-2", deletedAudit.Before["value"])
+	require.Nil(t, deletedAudit.After)
+
+	audits, err = audit_v2.LoadAll(ctx, db, sdk.AuditV2Filter{ProjectKey: projectKey, EventType: string(sdk.EventVariableSetItemUpdated)})
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+
+	audits, err = audit_v2.LoadAll(ctx, db, sdk.AuditV2Filter{ProjectKey: projectKey, Limit: 1, Offset: 2})
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	require.Equal(t, createdAudit.ID, audits[0].ID)
+
+	_, err = audit_v2.DeleteOlderThan(db, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	audits, err = audit_v2.LoadAll(ctx, db, sdk.AuditV2Filter{ProjectKey: projectKey})
+	require.NoError(t, err)
+	require.Empty(t, audits)
+}
@@ -0,0 +1,84 @@
+package audit_v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultLimit = 50
+
+// Insert stores a new audit
+func Insert(db gorp.SqlExecutor, a *sdk.AuditV2) error {
+	if a.Created.IsZero() {
+		a.Created = time.Now()
+	}
+	return sdk.WrapError(gorpmapping.Insert(db, a), "unable to insert audit for %s %s", a.EntityType, a.EntityName)
+}
+
+// LoadAll loads the audits matching the filter, most recent first
+func LoadAll(ctx context.Context, db gorp.SqlExecutor, filter sdk.AuditV2Filter) ([]sdk.AuditV2, error) {
+	var clauses []string
+	var args []interface{}
+	addClause := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		clauses = append(clauses, fmt.Sprintf(clause, len(args)))
+	}
+	if filter.ProjectKey != "" {
+		addClause("project_key = $%d", filter.ProjectKey)
+	}
+	if filter.Username != "" {
+		addClause("triggered_by = $%d", filter.Username)
+	}
+	if filter.ConsumerID != "" {
+		addClause("consumer_id = $%d", filter.ConsumerID)
+	}
+	if filter.EntityType != "" {
+		addClause("entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityName != "" {
+		addClause("entity_name = $%d", filter.EntityName)
+	}
+	if filter.EventType != "" {
+		addClause("event_type = $%d", filter.EventType)
+	}
+	if !filter.Since.IsZero() {
+		addClause("created >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		addClause("created <= $%d", filter.Until)
+	}
+
+	query := "SELECT * FROM audit_v2"
+	if len(clauses) > 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	args = append(args, limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	var audits []sdk.AuditV2
+	if err := gorpmapping.GetAll(ctx, db, gorpmapping.NewQuery(query).Args(args...), &audits); err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+// DeleteOlderThan deletes the audits created before the given date, it returns the number of deleted audits
+func DeleteOlderThan(db gorp.SqlExecutor, before time.Time) (int64, error) {
+	res, err := db.Exec(`DELETE FROM audit_v2 WHERE created < $1`, before)
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	n, err := res.RowsAffected()
+	return n, sdk.WithStack(err)
+}
@@ -0,0 +1,10 @@
+package audit_v2
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(sdk.AuditV2{}, "audit_v2", true, "id"))
+}
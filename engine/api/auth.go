@@ -316,6 +316,11 @@ func (api *API) postAuthSigninHandler() service.Handler {
 			return err
 		}
 
+		if userCreated {
+			if err := recordUserAudit(ctx, tx, usr, sdk.EventUserCreated, "", usr.Username, nil, usr); err != nil {
+				return err
+			}
+		}
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
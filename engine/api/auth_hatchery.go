@@ -121,9 +121,13 @@ func (api *API) postAuthHatcherySigninHandler() ([]service.RbacChecker, service.
 				Region:   reg.Name,
 			}
 
+			before := *h
 			if err := api.hatcheryRegister(ctx, tx, *consumer, session.ID, h, req); err != nil {
 				return err
 			}
+			if err := recordUserAudit(ctx, tx, nil, sdk.EventHatcheryUpdated, "", h.Name, before, h); err != nil {
+				return err
+			}
 
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
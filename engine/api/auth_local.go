@@ -415,6 +415,9 @@ func (api *API) postAuthLocalVerifyHandler() service.Handler {
 			return err
 		}
 
+		if err := recordUserAudit(ctx, tx, usr, sdk.EventUserCreated, "", usr.Username, nil, usr); err != nil {
+			return err
+		}
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -0,0 +1,128 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+// tableAndColumn returns the table and the scope column used to store concurrencies of the given scope
+func tableAndColumn(scope sdk.V2RunJobConcurrencyScope) (string, string, error) {
+	switch scope {
+	case sdk.V2RunConcurrencyScopeOrganization:
+		return "organization_concurrency", "organization_id", nil
+	case sdk.V2RunConcurrencyScopeRegion:
+		return "region_concurrency", "region_id", nil
+	}
+	return "", "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid concurrency scope %q", scope)
+}
+
+func Insert(ctx context.Context, db gorpmapper.SqlExecutorWithTx, c *sdk.SharedConcurrency) error {
+	c.ID = sdk.UUID()
+	c.LastModified = time.Now()
+	switch c.Scope {
+	case sdk.V2RunConcurrencyScopeOrganization:
+		dbData := &dbOrganizationConcurrency{SharedConcurrency: *c, OrganizationID: c.ScopeID}
+		if err := gorpmapping.Insert(db, dbData); err != nil {
+			return err
+		}
+		*c = dbData.concurrency()
+	case sdk.V2RunConcurrencyScopeRegion:
+		dbData := &dbRegionConcurrency{SharedConcurrency: *c, RegionID: c.ScopeID}
+		if err := gorpmapping.Insert(db, dbData); err != nil {
+			return err
+		}
+		*c = dbData.concurrency()
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid concurrency scope %q", c.Scope)
+	}
+	return nil
+}
+
+func Update(ctx context.Context, db gorpmapper.SqlExecutorWithTx, c *sdk.SharedConcurrency) error {
+	c.LastModified = time.Now()
+	switch c.Scope {
+	case sdk.V2RunConcurrencyScopeOrganization:
+		dbData := &dbOrganizationConcurrency{SharedConcurrency: *c, OrganizationID: c.ScopeID}
+		if err := gorpmapping.Update(db, dbData); err != nil {
+			return err
+		}
+		*c = dbData.concurrency()
+	case sdk.V2RunConcurrencyScopeRegion:
+		dbData := &dbRegionConcurrency{SharedConcurrency: *c, RegionID: c.ScopeID}
+		if err := gorpmapping.Update(db, dbData); err != nil {
+			return err
+		}
+		*c = dbData.concurrency()
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid concurrency scope %q", c.Scope)
+	}
+	return nil
+}
+
+func Delete(db gorpmapper.SqlExecutorWithTx, scope sdk.V2RunJobConcurrencyScope, scopeID string, concurrencyID string) error {
+	table, column, err := tableAndColumn(scope)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND %s = $2", table, column), concurrencyID, scopeID)
+	return sdk.WrapError(err, "cannot delete %s %s / %s", table, scopeID, concurrencyID)
+}
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, query gorpmapping.Query) ([]sdk.SharedConcurrency, error) {
+	var concurrencies []sdk.SharedConcurrency
+	switch scope {
+	case sdk.V2RunConcurrencyScopeOrganization:
+		var res []dbOrganizationConcurrency
+		if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+			return nil, err
+		}
+		concurrencies = make([]sdk.SharedConcurrency, 0, len(res))
+		for _, r := range res {
+			concurrencies = append(concurrencies, r.concurrency())
+		}
+	case sdk.V2RunConcurrencyScopeRegion:
+		var res []dbRegionConcurrency
+		if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+			return nil, err
+		}
+		concurrencies = make([]sdk.SharedConcurrency, 0, len(res))
+		for _, r := range res {
+			concurrencies = append(concurrencies, r.concurrency())
+		}
+	}
+	return concurrencies, nil
+}
+
+// LoadByName loads a concurrency of an organization or a region
+func LoadByName(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, scopeID string, name string) (*sdk.SharedConcurrency, error) {
+	table, column, err := tableAndColumn(scope)
+	if err != nil {
+		return nil, err
+	}
+	query := gorpmapping.NewQuery(fmt.Sprintf(`SELECT %s.* FROM %s WHERE %s = $1 AND name = $2`, table, table, column)).Args(scopeID, name)
+	concurrencies, err := getAll(ctx, db, scope, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(concurrencies) == 0 {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &concurrencies[0], nil
+}
+
+// LoadAll loads all the concurrencies of an organization or a region
+func LoadAll(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, scopeID string) ([]sdk.SharedConcurrency, error) {
+	table, column, err := tableAndColumn(scope)
+	if err != nil {
+		return nil, err
+	}
+	query := gorpmapping.NewQuery(fmt.Sprintf(`SELECT %s.* FROM %s WHERE %s = $1 ORDER BY name`, table, table, column)).Args(scopeID)
+	return getAll(ctx, db, scope, query)
+}
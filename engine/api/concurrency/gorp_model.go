@@ -0,0 +1,35 @@
+package concurrency
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbOrganizationConcurrency{}, "organization_concurrency", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbRegionConcurrency{}, "region_concurrency", false, "id"))
+}
+
+type dbOrganizationConcurrency struct {
+	sdk.SharedConcurrency
+	OrganizationID string `db:"organization_id"`
+}
+
+func (c dbOrganizationConcurrency) concurrency() sdk.SharedConcurrency {
+	res := c.SharedConcurrency
+	res.Scope = sdk.V2RunConcurrencyScopeOrganization
+	res.ScopeID = c.OrganizationID
+	return res
+}
+
+type dbRegionConcurrency struct {
+	sdk.SharedConcurrency
+	RegionID string `db:"region_id"`
+}
+
+func (c dbRegionConcurrency) concurrency() sdk.SharedConcurrency {
+	res := c.SharedConcurrency
+	res.Scope = sdk.V2RunConcurrencyScopeRegion
+	res.ScopeID = c.RegionID
+	return res
+}
@@ -150,6 +150,19 @@ func LoadHeadEntityByRefTypeName(ctx context.Context, db gorp.SqlExecutor, proje
 	return getEntity(ctx, db, query, opts...)
 }
 
+// LoadTagRefsByTypeName returns all the tags where an entity is defined
+func LoadTagRefsByTypeName(ctx context.Context, db gorp.SqlExecutor, projectRepositoryID string, entityType string, name string) ([]string, error) {
+	_, next := telemetry.Span(ctx, "entity.LoadTagRefsByTypeName")
+	defer next()
+	var refs []string
+	if _, err := db.Select(&refs, `
+		SELECT DISTINCT ref from entity
+		WHERE project_repository_id = $1 AND type = $2 AND name = $3 AND head = true AND ref LIKE 'refs/tags/%'`, projectRepositoryID, entityType, name); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return refs, nil
+}
+
 // LoadByRefTypeNameCommit loads an entity by its repository, ref, type, name and commit
 func LoadByRefTypeNameCommit(ctx context.Context, db gorp.SqlExecutor, projectRepositoryID string, ref string, entityType string, name string, commit string, opts ...gorpmapping.GetOptionFunc) (*sdk.Entity, error) {
 	ctx, next := telemetry.Span(ctx, "entity.LoadByRefTypeNameCommit")
@@ -8,6 +8,7 @@ import (
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/entity"
 	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/repository"
@@ -234,7 +235,7 @@ func (ef *EntityFinder) searchEntity(ctx context.Context, db gorp.SqlExecutor, s
 				ref = defaultCache
 			} else {
 				// Get default branch
-				client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, projKey, entityVCS.Name)
+				client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, projKey, entityVCS.Name, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return nil, "", err
 				}
@@ -248,9 +249,23 @@ func (ef *EntityFinder) searchEntity(ctx context.Context, db gorp.SqlExecutor, s
 		}
 	} else if strings.HasPrefix(branchOrTag, sdk.GitRefBranchPrefix) || strings.HasPrefix(branchOrTag, sdk.GitRefTagPrefix) {
 		ref = branchOrTag
+	} else if sdk.IsSemverConstraint(branchOrTag) {
+		// Select the highest tag matching the version constraint
+		tags, err := entity.LoadTagRefsByTypeName(ctx, db, entityRepo.ID, entityType, entityName)
+		if err != nil {
+			return nil, "", err
+		}
+		t, err := sdk.ResolveSemverConstraint(branchOrTag, tags)
+		if err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return nil, "", err
+			}
+			return nil, fmt.Sprintf("unable to find a version of %s matching %s for repository %s on vcs %s", entityName, branchOrTag, repoName, vcsName), nil
+		}
+		ref = t
 	} else {
 		// Need to known if branchOrTag is a tag or a branch
-		client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, projKey, entityVCS.Name)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, projKey, entityVCS.Name, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return nil, "", err
 		}
@@ -303,7 +303,7 @@ func (api *API) updateAsCodeEnvironmentHandler() service.Handler {
 			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find the root application of the workflow %s that hold the pipeline", wkHolder.Name)
 		}
 
-		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, key, rootApp.VCSServer)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, key, rootApp.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "updateAsCodeEnvironmentHandler> Cannot get client got %s %s : %v", key, rootApp.VCSServer, err)
 		}
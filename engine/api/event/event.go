@@ -21,8 +21,9 @@ import (
 )
 
 type Config struct {
-	GlobalKafka     event.KafkaConfig `toml:"globalKafka" json:"globalKafka" mapstructure:"globalKafka"`
-	JobSummaryKafka event.KafkaConfig `toml:"jobSummaryKafka" json:"jobSummaryKafka" mapstructure:"jobSummaryKafka"`
+	GlobalKafka     event.KafkaConfig  `toml:"globalKafka" json:"globalKafka" mapstructure:"globalKafka"`
+	JobSummaryKafka event.KafkaConfig  `toml:"jobSummaryKafka" json:"jobSummaryKafka" mapstructure:"jobSummaryKafka"`
+	Sinks           []event.SinkConfig `toml:"sinks" comment:"External sinks receiving the v2 events encoded as CloudEvents" json:"sinks" mapstructure:"sinks"`
 }
 
 // cache with go cache
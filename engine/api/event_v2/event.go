@@ -20,6 +20,7 @@ import (
 	"github.com/rockbears/log"
 
 	"github.com/ovh/cds/engine/api/notification_v2"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/workflow_v2"
@@ -35,9 +36,21 @@ const (
 
 var httpClient = cdsclient.NewHTTPClient(10*time.Second, false)
 
-// Enqueue event into cache
+// Enqueue event into cache. The consumer and the IP address of the request that triggered the event are added to it for the audit.
 func publish(ctx context.Context, store cache.Store, event interface{}) {
-	if err := store.Enqueue(eventQueue, event); err != nil {
+	bts, err := json.Marshal(event)
+	if err != nil {
+		log.Error(ctx, "EventV2.publish: unable to marshal event: %v", err)
+		return
+	}
+	var e sdk.FullEventV2
+	if err := json.Unmarshal(bts, &e); err != nil {
+		log.Error(ctx, "EventV2.publish: unable to read event: %v", err)
+		return
+	}
+	e.ConsumerID, _ = ctx.Value(cdslog.AuthConsumerID).(string)
+	e.IPAddress, _ = ctx.Value(cdslog.IPAddress).(string)
+	if err := store.Enqueue(eventQueue, e); err != nil {
 		log.Error(ctx, "EventV2.publish: %s", err)
 		return
 	}
@@ -69,11 +82,13 @@ func Dequeue(ctx context.Context, db *gorp.DbMap, store cache.Store, goroutines
 			}
 		})
 
-		// Create audit
+		// Push to external sinks
 		wg.Add(1)
-		goroutines.Exec(ctx, "event.audit", func(_ context.Context) {
+		goroutines.Exec(ctx, "event.sinks", func(ctx context.Context) {
 			defer wg.Done()
-			// TODO Audit
+			if err := pushToSinks(ctx, store, event); err != nil {
+				log.Error(ctx, "EventV2.pushToSinks: %v", err)
+			}
 		})
 
 		// Push to websockets channels
@@ -144,7 +159,7 @@ func workflowNotifications(ctx context.Context, db *gorp.DbMap, store cache.Stor
 	}
 
 	// always send build status on workflow End
-	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, event.ProjectKey, event.VCSName)
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, event.ProjectKey, event.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return sdk.WrapError(err, "can't get AuthorizedClient for %v/%v", event.ProjectKey, event.VCSName)
 	}
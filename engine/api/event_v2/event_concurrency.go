@@ -27,3 +27,26 @@ func PublishConcurrencyEvent(ctx context.Context, store cache.Store, eventType s
 	}
 	publish(ctx, store, e)
 }
+
+// PublishSharedConcurrencyEvent publishes an event on a concurrency shared at the organization or region level. scopeName is the name of the organization or of the region.
+func PublishSharedConcurrencyEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, scopeName string, concu sdk.SharedConcurrency, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(concu)
+	e := sdk.SharedConcurrencyEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		Concurrency: concu.Name,
+		UserID:      u.ID,
+		Username:    u.Username,
+	}
+	switch concu.Scope {
+	case sdk.V2RunConcurrencyScopeRegion:
+		e.Region = scopeName
+	default:
+		e.Organization = scopeName
+	}
+	publish(ctx, store, e)
+}
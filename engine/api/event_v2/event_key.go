@@ -0,0 +1,31 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+func PublishProjectKeyEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, projectKey string, k sdk.ProjectKey, u sdk.AuthentifiedUser) {
+	k.Private = ""
+	bts, _ := json.Marshal(k)
+	e := sdk.KeyEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: projectKey,
+		},
+		KeyName:  k.Name,
+		KeyType:  string(k.Type),
+		UserID:   u.ID,
+		Username: u.Username,
+	}
+	publish(ctx, store, e)
+}
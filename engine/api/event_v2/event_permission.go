@@ -24,3 +24,19 @@ func PublishPermissionEvent(ctx context.Context, store cache.Store, eventType sd
 	}
 	publish(ctx, store, e)
 }
+
+func PublishPermissionGrantEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, g sdk.RBACGrant, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(g)
+	e := sdk.PermissionEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		Permission: g.String(),
+		UserID:     u.ID,
+		Username:   u.Username,
+	}
+	publish(ctx, store, e)
+}
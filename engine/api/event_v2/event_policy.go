@@ -0,0 +1,30 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+func PublishPolicyEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, organization string, p sdk.Policy, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(p)
+	e := sdk.PolicyEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: p.ProjectKey,
+		},
+		Organization: organization,
+		Policy:       p.Name,
+		UserID:       u.ID,
+		Username:     u.Username,
+	}
+	publish(ctx, store, e)
+}
@@ -43,3 +43,21 @@ func PublishProjectPurgeEvent(ctx context.Context, store cache.Store, eventType
 	}
 	publish(ctx, store, e)
 }
+
+func PublishProjectRunRetentionEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, projectKey string, r sdk.Retentions, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(r)
+	e := sdk.ProjectEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: projectKey,
+		},
+		UserID:   u.ID,
+		Username: u.Username,
+	}
+	publish(ctx, store, e)
+}
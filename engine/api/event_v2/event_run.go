@@ -68,6 +68,33 @@ func PublishRunJobManualEvent(ctx context.Context, store cache.Store, eventType
 	publish(ctx, store, e)
 }
 
+func PublishRunJobDebugSessionEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, rj sdk.V2WorkflowRunJob, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(rj)
+	e := sdk.WorkflowRunJobDebugSessionEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: rj.ProjectKey,
+		},
+		VCSName:       rj.VCSServer,
+		Repository:    rj.Repository,
+		Workflow:      rj.WorkflowName,
+		WorkflowRunID: rj.WorkflowRunID,
+		RunJobID:      rj.ID,
+		RunNumber:     rj.RunNumber,
+		RunAttempt:    rj.RunAttempt,
+		Region:        rj.Region,
+		JobID:         rj.JobID,
+		UserID:        u.ID,
+		Username:      u.Username,
+	}
+	publish(ctx, store, e)
+}
+
 func PublishRunJobEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, wr sdk.V2WorkflowRun, rj sdk.V2WorkflowRunJob) {
 	bts, _ := json.Marshal(rj)
 	e := sdk.WorkflowRunJobEvent{
@@ -0,0 +1,29 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+func PublishProjectRunFilterEvent(ctx context.Context, store cache.Store, eventType sdk.EventType, projectKey string, f sdk.ProjectRunFilter, u sdk.AuthentifiedUser) {
+	bts, _ := json.Marshal(f)
+	e := sdk.RunFilterEvent{
+		GlobalEventV2: sdk.GlobalEventV2{
+			ID:        sdk.UUID(),
+			Type:      eventType,
+			Payload:   bts,
+			Timestamp: time.Now(),
+		},
+		ProjectEventV2: sdk.ProjectEventV2{
+			ProjectKey: projectKey,
+		},
+		RunFilter: f.Name,
+		UserID:    u.ID,
+		Username:  u.Username,
+	}
+	publish(ctx, store, e)
+}
@@ -60,7 +60,7 @@ func TestWorkflowEventInterpolateAndTemplating(t *testing.T) {
 		},
 	}
 	comment := `[[- if eq .cds.workflow "myWorkflow"]]I'm workflow [[.cds.workflow ]][[- end]]`
-	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, "github")
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, "github", nil)
 	require.NoError(t, err)
 
 	require.NoError(t, sendVCSPullRequestComment(ctx, db.DbMap, vcsClient, eventPayload, comment))
@@ -116,7 +116,7 @@ func TestWorkflowEventErrorTempalte(t *testing.T) {
 		},
 	}
 	comment := `[[-if eq .WorkflowName "myWorkflow"]]`
-	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, "github")
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, "github", nil)
 	require.NoError(t, err)
 
 	require.Error(t, sendVCSPullRequestComment(ctx, db.DbMap, vcsClient, eventPayload, comment))
@@ -0,0 +1,271 @@
+package event_v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/event"
+)
+
+const (
+	sinkQueuePrefix        = "events_v2:sink"
+	sinkInflightTimeout    = 5 * time.Minute
+	sinkMaxRetryInterval   = 30 * time.Second
+	sinkDefaultMaxAttempts = 10
+)
+
+var (
+	sinks      []*sink
+	sinkCDSURL string
+)
+
+// sinkClient sends a CloudEvent to an external system. Send must only return nil once the event has been acknowledged.
+type sinkClient interface {
+	send(ctx context.Context, e sdk.CloudEventV2) error
+	close()
+}
+
+type sink struct {
+	cfg    event.SinkConfig
+	client sinkClient
+}
+
+// sinkDelivery is the element stored in the Redis queue of a sink
+type sinkDelivery struct {
+	Event      sdk.CloudEventV2 `json:"event"`
+	Attempts   int              `json:"attempts"`
+	DequeuedAt time.Time        `json:"dequeued_at"`
+}
+
+func (s *sink) queue() string {
+	return cache.Key(sinkQueuePrefix, s.cfg.Name)
+}
+
+func (s *sink) inflightKey() string {
+	return cache.Key(sinkQueuePrefix, s.cfg.Name, "inflight")
+}
+
+// deadLetterQueue stores the events that could not be sent after the max attempts of the sink
+func (s *sink) deadLetterQueue() string {
+	return cache.Key(sinkQueuePrefix, s.cfg.Name, "dead-letter")
+}
+
+// retryInterval returns the time to wait after a failed attempt, doubled at each attempt
+func retryInterval(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	if attempts > 6 {
+		return sinkMaxRetryInterval
+	}
+	wait := time.Second << uint(attempts-1)
+	if wait > sinkMaxRetryInterval {
+		wait = sinkMaxRetryInterval
+	}
+	return wait
+}
+
+// InitializeSinks creates the clients of the configured event sinks. cdsURL is used as the source of the CloudEvents.
+// An error is returned if a sink can't be initialized, the clients already created are closed.
+func InitializeSinks(ctx context.Context, cfgs []event.SinkConfig, cdsURL string) (err error) {
+	sinkCDSURL = cdsURL
+	defer func() {
+		if err != nil {
+			CloseSinks()
+			sinks = nil
+		}
+	}()
+	for _, cfg := range cfgs {
+		if err := cfg.Check(); err != nil {
+			return sdk.WithStack(err)
+		}
+		if cfg.MaxAttempts == 0 {
+			cfg.MaxAttempts = sinkDefaultMaxAttempts
+		}
+		var client sinkClient
+		var err error
+		switch cfg.Type {
+		case event.SinkTypeHTTP:
+			client = newHTTPSink(cfg.HTTP)
+		case event.SinkTypeNATS:
+			client, err = newNATSSink(cfg.NATS)
+		case event.SinkTypeAMQP:
+			client = newAMQPSink(cfg.AMQP)
+		}
+		if err != nil {
+			return sdk.WrapError(err, "unable to initialize event sink %s", cfg.Name)
+		}
+		log.Info(ctx, "EventV2.InitializeSinks: %s sink %s initialized", cfg.Type, cfg.Name)
+		sinks = append(sinks, &sink{cfg: cfg, client: client})
+	}
+	return nil
+}
+
+// StartSinks starts the routines that deliver the events to the sinks
+func StartSinks(ctx context.Context, store cache.Store, goroutines *sdk.GoRoutines) {
+	for i := range sinks {
+		s := sinks[i]
+		goroutines.RunWithRestart(ctx, "event_v2.sink."+s.cfg.Name, func(ctx context.Context) {
+			s.deliver(ctx, store)
+		})
+		goroutines.RunWithRestart(ctx, "event_v2.sink."+s.cfg.Name+".recover", func(ctx context.Context) {
+			s.recover(ctx, store)
+		})
+	}
+}
+
+// CloseSinks closes the connections of the sinks
+func CloseSinks() {
+	for _, s := range sinks {
+		s.client.close()
+	}
+}
+
+// SinksStatus returns the size of the queue and of the dead-letter queue of each sink
+func SinksStatus(store cache.Store) []sdk.MonitoringStatusLine {
+	lines := make([]sdk.MonitoringStatusLine, 0, 2*len(sinks))
+	for _, s := range sinks {
+		status := sdk.MonitoringStatusOK
+		size, err := store.QueueLen(s.queue())
+		if err != nil || size > 1000 {
+			status = sdk.MonitoringStatusWarn
+		}
+		lines = append(lines, sdk.MonitoringStatusLine{Component: "Event Sink/" + s.cfg.Name, Value: fmt.Sprintf("%d", size), Status: status})
+
+		status = sdk.MonitoringStatusOK
+		size, err = store.QueueLen(s.deadLetterQueue())
+		if err != nil || size > 0 {
+			status = sdk.MonitoringStatusWarn
+		}
+		lines = append(lines, sdk.MonitoringStatusLine{Component: "Event Sink/" + s.cfg.Name + "/dead-letter", Value: fmt.Sprintf("%d", size), Status: status})
+	}
+	return lines
+}
+
+// pushToSinks enqueues the event in the queue of each sink that matches it
+func pushToSinks(ctx context.Context, store cache.Store, e sdk.FullEventV2) error {
+	var ce *sdk.CloudEventV2
+	for _, s := range sinks {
+		if !s.cfg.Match(e.ProjectKey, string(e.Type)) {
+			continue
+		}
+		if ce == nil {
+			c, err := sdk.NewCloudEventV2(e, sinkCDSURL)
+			if err != nil {
+				return err
+			}
+			ce = &c
+		}
+		if err := store.Enqueue(s.queue(), sinkDelivery{Event: *ce}); err != nil {
+			return sdk.WrapError(err, "unable to enqueue event %s for sink %s", e.ID, s.cfg.Name)
+		}
+	}
+	return nil
+}
+
+// deliver sends the events of the sink queue. An event is kept in the inflight set while it is sent. An event that can't be sent
+// is enqueued again, and moved to the dead-letter queue of the sink once the max attempts are reached.
+func (s *sink) deliver(ctx context.Context, store cache.Store) {
+	for {
+		if err := ctx.Err(); err != nil {
+			log.Error(ctx, "EventV2.sink.deliver> Exiting %s: %v", s.cfg.Name, err)
+			return
+		}
+		var d sinkDelivery
+		if err := store.DequeueWithContext(ctx, s.queue(), 50*time.Millisecond, &d); err != nil {
+			if ctx.Err() == nil {
+				log.Error(ctx, "EventV2.sink.deliver> %s: store.DequeueWithContext err: %v", s.cfg.Name, err)
+			}
+			continue
+		}
+		d.DequeuedAt = time.Now()
+		if err := store.SetAdd(s.inflightKey(), d.Event.ID, d); err != nil {
+			log.Error(ctx, "EventV2.sink.deliver> %s: unable to mark event %s as inflight: %v", s.cfg.Name, d.Event.ID, err)
+		}
+
+		err := s.client.send(ctx, d.Event)
+		if err != nil {
+			d.Attempts++
+			log.Error(ctx, "EventV2.sink.deliver> %s: unable to send event %s (attempt %d/%d): %v", s.cfg.Name, d.Event.ID, d.Attempts, s.cfg.MaxAttempts, err)
+			queue := s.queue()
+			if d.Attempts >= s.cfg.MaxAttempts {
+				log.Error(ctx, "EventV2.sink.deliver> %s: move event %s to the dead-letter queue", s.cfg.Name, d.Event.ID)
+				queue = s.deadLetterQueue()
+			}
+			if err := store.Enqueue(queue, d); err != nil {
+				log.Error(ctx, "EventV2.sink.deliver> %s: unable to enqueue again event %s: %v", s.cfg.Name, d.Event.ID, err)
+				continue
+			}
+		}
+		if err := store.SetRemove(s.inflightKey(), d.Event.ID, d); err != nil {
+			log.Error(ctx, "EventV2.sink.deliver> %s: unable to remove inflight event %s: %v", s.cfg.Name, d.Event.ID, err)
+		}
+
+		// Wait before the next event if the sink is failing
+		if err != nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryInterval(d.Attempts)):
+			}
+		}
+	}
+}
+
+// recover enqueues again the inflight events that were not acknowledged in time, i.e. if an API instance stopped while sending them
+func (s *sink) recover(ctx context.Context, store cache.Store) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting EventV2.sink.recover %s: %v", s.cfg.Name, ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			if err := s.recoverInflight(ctx, store); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+		}
+	}
+}
+
+func (s *sink) recoverInflight(ctx context.Context, store cache.Store) error {
+	lockKey := cache.Key(s.inflightKey(), "lock")
+	locked, err := store.Lock(lockKey, time.Minute, -1, 1)
+	if err != nil || !locked {
+		return err
+	}
+	defer store.Unlock(lockKey) // nolint
+
+	nb, err := store.SetCard(s.inflightKey())
+	if err != nil {
+		return sdk.WrapError(err, "unable to count inflight events of sink %s", s.cfg.Name)
+	}
+	deliveries := make([]*sinkDelivery, nb)
+	for i := range deliveries {
+		deliveries[i] = &sinkDelivery{}
+	}
+	if err := store.SetScan(ctx, s.inflightKey(), sdk.InterfaceSlice(deliveries)...); err != nil {
+		return sdk.WrapError(err, "unable to scan inflight events of sink %s", s.cfg.Name)
+	}
+	for _, d := range deliveries {
+		if d.Event.ID == "" || time.Since(d.DequeuedAt) < sinkInflightTimeout {
+			continue
+		}
+		log.Warn(ctx, "EventV2.sink.recover> %s: event %s was not acknowledged, enqueue it again", s.cfg.Name, d.Event.ID)
+		if err := store.Enqueue(s.queue(), d); err != nil {
+			return sdk.WrapError(err, "unable to enqueue event %s for sink %s", d.Event.ID, s.cfg.Name)
+		}
+		if err := store.SetRemove(s.inflightKey(), d.Event.ID, d); err != nil {
+			return sdk.WrapError(err, "unable to remove inflight event %s of sink %s", d.Event.ID, s.cfg.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/event"
+)
+
+// amqpSink publishes the events on an AMQP exchange as persistent messages, using publisher confirms.
+// The connection is opened on the first event and opened again after an error.
+type amqpSink struct {
+	cfg      event.AMQPSinkConfig
+	mutex    sync.Mutex
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	confirms chan amqp.Confirmation
+}
+
+func newAMQPSink(cfg event.AMQPSinkConfig) *amqpSink {
+	return &amqpSink{cfg: cfg}
+}
+
+func (s *amqpSink) connect() error {
+	conn, err := amqp.Dial(s.cfg.URI)
+	if err != nil {
+		return sdk.WrapError(err, "unable to connect to amqp server")
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return sdk.WrapError(err, "unable to open amqp channel")
+	}
+	if err := channel.Confirm(false); err != nil {
+		_ = conn.Close()
+		return sdk.WrapError(err, "unable to enable publisher confirms")
+	}
+	s.conn = conn
+	s.channel = channel
+	s.confirms = channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+	return nil
+}
+
+// routingKey returns <project key>.<event type>, "global" is used for the events without project
+func (s *amqpSink) routingKey(e sdk.CloudEventV2) string {
+	project := e.CDSProject
+	if project == "" {
+		project = "global"
+	}
+	return project + "." + string(e.EventType())
+}
+
+func (s *amqpSink) send(ctx context.Context, e sdk.CloudEventV2) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bts, err := json.Marshal(e)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	if err := s.channel.Publish(s.cfg.Exchange, s.routingKey(e), false, false, amqp.Publishing{
+		ContentType:  sdk.CloudEventContentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    e.ID,
+		Timestamp:    e.Time,
+		Type:         e.Type,
+		Body:         bts,
+	}); err != nil {
+		s.reset()
+		return sdk.WrapError(err, "unable to publish on exchange %s", s.cfg.Exchange)
+	}
+
+	select {
+	case c, ok := <-s.confirms:
+		if !ok || !c.Ack {
+			s.reset()
+			return sdk.WithStack(fmt.Errorf("event %s was not acknowledged by the amqp server", e.ID))
+		}
+	case <-time.After(10 * time.Second):
+		s.reset()
+		return sdk.WithStack(fmt.Errorf("timeout while waiting for the amqp server to acknowledge event %s", e.ID))
+	case <-ctx.Done():
+		s.reset()
+		return sdk.WithStack(ctx.Err())
+	}
+	return nil
+}
+
+func (s *amqpSink) reset() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn = nil
+	s.channel = nil
+	s.confirms = nil
+}
+
+func (s *amqpSink) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reset()
+}
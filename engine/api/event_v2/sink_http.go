@@ -0,0 +1,60 @@
+package event_v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/event"
+)
+
+// httpSink posts the events to an HTTP endpoint with the CloudEvents structured content mode
+type httpSink struct {
+	cfg    event.HTTPSinkConfig
+	client *http.Client
+}
+
+func newHTTPSink(cfg event.HTTPSinkConfig) *httpSink {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10
+	}
+	return &httpSink{
+		cfg:    cfg,
+		client: cdsclient.NewHTTPClient(time.Duration(cfg.Timeout)*time.Second, cfg.InsecureSkipVerify),
+	}
+}
+
+func (s *httpSink) send(ctx context.Context, e sdk.CloudEventV2) error {
+	bts, err := json.Marshal(e)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.Timeout)*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(bts))
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	req.Header.Set("Content-Type", sdk.CloudEventContentType)
+	for k, v := range s.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return sdk.WithStack(fmt.Errorf("http sink returned status %d: %s", resp.StatusCode, string(body)))
+	}
+	return nil
+}
+
+func (s *httpSink) close() {}
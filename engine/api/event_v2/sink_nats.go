@@ -0,0 +1,73 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/event"
+)
+
+// natsSink publishes the events on a NATS JetStream stream. The event ID is used as message ID so JetStream
+// can discard the duplicates sent when an event is delivered again.
+type natsSink struct {
+	cfg  event.NATSSinkConfig
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+func newNATSSink(cfg event.NATSSinkConfig) (*natsSink, error) {
+	if cfg.Subject == "" {
+		cfg.Subject = "cds.events"
+	}
+	opts := []nats.Option{nats.Name("cds-api"), nats.MaxReconnects(-1)}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredentialsFile))
+	}
+	if cfg.Token != "" {
+		opts = append(opts, nats.Token(cfg.Token))
+	}
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to connect to %s", cfg.URL)
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, sdk.WrapError(err, "unable to get JetStream context")
+	}
+	return &natsSink{cfg: cfg, conn: conn, js: js}, nil
+}
+
+// subject returns <subject>.<project key>.<event type>, "global" is used for the events without project
+func (s *natsSink) subject(e sdk.CloudEventV2) string {
+	project := e.CDSProject
+	if project == "" {
+		project = "global"
+	}
+	return strings.Join([]string{s.cfg.Subject, project, string(e.EventType())}, ".")
+}
+
+func (s *natsSink) send(ctx context.Context, e sdk.CloudEventV2) error {
+	bts, err := json.Marshal(e)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	msg := nats.NewMsg(s.subject(e))
+	msg.Header.Set("Content-Type", sdk.CloudEventContentType)
+	msg.Data = bts
+	if _, err := s.js.PublishMsg(msg, nats.MsgId(e.ID), nats.Context(ctx)); err != nil {
+		return sdk.WrapError(err, "unable to publish on %s", msg.Subject)
+	}
+	return nil
+}
+
+func (s *natsSink) close() {
+	s.conn.Close()
+}
@@ -0,0 +1,85 @@
+package event_v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/event"
+)
+
+func TestSinkConfigMatch(t *testing.T) {
+	cfg := event.SinkConfig{Name: "sink", Type: event.SinkTypeHTTP, HTTP: event.HTTPSinkConfig{URL: "https://sink.local"}}
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Match("", string(sdk.EventUserCreated)))
+
+	cfg.Projects = []string{"PROJ"}
+	cfg.EventTypes = []string{"Run*", string(sdk.EventVariableSetItemCreated)}
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Match("PROJ", string(sdk.EventRunEnded)))
+	require.True(t, cfg.Match("PROJ", string(sdk.EventVariableSetItemCreated)))
+	require.False(t, cfg.Match("PROJ", string(sdk.EventVariableSetItemDeleted)))
+	require.False(t, cfg.Match("OTHER", string(sdk.EventRunEnded)))
+	require.False(t, cfg.Match("", string(sdk.EventUserCreated)))
+
+	cfg.EventTypes = []string{"Run["}
+	require.Error(t, cfg.Check())
+	require.Error(t, event.SinkConfig{Name: "sink", Type: "kafka"}.Check())
+	require.Error(t, event.SinkConfig{Name: "sink", Type: event.SinkTypeAMQP, AMQP: event.AMQPSinkConfig{URI: "amqp://localhost"}}.Check())
+}
+
+func TestHTTPSinkSend(t *testing.T) {
+	var received sdk.CloudEventV2
+	var contentType, auth string
+	status := http.StatusAccepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := newHTTPSink(event.HTTPSinkConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	ce, err := sdk.NewCloudEventV2(sdk.FullEventV2{ID: "event-id", Type: sdk.EventRunEnded, ProjectKey: "PROJ", WorkflowRunID: "run-id"}, "https://cds.local")
+	require.NoError(t, err)
+
+	require.NoError(t, s.send(context.TODO(), ce))
+	require.Equal(t, sdk.CloudEventContentType, contentType)
+	require.Equal(t, "Bearer token", auth)
+	require.Equal(t, "event-id", received.ID)
+	require.Equal(t, "com.ovhcloud.cds.v2.RunEnded", received.Type)
+	require.Equal(t, "PROJ", received.CDSProject)
+
+	status = http.StatusInternalServerError
+	require.Error(t, s.send(context.TODO(), ce))
+}
+
+func TestSinkRetryInterval(t *testing.T) {
+	require.Equal(t, time.Duration(0), retryInterval(0))
+	require.Equal(t, time.Second, retryInterval(1))
+	require.Equal(t, 2*time.Second, retryInterval(2))
+	require.Equal(t, 16*time.Second, retryInterval(5))
+	require.Equal(t, sinkMaxRetryInterval, retryInterval(6))
+	require.Equal(t, sinkMaxRetryInterval, retryInterval(100))
+}
+
+func TestInitializeSinksError(t *testing.T) {
+	cfgs := []event.SinkConfig{
+		{Name: "http", Type: event.SinkTypeHTTP, HTTP: event.HTTPSinkConfig{URL: "https://sink.local"}},
+		{Name: "kafka", Type: "kafka"},
+	}
+	require.Error(t, InitializeSinks(context.TODO(), cfgs, "https://cds.local"))
+	require.Len(t, sinks, 0)
+
+	require.NoError(t, InitializeSinks(context.TODO(), cfgs[:1], "https://cds.local"))
+	t.Cleanup(func() { CloseSinks(); sinks = nil })
+	require.Len(t, sinks, 1)
+	require.Equal(t, sinkDefaultMaxAttempts, sinks[0].cfg.MaxAttempts)
+}
@@ -182,6 +182,9 @@ func (api *API) deleteGRPCluginHandler() service.Handler {
 			return sdk.WrapError(err, "unable to delete plugin")
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventPluginDeleted, "", old.Name, old, nil); err != nil {
+			return err
+		}
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
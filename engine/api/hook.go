@@ -47,7 +47,7 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 		}
 
 		//get the client for the repositories manager
-		client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, proj.Key, vcsServerParam)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, proj.Key, vcsServerParam, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return err
 		}
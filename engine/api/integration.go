@@ -71,6 +71,10 @@ func (api *API) postIntegrationModelHandler() service.Handler {
 			return sdk.WrapError(err, "unable to insert model %s", m.Name)
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventIntegrationModelCreated, "", m.Name, nil, m); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "unable to commit tx")
 		}
@@ -128,6 +132,10 @@ func (api *API) putIntegrationModelHandler() service.Handler {
 			return err
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventIntegrationModelUpdated, "", m.Name, old, m); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "Unable to commit tx")
 		}
@@ -161,7 +169,7 @@ func propagatePublicIntegrationModel(ctx context.Context, db *gorp.DbMap, store
 			log.Error(ctx, "propagatePublicIntegrationModel> error: %v", err)
 			continue
 		}
-		created, updated, err := propagatePublicIntegrationModelOnProject(ctx, tx, store, m, p, u)
+		created, updated, err := propagatePublicIntegrationModelOnProject(ctx, tx, store, m, p, &u)
 		if err != nil {
 			log.Error(ctx, "propagatePublicIntegrationModel> error: %v", err)
 			_ = tx.Rollback()
@@ -179,7 +187,7 @@ func propagatePublicIntegrationModel(ctx context.Context, db *gorp.DbMap, store
 	}
 }
 
-func propagatePublicIntegrationModelOnProject(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, m sdk.IntegrationModel, p sdk.Project, u sdk.Identifiable) ([]sdk.ProjectIntegration, []sdk.ProjectIntegration, error) {
+func propagatePublicIntegrationModelOnProject(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cache.Store, m sdk.IntegrationModel, p sdk.Project, u *sdk.AuthUserConsumer) ([]sdk.ProjectIntegration, []sdk.ProjectIntegration, error) {
 	if !m.Public {
 		return nil, nil, nil
 	}
@@ -200,6 +208,9 @@ func propagatePublicIntegrationModelOnProject(ctx context.Context, db gorpmapper
 			if err := integration.InsertIntegration(db, &pp); err != nil {
 				return nil, nil, sdk.WrapError(err, "Unable to insert integration %s", pp.Name)
 			}
+			if err := recordUserAudit(ctx, db, u.AuthConsumerUser.AuthentifiedUser, sdk.EventIntegrationCreated, p.Key, pp.Name, nil, pp); err != nil {
+				return nil, nil, err
+			}
 			createdIntegration = append(createdIntegration, pp)
 			event.PublishAddProjectIntegration(ctx, &p, pp, u)
 			continue
@@ -213,10 +224,13 @@ func propagatePublicIntegrationModelOnProject(ctx context.Context, db gorpmapper
 			Config:             cfg,
 			ProjectID:          p.ID,
 		}
-		oldPP.Config = m.DefaultConfig
 		if err := integration.UpdateIntegration(ctx, db, pp); err != nil {
 			return nil, nil, err
 		}
+		if err := recordUserAudit(ctx, db, u.AuthConsumerUser.AuthentifiedUser, sdk.EventIntegrationUpdated, p.Key, pp.Name, oldPP, pp); err != nil {
+			return nil, nil, err
+		}
+		oldPP.Config = m.DefaultConfig
 		event.PublishUpdateProjectIntegration(ctx, &p, oldPP, pp, u)
 		updatedIntegration = append(updatedIntegration, pp)
 	}
@@ -248,6 +262,10 @@ func (api *API) deleteIntegrationModelHandler() service.Handler {
 			return sdk.WithStack(err)
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventIntegrationModelDeleted, "", old.Name, old, nil); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "Unable to commit tx")
 		}
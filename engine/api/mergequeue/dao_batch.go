@@ -0,0 +1,64 @@
+package mergequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func getBatches(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.MergeQueueBatch, error) {
+	var dbBatches []dbMergeQueueBatch
+	if err := gorpmapping.GetAll(ctx, db, query, &dbBatches); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	batches := make([]sdk.MergeQueueBatch, 0, len(dbBatches))
+	for _, b := range dbBatches {
+		batches = append(batches, b.MergeQueueBatch)
+	}
+	return batches, nil
+}
+
+// InsertBatch creates a new batch
+func InsertBatch(ctx context.Context, db gorpmapper.SqlExecutorWithTx, b *sdk.MergeQueueBatch) error {
+	if b.ID == "" {
+		b.ID = sdk.UUID()
+	}
+	b.Created = time.Now()
+	b.LastModified = b.Created
+	if b.RunIDs == nil {
+		b.RunIDs = sdk.StringSlice{}
+	}
+	dbBatch := dbMergeQueueBatch{*b}
+	if err := gorpmapping.Insert(db, &dbBatch); err != nil {
+		return sdk.WithStack(err)
+	}
+	*b = dbBatch.MergeQueueBatch
+	return nil
+}
+
+// UpdateBatch updates a batch
+func UpdateBatch(ctx context.Context, db gorpmapper.SqlExecutorWithTx, b *sdk.MergeQueueBatch) error {
+	b.LastModified = time.Now()
+	dbBatch := dbMergeQueueBatch{*b}
+	if err := gorpmapping.Update(db, &dbBatch); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
+
+// LoadActiveBatches loads all batches that are not terminated
+func LoadActiveBatches(ctx context.Context, db gorp.SqlExecutor) ([]sdk.MergeQueueBatch, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_batch
+		WHERE status = ANY($1)
+		ORDER BY created ASC
+	`).Args(pq.StringArray{string(sdk.MergeQueueBatchStatusMerging), string(sdk.MergeQueueBatchStatusTesting), string(sdk.MergeQueueBatchStatusLanding)})
+	return getBatches(ctx, db, query)
+}
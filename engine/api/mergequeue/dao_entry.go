@@ -0,0 +1,107 @@
+package mergequeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func getEntry(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) (*sdk.MergeQueueEntry, error) {
+	var dbEntry dbMergeQueueEntry
+	found, err := gorpmapping.Get(ctx, db, query, &dbEntry)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &dbEntry.MergeQueueEntry, nil
+}
+
+func getEntries(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.MergeQueueEntry, error) {
+	var dbEntries []dbMergeQueueEntry
+	if err := gorpmapping.GetAll(ctx, db, query, &dbEntries); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	entries := make([]sdk.MergeQueueEntry, 0, len(dbEntries))
+	for _, e := range dbEntries {
+		entries = append(entries, e.MergeQueueEntry)
+	}
+	return entries, nil
+}
+
+// InsertEntry adds a pull request in the merge queue
+func InsertEntry(ctx context.Context, db gorpmapper.SqlExecutorWithTx, e *sdk.MergeQueueEntry) error {
+	e.ID = sdk.UUID()
+	e.Created = time.Now()
+	e.LastModified = e.Created
+	e.Status = sdk.MergeQueueEntryStatusQueued
+	dbEntry := dbMergeQueueEntry{*e}
+	if err := gorpmapping.Insert(db, &dbEntry); err != nil {
+		if errPG, ok := err.(*pq.Error); ok && errPG.Code == "23505" {
+			return sdk.WithStack(sdk.NewErrorFrom(sdk.ErrConflictData, "pull request #%d is already in the merge queue", e.PullRequestID))
+		}
+		return sdk.WithStack(err)
+	}
+	*e = dbEntry.MergeQueueEntry
+	return nil
+}
+
+// UpdateEntry updates a merge queue entry
+func UpdateEntry(ctx context.Context, db gorpmapper.SqlExecutorWithTx, e *sdk.MergeQueueEntry) error {
+	e.LastModified = time.Now()
+	dbEntry := dbMergeQueueEntry{*e}
+	if err := gorpmapping.Update(db, &dbEntry); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteEntry removes a queued entry from the merge queue
+func DeleteEntry(db gorpmapper.SqlExecutorWithTx, projectKey, entryID string) error {
+	res, err := db.Exec("DELETE FROM merge_queue_entry WHERE id = $1 AND project_key = $2 AND status = $3", entryID, projectKey, sdk.MergeQueueEntryStatusQueued)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return sdk.WithStack(sdk.NewErrorFrom(sdk.ErrNotFound, "no queued entry %s", entryID))
+	}
+	return nil
+}
+
+// LoadEntryByID loads a merge queue entry
+func LoadEntryByID(ctx context.Context, db gorp.SqlExecutor, projectKey, entryID string) (*sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM merge_queue_entry WHERE id = $1 AND project_key = $2`).Args(entryID, projectKey)
+	return getEntry(ctx, db, query)
+}
+
+// LoadEntriesByRepository loads the merge queue of a repository, latest terminated entries included
+func LoadEntriesByRepository(ctx context.Context, db gorp.SqlExecutor, projectKey, vcsServer, repository string, limit int) ([]sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT *
+		FROM merge_queue_entry
+		WHERE project_key = $1 AND vcs_server = $2 AND repository = $3
+		ORDER BY created DESC
+		LIMIT $4
+	`).Args(projectKey, vcsServer, repository, limit)
+	return getEntries(ctx, db, query)
+}
+
+// LoadEntriesByBatch loads all entries tested in a batch, in queue order
+func LoadEntriesByBatch(ctx context.Context, db gorp.SqlExecutor, batchID string) ([]sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM merge_queue_entry WHERE batch_id = $1 ORDER BY created ASC`).Args(batchID)
+	return getEntries(ctx, db, query)
+}
+
+// LoadQueuedEntries loads all queued entries of all repositories, in queue order
+func LoadQueuedEntries(ctx context.Context, db gorp.SqlExecutor) ([]sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`SELECT * FROM merge_queue_entry WHERE status = $1 ORDER BY created ASC`).Args(sdk.MergeQueueEntryStatusQueued)
+	return getEntries(ctx, db, query)
+}
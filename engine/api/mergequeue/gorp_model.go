@@ -0,0 +1,19 @@
+package mergequeue
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbMergeQueueEntry{}, "merge_queue_entry", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbMergeQueueBatch{}, "merge_queue_batch", false, "id"))
+}
+
+type dbMergeQueueEntry struct {
+	sdk.MergeQueueEntry
+}
+
+type dbMergeQueueBatch struct {
+	sdk.MergeQueueBatch
+}
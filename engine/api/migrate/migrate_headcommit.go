@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-gorp/gorp"
 	"github.com/ovh/cds/engine/api/entity"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/workflow_v2"
 	"github.com/ovh/cds/engine/cache"
@@ -46,7 +47,7 @@ func MigrateHeadCommit(ctx context.Context, db *gorp.DbMap, store cache.Store) e
 		repoCacheKey := h.VCSName + "/" + h.RepositoryName
 		defaultBranch, has := repoCache[repoCacheKey]
 		if !has {
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, h.ProjectKey, h.VCSName)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, h.ProjectKey, h.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
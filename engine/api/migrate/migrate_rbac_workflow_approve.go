@@ -0,0 +1,66 @@
+package migrate
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-gorp/gorp"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/sdk"
+)
+
+// MigrateRBACWorkflowApprove adds an approve rule for each workflow trigger rule, as the trigger role
+// doesn't allow anymore to start the jobs waiting for a gate.
+func MigrateRBACWorkflowApprove(ctx context.Context, db *gorp.DbMap) error {
+	rbacs, err := rbac.LoadAllRBACByWorkflowRole(ctx, db, sdk.WorkflowRoleTrigger, rbac.LoadOptions.All)
+	if err != nil {
+		return err
+	}
+
+	for i := range rbacs {
+		rb := &rbacs[i]
+		if !addRBACWorkflowApprove(rb) {
+			continue
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		if err := rbac.Update(ctx, tx, rb); err != nil {
+			_ = tx.Rollback()
+			return sdk.WrapError(err, "unable to add approve rules to permission %s", rb.Name)
+		}
+		if err := tx.Commit(); err != nil {
+			_ = tx.Rollback()
+			return sdk.WithStack(err)
+		}
+		log.Info(ctx, "migrate.MigrateRBACWorkflowApprove> approve rules added to permission %s", rb.Name)
+	}
+	return nil
+}
+
+// addRBACWorkflowApprove copies the workflow trigger rules of the permission with the approve role, and returns true if a rule was added
+func addRBACWorkflowApprove(rb *sdk.RBAC) bool {
+	var added bool
+	for _, w := range rb.Workflows {
+		if w.Role != sdk.WorkflowRoleTrigger {
+			continue
+		}
+		approve := w
+		approve.Role = sdk.WorkflowRoleApprove
+		var exists bool
+		for _, existing := range rb.Workflows {
+			if reflect.DeepEqual(existing, approve) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			rb.Workflows = append(rb.Workflows, approve)
+			added = true
+		}
+	}
+	return added
+}
@@ -0,0 +1,28 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestAddRBACWorkflowApprove(t *testing.T) {
+	rb := sdk.RBAC{
+		Name: "perm",
+		Workflows: []sdk.RBACWorkflow{
+			{Role: sdk.WorkflowRoleTrigger, ProjectKey: "PROJ", AllWorkflows: true, RBACUsersIDs: []string{"u1"}},
+			{Role: sdk.WorkflowRoleTrigger, ProjectKey: "PROJ", RBACWorkflowsNames: []string{"deploy"}, RBACGroupsIDs: []int64{1}},
+			{Role: sdk.WorkflowRoleApprove, ProjectKey: "PROJ", RBACWorkflowsNames: []string{"deploy"}, RBACGroupsIDs: []int64{1}},
+			{Role: sdk.WorkflowRoleStop, ProjectKey: "PROJ", AllWorkflows: true, AllUsers: true},
+		},
+	}
+	require.True(t, addRBACWorkflowApprove(&rb))
+	require.Len(t, rb.Workflows, 5)
+	require.Equal(t, sdk.RBACWorkflow{Role: sdk.WorkflowRoleApprove, ProjectKey: "PROJ", AllWorkflows: true, RBACUsersIDs: []string{"u1"}}, rb.Workflows[4])
+
+	// Already migrated
+	require.False(t, addRBACWorkflowApprove(&rb))
+	require.Len(t, rb.Workflows, 5)
+}
@@ -1,6 +1,7 @@
 package operation
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"fmt"
@@ -29,7 +30,7 @@ func pushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		ope.RepositoryStrategy.SSHKeyContent = key.Private
 	}
 
-	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, ope.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, ope.VCSServer, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -224,3 +225,76 @@ func CheckoutAndAnalyzeOperation(ctx context.Context, db gorp.SqlExecutor, proj
 	}
 	return ope, nil
 }
+
+// MergeOperation creates a repository operation to build, land or delete a merge queue branch
+func MergeOperation(ctx context.Context, db gorp.SqlExecutor, proj sdk.Project, vcsWithSecret sdk.VCSProject, repoName, repoCloneURL string, merge sdk.OperationMerge) (*sdk.Operation, error) {
+	ope := &sdk.Operation{
+		VCSServer:    vcsWithSecret.Name,
+		RepoFullName: repoName,
+		URL:          repoCloneURL,
+		RepositoryStrategy: sdk.RepositoryStrategy{
+			SSHKey:   vcsWithSecret.Auth.SSHKeyName,
+			User:     vcsWithSecret.Auth.Username,
+			Password: vcsWithSecret.Auth.Token,
+		},
+		Setup: sdk.OperationSetup{
+			Merge: merge,
+		},
+	}
+	if vcsWithSecret.Auth.SSHKeyName != "" {
+		ope.RepositoryStrategy.ConnectionType = "ssh"
+	} else {
+		ope.RepositoryStrategy.ConnectionType = "https"
+	}
+
+	if err := PostRepositoryOperation(ctx, db, proj, ope, nil); err != nil {
+		return nil, err
+	}
+	return ope, nil
+}
+
+// PushFilesOperation creates a repository operation that commits the given files on a branch of the repository.
+// Files paths are relative to the .cds directory of the repository.
+func PushFilesOperation(ctx context.Context, db gorp.SqlExecutor, proj sdk.Project, vcsWithSecret sdk.VCSProject, repoName, repoCloneURL string, push sdk.OperationPush, files map[string][]byte) (*sdk.Operation, error) {
+	ope := &sdk.Operation{
+		VCSServer:    vcsWithSecret.Name,
+		RepoFullName: repoName,
+		URL:          repoCloneURL,
+		RepositoryStrategy: sdk.RepositoryStrategy{
+			SSHKey:   vcsWithSecret.Auth.SSHKeyName,
+			User:     vcsWithSecret.Auth.Username,
+			Password: vcsWithSecret.Auth.Token,
+		},
+		Setup: sdk.OperationSetup{
+			Push: push,
+		},
+	}
+	if vcsWithSecret.Auth.SSHKeyName != "" {
+		ope.RepositoryStrategy.ConnectionType = "ssh"
+	} else {
+		ope.RepositoryStrategy.ConnectionType = "https"
+	}
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, sdk.WithStack(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	multipartData := &services.MultiPartData{
+		Reader:      buf,
+		ContentType: "application/tar",
+	}
+	if err := PostRepositoryOperation(ctx, db, proj, ope, multipartData); err != nil {
+		return nil, err
+	}
+	return ope, nil
+}
@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+)
+
+func InsertProjectPolicy(ctx context.Context, db gorpmapper.SqlExecutorWithTx, p *sdk.Policy) error {
+	p.ID = sdk.UUID()
+	p.OrganizationID = ""
+	p.LastModified = time.Now()
+	dbData := &dbProjectPolicy{Policy: *p, ProjectKey: p.ProjectKey}
+	if err := gorpmapping.Insert(db, dbData); err != nil {
+		return err
+	}
+	*p = dbData.policy()
+	return nil
+}
+
+func UpdateProjectPolicy(ctx context.Context, db gorpmapper.SqlExecutorWithTx, p *sdk.Policy) error {
+	p.OrganizationID = ""
+	p.LastModified = time.Now()
+	dbData := &dbProjectPolicy{Policy: *p, ProjectKey: p.ProjectKey}
+	if err := gorpmapping.Update(db, dbData); err != nil {
+		return err
+	}
+	*p = dbData.policy()
+	return nil
+}
+
+func DeleteProjectPolicy(db gorpmapper.SqlExecutorWithTx, projectKey string, policyID string) error {
+	_, err := db.Exec("DELETE FROM project_policy WHERE id = $1 AND project_key = $2", policyID, projectKey)
+	return sdk.WrapError(err, "cannot delete project_policy %s / %s", projectKey, policyID)
+}
+
+func LoadProjectPolicyByName(ctx context.Context, db gorp.SqlExecutor, projKey string, name string) (*sdk.Policy, error) {
+	query := gorpmapping.NewQuery(`SELECT project_policy.* FROM project_policy WHERE project_key = $1 AND name = $2`).Args(projKey, name)
+	var res dbProjectPolicy
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	p := res.policy()
+	return &p, nil
+}
+
+func LoadProjectPolicies(ctx context.Context, db gorp.SqlExecutor, projKey string) ([]sdk.Policy, error) {
+	query := gorpmapping.NewQuery(`SELECT project_policy.* FROM project_policy WHERE project_key = $1 ORDER BY name`).Args(projKey)
+	var res []dbProjectPolicy
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	policies := make([]sdk.Policy, 0, len(res))
+	for _, r := range res {
+		policies = append(policies, r.policy())
+	}
+	return policies, nil
+}
+
+func InsertOrganizationPolicy(ctx context.Context, db gorpmapper.SqlExecutorWithTx, p *sdk.Policy) error {
+	p.ID = sdk.UUID()
+	p.ProjectKey = ""
+	p.LastModified = time.Now()
+	dbData := &dbOrganizationPolicy{Policy: *p, OrganizationID: p.OrganizationID}
+	if err := gorpmapping.Insert(db, dbData); err != nil {
+		return err
+	}
+	*p = dbData.policy()
+	return nil
+}
+
+func UpdateOrganizationPolicy(ctx context.Context, db gorpmapper.SqlExecutorWithTx, p *sdk.Policy) error {
+	p.ProjectKey = ""
+	p.LastModified = time.Now()
+	dbData := &dbOrganizationPolicy{Policy: *p, OrganizationID: p.OrganizationID}
+	if err := gorpmapping.Update(db, dbData); err != nil {
+		return err
+	}
+	*p = dbData.policy()
+	return nil
+}
+
+func DeleteOrganizationPolicy(db gorpmapper.SqlExecutorWithTx, organizationID string, policyID string) error {
+	_, err := db.Exec("DELETE FROM organization_policy WHERE id = $1 AND organization_id = $2", policyID, organizationID)
+	return sdk.WrapError(err, "cannot delete organization_policy %s / %s", organizationID, policyID)
+}
+
+func LoadOrganizationPolicyByName(ctx context.Context, db gorp.SqlExecutor, organizationID string, name string) (*sdk.Policy, error) {
+	query := gorpmapping.NewQuery(`SELECT organization_policy.* FROM organization_policy WHERE organization_id = $1 AND name = $2`).Args(organizationID, name)
+	var res dbOrganizationPolicy
+	found, err := gorpmapping.Get(ctx, db, query, &res)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	p := res.policy()
+	return &p, nil
+}
+
+func LoadOrganizationPolicies(ctx context.Context, db gorp.SqlExecutor, organizationID string) ([]sdk.Policy, error) {
+	query := gorpmapping.NewQuery(`SELECT organization_policy.* FROM organization_policy WHERE organization_id = $1 ORDER BY name`).Args(organizationID)
+	var res []dbOrganizationPolicy
+	if err := gorpmapping.GetAll(ctx, db, query, &res); err != nil {
+		return nil, err
+	}
+	policies := make([]sdk.Policy, 0, len(res))
+	for _, r := range res {
+		policies = append(policies, r.policy())
+	}
+	return policies, nil
+}
@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	gocache "github.com/patrickmn/go-cache"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// regoEvalTimeout is the maximum duration of the evaluation of a Rego policy
+const regoEvalTimeout = 5 * time.Second
+
+var (
+	// regoCapabilities are the capabilities of this OPA version without the builtins that reach the network
+	// or the API process, as policies are written by project managers and evaluated in the API.
+	regoCapabilities = safeRegoCapabilities()
+	// regoQueries caches the compiled queries by policy version
+	regoQueries = gocache.New(time.Hour, 10*time.Minute)
+)
+
+func safeRegoCapabilities() *ast.Capabilities {
+	caps := ast.CapabilitiesForThisVersion()
+	builtins := make([]*ast.Builtin, 0, len(caps.Builtins))
+	for _, b := range caps.Builtins {
+		if b.Name == "http.send" || b.Name == "opa.runtime" || strings.HasPrefix(b.Name, "net.") {
+			continue
+		}
+		builtins = append(builtins, b)
+	}
+	caps.Builtins = builtins
+	return caps
+}
+
+// Validate checks the policy fields and compiles its rule
+func Validate(ctx context.Context, p *sdk.Policy) error {
+	if err := p.Check(); err != nil {
+		return err
+	}
+	if p.Language == sdk.PolicyLanguageRego {
+		if _, err := prepareRego(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate runs the policies matching the type of the given entity and returns the raised outcomes.
+// An error is returned if a rule cannot be evaluated.
+func Evaluate(ctx context.Context, policies []sdk.Policy, input sdk.PolicyInput) ([]sdk.PolicyViolation, error) {
+	var document map[string]interface{}
+	btes, err := json.Marshal(input)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	if err := json.Unmarshal(btes, &document); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var violations []sdk.PolicyViolation
+	for i := range policies {
+		p := &policies[i]
+		if p.EntityType != input.EntityType {
+			continue
+		}
+		var vs []sdk.PolicyViolation
+		switch p.Language {
+		case sdk.PolicyLanguageExpression:
+			vs, err = evaluateExpression(ctx, p, document)
+		case sdk.PolicyLanguageRego:
+			vs, err = evaluateRego(ctx, p, document)
+		default:
+			err = sdk.NewErrorFrom(sdk.ErrInvalidData, "unknown language %q", p.Language)
+		}
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to evaluate policy %s: %v", p.Name, err)
+		}
+		violations = append(violations, vs...)
+	}
+	return violations, nil
+}
+
+func evaluateExpression(ctx context.Context, p *sdk.Policy, document map[string]interface{}) ([]sdk.PolicyViolation, error) {
+	ap := sdk.NewActionParser(document, sdk.DefaultFuncs)
+	raised, err := ap.InterpolateToBool(ctx, p.ExpressionRule())
+	if err != nil {
+		return nil, err
+	}
+	if !raised {
+		return nil, nil
+	}
+	return []sdk.PolicyViolation{{Policy: p.Name, Outcome: p.Outcome, Message: p.Message}}, nil
+}
+
+// prepareRego compiles a query collecting the messages of the "deny" and "warn" rules of the policy module
+func prepareRego(ctx context.Context, p *sdk.Policy) (rego.PreparedEvalQuery, error) {
+	filename := p.Name + ".rego"
+	module, err := ast.ParseModule(filename, p.Rule)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, sdk.NewErrorFrom(sdk.ErrInvalidData, "invalid rego module: %v", err)
+	}
+	if module == nil {
+		return rego.PreparedEvalQuery{}, sdk.NewErrorFrom(sdk.ErrInvalidData, "empty rego module")
+	}
+	pkg := module.Package.Path.String()
+	query := fmt.Sprintf("deny := [m | m := %[1]s.deny[_]]; warn := [m | m := %[1]s.warn[_]]", pkg)
+	prepared, err := rego.New(
+		rego.Query(query),
+		rego.Module(filename, p.Rule),
+		rego.Capabilities(regoCapabilities),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, sdk.NewErrorFrom(sdk.ErrInvalidData, "invalid rego module: %v", err)
+	}
+	return prepared, nil
+}
+
+// loadRegoQuery returns the compiled query of the policy from the cache, or compiles it.
+// A policy version is identified by its ID and its last modification date.
+func loadRegoQuery(ctx context.Context, p *sdk.Policy) (rego.PreparedEvalQuery, error) {
+	if p.ID == "" {
+		return prepareRego(ctx, p)
+	}
+	key := p.ID + "-" + p.LastModified.Format(time.RFC3339Nano)
+	if query, has := regoQueries.Get(key); has {
+		return query.(rego.PreparedEvalQuery), nil
+	}
+	query, err := prepareRego(ctx, p)
+	if err != nil {
+		return query, err
+	}
+	regoQueries.SetDefault(key, query)
+	return query, nil
+}
+
+func evaluateRego(ctx context.Context, p *sdk.Policy, document map[string]interface{}) ([]sdk.PolicyViolation, error) {
+	query, err := loadRegoQuery(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, regoEvalTimeout)
+	defer cancel()
+	rs, err := query.Eval(ctx, rego.EvalInput(document))
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []sdk.PolicyViolation
+	for _, result := range rs {
+		for _, outcome := range []sdk.PolicyOutcome{sdk.PolicyOutcomeDeny, sdk.PolicyOutcomeWarn} {
+			messages, _ := result.Bindings[string(outcome)].([]interface{})
+			for _, m := range messages {
+				violations = append(violations, sdk.PolicyViolation{Policy: p.Name, Outcome: outcome, Message: regoMessage(m)})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// regoMessage returns the message of a rule value: a string, an object with a "msg" key or any value encoded in JSON
+func regoMessage(v interface{}) string {
+	switch m := v.(type) {
+	case string:
+		return m
+	case map[string]interface{}:
+		if msg, ok := m["msg"].(string); ok {
+			return msg
+		}
+	}
+	btes, _ := json.Marshal(v)
+	return string(btes)
+}
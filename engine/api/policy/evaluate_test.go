@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	policies := []sdk.Policy{
+		{
+			Name:       "prod-gate",
+			EntityType: sdk.EntityTypeWorkflow,
+			Language:   sdk.PolicyLanguageExpression,
+			Rule:       "contains(entity.vars, 'prod') && !contains(toJSON(entity.gates), 'reviewers')",
+			Outcome:    sdk.PolicyOutcomeDeny,
+			Message:    "workflows using the prod variable set must have a gate with reviewers",
+		},
+		{
+			Name:       "worker-model",
+			EntityType: sdk.EntityTypeWorkerModel,
+			Language:   sdk.PolicyLanguageExpression,
+			Rule:       "true",
+			Outcome:    sdk.PolicyOutcomeWarn,
+			Message:    "not a workflow",
+		},
+	}
+	for i := range policies {
+		require.NoError(t, Validate(context.TODO(), &policies[i]))
+	}
+
+	input := sdk.PolicyInput{
+		Stage:      sdk.PolicyStageAnalysis,
+		ProjectKey: "PROJ",
+		EntityType: sdk.EntityTypeWorkflow,
+		EntityName: "deploy",
+		Entity:     sdk.V2Workflow{Name: "deploy", VariableSets: []string{"prod"}},
+	}
+	violations, err := Evaluate(context.TODO(), policies, input)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.PolicyViolation{{Policy: "prod-gate", Outcome: sdk.PolicyOutcomeDeny, Message: "workflows using the prod variable set must have a gate with reviewers"}}, violations)
+
+	input.Entity = sdk.V2Workflow{
+		Name:         "deploy",
+		VariableSets: []string{"prod"},
+		Gates:        map[string]sdk.V2JobGate{"approve": {Reviewers: sdk.V2JobGateReviewers{Groups: []string{"ops"}}}},
+	}
+	violations, err = Evaluate(context.TODO(), policies, input)
+	require.NoError(t, err)
+	require.Empty(t, violations)
+}
+
+func TestEvaluateRego(t *testing.T) {
+	p := sdk.Policy{
+		Name:       "library-models",
+		EntityType: sdk.EntityTypeWorkflow,
+		Language:   sdk.PolicyLanguageRego,
+		Rule: `package cds.models
+
+deny contains msg if {
+	job := input.entity.jobs[name]
+	job.region == "eu"
+	not startswith(job["runs-on"], "library/")
+	msg := sprintf("job %s must use a library worker model in region eu", [name])
+}
+
+warn contains msg if {
+	not input.entity.retention
+	msg := "no retention"
+}
+`,
+	}
+	require.NoError(t, Validate(context.TODO(), &p))
+
+	input := sdk.PolicyInput{
+		Stage:      sdk.PolicyStageCrafting,
+		EntityType: sdk.EntityTypeWorkflow,
+		EntityName: "build",
+		Entity: map[string]interface{}{
+			"jobs": map[string]interface{}{
+				"compile": map[string]interface{}{"region": "eu", "runs-on": "my-models/debian"},
+				"test":    map[string]interface{}{"region": "eu", "runs-on": "library/debian"},
+				"lint":    map[string]interface{}{"region": "us", "runs-on": "my-models/debian"},
+			},
+		},
+	}
+	violations, err := Evaluate(context.TODO(), []sdk.Policy{p}, input)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.PolicyViolation{
+		{Policy: "library-models", Outcome: sdk.PolicyOutcomeDeny, Message: "job compile must use a library worker model in region eu"},
+		{Policy: "library-models", Outcome: sdk.PolicyOutcomeWarn, Message: "no retention"},
+	}, violations)
+
+	p.Rule = "package cds\n\ndeny contains msg if {"
+	require.Error(t, Validate(context.TODO(), &p))
+}
+
+func TestEvaluateRegoUnsafeBuiltins(t *testing.T) {
+	for _, call := range []string{
+		`http.send({"method": "get", "url": "http://169.254.169.254"})`,
+		`net.lookup_ip_addr("internal.local")`,
+		`opa.runtime()`,
+	} {
+		p := sdk.Policy{
+			Name:       "unsafe",
+			EntityType: sdk.EntityTypeWorkflow,
+			Language:   sdk.PolicyLanguageRego,
+			Rule:       "package cds\n\ndeny contains msg if {\n\tr := " + call + "\n\tmsg := sprintf(\"%v\", [r])\n}\n",
+		}
+		require.Error(t, Validate(context.TODO(), &p), call)
+	}
+}
+
+func TestLoadRegoQueryCache(t *testing.T) {
+	p := sdk.Policy{
+		ID:           sdk.UUID(),
+		Name:         "cached",
+		EntityType:   sdk.EntityTypeWorkflow,
+		Language:     sdk.PolicyLanguageRego,
+		Rule:         "package cds\n\ndeny contains \"first\" if { true }\n",
+		LastModified: time.Now(),
+	}
+	input := sdk.PolicyInput{EntityType: sdk.EntityTypeWorkflow, Entity: map[string]interface{}{}}
+	violations, err := Evaluate(context.TODO(), []sdk.Policy{p}, input)
+	require.NoError(t, err)
+	require.Equal(t, "first", violations[0].Message)
+
+	// Same version: the compiled query is reused
+	p.Rule = "package cds\n\ndeny contains \"second\" if { true }\n"
+	violations, err = Evaluate(context.TODO(), []sdk.Policy{p}, input)
+	require.NoError(t, err)
+	require.Equal(t, "first", violations[0].Message)
+
+	p.LastModified = p.LastModified.Add(time.Second)
+	violations, err = Evaluate(context.TODO(), []sdk.Policy{p}, input)
+	require.NoError(t, err)
+	require.Equal(t, "second", violations[0].Message)
+}
@@ -0,0 +1,33 @@
+package policy
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbProjectPolicy{}, "project_policy", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbOrganizationPolicy{}, "organization_policy", false, "id"))
+}
+
+type dbProjectPolicy struct {
+	sdk.Policy
+	ProjectKey string `db:"project_key"`
+}
+
+func (p dbProjectPolicy) policy() sdk.Policy {
+	res := p.Policy
+	res.ProjectKey = p.ProjectKey
+	return res
+}
+
+type dbOrganizationPolicy struct {
+	sdk.Policy
+	OrganizationID string `db:"organization_id"`
+}
+
+func (p dbOrganizationPolicy) policy() sdk.Policy {
+	res := p.Policy
+	res.OrganizationID = p.OrganizationID
+	return res
+}
@@ -230,9 +230,20 @@ func (api *API) updateProjectHandler() service.Handler {
 		// Update in DB is made given the primary key
 		proj.ID = p.ID
 		proj.VCSServers = p.VCSServers
-		if errUp := project.Update(api.mustDB(), proj); errUp != nil {
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+		if errUp := project.Update(tx, proj); errUp != nil {
 			return sdk.WrapError(errUp, "updateProject> Cannot update project %s", key)
 		}
+		if err := recordAudit(ctx, tx, sdk.EventProjectUpdated, proj.Key, proj.Key, p, proj); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
 		event.PublishUpdateProject(ctx, proj, p, getUserConsumer(ctx))
 		event_v2.PublishProjectEvent(ctx, api.Cache, sdk.EventProjectUpdated, *proj, *u.AuthConsumerUser.AuthentifiedUser)
 
@@ -606,6 +617,10 @@ func (api *API) postProjectHandler() service.Handler {
 			return err
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventProjectCreated, prj.Key, prj.Key, nil, prj); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -672,6 +687,9 @@ func (api *API) deleteProjectHandler() service.Handler {
 		if err := project.Delete(tx, p.Key); err != nil {
 			return err
 		}
+		if err := recordAudit(ctx, tx, sdk.EventProjectDeleted, p.Key, p.Key, p, nil); err != nil {
+			return err
+		}
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -149,6 +149,35 @@ func LoadKey(ctx context.Context, db gorp.SqlExecutor, id int64, keyName string)
 	return &k.ProjectKey, nil
 }
 
+// LoadKeyWithPrivateContentByProjectKey load a project key by its name with its private part
+func LoadKeyWithPrivateContentByProjectKey(ctx context.Context, db gorp.SqlExecutor, projectKey string, keyName string) (*sdk.ProjectKey, error) {
+	query := gorpmapping.NewQuery(`
+	SELECT project_key.*
+	FROM project_key
+	JOIN project ON project.id = project_key.project_id
+	WHERE project.projectKey = $1
+	AND project_key.name = $2
+	AND project_key.builtin = false
+	`).Args(projectKey, keyName)
+	var k dbProjectKey
+	found, err := gorpmapping.Get(ctx, db, query, &k, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	isValid, err := gorpmapping.CheckSignature(k, k.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !isValid {
+		log.Error(ctx, "project.LoadKeyWithPrivateContentByProjectKey> project key %d data corrupted", k.ID)
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &k.ProjectKey, nil
+}
+
 // DeleteProjectKey Delete the given key from the given project
 func DeleteProjectKey(db gorp.SqlExecutor, projectID int64, keyName string) error {
 	_, err := db.Exec("DELETE FROM project_key WHERE project_id = $1 AND name = $2", projectID, keyName)
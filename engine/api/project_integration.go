@@ -135,6 +135,10 @@ func (api *API) putProjectIntegrationHandler() service.Handler {
 			}
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventIntegrationUpdated, p.Key, projectIntegration.Name, ppDB, projectIntegration); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -184,6 +188,10 @@ func (api *API) deleteProjectIntegrationHandler() service.Handler {
 			}
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventIntegrationDeleted, projectKey, deletedIntegration.Name, deletedIntegration, nil); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -281,6 +289,10 @@ func (api *API) postProjectIntegrationHandler() service.Handler {
 			}
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventIntegrationCreated, projectKey, pp.Name, nil, pp); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
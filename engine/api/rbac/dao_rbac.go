@@ -33,6 +33,28 @@ func LoadRBACByIDs(ctx context.Context, db gorp.SqlExecutor, IDs sdk.StringSlice
 	return getAll(ctx, db, gorpmapping.NewQuery(query).Args(pq.StringArray(IDs)), opts...)
 }
 
+// LoadAllRBACByProjectKey loads all the rules that give a permission on the project, its workflows or its variable sets
+func LoadAllRBACByProjectKey(ctx context.Context, db gorp.SqlExecutor, projectKey string, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
+	query := `
+		SELECT * FROM rbac WHERE id IN (
+			SELECT rbac_project.rbac_id FROM rbac_project
+			JOIN rbac_project_keys ON rbac_project_keys.rbac_project_id = rbac_project.id
+			WHERE rbac_project_keys.project_key = $1
+			UNION
+			SELECT rbac_id FROM rbac_workflow WHERE project_key = $1
+			UNION
+			SELECT rbac_id FROM rbac_variableset WHERE project_key = $1
+		)
+		ORDER BY name`
+	return getAll(ctx, db, gorpmapping.NewQuery(query).Args(projectKey), opts...)
+}
+
+// LoadAllRBACByWorkflowRole loads all the rules that give the role on workflows
+func LoadAllRBACByWorkflowRole(ctx context.Context, db gorp.SqlExecutor, role string, opts ...LoadOptionFunc) ([]sdk.RBAC, error) {
+	query := `SELECT * FROM rbac WHERE id IN (SELECT rbac_id FROM rbac_workflow WHERE role = $1) ORDER BY name`
+	return getAll(ctx, db, gorpmapping.NewQuery(query).Args(role), opts...)
+}
+
 // Insert a RBAC permission in database
 func Insert(ctx context.Context, db gorpmapper.SqlExecutorWithTx, rb *sdk.RBAC) error {
 	if err := IsValidRBAC(ctx, db, rb); err != nil {
@@ -0,0 +1,197 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+// InsertGrant inserts a temporary grant request
+func InsertGrant(ctx context.Context, db gorpmapper.SqlExecutorWithTx, g *sdk.RBACGrant) error {
+	if g.ID == "" {
+		g.ID = sdk.UUID()
+	}
+	if g.Created.IsZero() {
+		g.Created = time.Now()
+	}
+	dbGrant := rbacGrant{RBACGrant: *g}
+	if err := gorpmapping.InsertAndSign(ctx, db, &dbGrant); err != nil {
+		return err
+	}
+	*g = dbGrant.RBACGrant
+	return nil
+}
+
+// UpdateGrant updates a temporary grant
+func UpdateGrant(ctx context.Context, db gorpmapper.SqlExecutorWithTx, g *sdk.RBACGrant) error {
+	dbGrant := rbacGrant{RBACGrant: *g}
+	if err := gorpmapping.UpdateAndSign(ctx, db, &dbGrant); err != nil {
+		return err
+	}
+	*g = dbGrant.RBACGrant
+	return nil
+}
+
+// LoadGrantByID loads a temporary grant
+func LoadGrantByID(ctx context.Context, db gorp.SqlExecutor, id string) (*sdk.RBACGrant, error) {
+	q := gorpmapping.NewQuery(`SELECT * FROM rbac_grant WHERE id = $1`).Args(id)
+	var dbGrant rbacGrant
+	found, err := gorpmapping.Get(ctx, db, q, &dbGrant)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	isValid, err := gorpmapping.CheckSignature(dbGrant, dbGrant.Signature)
+	if err != nil {
+		return nil, sdk.WrapError(err, "error when checking signature for rbac_grant %s", dbGrant.ID)
+	}
+	if !isValid {
+		log.Error(ctx, "rbac.LoadGrantByID> rbac_grant %s data corrupted", dbGrant.ID)
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &dbGrant.RBACGrant, nil
+}
+
+// LoadGrantsByStatus loads all temporary grants with one of the given status. All grants are returned if no status is given.
+func LoadGrantsByStatus(ctx context.Context, db gorp.SqlExecutor, status ...string) ([]sdk.RBACGrant, error) {
+	if len(status) == 0 {
+		return getAllGrants(ctx, db, gorpmapping.NewQuery(`SELECT * FROM rbac_grant ORDER BY created DESC`))
+	}
+	q := gorpmapping.NewQuery(`SELECT * FROM rbac_grant WHERE status = ANY($1) ORDER BY created DESC`).Args(pq.StringArray(status))
+	return getAllGrants(ctx, db, q)
+}
+
+// LoadGrantsByUserID loads all temporary grants requested by the given user
+func LoadGrantsByUserID(ctx context.Context, db gorp.SqlExecutor, userID string) ([]sdk.RBACGrant, error) {
+	q := gorpmapping.NewQuery(`SELECT * FROM rbac_grant WHERE user_id = $1 ORDER BY created DESC`).Args(userID)
+	return getAllGrants(ctx, db, q)
+}
+
+// LoadExpiredGrants loads approved grants whose expiration date is passed
+func LoadExpiredGrants(ctx context.Context, db gorp.SqlExecutor) ([]sdk.RBACGrant, error) {
+	q := gorpmapping.NewQuery(`SELECT * FROM rbac_grant WHERE status = $1 AND expires <= $2`).Args(sdk.RBACGrantStatusApproved, time.Now())
+	return getAllGrants(ctx, db, q)
+}
+
+func loadActiveGrants(ctx context.Context, db gorp.SqlExecutor, userID string, scope string, projectKey string, role string) ([]sdk.RBACGrant, error) {
+	q := gorpmapping.NewQuery(`
+		SELECT * FROM rbac_grant
+		WHERE user_id = $1 AND scope = $2 AND project_key = $3 AND role = $4 AND status = $5 AND expires > $6`).
+		Args(userID, scope, projectKey, role, sdk.RBACGrantStatusApproved, time.Now())
+	return getAllGrants(ctx, db, q)
+}
+
+func getAllGrants(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.RBACGrant, error) {
+	var dbGrants []rbacGrant
+	if err := gorpmapping.GetAll(ctx, db, q, &dbGrants); err != nil {
+		return nil, err
+	}
+	grants := make([]sdk.RBACGrant, 0, len(dbGrants))
+	for _, g := range dbGrants {
+		isValid, err := gorpmapping.CheckSignature(g, g.Signature)
+		if err != nil {
+			return nil, sdk.WrapError(err, "error when checking signature for rbac_grant %s", g.ID)
+		}
+		if !isValid {
+			log.Error(ctx, "rbac.getAllGrants> rbac_grant %s data corrupted", g.ID)
+			continue
+		}
+		grants = append(grants, g.RBACGrant)
+	}
+	return grants, nil
+}
+
+// findActiveGrant returns the first active grant given to the user for the role on the project.
+// For variable set and workflow scopes, the target of the grant is a pattern matched against the given target.
+func findActiveGrant(ctx context.Context, db gorp.SqlExecutor, userID string, scope string, projectKey string, role string, target string) (*sdk.RBACGrant, error) {
+	grants, err := loadActiveGrants(ctx, db, userID, scope, projectKey, role)
+	if err != nil {
+		return nil, err
+	}
+	for i := range grants {
+		if scope != sdk.RBACGrantScopeProject {
+			match, err := matchGlobs([]string{grants[i].Target}, target)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+		return &grants[i], nil
+	}
+	return nil, nil
+}
+
+// useActiveGrant looks for an active grant and audits its use
+func useActiveGrant(ctx context.Context, db gorp.SqlExecutor, userID string, scope string, projectKey string, role string, target string) (*sdk.RBACGrant, error) {
+	g, err := findActiveGrant(ctx, db, userID, scope, projectKey, role, target)
+	if err != nil || g == nil {
+		return nil, err
+	}
+	auditGrantUse(ctx, db, *g, target)
+	return g, nil
+}
+
+// grantUseAudits keeps the grant uses already audited, to audit a grant only once per session and target
+var grantUseAudits = gocache.New(time.Hour, 10*time.Minute)
+
+// auditGrantUse records that a grant gave a permission, the first time it is used in the session on the target.
+// Failing to audit the use doesn't deny the permission.
+func auditGrantUse(ctx context.Context, db gorp.SqlExecutor, g sdk.RBACGrant, target string) {
+	session, _ := ctx.Value(cdslog.AuthSessionID).(string)
+	if session == "" {
+		session, _ = ctx.Value(cdslog.RequestID).(string)
+	}
+	key := fmt.Sprintf("%s-%s-%s", g.ID, session, target)
+	if err := grantUseAudits.Add(key, true, gocache.DefaultExpiration); err != nil {
+		// Already audited
+		return
+	}
+
+	details := fmt.Sprintf("role %s used on %s %s", g.Role, g.Scope, g.ProjectKey)
+	if target != "" {
+		details += "/" + target
+	}
+	if err := InsertGrantAudit(db, &g, sdk.AuditRBACGrantUse, g.Username, details); err != nil {
+		grantUseAudits.Delete(key)
+		log.ErrorWithStackTrace(ctx, err)
+	}
+}
+
+// InsertGrantAudit records an action done on a temporary grant
+func InsertGrantAudit(db gorp.SqlExecutor, g *sdk.RBACGrant, eventType string, triggeredBy string, details string) error {
+	a := sdk.AuditRBACGrant{
+		AuditCommon: sdk.AuditCommon{
+			EventType:   eventType,
+			Created:     time.Now(),
+			TriggeredBy: triggeredBy,
+		},
+		GrantID:    g.ID,
+		ProjectKey: g.ProjectKey,
+		Details:    details,
+	}
+	return sdk.WrapError(gorpmapping.Insert(db, &a), "unable to insert audit for rbac grant %s", g.ID)
+}
+
+// LoadGrantAudits loads all audits of a temporary grant
+func LoadGrantAudits(ctx context.Context, db gorp.SqlExecutor, grantID string) ([]sdk.AuditRBACGrant, error) {
+	var audits []sdk.AuditRBACGrant
+	q := gorpmapping.NewQuery(`SELECT * FROM rbac_grant_audit WHERE grant_id = $1 ORDER BY created ASC`).Args(grantID)
+	if err := gorpmapping.GetAll(ctx, db, q, &audits); err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
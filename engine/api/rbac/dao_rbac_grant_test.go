@@ -0,0 +1,130 @@
+package rbac_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestHasRoleWithTemporaryGrant(t *testing.T) {
+	db, cache := test.SetupPG(t)
+	ctx := context.TODO()
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key)
+	u, _ := assets.InsertLambdaUser(t, db)
+
+	hasRole, err := rbac.HasRoleOnVariableSetAndUserID(ctx, db, sdk.VariableSetRoleManageItem, u.ID, proj.Key, "prod-secrets")
+	require.NoError(t, err)
+	require.False(t, hasRole)
+
+	grant := sdk.RBACGrant{
+		UserID:        u.ID,
+		Username:      u.Username,
+		Scope:         sdk.RBACGrantScopeVariableSet,
+		ProjectKey:    proj.Key,
+		Target:        "prod-*",
+		Role:          sdk.VariableSetRoleManageItem,
+		Justification: "incident",
+		Duration:      "1h",
+		Status:        sdk.RBACGrantStatusRequested,
+	}
+	require.NoError(t, rbac.InsertGrant(ctx, db, &grant))
+
+	// A grant waiting for approval gives nothing
+	hasRole, err = rbac.HasRoleOnVariableSetAndUserID(ctx, db, sdk.VariableSetRoleManageItem, u.ID, proj.Key, "prod-secrets")
+	require.NoError(t, err)
+	require.False(t, hasRole)
+
+	expires := time.Now().Add(time.Hour)
+	grant.Status = sdk.RBACGrantStatusApproved
+	grant.Expires = &expires
+	require.NoError(t, rbac.UpdateGrant(ctx, db, &grant))
+
+	hasRole, err = rbac.HasRoleOnVariableSetAndUserID(ctx, db, sdk.VariableSetRoleManageItem, u.ID, proj.Key, "prod-secrets")
+	require.NoError(t, err)
+	require.True(t, hasRole)
+	hasRole, err = rbac.HasRoleOnVariableSetAndUserID(ctx, db, sdk.VariableSetRoleManageItem, u.ID, proj.Key, "dev-secrets")
+	require.NoError(t, err)
+	require.False(t, hasRole)
+	hasRole, err = rbac.HasRoleOnProjectAndUserID(ctx, db, sdk.ProjectRoleManage, u.ID, proj.Key)
+	require.NoError(t, err)
+	require.False(t, hasRole)
+
+	// The use of the grant is audited once on the same target
+	hasRole, err = rbac.HasRoleOnVariableSetAndUserID(ctx, db, sdk.VariableSetRoleManageItem, u.ID, proj.Key, "prod-secrets")
+	require.NoError(t, err)
+	require.True(t, hasRole)
+
+	audits, err := rbac.LoadGrantAudits(ctx, db, grant.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	require.Equal(t, sdk.AuditRBACGrantUse, audits[0].EventType)
+
+	// An expired grant gives nothing
+	expired := time.Now().Add(-time.Minute)
+	grant.Expires = &expired
+	require.NoError(t, rbac.UpdateGrant(ctx, db, &grant))
+	hasRole, err = rbac.HasRoleOnVariableSetAndUserID(ctx, db, sdk.VariableSetRoleManageItem, u.ID, proj.Key, "prod-secrets")
+	require.NoError(t, err)
+	require.False(t, hasRole)
+
+	expiredGrants, err := rbac.LoadExpiredGrants(ctx, db)
+	require.NoError(t, err)
+	var found bool
+	for _, g := range expiredGrants {
+		found = found || g.ID == grant.ID
+	}
+	require.True(t, found)
+}
+
+func TestCheckRoleOnProjectWithTemporaryGrant(t *testing.T) {
+	db, cache := test.SetupPG(t)
+	ctx := context.TODO()
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, cache, key, key)
+	u, _ := assets.InsertLambdaUser(t, db)
+
+	expires := time.Now().Add(time.Hour)
+	grant := sdk.RBACGrant{
+		UserID:        u.ID,
+		Username:      u.Username,
+		Scope:         sdk.RBACGrantScopeProject,
+		ProjectKey:    proj.Key,
+		Role:          sdk.ProjectRoleManage,
+		Justification: "incident",
+		Duration:      "1h",
+		Status:        sdk.RBACGrantStatusApproved,
+		Expires:       &expires,
+	}
+	require.NoError(t, rbac.InsertGrant(ctx, db, &grant))
+
+	// Checking the role doesn't audit the use of the grant
+	hasRole, err := rbac.CheckRoleOnProjectAndUserID(ctx, db, sdk.ProjectRoleManage, u.ID, proj.Key)
+	require.NoError(t, err)
+	require.True(t, hasRole)
+	audits, err := rbac.LoadGrantAudits(ctx, db, grant.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 0)
+
+	hasRole, err = rbac.HasRoleOnProjectAndUserID(ctx, db, sdk.ProjectRoleManage, u.ID, proj.Key)
+	require.NoError(t, err)
+	require.True(t, hasRole)
+	audits, err = rbac.LoadGrantAudits(ctx, db, grant.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+}
@@ -64,11 +64,31 @@ func HasRoleOnProjectAndVCSUser(ctx context.Context, db gorp.SqlExecutor, role s
 func HasRoleOnProjectAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string) (bool, error) {
 	ctx, next := telemetry.Span(ctx, "rbac.HasRoleOnProjectAndUserID")
 	defer next()
+	return hasRoleOnProjectAndUserID(ctx, db, role, userID, projectKey, useActiveGrant)
+}
+
+// CheckRoleOnProjectAndUserID returns true if the user has the role on the project, without auditing the use of a temporary grant.
+// It is used to explain the permissions of a user, when the role is not used to access the project.
+func CheckRoleOnProjectAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string) (bool, error) {
+	ctx, next := telemetry.Span(ctx, "rbac.CheckRoleOnProjectAndUserID")
+	defer next()
+	return hasRoleOnProjectAndUserID(ctx, db, role, userID, projectKey, findActiveGrant)
+}
+
+func hasRoleOnProjectAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string,
+	loadGrant func(ctx context.Context, db gorp.SqlExecutor, userID string, scope string, projectKey string, role string, target string) (*sdk.RBACGrant, error)) (bool, error) {
 	projectKeys, err := LoadAllProjectKeysAllowed(ctx, db, role, userID)
 	if err != nil {
 		return false, err
 	}
-	return sdk.IsInArray(projectKey, projectKeys), nil
+	if sdk.IsInArray(projectKey, projectKeys) {
+		return true, nil
+	}
+	grant, err := loadGrant(ctx, db, userID, sdk.RBACGrantScopeProject, projectKey, role, "")
+	if err != nil {
+		return false, err
+	}
+	return grant != nil, nil
 }
 
 func loadPublicProjectKeysByRole(ctx context.Context, db gorp.SqlExecutor, role string) (sdk.StringSlice, error) {
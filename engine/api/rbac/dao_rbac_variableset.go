@@ -97,7 +97,11 @@ func HasRoleOnVariableSetAndUserID(ctx context.Context, db gorp.SqlExecutor, rol
 			return true, nil
 		}
 	}
-	return false, nil
+	grant, err := useActiveGrant(ctx, db, userID, sdk.RBACGrantScopeVariableSet, projectKey, role, vsName)
+	if err != nil {
+		return false, err
+	}
+	return grant != nil, nil
 }
 
 func HasRoleOnVariableSetsAndVCSUser(ctx context.Context, db gorp.SqlExecutor, role string, user sdk.RBACVCSUser, projectKey string, vsNames []string) (bool, string, error) {
@@ -141,7 +145,14 @@ func HasRoleOnVariableSetsAndUserID(ctx context.Context, db gorp.SqlExecutor, ro
 		return true, "", nil
 	}
 	for _, v := range vsNames {
-		if !variableSets.Contains(v) {
+		if variableSets.Contains(v) {
+			continue
+		}
+		grant, err := useActiveGrant(ctx, db, userID, sdk.RBACGrantScopeVariableSet, projectKey, role, v)
+		if err != nil {
+			return false, "", err
+		}
+		if grant == nil {
 			return false, v, nil
 		}
 	}
@@ -3,6 +3,7 @@ package rbac
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-gorp/gorp"
 	"github.com/lib/pq"
@@ -77,136 +78,186 @@ func getAllRBACWorkflows(ctx context.Context, db gorp.SqlExecutor, q gorpmapping
 	return worflowsFiltered, nil
 }
 
-func HasRoleOnWorkflowAndVCSUsername(ctx context.Context, db gorp.SqlExecutor, role string, VCSUser sdk.RBACVCSUser, projectKey string, vcs, repo, workflowName string) (bool, error) {
-	workflowNamePerm := fmt.Sprintf("%s/%s/%s", vcs, repo, workflowName)
-
-	workflows, allWorkflowAllowed, err := LoadAllWorkflowsAllowedForVCSUSer(ctx, db, role, projectKey, VCSUser)
+func HasRoleOnWorkflowAndVCSUsername(ctx context.Context, db gorp.SqlExecutor, role string, VCSUser sdk.RBACVCSUser, projectKey string, vcs, repo, workflowName, ref string) (bool, error) {
+	check, err := CheckRoleOnWorkflow(ctx, db, role, "", &VCSUser, projectKey, vcs, repo, workflowName, ref)
 	if err != nil {
 		return false, err
 	}
-	log.Info(ctx, "HasRoleOnWorkflowAndVCSUsername> granted workflows for %s/%s: %+v , allWorkflowAllowed=%v", VCSUser.VCSServer, VCSUser.VCSUsername, workflows, allWorkflowAllowed)
-
-	if allWorkflowAllowed {
-		return true, nil
-	}
-	for _, item := range workflows {
-		g := glob.New(item)
-		r, err := g.MatchString(workflowNamePerm)
-		if err != nil {
-			return false, err
-		}
-		if r != nil {
-			return true, nil
-		}
-	}
-	return false, nil
+	log.Info(ctx, "HasRoleOnWorkflowAndVCSUsername> role %s on %s for %s/%s: %v", role, check.Workflow, VCSUser.VCSServer, VCSUser.VCSUsername, check.Granted)
+	return check.Granted, nil
 }
 
-func HasRoleOnWorkflowAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string, vcs, repo, workflowName string) (bool, error) {
+func HasRoleOnWorkflowAndUserID(ctx context.Context, db gorp.SqlExecutor, role string, userID string, projectKey string, vcs, repo, workflowName, ref string) (bool, error) {
 	ctx, next := telemetry.Span(ctx, "rbac.HasRoleOnWorkflowAndUserID")
 	defer next()
 
-	workflowNamePerm := fmt.Sprintf("%s/%s/%s", vcs, repo, workflowName)
-
-	workflows, allWorkflowAllowed, err := LoadAllWorkflowsAllowed(ctx, db, role, projectKey, userID)
+	check, grant, err := checkRoleOnWorkflow(ctx, db, role, userID, nil, projectKey, vcs, repo, workflowName, ref)
 	if err != nil {
 		return false, err
 	}
-	if allWorkflowAllowed {
-		return true, nil
+	if grant != nil {
+		auditGrantUse(ctx, db, *grant, check.Workflow)
 	}
-	for _, item := range workflows {
-		g := glob.New(item)
-		r, err := g.MatchString(workflowNamePerm)
+	return check.Granted, nil
+}
+
+// CheckRoleOnWorkflow evaluates all the workflow permissions of a project for the given role and explains which ones granted or denied it.
+// The permissions are evaluated for the VCS user if it is set, otherwise for the user and its groups.
+// A permission restricted to git refs never grants the role if the ref is unknown.
+func CheckRoleOnWorkflow(ctx context.Context, db gorp.SqlExecutor, role string, userID string, vcsUser *sdk.RBACVCSUser, projectKey string, vcs, repo, workflowName, ref string) (*sdk.RBACWorkflowCheck, error) {
+	check, _, err := checkRoleOnWorkflow(ctx, db, role, userID, vcsUser, projectKey, vcs, repo, workflowName, ref)
+	return check, err
+}
+
+// checkRoleOnWorkflow also returns the temporary grant of the user if it is the only one giving the role
+func checkRoleOnWorkflow(ctx context.Context, db gorp.SqlExecutor, role string, userID string, vcsUser *sdk.RBACVCSUser, projectKey string, vcs, repo, workflowName, ref string) (*sdk.RBACWorkflowCheck, *sdk.RBACGrant, error) {
+	check := sdk.RBACWorkflowCheck{
+		Role:     role,
+		Workflow: fmt.Sprintf("%s/%s/%s", vcs, repo, workflowName),
+		Ref:      ref,
+		Rules:    make([]sdk.RBACWorkflowRuleCheck, 0),
+	}
+
+	var groupIDs sdk.Int64Slice
+	if vcsUser == nil {
+		groups, err := group.LoadAllByUserID(ctx, db, userID)
 		if err != nil {
-			return false, err
+			return nil, nil, err
 		}
-		if r != nil {
-			return true, nil
+		groupIDs = make(sdk.Int64Slice, 0, len(groups))
+		for _, g := range groups {
+			groupIDs = append(groupIDs, g.ID)
 		}
 	}
-	return false, nil
-}
-
-func LoadAllWorkflowsAllowedForVCSUSer(ctx context.Context, db gorp.SqlExecutor, role string, projectKey string, user sdk.RBACVCSUser) (sdk.StringSlice, bool, error) {
-	workflows := sdk.StringSlice{}
 
 	rbacWorkflows, err := loadRBACWorkflowsByProjectAndRole(ctx, db, projectKey, role)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, err
+	}
+	if len(rbacWorkflows) == 0 {
+		return checkGrantOnWorkflow(ctx, db, &check, userID, vcsUser, projectKey)
 	}
 
+	rbacIDs := make(sdk.StringSlice, 0, len(rbacWorkflows))
 	for _, rw := range rbacWorkflows {
-		if rw.AllUsers {
-			if rw.AllWorkflows {
-				return nil, true, nil
-			}
-			workflows = append(workflows, rw.RBACWorkflowsNames...)
-			continue
+		rbacIDs = append(rbacIDs, rw.RbacID)
+	}
+	rbacIDs.Unique()
+	rbacs, err := LoadRBACByIDs(ctx, db, rbacIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	rbacNames := make(map[string]string, len(rbacs))
+	for _, r := range rbacs {
+		rbacNames[r.ID] = r.Name
+	}
+
+	for _, rw := range rbacWorkflows {
+		ruleCheck := sdk.RBACWorkflowRuleCheck{
+			RBACName: rbacNames[rw.RbacID],
+			Role:     role,
 		}
-		for _, rbacVCSUser := range rw.RBACVCSUsers {
-			log.Info(ctx, "LoadAllWorkflowsAllowedForVCSUSer> checking %s/%s against %s/%s", user.VCSServer, user.VCSUsername, rbacVCSUser.VCSServer, rbacVCSUser.VCSUsername)
-			if rbacVCSUser.VCSServer == user.VCSServer && rbacVCSUser.VCSUsername == user.VCSUsername {
-				if rw.AllWorkflows {
-					log.Info(ctx, "LoadAllWorkflowsAllowedForVCSUSer> %s/%s is allowed on all workflows of project %s", rbacVCSUser.VCSServer, rbacVCSUser.VCSUsername, projectKey)
-					return nil, true, nil
-				}
-				log.Info(ctx, "LoadAllWorkflowsAllowedForVCSUSer> %s/%s is allowed on workflows %+v of project %s", rbacVCSUser.VCSServer, rbacVCSUser.VCSUsername, rw.RBACWorkflowsNames, projectKey)
-				workflows = append(workflows, rw.RBACWorkflowsNames...)
-				break
-			}
+		matchWorkflow, err := rw.matchWorkflow(check.Workflow)
+		if err != nil {
+			return nil, nil, err
+		}
+		matchRef, err := rw.matchRef(ref)
+		if err != nil {
+			return nil, nil, err
 		}
+		switch {
+		case !rw.matchSubject(userID, groupIDs, vcsUser):
+			ruleCheck.Reason = "the permission is not given to the user or its groups"
+		case !matchWorkflow:
+			ruleCheck.Reason = fmt.Sprintf("workflow %s doesn't match %v", check.Workflow, rw.RBACWorkflowsNames)
+		case !matchRef && ref == "":
+			ruleCheck.Reason = fmt.Sprintf("the permission is restricted to git refs %v", rw.RBACRefs)
+		case !matchRef:
+			ruleCheck.Reason = fmt.Sprintf("git ref %s doesn't match %v", ref, rw.RBACRefs)
+		default:
+			ruleCheck.Granted = true
+			ruleCheck.Reason = "granted"
+			check.Granted = true
+		}
+		check.Rules = append(check.Rules, ruleCheck)
 	}
-
-	return workflows, false, nil
+	return checkGrantOnWorkflow(ctx, db, &check, userID, vcsUser, projectKey)
 }
 
-func LoadAllWorkflowsAllowed(ctx context.Context, db gorp.SqlExecutor, role string, projectKey string, userID string) (sdk.StringSlice, bool, error) {
-	workflows := sdk.StringSlice{}
-
-	groups, err := group.LoadAllByUserID(ctx, db, userID)
+// checkGrantOnWorkflow completes the check with the active temporary grant of the user if no permission gives the role
+func checkGrantOnWorkflow(ctx context.Context, db gorp.SqlExecutor, check *sdk.RBACWorkflowCheck, userID string, vcsUser *sdk.RBACVCSUser, projectKey string) (*sdk.RBACWorkflowCheck, *sdk.RBACGrant, error) {
+	if check.Granted || vcsUser != nil {
+		return check, nil, nil
+	}
+	grant, err := findActiveGrant(ctx, db, userID, sdk.RBACGrantScopeWorkflow, projectKey, check.Role, check.Workflow)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, err
 	}
-	groupIDs := make(sdk.Int64Slice, 0, len(groups))
-	for _, g := range groups {
-		groupIDs = append(groupIDs, g.ID)
+	if grant == nil {
+		return check, nil, nil
 	}
+	check.Granted = true
+	check.Rules = append(check.Rules, sdk.RBACWorkflowRuleCheck{
+		RBACName: "grant/" + grant.ID,
+		Role:     check.Role,
+		Granted:  true,
+		Reason:   fmt.Sprintf("temporary grant approved by %s until %s", grant.ReviewedBy, grant.Expires.Format(time.RFC3339)),
+	})
+	return check, grant, nil
+}
 
-	rbacWorkflows, err := loadRBACWorkflowsByProjectAndRole(ctx, db, projectKey, role)
-	if err != nil {
-		return nil, false, err
+func (rw rbacWorkflow) matchSubject(userID string, groupIDs sdk.Int64Slice, vcsUser *sdk.RBACVCSUser) bool {
+	if rw.AllUsers {
+		return true
 	}
-
-	for _, rw := range rbacWorkflows {
-		if rw.AllUsers {
-			if rw.AllWorkflows {
-				return nil, true, nil
-			}
-			workflows = append(workflows, rw.RBACWorkflowsNames...)
-			continue
-		}
-		for _, rbacUserID := range rw.RBACUsersIDs {
-			if rbacUserID == userID {
-				if rw.AllWorkflows {
-					return nil, true, nil
-				}
-				workflows = append(workflows, rw.RBACWorkflowsNames...)
-				continue
+	if vcsUser != nil {
+		for _, u := range rw.RBACVCSUsers {
+			if u.VCSServer == vcsUser.VCSServer && u.VCSUsername == vcsUser.VCSUsername {
+				return true
 			}
 		}
-		for _, groupID := range rw.RBACGroupsIDs {
-			if groupIDs.Contains(groupID) {
-				if rw.AllWorkflows {
-					return nil, true, nil
-				}
-				workflows = append(workflows, rw.RBACWorkflowsNames...)
-				continue
-			}
+		return false
+	}
+	if sdk.IsInArray(userID, rw.RBACUsersIDs) {
+		return true
+	}
+	for _, groupID := range rw.RBACGroupsIDs {
+		if groupIDs.Contains(groupID) {
+			return true
 		}
+	}
+	return false
+}
+
+func (rw rbacWorkflow) matchWorkflow(workflowNamePerm string) (bool, error) {
+	if rw.AllWorkflows {
+		return true, nil
+	}
+	return matchGlobs(rw.RBACWorkflowsNames, workflowNamePerm)
+}
+
+func (rw rbacWorkflow) matchRef(ref string) (bool, error) {
+	if len(rw.RBACRefs) == 0 {
+		return true, nil
+	}
+	if ref == "" {
+		return false, nil
+	}
+	return matchGlobs(rw.RBACRefs, ref)
+}
 
+func matchGlobs(patterns []string, s string) (bool, error) {
+	for _, item := range patterns {
+		g := glob.New(item)
+		r, err := g.MatchString(s)
+		if err != nil {
+			return false, err
+		}
+		if r != nil {
+			return true, nil
+		}
 	}
-	return workflows, false, nil
+	return false, nil
 }
 
 func loadRBACWorkflowsByIDs(ctx context.Context, db gorp.SqlExecutor, ids []int64) ([]rbacWorkflow, error) {
@@ -296,6 +296,18 @@ func (rgg rbacWorkflowGroup) Canonical() gorpmapper.CanonicalForms {
 	}
 }
 
+type rbacGrant struct {
+	sdk.RBACGrant
+	gorpmapper.SignedEntity
+}
+
+func (rg rbacGrant) Canonical() gorpmapper.CanonicalForms {
+	_ = []interface{}{rg.ID, rg.UserID, rg.Scope, rg.ProjectKey, rg.Target, rg.Role, rg.Status, rg.Expires}
+	return []gorpmapper.CanonicalForm{
+		"{{.ID}}{{.UserID}}{{.Scope}}{{.ProjectKey}}{{.Target}}{{.Role}}{{.Status}}{{if .Expires}}{{printDate .Expires}}{{end}}",
+	}
+}
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(rbac{}, "rbac", false, "id"))
 	gorpmapping.Register(gorpmapping.New(rbacGlobal{}, "rbac_global", true, "id"))
@@ -325,4 +337,7 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(rbacRegionProject{}, "rbac_region_project", true, "id"))
 	gorpmapping.Register(gorpmapping.New(rbacRegionProjectKey{}, "rbac_region_project_keys_project", true, "id"))
 
+	gorpmapping.Register(gorpmapping.New(rbacGrant{}, "rbac_grant", false, "id"))
+	gorpmapping.Register(gorpmapping.New(sdk.AuditRBACGrant{}, "rbac_grant_audit", true, "id"))
+
 }
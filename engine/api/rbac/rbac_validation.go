@@ -2,6 +2,7 @@ package rbac
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-gorp/gorp"
 
@@ -81,6 +82,13 @@ func isValidRBACWorkflow(rbacName string, rbacWorkflow sdk.RBACWorkflow) error {
 	if !roleFound {
 		return sdk.NewErrorFrom(sdk.ErrInvalidData, "rbac %s: role %s is not allowed on a workflow permission", rbacName, rbacWorkflow.Role)
 	}
+
+	// Check git refs patterns
+	for _, ref := range rbacWorkflow.RBACRefs {
+		if strings.TrimSpace(ref) == "" {
+			return sdk.NewErrorFrom(sdk.ErrInvalidData, "rbac %s: git ref pattern on workflow permission cannot be empty", rbacName)
+		}
+	}
 	return nil
 }
 
@@ -50,7 +50,7 @@ func (api *API) getReposFromRepositoriesManagerHandler() service.Handler {
 				"cannot get client got %s %s", projectKey, vcsServerName))
 		}
 
-		repos, err := repositoriesmanager.GetReposForProjectVCSServer(ctx, tx, api.Cache, *proj, vcsServerName, repositoriesmanager.Options{
+		repos, err := repositoriesmanager.GetReposForProjectVCSServer(ctx, tx, api.Cache, *proj, vcsServerName, project.LoadKeyWithPrivateContentByProjectKey, repositoriesmanager.Options{
 			Sync: sync,
 		})
 		if err != nil {
@@ -77,7 +77,7 @@ func (api *API) getRepoFromRepositoriesManagerHandler() service.Handler {
 			return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("Missing repository name 'repo' as a query parameter"))
 		}
 
-		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projectKey, rmName)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projectKey, rmName, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth,
 				"cannot get client got %s %s", projectKey, rmName))
@@ -114,7 +114,7 @@ func (api *API) attachRepositoriesManagerHandler() service.Handler {
 		}
 
 		//Get an authorized Client
-		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, projectKey, rmName)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, projectKey, rmName, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "cannot get client got %s %s : %s", projectKey, rmName, err)
 		}
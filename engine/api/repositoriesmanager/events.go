@@ -64,7 +64,7 @@ func processEvent(ctx context.Context, db *gorp.DbMap, event sdk.Event, store ca
 		return nil
 	}
 
-	c, err := AuthorizedClient(ctx, db, store, event.ProjectKey, eventWNR.RepositoryManagerName)
+	c, err := AuthorizedClient(ctx, db, store, event.ProjectKey, eventWNR.RepositoryManagerName, nil)
 	if err != nil {
 		return sdk.WrapError(err, "AuthorizedClient (%s, %s)", event.ProjectKey, eventWNR.RepositoryManagerName)
 	}
@@ -57,10 +57,10 @@ type Options struct {
 	Sync bool
 }
 
-func GetReposForProjectVCSServer(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, vcsServerName string, opts Options) ([]sdk.VCSRepo, error) {
+func GetReposForProjectVCSServer(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, vcsServerName string, loadKey ProjectKeyLoader, opts Options) ([]sdk.VCSRepo, error) {
 	log.Debug(ctx, "GetReposForProjectVCSServer> Loading repo for %s", vcsServerName)
 
-	client, err := AuthorizedClient(ctx, db, store, proj.Key, vcsServerName)
+	client, err := AuthorizedClient(ctx, db, store, proj.Key, vcsServerName, loadKey)
 	if err != nil {
 		return nil, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth,
 			"cannot get client got %s %s", proj.Key, vcsServerName))
@@ -91,13 +91,30 @@ func GetReposForProjectVCSServer(ctx context.Context, db gorp.SqlExecutor, store
 	return repos, nil
 }
 
-// AuthorizedClient returns an implementation of AuthorizedClient wrapping calls to vcs uService
-func AuthorizedClient(ctx context.Context, db gorp.SqlExecutor, store cache.Store, projectKey string, vcsName string) (sdk.VCSAuthorizedClientService, error) {
+// ProjectKeyLoader loads a project key with its private part. The loader is given by the caller because the project package
+// depends on repositoriesmanager.
+type ProjectKeyLoader func(ctx context.Context, db gorp.SqlExecutor, projectKey string, keyName string) (*sdk.ProjectKey, error)
+
+// AuthorizedClient returns an implementation of AuthorizedClient wrapping calls to vcs uService.
+// loadKey is used to load the SSH key of a plain git VCS, it can be nil for callers that don't support plain git.
+func AuthorizedClient(ctx context.Context, db gorp.SqlExecutor, store cache.Store, projectKey string, vcsName string, loadKey ProjectKeyLoader) (sdk.VCSAuthorizedClientService, error) {
 	vcsProject, err := vcs.LoadVCSByProject(ctx, db, projectKey, vcsName, gorpmapping.GetOptions.WithDecryption)
 	if err != nil {
 		return nil, sdk.WithStack(err)
 	}
 
+	// The private key used to read a plain git server is resolved from the project key at each call
+	if vcsProject.Type == sdk.VCSTypeGit && vcsProject.Auth.SSHKeyName != "" {
+		if loadKey == nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "plain git repositories manager %s is not supported here", vcsProject.Name)
+		}
+		key, err := loadKey(ctx, db, projectKey, vcsProject.Auth.SSHKeyName)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(err, "unable to load ssh key %s on project", vcsProject.Auth.SSHKeyName)
+		}
+		vcsProject.Auth.SSHPrivateKey = key.Private
+	}
+
 	srvs, err := services.LoadAllByType(ctx, db, sdk.TypeVCS)
 	if err != nil {
 		return nil, sdk.WithStack(err)
@@ -123,6 +140,7 @@ func (c *vcsClient) setAuthHeader(ctx context.Context, req *http.Request) {
 	req.Header.Set(sdk.HeaderXVCSSSHUsername, base64.StdEncoding.EncodeToString([]byte(c.vcsProject.Auth.SSHUsername)))
 	req.Header.Set(sdk.HeaderXVCSSSHPort, base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(c.vcsProject.Auth.SSHPort))))
 	req.Header.Set(sdk.HeaderXVCSSSHPrivateKey, base64.StdEncoding.EncodeToString([]byte(c.vcsProject.Auth.SSHPrivateKey)))
+	req.Header.Set(sdk.HeaderXVCSSSHKnownHosts, base64.StdEncoding.EncodeToString([]byte(c.vcsProject.Auth.SSHKnownHosts)))
 }
 
 func (c *vcsClient) doStreamRequest(ctx context.Context, method, path string, in interface{}) (io.Reader, http.Header, error) {
@@ -323,6 +341,15 @@ func (c *vcsClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head
 	return commits, nil
 }
 
+func (c *vcsClient) DiffBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSFileDiff, error) {
+	var files []sdk.VCSFileDiff
+	path := fmt.Sprintf("/vcs/%s/repos/%s/diff?base=%s&head=%s", c.name, fullname, url.QueryEscape(base), url.QueryEscape(head))
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &files); err != nil {
+		return nil, sdk.NewErrorFrom(err, "unable to get diff on repository %s from %s", fullname, c.name)
+	}
+	return files, nil
+}
+
 func (c *vcsClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
 	commit := sdk.VCSCommit{}
 	path := fmt.Sprintf("/vcs/%s/repos/%s/commits/%s", c.name, fullname, hash)
@@ -377,6 +404,23 @@ func (c *vcsClient) PullRequestCreate(ctx context.Context, fullname string, pr s
 	return pr, nil
 }
 
+func (c *vcsClient) PullRequestMergeStatus(ctx context.Context, fullname string, ID string) (sdk.VCSPullRequestMergeStatus, error) {
+	var status sdk.VCSPullRequestMergeStatus
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%s/merge", c.name, fullname, url.PathEscape(ID))
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &status); err != nil {
+		return status, sdk.NewErrorFrom(err, "unable to get merge status of pullrequest %s on repository %s from %s", ID, fullname, c.name)
+	}
+	return status, nil
+}
+
+func (c *vcsClient) PullRequestMerge(ctx context.Context, fullname string, req sdk.VCSPullRequestMergeRequest) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d/merge", c.name, fullname, req.ID)
+	if _, err := c.doJSONRequest(ctx, "POST", path, req, nil); err != nil {
+		return sdk.NewErrorFrom(err, "unable to merge pullrequest %d on repository %s from %s", req.ID, fullname, c.name)
+	}
+	return nil
+}
+
 func (c *vcsClient) CreateHook(ctx context.Context, fullname string, hook *sdk.VCSHook) error {
 	path := fmt.Sprintf("/vcs/%s/repos/%s/hooks", c.name, fullname)
 	if _, err := c.doJSONRequest(ctx, "POST", path, hook, hook); err != nil {
@@ -519,6 +563,14 @@ func (c *vcsClient) CreateInsightReport(ctx context.Context, repo string, sha st
 	return nil
 }
 
+func (c *vcsClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/commits/%s/annotations", c.name, repo, sha)
+	if _, err := c.doJSONRequest(ctx, "POST", path, annotations, nil); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
+
 func (c *vcsClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
 	statuses := []sdk.VCSCommitStatus{}
 	path := fmt.Sprintf("/vcs/%s/repos/%s/commits/%s/statuses", c.name, repo, ref)
@@ -612,6 +664,16 @@ func GetWebhooksInfos(ctx context.Context, c sdk.VCSAuthorizedClientService) (We
 			string(gitlab.EventTypePipeline),
 			"Job Hook", // TODO update gitlab sdk
 		}
+	case client.vcsProject.Type == sdk.VCSTypeAzureDevOps:
+		res.WebhooksSupported = true
+		res.Icon = sdk.AzureDevOpsIcon
+		// https://learn.microsoft.com/en-us/azure/devops/service-hooks/events
+		res.Events = sdk.AzureDevOpsEvents
+		res.WebhooksDisabled = client.vcsProject.Options.DisableWebhooks
+	case client.vcsProject.Type == sdk.VCSTypeGit:
+		// A plain git server has no webhook, its refs are polled by the hooks service
+		res.WebhooksSupported = false
+		res.Icon = sdk.GitIcon
 	case client.vcsProject.Type == sdk.VCSTypeGerrit:
 		res.WebhooksSupported = false
 		res.Icon = sdk.GerritIcon
@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// auditRead checks that the current user can read the audits of all the v2 entities
+func (api *API) auditRead(ctx context.Context, _ map[string]string) error {
+	if isMaintainer(ctx) {
+		return nil
+	}
+	return sdk.WithStack(sdk.ErrForbidden)
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// sharedConcurrencyRead return nil if the current AuthConsumer can read the concurrencies of the region or the organization
+func (api *API) sharedConcurrencyRead(ctx context.Context, vars map[string]string) error {
+	if _, has := vars["regionIdentifier"]; has {
+		return api.regionRead(ctx, vars)
+	}
+
+	auth := getUserConsumer(ctx)
+	if auth == nil {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	// Members of an organization can see its locks
+	orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+	if err != nil {
+		return err
+	}
+	if auth.AuthConsumerUser.AuthentifiedUser.Organization == orga.Name {
+		return nil
+	}
+	return api.globalOrganizationManage(ctx, vars)
+}
+
+// sharedConcurrencyManage return nil if the current AuthConsumer can manage the concurrencies of the region or the organization
+func (api *API) sharedConcurrencyManage(ctx context.Context, vars map[string]string) error {
+	if _, has := vars["regionIdentifier"]; has {
+		return api.globalRegionManage(ctx, vars)
+	}
+	return api.globalOrganizationManage(ctx, vars)
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/sdk"
+)
+
+// isRBACGrantApprover returns true if the current user is member of the group configured to approve temporary grants
+func (api *API) isRBACGrantApprover(ctx context.Context) (bool, error) {
+	c := getUserConsumer(ctx)
+	if c == nil || api.Config.Auth.TemporaryGrant.ApproverGroup == "" {
+		return false, nil
+	}
+	g, err := group.LoadByName(ctx, api.mustDBWithCtx(ctx), api.Config.Auth.TemporaryGrant.ApproverGroup)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	groups, err := group.LoadAllByUserID(ctx, api.mustDBWithCtx(ctx), c.AuthConsumerUser.AuthentifiedUser.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, ug := range groups {
+		if ug.ID == g.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// rbacGrantRequest allows any user to request a temporary grant
+func (api *API) rbacGrantRequest(ctx context.Context, _ map[string]string) error {
+	if getUserConsumer(ctx) == nil {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	if api.Config.Auth.TemporaryGrant.ApproverGroup == "" {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "temporary permissions are not enabled on this CDS instance")
+	}
+	return nil
+}
+
+// rbacGrantApprove checks that the current user can approve or reject temporary grants
+func (api *API) rbacGrantApprove(ctx context.Context, _ map[string]string) error {
+	isApprover, err := api.isRBACGrantApprover(ctx)
+	if err != nil {
+		return err
+	}
+	if !isApprover {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	return nil
+}
+
+// rbacGrantRead checks that the current user is the requester of the grant or an approver
+func (api *API) rbacGrantRead(ctx context.Context, vars map[string]string) error {
+	c := getUserConsumer(ctx)
+	if c == nil {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	g, err := api.loadRBACGrant(ctx, vars["grantID"])
+	if err != nil {
+		return sdk.NewErrorWithStack(err, sdk.ErrForbidden)
+	}
+	if g.UserID == c.AuthConsumerUser.AuthentifiedUser.ID {
+		return nil
+	}
+	return api.rbacGrantApprove(ctx, vars)
+}
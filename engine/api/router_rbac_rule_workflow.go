@@ -8,21 +8,27 @@ import (
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/workflow_v2"
 	"github.com/ovh/cds/engine/featureflipping"
+	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	cdslog "github.com/ovh/cds/sdk/log"
 )
 
 func (api *API) hasRoleOnWorkflow(ctx context.Context, vars map[string]string, role string) error {
+	vcsName, repoName, workflowName, ref, err := api.getWorkflowRBACTarget(ctx, vars)
+	if err != nil {
+		return err
+	}
+	return api.hasRoleOnWorkflowRef(ctx, vars["projectKey"], vcsName, repoName, workflowName, ref, role)
+}
+
+// hasRoleOnWorkflowRef return nil if the current AuthUserConsumer has the role on the workflow for the given git ref
+func (api *API) hasRoleOnWorkflowRef(ctx context.Context, projectKey, vcsName, repoName, workflowName, ref, role string) error {
 	ctx = context.WithValue(ctx, cdslog.RbacRole, role)
 	auth := getUserConsumer(ctx)
 	if auth == nil {
 		return sdk.WithStack(sdk.ErrForbidden)
 	}
 
-	projectKey := vars["projectKey"]
-	workflowName := vars["workflow"]
-	workflowRunID := vars["workflowRunID"]
-
 	if supportMFA(ctx) && !isMFA(ctx) {
 		_, requireMFA := featureflipping.IsEnabled(ctx, gorpmapping.Mapper, api.mustDBWithCtx(ctx), sdk.FeatureMFARequired, map[string]string{
 			"project_key": projectKey,
@@ -32,56 +38,100 @@ func (api *API) hasRoleOnWorkflow(ctx context.Context, vars map[string]string, r
 		}
 	}
 
-	var vcsName, repoName string
+	hasRole, err := rbac.HasRoleOnWorkflowAndUserID(ctx, api.mustDBWithCtx(ctx), role, auth.AuthConsumerUser.AuthentifiedUser.ID, projectKey, vcsName, repoName, workflowName, ref)
+	if err != nil {
+		return err
+	}
+
+	if !hasRole {
+		return sdk.WrapError(sdk.ErrForbidden, "user %s does not have %s role on workflow %s/%s/%s for project %s", auth.AuthConsumerUser.AuthentifiedUser.Username, role, vcsName, repoName, workflowName, projectKey)
+	}
+
+	return nil
+}
+
+// getWorkflowRBACTarget returns the vcs, repository and workflow names from the route, and the git ref if the route targets a workflow run
+func (api *API) getWorkflowRBACTarget(ctx context.Context, vars map[string]string) (string, string, string, string, error) {
+	projectKey := vars["projectKey"]
+	workflowRunID := vars["workflowRunID"]
+
 	if workflowRunID != "" {
 		run, err := workflow_v2.LoadRunByID(ctx, api.mustDBWithCtx(ctx), workflowRunID)
 		if err != nil {
-			return err
-		}
-		vcsName = run.Contexts.CDS.WorkflowVCSServer
-		repoName = run.Contexts.CDS.WorkflowRepository
-		workflowName = run.WorkflowName
-	} else {
-		// Retrieve VCSName
-		vcsIdentifier, err := url.PathUnescape(vars["vcsIdentifier"])
-		if err != nil {
-			return sdk.NewError(sdk.ErrWrongRequest, err)
-		}
-		vcsProject, err := api.getVCSByIdentifier(ctx, projectKey, vcsIdentifier)
-		if err != nil {
-			return err
-		}
-		vcsName = vcsProject.Name
-
-		// Retrieve Repo name
-		repositoryIdentifier, err := url.PathUnescape(vars["repositoryIdentifier"])
-		if err != nil {
-			return sdk.NewError(sdk.ErrWrongRequest, err)
-		}
-		if sdk.IsValidUUID(repositoryIdentifier) {
-			repo, err := api.getRepositoryByIdentifier(ctx, vcsProject.ID, repositoryIdentifier)
-			if err != nil {
-				return err
-			}
-			repoName = repo.Name
-		} else {
-			repoName = repositoryIdentifier
+			return "", "", "", "", err
 		}
+		return run.Contexts.CDS.WorkflowVCSServer, run.Contexts.CDS.WorkflowRepository, run.WorkflowName, run.Contexts.Git.Ref, nil
 	}
 
-	hasRole, err := rbac.HasRoleOnWorkflowAndUserID(ctx, api.mustDBWithCtx(ctx), role, auth.AuthConsumerUser.AuthentifiedUser.ID, projectKey, vcsName, repoName, workflowName)
+	// Retrieve VCSName
+	vcsIdentifier, err := url.PathUnescape(vars["vcsIdentifier"])
 	if err != nil {
-		return err
+		return "", "", "", "", sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	vcsProject, err := api.getVCSByIdentifier(ctx, projectKey, vcsIdentifier)
+	if err != nil {
+		return "", "", "", "", err
 	}
 
-	if !hasRole {
-		return sdk.WrapError(sdk.ErrForbidden, "user %s does not have %s role on workflow %s/%s/%s for project %s", auth.AuthConsumerUser.AuthentifiedUser.Username, role, vcsName, repoName, workflowName, projectKey)
+	// Retrieve Repo name
+	repositoryIdentifier, err := url.PathUnescape(vars["repositoryIdentifier"])
+	if err != nil {
+		return "", "", "", "", sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	repoName := repositoryIdentifier
+	if sdk.IsValidUUID(repositoryIdentifier) {
+		repo, err := api.getRepositoryByIdentifier(ctx, vcsProject.ID, repositoryIdentifier)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		repoName = repo.Name
 	}
+	return vcsProject.Name, repoName, vars["workflow"], "", nil
+}
 
-	return nil
+// hasRoleOnWorkflowOrFallback return nil if the current AuthUserConsumer passes the fallback checker, used before the workflow role existed, or has the role on the current workflow
+func (api *API) hasRoleOnWorkflowOrFallback(ctx context.Context, vars map[string]string, role string, fallback service.RbacChecker) error {
+	errFallback := fallback(ctx, vars)
+	if errFallback == nil {
+		return nil
+	}
+	if sdk.ErrorIs(errFallback, sdk.ErrMFARequired) {
+		return errFallback
+	}
+	return api.hasRoleOnWorkflow(ctx, vars, role)
 }
 
 // workflowTrigger return nil if the current AuthUserConsumer have the WorkflowRoleTrigger on current workflow
 func (api *API) workflowTrigger(ctx context.Context, vars map[string]string) error {
 	return api.hasRoleOnWorkflow(ctx, vars, sdk.WorkflowRoleTrigger)
 }
+
+// workflowDebug return nil if the current AuthUserConsumer have the WorkflowRoleDebug on current workflow
+func (api *API) workflowDebug(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflow(ctx, vars, sdk.WorkflowRoleDebug)
+}
+
+// workflowStop return nil if the current AuthUserConsumer have the WorkflowRoleStop or the WorkflowRoleTrigger on current workflow
+func (api *API) workflowStop(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflowOrFallback(ctx, vars, sdk.WorkflowRoleStop, api.workflowTrigger)
+}
+
+// workflowRestart return nil if the current AuthUserConsumer have the WorkflowRoleRestart or the WorkflowRoleTrigger on current workflow
+func (api *API) workflowRestart(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflowOrFallback(ctx, vars, sdk.WorkflowRoleRestart, api.workflowTrigger)
+}
+
+// workflowApprove return nil if the current AuthUserConsumer have the WorkflowRoleApprove on current workflow
+func (api *API) workflowApprove(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflow(ctx, vars, sdk.WorkflowRoleApprove)
+}
+
+// workflowReadLogs return nil if the current AuthUserConsumer have the ProjectRoleRead on current project or the WorkflowRoleReadLogs on current workflow
+func (api *API) workflowReadLogs(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflowOrFallback(ctx, vars, sdk.WorkflowRoleReadLogs, api.projectRead)
+}
+
+// workflowDeleteRun return nil if the current AuthUserConsumer have the ProjectRoleManage on current project or the WorkflowRoleDeleteRun on current workflow
+func (api *API) workflowDeleteRun(ctx context.Context, vars map[string]string) error {
+	return api.hasRoleOnWorkflowOrFallback(ctx, vars, sdk.WorkflowRoleDeleteRun, api.projectManage)
+}
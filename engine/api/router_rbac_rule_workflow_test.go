@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/workflow_v2"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -132,3 +133,77 @@ workflows:
 		})
 	}
 }
+
+func TestHasRoleWorkflowApproveWithRefs(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+
+	user1, _ := assets.InsertLambdaUser(t, db)
+	auth := sdk.AuthUserConsumer{
+		AuthConsumerUser: sdk.AuthUserConsumerData{
+			AuthentifiedUser: &sdk.AuthentifiedUser{
+				ID: user1.ID,
+			},
+		},
+	}
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	vcs := assets.InsertTestVCSProject(t, db, proj.ID, "myvcs", "github")
+	repo := assets.InsertTestProjectRepository(t, db, proj.Key, vcs.ID, "my/repo")
+
+	insertRun := func(ref string) sdk.V2WorkflowRun {
+		wr := sdk.V2WorkflowRun{
+			Status:       sdk.V2WorkflowRunStatusSuccess,
+			ProjectKey:   proj.Key,
+			WorkflowName: "deploy",
+			RepositoryID: repo.ID,
+			VCSServerID:  vcs.ID,
+			VCSServer:    vcs.Name,
+			Repository:   repo.Name,
+			Contexts: sdk.WorkflowRunContext{
+				CDS: sdk.CDSContext{WorkflowVCSServer: vcs.Name, WorkflowRepository: repo.Name},
+				Git: sdk.GitContext{Ref: ref},
+			},
+			Initiator: &sdk.V2Initiator{UserID: user1.ID, User: user1.Initiator()},
+		}
+		require.NoError(t, workflow_v2.InsertRun(context.TODO(), db, &wr))
+		return wr
+	}
+	mainRun := insertRun("refs/heads/main")
+	featureRun := insertRun("refs/heads/feature")
+
+	_, err := db.Exec("DELETE FROM rbac")
+	require.NoError(t, err)
+	var r sdk.RBAC
+	require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(`name: test-perm
+workflows:
+- role: approve
+  users: [%s]
+  workflows: [myvcs/my/repo/deploy]
+  refs: [refs/heads/main]
+  project: %s
+- role: trigger
+  users: [%s]
+  workflows: [myvcs/my/repo/deploy]
+  refs: [refs/heads/feature]
+  project: %s`, user1.Username, proj.Key, user1.Username, proj.Key)), &r))
+	rbacLoader := NewRBACLoader(api.mustDB())
+	require.NoError(t, rbacLoader.FillRBACWithIDs(context.TODO(), &r))
+	require.NoError(t, rbac.Insert(context.TODO(), db, &r))
+
+	ctx := context.WithValue(context.TODO(), contextUserConsumer, &auth)
+	require.NoError(t, api.workflowApprove(ctx, map[string]string{"projectKey": proj.Key, "workflowRunID": mainRun.ID}))
+	require.True(t, sdk.ErrorIs(api.workflowApprove(ctx, map[string]string{"projectKey": proj.Key, "workflowRunID": featureRun.ID}), sdk.ErrForbidden))
+	require.True(t, sdk.ErrorIs(api.workflowStop(ctx, map[string]string{"projectKey": proj.Key, "workflowRunID": mainRun.ID}), sdk.ErrForbidden))
+
+	// The trigger role doesn't allow to approve, and is checked on the git ref of a manual run
+	require.NoError(t, api.workflowStop(ctx, map[string]string{"projectKey": proj.Key, "workflowRunID": featureRun.ID}))
+	require.NoError(t, api.hasRoleOnWorkflowRef(ctx, proj.Key, vcs.Name, repo.Name, "deploy", "refs/heads/feature", sdk.WorkflowRoleTrigger))
+	require.True(t, sdk.ErrorIs(api.hasRoleOnWorkflowRef(ctx, proj.Key, vcs.Name, repo.Name, "deploy", "refs/heads/main", sdk.WorkflowRoleTrigger), sdk.ErrForbidden))
+
+	check, err := rbac.CheckRoleOnWorkflow(context.TODO(), db, sdk.WorkflowRoleApprove, user1.ID, nil, proj.Key, vcs.Name, repo.Name, "deploy", "refs/heads/feature")
+	require.NoError(t, err)
+	require.False(t, check.Granted)
+	require.Len(t, check.Rules, 1)
+	require.Equal(t, "test-perm", check.Rules[0].RBACName)
+	require.Contains(t, check.Rules[0].Reason, "refs/heads/feature")
+}
@@ -14,6 +14,7 @@ import (
 	"go.opencensus.io/tag"
 
 	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/event_v2"
 	"github.com/ovh/cds/engine/api/mail"
 	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/services"
@@ -32,6 +33,7 @@ func (api *API) Status(ctx context.Context) *sdk.MonitoringStatus {
 	m.AddLine(sdk.MonitoringStatusLine{Component: "CDSName", Value: api.Name(), Status: sdk.MonitoringStatusOK})
 	m.AddLine(api.Router.StatusPanic())
 	m.AddLine(event.Status(ctx))
+	m.AddLine(event_v2.SinksStatus(api.Cache)...)
 	m.AddLine(api.SharedStorage.Status(ctx))
 	m.AddLine(mail.Status(ctx))
 	m.AddLine(api.DBConnectionFactory.Status(ctx))
@@ -253,6 +255,35 @@ func (api *API) initMetrics(ctx context.Context) error {
 		"number of synchronized run results with error",
 		stats.UnitDimensionless)
 
+	api.Metrics.V2StepDuration = stats.Float64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_duration", api.Name()),
+		"duration of the steps of v2 jobs",
+		stats.UnitSeconds)
+	api.Metrics.V2StepCPU = stats.Float64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_cpu", api.Name()),
+		"CPU time used by the steps of v2 jobs",
+		stats.UnitSeconds)
+	api.Metrics.V2StepMemoryPeak = stats.Int64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_memory_peak", api.Name()),
+		"memory peak of the steps of v2 jobs",
+		stats.UnitBytes)
+	api.Metrics.V2StepDiskRead = stats.Int64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_disk_read", api.Name()),
+		"bytes read on disk by the steps of v2 jobs",
+		stats.UnitBytes)
+	api.Metrics.V2StepDiskWrite = stats.Int64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_disk_write", api.Name()),
+		"bytes written on disk by the steps of v2 jobs",
+		stats.UnitBytes)
+	api.Metrics.V2StepNetworkReceived = stats.Int64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_network_received", api.Name()),
+		"bytes received on the network by the steps of v2 jobs",
+		stats.UnitBytes)
+	api.Metrics.V2StepNetworkSent = stats.Int64(
+		fmt.Sprintf("cds/cds-api/%s/v2_step_network_sent", api.Name()),
+		"bytes sent on the network by the steps of v2 jobs",
+		stats.UnitBytes)
+
 	tagRange, _ = tag.NewKey("range")
 	tagStatus, _ = tag.NewKey("status")
 
@@ -260,6 +291,10 @@ func (api *API) initMetrics(ctx context.Context) error {
 	tagServiceName := telemetry.MustNewKey(telemetry.TagServiceName)
 	tagsRange := []tag.Key{tagRange, tagStatus}
 	tagsService = []tag.Key{tagServiceName, tagServiceType}
+	tagsStep := []tag.Key{tagServiceName, tagServiceType,
+		telemetry.MustNewKey(telemetry.TagRegion), telemetry.MustNewKey(telemetry.TagWorkerModel),
+		telemetry.MustNewKey(telemetry.TagWorkerFlavor), telemetry.MustNewKey(telemetry.TagWorkerMemory),
+		telemetry.MustNewKey(telemetry.TagStatus)}
 
 	err := telemetry.RegisterView(ctx,
 		telemetry.NewViewLast("cds/nb_users", api.Metrics.nbUsers, nil),
@@ -284,6 +319,13 @@ func (api *API) initMetrics(ctx context.Context) error {
 		telemetry.NewViewLast("cds/run_results_synchronized", api.Metrics.RunResultSynchronized, tagsService),
 		telemetry.NewViewLast("cds/run_results_to_synchronized", api.Metrics.RunResultToSynchronized, tagsService),
 		telemetry.NewViewLast("cds/run_results_to_synchronized_error", api.Metrics.RunResultSynchronizedError, tagsService),
+		telemetry.NewViewDistribution("cds/v2_step_duration", api.Metrics.V2StepDuration, tagsStep, telemetry.DefaultDurationDistribution),
+		telemetry.NewViewDistribution("cds/v2_step_cpu", api.Metrics.V2StepCPU, tagsStep, telemetry.DefaultDurationDistribution),
+		telemetry.NewViewDistribution("cds/v2_step_memory_peak", api.Metrics.V2StepMemoryPeak, tagsStep, telemetry.DefaultMemoryDistribution),
+		telemetry.NewViewDistribution("cds/v2_step_disk_read", api.Metrics.V2StepDiskRead, tagsStep, telemetry.DefaultTransferDistribution),
+		telemetry.NewViewDistribution("cds/v2_step_disk_write", api.Metrics.V2StepDiskWrite, tagsStep, telemetry.DefaultTransferDistribution),
+		telemetry.NewViewDistribution("cds/v2_step_network_received", api.Metrics.V2StepNetworkReceived, tagsStep, telemetry.DefaultTransferDistribution),
+		telemetry.NewViewDistribution("cds/v2_step_network_sent", api.Metrics.V2StepNetworkSent, tagsStep, telemetry.DefaultTransferDistribution),
 	)
 
 	api.computeMetrics(ctx)
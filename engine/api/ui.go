@@ -8,6 +8,7 @@ import (
 
 	"github.com/ovh/cds/engine/api/application"
 	"github.com/ovh/cds/engine/api/metrics"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/workflow"
@@ -59,7 +60,7 @@ func (api *API) getApplicationOverviewHandler() service.Handler {
 		if app.VCSServer != "" {
 			// GET VCS URL
 			// Get vcs info to known if we are on the default branch or not
-			client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, projectKey, app.VCSServer)
+			client, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, projectKey, app.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth,
 					"cannot get repo client %s", app.VCSServer))
@@ -247,6 +247,10 @@ func (api *API) putUserHandler() service.Handler {
 			}
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventUserUpdated, "", newUser.Username, oldUser, newUser); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -377,6 +381,10 @@ func (api *API) deleteUserHandler() service.Handler {
 			return sdk.WrapError(err, "cannot delete user")
 		}
 
+		if err := recordAudit(ctx, tx, sdk.EventUserDeleted, "", u.Username, u, nil); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
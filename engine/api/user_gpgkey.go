@@ -75,10 +75,14 @@ func (api *API) postUserGPGGKeyHandler() ([]service.RbacChecker, service.Handler
 			if err != nil {
 				return err
 			}
+			defer tx.Rollback() // nolint
 			if err := user.InsertGPGKey(ctx, tx, &gpgKey); err != nil {
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventUserGPGKeyCreated, "", u.Username+"/"+gpgKey.KeyID, nil, gpgKey); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -113,10 +117,14 @@ func (api *API) deleteUserGPGKey() ([]service.RbacChecker, service.Handler) {
 			if err != nil {
 				return err
 			}
+			defer tx.Rollback() // nolint
 			if err := user.DeleteGPGKey(tx, *gpgKey); err != nil {
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventUserGPGKeyDeleted, "", u.Username+"/"+gpgKey.KeyID, gpgKey, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
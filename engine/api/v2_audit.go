@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/audit_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const auditV2MaxLimit = 500
+
+// recordAudit stores the audit of a change made by the current user. It must be called in the transaction of the change.
+func recordAudit(ctx context.Context, tx gorp.SqlExecutor, eventType sdk.EventType, projectKey, entityName string, before, after interface{}) error {
+	var u *sdk.AuthentifiedUser
+	if c := getUserConsumer(ctx); c != nil {
+		u = c.AuthConsumerUser.AuthentifiedUser
+	}
+	return recordUserAudit(ctx, tx, u, eventType, projectKey, entityName, before, after)
+}
+
+// recordUserAudit stores the audit of a change made by the given user, it can be nil for changes made by CDS itself.
+func recordUserAudit(ctx context.Context, tx gorp.SqlExecutor, u *sdk.AuthentifiedUser, eventType sdk.EventType, projectKey, entityName string, before, after interface{}) error {
+	c := audit_v2.Change{
+		EventType:  eventType,
+		ProjectKey: projectKey,
+		EntityName: entityName,
+		Before:     before,
+		After:      after,
+	}
+	if u != nil {
+		c.UserID = u.ID
+		c.Username = u.Username
+	}
+	return audit_v2.Record(ctx, tx, c)
+}
+
+// getAuditsV2Handler searches the audits of all v2 entities
+func (api *API) getAuditsV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.auditRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			filter, err := auditV2FilterFromRequest(req)
+			if err != nil {
+				return err
+			}
+			filter.ProjectKey = req.FormValue("project")
+			audits, err := audit_v2.LoadAll(ctx, api.mustDB(), filter)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, audits, http.StatusOK)
+		}
+}
+
+// getProjectAuditsV2Handler searches the audits of the v2 entities of a project
+func (api *API) getProjectAuditsV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			filter, err := auditV2FilterFromRequest(req)
+			if err != nil {
+				return err
+			}
+			filter.ProjectKey = mux.Vars(req)["projectKey"]
+			audits, err := audit_v2.LoadAll(ctx, api.mustDB(), filter)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, audits, http.StatusOK)
+		}
+}
+
+func auditV2FilterFromRequest(req *http.Request) (sdk.AuditV2Filter, error) {
+	filter := sdk.AuditV2Filter{
+		Username:   req.FormValue("user"),
+		ConsumerID: req.FormValue("consumer"),
+		EntityType: req.FormValue("entity_type"),
+		EntityName: req.FormValue("entity_name"),
+		EventType:  req.FormValue("event_type"),
+		Offset:     service.FormInt64(req, "offset"),
+		Limit:      service.FormInt64(req, "limit"),
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	if filter.Limit > auditV2MaxLimit {
+		filter.Limit = auditV2MaxLimit
+	}
+	var err error
+	if filter.Since, err = parseAuditV2Date(req.FormValue("since")); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = parseAuditV2Date(req.FormValue("until")); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseAuditV2Date reads a RFC3339 date or a duration relative to now, e.g. 24h
+func parseAuditV2Date(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid date %q, expected RFC3339 date or duration", s)
+	}
+	return t, nil
+}
+
+// purgeAuditsV2 deletes the audits older than the configured retention
+func (api *API) purgeAuditsV2(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(api.Config.Audit.PurgeScheduling) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting purgeAuditsV2: %v", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			if api.Config.Audit.Retention <= 0 {
+				continue
+			}
+			before := time.Now().Add(-time.Duration(api.Config.Audit.Retention) * 24 * time.Hour)
+			n, err := audit_v2.DeleteOlderThan(api.mustDB(), before)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			if n > 0 {
+				log.Info(ctx, "purgeAuditsV2: %d audits deleted", n)
+			}
+		}
+	}
+}
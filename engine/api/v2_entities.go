@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/entity"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/service"
@@ -35,7 +36,7 @@ func (api *API) getEntityRefFromQueryParams(ctx context.Context, req *http.Reque
 	}
 
 	if branch == "" {
-		vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projKey, vcsName)
+		vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projKey, vcsName, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return "", commit, err
 		}
@@ -56,6 +56,9 @@ func (api *API) postHatcheryRegenTokenHandler() ([]service.RbacChecker, service.
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventHatcheryTokenRegen, "", hatch.Name, hatch, hatch); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -182,6 +185,9 @@ func (api *API) postHatcheryHandler() ([]service.RbacChecker, service.Handler) {
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventHatcheryCreated, "", h.Name, nil, h); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -286,6 +292,7 @@ func (api *API) deleteHatcheryHandler() ([]service.RbacChecker, service.Handler)
 				rbacFound = false
 			}
 			if rbacFound {
+				before := *hatcheryPermission
 				// Remove all permissions on this hatchery
 				rbacHatcheries := make([]sdk.RBACHatchery, 0)
 
@@ -300,10 +307,16 @@ func (api *API) deleteHatcheryHandler() ([]service.RbacChecker, service.Handler)
 					if err := rbac.Delete(ctx, tx, *hatcheryPermission); err != nil {
 						return err
 					}
+					if err := recordAudit(ctx, tx, sdk.EventPermissionDeleted, "", hatcheryPermission.Name, before, nil); err != nil {
+						return err
+					}
 				} else {
 					if err := rbac.Update(ctx, tx, hatcheryPermission); err != nil {
 						return err
 					}
+					if err := recordAudit(ctx, tx, sdk.EventPermissionUpdated, "", hatcheryPermission.Name, before, hatcheryPermission); err != nil {
+						return err
+					}
 				}
 			}
 
@@ -316,6 +329,9 @@ func (api *API) deleteHatcheryHandler() ([]service.RbacChecker, service.Handler)
 			if err := hatchery.Delete(tx, hatch.ID); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventHatcheryDeleted, "", hatch.Name, hatch, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
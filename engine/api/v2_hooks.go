@@ -49,7 +49,7 @@ func (api *API) postInsightReportHandler() ([]service.RbacChecker, service.Handl
 				return err
 			}
 
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pkey, vcsProject.Name)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pkey, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -132,7 +132,7 @@ func (api *API) postHookEventRetrieveSignKeyHandler() ([]service.RbacChecker, se
 				return err
 			}
 
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, hookRetrieveSignKey.ProjectKey, hookRetrieveSignKey.VCSServerName)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, hookRetrieveSignKey.ProjectKey, hookRetrieveSignKey.VCSServerName, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -318,7 +318,7 @@ func (api *API) postRetrieveWorkflowToTriggerHandler() ([]service.RbacChecker, s
 				for _, h := range filteredWorkflowHooks {
 					if !hookRequest.AnalyzedProjectKeys.Contains(h.ProjectKey) {
 						// Check project right
-						vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, h.ProjectKey, hookRequest.VCSName)
+						vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, api.Cache, h.ProjectKey, hookRequest.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 						if err != nil {
 							return err
 						}
@@ -393,7 +393,7 @@ func LoadWorkflowHooksWithWorkflowRun(ctx context.Context, db gorp.SqlExecutor,
 			clientCacheKey := h.ProjectKey + "/" + h.VCSName
 			client, has := vcsClientCache[clientCacheKey]
 			if !has {
-				client, err = repositoriesmanager.AuthorizedClient(ctx, db, cache, h.ProjectKey, h.VCSName)
+				client, err = repositoriesmanager.AuthorizedClient(ctx, db, cache, h.ProjectKey, h.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return nil, err
 				}
@@ -464,7 +464,7 @@ func LoadWorkflowHooksWithRepositoryWebHooks(ctx context.Context, db gorp.SqlExe
 			defaultBranch, has := repoCache[w.VCSName+"/"+w.RepositoryName]
 			if !has {
 				// Fallback on default branch
-				vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, w.ProjectKey, w.VCSName)
+				vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, w.ProjectKey, w.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return nil, err
 				}
@@ -505,7 +505,8 @@ func LoadWorkflowHooksWithRepositoryWebHooks(ctx context.Context, db gorp.SqlExe
 	}
 
 	// For PullRequest event, skipped hooks are always empty
-	if len(hookRequest.SkippedHooks) == 0 && (hookRequest.RepositoryEventName == sdk.WorkflowHookEventNamePullRequest || hookRequest.RepositoryEventName == sdk.WorkflowHookEventNamePullRequestComment) {
+	// Merge queue branches, releases and created/deleted branches are never analyzed, so these events always use head hooks
+	if len(hookRequest.SkippedHooks) == 0 && useHeadHooks(hookRequest.RepositoryEventName) {
 
 		hooks, err := workflow_v2.LoadHookHeadPullRequestHookByWorkflowAndEvent(ctx, db, hookRequest.RepositoryEventName, hookRequest.VCSName, hookRequest.RepositoryName)
 		if err != nil {
@@ -543,7 +544,7 @@ func LoadWorkflowHooksWithRepositoryWebHooks(ctx context.Context, db gorp.SqlExe
 				_, has := headhooks[hookKey]
 				if !has {
 					// Check default branch
-					vcsAuth, err := repositoriesmanager.AuthorizedClient(ctx, db, store, h.ProjectKey, h.VCSName)
+					vcsAuth, err := repositoriesmanager.AuthorizedClient(ctx, db, store, h.ProjectKey, h.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 					if err != nil {
 						return nil, err
 					}
@@ -605,7 +606,7 @@ func validateRepositoryWebHook(ctx context.Context, db gorp.SqlExecutor, store c
 			// For distant workflow, only allow default branch hook with head = true
 			defaultBranch, has := repoCache[w.VCSName+"/"+w.RepositoryName]
 			if !has {
-				vcsAuth, err := repositoriesmanager.AuthorizedClient(ctx, db, store, w.ProjectKey, w.VCSName)
+				vcsAuth, err := repositoriesmanager.AuthorizedClient(ctx, db, store, w.ProjectKey, w.VCSName, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return false, err
 				}
@@ -632,10 +633,30 @@ func validateRepositoryWebHook(ctx context.Context, db gorp.SqlExecutor, store c
 			validType = sdk.IsInArray(hookRequest.RepositoryEventType, w.Data.TypesFilter)
 		}
 		return w.Data.ValidateRef(ctx, hookRequest.PullRequestRefTo) && validType, nil
+	case sdk.WorkflowHookEventNameMergeQueue:
+		return w.Data.ValidateRef(ctx, hookRequest.PullRequestRefTo), nil
+	case sdk.WorkflowHookEventNameRelease:
+		validType := true
+		if len(w.Data.TypesFilter) > 0 {
+			validType = sdk.IsInArray(hookRequest.RepositoryEventType, w.Data.TypesFilter)
+		}
+		return w.Data.ValidateRef(ctx, hookRequest.Ref) && validType, nil
+	case sdk.WorkflowHookEventNameBranchCreate, sdk.WorkflowHookEventNameBranchDelete:
+		return w.Data.ValidateRef(ctx, hookRequest.Ref), nil
 	}
 	return false, nil
 }
 
+// useHeadHooks returns true for events whose hooks are read from the HEAD workflow definitions instead of the analyzed commit
+func useHeadHooks(eventName sdk.WorkflowHookEventName) bool {
+	switch eventName {
+	case sdk.WorkflowHookEventNamePullRequest, sdk.WorkflowHookEventNamePullRequestComment, sdk.WorkflowHookEventNameMergeQueue,
+		sdk.WorkflowHookEventNameRelease, sdk.WorkflowHookEventNameBranchCreate, sdk.WorkflowHookEventNameBranchDelete:
+		return true
+	}
+	return false
+}
+
 func (api *API) getHooksRepositoriesHandler() ([]service.RbacChecker, service.Handler) {
 	return service.RBAC(api.isHookService),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
@@ -665,3 +686,68 @@ func (api *API) getHooksRepositoriesHandler() ([]service.RbacChecker, service.Ha
 			return service.WriteJSON(w, repositories, http.StatusOK)
 		}
 }
+
+// getHooksPollingRepositoriesHandler returns the repositories hosted on vcs servers that cannot send webhooks
+func (api *API) getHooksPollingRepositoriesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isHookService),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vcsServers, err := vcs.LoadAllVCSByType(ctx, api.mustDB(), sdk.VCSTypeGit)
+			if err != nil {
+				return err
+			}
+
+			repositories := make([]sdk.HookPollingRepository, 0)
+			for _, v := range vcsServers {
+				repos, err := repository.LoadAllRepositoriesByVCSProjectID(ctx, api.mustDB(), v.ID)
+				if err != nil {
+					return err
+				}
+				for _, r := range repos {
+					repositories = append(repositories, sdk.HookPollingRepository{
+						ProjectKey:     r.ProjectKey,
+						VCSServerName:  v.Name,
+						VCSServerType:  v.Type,
+						RepositoryName: r.Name,
+					})
+				}
+			}
+			return service.WriteJSON(w, repositories, http.StatusOK)
+		}
+}
+
+// getHooksRepositoryRefsHandler returns the latest commit of all branches and tags of a repository
+func (api *API) getHooksRepositoryRefsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isHookService),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			projKey := vars["projectKey"]
+			vcsName := vars["vcsServer"]
+			repoName, err := url.PathUnescape(vars["repositoryName"])
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, projKey, vcsName, project.LoadKeyWithPrivateContentByProjectKey)
+			if err != nil {
+				return err
+			}
+
+			branches, err := vcsClient.Branches(ctx, repoName, sdk.VCSBranchesFilter{NoCache: true})
+			if err != nil {
+				return err
+			}
+			tags, err := vcsClient.Tags(ctx, repoName)
+			if err != nil {
+				return err
+			}
+
+			refs := make(sdk.HookRepositoryRefs, len(branches)+len(tags))
+			for _, b := range branches {
+				refs[b.ID] = b.LatestCommit
+			}
+			for _, t := range tags {
+				refs[sdk.GitRefTagPrefix+t.Tag] = t.Hash
+			}
+			return service.WriteJSON(w, refs, http.StatusOK)
+		}
+}
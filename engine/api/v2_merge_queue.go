@@ -0,0 +1,697 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/mergequeue"
+	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/services"
+	"github.com/ovh/cds/engine/api/vcs"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+func (api *API) getMergeQueueVCSAndRepository(ctx context.Context, vars map[string]string) (*sdk.VCSProject, *sdk.ProjectRepository, error) {
+	vcsIdentifier, err := url.PathUnescape(vars["vcsIdentifier"])
+	if err != nil {
+		return nil, nil, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	repositoryIdentifier, err := url.PathUnescape(vars["repositoryIdentifier"])
+	if err != nil {
+		return nil, nil, sdk.WithStack(err)
+	}
+	vcsProject, err := api.getVCSByIdentifier(ctx, vars["projectKey"], vcsIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := api.getRepositoryByIdentifier(ctx, vcsProject.ID, repositoryIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vcsProject, repo, nil
+}
+
+func (api *API) getMergeQueueHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			vcsProject, repo, err := api.getMergeQueueVCSAndRepository(ctx, vars)
+			if err != nil {
+				return err
+			}
+			limit, err := strconv.Atoi(QueryString(req, "limit"))
+			if limit <= 0 || err != nil {
+				limit = 50
+			}
+			entries, err := mergequeue.LoadEntriesByRepository(ctx, api.mustDB(), vars["projectKey"], vcsProject.Name, repo.Name, limit)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, entries, http.StatusOK)
+		}
+}
+
+func (api *API) postMergeQueueEntryHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WrapError(sdk.ErrForbidden, "no user consumer")
+			}
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			vcsProject, repo, err := api.getMergeQueueVCSAndRepository(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			var enqueueRequest sdk.MergeQueueEnqueueRequest
+			if err := service.UnmarshalBody(req, &enqueueRequest); err != nil {
+				return err
+			}
+			if enqueueRequest.PullRequestID <= 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pull request id")
+			}
+
+			client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
+			if err != nil {
+				return err
+			}
+			pr, err := client.PullRequest(ctx, repo.Name, strconv.FormatInt(enqueueRequest.PullRequestID, 10))
+			if err != nil {
+				return err
+			}
+			if pr.Merged || pr.Closed {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request #%d is not open", enqueueRequest.PullRequestID)
+			}
+			// The review rules of the vcs server are enforced before the pull request can use the queue
+			mergeStatus, err := client.PullRequestMergeStatus(ctx, repo.Name, strconv.FormatInt(enqueueRequest.PullRequestID, 10))
+			if err != nil {
+				return err
+			}
+			if !mergeStatus.Mergeable || !mergeStatus.Approved {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request #%d cannot be merged: %s", enqueueRequest.PullRequestID, mergeStatus.Reason)
+			}
+			baseBranch := pr.Base.Branch.DisplayID
+
+			hooks, err := api.loadMergeQueueHooks(ctx, pKey, vcsProject.Name, repo.Name, baseBranch)
+			if err != nil {
+				return err
+			}
+			if len(hooks) == 0 {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "no workflow is triggered on merge-queue event for branch %s", baseBranch)
+			}
+
+			entry := sdk.MergeQueueEntry{
+				ProjectKey:       pKey,
+				VCSServer:        vcsProject.Name,
+				Repository:       repo.Name,
+				BaseBranch:       baseBranch,
+				PullRequestID:    enqueueRequest.PullRequestID,
+				PullRequestTitle: pr.Title,
+				PullRequestURL:   pr.URL,
+				HeadCommit:       pr.Head.Commit.Hash,
+				Username:         u.GetUsername(),
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := mergequeue.InsertEntry(ctx, tx, &entry); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			return service.WriteJSON(w, entry, http.StatusOK)
+		}
+}
+
+func (api *API) deleteMergeQueueEntryHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			if _, _, err := api.getMergeQueueVCSAndRepository(ctx, vars); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := mergequeue.DeleteEntry(tx, vars["projectKey"], vars["entryID"]); err != nil {
+				return err
+			}
+			return sdk.WithStack(tx.Commit())
+		}
+}
+
+// loadMergeQueueHooks returns head merge-queue hooks of the project that listen to the given base branch
+func (api *API) loadMergeQueueHooks(ctx context.Context, projectKey, vcsName, repoName, baseBranch string) ([]sdk.V2WorkflowHook, error) {
+	hooks, err := workflow_v2.LoadHookHeadPullRequestHookByWorkflowAndEvent(ctx, api.mustDB(), sdk.WorkflowHookEventNameMergeQueue, vcsName, repoName)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]sdk.V2WorkflowHook, 0, len(hooks))
+	for _, h := range hooks {
+		if h.ProjectKey != projectKey || !h.Data.ValidateRef(ctx, sdk.GitRefBranchPrefix+baseBranch) {
+			continue
+		}
+		filtered = append(filtered, h)
+	}
+	return filtered, nil
+}
+
+func (api *API) mergeQueueProcessor(ctx context.Context, tick time.Duration) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "%v", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			if err := api.processMergeQueues(ctx); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+		}
+	}
+}
+
+func mergeQueueKey(projectKey, vcsServer, repository, baseBranch string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", projectKey, vcsServer, repository, baseBranch)
+}
+
+// processMergeQueues moves active batches forward, then starts a new batch on each queue that has no active batch
+func (api *API) processMergeQueues(ctx context.Context) error {
+	lockKey := cache.Key("api:mergeQueue")
+	b, err := api.Cache.Lock(lockKey, 5*time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !b {
+		return nil
+	}
+	defer api.Cache.Unlock(lockKey) // nolint
+
+	batches, err := mergequeue.LoadActiveBatches(ctx, api.mustDB())
+	if err != nil {
+		return err
+	}
+	busyQueues := make(map[string]struct{})
+	for i := range batches {
+		batch := &batches[i]
+		if err := api.processMergeQueueBatch(ctx, batch); err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+		}
+		if !batch.Status.IsTerminated() {
+			busyQueues[mergeQueueKey(batch.ProjectKey, batch.VCSServer, batch.Repository, batch.BaseBranch)] = struct{}{}
+		}
+	}
+
+	entries, err := mergequeue.LoadQueuedEntries(ctx, api.mustDB())
+	if err != nil {
+		return err
+	}
+	queues := make(map[string][]sdk.MergeQueueEntry)
+	queueKeys := make([]string, 0)
+	for _, e := range entries {
+		k := mergeQueueKey(e.ProjectKey, e.VCSServer, e.Repository, e.BaseBranch)
+		if _, has := busyQueues[k]; has {
+			continue
+		}
+		if _, has := queues[k]; !has {
+			queueKeys = append(queueKeys, k)
+		}
+		queues[k] = append(queues[k], e)
+	}
+	for _, k := range queueKeys {
+		if err := api.startMergeQueueBatch(ctx, queues[k]); err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+		}
+	}
+	return nil
+}
+
+// startMergeQueueBatch takes the first queued entries and asks the repositories service to merge them on a temporary branch
+func (api *API) startMergeQueueBatch(ctx context.Context, queued []sdk.MergeQueueEntry) error {
+	first := queued[0]
+	ctx = context.WithValue(ctx, cdslog.Project, first.ProjectKey)
+	ctx = context.WithValue(ctx, cdslog.VCSServer, first.VCSServer)
+	ctx = context.WithValue(ctx, cdslog.Repository, first.Repository)
+
+	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, first.ProjectKey, first.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := api.loadMergeQueueHooks(ctx, first.ProjectKey, first.VCSServer, first.Repository, first.BaseBranch)
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		for i := range queued {
+			api.ejectMergeQueueEntry(ctx, client, &queued[i], fmt.Sprintf("no workflow is triggered on merge-queue event for branch %s", first.BaseBranch))
+		}
+		return nil
+	}
+	batchSize := int64(0)
+	for _, h := range hooks {
+		if h.Data.MergeQueueBatchSize > 0 && (batchSize == 0 || h.Data.MergeQueueBatchSize < batchSize) {
+			batchSize = h.Data.MergeQueueBatchSize
+		}
+	}
+	if batchSize == 0 {
+		batchSize = sdk.MergeQueueDefaultBatchSize
+	}
+
+	// Isolated entries come from a failed batch, they are tested alone
+	batch := sdk.MergeQueueBatch{
+		ID:         sdk.UUID(),
+		ProjectKey: first.ProjectKey,
+		VCSServer:  first.VCSServer,
+		Repository: first.Repository,
+		BaseBranch: first.BaseBranch,
+		Status:     sdk.MergeQueueBatchStatusMerging,
+	}
+	batch.Branch = batch.BranchName()
+	entries := make([]sdk.MergeQueueEntry, 0, batchSize)
+	commits := make([]sdk.OperationMergeCommit, 0, batchSize)
+	for i := range queued {
+		e := queued[i]
+		if int64(len(entries)) >= batchSize || (e.Isolated && len(entries) > 0) {
+			break
+		}
+
+		// Always test the latest commit of the pull request
+		pr, err := client.PullRequest(ctx, e.Repository, strconv.FormatInt(e.PullRequestID, 10))
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			api.ejectMergeQueueEntry(ctx, client, &e, fmt.Sprintf("unable to get pull request: %v", err))
+			continue
+		}
+		if pr.Merged || pr.Closed || pr.Base.Branch.DisplayID != e.BaseBranch {
+			api.ejectMergeQueueEntry(ctx, nil, &e, "pull request is not open anymore on this branch")
+			continue
+		}
+		// Approvals can be dismissed by new commits since the pull request has been queued
+		mergeStatus, err := client.PullRequestMergeStatus(ctx, e.Repository, strconv.FormatInt(e.PullRequestID, 10))
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+			api.ejectMergeQueueEntry(ctx, client, &e, fmt.Sprintf("unable to get pull request merge status: %v", err))
+			continue
+		}
+		if !mergeStatus.Approved {
+			api.ejectMergeQueueEntry(ctx, client, &e, mergeStatus.Reason)
+			continue
+		}
+		e.HeadCommit = pr.Head.Commit.Hash
+		e.PullRequestTitle = pr.Title
+		e.Status = sdk.MergeQueueEntryStatusTesting
+		e.BatchID = batch.ID
+		e.Error = ""
+		entries = append(entries, e)
+		commits = append(commits, sdk.OperationMergeCommit{
+			Commit:  e.HeadCommit,
+			Message: fmt.Sprintf("Merge pull request #%d: %s", e.PullRequestID, e.PullRequestTitle),
+		})
+		if e.Isolated {
+			break
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	proj, err := project.Load(ctx, api.mustDB(), batch.ProjectKey, project.LoadOptions.WithClearKeys)
+	if err != nil {
+		return err
+	}
+	vcsWithSecret, err := vcs.LoadVCSByProject(ctx, api.mustDB(), batch.ProjectKey, batch.VCSServer, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		return err
+	}
+	vcsRepo, err := client.RepoByFullname(ctx, batch.Repository)
+	if err != nil {
+		return err
+	}
+	cloneURL := vcsRepo.SSHCloneURL
+	if vcsWithSecret.Auth.SSHKeyName == "" {
+		cloneURL = vcsRepo.HTTPCloneURL
+	}
+	ope, err := operation.MergeOperation(ctx, api.mustDB(), *proj, *vcsWithSecret, batch.Repository, cloneURL, sdk.OperationMerge{
+		Action:     sdk.OperationMergeActionCreate,
+		BaseBranch: batch.BaseBranch,
+		Branch:     batch.Branch,
+		Commits:    commits,
+	})
+	if err != nil {
+		return err
+	}
+	batch.OperationUUID = ope.UUID
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := mergequeue.InsertBatch(ctx, tx, &batch); err != nil {
+		return err
+	}
+	for i := range entries {
+		if err := mergequeue.UpdateEntry(ctx, tx, &entries[i]); err != nil {
+			return err
+		}
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+func (api *API) processMergeQueueBatch(ctx context.Context, batch *sdk.MergeQueueBatch) error {
+	ctx = context.WithValue(ctx, cdslog.Project, batch.ProjectKey)
+	ctx = context.WithValue(ctx, cdslog.VCSServer, batch.VCSServer)
+	ctx = context.WithValue(ctx, cdslog.Repository, batch.Repository)
+
+	entries, err := mergequeue.LoadEntriesByBatch(ctx, api.mustDB(), batch.ID)
+	if err != nil {
+		return err
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, batch.ProjectKey, batch.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
+	if err != nil {
+		return err
+	}
+
+	switch batch.Status {
+	case sdk.MergeQueueBatchStatusMerging:
+		ope, err := operation.GetRepositoryOperation(ctx, api.mustDB(), batch.OperationUUID)
+		if err != nil {
+			return err
+		}
+		switch ope.Status {
+		case sdk.OperationStatusError:
+			return api.failMergeQueueBatch(ctx, client, batch, entries, fmt.Sprintf("unable to build branch %s: %s", batch.Branch, ope.Error.ToError()))
+		case sdk.OperationStatusDone:
+		default:
+			return nil
+		}
+
+		// Conflicting pull requests are tested alone later, or ejected if they were already alone
+		remaining := make([]sdk.MergeQueueEntry, 0, len(entries))
+		for i := range entries {
+			if !sdk.IsInArray(entries[i].HeadCommit, ope.Setup.Merge.Result.Conflicts) {
+				remaining = append(remaining, entries[i])
+				continue
+			}
+			if len(entries) > 1 {
+				if err := api.requeueMergeQueueEntry(ctx, &entries[i], true); err != nil {
+					return err
+				}
+			} else {
+				api.ejectMergeQueueEntry(ctx, client, &entries[i], fmt.Sprintf("pull request cannot be merged on branch %s", batch.BaseBranch))
+			}
+		}
+		if len(remaining) == 0 || ope.Setup.Merge.Result.Commit == "" {
+			batch.Status = sdk.MergeQueueBatchStatusFail
+			batch.Error = "no pull request can be merged"
+			return api.updateMergeQueueBatch(ctx, batch)
+		}
+
+		batch.BaseCommit = ope.Setup.Merge.Result.BaseCommit
+		batch.Commit = ope.Setup.Merge.Result.Commit
+
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), sdk.TypeHooks)
+		if err != nil {
+			return err
+		}
+		if len(srvs) < 1 {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find hook uservice")
+		}
+		hookRequest := sdk.HookMergeQueueRequest{
+			ProjectKey: batch.ProjectKey,
+			VCSServer:  batch.VCSServer,
+			Repository: batch.Repository,
+			Branch:     batch.Branch,
+			Commit:     batch.Commit,
+			BaseBranch: batch.BaseBranch,
+		}
+		var hookEvent sdk.HookRepositoryEvent
+		if _, code, err := services.NewClient(srvs).DoJSONRequest(ctx, http.MethodPost, "/v2/workflow/mergequeue", hookRequest, &hookEvent); err != nil {
+			return sdk.WrapError(err, "unable to trigger merge-queue event [HTTP: %d]", code)
+		}
+		batch.HookEventUUID = hookEvent.UUID
+		batch.Status = sdk.MergeQueueBatchStatusTesting
+		return api.updateMergeQueueBatch(ctx, batch)
+
+	case sdk.MergeQueueBatchStatusTesting:
+		srvs, err := services.LoadAllByType(ctx, api.mustDB(), sdk.TypeHooks)
+		if err != nil {
+			return err
+		}
+		if len(srvs) < 1 {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find hook uservice")
+		}
+		var hookEvent sdk.HookRepositoryEvent
+		path := fmt.Sprintf("/v2/repository/event/%s/%s/%s", batch.VCSServer, url.PathEscape(strings.ToLower(batch.Repository)), batch.HookEventUUID)
+		if _, code, err := services.NewClient(srvs).DoJSONRequest(ctx, http.MethodGet, path, nil, &hookEvent); err != nil {
+			return sdk.WrapError(err, "unable to get merge-queue event [HTTP: %d]", code)
+		}
+		if !hookEvent.IsTerminated() {
+			return nil
+		}
+		if hookEvent.Status != sdk.HookEventStatusDone {
+			return api.failMergeQueueBatch(ctx, client, batch, entries, fmt.Sprintf("merge-queue event %s: %s", hookEvent.Status, hookEvent.LastError))
+		}
+
+		runIDs := make(sdk.StringSlice, 0, len(hookEvent.WorkflowHooks))
+		for _, wh := range hookEvent.WorkflowHooks {
+			if wh.Status == sdk.HookEventWorkflowStatusError {
+				return api.failMergeQueueBatch(ctx, client, batch, entries, fmt.Sprintf("unable to trigger workflow %s: %s", wh.WorkflowName, wh.Error))
+			}
+			if wh.RunID != "" {
+				runIDs = append(runIDs, wh.RunID)
+			}
+		}
+		if len(runIDs) == 0 {
+			return api.failMergeQueueBatch(ctx, client, batch, entries, "no workflow has been triggered by the merge-queue event")
+		}
+		batch.RunIDs = runIDs
+
+		for _, id := range runIDs {
+			run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), id)
+			if err != nil {
+				return err
+			}
+			if !run.Status.IsTerminated() {
+				return api.updateMergeQueueBatch(ctx, batch)
+			}
+			if run.Status != sdk.V2WorkflowRunStatusSuccess {
+				return api.failMergeQueueBatch(ctx, client, batch, entries, fmt.Sprintf("workflow %s #%d ended with status %s: %s/project/%s/run/%s",
+					run.WorkflowName, run.RunNumber, run.Status, api.Config.URL.UI, run.ProjectKey, run.ID))
+			}
+		}
+
+		batch.Status = sdk.MergeQueueBatchStatusLanding
+		if err := api.updateMergeQueueBatch(ctx, batch); err != nil {
+			return err
+		}
+		return api.landMergeQueueBatch(ctx, client, batch, entries)
+
+	case sdk.MergeQueueBatchStatusLanding:
+		return api.landMergeQueueBatch(ctx, client, batch, entries)
+	}
+	return nil
+}
+
+// landMergeQueueBatch merges the pull requests of a tested batch through the vcs server, in the order they have been tested.
+// The vcs server applies its own protection rules and refuses the merge of a pull request that has changed since the tests.
+func (api *API) landMergeQueueBatch(ctx context.Context, client sdk.VCSAuthorizedClientService, batch *sdk.MergeQueueBatch, entries []sdk.MergeQueueEntry) error {
+	var merged int
+	for i := range entries {
+		if entries[i].Status == sdk.MergeQueueEntryStatusMerged {
+			merged++
+		}
+	}
+
+	// The tests are only valid for the base commit they have been run on
+	if merged == 0 {
+		branch, err := client.Branch(ctx, batch.Repository, sdk.VCSBranchFilters{BranchName: batch.BaseBranch, NoCache: true})
+		if err != nil {
+			return err
+		}
+		if branch.LatestCommit != batch.BaseCommit {
+			for i := range entries {
+				if err := api.requeueMergeQueueEntry(ctx, &entries[i], entries[i].Isolated); err != nil {
+					return err
+				}
+			}
+			batch.Status = sdk.MergeQueueBatchStatusFail
+			batch.Error = fmt.Sprintf("branch %s has moved during the tests", batch.BaseBranch)
+			api.deleteMergeQueueBranch(ctx, client, batch)
+			return api.updateMergeQueueBatch(ctx, batch)
+		}
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		if e.Status != sdk.MergeQueueEntryStatusTesting {
+			continue
+		}
+		if err := client.PullRequestMerge(ctx, e.Repository, sdk.VCSPullRequestMergeRequest{
+			ID:      int(e.PullRequestID),
+			Commit:  e.HeadCommit,
+			Message: fmt.Sprintf("Merge pull request #%d: %s", e.PullRequestID, e.PullRequestTitle),
+		}); err != nil {
+			// The pull request may have been merged by a previous attempt that has been interrupted
+			pr, errPR := client.PullRequest(ctx, e.Repository, strconv.FormatInt(e.PullRequestID, 10))
+			if errPR != nil || !pr.Merged {
+				reason := fmt.Sprintf("unable to merge pull request on branch %s: %v", batch.BaseBranch, err)
+				api.ejectMergeQueueEntry(ctx, client, e, reason)
+				// The next pull requests have been tested on top of this one, they have to be tested again
+				for j := i + 1; j < len(entries); j++ {
+					if err := api.requeueMergeQueueEntry(ctx, &entries[j], false); err != nil {
+						return err
+					}
+				}
+				batch.Status = sdk.MergeQueueBatchStatusFail
+				batch.Error = reason
+				api.deleteMergeQueueBranch(ctx, client, batch)
+				return api.updateMergeQueueBatch(ctx, batch)
+			}
+		}
+
+		e.Status = sdk.MergeQueueEntryStatusMerged
+		if err := api.updateMergeQueueEntry(ctx, e); err != nil {
+			return err
+		}
+		if err := client.PullRequestComment(ctx, e.Repository, sdk.VCSPullRequestCommentRequest{
+			ID:      int(e.PullRequestID),
+			Message: fmt.Sprintf("Merged on %s by the CDS merge queue", batch.BaseBranch),
+		}); err != nil {
+			log.Warn(ctx, "unable to comment pull request #%d: %v", e.PullRequestID, err)
+		}
+	}
+	batch.Status = sdk.MergeQueueBatchStatusSuccess
+	api.deleteMergeQueueBranch(ctx, client, batch)
+	return api.updateMergeQueueBatch(ctx, batch)
+}
+
+// failMergeQueueBatch tests again each entry of a multi pull requests batch alone, or ejects the entry of a single pull request batch
+func (api *API) failMergeQueueBatch(ctx context.Context, client sdk.VCSAuthorizedClientService, batch *sdk.MergeQueueBatch, entries []sdk.MergeQueueEntry, reason string) error {
+	log.Info(ctx, "merge queue batch %s failed: %s", batch.ID, reason)
+	for i := range entries {
+		if len(entries) > 1 {
+			if err := api.requeueMergeQueueEntry(ctx, &entries[i], true); err != nil {
+				return err
+			}
+			continue
+		}
+		api.ejectMergeQueueEntry(ctx, client, &entries[i], reason)
+	}
+	batch.Status = sdk.MergeQueueBatchStatusFail
+	batch.Error = reason
+	api.deleteMergeQueueBranch(ctx, client, batch)
+	return api.updateMergeQueueBatch(ctx, batch)
+}
+
+func (api *API) requeueMergeQueueEntry(ctx context.Context, e *sdk.MergeQueueEntry, isolated bool) error {
+	e.Status = sdk.MergeQueueEntryStatusQueued
+	e.BatchID = ""
+	e.Isolated = isolated
+	return api.updateMergeQueueEntry(ctx, e)
+}
+
+// ejectMergeQueueEntry removes the entry from the queue and explains why on the pull request
+func (api *API) ejectMergeQueueEntry(ctx context.Context, client sdk.VCSAuthorizedClientService, e *sdk.MergeQueueEntry, reason string) {
+	e.Status = sdk.MergeQueueEntryStatusEjected
+	e.Error = reason
+	if err := api.updateMergeQueueEntry(ctx, e); err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	if client == nil {
+		return
+	}
+	if err := client.PullRequestComment(ctx, e.Repository, sdk.VCSPullRequestCommentRequest{
+		ID:      int(e.PullRequestID),
+		Message: fmt.Sprintf("Removed from the CDS merge queue: %s", reason),
+	}); err != nil {
+		log.Warn(ctx, "unable to comment pull request #%d: %v", e.PullRequestID, err)
+	}
+}
+
+// deleteMergeQueueBranch removes the temporary branch of a batch, the result of the operation is not checked
+func (api *API) deleteMergeQueueBranch(ctx context.Context, client sdk.VCSAuthorizedClientService, batch *sdk.MergeQueueBatch) {
+	if batch.Commit == "" {
+		return
+	}
+	proj, err := project.Load(ctx, api.mustDB(), batch.ProjectKey, project.LoadOptions.WithClearKeys)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	vcsWithSecret, err := vcs.LoadVCSByProject(ctx, api.mustDB(), batch.ProjectKey, batch.VCSServer, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	vcsRepo, err := client.RepoByFullname(ctx, batch.Repository)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	cloneURL := vcsRepo.SSHCloneURL
+	if vcsWithSecret.Auth.SSHKeyName == "" {
+		cloneURL = vcsRepo.HTTPCloneURL
+	}
+	if _, err := operation.MergeOperation(ctx, api.mustDB(), *proj, *vcsWithSecret, batch.Repository, cloneURL, sdk.OperationMerge{
+		Action:     sdk.OperationMergeActionDelete,
+		BaseBranch: batch.BaseBranch,
+		Branch:     batch.Branch,
+	}); err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+	}
+}
+
+func (api *API) updateMergeQueueEntry(ctx context.Context, e *sdk.MergeQueueEntry) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := mergequeue.UpdateEntry(ctx, tx, e); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+func (api *API) updateMergeQueueBatch(ctx context.Context, batch *sdk.MergeQueueBatch) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := mergequeue.UpdateBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
@@ -49,6 +49,9 @@ func (api *API) postOrganizationHandler() ([]service.RbacChecker, service.Handle
 			if err := organization.Insert(ctx, tx, &org); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventOrganizationCreated, "", org.Name, nil, org); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -108,6 +111,9 @@ func (api *API) deleteOrganizationHandler() ([]service.RbacChecker, service.Hand
 			if err := organization.Delete(tx, orga.ID); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventOrganizationDeleted, "", orga.Name, orga, nil); err != nil {
+				return err
+			}
 			event_v2.PublishOrganizationEvent(ctx, api.Cache, sdk.EventOrganizationDeleted, *orga, *u.AuthConsumerUser.AuthentifiedUser)
 			return sdk.WithStack(tx.Commit())
 		}
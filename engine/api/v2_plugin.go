@@ -73,15 +73,18 @@ func (api *API) postImportPluginHandler() ([]service.RbacChecker, service.Handle
 				}
 			}
 
+			eventType := sdk.EventPluginCreated
+			if oldP != nil {
+				eventType = sdk.EventPluginUpdated
+			}
+			if err := recordAudit(ctx, tx, eventType, "", p.Name, oldP, p); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
-			if oldP == nil {
-				event_v2.PublishPluginEvent(ctx, api.Cache, sdk.EventPluginCreated, p, *u.AuthConsumerUser.AuthentifiedUser)
-			} else {
-				event_v2.PublishPluginEvent(ctx, api.Cache, sdk.EventPluginUpdated, p, *u.AuthConsumerUser.AuthentifiedUser)
-			}
+			event_v2.PublishPluginEvent(ctx, api.Cache, eventType, p, *u.AuthConsumerUser.AuthentifiedUser)
 
 			return service.WriteJSON(w, p, http.StatusOK)
 		}
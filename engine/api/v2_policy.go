@@ -0,0 +1,427 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/organization"
+	"github.com/ovh/cds/engine/api/policy"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// loadProjectPolicies returns the policies of the project and of its organization
+func loadProjectPolicies(ctx context.Context, db gorp.SqlExecutor, projKey string) ([]sdk.Policy, error) {
+	proj, err := project.Load(ctx, db, projKey, project.LoadOptions.WithGroups)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := policy.LoadProjectPolicies(ctx, db, projKey)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Organization == "" {
+		return policies, nil
+	}
+	orga, err := organization.LoadOrganizationByName(ctx, db, proj.Organization)
+	if err != nil {
+		return nil, err
+	}
+	orgaPolicies, err := policy.LoadOrganizationPolicies(ctx, db, orga.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append(orgaPolicies, policies...), nil
+}
+
+// checkEntitiesPolicies evaluates the policies on the entities found during an analysis.
+// Denied entities are returned as errors, warnings are added to the analysis.
+func (api *API) checkEntitiesPolicies(ctx context.Context, analysis *sdk.ProjectRepositoryAnalysis, ef *EntityFinder, entities []sdk.EntityWithObject) []error {
+	policies, err := loadProjectPolicies(ctx, api.mustDB(), analysis.ProjectKey)
+	if err != nil {
+		return []error{err}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, e := range entities {
+		input := sdk.PolicyInput{
+			Stage:      sdk.PolicyStageAnalysis,
+			ProjectKey: analysis.ProjectKey,
+			VCSServer:  ef.currentVCS.Name,
+			Repository: ef.currentRepo.Name,
+			Ref:        analysis.Ref,
+			Commit:     analysis.Commit,
+			EntityType: e.Type,
+			EntityName: e.Name,
+			FilePath:   e.FilePath,
+		}
+		switch e.Type {
+		case sdk.EntityTypeWorkerModel:
+			input.Entity = e.Model
+		case sdk.EntityTypeAction:
+			input.Entity = e.Action
+		case sdk.EntityTypeWorkflow:
+			input.Entity = e.Workflow
+		case sdk.EntityTypeWorkflowTemplate:
+			input.Entity = e.Template
+		}
+		violations, err := policy.Evaluate(ctx, policies, input)
+		if err != nil {
+			return []error{err}
+		}
+		for _, v := range violations {
+			if v.Outcome == sdk.PolicyOutcomeDeny {
+				errs = append(errs, sdk.NewErrorFrom(sdk.ErrForbidden, "%s: %s", e.FilePath, v))
+				continue
+			}
+			analysis.Data.Warnings = append(analysis.Data.Warnings, fmt.Sprintf("%s: %s", e.FilePath, v))
+		}
+	}
+	return errs
+}
+
+// evaluateWorkflowRunPolicies evaluates the workflow policies on the workflow of a run, once templates and variables are resolved
+func (api *API) evaluateWorkflowRunPolicies(ctx context.Context, run *sdk.V2WorkflowRun) ([]sdk.PolicyViolation, error) {
+	policies, err := loadProjectPolicies(ctx, api.mustDB(), run.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return policy.Evaluate(ctx, policies, sdk.PolicyInput{
+		Stage:      sdk.PolicyStageCrafting,
+		ProjectKey: run.ProjectKey,
+		VCSServer:  run.VCSServer,
+		Repository: run.Repository,
+		Ref:        run.WorkflowRef,
+		Commit:     run.WorkflowSha,
+		EntityType: sdk.EntityTypeWorkflow,
+		EntityName: run.WorkflowName,
+		Entity:     run.WorkflowData.Workflow,
+	})
+}
+
+func (api *API) getProjectPoliciesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			var policies []sdk.Policy
+			var err error
+			if service.FormBool(req, "withOrganization") {
+				policies, err = loadProjectPolicies(ctx, api.mustDB(), pKey)
+			} else {
+				policies, err = policy.LoadProjectPolicies(ctx, api.mustDB(), pKey)
+			}
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, policies, http.StatusOK)
+		}
+}
+
+func (api *API) getProjectPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			p, err := policy.LoadProjectPolicyByName(ctx, api.mustDB(), vars["projectKey"], vars["policyName"])
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, p, http.StatusOK)
+		}
+}
+
+func (api *API) postProjectPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var p sdk.Policy
+			if err := service.UnmarshalBody(req, &p); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			p.ProjectKey = pKey
+			if err := policy.Validate(ctx, &p); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := policy.InsertProjectPolicy(ctx, tx, &p); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPolicyCreated, p.ProjectKey, p.Name, nil, p); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishPolicyEvent(ctx, api.Cache, sdk.EventPolicyCreated, "", p, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, p, http.StatusOK)
+		}
+}
+
+func (api *API) putProjectPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			oldPolicy, err := policy.LoadProjectPolicyByName(ctx, api.mustDB(), pKey, vars["policyName"])
+			if err != nil {
+				return err
+			}
+
+			var p sdk.Policy
+			if err := service.UnmarshalBody(req, &p); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			p.ID = oldPolicy.ID
+			p.ProjectKey = pKey
+			if err := policy.Validate(ctx, &p); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := policy.UpdateProjectPolicy(ctx, tx, &p); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPolicyUpdated, p.ProjectKey, p.Name, oldPolicy, p); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishPolicyEvent(ctx, api.Cache, sdk.EventPolicyUpdated, "", p, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, p, http.StatusOK)
+		}
+}
+
+func (api *API) deleteProjectPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			p, err := policy.LoadProjectPolicyByName(ctx, api.mustDB(), pKey, vars["policyName"])
+			if err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := policy.DeleteProjectPolicy(tx, pKey, p.ID); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPolicyDeleted, p.ProjectKey, p.Name, p, nil); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishPolicyEvent(ctx, api.Cache, sdk.EventPolicyDeleted, "", *p, *u.AuthConsumerUser.AuthentifiedUser)
+			return nil
+		}
+}
+
+func (api *API) getOrganizationPoliciesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalOrganizationManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+			if err != nil {
+				return err
+			}
+			policies, err := policy.LoadOrganizationPolicies(ctx, api.mustDB(), orga.ID)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, policies, http.StatusOK)
+		}
+}
+
+func (api *API) getOrganizationPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalOrganizationManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+			if err != nil {
+				return err
+			}
+			p, err := policy.LoadOrganizationPolicyByName(ctx, api.mustDB(), orga.ID, vars["policyName"])
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, p, http.StatusOK)
+		}
+}
+
+func (api *API) postOrganizationPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalOrganizationManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+			if err != nil {
+				return err
+			}
+
+			var p sdk.Policy
+			if err := service.UnmarshalBody(req, &p); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			p.OrganizationID = orga.ID
+			if err := policy.Validate(ctx, &p); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := policy.InsertOrganizationPolicy(ctx, tx, &p); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPolicyCreated, p.ProjectKey, p.Name, nil, p); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishPolicyEvent(ctx, api.Cache, sdk.EventPolicyCreated, orga.Name, p, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, p, http.StatusOK)
+		}
+}
+
+func (api *API) putOrganizationPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalOrganizationManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+			if err != nil {
+				return err
+			}
+			oldPolicy, err := policy.LoadOrganizationPolicyByName(ctx, api.mustDB(), orga.ID, vars["policyName"])
+			if err != nil {
+				return err
+			}
+
+			var p sdk.Policy
+			if err := service.UnmarshalBody(req, &p); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			p.ID = oldPolicy.ID
+			p.OrganizationID = orga.ID
+			if err := policy.Validate(ctx, &p); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := policy.UpdateOrganizationPolicy(ctx, tx, &p); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPolicyUpdated, p.ProjectKey, p.Name, oldPolicy, p); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishPolicyEvent(ctx, api.Cache, sdk.EventPolicyUpdated, orga.Name, p, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, p, http.StatusOK)
+		}
+}
+
+func (api *API) deleteOrganizationPolicyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.globalOrganizationManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+			if err != nil {
+				return err
+			}
+			p, err := policy.LoadOrganizationPolicyByName(ctx, api.mustDB(), orga.ID, vars["policyName"])
+			if err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := policy.DeleteOrganizationPolicy(tx, orga.ID, p.ID); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPolicyDeleted, p.ProjectKey, p.Name, p, nil); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishPolicyEvent(ctx, api.Cache, sdk.EventPolicyDeleted, orga.Name, *p, *u.AuthConsumerUser.AuthentifiedUser)
+			return nil
+		}
+}
@@ -32,7 +32,7 @@ func (api *API) getReposFromRepositoriesManagerV2Handler() ([]service.RbacChecke
 					"cannot get client got %s %s", projectKey, vcsServerName))
 			}
 
-			repos, err := repositoriesmanager.GetReposForProjectVCSServer(ctx, api.mustDB(), api.Cache, *proj, vcsServerName, repositoriesmanager.Options{
+			repos, err := repositoriesmanager.GetReposForProjectVCSServer(ctx, api.mustDB(), api.Cache, *proj, vcsServerName, project.LoadKeyWithPrivateContentByProjectKey, repositoriesmanager.Options{
 				Sync: sync,
 			})
 			if err != nil {
@@ -122,6 +122,9 @@ func (api *API) deleteProjectV2Handler() ([]service.RbacChecker, service.Handler
 			if err := project.Delete(tx, p.Key); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventProjectDeleted, p.Key, p.Key, p, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -182,9 +185,20 @@ func (api *API) updateProjectV2Handler() ([]service.RbacChecker, service.Handler
 			// Update in DB is made given the primary key
 			proj.ID = p.ID
 			proj.VCSServers = p.VCSServers
-			if err := project.Update(api.mustDB(), proj); err != nil {
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := project.Update(tx, proj); err != nil {
 				return sdk.WrapError(err, "cannot update project %s", key)
 			}
+			if err := recordAudit(ctx, tx, sdk.EventProjectUpdated, proj.Key, proj.Key, p, proj); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
 			event_v2.PublishProjectEvent(ctx, api.Cache, sdk.EventProjectUpdated, *proj, *u.AuthConsumerUser.AuthentifiedUser)
 
 			proj.Permissions.Writable = true
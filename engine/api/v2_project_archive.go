@@ -0,0 +1,878 @@
+package api
+
+import (
+	"context"
+	"crypto/rsa"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/integration"
+	"github.com/ovh/cds/engine/api/notification_v2"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/repository"
+	"github.com/ovh/cds/engine/api/vcs"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
+)
+
+// projectArchiveKey returns the key pair used to sign the exported project archives and to decrypt the secrets of the imported ones
+func (api *API) projectArchiveKey() (*rsa.PrivateKey, error) {
+	if api.Config.Project.ArchiveRSAPrivateKey == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "project archives are not configured on this instance")
+	}
+	k, err := jws.NewPrivateKeyFromPEM([]byte(api.Config.Project.ArchiveRSAPrivateKey))
+	if err != nil {
+		return nil, sdk.WrapError(err, "invalid project archive private key")
+	}
+	return k, nil
+}
+
+// projectArchiveTrustedKeys returns the public keys of the CDS instances allowed to exchange project archives with this instance
+func (api *API) projectArchiveTrustedKeys() (map[string]*rsa.PublicKey, error) {
+	keys := make(map[string]*rsa.PublicKey, len(api.Config.Project.ArchiveTrustedInstances))
+	for name, k := range api.Config.Project.ArchiveTrustedInstances {
+		pub, err := jws.NewPublicKeyFromPEM([]byte(k))
+		if err != nil {
+			return nil, sdk.WrapError(err, "invalid public key for trusted instance %s", name)
+		}
+		keys[name] = pub
+	}
+	return keys, nil
+}
+
+func (api *API) isProjectArchiveTrustedKey(archiveKey *rsa.PrivateKey, pub *rsa.PublicKey) (bool, error) {
+	if pub.Equal(&archiveKey.PublicKey) {
+		return true, nil
+	}
+	keys, err := api.projectArchiveTrustedKeys()
+	if err != nil {
+		return false, err
+	}
+	for _, k := range keys {
+		if pub.Equal(k) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getProjectArchiveKeyHandler returns the public key used to encrypt the secrets of project archives imported on this instance
+func (api *API) getProjectArchiveKeyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBACNone(),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			archiveKey, err := api.projectArchiveKey()
+			if err != nil {
+				return err
+			}
+			pubKey, err := jws.ExportPublicKey(archiveKey)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, string(pubKey), http.StatusOK)
+		}
+}
+
+// postProjectExportHandler exports the configuration of the project in a signed archive.
+// Secrets are encrypted for the target instance, that must be a trusted instance.
+func (api *API) postProjectExportHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			pKey := mux.Vars(req)["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var exportRequest sdk.ProjectExportRequest
+			if err := service.UnmarshalRequest(ctx, req, &exportRequest); err != nil {
+				return err
+			}
+
+			signingKey, err := api.projectArchiveKey()
+			if err != nil {
+				return err
+			}
+			recipient := &signingKey.PublicKey
+			if exportRequest.Target != "" {
+				keys, err := api.projectArchiveTrustedKeys()
+				if err != nil {
+					return err
+				}
+				k, has := keys[exportRequest.Target]
+				if !has {
+					return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown trusted instance %q", exportRequest.Target)
+				}
+				recipient = k
+			}
+			recipientKey, err := jws.MarshalPublicKey(recipient)
+			if err != nil {
+				return err
+			}
+
+			archive, err := api.exportProject(ctx, pKey)
+			if err != nil {
+				return err
+			}
+			archive.ExportedAt = time.Now()
+			archive.ExportedBy = u.GetUsername()
+			archive.Source = api.Config.URL.API
+			archive.RecipientKey = string(recipientKey)
+			if err := archive.TransformSecrets(func(s string) (string, error) {
+				return jws.Encrypt(recipient, []byte(s))
+			}); err != nil {
+				return err
+			}
+
+			signer, err := jws.NewSigner(signingKey)
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			signedArchive, err := jws.Sign(signer, archive)
+			if err != nil {
+				return err
+			}
+			signerKey, err := jws.ExportPublicKey(signingKey)
+			if err != nil {
+				return err
+			}
+
+			log.Info(ctx, "project %s exported by %s for %s", pKey, u.GetUsername(), exportRequest.Target)
+			return service.WriteJSON(w, sdk.SignedProjectArchive{
+				SignerKey: string(signerKey),
+				Archive:   signedArchive,
+			}, http.StatusOK)
+		}
+}
+
+func (api *API) exportProject(ctx context.Context, pKey string) (*sdk.ProjectArchive, error) {
+	db := api.mustDB()
+	proj, err := project.Load(ctx, db, pKey)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := sdk.ProjectArchive{
+		Version: sdk.ProjectArchiveVersion,
+		Project: sdk.ProjectArchiveProject{
+			Key:         proj.Key,
+			Name:        proj.Name,
+			Description: proj.Description,
+		},
+	}
+
+	vcsProjects, err := vcs.LoadAllVCSByProject(ctx, db, pKey, gorpmapping.GetAllOptions.WithDecryption)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vcsProjects {
+		repos, err := repository.LoadAllRepositoriesByVCSProjectID(ctx, db, v.ID)
+		if err != nil {
+			return nil, err
+		}
+		archive.VCSServers = append(archive.VCSServers, sdk.ProjectArchiveVCS{VCSProject: v, Repositories: repos})
+	}
+
+	varSets, err := project.LoadVariableSetsByProject(ctx, db, pKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, vs := range varSets {
+		vs.Items, err = project.LoadVariableSetAllItem(ctx, db, vs.ID, gorpmapper.GetAllOptions.WithDecryption)
+		if err != nil {
+			return nil, err
+		}
+		archive.VariableSets = append(archive.VariableSets, vs)
+	}
+
+	integrations, err := integration.LoadIntegrationsByProjectIDWithClearPassword(ctx, db, proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range integrations {
+		// Public integrations are added to all projects by each instance
+		if i.Model.Public {
+			continue
+		}
+		archive.Integrations = append(archive.Integrations, i)
+	}
+
+	archive.Notifications, err = notification_v2.LoadAll(ctx, db, pKey, gorpmapping.GetAllOptions.WithDecryption)
+	if err != nil {
+		return nil, err
+	}
+	archive.Concurrencies, err = project.LoadConcurrenciesByProjectKey(ctx, db, pKey)
+	if err != nil {
+		return nil, err
+	}
+	archive.RunFilters, err = project.LoadRunFiltersByProjectKey(ctx, db, pKey)
+	if err != nil {
+		return nil, err
+	}
+	retention, err := project.LoadRunRetentionByProjectKey(ctx, db, pKey)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	if retention != nil {
+		archive.RunRetention = &retention.Retentions
+	}
+
+	keys, err := project.LoadAllKeysWithPrivateContent(ctx, db, proj.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k.Builtin {
+			continue
+		}
+		k.ID, k.ProjectID = 0, 0
+		archive.Keys = append(archive.Keys, k)
+	}
+
+	rules, err := rbac.LoadAllRBACByProjectKey(ctx, db, pKey, rbac.LoadOptions.All)
+	if err != nil {
+		return nil, err
+	}
+	rbacLoader := NewRBACLoader(db)
+	for i := range rules {
+		if err := rbacLoader.FillRBACWithNames(ctx, &rules[i]); err != nil {
+			return nil, err
+		}
+		archive.Permissions = append(archive.Permissions, rules[i].ProjectPermissions(pKey))
+	}
+
+	return &archive, nil
+}
+
+// postProjectImportHandler imports a signed project archive in an existing project.
+// Existing entities are skipped, or updated if force is set.
+func (api *API) postProjectImportHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			force := service.FormBool(req, "force")
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var signedArchive sdk.SignedProjectArchive
+			if err := service.UnmarshalRequest(ctx, req, &signedArchive); err != nil {
+				return err
+			}
+			archiveKey, err := api.projectArchiveKey()
+			if err != nil {
+				return err
+			}
+			archive, err := api.verifyProjectArchive(archiveKey, signedArchive)
+			if err != nil {
+				return err
+			}
+			if err := archive.TransformSecrets(func(s string) (string, error) {
+				clear, err := jws.Decrypt(archiveKey, s)
+				return string(clear), err
+			}); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to decrypt archive secrets: %v", err)
+			}
+			archive.SetProjectKey(pKey)
+
+			proj, err := project.Load(ctx, api.mustDB(), pKey)
+			if err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			importer := projectImporter{
+				tx:    tx,
+				proj:  *proj,
+				force: force,
+				user:  *u.AuthConsumerUser.AuthentifiedUser,
+			}
+			if err := importer.importArchive(ctx, *archive); err != nil {
+				return err
+			}
+
+			// Permissions are not limited to the project, only permission managers can import them
+			if len(archive.Permissions) > 0 {
+				if isAdmin(ctx) || api.globalPermissionManage(ctx, vars) == nil {
+					if err := importer.importPermissions(ctx, archive.Permissions); err != nil {
+						return err
+					}
+				} else {
+					for _, r := range archive.Permissions {
+						importer.add(sdk.ProjectArchiveEntityPermission, r.Name, sdk.ProjectImportActionSkipped, "permission manager role is required")
+					}
+				}
+			}
+
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			for _, publish := range importer.events {
+				publish(ctx, api.Cache)
+			}
+
+			log.Info(ctx, "project archive of %s exported by %s from %s imported in %s by %s", archive.Project.Name, archive.ExportedBy, archive.Source, pKey, u.GetUsername())
+			return service.WriteJSON(w, importer.report, http.StatusOK)
+		}
+}
+
+// verifyProjectArchive checks that the archive was signed by a trusted instance and that its secrets were encrypted for this instance
+func (api *API) verifyProjectArchive(archiveKey *rsa.PrivateKey, signedArchive sdk.SignedProjectArchive) (*sdk.ProjectArchive, error) {
+	signerKey, err := jws.NewPublicKeyFromPEM([]byte(signedArchive.SignerKey))
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid archive signer key: %v", err)
+	}
+	trusted, err := api.isProjectArchiveTrustedKey(archiveKey, signerKey)
+	if err != nil {
+		return nil, err
+	}
+	if !trusted {
+		return nil, sdk.NewErrorFrom(sdk.ErrForbidden, "archive was exported by an untrusted CDS instance")
+	}
+
+	var archive sdk.ProjectArchive
+	if err := jws.Verify(signerKey, signedArchive.Archive, &archive); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid archive signature")
+	}
+	if archive.Version != sdk.ProjectArchiveVersion {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unsupported archive version %q", archive.Version)
+	}
+
+	pubKey, err := jws.ExportPublicKey(archiveKey)
+	if err != nil {
+		return nil, err
+	}
+	if archive.RecipientKey != string(pubKey) {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "archive secrets were encrypted for another CDS instance")
+	}
+	return &archive, nil
+}
+
+type projectImporter struct {
+	tx     gorpmapper.SqlExecutorWithTx
+	proj   sdk.Project
+	force  bool
+	user   sdk.AuthentifiedUser
+	report []sdk.ProjectImportReportLine
+	// events are published once the import is committed
+	events []func(context.Context, cache.Store)
+}
+
+func (i *projectImporter) publishLater(f func(context.Context, cache.Store)) {
+	i.events = append(i.events, f)
+}
+
+// audit stores the audit of an imported entity in the transaction of the import
+func (i *projectImporter) audit(ctx context.Context, eventType sdk.EventType, entityName string, before, after interface{}) error {
+	return recordUserAudit(ctx, i.tx, &i.user, eventType, i.proj.Key, entityName, before, after)
+}
+
+// eventType returns the created or the updated event type depending on the existence of the entity
+func (i *projectImporter) eventType(exists bool, created, updated sdk.EventType) sdk.EventType {
+	if exists {
+		return updated
+	}
+	return created
+}
+
+func (i *projectImporter) add(entityType, name, action, message string) {
+	i.report = append(i.report, sdk.ProjectImportReportLine{Type: entityType, Name: name, Action: action, Message: message})
+}
+
+// action returns the action to apply on an entity depending on its existence
+func (i *projectImporter) action(entityType, name string, exists bool) string {
+	switch {
+	case !exists:
+		return sdk.ProjectImportActionCreated
+	case i.force:
+		return sdk.ProjectImportActionUpdated
+	default:
+		i.add(entityType, name, sdk.ProjectImportActionSkipped, "already exists")
+		return sdk.ProjectImportActionSkipped
+	}
+}
+
+func (i *projectImporter) importArchive(ctx context.Context, archive sdk.ProjectArchive) error {
+	// Keys first: git vcs servers use the project ssh keys
+	for _, k := range archive.Keys {
+		if err := i.importKey(ctx, k); err != nil {
+			return sdk.WrapError(err, "unable to import key %s", k.Name)
+		}
+	}
+	for _, v := range archive.VCSServers {
+		if err := i.importVCS(ctx, v); err != nil {
+			return sdk.WrapError(err, "unable to import vcs %s", v.Name)
+		}
+	}
+	for _, vs := range archive.VariableSets {
+		if err := i.importVariableSet(ctx, vs); err != nil {
+			return sdk.WrapError(err, "unable to import variable set %s", vs.Name)
+		}
+	}
+	for _, integ := range archive.Integrations {
+		if err := i.importIntegration(ctx, integ); err != nil {
+			return sdk.WrapError(err, "unable to import integration %s", integ.Name)
+		}
+	}
+	for _, n := range archive.Notifications {
+		if err := i.importNotification(ctx, n); err != nil {
+			return sdk.WrapError(err, "unable to import notification %s", n.Name)
+		}
+	}
+	for _, c := range archive.Concurrencies {
+		if err := i.importConcurrency(ctx, c); err != nil {
+			return sdk.WrapError(err, "unable to import concurrency %s", c.Name)
+		}
+	}
+	for _, f := range archive.RunFilters {
+		if err := i.importRunFilter(ctx, f); err != nil {
+			return sdk.WrapError(err, "unable to import run filter %s", f.Name)
+		}
+	}
+	if archive.RunRetention != nil {
+		if err := i.importRunRetention(ctx, *archive.RunRetention); err != nil {
+			return sdk.WrapError(err, "unable to import run retention")
+		}
+	}
+	return nil
+}
+
+func (i *projectImporter) importKey(ctx context.Context, k sdk.ProjectKey) error {
+	keys, err := project.LoadAllKeysWithPrivateContent(ctx, i.tx, i.proj.ID)
+	if err != nil {
+		return err
+	}
+	var existing *sdk.ProjectKey
+	for j := range keys {
+		if keys[j].Name == k.Name && !keys[j].Builtin {
+			existing = &keys[j]
+		}
+	}
+	k.ProjectID = i.proj.ID
+	switch i.action(sdk.ProjectArchiveEntityKey, k.Name, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+		if err := project.InsertKey(i.tx, &k); err != nil {
+			return err
+		}
+	case sdk.ProjectImportActionUpdated:
+		k.ID = existing.ID
+		if err := project.UpdateKey(i.tx, &k); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	i.add(sdk.ProjectArchiveEntityKey, k.Name, i.lastAction(existing != nil), "")
+	eventType := i.eventType(existing != nil, sdk.EventProjectKeyCreated, sdk.EventProjectKeyUpdated)
+	if err := i.audit(ctx, eventType, k.Name, existing, k); err != nil {
+		return err
+	}
+	i.publishLater(func(ctx context.Context, store cache.Store) {
+		event_v2.PublishProjectKeyEvent(ctx, store, eventType, i.proj.Key, k, i.user)
+	})
+	return nil
+}
+
+func (i *projectImporter) importVCS(ctx context.Context, v sdk.ProjectArchiveVCS) error {
+	existing, err := vcs.LoadVCSByProject(ctx, i.tx, i.proj.Key, v.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	vcsProject := v.VCSProject
+	vcsProject.ProjectID = i.proj.ID
+	switch i.action(sdk.ProjectArchiveEntityVCS, v.Name, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+		vcsProject.CreatedBy = i.user.Username
+		if err := vcs.Insert(ctx, i.tx, &vcsProject); err != nil {
+			return err
+		}
+	case sdk.ProjectImportActionUpdated:
+		vcsProject.ID = existing.ID
+		vcsProject.Created = existing.Created
+		vcsProject.CreatedBy = existing.CreatedBy
+		if err := vcs.Update(ctx, i.tx, &vcsProject); err != nil {
+			return err
+		}
+		i.add(sdk.ProjectArchiveEntityVCS, v.Name, sdk.ProjectImportActionUpdated, "")
+	default:
+		// Missing repositories are still added to the existing vcs
+		vcsProject = *existing
+	}
+	if existing == nil {
+		i.add(sdk.ProjectArchiveEntityVCS, v.Name, sdk.ProjectImportActionCreated, "")
+	}
+	if existing == nil || i.force {
+		eventVCS := vcsProject
+		eventVCS.Auth.Token = ""
+		eventVCS.Auth.SSHPrivateKey = ""
+		eventType := i.eventType(existing != nil, sdk.EventVCSCreated, sdk.EventVCSUpdated)
+		if err := i.audit(ctx, eventType, v.Name, existing, vcsProject); err != nil {
+			return err
+		}
+		i.publishLater(func(ctx context.Context, store cache.Store) {
+			event_v2.PublishVCSEvent(ctx, store, eventType, i.proj.Key, eventVCS, i.user)
+		})
+	}
+
+	for _, r := range v.Repositories {
+		existingRepo, err := repository.LoadRepositoryByName(ctx, i.tx, vcsProject.ID, r.Name)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		repoName := v.Name + "/" + r.Name
+		switch i.action(sdk.ProjectArchiveEntityRepository, repoName, existingRepo != nil) {
+		case sdk.ProjectImportActionCreated:
+			r.VCSProjectID = vcsProject.ID
+			r.CreatedBy = i.user.Username
+			if err := repository.Insert(ctx, i.tx, &r); err != nil {
+				return err
+			}
+			i.add(sdk.ProjectArchiveEntityRepository, repoName, sdk.ProjectImportActionCreated, "trigger an analysis to load its entities")
+			if err := i.audit(ctx, sdk.EventRepositoryCreated, repoName, nil, r); err != nil {
+				return err
+			}
+			repo := r
+			i.publishLater(func(ctx context.Context, store cache.Store) {
+				event_v2.PublishRepositoryEvent(ctx, store, sdk.EventRepositoryCreated, i.proj.Key, v.Name, repo, i.user)
+			})
+		case sdk.ProjectImportActionUpdated:
+			existingRepo.CloneURL = r.CloneURL
+			if err := repository.Update(ctx, i.tx, existingRepo); err != nil {
+				return err
+			}
+			i.add(sdk.ProjectArchiveEntityRepository, repoName, sdk.ProjectImportActionUpdated, "")
+		}
+	}
+	return nil
+}
+
+func (i *projectImporter) importVariableSet(ctx context.Context, vs sdk.ProjectVariableSet) error {
+	existing, err := project.LoadVariableSetByName(ctx, i.tx, i.proj.Key, vs.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	existingItems := make(map[string]sdk.ProjectVariableSetItem)
+	switch i.action(sdk.ProjectArchiveEntityVariableSet, vs.Name, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+		existing = &sdk.ProjectVariableSet{ProjectKey: i.proj.Key, Name: vs.Name}
+		if err := project.InsertVariableSet(ctx, i.tx, existing); err != nil {
+			return err
+		}
+		i.add(sdk.ProjectArchiveEntityVariableSet, vs.Name, sdk.ProjectImportActionCreated, "")
+		createdVS := *existing
+		if err := i.audit(ctx, sdk.EventVariableSetCreated, vs.Name, nil, createdVS); err != nil {
+			return err
+		}
+		i.publishLater(func(ctx context.Context, store cache.Store) {
+			event_v2.PublishProjectVariableSetEvent(ctx, store, sdk.EventVariableSetCreated, i.proj.Key, createdVS, i.user)
+		})
+	case sdk.ProjectImportActionUpdated:
+		items, err := project.LoadVariableSetAllItem(ctx, i.tx, existing.ID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			existingItems[item.Name] = item
+		}
+		i.add(sdk.ProjectArchiveEntityVariableSet, vs.Name, sdk.ProjectImportActionUpdated, "")
+	default:
+		return nil
+	}
+
+	for _, item := range vs.Items {
+		item.ProjectVariableSetID = existing.ID
+		old, has := existingItems[item.Name]
+		if has && old.Type != item.Type {
+			if err := deleteVariableSetItem(ctx, i.tx, old); err != nil {
+				return err
+			}
+			if err := i.audit(ctx, sdk.EventVariableSetItemDeleted, vs.Name+"/"+old.Name, old, nil); err != nil {
+				return err
+			}
+			has = false
+		}
+		if has {
+			item.ID = old.ID
+			if item.Type == sdk.ProjectVariableTypeSecret {
+				err = project.UpdateVariableSetItemSecret(ctx, i.tx, &item)
+			} else {
+				err = project.UpdateVariableSetItemText(ctx, i.tx, &item)
+			}
+		} else {
+			if item.Type == sdk.ProjectVariableTypeSecret {
+				err = project.InsertVariableSetItemSecret(ctx, i.tx, &item)
+			} else {
+				err = project.InsertVariableSetItemText(ctx, i.tx, &item)
+			}
+		}
+		if err != nil {
+			return sdk.WrapError(err, "unable to import item %s", item.Name)
+		}
+		eventItem := item
+		eventType := i.eventType(has, sdk.EventVariableSetItemCreated, sdk.EventVariableSetItemUpdated)
+		var before interface{}
+		if has {
+			before = old
+		}
+		if err := i.audit(ctx, eventType, vs.Name+"/"+item.Name, before, item); err != nil {
+			return err
+		}
+		i.publishLater(func(ctx context.Context, store cache.Store) {
+			event_v2.PublishProjectVariableSetItemEvent(ctx, store, eventType, i.proj.Key, vs.Name, eventItem, i.user)
+		})
+	}
+	return nil
+}
+
+func deleteVariableSetItem(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, item sdk.ProjectVariableSetItem) error {
+	if item.Type == sdk.ProjectVariableTypeSecret {
+		return project.DeleteVariableSetItemSecret(ctx, tx, item)
+	}
+	return project.DeleteVariableSetItemText(ctx, tx, item)
+}
+
+func (i *projectImporter) importIntegration(ctx context.Context, pi sdk.ProjectIntegration) error {
+	model, err := integration.LoadModelByName(ctx, i.tx, pi.Model.Name)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			i.add(sdk.ProjectArchiveEntityIntegration, pi.Name, sdk.ProjectImportActionSkipped, "integration model "+pi.Model.Name+" not found")
+			return nil
+		}
+		return err
+	}
+	existing, err := integration.LoadProjectIntegrationByName(ctx, i.tx, i.proj.Key, pi.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	exists := err == nil
+	pi.ProjectID = i.proj.ID
+	pi.IntegrationModelID = model.ID
+	pi.Model = model
+	switch i.action(sdk.ProjectArchiveEntityIntegration, pi.Name, exists) {
+	case sdk.ProjectImportActionCreated:
+		pi.ID = 0
+		if err := integration.InsertIntegration(i.tx, &pi); err != nil {
+			return err
+		}
+	case sdk.ProjectImportActionUpdated:
+		if existing.Model.Public {
+			i.add(sdk.ProjectArchiveEntityIntegration, pi.Name, sdk.ProjectImportActionSkipped, "public integration")
+			return nil
+		}
+		pi.ID = existing.ID
+		if err := integration.UpdateIntegration(ctx, i.tx, pi); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	if model.Event {
+		if err := event.ResetEventIntegration(ctx, i.tx, pi.ID); err != nil {
+			return sdk.WrapError(err, "cannot connect to event broker")
+		}
+	}
+	i.add(sdk.ProjectArchiveEntityIntegration, pi.Name, i.lastAction(exists), "")
+	eventType := i.eventType(exists, sdk.EventIntegrationCreated, sdk.EventIntegrationUpdated)
+	var before interface{}
+	if exists {
+		before = existing
+	}
+	if err := i.audit(ctx, eventType, pi.Name, before, pi); err != nil {
+		return err
+	}
+	i.publishLater(func(ctx context.Context, store cache.Store) {
+		event_v2.PublishProjectIntegrationEvent(ctx, store, eventType, i.proj.Key, pi, i.user)
+	})
+	return nil
+}
+
+func (i *projectImporter) importNotification(ctx context.Context, n sdk.ProjectNotification) error {
+	existing, err := notification_v2.LoadByName(ctx, i.tx, i.proj.Key, n.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	switch i.action(sdk.ProjectArchiveEntityNotification, n.Name, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+		if err := notification_v2.Insert(ctx, i.tx, &n); err != nil {
+			return err
+		}
+	case sdk.ProjectImportActionUpdated:
+		n.ID = existing.ID
+		if err := notification_v2.Update(ctx, i.tx, &n); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	i.add(sdk.ProjectArchiveEntityNotification, n.Name, i.lastAction(existing != nil), "")
+	eventType := i.eventType(existing != nil, sdk.EventNotificationCreated, sdk.EventNotificationUpdated)
+	if err := i.audit(ctx, eventType, n.Name, existing, n); err != nil {
+		return err
+	}
+	i.publishLater(func(ctx context.Context, store cache.Store) {
+		event_v2.PublishProjectNotificationEvent(ctx, store, eventType, i.proj.Key, n, i.user)
+	})
+	return nil
+}
+
+func (i *projectImporter) importConcurrency(ctx context.Context, c sdk.ProjectConcurrency) error {
+	existing, err := project.LoadConcurrencyByNameAndProjectKey(ctx, i.tx, i.proj.Key, c.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	switch i.action(sdk.ProjectArchiveEntityConcurrency, c.Name, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+		if err := project.InsertConcurrency(ctx, i.tx, &c); err != nil {
+			return err
+		}
+	case sdk.ProjectImportActionUpdated:
+		c.ID = existing.ID
+		if err := project.UpdateConcurrency(ctx, i.tx, &c); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	i.add(sdk.ProjectArchiveEntityConcurrency, c.Name, i.lastAction(existing != nil), "")
+	eventType := i.eventType(existing != nil, sdk.EventConcurrencyCreated, sdk.EventConcurrencyUpdated)
+	if err := i.audit(ctx, eventType, c.Name, existing, c); err != nil {
+		return err
+	}
+	i.publishLater(func(ctx context.Context, store cache.Store) {
+		event_v2.PublishConcurrencyEvent(ctx, store, eventType, i.proj.Key, c, i.user)
+	})
+	return nil
+}
+
+func (i *projectImporter) importRunFilter(ctx context.Context, f sdk.ProjectRunFilter) error {
+	existing, err := project.LoadRunFilterByNameAndProjectKey(ctx, i.tx, i.proj.Key, f.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	switch i.action(sdk.ProjectArchiveEntityRunFilter, f.Name, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+	case sdk.ProjectImportActionUpdated:
+		if err := project.DeleteRunFilter(i.tx, i.proj.Key, existing.ID); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	if err := project.InsertRunFilter(ctx, i.tx, &f); err != nil {
+		return err
+	}
+	i.add(sdk.ProjectArchiveEntityRunFilter, f.Name, i.lastAction(existing != nil), "")
+	eventType := i.eventType(existing != nil, sdk.EventProjectRunFilterCreated, sdk.EventProjectRunFilterUpdated)
+	if err := i.audit(ctx, eventType, f.Name, existing, f); err != nil {
+		return err
+	}
+	i.publishLater(func(ctx context.Context, store cache.Store) {
+		event_v2.PublishProjectRunFilterEvent(ctx, store, eventType, i.proj.Key, f, i.user)
+	})
+	return nil
+}
+
+func (i *projectImporter) importRunRetention(ctx context.Context, retentions sdk.Retentions) error {
+	existing, err := project.LoadRunRetentionByProjectKey(ctx, i.tx, i.proj.Key)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	var before interface{}
+	switch i.action(sdk.ProjectArchiveEntityRunRetention, i.proj.Key, existing != nil) {
+	case sdk.ProjectImportActionCreated:
+		if err := project.InsertRunRetention(ctx, i.tx, &sdk.ProjectRunRetention{ProjectKey: i.proj.Key, Retentions: retentions}); err != nil {
+			return err
+		}
+	case sdk.ProjectImportActionUpdated:
+		before = existing.Retentions
+		existing.Retentions = retentions
+		if err := project.UpdateRunRetention(ctx, i.tx, existing); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	i.add(sdk.ProjectArchiveEntityRunRetention, i.proj.Key, i.lastAction(existing != nil), "")
+	if err := i.audit(ctx, sdk.EventProjectRunRetentionUpdated, i.proj.Key, before, retentions); err != nil {
+		return err
+	}
+	i.publishLater(func(ctx context.Context, store cache.Store) {
+		event_v2.PublishProjectRunRetentionEvent(ctx, store, sdk.EventProjectRunRetentionUpdated, i.proj.Key, retentions, i.user)
+	})
+	return nil
+}
+
+// importPermissions creates the rules of the archive. When forced, the permissions of an existing rule on the project
+// are replaced by the ones of the archive, permissions on other projects are kept.
+func (i *projectImporter) importPermissions(ctx context.Context, rules []sdk.RBAC) error {
+	rbacLoader := NewRBACLoader(i.tx)
+	for _, r := range rules {
+		existing, err := rbac.LoadRBACByName(ctx, i.tx, r.Name, rbac.LoadOptions.All)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		action := i.action(sdk.ProjectArchiveEntityPermission, r.Name, existing != nil)
+		if action == sdk.ProjectImportActionSkipped {
+			continue
+		}
+
+		newRule := r
+		if existing != nil {
+			if err := rbacLoader.FillRBACWithNames(ctx, existing); err != nil {
+				return err
+			}
+			newRule = existing.WithoutProjectPermissions(i.proj.Key)
+			newRule.Projects = append(newRule.Projects, r.Projects...)
+			newRule.Workflows = append(newRule.Workflows, r.Workflows...)
+			newRule.VariableSets = append(newRule.VariableSets, r.VariableSets...)
+		}
+		// Users and groups may not exist on this instance
+		if err := rbacLoader.FillRBACWithIDs(ctx, &newRule); err != nil {
+			i.add(sdk.ProjectArchiveEntityPermission, r.Name, sdk.ProjectImportActionSkipped, err.Error())
+			continue
+		}
+		if existing != nil {
+			if err := rbac.Delete(ctx, i.tx, *existing); err != nil {
+				return err
+			}
+		}
+		if err := rbac.Insert(ctx, i.tx, &newRule); err != nil {
+			return sdk.WrapError(err, "unable to import permission %s", r.Name)
+		}
+		i.add(sdk.ProjectArchiveEntityPermission, r.Name, action, "")
+		eventRule := newRule
+		eventType := i.eventType(existing != nil, sdk.EventPermissionCreated, sdk.EventPermissionUpdated)
+		if err := recordUserAudit(ctx, i.tx, &i.user, eventType, "", newRule.Name, existing, newRule); err != nil {
+			return err
+		}
+		i.publishLater(func(ctx context.Context, store cache.Store) {
+			event_v2.PublishPermissionEvent(ctx, store, eventType, eventRule, i.user)
+		})
+	}
+	return nil
+}
+
+func (i *projectImporter) lastAction(exists bool) string {
+	if exists {
+		return sdk.ProjectImportActionUpdated
+	}
+	return sdk.ProjectImportActionCreated
+}
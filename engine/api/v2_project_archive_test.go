@@ -0,0 +1,96 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/jws"
+)
+
+func Test_exportAndImportProject(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+	ctx := context.TODO()
+
+	archiveKey, err := jws.NewRandomRSAKey()
+	require.NoError(t, err)
+	pemKey, err := jws.ExportPrivateKey(archiveKey)
+	require.NoError(t, err)
+	api.Config.Project.ArchiveRSAPrivateKey = string(pemKey)
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	target := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	user1, pass := assets.InsertLambdaUser(t, db)
+	assets.InsertRBAcProject(t, db, sdk.ProjectRoleManage, proj.Key, *user1)
+	assets.InsertRBAcProject(t, db, sdk.ProjectRoleManage, target.Key, *user1)
+
+	vs := sdk.ProjectVariableSet{ProjectKey: proj.Key, Name: "prod"}
+	require.NoError(t, project.InsertVariableSet(ctx, db, &vs))
+	item := sdk.ProjectVariableSetItem{ProjectVariableSetID: vs.ID, Name: "password", Type: sdk.ProjectVariableTypeSecret, Value: "mysecret"}
+	require.NoError(t, project.InsertVariableSetItemSecret(ctx, db, &item))
+	concurrency := sdk.ProjectConcurrency{ProjectKey: proj.Key, Name: "deploy", Order: sdk.ConcurrencyOrderOldestFirst, Pool: 1}
+	require.NoError(t, project.InsertConcurrency(ctx, db, &concurrency))
+
+	// Export
+	uri := api.Router.GetRouteV2("POST", api.postProjectExportHandler, map[string]string{"projectKey": proj.Key})
+	test.NotEmpty(t, uri)
+	req := assets.NewAuthentifiedRequest(t, user1, pass, "POST", uri, nil)
+	w := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	var archive sdk.SignedProjectArchive
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &archive))
+	require.NotContains(t, archive.Archive, "mysecret")
+
+	// Import in another project
+	uri = api.Router.GetRouteV2("POST", api.postProjectImportHandler, map[string]string{"projectKey": target.Key})
+	test.NotEmpty(t, uri)
+	req = assets.NewAuthentifiedRequest(t, user1, pass, "POST", uri, nil)
+	bts, _ := json.Marshal(archive)
+	req.Body = io.NopCloser(bytes.NewReader(bts))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	var report []sdk.ProjectImportReportLine
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Contains(t, report, sdk.ProjectImportReportLine{Type: sdk.ProjectArchiveEntityVariableSet, Name: "prod", Action: sdk.ProjectImportActionCreated})
+	require.Contains(t, report, sdk.ProjectImportReportLine{Type: sdk.ProjectArchiveEntityConcurrency, Name: "deploy", Action: sdk.ProjectImportActionCreated})
+
+	importedVS, err := project.LoadVariableSetByName(ctx, db, target.Key, "prod")
+	require.NoError(t, err)
+	items, err := project.LoadVariableSetAllItem(ctx, db, importedVS.ID, gorpmapper.GetAllOptions.WithDecryption)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "mysecret", items[0].Value)
+
+	// A second import skips existing entities
+	req = assets.NewAuthentifiedRequest(t, user1, pass, "POST", uri, nil)
+	req.Body = io.NopCloser(bytes.NewReader(bts))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Contains(t, report, sdk.ProjectImportReportLine{Type: sdk.ProjectArchiveEntityConcurrency, Name: "deploy", Action: sdk.ProjectImportActionSkipped, Message: "already exists"})
+
+	// An archive whose signature was altered is refused
+	archive.Archive = archive.Archive[:len(archive.Archive)-4] + "AAAA"
+	req = assets.NewAuthentifiedRequest(t, user1, pass, "POST", uri, nil)
+	bts, _ = json.Marshal(archive)
+	req.Body = io.NopCloser(bytes.NewReader(bts))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(w, req)
+	require.Equal(t, 400, w.Code)
+}
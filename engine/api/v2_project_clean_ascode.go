@@ -141,7 +141,7 @@ func cleanWorkflowVersion(ctx context.Context, db *gorp.DbMap, store cache.Store
 		}
 
 		if !cleanAll {
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, w.ProjectKey, w.WorkflowVCS)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, w.ProjectKey, w.WorkflowVCS, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -314,7 +314,7 @@ func cleanAscodeProject(ctx context.Context, db *gorp.DbMap, store cache.Store,
 }
 
 func (c *EntitiesCleaner) getRefs(ctx context.Context, db *gorp.DbMap, store cache.Store) error {
-	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, c.projKey, c.vcsName)
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, c.projKey, c.vcsName, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return err
 	}
@@ -356,6 +356,9 @@ func (c *EntitiesCleaner) cleanNonHeadEntities(ctx context.Context, db *gorp.DbM
 				if err := DeleteEntity(ctx, tx, &e, hookServices, DeleteEntityOps{WithHooks: false}); err != nil {
 					return err
 				}
+				if err := recordUserAudit(ctx, tx, nil, sdk.EventEntityDeleted, e.ProjectKey, c.vcsName+"/"+c.repoName+"/"+e.Name, e, nil); err != nil {
+					return err
+				}
 				log.Info(ctx, "entity %s of type %s deleted on branch %s for commit %s", e.Name, e.Type, e.Ref, e.Commit)
 				deletedEntities = append(deletedEntities, e)
 			}
@@ -387,6 +390,9 @@ func (c *EntitiesCleaner) cleanEntitiesByDeletedRef(ctx context.Context, db *gor
 			if err := DeleteEntity(ctx, tx, &e, hookServices, DeleteEntityOps{WithHooks: false}); err != nil {
 				return err
 			}
+			if err := recordUserAudit(ctx, tx, nil, sdk.EventEntityDeleted, e.ProjectKey, c.vcsName+"/"+c.repoName+"/"+e.Name, e, nil); err != nil {
+				return err
+			}
 			log.Info(ctx, "entity %s of type %s deleted on branch %s for commit %s", e.Name, e.Type, e.Ref, e.Commit)
 			deletedEntities = append(deletedEntities, e)
 		}
@@ -423,6 +429,12 @@ func DeleteEntity(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, e *sdk.E
 		}
 	}
 
+	if e.Type == sdk.EntityTypeWorkflow && e.Head {
+		if err := workflow_v2.DeleteTemplateInstance(tx, e.ProjectRepositoryID, e.Name, e.Ref); err != nil {
+			return err
+		}
+	}
+
 	if err := entity.Delete(ctx, tx, e); err != nil {
 		return err
 	}
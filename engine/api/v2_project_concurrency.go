@@ -56,6 +56,9 @@ func (api *API) postProjectConcurrencyHandler() ([]service.RbacChecker, service.
 			if err := project.InsertConcurrency(ctx, tx, &projConcu); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventConcurrencyCreated, key, projConcu.Name, nil, projConcu); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -84,7 +87,8 @@ func (api *API) putProjectConcurrencyHandler() ([]service.RbacChecker, service.H
 				return err
 			}
 
-			if _, err := project.LoadConcurrencyByIDAndProjectKey(ctx, api.mustDB(), key, projConcu.ID); err != nil {
+			oldConcu, err := project.LoadConcurrencyByIDAndProjectKey(ctx, api.mustDB(), key, projConcu.ID)
+			if err != nil {
 				return err
 			}
 
@@ -96,6 +100,9 @@ func (api *API) putProjectConcurrencyHandler() ([]service.RbacChecker, service.H
 			if err := project.UpdateConcurrency(ctx, tx, &projConcu); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventConcurrencyUpdated, key, projConcu.Name, oldConcu, projConcu); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -159,6 +166,9 @@ func (api *API) deleteProjectConcurrencyHandler() ([]service.RbacChecker, servic
 			if err := project.DeleteConcurrency(tx, key, concurrency.ID); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventConcurrencyDeleted, key, concurrency.Name, concurrency, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
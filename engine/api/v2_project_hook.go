@@ -105,7 +105,7 @@ func (api *API) postProjectHookHandler() ([]service.RbacChecker, service.Handler
 				if err != nil {
 					return err
 				}
-				vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcs.Name)
+				vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcs.Name, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return err
 				}
@@ -119,7 +119,7 @@ func (api *API) postProjectHookHandler() ([]service.RbacChecker, service.Handler
 				}
 			default:
 				// Check if project has read access to the target repository
-				vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcs.Name)
+				vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcs.Name, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return err
 				}
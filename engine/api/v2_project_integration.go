@@ -53,6 +53,9 @@ func (api *API) deleteProjectV2IntegrationHandler() ([]service.RbacChecker, serv
 				}
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventIntegrationDeleted, projectKey, deletedIntegration.Name, deletedIntegration, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -151,6 +154,9 @@ func (api *API) putProjectV2IntegrationHandler() ([]service.RbacChecker, service
 				}
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventIntegrationUpdated, projectKey, projectIntegration.Name, ppDB, projectIntegration); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -254,6 +260,9 @@ func (api *API) postProjectV2IntegrationHandler() ([]service.RbacChecker, servic
 				}
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventIntegrationCreated, projectKey, pp.Name, nil, pp); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
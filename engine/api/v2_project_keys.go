@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/event_v2"
 	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/vcs"
@@ -67,10 +68,15 @@ func (api *API) deleteKeyInProjectV2Handler() ([]service.RbacChecker, service.Ha
 				return sdk.WithStack(err)
 			}
 			defer tx.Rollback() // nolint
-			for _, k := range p.Keys {
-				if k.Name == keyName {
+			var deletedKey *sdk.ProjectKey
+			for i := range p.Keys {
+				if p.Keys[i].Name == keyName {
 					if err := project.DeleteProjectKey(tx, p.ID, keyName); err != nil {
-						return sdk.WrapError(err, "cannot delete key %s on project %s", k.Name, key)
+						return sdk.WrapError(err, "cannot delete key %s on project %s", keyName, key)
+					}
+					deletedKey = &p.Keys[i]
+					if err := recordAudit(ctx, tx, sdk.EventProjectKeyDeleted, p.Key, keyName, deletedKey, nil); err != nil {
+						return err
 					}
 					break
 				}
@@ -80,6 +86,10 @@ func (api *API) deleteKeyInProjectV2Handler() ([]service.RbacChecker, service.Ha
 				return sdk.WithStack(err)
 			}
 
+			if deletedKey != nil {
+				event_v2.PublishProjectKeyEvent(ctx, api.Cache, sdk.EventProjectKeyDeleted, p.Key, *deletedKey, *u.AuthConsumerUser.AuthentifiedUser)
+			}
+
 			return service.WriteJSON(w, nil, http.StatusOK)
 		}
 }
@@ -148,10 +158,15 @@ func (api *API) addKeyInProjectV2Handler() ([]service.RbacChecker, service.Handl
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventProjectKeyCreated, p.Key, newKey.Name, nil, newKey); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
+			event_v2.PublishProjectKeyEvent(ctx, api.Cache, sdk.EventProjectKeyCreated, p.Key, newKey, *u.AuthConsumerUser.AuthentifiedUser)
+
 			return service.WriteJSON(w, newKey, http.StatusOK)
 		}
 }
@@ -162,6 +177,7 @@ func (api *API) postDisableKeyInProjectV2Handler() ([]service.RbacChecker, servi
 			if !isAdmin(ctx) {
 				return sdk.ErrForbidden
 			}
+			u := getUserConsumer(ctx)
 
 			vars := mux.Vars(r)
 			key := vars["projectKey"]
@@ -186,6 +202,9 @@ func (api *API) postDisableKeyInProjectV2Handler() ([]service.RbacChecker, servi
 					if err := project.DisableProjectKey(tx, p.ID, keyName); err != nil {
 						return err
 					}
+					if err := recordAudit(ctx, tx, sdk.EventProjectKeyUpdated, p.Key, keyName, k, updateKey); err != nil {
+						return err
+					}
 					break
 				}
 			}
@@ -194,6 +213,10 @@ func (api *API) postDisableKeyInProjectV2Handler() ([]service.RbacChecker, servi
 				return err
 			}
 
+			if updateKey.Name != "" {
+				event_v2.PublishProjectKeyEvent(ctx, api.Cache, sdk.EventProjectKeyUpdated, p.Key, updateKey, *u.AuthConsumerUser.AuthentifiedUser)
+			}
+
 			return service.WriteJSON(w, nil, http.StatusOK)
 		}
 }
@@ -204,6 +227,7 @@ func (api *API) postEnableKeyInProjectV2Handler() ([]service.RbacChecker, servic
 			if !isAdmin(ctx) {
 				return sdk.ErrForbidden
 			}
+			u := getUserConsumer(ctx)
 
 			vars := mux.Vars(r)
 			key := vars["projectKey"]
@@ -228,6 +252,9 @@ func (api *API) postEnableKeyInProjectV2Handler() ([]service.RbacChecker, servic
 					if err := project.EnableProjectKey(tx, p.ID, keyName); err != nil {
 						return err
 					}
+					if err := recordAudit(ctx, tx, sdk.EventProjectKeyUpdated, p.Key, keyName, k, updateKey); err != nil {
+						return err
+					}
 					break
 				}
 			}
@@ -236,6 +263,10 @@ func (api *API) postEnableKeyInProjectV2Handler() ([]service.RbacChecker, servic
 				return sdk.WithStack(err)
 			}
 
+			if updateKey.Name != "" {
+				event_v2.PublishProjectKeyEvent(ctx, api.Cache, sdk.EventProjectKeyUpdated, p.Key, updateKey, *u.AuthConsumerUser.AuthentifiedUser)
+			}
+
 			return service.WriteJSON(w, nil, http.StatusOK)
 		}
 }
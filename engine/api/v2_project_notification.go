@@ -104,6 +104,9 @@ func (api *API) postProjectNotificationHandler() ([]service.RbacChecker, service
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventNotificationCreated, p.Key, n.Name, nil, n); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -161,6 +164,9 @@ func (api *API) putProjectNotificationHandler() ([]service.RbacChecker, service.
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventNotificationUpdated, pKey, n.Name, oldNotif, n); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -197,6 +203,9 @@ func (api *API) deleteProjectNotificationHandler() ([]service.RbacChecker, servi
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventNotificationDeleted, pKey, n.Name, n, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -195,6 +195,9 @@ func (api *API) deleteProjectRepositoryHandler() ([]service.RbacChecker, service
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventRepositoryDeleted, pKey, vcsProject.Name+"/"+repo.Name, repo, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -252,7 +255,7 @@ func (api *API) postProjectRepositoryHandler() ([]service.RbacChecker, service.H
 			}
 
 			// Check if repo exist
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, pKey, vcsProjectWithSecret.Name)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, pKey, vcsProjectWithSecret.Name, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -298,6 +301,9 @@ func (api *API) postProjectRepositoryHandler() ([]service.RbacChecker, service.H
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventRepositoryCreated, pKey, vcsProjectWithSecret.Name+"/"+repoDB.Name, nil, repoDB); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -382,7 +388,7 @@ func (api *API) getProjectRepositoryBranchesHandler() ([]service.RbacChecker, se
 				repoName = repositoryIdentifier
 			}
 
-			client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name)
+			client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -426,7 +432,7 @@ func (api *API) getProjectRepositoryTagsHandler() ([]service.RbacChecker, servic
 				repoName = repositoryIdentifier
 			}
 
-			client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name)
+			client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/event_v2"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -32,6 +33,11 @@ func (api *API) postProjectRunFilterHandler() ([]service.RbacChecker, service.Ha
 			vars := mux.Vars(r)
 			projectKey := vars["projectKey"]
 
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
 			var filter sdk.ProjectRunFilter
 			if err := service.UnmarshalBody(r, &filter); err != nil {
 				return err
@@ -66,10 +72,15 @@ func (api *API) postProjectRunFilterHandler() ([]service.RbacChecker, service.Ha
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventProjectRunFilterCreated, projectKey, filter.Name, nil, filter); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
+			event_v2.PublishProjectRunFilterEvent(ctx, api.Cache, sdk.EventProjectRunFilterCreated, projectKey, filter, *u.AuthConsumerUser.AuthentifiedUser)
+
 			return service.WriteJSON(w, filter, http.StatusCreated)
 		}
 }
@@ -81,6 +92,11 @@ func (api *API) putProjectRunFilterHandler() ([]service.RbacChecker, service.Han
 			projectKey := vars["projectKey"]
 			filterName := vars["filterName"]
 
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
 			var filterUpdate sdk.ProjectRunFilter
 			if err := service.UnmarshalBody(r, &filterUpdate); err != nil {
 				return err
@@ -103,16 +119,21 @@ func (api *API) putProjectRunFilterHandler() ([]service.RbacChecker, service.Han
 				return err
 			}
 
-			if err := tx.Commit(); err != nil {
-				return sdk.WithStack(err)
-			}
-
 			// Reload updated filter
-			updatedFilter, err := project.LoadRunFilterByNameAndProjectKey(ctx, api.mustDB(), projectKey, existingFilter.Name)
+			updatedFilter, err := project.LoadRunFilterByNameAndProjectKey(ctx, tx, projectKey, existingFilter.Name)
 			if err != nil {
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventProjectRunFilterUpdated, projectKey, updatedFilter.Name, existingFilter, updatedFilter); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			event_v2.PublishProjectRunFilterEvent(ctx, api.Cache, sdk.EventProjectRunFilterUpdated, projectKey, *updatedFilter, *u.AuthConsumerUser.AuthentifiedUser)
+
 			return service.WriteJSON(w, updatedFilter, http.StatusOK)
 		}
 }
@@ -124,6 +145,11 @@ func (api *API) deleteProjectRunFilterHandler() ([]service.RbacChecker, service.
 			projectKey := vars["projectKey"]
 			filterName := vars["filterName"]
 
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
 			// Check that filter exists
 			filter, err := project.LoadRunFilterByNameAndProjectKey(ctx, api.mustDB(), projectKey, filterName)
 			if err != nil {
@@ -145,10 +171,15 @@ func (api *API) deleteProjectRunFilterHandler() ([]service.RbacChecker, service.
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventProjectRunFilterDeleted, projectKey, filter.Name, filter, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
+			event_v2.PublishProjectRunFilterEvent(ctx, api.Cache, sdk.EventProjectRunFilterDeleted, projectKey, *filter, *u.AuthConsumerUser.AuthentifiedUser)
+
 			return service.WriteJSON(w, nil, http.StatusNoContent)
 		}
 }
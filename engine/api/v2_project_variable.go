@@ -107,6 +107,9 @@ func (api *API) postProjectVariableSetHandler() ([]service.RbacChecker, service.
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVariableSetCreated, p.Key, vs.Name, nil, vs); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -164,12 +167,18 @@ func (api *API) deleteProjectVariableSetHandler() ([]service.RbacChecker, servic
 							return err
 						}
 					}
+					if err := recordAudit(ctx, tx, sdk.EventVariableSetItemDeleted, pKey, vs.Name+"/"+it.Name, it, nil); err != nil {
+						return err
+					}
 				}
 			}
 
 			if err := project.DeleteVariableSet(ctx, tx, *vs); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventVariableSetDeleted, pKey, vs.Name, *vs, nil); err != nil {
+				return err
+			}
 
 			if err := tx.Commit(); err != nil {
 				return err
@@ -98,6 +98,9 @@ func (api *API) postProjectVariableSetItemHandler() ([]service.RbacChecker, serv
 				}
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVariableSetItemCreated, pKey, vs.Name+"/"+item.Name, nil, item); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -162,6 +165,9 @@ func (api *API) putProjectVariableSetItemHandler() ([]service.RbacChecker, servi
 				}
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVariableSetItemUpdated, pKey, vs.Name+"/"+item.Name, itemDB, item); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
@@ -214,6 +220,9 @@ func (api *API) deleteProjectVariableSetItemHandler() ([]service.RbacChecker, se
 				}
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVariableSetItemDeleted, pKey, vs.Name+"/"+item.Name, item, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
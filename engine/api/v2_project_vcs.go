@@ -35,6 +35,14 @@ func (api *API) getVCSByIdentifier(ctx context.Context, projectKey string, vcsId
 	return vcsProject, nil
 }
 
+// resetGitVCSSSHPrivateKey ensures that the private part of a project ssh key is never stored with the vcs auth.
+// It is resolved from the key name each time the vcs service is called.
+func resetGitVCSSSHPrivateKey(vcsProject *sdk.VCSProject) {
+	if vcsProject.Type == sdk.VCSTypeGit && vcsProject.Auth.SSHKeyName != "" {
+		vcsProject.Auth.SSHPrivateKey = ""
+	}
+}
+
 func (api *API) postVCSProjectHandler() ([]service.RbacChecker, service.Handler) {
 	return service.RBAC(api.projectManage),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
@@ -52,7 +60,7 @@ func (api *API) postVCSProjectHandler() ([]service.RbacChecker, service.Handler)
 			}
 			defer tx.Rollback() // nolint
 
-			project, err := project.Load(ctx, tx, pKey, project.LoadOptions.WithKeys)
+			proj, err := project.Load(ctx, tx, pKey, project.LoadOptions.WithKeys)
 			if err != nil {
 				return sdk.WithStack(err)
 			}
@@ -62,18 +70,19 @@ func (api *API) postVCSProjectHandler() ([]service.RbacChecker, service.Handler)
 				return err
 			}
 
-			if err := vcsProject.Lint(*project); err != nil {
+			if err := vcsProject.Lint(*proj); err != nil {
 				return err
 			}
+			resetGitVCSSSHPrivateKey(&vcsProject)
 
-			vcsProject.ProjectID = project.ID
+			vcsProject.ProjectID = proj.ID
 			vcsProject.CreatedBy = getUserConsumer(ctx).GetUsername()
 
 			if err := vcs.Insert(ctx, tx, &vcsProject); err != nil {
 				return err
 			}
 
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, pKey, vcsProject.Name)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, pKey, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -82,10 +91,14 @@ func (api *API) postVCSProjectHandler() ([]service.RbacChecker, service.Handler)
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVCSCreated, pKey, vcsProject.Name, nil, vcsProject); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
+			vcsProject.Auth.SSHPrivateKey = ""
 			event_v2.PublishVCSEvent(ctx, api.Cache, sdk.EventVCSCreated, pKey, vcsProject, *u.AuthConsumerUser.AuthentifiedUser)
 
 			return service.WriteMarshal(w, req, vcsProject, http.StatusCreated)
@@ -142,17 +155,22 @@ func (api *API) putVCSProjectHandler() ([]service.RbacChecker, service.Handler)
 			if err := vcsProject.Lint(*proj); err != nil {
 				return err
 			}
+			resetGitVCSSSHPrivateKey(&vcsProject)
 
 			if err := vcs.Update(ctx, tx, &vcsProject); err != nil {
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVCSUpdated, proj.Key, vcsProject.Name, vcsOld, vcsProject); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
 			// Reset the token to avoid leak
 			vcsProject.Auth.Token = ""
+			vcsProject.Auth.SSHPrivateKey = ""
 
 			event_v2.PublishVCSEvent(ctx, api.Cache, sdk.EventVCSUpdated, proj.Key, vcsProject, *u.AuthConsumerUser.AuthentifiedUser)
 
@@ -196,6 +214,9 @@ func (api *API) deleteVCSProjectHandler() ([]service.RbacChecker, service.Handle
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventVCSDeleted, project.Key, vcsProject.Name, vcsProject, nil); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
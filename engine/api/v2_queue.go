@@ -20,6 +20,7 @@ import (
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/region"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/engine/api/workflow_v2"
 	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/engine/service"
@@ -54,6 +55,7 @@ func (api *API) postJobRunStepHandler() ([]service.RbacChecker, service.Handler)
 			return sdk.WithStack(err)
 		}
 
+		previousStepsStatus := runjob.StepsStatus
 		runjob.StepsStatus = stepsStatus
 		if err := workflow_v2.UpdateJobRun(ctx, tx, runjob); err != nil {
 			return err
@@ -61,10 +63,37 @@ func (api *API) postJobRunStepHandler() ([]service.RbacChecker, service.Handler)
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
+
+		// Record the metrics of the steps that just ended
+		for stepName, ss := range stepsStatus {
+			if ss.Metrics == nil || previousStepsStatus[stepName].Metrics != nil {
+				continue
+			}
+			api.recordStepMetrics(*runjob, ss)
+		}
 		return nil
 	}
 }
 
+func (api *API) recordStepMetrics(runJob sdk.V2WorkflowRunJob, ss sdk.JobStepStatus) {
+	ctx := telemetry.ContextWithTag(api.Router.Background,
+		telemetry.TagRegion, runJob.Region,
+		telemetry.TagWorkerModel, runJob.Job.RunsOn.Model,
+		telemetry.TagWorkerFlavor, runJob.Job.RunsOn.Flavor,
+		telemetry.TagWorkerMemory, runJob.Job.RunsOn.Memory,
+		telemetry.TagStatus, ss.Outcome,
+	)
+	if !ss.Started.IsZero() && ss.Ended.After(ss.Started) {
+		telemetry.RecordFloat64(ctx, api.Metrics.V2StepDuration, ss.Ended.Sub(ss.Started).Seconds())
+	}
+	telemetry.RecordFloat64(ctx, api.Metrics.V2StepCPU, ss.Metrics.CPUSeconds)
+	telemetry.Record(ctx, api.Metrics.V2StepMemoryPeak, ss.Metrics.MemoryPeak)
+	telemetry.Record(ctx, api.Metrics.V2StepDiskRead, ss.Metrics.DiskRead)
+	telemetry.Record(ctx, api.Metrics.V2StepDiskWrite, ss.Metrics.DiskWrite)
+	telemetry.Record(ctx, api.Metrics.V2StepNetworkReceived, ss.Metrics.NetworkReceived)
+	telemetry.Record(ctx, api.Metrics.V2StepNetworkSent, ss.Metrics.NetworkSent)
+}
+
 func (api *API) postRunInfoHandler() ([]service.RbacChecker, service.Handler) {
 	return []service.RbacChecker{api.jobRunUpdate}, func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -389,10 +418,14 @@ func (api *API) postJobRunResultHandler() ([]service.RbacChecker, service.Handle
 			if runResult.Status == "" {
 				return sdk.WithStack(sdk.ErrWrongRequest)
 			}
+			if err := parseRunResultCoverage(&runResult, nil); err != nil {
+				return err
+			}
 
 			if err := workflow_v2.InsertRunResult(ctx, api.mustDB(), &runResult); err != nil {
 				return err
 			}
+			api.indexRunResultTestCasesAsync(ctx, *runJob, runResult)
 
 			api.GoRoutines.Exec(ctx, "postJobRunResultHandler-"+runResult.ID, func(ctx context.Context) {
 				run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
@@ -407,6 +440,61 @@ func (api *API) postJobRunResultHandler() ([]service.RbacChecker, service.Handle
 		}
 }
 
+// postJobRunAnnotationsHandler sends annotations produced by a job to the vcs server of the workflow run
+func (api *API) postJobRunAnnotationsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.jobRunUpdate),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			jobRunID := vars["runJobID"]
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), jobRunID)
+			if err != nil {
+				return err
+			}
+			service.TrackActionMetadataFromFields(w, runJob)
+
+			var annotations sdk.VCSAnnotations
+			if err := service.UnmarshalBody(req, &annotations); err != nil {
+				return err
+			}
+			if err := annotations.Lint(); err != nil {
+				return err
+			}
+
+			run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+			if err != nil {
+				return err
+			}
+			if run.Contexts.Git.Server == "" || run.Contexts.Git.Sha == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to send annotations: the workflow run has no git commit")
+			}
+
+			// Annotations of each job and producer are kept separated
+			annotations.Key = fmt.Sprintf("%s-%s/%s/%s", run.ProjectKey, run.WorkflowName, runJob.JobID, annotations.Key)
+			if annotations.Title == "" {
+				annotations.Title = annotations.Key
+			}
+			annotations.URL = fmt.Sprintf("%s/project/%s/run/%s", api.Config.URL.UI, run.ProjectKey, run.ID)
+
+			repo := run.Contexts.Git.RepositoryOrigin
+			if repo == "" {
+				repo = run.Contexts.Git.Repository
+			}
+
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, run.ProjectKey, run.Contexts.Git.Server, project.LoadKeyWithPrivateContentByProjectKey)
+			if err != nil {
+				return err
+			}
+			if err := vcsClient.CreateAnnotations(ctx, repo, run.Contexts.Git.Sha, annotations); err != nil {
+				if sdk.ErrorIs(err, sdk.ErrNotImplemented) {
+					return sdk.NewErrorFrom(sdk.ErrNotImplemented, "annotations are not supported by vcs server %s", run.Contexts.Git.Server)
+				}
+				return err
+			}
+			return service.WriteJSON(w, nil, http.StatusNoContent)
+		}
+}
+
 func (api *API) putJobRunResultSynchronizeHandler() ([]service.RbacChecker, service.Handler) {
 	return service.RBAC(api.jobRunUpdate, api.isWorker),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
@@ -457,10 +545,14 @@ func (api *API) putJobRunResultHandler() ([]service.RbacChecker, service.Handler
 			if runResult.Status == "" {
 				return sdk.WithStack(sdk.ErrWrongRequest)
 			}
+			if err := parseRunResultCoverage(&runResult, oldRunResult); err != nil {
+				return err
+			}
 
 			if err := workflow_v2.UpdateRunResult(ctx, api.mustDB(), &runResult); err != nil {
 				return err
 			}
+			api.indexRunResultTestCasesAsync(ctx, *runJob, runResult)
 
 			api.GoRoutines.Exec(ctx, "putJobRunResultHandler-"+runResult.ID, func(ctx context.Context) {
 				run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
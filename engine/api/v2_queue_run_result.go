@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getJobExternalRunResultsHandler returns the run results of the triggering run or of any run referenced by the job
+func (api *API) getJobExternalRunResultsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.jobRunRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			runJobID := vars["runJobID"]
+
+			ref, err := sdk.ParseV2WorkflowRunResultRef(FormString(req, "run"))
+			if err != nil {
+				return err
+			}
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), runJobID)
+			if err != nil {
+				return err
+			}
+			run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+			if err != nil {
+				return err
+			}
+
+			sourceRun, err := api.loadRunResultSourceRun(ctx, *run, ref)
+			if err != nil {
+				return err
+			}
+			if err := api.checkRunResultConsumption(ctx, *run, *sourceRun); err != nil {
+				return err
+			}
+
+			runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDB(), sourceRun.ID, sourceRun.RunAttempt)
+			if err != nil {
+				return err
+			}
+			runJobIds := make([]string, 0, len(runJobs))
+			for _, rj := range runJobs {
+				runJobIds = append(runJobIds, rj.ID)
+			}
+			allRunResults, err := workflow_v2.LoadRunResultsByRunIDAttempt(ctx, api.mustDB(), sourceRun.ID, runJobIds, sourceRun.RunAttempt)
+			if err != nil {
+				return err
+			}
+			runResults := make([]sdk.V2WorkflowRunResult, 0, len(allRunResults))
+			for _, r := range allRunResults {
+				if r.Status == sdk.V2WorkflowRunResultStatusPending || r.Status == sdk.V2WorkflowRunResultStatusCancelled {
+					continue
+				}
+				runResults = append(runResults, r)
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			info := sdk.V2WorkflowRunInfo{
+				WorkflowRunID: run.ID,
+				IssuedAt:      time.Now(),
+				Level:         sdk.WorkflowRunInfoLevelInfo,
+				Message: fmt.Sprintf("Job %s consumed %d run results from %s/%s/%s/%s #%d.%d", runJob.JobID, len(runResults),
+					sourceRun.ProjectKey, sourceRun.VCSServer, sourceRun.Repository, sourceRun.WorkflowName, sourceRun.RunNumber, sourceRun.RunAttempt),
+			}
+			if err := workflow_v2.InsertRunInfo(ctx, tx, &info); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			return service.WriteJSON(w, runResults, http.StatusOK)
+		}
+}
+
+// getJobExternalRunResultAccessHandler is used by CDN to check that a job can download the run results of another run
+func (api *API) getJobExternalRunResultAccessHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.jobRunRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), vars["runJobID"])
+			if err != nil {
+				return err
+			}
+			run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+			if err != nil {
+				return err
+			}
+			sourceRun, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), vars["workflowRunID"])
+			if err != nil {
+				return err
+			}
+			if err := api.checkRunResultConsumption(ctx, *run, *sourceRun); err != nil {
+				return err
+			}
+			return service.WriteJSON(w, nil, http.StatusOK)
+		}
+}
+
+// loadRunResultSourceRun loads the workflow run referenced by a job that wants to consume its run results
+func (api *API) loadRunResultSourceRun(ctx context.Context, run sdk.V2WorkflowRun, ref sdk.V2WorkflowRunResultRef) (*sdk.V2WorkflowRun, error) {
+	if ref.Trigger {
+		if run.RunEvent.WorkflowRunID == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow run %s #%d was not triggered by another workflow run", run.WorkflowName, run.RunNumber)
+		}
+		sourceRun, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), run.RunEvent.WorkflowRunID)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(err, "unable to load triggering workflow run")
+		}
+		return sourceRun, nil
+	}
+
+	fullName := computeWorkflowRunHookFullName(run.ProjectKey, run.VCSServer, run.Repository, ref.Workflow)
+	mSplit := strings.Split(fullName, "/")
+	if len(mSplit) != 5 {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid workflow reference %q", ref.Workflow)
+	}
+	sourceRun, err := workflow_v2.LoadRunByWorkflowName(ctx, api.mustDB(), mSplit[0], mSplit[1], mSplit[2]+"/"+mSplit[3], mSplit[4], ref.RunNumber)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			if ref.RunNumber == 0 {
+				return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find a successful run of workflow %s", fullName)
+			}
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find run %d of workflow %s", ref.RunNumber, fullName)
+		}
+		return nil, err
+	}
+	return sourceRun, nil
+}
+
+// checkRunResultConsumption checks that the initiator of a workflow run is allowed to consume the run results of the source run.
+// Inside a project, any run can consume the run results of another run. Otherwise, the initiator must have the role publish-run-result on the source project.
+func (api *API) checkRunResultConsumption(ctx context.Context, run sdk.V2WorkflowRun, sourceRun sdk.V2WorkflowRun) error {
+	if run.ProjectKey == sourceRun.ProjectKey {
+		return nil
+	}
+	if run.Initiator == nil {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "unable to identify the initiator of workflow run %s #%d", run.WorkflowName, run.RunNumber)
+	}
+
+	var hasRole bool
+	var err error
+	if run.Initiator.IsUser() {
+		hasRole, err = rbac.HasRoleOnProjectAndUserID(ctx, api.mustDB(), sdk.ProjectRolePublishRunResult, run.Initiator.UserID, sourceRun.ProjectKey)
+	} else {
+		hasRole, err = rbac.HasRoleOnProjectAndVCSUser(ctx, api.mustDB(), sdk.ProjectRolePublishRunResult, sdk.RBACVCSUser{VCSServer: run.Initiator.VCS, VCSUsername: run.Initiator.VCSUsername}, sourceRun.ProjectKey)
+	}
+	if err != nil {
+		return err
+	}
+	if !hasRole {
+		log.Info(ctx, "%s is not allowed to consume run results of project %s", run.Initiator.Username(), sourceRun.ProjectKey)
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "%s is missing the role %s on project %s", run.Initiator.Username(), sdk.ProjectRolePublishRunResult, sourceRun.ProjectKey)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCheckRunResultConsumption(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+	ctx := context.TODO()
+
+	db.Exec("DELETE FROM rbac")
+
+	platformProj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	appProj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	u, _ := assets.InsertLambdaUser(t, db)
+
+	sourceRun := sdk.V2WorkflowRun{ProjectKey: platformProj.Key, WorkflowName: "base-image", RunNumber: 1}
+	run := sdk.V2WorkflowRun{
+		ProjectKey:   appProj.Key,
+		WorkflowName: "app",
+		RunNumber:    1,
+		Initiator: &sdk.V2Initiator{
+			UserID: u.ID,
+			User:   u.Initiator(),
+		},
+	}
+
+	// Same project
+	require.NoError(t, api.checkRunResultConsumption(ctx, sourceRun, sourceRun))
+
+	// Missing role on the source project
+	err := api.checkRunResultConsumption(ctx, run, sourceRun)
+	require.Error(t, err)
+	require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+
+	assets.InsertRBAcProject(t, db, sdk.ProjectRolePublishRunResult, platformProj.Key, *u)
+	require.NoError(t, api.checkRunResultConsumption(ctx, run, sourceRun))
+
+	// The role is not reciprocal
+	require.Error(t, api.checkRunResultConsumption(ctx, sdk.V2WorkflowRun{ProjectKey: platformProj.Key, Initiator: run.Initiator}, sdk.V2WorkflowRun{ProjectKey: appProj.Key}))
+}
@@ -92,7 +92,7 @@ func (api *API) deleteRBACHandler() ([]service.RbacChecker, service.Handler) {
 				return sdk.WithStack(sdk.ErrForbidden)
 			}
 
-			perm, err := api.getRBACByIdentifier(ctx, rbacIdentifier)
+			perm, err := api.getRBACByIdentifier(ctx, rbacIdentifier, rbac.LoadOptions.All)
 			if err != nil {
 				return err
 			}
@@ -107,6 +107,10 @@ func (api *API) deleteRBACHandler() ([]service.RbacChecker, service.Handler) {
 				return err
 			}
 
+			if err := recordAudit(ctx, tx, sdk.EventPermissionDeleted, "", perm.Name, perm, nil); err != nil {
+				return err
+			}
+
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -130,7 +134,7 @@ func (api *API) postImportRBACHandler() ([]service.RbacChecker, service.Handler)
 				return err
 			}
 
-			existingRule, err := rbac.LoadRBACByName(ctx, api.mustDB(), rbacRule.Name)
+			existingRule, err := rbac.LoadRBACByName(ctx, api.mustDB(), rbacRule.Name, rbac.LoadOptions.All)
 			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
 				return err
 			}
@@ -159,15 +163,18 @@ func (api *API) postImportRBACHandler() ([]service.RbacChecker, service.Handler)
 				return err
 			}
 
+			eventType := sdk.EventPermissionCreated
+			if existingRule != nil {
+				eventType = sdk.EventPermissionUpdated
+			}
+			if err := recordAudit(ctx, tx, eventType, "", rbacRule.Name, existingRule, rbacRule); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
 
-			if existingRule == nil {
-				event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionCreated, rbacRule, *u.AuthConsumerUser.AuthentifiedUser)
-			} else {
-				event_v2.PublishPermissionEvent(ctx, api.Cache, sdk.EventPermissionUpdated, rbacRule, *u.AuthConsumerUser.AuthentifiedUser)
-			}
+			event_v2.PublishPermissionEvent(ctx, api.Cache, eventType, rbacRule, *u.AuthConsumerUser.AuthentifiedUser)
 			return service.WriteMarshal(w, req, nil, http.StatusCreated)
 		}
 }
@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/ovh/cds/engine/api/authentication"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/user"
@@ -56,3 +59,75 @@ func (api *API) getCheckSessionProjectAccessHandler() ([]service.RbacChecker, se
 			return nil
 		}
 }
+
+// postWorkflowRBACCheckHandler explains which workflow permissions grant or deny a workflow role to a user
+func (api *API) postWorkflowRBACCheckHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			projectKey := vars["projectKey"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var checkRequest sdk.RBACWorkflowCheckRequest
+			if err := service.UnmarshalRequest(ctx, req, &checkRequest); err != nil {
+				return sdk.WithStack(err)
+			}
+			if !sdk.IsInArray(checkRequest.Role, sdk.WorkflowRoles) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown workflow role %q", checkRequest.Role)
+			}
+
+			// Explaining the permissions of another user requires to manage the project
+			target := u.AuthConsumerUser.AuthentifiedUser
+			if checkRequest.Username != "" && checkRequest.Username != target.Username {
+				if err := api.projectManage(ctx, vars); err != nil && !isAdmin(ctx) {
+					return err
+				}
+				var err error
+				target, err = user.LoadByUsername(ctx, api.mustDB(), checkRequest.Username)
+				if err != nil {
+					return err
+				}
+			}
+
+			check, err := rbac.CheckRoleOnWorkflow(ctx, api.mustDB(), checkRequest.Role, target.ID, nil, projectKey, checkRequest.VCSServer, checkRequest.Repository, checkRequest.Workflow, checkRequest.Ref)
+			if err != nil {
+				return err
+			}
+
+			// Roles that were granted before fine-grained workflow roles existed
+			switch checkRequest.Role {
+			case sdk.WorkflowRoleStop, sdk.WorkflowRoleRestart:
+				triggerCheck, err := rbac.CheckRoleOnWorkflow(ctx, api.mustDB(), sdk.WorkflowRoleTrigger, target.ID, nil, projectKey, checkRequest.VCSServer, checkRequest.Repository, checkRequest.Workflow, checkRequest.Ref)
+				if err != nil {
+					return err
+				}
+				check.Rules = append(check.Rules, triggerCheck.Rules...)
+				check.Granted = check.Granted || triggerCheck.Granted
+			case sdk.WorkflowRoleReadLogs, sdk.WorkflowRoleDeleteRun:
+				projectRole := sdk.ProjectRoleRead
+				if checkRequest.Role == sdk.WorkflowRoleDeleteRun {
+					projectRole = sdk.ProjectRoleManage
+				}
+				hasRole, err := rbac.CheckRoleOnProjectAndUserID(ctx, api.mustDB(), projectRole, target.ID, projectKey)
+				if err != nil {
+					return err
+				}
+				ruleCheck := sdk.RBACWorkflowRuleCheck{
+					Role:    "project:" + projectRole,
+					Granted: hasRole,
+					Reason:  fmt.Sprintf("role %s on project %s is not given to the user or its groups", projectRole, projectKey),
+				}
+				if hasRole {
+					ruleCheck.Reason = fmt.Sprintf("granted by role %s on project %s", projectRole, projectKey)
+				}
+				check.Rules = append(check.Rules, ruleCheck)
+				check.Granted = check.Granted || hasRole
+			}
+
+			return service.WriteJSON(w, check, http.StatusOK)
+		}
+}
@@ -0,0 +1,331 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/rbac"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) loadRBACGrant(ctx context.Context, grantID string) (*sdk.RBACGrant, error) {
+	if !sdk.IsValidUUID(grantID) {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "invalid grant identifier %q", grantID)
+	}
+	return rbac.LoadGrantByID(ctx, api.mustDBWithCtx(ctx), grantID)
+}
+
+func (api *API) rbacGrantMaxDuration() time.Duration {
+	d, _ := time.ParseDuration(api.Config.Auth.TemporaryGrant.MaxDuration)
+	return d
+}
+
+// postRBACGrantHandler creates a temporary grant request for the current user
+func (api *API) postRBACGrantHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantRequest),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			var grantRequest sdk.RBACGrantRequest
+			if err := service.UnmarshalRequest(ctx, req, &grantRequest); err != nil {
+				return err
+			}
+			if err := grantRequest.IsValid(api.rbacGrantMaxDuration()); err != nil {
+				return err
+			}
+			exist, err := project.Exist(api.mustDB(), grantRequest.ProjectKey)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "project %s not found", grantRequest.ProjectKey)
+			}
+
+			grant := sdk.RBACGrant{
+				UserID:        u.AuthConsumerUser.AuthentifiedUser.ID,
+				Username:      u.AuthConsumerUser.AuthentifiedUser.Username,
+				Scope:         grantRequest.Scope,
+				ProjectKey:    grantRequest.ProjectKey,
+				Target:        grantRequest.Target,
+				Role:          grantRequest.Role,
+				Justification: grantRequest.Justification,
+				Duration:      grantRequest.Duration,
+				Status:        sdk.RBACGrantStatusRequested,
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+
+			if err := rbac.InsertGrant(ctx, tx, &grant); err != nil {
+				return err
+			}
+			if err := rbac.InsertGrantAudit(tx, &grant, sdk.AuditRBACGrantRequest, grant.Username,
+				fmt.Sprintf("%s requested for %s: %s", grant.String(), grant.Duration, grant.Justification)); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventPermissionGrantRequested, "", grant.String(), nil, grant); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+
+			event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantRequested, grant, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, grant, http.StatusOK)
+		}
+}
+
+// getRBACGrantsHandler lists all the temporary grants for approvers, and the grants of the current user for others
+func (api *API) getRBACGrantsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantRequest),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+			status := req.URL.Query()["status"]
+
+			isApprover, err := api.isRBACGrantApprover(ctx)
+			if err != nil {
+				return err
+			}
+
+			var grants []sdk.RBACGrant
+			if isApprover || isAdmin(ctx) {
+				grants, err = rbac.LoadGrantsByStatus(ctx, api.mustDB(), status...)
+				if err != nil {
+					return err
+				}
+			} else {
+				userGrants, err := rbac.LoadGrantsByUserID(ctx, api.mustDB(), u.AuthConsumerUser.AuthentifiedUser.ID)
+				if err != nil {
+					return err
+				}
+				grants = make([]sdk.RBACGrant, 0, len(userGrants))
+				for _, g := range userGrants {
+					if len(status) == 0 || sdk.IsInArray(g.Status, status) {
+						grants = append(grants, g)
+					}
+				}
+			}
+			return service.WriteJSON(w, grants, http.StatusOK)
+		}
+}
+
+func (api *API) getRBACGrantHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			grant, err := api.loadRBACGrant(ctx, mux.Vars(req)["grantID"])
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, grant, http.StatusOK)
+		}
+}
+
+// getRBACGrantAuditsHandler returns the request, reviews and uses of a temporary grant
+func (api *API) getRBACGrantAuditsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			grant, err := api.loadRBACGrant(ctx, mux.Vars(req)["grantID"])
+			if err != nil {
+				return err
+			}
+			audits, err := rbac.LoadGrantAudits(ctx, api.mustDB(), grant.ID)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, audits, http.StatusOK)
+		}
+}
+
+func (api *API) postRBACGrantApproveHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantApprove),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			return api.reviewRBACGrant(ctx, w, req, sdk.RBACGrantStatusApproved)
+		}
+}
+
+func (api *API) postRBACGrantRejectHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantApprove),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			return api.reviewRBACGrant(ctx, w, req, sdk.RBACGrantStatusRejected)
+		}
+}
+
+// postRBACGrantRevokeHandler ends a grant before its expiration. The requester can revoke its own grant.
+func (api *API) postRBACGrantRevokeHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.rbacGrantRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			return api.reviewRBACGrant(ctx, w, req, sdk.RBACGrantStatusRevoked)
+		}
+}
+
+func (api *API) reviewRBACGrant(ctx context.Context, w http.ResponseWriter, req *http.Request, status string) error {
+	u := getUserConsumer(ctx)
+	if u == nil {
+		return sdk.WithStack(sdk.ErrForbidden)
+	}
+	reviewer := u.AuthConsumerUser.AuthentifiedUser
+
+	var review sdk.RBACGrantReview
+	if err := service.UnmarshalRequest(ctx, req, &review); err != nil {
+		return err
+	}
+
+	grant, err := api.loadRBACGrant(ctx, mux.Vars(req)["grantID"])
+	if err != nil {
+		return err
+	}
+	before := *grant
+
+	var eventType sdk.EventType
+	var auditType string
+	now := time.Now()
+	switch status {
+	case sdk.RBACGrantStatusApproved, sdk.RBACGrantStatusRejected:
+		if grant.Status != sdk.RBACGrantStatusRequested {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "grant %s is %s", grant.ID, grant.Status)
+		}
+		if grant.UserID == reviewer.ID {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "you can't review your own grant request")
+		}
+		eventType, auditType = sdk.EventPermissionGrantRejected, sdk.AuditRBACGrantReject
+		if status == sdk.RBACGrantStatusApproved {
+			d, err := time.ParseDuration(grant.Duration)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid duration %q: %v", grant.Duration, err)
+			}
+			expires := now.Add(d)
+			grant.Expires = &expires
+			eventType, auditType = sdk.EventPermissionGrantApproved, sdk.AuditRBACGrantApprove
+		}
+		grant.ReviewedBy = reviewer.Username
+		grant.ReviewComment = review.Comment
+		grant.Reviewed = &now
+	case sdk.RBACGrantStatusRevoked:
+		if grant.Status != sdk.RBACGrantStatusRequested && grant.Status != sdk.RBACGrantStatusApproved {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "grant %s is %s", grant.ID, grant.Status)
+		}
+		if grant.Expires != nil && grant.Expires.After(now) {
+			grant.Expires = &now
+		}
+		eventType, auditType = sdk.EventPermissionGrantRevoked, sdk.AuditRBACGrantRevoke
+	default:
+		return sdk.WithStack(sdk.ErrWrongRequest)
+	}
+	grant.Status = status
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	if err := rbac.UpdateGrant(ctx, tx, grant); err != nil {
+		return err
+	}
+	details := fmt.Sprintf("%s %s by %s", grant.String(), status, reviewer.Username)
+	if review.Comment != "" {
+		details += ": " + review.Comment
+	}
+	if err := rbac.InsertGrantAudit(tx, grant, auditType, reviewer.Username, details); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, eventType, "", grant.String(), before, grant); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	event_v2.PublishPermissionGrantEvent(ctx, api.Cache, eventType, *grant, *reviewer)
+	return service.WriteJSON(w, grant, http.StatusOK)
+}
+
+// expireRBACGrants marks the approved grants whose expiration date is passed as expired.
+// Permission checks ignore those grants anyway, this routine keeps the status and the audit up to date.
+func (api *API) expireRBACGrants(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting expireRBACGrants: %v", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+			if err := api.expireRBACGrantsPass(ctx); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+		}
+	}
+}
+
+// expireRBACGrantsPass expires the grants under a lock, so only one API instance expires and audits a grant
+func (api *API) expireRBACGrantsPass(ctx context.Context) error {
+	lockKey := cache.Key("api", "rbac", "grant", "expire")
+	b, err := api.Cache.Lock(lockKey, 1*time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !b {
+		return nil
+	}
+	defer func() {
+		_ = api.Cache.Unlock(lockKey)
+	}()
+
+	grants, err := rbac.LoadExpiredGrants(ctx, api.mustDB())
+	if err != nil {
+		return err
+	}
+	for i := range grants {
+		if err := api.expireRBACGrant(ctx, &grants[i]); err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+		}
+	}
+	return nil
+}
+
+func (api *API) expireRBACGrant(ctx context.Context, grant *sdk.RBACGrant) error {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	before := *grant
+	grant.Status = sdk.RBACGrantStatusExpired
+	if err := rbac.UpdateGrant(ctx, tx, grant); err != nil {
+		return err
+	}
+	if err := rbac.InsertGrantAudit(tx, grant, sdk.AuditRBACGrantExpire, "cds", fmt.Sprintf("%s expired", grant.String())); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, tx, sdk.EventPermissionGrantExpired, "", grant.String(), before, grant); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+	event_v2.PublishPermissionGrantEvent(ctx, api.Cache, sdk.EventPermissionGrantExpired, *grant, sdk.AuthentifiedUser{})
+	return nil
+}
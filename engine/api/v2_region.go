@@ -49,6 +49,9 @@ func (api *API) postRegionHandler() ([]service.RbacChecker, service.Handler) {
 			if err := region.Insert(ctx, tx, &reg); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventRegionCreated, "", reg.Name, nil, reg); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return sdk.WithStack(err)
 			}
@@ -134,6 +137,7 @@ func (api *API) deleteRegionHandler() ([]service.RbacChecker, service.Handler) {
 			updatedPermission := make([]sdk.RBAC, 0)
 
 			for _, rbacPerm := range rbacRegions {
+				before := rbacPerm
 				rbacPermRegions := make([]sdk.RBACRegion, 0)
 				for _, r := range rbacPerm.Regions {
 					if r.RegionID != reg.ID {
@@ -154,11 +158,17 @@ func (api *API) deleteRegionHandler() ([]service.RbacChecker, service.Handler) {
 					if err := rbac.Delete(ctx, tx, rbacPerm); err != nil {
 						return err
 					}
+					if err := recordAudit(ctx, tx, sdk.EventPermissionDeleted, "", rbacPerm.Name, before, nil); err != nil {
+						return err
+					}
 					deletedPermission = append(deletedPermission, rbacPerm)
 				} else {
 					if err := rbac.Update(ctx, tx, &rbacPerm); err != nil {
 						return err
 					}
+					if err := recordAudit(ctx, tx, sdk.EventPermissionUpdated, "", rbacPerm.Name, before, rbacPerm); err != nil {
+						return err
+					}
 					updatedPermission = append(updatedPermission, rbacPerm)
 				}
 			}
@@ -166,6 +176,9 @@ func (api *API) deleteRegionHandler() ([]service.RbacChecker, service.Handler) {
 			if err := region.Delete(tx, reg.ID); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventRegionDeleted, "", reg.Name, reg, nil); err != nil {
+				return err
+			}
 
 			if err := tx.Commit(); err != nil {
 				sdk.WithStack(tx.Commit())
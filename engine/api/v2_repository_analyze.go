@@ -23,6 +23,8 @@ import (
 	"github.com/rockbears/yaml"
 	"go.opencensus.io/trace"
 
+	"github.com/ovh/cds/engine/api/audit_v2"
+	"github.com/ovh/cds/engine/api/concurrency"
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/entity"
 	"github.com/ovh/cds/engine/api/event_v2"
@@ -30,6 +32,7 @@ import (
 	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/link"
 	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/engine/api/organization"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/rbac"
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
@@ -238,7 +241,7 @@ func (api *API) postRepositoryAnalysisHandler() ([]service.RbacChecker, service.
 
 			if analysis.Commit == "" || analysis.Ref == "" {
 				// retrieve commit for the given ref
-				client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcs.Name)
+				client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcs.Name, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return err
 				}
@@ -473,7 +476,7 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 		// Check Commit Signature
 		var keyID, analysisError string
 		switch vcsProjectWithSecret.Type {
-		case sdk.VCSTypeBitbucketCloud, sdk.VCSTypeGitlab:
+		case sdk.VCSTypeBitbucketCloud, sdk.VCSTypeGitlab, sdk.VCSTypeAzureDevOps, sdk.VCSTypeGit:
 			keyID, analysisError, err = api.analyzeCommitSignatureThroughOperation(ctx, analysis, *vcsProjectWithSecret, *repo)
 			if err != nil {
 				return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(err, "unable to check the commit signature"))
@@ -543,7 +546,7 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 	case sdk.VCSTypeBitbucketServer, sdk.VCSTypeBitbucketCloud:
 		// get archive
 		filesContent, err = api.getCdsArchiveFileOnRepo(ctx, *repo, analysis, vcsProjectWithSecret.Name)
-	case sdk.VCSTypeGitlab, sdk.VCSTypeGithub, sdk.VCSTypeGitea, sdk.VCSTypeForgejo:
+	case sdk.VCSTypeGitlab, sdk.VCSTypeGithub, sdk.VCSTypeGitea, sdk.VCSTypeForgejo, sdk.VCSTypeAzureDevOps, sdk.VCSTypeGit:
 		analysis.Data.Entities = make([]sdk.ProjectRepositoryDataEntity, 0)
 		filesContent, err = api.getCdsFilesOnVCSDirectory(ctx, analysis, vcsProjectWithSecret.Name, repo.Name, analysis.Commit, ".cds")
 	case sdk.VCSTypeGerrit:
@@ -582,6 +585,11 @@ func (api *API) analyzeRepository(ctx context.Context, projectRepoID string, ana
 		return api.stopAnalysis(ctx, analysis, multiErr...)
 	}
 
+	// Check project and organization policies
+	if multiErr := api.checkEntitiesPolicies(ctx, analysis, ef, entities); len(multiErr) > 0 {
+		return api.stopAnalysis(ctx, analysis, multiErr...)
+	}
+
 	userRoles := make(map[string]bool)
 	skippedFiles := make(sdk.StringSlice, 0)
 
@@ -636,7 +644,7 @@ skipEntity:
 	}
 
 	// Insert / Update entities
-	vcsAuthClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcsProjectWithSecret.Name)
+	vcsAuthClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcsProjectWithSecret.Name, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		log.ErrorWithStackTrace(ctx, err)
 		return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(sdk.ErrNotFound, "unable to retrieve vcs_server %s on project %s", vcsProjectWithSecret.Name, analysis.ProjectKey))
@@ -767,6 +775,9 @@ skipEntity:
 		if err := entity.Insert(ctx, tx, &e.Entity); err != nil {
 			return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(err, "unable to save %s of type %s for ref %s and commit %s", e.Name, e.Type, e.Ref, e.Commit))
 		}
+		if err := recordEntityAudit(ctx, tx, sdk.EventEntityCreated, vcsProjectWithSecret.Name, repo.Name, nil, &e.Entity, analysis.Data.Initiator); err != nil {
+			return api.stopAnalysis(ctx, analysis, err)
+		}
 		eventInsertedEntities = append(eventInsertedEntities, e.Entity)
 
 		// If it's an update, add it to the list of entity updated (for hooks)
@@ -781,6 +792,12 @@ skipEntity:
 				return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(err, "unable to create workflow hooks for %s", e.Name))
 			}
 			newHooks = append(newHooks, hooks...)
+
+			if e.Entity.Head {
+				if err := indexWorkflowTemplateInstance(ctx, tx, api.Cache, ef, *e, vcsProjectWithSecret.Name, repo.Name); err != nil {
+					return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(err, "unable to index workflow template instance for %s", e.Name))
+				}
+			}
 		}
 	}
 
@@ -826,6 +843,9 @@ skipEntity:
 				if err := DeleteEntity(ctx, tx, &e, srvs, delOpts); err != nil {
 					return api.stopAnalysis(ctx, analysis, sdk.NewErrorFrom(err, "unable to delete entity %s [%s] ", e.Name, e.Type))
 				}
+				if err := recordEntityAudit(ctx, tx, sdk.EventEntityDeleted, vcsProjectWithSecret.Name, repo.Name, &e, nil, analysis.Data.Initiator); err != nil {
+					return api.stopAnalysis(ctx, analysis, err)
+				}
 				eventRemovedEntities = append(eventRemovedEntities, e)
 			}
 		}
@@ -861,6 +881,20 @@ skipEntity:
 	for _, e := range eventInsertedEntities {
 		event_v2.PublishEntityEvent(ctx, api.Cache, sdk.EventEntityCreated, repo.Name, repo.Name, e, analysis.Data.Initiator)
 	}
+
+	// Open pull requests on workflows using a previous version of the new workflow templates
+	for _, e := range eventInsertedEntities {
+		if e.Type != sdk.EntityTypeWorkflowTemplate || !e.Head || !strings.HasPrefix(e.Ref, sdk.GitRefTagPrefix) {
+			continue
+		}
+		templateEntity := e
+		templateName := fmt.Sprintf("%s/%s/%s/%s", e.ProjectKey, vcsProjectWithSecret.Name, repo.Name, e.Name)
+		api.GoRoutines.Exec(context.Background(), "upgradeWorkflowTemplateInstances-"+templateName, func(ctx context.Context) {
+			if err := api.upgradeWorkflowTemplateInstances(ctx, templateName, templateEntity); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+		})
+	}
 	for _, eUpdated := range eventUpdatedEntities {
 		event_v2.PublishEntityEvent(ctx, api.Cache, sdk.EventEntityUpdated, vcsProjectWithSecret.Name, repo.Name, eUpdated, analysis.Data.Initiator)
 	}
@@ -1025,6 +1059,124 @@ func prepareWorkflowHooks(ctx context.Context, db gorpmapper.SqlExecutorWithTx,
 		}
 	}
 
+	// Prepare merge queue hook
+	// 1. workflow + code on same repo: create hook
+	// 2. workflow distant: create only on default branch
+	if e.Workflow.On != nil && e.Workflow.On.MergeQueue != nil {
+		if workflowSameRepo || e.Ref == defaultBranch.ID {
+			wh := sdk.V2WorkflowHook{
+				EntityID:       e.ID,
+				ProjectKey:     e.ProjectKey,
+				Type:           sdk.WorkflowHookTypeRepository,
+				Ref:            e.Ref,
+				Commit:         e.Commit,
+				WorkflowName:   e.Name,
+				VCSName:        workflowDefVCSName,
+				RepositoryName: workflowDefRepositoryName,
+				Data: sdk.V2WorkflowHookData{
+					RepositoryEvent:     sdk.WorkflowHookEventNameMergeQueue,
+					VCSServer:           targetVCS,
+					RepositoryName:      targetRepository,
+					BranchFilter:        e.Workflow.On.MergeQueue.Branches,
+					MergeQueueBatchSize: e.Workflow.On.MergeQueue.BatchSize,
+				},
+				Head: e.Head,
+			}
+			if e.Workflow.Repository != nil {
+				wh.Data.InsecureSkipSignatureVerify = e.Workflow.Repository.InsecureSkipSignatureVerify
+			}
+			whs = append(whs, wh)
+		}
+	}
+
+	// Prepare release hook
+	// 1. workflow + code on same repo: create hook
+	// 2. workflow distant: create only on default branch
+	if e.Workflow.On != nil && e.Workflow.On.Release != nil {
+		if workflowSameRepo || e.Ref == defaultBranch.ID {
+			wh := sdk.V2WorkflowHook{
+				EntityID:       e.ID,
+				ProjectKey:     e.ProjectKey,
+				Type:           sdk.WorkflowHookTypeRepository,
+				Ref:            e.Ref,
+				Commit:         e.Commit,
+				WorkflowName:   e.Name,
+				VCSName:        workflowDefVCSName,
+				RepositoryName: workflowDefRepositoryName,
+				Data: sdk.V2WorkflowHookData{
+					RepositoryEvent: sdk.WorkflowHookEventNameRelease,
+					VCSServer:       targetVCS,
+					RepositoryName:  targetRepository,
+					TagFilter:       e.Workflow.On.Release.Tags,
+					TypesFilter:     e.Workflow.On.Release.Types,
+				},
+				Head: e.Head,
+			}
+			if e.Workflow.Repository != nil {
+				wh.Data.InsecureSkipSignatureVerify = e.Workflow.Repository.InsecureSkipSignatureVerify
+			}
+			whs = append(whs, wh)
+		}
+	}
+
+	// Prepare branch-create hook
+	// 1. workflow + code on same repo: create hook
+	// 2. workflow distant: create only on default branch
+	if e.Workflow.On != nil && e.Workflow.On.BranchCreate != nil {
+		if workflowSameRepo || e.Ref == defaultBranch.ID {
+			wh := sdk.V2WorkflowHook{
+				EntityID:       e.ID,
+				ProjectKey:     e.ProjectKey,
+				Type:           sdk.WorkflowHookTypeRepository,
+				Ref:            e.Ref,
+				Commit:         e.Commit,
+				WorkflowName:   e.Name,
+				VCSName:        workflowDefVCSName,
+				RepositoryName: workflowDefRepositoryName,
+				Data: sdk.V2WorkflowHookData{
+					RepositoryEvent: sdk.WorkflowHookEventNameBranchCreate,
+					VCSServer:       targetVCS,
+					RepositoryName:  targetRepository,
+					BranchFilter:    e.Workflow.On.BranchCreate.Branches,
+				},
+				Head: e.Head,
+			}
+			if e.Workflow.Repository != nil {
+				wh.Data.InsecureSkipSignatureVerify = e.Workflow.Repository.InsecureSkipSignatureVerify
+			}
+			whs = append(whs, wh)
+		}
+	}
+
+	// Prepare branch-delete hook
+	// 1. workflow + code on same repo: create hook
+	// 2. workflow distant: create only on default branch
+	if e.Workflow.On != nil && e.Workflow.On.BranchDelete != nil {
+		if workflowSameRepo || e.Ref == defaultBranch.ID {
+			wh := sdk.V2WorkflowHook{
+				EntityID:       e.ID,
+				ProjectKey:     e.ProjectKey,
+				Type:           sdk.WorkflowHookTypeRepository,
+				Ref:            e.Ref,
+				Commit:         e.Commit,
+				WorkflowName:   e.Name,
+				VCSName:        workflowDefVCSName,
+				RepositoryName: workflowDefRepositoryName,
+				Data: sdk.V2WorkflowHookData{
+					RepositoryEvent: sdk.WorkflowHookEventNameBranchDelete,
+					VCSServer:       targetVCS,
+					RepositoryName:  targetRepository,
+					BranchFilter:    e.Workflow.On.BranchDelete.Branches,
+				},
+				Head: e.Head,
+			}
+			if e.Workflow.Repository != nil {
+				wh.Data.InsecureSkipSignatureVerify = e.Workflow.Repository.InsecureSkipSignatureVerify
+			}
+			whs = append(whs, wh)
+		}
+	}
+
 	// Prepare workflow_update hook:
 	// * workflow distant && default branch
 	if e.Workflow.On != nil && e.Workflow.On.WorkflowUpdate != nil {
@@ -1124,10 +1276,15 @@ func prepareWorkflowHooks(ctx context.Context, db gorpmapper.SqlExecutorWithTx,
 				WorkflowName:   e.Name,
 				RepositoryName: workflowDefRepositoryName,
 				Data: sdk.V2WorkflowHookData{
-					Cron:           s.Cron,
-					CronTimeZone:   s.Timezone,
-					VCSServer:      destVCS,
-					RepositoryName: destRepo,
+					Cron:              s.Cron,
+					CronTimeZone:      s.Timezone,
+					VCSServer:         destVCS,
+					RepositoryName:    destRepo,
+					TargetBranch:      s.Branch,
+					ScheduleInputs:    s.Inputs,
+					ScheduleBlackouts: s.Blackouts,
+					ScheduleJitter:    s.Jitter,
+					ScheduleCatchUp:   s.CatchUp,
 				},
 				Head: e.Head,
 			}
@@ -1380,7 +1537,7 @@ func findCommitter(ctx context.Context, cache cache.Store, db *gorp.DbMap, ref,
 		}
 	}
 
-	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, projKey, vcsProjectWithSecret.Name)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, projKey, vcsProjectWithSecret.Name, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return nil, sdk.RepositoryAnalysisStatusError, "", sdk.WithStack(err)
 	}
@@ -1395,7 +1552,7 @@ func findCommitter(ctx context.Context, cache cache.Store, db *gorp.DbMap, ref,
 			return nil, sdk.RepositoryAnalysisStatusError, "", err
 		}
 		committer = commit.Committer.DisplayName
-	case sdk.VCSTypeGitlab:
+	case sdk.VCSTypeGitlab, sdk.VCSTypeAzureDevOps, sdk.VCSTypeGit:
 		commit, err := client.Commit(ctx, repoName, sha)
 		if err != nil {
 			return nil, sdk.RepositoryAnalysisStatusError, "", err
@@ -1649,6 +1806,40 @@ func (api *API) handleEntitiesFiles(ctx context.Context, ef *EntityFinder, files
 	return entities, nil
 }
 
+// lintSharedConcurrency checks that an organization concurrency exists. Region concurrencies depend on the region of the job
+// so they are only checked when the job is triggered.
+func lintSharedConcurrency(ctx context.Context, db gorp.SqlExecutor, ownerProjectKey string, ref string, onJob bool) error {
+	scope, name, _ := sdk.ParseSharedConcurrencyName(ref)
+	if scope == sdk.V2RunConcurrencyScopeRegion {
+		if !onJob {
+			return fmt.Errorf("region concurrency %s can only be used on jobs", ref)
+		}
+		return nil
+	}
+	if strings.Contains(name, "${{") {
+		return nil
+	}
+	proj, err := project.Load(ctx, db, ownerProjectKey)
+	if err != nil {
+		return err
+	}
+	if proj.Organization == "" {
+		return fmt.Errorf("concurrency %s doesn't exist, project %s has no organization", ref, ownerProjectKey)
+	}
+	orga, err := organization.LoadOrganizationByName(ctx, db, proj.Organization)
+	if err != nil {
+		return err
+	}
+	if _, err := concurrency.LoadByName(ctx, db, scope, orga.ID, name); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return fmt.Errorf("concurrency %s doesn't exist", ref)
+		}
+		log.ErrorWithStackTrace(ctx, err)
+		return fmt.Errorf("unable to check if concurrency %s exists", ref)
+	}
+	return nil
+}
+
 // Lint validates an entity. ef provides the resolution location (project/vcs/repo of the body
 // being linted) for entity references, while ownerProjectKey is the project owning the run's
 // project-scoped resources (integrations, concurrencies). They differ when a run uses a template
@@ -1698,12 +1889,11 @@ func Lint[T sdk.Lintable](ctx context.Context, db *gorp.DbMap, store cache.Store
 			}
 			if entTmpl == nil || entTmpl.Template.Name == "" {
 				err = append(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow %s: unknown workflow template %s", x.Name, x.From))
+				break
 			}
-			// Check required parameters
-			for _, v := range entTmpl.Template.Parameters {
-				if wkfP, has := x.Parameters[v.Key]; (!has || len(wkfP) == 0) && v.Required {
-					err = append(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow %s: required template parameter %q is missing or empty", x.Name, v.Key))
-				}
+			// Check required parameters and parameter types
+			for _, e := range entTmpl.Template.CheckParameters(x.Parameters) {
+				err = append(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow %s: %v", x.Name, e))
 			}
 
 		default:
@@ -1712,6 +1902,34 @@ func Lint[T sdk.Lintable](ctx context.Context, db *gorp.DbMap, store cache.Store
 			if sameVCS && sameRepo && x.Repository != nil && x.Repository.InsecureSkipSignatureVerify {
 				err = append(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow %s: parameter `insecure-skip-signature-verify`is not allowed if the workflow is defined on the same repository as `workflow.repository.name`. ", x.Name))
 			}
+			// Check that the vcs server of the target repository sends the events of the workflow hooks
+			if x.On != nil {
+				vcsType := ef.currentVCS.Type
+				if !sameVCS {
+					targetVCS, errV := vcs.LoadVCSByProject(ctx, db, ef.currentProject, x.Repository.VCSServer)
+					if errV != nil && !sdk.ErrorIs(errV, sdk.ErrNotFound) {
+						log.ErrorWithStackTrace(ctx, errV)
+					}
+					vcsType = ""
+					if targetVCS != nil {
+						vcsType = targetVCS.Type
+					}
+				}
+				hookEvents := []struct {
+					name sdk.WorkflowHookEventName
+					set  bool
+				}{
+					{sdk.WorkflowHookEventNameRelease, x.On.Release != nil},
+					{sdk.WorkflowHookEventNameBranchCreate, x.On.BranchCreate != nil},
+					{sdk.WorkflowHookEventNameBranchDelete, x.On.BranchDelete != nil},
+				}
+				for _, h := range hookEvents {
+					if h.set && vcsType != "" && !h.name.IsSupportedByVCS(vcsType) {
+						err = append(err, sdk.NewErrorFrom(sdk.ErrInvalidData, "workflow %s: %s event is not supported on %s repositories", x.Name, h.name, vcsType))
+					}
+				}
+			}
+
 			// Check gate user and group
 			for gateName, gateValue := range x.Gates {
 				for _, g := range gateValue.Reviewers.Groups {
@@ -1760,7 +1978,11 @@ func Lint[T sdk.Lintable](ctx context.Context, db *gorp.DbMap, store cache.Store
 							break
 						}
 					}
-					if !found {
+					if _, _, isShared := sdk.ParseSharedConcurrencyName(j.Concurrency); !found && isShared {
+						if errC := lintSharedConcurrency(ctx, db, ownerProjectKey, j.Concurrency, true); errC != nil {
+							err = append(err, sdk.NewErrorFrom(sdk.ErrInvalidData, "workflow %s job %s: %v", x.Name, jobID, errC))
+						}
+					} else if !found {
 						// Check if there is interpolation
 						if !strings.Contains(j.Concurrency, "${{") {
 							if _, errC := project.LoadConcurrencyByNameAndProjectKey(ctx, db, ownerProjectKey, j.Concurrency); errC != nil {
@@ -1783,7 +2005,11 @@ func Lint[T sdk.Lintable](ctx context.Context, db *gorp.DbMap, store cache.Store
 							break
 						}
 					}
-					if !found {
+					if _, _, isShared := sdk.ParseSharedConcurrencyName(x.Concurrency); !found && isShared {
+						if errC := lintSharedConcurrency(ctx, db, ownerProjectKey, x.Concurrency, false); errC != nil {
+							err = append(err, sdk.NewErrorFrom(sdk.ErrInvalidData, "workflow %s: %v", x.Name, errC))
+						}
+					} else if !found {
 						// Check if there is interpolation
 						if !strings.Contains(x.Concurrency, "${{") {
 							if _, errC := project.LoadConcurrencyByNameAndProjectKey(ctx, db, ownerProjectKey, x.Concurrency); errC != nil {
@@ -1916,7 +2142,7 @@ func retrieveSigninKey(ctx context.Context, db gorp.SqlExecutor, cache cache.Sto
 	defer next()
 
 	// Check commit signature
-	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, projKey, vcsName)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, projKey, vcsName, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return keyID, err
 	}
@@ -2012,7 +2238,7 @@ func (api *API) getCdsFilesOnVCSDirectory(ctx context.Context, analysis *sdk.Pro
 	ctx, next := telemetry.Span(ctx, "api.getCdsFilesOnVCSDirectory")
 	defer next()
 
-	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcsName)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcsName, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return nil, sdk.WithStack(err)
 	}
@@ -2052,7 +2278,7 @@ func (api *API) getCdsArchiveFileOnRepo(ctx context.Context, repo sdk.ProjectRep
 	ctx, next := telemetry.Span(ctx, "api.getCdsArchiveFileOnRepo")
 	defer next()
 
-	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcsName)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, analysis.ProjectKey, vcsName, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return nil, sdk.WithStack(err)
 	}
@@ -2124,3 +2350,23 @@ func (api *API) stopAnalysis(ctx context.Context, analysis *sdk.ProjectRepositor
 	}
 	return nil
 }
+
+// recordEntityAudit stores the audit of an entity changed by the analysis of a repository, on behalf of its initiator
+func recordEntityAudit(ctx context.Context, tx gorp.SqlExecutor, eventType sdk.EventType, vcsName, repoName string, before, after *sdk.Entity, initiator *sdk.V2Initiator) error {
+	e := after
+	if e == nil {
+		e = before
+	}
+	c := audit_v2.Change{
+		EventType:  eventType,
+		ProjectKey: e.ProjectKey,
+		EntityName: vcsName + "/" + repoName + "/" + e.Name,
+		Before:     before,
+		After:      after,
+	}
+	if initiator != nil {
+		c.UserID = initiator.UserID
+		c.Username = initiator.Username()
+	}
+	return audit_v2.Record(ctx, tx, c)
+}
@@ -0,0 +1,374 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/concurrency"
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getSharedConcurrencyScope returns the scope and the identifier of the organization or the region from the route
+func (api *API) getSharedConcurrencyScope(ctx context.Context, vars map[string]string) (sdk.V2RunJobConcurrencyScope, string, error) {
+	if regionIdentifier, has := vars["regionIdentifier"]; has {
+		reg, err := api.getRegionByIdentifier(ctx, regionIdentifier)
+		if err != nil {
+			return "", "", err
+		}
+		return sdk.V2RunConcurrencyScopeRegion, reg.ID, nil
+	}
+	orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+	if err != nil {
+		return "", "", err
+	}
+	return sdk.V2RunConcurrencyScopeOrganization, orga.ID, nil
+}
+
+// getSharedConcurrencyScopeName returns the name of the organization or the region from the route
+func (api *API) getSharedConcurrencyScopeName(ctx context.Context, vars map[string]string) (string, error) {
+	if regionIdentifier, has := vars["regionIdentifier"]; has {
+		reg, err := api.getRegionByIdentifier(ctx, regionIdentifier)
+		if err != nil {
+			return "", err
+		}
+		return reg.Name, nil
+	}
+	orga, err := api.getOrganizationByIdentifier(ctx, vars["organizationIdentifier"])
+	if err != nil {
+		return "", err
+	}
+	return orga.Name, nil
+}
+
+func (api *API) getSharedConcurrenciesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, mux.Vars(req))
+			if err != nil {
+				return err
+			}
+
+			concurrencies, err := concurrency.LoadAll(ctx, api.mustDB(), scope, scopeID)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, concurrencies, http.StatusOK)
+		}
+}
+
+func (api *API) getSharedConcurrencyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			c, err := concurrency.LoadByName(ctx, api.mustDB(), scope, scopeID, vars["concurrencyName"])
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, c, http.StatusOK)
+		}
+}
+
+func (api *API) postSharedConcurrencyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			vars := mux.Vars(req)
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, vars)
+			if err != nil {
+				return err
+			}
+			scopeName, err := api.getSharedConcurrencyScopeName(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			var c sdk.SharedConcurrency
+			if err := service.UnmarshalBody(req, &c); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			c.Scope = scope
+			c.ScopeID = scopeID
+			if err := (&c).Check(); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := concurrency.Insert(ctx, tx, &c); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventSharedConcurrencyCreated, "", sharedConcurrencyAuditName(c, scopeName), nil, c); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishSharedConcurrencyEvent(ctx, api.Cache, sdk.EventSharedConcurrencyCreated, scopeName, c, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, c, http.StatusOK)
+		}
+}
+
+func (api *API) putSharedConcurrencyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			vars := mux.Vars(req)
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, vars)
+			if err != nil {
+				return err
+			}
+			scopeName, err := api.getSharedConcurrencyScopeName(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			oldConcurrency, err := concurrency.LoadByName(ctx, api.mustDB(), scope, scopeID, vars["concurrencyName"])
+			if err != nil {
+				return err
+			}
+
+			var c sdk.SharedConcurrency
+			if err := service.UnmarshalBody(req, &c); err != nil {
+				return sdk.WrapError(err, "cannot read body")
+			}
+			c.ID = oldConcurrency.ID
+			c.Scope = scope
+			c.ScopeID = scopeID
+			if err := (&c).Check(); err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := concurrency.Update(ctx, tx, &c); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventSharedConcurrencyUpdated, "", sharedConcurrencyAuditName(c, scopeName), oldConcurrency, c); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishSharedConcurrencyEvent(ctx, api.Cache, sdk.EventSharedConcurrencyUpdated, scopeName, c, *u.AuthConsumerUser.AuthentifiedUser)
+			return service.WriteJSON(w, c, http.StatusOK)
+		}
+}
+
+func (api *API) deleteSharedConcurrencyHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			vars := mux.Vars(req)
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, vars)
+			if err != nil {
+				return err
+			}
+			scopeName, err := api.getSharedConcurrencyScopeName(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			c, err := concurrency.LoadByName(ctx, api.mustDB(), scope, scopeID, vars["concurrencyName"])
+			if err != nil {
+				return err
+			}
+
+			tx, err := api.mustDB().Begin()
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			defer tx.Rollback() // nolint
+			if err := concurrency.Delete(tx, scope, scopeID, c.ID); err != nil {
+				return err
+			}
+			if err := recordAudit(ctx, tx, sdk.EventSharedConcurrencyDeleted, "", sharedConcurrencyAuditName(*c, scopeName), c, nil); err != nil {
+				return err
+			}
+			if err := tx.Commit(); err != nil {
+				return sdk.WithStack(err)
+			}
+			event_v2.PublishSharedConcurrencyEvent(ctx, api.Cache, sdk.EventSharedConcurrencyDeleted, scopeName, *c, *u.AuthConsumerUser.AuthentifiedUser)
+			return nil
+		}
+}
+
+// getSharedConcurrencyRunsHandler returns the lock holders and the waiter queue of a concurrency
+func (api *API) getSharedConcurrencyRunsHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			runObjects, err := workflow_v2.LoadSharedConcurrencyRunObjects(ctx, api.mustDB(), scope, scopeID, vars["concurrencyName"])
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, runObjects, http.StatusOK)
+		}
+}
+
+// postSharedConcurrencyReleaseHandler force releases a concurrency: current holders keep running but no longer hold the lock
+func (api *API) postSharedConcurrencyReleaseHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.sharedConcurrencyManage),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			concurrencyName := vars["concurrencyName"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			scope, scopeID, err := api.getSharedConcurrencyScope(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			runObjects, err := workflow_v2.LoadSharedConcurrencyRunObjects(ctx, api.mustDB(), scope, scopeID, concurrencyName)
+			if err != nil {
+				return err
+			}
+
+			released := make([]sdk.ProjectConcurrencyRunObject, 0)
+			for _, o := range runObjects {
+				if o.Status == string(sdk.V2WorkflowRunJobStatusBlocked) {
+					continue
+				}
+				concurrencyDef, err := api.releaseSharedConcurrencyRunObject(ctx, o, u.GetUsername())
+				if err != nil {
+					return err
+				}
+				if concurrencyDef == nil {
+					continue
+				}
+				released = append(released, o)
+
+				// Unlock waiters
+				currentObjectID := o.RunJobID
+				if o.Type == string(workflow_v2.ConcurrencyObjectTypeWorkflow) {
+					currentObjectID = o.WorkflowRunID
+				}
+				api.manageEndConcurrency(o.ProjectKey, "", "", o.WorkflowName, o.WorkflowRunID, currentObjectID, concurrencyDef)
+			}
+			return service.WriteJSON(w, released, http.StatusOK)
+		}
+}
+
+// releaseSharedConcurrencyRunObject removes the concurrency from a lock holder and returns the released concurrency
+func (api *API) releaseSharedConcurrencyRunObject(ctx context.Context, o sdk.ProjectConcurrencyRunObject, username string) (*sdk.V2RunConcurrency, error) {
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	var concurrencyDef *sdk.V2RunConcurrency
+	switch o.Type {
+	case string(workflow_v2.ConcurrencyObjectTypeWorkflow):
+		run, err := workflow_v2.LoadRunByID(ctx, tx, o.WorkflowRunID)
+		if err != nil {
+			return nil, err
+		}
+		if run.Concurrency == nil {
+			return nil, nil
+		}
+		concurrencyDef = run.Concurrency
+		run.Concurrency = nil
+		if err := workflow_v2.UpdateRun(ctx, tx, run); err != nil {
+			return nil, err
+		}
+		info := sdk.V2WorkflowRunInfo{
+			WorkflowRunID: run.ID,
+			IssuedAt:      time.Now(),
+			Level:         sdk.WorkflowRunInfoLevelWarning,
+			Message:       fmt.Sprintf("%s lock %q force released by %s", concurrencyDef.Scope, concurrencyDef.Name, username),
+		}
+		if err := workflow_v2.InsertRunInfo(ctx, tx, &info); err != nil {
+			return nil, err
+		}
+	default:
+		lockKey := cache.Key(jobLockKey, o.RunJobID)
+		b, err := api.Cache.Lock(lockKey, 1*time.Minute, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if !b {
+			return nil, sdk.NewErrorFrom(sdk.ErrJobLocked, "job %s of workflow %s is currently updated, try again later", o.JobName, o.WorkflowName)
+		}
+		defer func() {
+			_ = api.Cache.Unlock(lockKey)
+		}()
+
+		rj, err := workflow_v2.LoadRunJobByID(ctx, tx, o.RunJobID)
+		if err != nil {
+			return nil, err
+		}
+		if rj.Concurrency == nil {
+			return nil, nil
+		}
+		concurrencyDef = rj.Concurrency
+		rj.Concurrency = nil
+		if err := workflow_v2.UpdateJobRun(ctx, tx, rj); err != nil {
+			return nil, err
+		}
+		info := sdk.V2WorkflowRunJobInfo{
+			WorkflowRunID:    rj.WorkflowRunID,
+			WorkflowRunJobID: rj.ID,
+			IssuedAt:         time.Now(),
+			Level:            sdk.WorkflowRunInfoLevelWarning,
+			Message:          fmt.Sprintf("%s lock %q force released by %s", concurrencyDef.Scope, concurrencyDef.Name, username),
+		}
+		if err := workflow_v2.InsertRunJobInfo(ctx, tx, &info); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	log.Info(ctx, "%s lock %s released on %s/%s #%d by %s", concurrencyDef.Scope, concurrencyDef.Name, o.ProjectKey, o.WorkflowName, o.RunNumber, username)
+	return concurrencyDef, nil
+}
+
+// sharedConcurrencyAuditName returns the name of a shared concurrency in the audits, prefixed by its scope
+func sharedConcurrencyAuditName(c sdk.SharedConcurrency, scopeName string) string {
+	if c.Scope == sdk.V2RunConcurrencyScopeRegion {
+		return "region/" + scopeName + "/" + c.Name
+	}
+	return "organization/" + scopeName + "/" + c.Name
+}
@@ -75,7 +75,7 @@ func (api *API) getWorkflowRunJobsV2Handler() ([]service.RbacChecker, service.Ha
 }
 
 func (api *API) getWorkflowRunResultsV2Handler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectRead),
+	return service.RBAC(api.workflowReadLogs),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -232,7 +232,7 @@ func (api *API) getWorkflowRunJobHandler() ([]service.RbacChecker, service.Handl
 }
 
 func (api *API) postStopJobHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.workflowTrigger),
+	return service.RBAC(api.workflowStop),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -332,7 +332,7 @@ func (api *API) postStopJobHandler() ([]service.RbacChecker, service.Handler) {
 }
 
 func (api *API) getWorkflowRunJobLogsLinksV2Handler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectRead),
+	return service.RBAC(api.workflowReadLogs),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -423,7 +423,7 @@ func (api *API) getWorkflowRunJobLogsLinksV2Handler() ([]service.RbacChecker, se
 }
 
 func (api *API) getWorkflowRunJobServiceLogsLinkV2Handler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectRead),
+	return service.RBAC(api.workflowReadLogs),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -536,7 +536,7 @@ func (api *API) getWorkflowRunV2Handler() ([]service.RbacChecker, service.Handle
 }
 
 func (api *API) deleteWorkflowRunV2Handler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.projectManage),
+	return service.RBAC(api.workflowDeleteRun),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -783,7 +783,7 @@ func (api *API) getWorkflowRunsSearchV2Handler() ([]service.RbacChecker, service
 }
 
 func (api *API) postStopWorkflowRunHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.workflowTrigger),
+	return service.RBAC(api.workflowStop),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -851,7 +851,7 @@ func (api *API) postWorkflowRunFromHookV2Handler() ([]service.RbacChecker, servi
 				return err
 			}
 			if commit == "HEAD" && strings.HasPrefix(ref, sdk.GitRefTagPrefix) {
-				client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsProject.Name)
+				client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return err
 				}
@@ -909,7 +909,7 @@ func (api *API) postWorkflowRunFromHookV2Handler() ([]service.RbacChecker, servi
 			log.Debug(ctx, "theOneWhoTriggers = %+v", theOneWhoTriggers)
 
 			if !theOneWhoTriggers.IsUser() {
-				hasRole, err = rbac.HasRoleOnWorkflowAndVCSUsername(ctx, api.mustDB(), sdk.WorkflowRoleTrigger, sdk.RBACVCSUser{VCSServer: runRequest.Initiator.VCS, VCSUsername: runRequest.Initiator.VCSUsername}, proj.Key, vcsProject.Name, repo.Name, wk.Name)
+				hasRole, err = rbac.HasRoleOnWorkflowAndVCSUsername(ctx, api.mustDB(), sdk.WorkflowRoleTrigger, sdk.RBACVCSUser{VCSServer: runRequest.Initiator.VCS, VCSUsername: runRequest.Initiator.VCSUsername}, proj.Key, vcsProject.Name, repo.Name, wk.Name, runRequest.Ref)
 				if err != nil {
 					return err
 				}
@@ -920,7 +920,7 @@ func (api *API) postWorkflowRunFromHookV2Handler() ([]service.RbacChecker, servi
 					return err
 				}
 				theOneWhoTriggers.User = u.Initiator()
-				hasRole, err = rbac.HasRoleOnWorkflowAndUserID(ctx, api.mustDB(), sdk.WorkflowRoleTrigger, u.ID, proj.Key, vcsProject.Name, repo.Name, wk.Name)
+				hasRole, err = rbac.HasRoleOnWorkflowAndUserID(ctx, api.mustDB(), sdk.WorkflowRoleTrigger, u.ID, proj.Key, vcsProject.Name, repo.Name, wk.Name, runRequest.Ref)
 				if err != nil {
 					return err
 				}
@@ -946,7 +946,7 @@ func (api *API) postWorkflowRunFromHookV2Handler() ([]service.RbacChecker, servi
 			if runRequest.TargetRepository != "" && runRequest.TargetRepository != originRepo {
 
 				// Check fork
-				client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, originVCS)
+				client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, originVCS, project.LoadKeyWithPrivateContentByProjectKey)
 				if err != nil {
 					return err
 				}
@@ -981,7 +981,7 @@ func (api *API) postWorkflowRunFromHookV2Handler() ([]service.RbacChecker, servi
 }
 
 func (api *API) postStartJobWorkflowRunHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.workflowTrigger),
+	return service.RBAC(api.workflowRestart),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -1013,6 +1013,11 @@ func (api *API) postStartJobWorkflowRunHandler() ([]service.RbacChecker, service
 			if len(jobsRequest.JobInputs) == 0 {
 				return sdk.NewErrorFrom(sdk.ErrInvalidData, "no job provided")
 			}
+			if jobsRequest.Debug {
+				if err := api.workflowDebug(ctx, vars); err != nil {
+					return err
+				}
+			}
 
 			runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDB(), wr.ID, wr.RunAttempt)
 			if err != nil {
@@ -1114,6 +1119,7 @@ func (api *API) postStartJobWorkflowRunHandler() ([]service.RbacChecker, service
 					UserID:     u.AuthConsumerUser.AuthentifiedUserID,
 					Username:   u.GetUsername(),
 					RunAttempt: wr.RunAttempt + 1,
+					Debug:      jobsRequest.Debug,
 				})
 				startJobs = append(startJobs, jobID)
 			}
@@ -1134,6 +1140,9 @@ func (api *API) postStartJobWorkflowRunHandler() ([]service.RbacChecker, service
 				Level:         sdk.WorkflowRunInfoLevelInfo,
 				Message:       fmt.Sprintf("%s starts jobs: %v", u.GetFullname(), startJobs),
 			}
+			if jobsRequest.Debug {
+				runInfo.Message += " in debug mode"
+			}
 			if err := workflow_v2.InsertRunInfo(ctx, tx, &runInfo); err != nil {
 				return err
 			}
@@ -1171,7 +1180,7 @@ func (api *API) postStartJobWorkflowRunHandler() ([]service.RbacChecker, service
 }
 
 func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.workflowTrigger),
+	return service.RBAC(api.workflowRestart),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -1196,6 +1205,13 @@ func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.
 				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to rerun a running workflow")
 			}
 
+			debug := service.FormBool(req, "debug")
+			if debug {
+				if err := api.workflowDebug(ctx, vars); err != nil {
+					return err
+				}
+			}
+
 			runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDB(), wr.ID, wr.RunAttempt)
 			if err != nil {
 				return err
@@ -1256,6 +1272,9 @@ func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.
 				Level:         sdk.WorkflowRunInfoLevelInfo,
 				Message:       u.GetFullname() + " restarted all failed and stopped jobs",
 			}
+			if debug {
+				runInfo.Message += " in debug mode"
+			}
 			if err := workflow_v2.InsertRunInfo(ctx, tx, &runInfo); err != nil {
 				return err
 			}
@@ -1281,6 +1300,32 @@ func (api *API) postRestartWorkflowRunHandler() ([]service.RbacChecker, service.
 					wr.RunJobEvent = append(wr.RunJobEvent, runJobEvent)
 				}
 			}
+
+			// In debug mode, flag all the failed and stopped jobs that will run again
+			if debug {
+				updateRun = true
+				for _, rj := range failedRunJob {
+					if _, has := runJobsToKeep[rj.JobID]; has {
+						continue
+					}
+					var found bool
+					for i := range wr.RunJobEvent {
+						if wr.RunJobEvent[i].RunAttempt == wr.RunAttempt && wr.RunJobEvent[i].JobID == rj.JobID {
+							wr.RunJobEvent[i].Debug = true
+							found = true
+						}
+					}
+					if !found {
+						wr.RunJobEvent = append(wr.RunJobEvent, sdk.V2WorkflowRunJobEvent{
+							UserID:     u.AuthConsumerUser.AuthentifiedUserID,
+							Username:   u.GetUsername(),
+							JobID:      rj.JobID,
+							RunAttempt: wr.RunAttempt,
+							Debug:      true,
+						})
+					}
+				}
+			}
 			if updateRun {
 				if err := workflow_v2.UpdateRun(ctx, tx, wr); err != nil {
 					return err
@@ -1394,7 +1439,7 @@ func (api *API) restartWorkflowRun(ctx context.Context, tx gorpmapper.SqlExecuto
 }
 
 func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.workflowTrigger),
+	return service.RBAC(api.workflowApprove),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			vars := mux.Vars(req)
 			pKey := vars["projectKey"]
@@ -1504,7 +1549,7 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 			}
 
 			runJobsContexts, _ := computeExistingRunJobContexts(ctx, runJobs, runResults)
-			jobContext := buildContextForJob(ctx, wr.WorkflowData.Workflow, runJobsContexts, wr.Contexts, stages, jobToRuns[0].JobID)
+			jobContext := buildContextForJob(ctx, wr.WorkflowData.Workflow, runJobsContexts, wr.Contexts, jobToRuns[0].JobID)
 			initiator := sdk.V2Initiator{
 				UserID:         u.AuthConsumerUser.AuthentifiedUser.ID,
 				User:           u.AuthConsumerUser.AuthentifiedUser.Initiator(),
@@ -1573,7 +1618,7 @@ func (api *API) postRunJobHandler() ([]service.RbacChecker, service.Handler) {
 }
 
 func (api *API) postWorkflowRunV2Handler() ([]service.RbacChecker, service.Handler) {
-	return service.RBAC(api.workflowTrigger),
+	return service.RBAC(api.projectRead),
 		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
 			u := getUserConsumer(ctx)
 			if u == nil {
@@ -1612,7 +1657,7 @@ func (api *API) postWorkflowRunV2Handler() ([]service.RbacChecker, service.Handl
 				return err
 			}
 
-			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name)
+			vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, pKey, vcsProject.Name, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				return err
 			}
@@ -1637,6 +1682,31 @@ func (api *API) postWorkflowRunV2Handler() ([]service.RbacChecker, service.Handl
 				workflowRef = defaultBranch.ID
 			}
 
+			// The trigger role is checked on the git ref of the run, that can be restricted by the workflow permissions
+			var runRef string
+			switch {
+			case runRequest.Branch != "":
+				runRef = sdk.GitRefBranchPrefix + runRequest.Branch
+			case runRequest.Tag != "":
+				runRef = sdk.GitRefTagPrefix + runRequest.Tag
+			default:
+				targetRepo := repo.Name
+				if runRequest.TargetRepository != "" {
+					targetRepo = runRequest.TargetRepository
+				}
+				defaultBranch, err := vcsClient.Branch(ctx, targetRepo, sdk.VCSBranchFilters{Default: true})
+				if err != nil {
+					return err
+				}
+				runRef = defaultBranch.ID
+			}
+			if err := api.hasRoleOnWorkflowRef(ctx, pKey, vcsProject.Name, repo.Name, workflowName, runRef, sdk.WorkflowRoleTrigger); err != nil {
+				if !isAdmin(ctx) {
+					return err
+				}
+				trackSudo(ctx, w)
+			}
+
 			// Search entity on workflowCommit
 			var workflowEntity *sdk.Entity
 			if workflowCommit != "" {
@@ -1748,6 +1818,11 @@ func (api *API) startWorkflowV2(ctx context.Context, proj sdk.Project, vcsProjec
 		WebHookID:         runRequest.WebhookID,
 		RepositoryOrigin:  repoOrigin,
 		HookEventID:       runRequest.HookEventID,
+		EventType:         runRequest.EventType,
+		DeletedRef:        runRequest.DeletedRef,
+		ReleaseTag:        runRequest.ReleaseTag,
+		ReleaseName:       runRequest.ReleaseName,
+		ReleaseURL:        runRequest.ReleaseURL,
 	}
 
 	var msg string
@@ -1792,6 +1867,7 @@ func (api *API) startWorkflowV2(ctx context.Context, proj sdk.Project, vcsProjec
 		DeprecatedAdminMFA: initiator.IsAdminWithMFA, // Deprecated
 		DeprecatedUsername: initiator.Username(),     // Deprecated
 		RunJobEvent:        make([]sdk.V2WorkflowRunJobEvent, 0, len(runRequest.JobInputs)),
+		TraceParent:        runRequest.TraceParent,
 	}
 
 	wrNumber, err := workflow_v2.WorkflowRunNextNumber(api.mustDB(), repo.ID, wk.Name)
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// computeWorkflowRunCoverage merges the coverage reports of the current attempt of a run and stores the totals.
+// On pull requests, the coverage of the changed lines is computed from the diff given by the VCS server.
+// The summary is posted as a comment on the pull request, or as a commit status otherwise.
+func (api *API) computeWorkflowRunCoverage(ctx context.Context, runID string) error {
+	run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runID)
+	if err != nil {
+		return err
+	}
+	runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDB(), run.ID, run.RunAttempt)
+	if err != nil {
+		return err
+	}
+	runJobIDs := make([]string, 0, len(runJobs))
+	for _, rj := range runJobs {
+		runJobIDs = append(runJobIDs, rj.ID)
+	}
+	runResults, err := workflow_v2.LoadRunResultsByRunIDAttempt(ctx, api.mustDB(), run.ID, runJobIDs, run.RunAttempt)
+	if err != nil {
+		return err
+	}
+
+	var reports []sdk.CoverageReport
+	for i := range runResults {
+		if runResults[i].Type != sdk.V2WorkflowRunResultTypeCoverage {
+			continue
+		}
+		detail, err := runResults[i].GetDetail()
+		if err != nil {
+			return err
+		}
+		if generic, ok := detail.(*sdk.V2WorkflowRunResultGenericDetail); ok && generic.Coverage != nil {
+			reports = append(reports, *generic.Coverage)
+		}
+	}
+	if len(reports) == 0 {
+		return nil
+	}
+	report := sdk.MergeCoverageReports(reports...)
+
+	git := run.Contexts.Git
+	coverage := sdk.V2WorkflowRunCoverage{
+		ProjectKey:    run.ProjectKey,
+		VCSServer:     run.VCSServer,
+		Repository:    run.Repository,
+		WorkflowName:  run.WorkflowName,
+		WorkflowRunID: run.ID,
+		RunNumber:     run.RunNumber,
+		RunAttempt:    run.RunAttempt,
+		GitRef:        git.Ref,
+		GitSha:        git.Sha,
+		PullRequestID: git.PullRequestID,
+		LinesTotal:    report.LinesTotal,
+		LinesCovered:  report.LinesCovered,
+		Rate:          report.Rate(),
+	}
+
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, run.ProjectKey, git.Server, project.LoadKeyWithPrivateContentByProjectKey)
+	if err != nil {
+		return err
+	}
+
+	// The coverage is compared to the last one on the target branch of the pull request, or on the same branch
+	baseRef := git.Ref
+	if git.PullRequestID != 0 && git.PullRequestToRef != "" {
+		baseRef = git.PullRequestToRef
+		files, err := vcsClient.DiffBetweenRefs(ctx, git.Repository, git.PullRequestToRef, git.Sha)
+		if err != nil {
+			log.Warn(ctx, "unable to get the diff of pull request %d on %s: %v", git.PullRequestID, git.Repository, err)
+		} else {
+			changedLines := make(map[string][]int, len(files))
+			for _, f := range files {
+				changedLines[f.Filename] = f.AddedLines()
+			}
+			coverage.DiffLinesTotal, coverage.DiffLinesCovered = report.DiffCoverage(changedLines)
+			coverage.DiffRate = sdk.CoverageRate(coverage.DiffLinesCovered, coverage.DiffLinesTotal)
+		}
+	}
+	var base *sdk.V2WorkflowRunCoverage
+	previous, err := workflow_v2.LoadWorkflowCoverageTrend(ctx, api.mustDB(), run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName, baseRef, 2)
+	if err != nil {
+		return err
+	}
+	for i := range previous {
+		if previous[i].WorkflowRunID != run.ID {
+			base = &previous[i]
+			break
+		}
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := workflow_v2.ReplaceRunCoverage(ctx, tx, &coverage); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	summary := coverage.Summary(base)
+	if git.PullRequestID != 0 {
+		return vcsClient.PullRequestComment(ctx, git.Repository, sdk.VCSPullRequestCommentRequest{
+			ID:       int(git.PullRequestID),
+			Revision: git.Ref,
+			Message:  fmt.Sprintf("%s: %s", run.WorkflowName, summary),
+		})
+	}
+
+	repositoryFullname := git.RepositoryOrigin
+	if repositoryFullname == "" {
+		repositoryFullname = git.Repository
+	}
+	statusContext := fmt.Sprintf("%s-%s-coverage", run.ProjectKey, run.WorkflowName)
+	return vcsClient.SetStatus(ctx, sdk.VCSBuildStatus{
+		Title:              statusContext,
+		Description:        summary,
+		URLCDS:             fmt.Sprintf("%s/project/%s/run/%s", api.Config.URL.UI, run.ProjectKey, run.ID),
+		Context:            statusContext,
+		Status:             sdk.StatusSuccess,
+		RepositoryFullname: repositoryFullname,
+		GitHash:            git.Sha,
+	})
+}
+
+// parseRunResultCoverage parses the coverage report sent with a coverage run result. The coverage sent by the worker
+// is ignored and the raw report is not stored. On update without report, the coverage parsed at creation is kept.
+func parseRunResultCoverage(runResult *sdk.V2WorkflowRunResult, previous *sdk.V2WorkflowRunResult) error {
+	if runResult.Type != sdk.V2WorkflowRunResultTypeCoverage {
+		return nil
+	}
+	detail, err := sdk.GetConcreteDetail[*sdk.V2WorkflowRunResultGenericDetail](runResult)
+	if err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	content := detail.CoverageReportContent
+	detail.CoverageReportContent = ""
+	detail.Coverage = nil
+	switch {
+	case content != "":
+		if len(content) > sdk.CoverageReportMaxSize {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "coverage report %s is bigger than %d bytes", detail.Name, sdk.CoverageReportMaxSize)
+		}
+		report, err := sdk.ParseCoverageReport([]byte(content))
+		if err != nil {
+			return err
+		}
+		detail.Coverage = &report
+	case previous != nil:
+		if previousDetail, err := sdk.GetConcreteDetail[*sdk.V2WorkflowRunResultGenericDetail](previous); err == nil {
+			detail.Coverage = previousDetail.Coverage
+		}
+	}
+	runResult.Detail.Data = detail
+	return nil
+}
+
+func (api *API) getWorkflowRunCoverageV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), pKey, workflowRunID)
+			if err != nil {
+				return err
+			}
+
+			attempt := wr.RunAttempt
+			if attemptS := FormString(req, "attempt"); attemptS != "" {
+				attempt, err = strconv.ParseInt(attemptS, 10, 64)
+				if err != nil {
+					return sdk.NewError(sdk.ErrWrongRequest, err)
+				}
+			}
+
+			coverage, err := workflow_v2.LoadRunCoverage(ctx, api.mustDB(), wr.ID, attempt)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, coverage, http.StatusOK)
+		}
+}
+
+func (api *API) getWorkflowCoverageTrendHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			vcsName, repoName, workflowName, err := api.getWorkflowNamesFromVars(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			ref := FormString(req, "ref")
+			if ref == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing git ref")
+			}
+
+			trend, err := workflow_v2.LoadWorkflowCoverageTrend(ctx, api.mustDB(), vars["projectKey"], vcsName, repoName, workflowName, ref, workflowTestCasesLimit(req))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, trend, http.StatusOK)
+		}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestParseRunResultCoverage(t *testing.T) {
+	// Run results are read from the body sent by the worker
+	newRunResult := func(detail sdk.V2WorkflowRunResultGenericDetail) *sdk.V2WorkflowRunResult {
+		btes, err := json.Marshal(&sdk.V2WorkflowRunResult{
+			Type:   sdk.V2WorkflowRunResultTypeCoverage,
+			Status: sdk.V2WorkflowRunResultStatusPending,
+			Detail: sdk.V2WorkflowRunResultDetail{Data: detail},
+		})
+		require.NoError(t, err)
+		var runResult sdk.V2WorkflowRunResult
+		require.NoError(t, json.Unmarshal(btes, &runResult))
+		return &runResult
+	}
+	coverage := func(runResult *sdk.V2WorkflowRunResult) *sdk.V2WorkflowRunResultGenericDetail {
+		detail, err := sdk.GetConcreteDetail[*sdk.V2WorkflowRunResultGenericDetail](runResult)
+		require.NoError(t, err)
+		require.Empty(t, detail.CoverageReportContent)
+		return detail
+	}
+
+	created := newRunResult(sdk.V2WorkflowRunResultGenericDetail{
+		Name:                  "coverage.out",
+		Coverage:              &sdk.CoverageReport{LinesTotal: 1, LinesCovered: 1},
+		CoverageReportContent: "mode: set\ngithub.com/ovh/cds/sdk/foo.go:3.20,5.2 2 1\ngithub.com/ovh/cds/sdk/foo.go:7.20,9.2 1 0\n",
+	})
+	require.NoError(t, parseRunResultCoverage(created, nil))
+	detail := coverage(created)
+	require.NotNil(t, detail.Coverage)
+	require.Equal(t, sdk.CoverageReportFormatGoCover, detail.Coverage.Format)
+	require.Equal(t, int64(6), detail.Coverage.LinesTotal)
+	require.Equal(t, int64(3), detail.Coverage.LinesCovered)
+
+	// The coverage sent by the worker on update is replaced by the one parsed at creation
+	updated := newRunResult(sdk.V2WorkflowRunResultGenericDetail{
+		Name:     "coverage.out",
+		Coverage: &sdk.CoverageReport{LinesTotal: 1, LinesCovered: 1},
+	})
+	require.NoError(t, parseRunResultCoverage(updated, created))
+	require.Equal(t, detail.Coverage, coverage(updated).Coverage)
+
+	forged := newRunResult(sdk.V2WorkflowRunResultGenericDetail{
+		Name:     "coverage.out",
+		Coverage: &sdk.CoverageReport{LinesTotal: 1, LinesCovered: 1},
+	})
+	require.NoError(t, parseRunResultCoverage(forged, nil))
+	require.Nil(t, coverage(forged).Coverage)
+
+	invalid := newRunResult(sdk.V2WorkflowRunResultGenericDetail{
+		Name:                  "coverage.xml",
+		CoverageReportContent: "<coverage><packages>",
+	})
+	require.True(t, sdk.ErrorIs(parseRunResultCoverage(invalid, nil), sdk.ErrWrongRequest))
+}
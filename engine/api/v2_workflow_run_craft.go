@@ -220,7 +220,7 @@ func (api *API) craftWorkflowRunV2(ctx context.Context, id string) error {
 			})
 		}
 
-		vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, e.Entity.ProjectKey, vcsProj.Name)
+		vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, e.Entity.ProjectKey, vcsProj.Name, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return err
 		}
@@ -285,7 +285,7 @@ func (api *API) craftWorkflowRunV2(ctx context.Context, id string) error {
 	mustSaveVersion := false
 	if run.WorkflowData.Workflow.Semver != nil {
 		var cdsVersion *semver.Version
-		vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, run.ProjectKey, run.Contexts.Git.Server)
+		vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, run.ProjectKey, run.Contexts.Git.Server, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return err
 		}
@@ -422,10 +422,40 @@ func (api *API) craftWorkflowRunV2(ctx context.Context, id string) error {
 		run.WorkflowData.WorkerModels[v.CompleteName] = v.Model
 	}
 
-	// check concurrency
+	// check project and organization policies
 	runInfos := make([]sdk.V2WorkflowRunInfo, 0)
+	violations, err := api.evaluateWorkflowRunPolicies(ctx, run)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return stopRun(ctx, api.mustDB(), api.Cache, run, nil, sdk.V2WorkflowRunInfo{
+			WorkflowRunID: run.ID,
+			IssuedAt:      time.Now(),
+			Level:         sdk.WorkflowRunInfoLevelError,
+			Message:       fmt.Sprintf("unable to check policies: %v", err),
+		})
+	}
+	deniedInfos := make([]sdk.V2WorkflowRunInfo, 0)
+	for _, v := range violations {
+		info := sdk.V2WorkflowRunInfo{
+			WorkflowRunID: run.ID,
+			IssuedAt:      time.Now(),
+			Level:         sdk.WorkflowRunInfoLevelWarning,
+			Message:       v.String(),
+		}
+		if v.Outcome == sdk.PolicyOutcomeDeny {
+			info.Level = sdk.WorkflowRunInfoLevelError
+			deniedInfos = append(deniedInfos, info)
+			continue
+		}
+		runInfos = append(runInfos, info)
+	}
+	if len(deniedInfos) > 0 {
+		return stopRun(ctx, api.mustDB(), api.Cache, run, nil, deniedInfos...)
+	}
+
+	// check concurrency
 	if run.WorkflowData.Workflow.Concurrency != "" {
-		concurrencyDef, err := retrieveConcurrencyDefinition(ctx, api.mustDB(), *run, run.WorkflowData.Workflow.Concurrency)
+		concurrencyDef, err := retrieveConcurrencyDefinition(ctx, api.mustDB(), *run, run.WorkflowData.Workflow.Concurrency, "")
 		if err != nil {
 			log.ErrorWithStackTrace(ctx, err)
 			return stopRun(ctx, api.mustDB(), api.Cache, run, nil, sdk.V2WorkflowRunInfo{
@@ -998,6 +1028,12 @@ func buildRunContext(ctx context.Context, db *gorp.DbMap, store cache.Store, wr
 		PullRequestID:        wr.RunEvent.PullRequestID,
 		PullRequestToRef:     wr.RunEvent.PullRequestToRef,
 		PullRequestToRefName: strings.TrimPrefix(strings.TrimPrefix(wr.RunEvent.PullRequestToRef, sdk.GitRefBranchPrefix), sdk.GitRefTagPrefix),
+		EventType:            string(wr.RunEvent.EventType),
+		DeletedRef:           wr.RunEvent.DeletedRef,
+		DeletedRefName:       strings.TrimPrefix(wr.RunEvent.DeletedRef, sdk.GitRefBranchPrefix),
+		ReleaseTag:           wr.RunEvent.ReleaseTag,
+		ReleaseName:          wr.RunEvent.ReleaseName,
+		ReleaseWebURL:        wr.RunEvent.ReleaseURL,
 	}
 
 	if gitContext.SSHKey != "" {
@@ -1012,7 +1048,7 @@ func buildRunContext(ctx context.Context, db *gorp.DbMap, store cache.Store, wr
 		gitContext.Repository = repo.Name
 	}
 
-	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, wr.ProjectKey, workflowVCSServer.Name)
+	vcsClient, err := repositoriesmanager.AuthorizedClient(ctx, db, store, wr.ProjectKey, workflowVCSServer.Name, project.LoadKeyWithPrivateContentByProjectKey)
 	if err != nil {
 		return nil, err
 	}
@@ -1109,7 +1145,7 @@ func getCDSversion(ctx context.Context, db gorp.SqlExecutor, vcsClient sdk.VCSAu
 
 	var fileContent string
 	switch typeVCS {
-	case sdk.VCSTypeGitlab, sdk.VCSTypeGithub, sdk.VCSTypeGitea, sdk.VCSTypeForgejo:
+	case sdk.VCSTypeGitlab, sdk.VCSTypeGithub, sdk.VCSTypeGitea, sdk.VCSTypeForgejo, sdk.VCSTypeAzureDevOps, sdk.VCSTypeGit:
 		contentBts, err := base64.StdEncoding.DecodeString(content.Content)
 		if err != nil {
 			return nil, false, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to decode file at path %s", workflowDef.Semver.Path)
@@ -214,7 +214,7 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 		}
 		hasRetryJob = true
 		newRj := sdk.V2WorkflowRunJob{
-			ID:                 "",
+			ID:                 sdk.UUID(),
 			JobID:              rj.JobID,
 			WorkflowRunID:      rj.WorkflowRunID,
 			ProjectKey:         rj.ProjectKey,
@@ -236,7 +236,9 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 			GateInputs:         rj.GateInputs,
 			Initiator:          rj.Initiator,
 			Concurrency:        rj.Concurrency,
+			Debug:              rj.Debug,
 		}
+		newRj.TraceParent = run.JobTraceParent(newRj.ID)
 		rj.Status = sdk.V2WorkflowRunJobStatusFail
 
 		tx, err := api.mustDB().Begin()
@@ -355,7 +357,25 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 		if jToTrigger.Job.Concurrency == "" {
 			continue
 		}
-		jobConcurrencyDef, err := retrieveConcurrencyDefinition(ctx, api.mustDB(), *run, jToTrigger.Job.Concurrency)
+		// Region scoped concurrencies are resolved on the region of the job
+		jobRegion := jToTrigger.Job.Region
+		if strings.Contains(jobRegion, "${{") {
+			jobRegion, err = ap.InterpolateToString(ctx, jobRegion)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				runInfo := sdk.V2WorkflowRunInfo{
+					WorkflowRunID: run.ID,
+					IssuedAt:      time.Now(),
+					Level:         sdk.WorkflowRunInfoLevelError,
+					Message:       fmt.Sprintf("Job %s: unable to interpolate region %q: %v", jobID, jToTrigger.Job.Region, err),
+				}
+				return failRunWithMessage(ctx, api.mustDB(), api.Cache, run, []sdk.V2WorkflowRunInfo{runInfo}, allrunJobsMap, runResults, &wrEnqueue.Initiator)
+			}
+		}
+		if jobRegion == "" {
+			jobRegion = api.Config.Workflow.JobDefaultRegion
+		}
+		jobConcurrencyDef, err := retrieveConcurrencyDefinition(ctx, api.mustDB(), *run, jToTrigger.Job.Concurrency, jobRegion)
 		if err != nil {
 			return err
 		}
@@ -366,6 +386,9 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 				Level:         sdk.WorkflowRunInfoLevelError,
 				Message:       fmt.Sprintf("Job %s: concurrency %q not found on workflow nor on project", jobID, jToTrigger.Job.Concurrency),
 			}
+			if scope, _, isShared := sdk.ParseSharedConcurrencyName(jToTrigger.Job.Concurrency); isShared {
+				runInfo.Message = fmt.Sprintf("Job %s: concurrency %q not found on %s", jobID, jToTrigger.Job.Concurrency, scope)
+			}
 			return failRunWithMessage(ctx, api.mustDB(), api.Cache, run, []sdk.V2WorkflowRunInfo{runInfo}, allrunJobsMap, runResults, &wrEnqueue.Initiator)
 		}
 
@@ -531,8 +554,11 @@ func (api *API) workflowRunV2Trigger(ctx context.Context, wrEnqueue sdk.V2Workfl
 
 func getConcurrencyUniqueKey(concu sdk.V2RunConcurrency, projKey, vcs, repo, workflow string) string {
 	concurrencyKey := fmt.Sprintf("%s-%s-%s", concu.Scope, projKey, concu.Name)
-	if concu.Scope == sdk.V2RunConcurrencyScopeWorkflow {
+	switch {
+	case concu.Scope == sdk.V2RunConcurrencyScopeWorkflow:
 		concurrencyKey = fmt.Sprintf("%s-%s-%s-%s-%s-%s", concu.Scope, projKey, vcs, repo, workflow, concu.Name)
+	case concu.Scope.IsShared():
+		concurrencyKey = fmt.Sprintf("%s-%s-%s", concu.Scope, concu.Key, concu.Name)
 	}
 	return concurrencyKey
 }
@@ -580,6 +606,18 @@ func (api *API) endWorkflowV2Trigger(ctx context.Context, run *sdk.V2WorkflowRun
 		// Send event
 		event_v2.PublishRunEvent(ctx, api.Cache, sdk.EventRunEnded, *run, allrunJobsMap, runResults, &wrEnqueue.Initiator)
 
+		// Export the trace of the run
+		api.GoRoutines.Exec(ctx, "api.exportWorkflowRunTrace", func(ctx context.Context) {
+			api.exportWorkflowRunTrace(ctx, run.ID)
+		})
+
+		// Aggregate the coverage reports of the run
+		api.GoRoutines.Exec(ctx, "api.computeWorkflowRunCoverage", func(ctx context.Context) {
+			if err := api.computeWorkflowRunCoverage(ctx, run.ID); err != nil {
+				log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to compute coverage of workflow run %s", run.ID))
+			}
+		})
+
 		// Try to unlocked workflow or jobs
 		if run.Concurrency != nil {
 			concurrencyKey := getConcurrencyUniqueKey(*run.Concurrency, run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName)
@@ -1530,9 +1568,22 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 		}
 
 		// Compute job matrix strategy
-		matrixPermutation, msInfo := generateMatrixPermutation(ctx, runJobContext, run, jobDef, jobToTrigger.Status)
-		if msInfo != nil {
-			return nil, nil, nil, []sdk.V2WorkflowRunInfo{*msInfo}, false, err
+		matrixPermutation := make([]map[string]string, 0)
+		if !jobToTrigger.Status.IsTerminated() {
+			permutations, interpolatedMatrix, err := sdk.GenerateMatrixPermutation(ctx, runJobContext, jobDef.Strategy)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				return nil, nil, nil, []sdk.V2WorkflowRunInfo{{
+					WorkflowRunID: run.ID,
+					IssuedAt:      time.Now(),
+					Level:         sdk.WorkflowRunInfoLevelError,
+					Message:       sdk.ExtractHTTPError(err).From,
+				}}, false, nil
+			}
+			matrixPermutation = permutations
+			for k := range interpolatedMatrix {
+				jobDef.Strategy.Matrix[k] = interpolatedMatrix[k]
+			}
 		}
 
 		// No matrixed jobs or templated job skipped
@@ -1554,7 +1605,9 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 				RunNumber:          run.RunNumber,
 				RunAttempt:         run.RunAttempt,
 				Initiator:          wrEnqueue.Initiator,
+				Debug:              run.RunJobEvent.Debug(run.RunAttempt, jobID),
 			}
+			runJob.TraceParent = run.JobTraceParent(runJob.ID)
 			if jobDef.From == "" && len(jobDef.Steps) == 0 && !jobToTrigger.Status.IsTerminated() {
 				runJob.Status = sdk.V2WorkflowRunJobStatusSuccess
 			}
@@ -1694,12 +1747,16 @@ func prepareRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Store, proj
 		for _, rjUnlocked := range objsToUnlocked {
 			if rj.ID == rjUnlocked.ID {
 
+				msg := "Job has been unlocked"
+				if rj.Concurrency.Scope.IsShared() {
+					msg = fmt.Sprintf("Job has been unlocked and acquired %s lock %q", rj.Concurrency.Scope, rj.Concurrency.Name)
+				}
 				runJobsInfo[rj.ID] = sdk.V2WorkflowRunJobInfo{
 					WorkflowRunID:    rj.WorkflowRunID,
 					WorkflowRunJobID: rj.ID,
 					IssuedAt:         time.Now(),
 					Level:            sdk.WorkflowRunInfoLevelInfo,
-					Message:          "Job has been unlocked",
+					Message:          msg,
 				}
 				rj.Queued = time.Now()
 				rj.Status = sdk.V2WorkflowRunJobStatusWaiting
@@ -2141,7 +2198,9 @@ func createMatrixedRunJobs(ctx context.Context, db *gorp.DbMap, store cache.Stor
 			RunAttempt:         run.RunAttempt,
 			Matrix:             sdk.JobMatrix{},
 			Initiator:          data.wrEnqueue.Initiator,
+			Debug:              run.RunJobEvent.Debug(run.RunAttempt, data.jobID),
 		}
+		runJob.TraceParent = run.JobTraceParent(runJob.ID)
 		if len(data.jobToTrigger.Job.Steps) == 0 && !data.jobToTrigger.Status.IsTerminated() {
 			runJob.Status = sdk.V2WorkflowRunJobStatusSuccess
 		}
@@ -2233,120 +2292,6 @@ func searchPermutationToTrigger(_ context.Context, permutations []map[string]str
 	return permutationToTrigger
 }
 
-func generateMatrixPermutation(ctx context.Context, rootJobContext sdk.WorkflowRunJobsContext, run *sdk.V2WorkflowRun, jobDef sdk.V2Job, status sdk.V2WorkflowRunJobStatus) ([]map[string]string, *sdk.V2WorkflowRunInfo) {
-	if status.IsTerminated() {
-		return make([]map[string]string, 0), nil
-	}
-	keys := make([]string, 0)
-	interpolatedMatrix := make(map[string][]string)
-	if jobDef.Strategy != nil && len(jobDef.Strategy.Matrix) > 0 {
-		bts, _ := json.Marshal(rootJobContext)
-		var mapContexts map[string]interface{}
-		_ = json.Unmarshal(bts, &mapContexts) // error cannot happen here
-
-		ap := sdk.NewActionParser(mapContexts, sdk.DefaultFuncs)
-
-		for k, v := range jobDef.Strategy.Matrix {
-			keys = append(keys, k)
-
-			matrixValues := make([]string, 0)
-			if slice, ok := v.([]interface{}); ok {
-				for _, sliceValue := range slice {
-					valueString, ok := sliceValue.(string)
-					if !ok {
-						msg := &sdk.V2WorkflowRunInfo{
-							WorkflowRunID: run.ID,
-							IssuedAt:      time.Now(),
-							Level:         sdk.WorkflowRunInfoLevelError,
-							Message:       fmt.Sprintf("matrix value %v is not a string", sliceValue),
-						}
-						return nil, msg
-					}
-
-					interpolatedValue, err := ap.InterpolateToString(ctx, valueString)
-					if err != nil {
-						log.ErrorWithStackTrace(ctx, err)
-						msg := &sdk.V2WorkflowRunInfo{
-							WorkflowRunID: run.ID,
-							IssuedAt:      time.Now(),
-							Level:         sdk.WorkflowRunInfoLevelError,
-							Message:       fmt.Sprintf("unable to interpolate matrix value %s: %v", valueString, err),
-						}
-						return nil, msg
-					}
-					matrixValues = append(matrixValues, interpolatedValue)
-				}
-			} else if valueString, ok := v.(string); ok {
-				interpolatedValue, err := ap.Interpolate(ctx, valueString)
-				if err != nil {
-					log.ErrorWithStackTrace(ctx, err)
-					msg := &sdk.V2WorkflowRunInfo{
-						WorkflowRunID: run.ID,
-						IssuedAt:      time.Now(),
-						Level:         sdk.WorkflowRunInfoLevelError,
-						Message:       fmt.Sprintf("unable to interpolate %s: %v", valueString, err),
-					}
-					return nil, msg
-				}
-				interpoaltedSlice, ok := interpolatedValue.([]interface{})
-				if !ok {
-					msg := &sdk.V2WorkflowRunInfo{
-						WorkflowRunID: run.ID,
-						IssuedAt:      time.Now(),
-						Level:         sdk.WorkflowRunInfoLevelError,
-						Message:       fmt.Sprintf("interpolated matrix is not a string slice, got %T", interpolatedValue),
-					}
-					return nil, msg
-				}
-				stringsValue := make([]string, 0, len(interpoaltedSlice))
-				for _, vle := range interpoaltedSlice {
-					stringsValue = append(stringsValue, fmt.Sprintf("%v", vle))
-				}
-				matrixValues = stringsValue
-			} else {
-				msg := &sdk.V2WorkflowRunInfo{
-					WorkflowRunID: run.ID,
-					IssuedAt:      time.Now(),
-					Level:         sdk.WorkflowRunInfoLevelError,
-					Message:       fmt.Sprintf("unable to use matrix key %s of type %T", k, v),
-				}
-				return nil, msg
-			}
-			interpolatedMatrix[k] = matrixValues
-
-		}
-	}
-
-	alls := make([]map[string]string, 0)
-	if jobDef.Strategy != nil && len(interpolatedMatrix) > 0 {
-		generateMatrix(interpolatedMatrix, keys, 0, make(map[string]string), &alls)
-		for k := range interpolatedMatrix {
-			jobDef.Strategy.Matrix[k] = interpolatedMatrix[k]
-		}
-	}
-
-	return alls, nil
-}
-
-func generateMatrix(matrix map[string][]string, keys []string, keyIndex int, current map[string]string, alls *[]map[string]string) {
-	if len(current) == len(keys) {
-		combinationCopy := make(map[string]string)
-		for k, v := range current {
-			combinationCopy[k] = v
-		}
-		*alls = append(*alls, combinationCopy)
-		return
-	}
-
-	key := keys[keyIndex]
-	values := matrix[key]
-	for _, value := range values {
-		current[key] = value
-		generateMatrix(matrix, keys, keyIndex+1, current, alls)
-		delete(current, key)
-	}
-}
-
 type JobToTrigger struct {
 	Status sdk.V2WorkflowRunJobStatus
 	Job    sdk.V2Job
@@ -2430,7 +2375,7 @@ func retrieveJobToQueue(ctx context.Context, db *gorp.DbMap, wrEnqueue sdk.V2Wor
 		}
 
 		// Build job context
-		jobContext := buildContextForJob(ctx, run.WorkflowData.Workflow, runJobsContexts, run.Contexts, stages, jobID)
+		jobContext := buildContextForJob(ctx, run.WorkflowData.Workflow, runJobsContexts, run.Contexts, jobID)
 
 		canBeQueued, infos, err := checkJob(ctx, db, wrEnqueue, *run, jobID, &jobDef, jobContext)
 		runInfos = append(runInfos, infos...)
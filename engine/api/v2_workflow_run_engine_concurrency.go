@@ -3,13 +3,17 @@ package api
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-gorp/gorp"
 	"github.com/rockbears/log"
 
+	"github.com/ovh/cds/engine/api/concurrency"
 	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/organization"
 	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/region"
 	"github.com/ovh/cds/engine/api/workflow_v2"
 	"github.com/ovh/cds/engine/gorpmapper"
 	"github.com/ovh/cds/sdk"
@@ -169,12 +173,20 @@ func manageJobConcurrency(ctx context.Context, db *gorp.DbMap, run sdk.V2Workflo
 
 		if !canRun {
 			runJob.Status = sdk.V2WorkflowRunJobStatusBlocked
+			msg := fmt.Sprintf("Job locked due to concurrency %q", runJob.Concurrency.Name)
+			if concurrencyDef.Scope.IsShared() {
+				holders, err := sharedConcurrencyHolders(ctx, db, concurrencyDef)
+				if err != nil {
+					return nil, err
+				}
+				msg = fmt.Sprintf("Job waiting for %s lock %q held by %s", concurrencyDef.Scope, concurrencyDef.Name, holders)
+			}
 			return &sdk.V2WorkflowRunJobInfo{
 				WorkflowRunID:    runJob.WorkflowRunID,
 				WorkflowRunJobID: runJob.ID,
 				Level:            sdk.WorkflowRunInfoLevelInfo,
 				IssuedAt:         time.Now(),
-				Message:          fmt.Sprintf("Job locked due to concurrency %q", runJob.Concurrency.Name),
+				Message:          msg,
 			}, nil
 		}
 
@@ -190,6 +202,16 @@ func manageJobConcurrency(ctx context.Context, db *gorp.DbMap, run sdk.V2Workflo
 				}, nil
 			}
 		}
+
+		if concurrencyDef.Scope.IsShared() {
+			return &sdk.V2WorkflowRunJobInfo{
+				WorkflowRunID:    runJob.WorkflowRunID,
+				WorkflowRunJobID: runJob.ID,
+				Level:            sdk.WorkflowRunInfoLevelInfo,
+				IssuedAt:         time.Now(),
+				Message:          fmt.Sprintf("Job acquired %s lock %q", concurrencyDef.Scope, concurrencyDef.Name),
+			}, nil
+		}
 	}
 	return nil, nil
 }
@@ -245,16 +267,24 @@ func canRunWithConcurrency(ctx context.Context, concurrencyDef sdk.V2RunConcurre
 	var ruleToApply *sdk.V2RunConcurrency
 	var nbRunJobBuilding, nbRunJobBlocked int64
 	var err error
-	if concurrencyDef.Scope == sdk.V2RunConcurrencyScopeProject {
+	switch {
+	case concurrencyDef.Scope == sdk.V2RunConcurrencyScopeProject:
 		ruleToApply, nbRunJobBuilding, nbRunJobBlocked, err = checkProjectScopedConcurrency(ctx, db, run.ProjectKey, concurrencyDef.WorkflowConcurrency)
-	} else {
+	case concurrencyDef.Scope.IsShared():
+		ruleToApply, nbRunJobBuilding, nbRunJobBlocked, err = checkSharedScopedConcurrency(ctx, db, concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.WorkflowConcurrency)
+	default:
 		ruleToApply, nbRunJobBuilding, nbRunJobBlocked, err = checkWorkflowScopedConcurrency(ctx, db, run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName, concurrencyDef.WorkflowConcurrency)
 	}
 	if err != nil {
 		return false, err
 	}
 
-	poolIsFull := nbRunJobBlocked+nbRunJobBuilding+concurrencyUnlockedCount[concurrencyDef.Name] >= ruleToApply.Pool
+	unlockedCountKey := concurrencyDef.Name
+	if concurrencyDef.Scope.IsShared() {
+		unlockedCountKey = fmt.Sprintf("%s:%s:%s", concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.Name)
+	}
+
+	poolIsFull := nbRunJobBlocked+nbRunJobBuilding+concurrencyUnlockedCount[unlockedCountKey] >= ruleToApply.Pool
 
 	if !ruleToApply.CancelInProgress {
 		if poolIsFull {
@@ -266,7 +296,7 @@ func canRunWithConcurrency(ctx context.Context, concurrencyDef sdk.V2RunConcurre
 		}
 	} else {
 		// Manage cancel-in-progress
-		objectsToCancelled, err := retrieveConcurrencyObjectToCancelled(ctx, db, run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName, currentConcurrencyObject, ruleToApply, nbRunJobBuilding, concurrencyUnlockedCount[concurrencyDef.Name])
+		objectsToCancelled, err := retrieveConcurrencyObjectToCancelled(ctx, db, run.ProjectKey, run.VCSServer, run.Repository, run.WorkflowName, currentConcurrencyObject, ruleToApply, nbRunJobBuilding, concurrencyUnlockedCount[unlockedCountKey])
 		if err != nil {
 			return false, err
 		}
@@ -279,7 +309,7 @@ func canRunWithConcurrency(ctx context.Context, concurrencyDef sdk.V2RunConcurre
 	if has {
 		return false, nil
 	}
-	concurrencyUnlockedCount[concurrencyDef.Name]++
+	concurrencyUnlockedCount[unlockedCountKey]++
 	return true, nil
 }
 
@@ -294,6 +324,11 @@ func retrieveRunObjectsToUnLocked(ctx context.Context, db *gorp.DbMap, projKey,
 		if err != nil {
 			return nil, nil, err
 		}
+	case sdk.V2RunConcurrencyScopeOrganization, sdk.V2RunConcurrencyScopeRegion:
+		ruleToApply, nbBuilding, _, err = checkSharedScopedConcurrency(ctx, db, concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.WorkflowConcurrency)
+		if err != nil {
+			return nil, nil, err
+		}
 	default:
 		ruleToApply, nbBuilding, _, err = checkWorkflowScopedConcurrency(ctx, db, projKey, vcsServer, repository, workflowName, concurrencyDef.WorkflowConcurrency)
 		if err != nil {
@@ -313,6 +348,8 @@ func retrieveRunObjectsToUnLocked(ctx context.Context, db *gorp.DbMap, projKey,
 		switch concurrencyDef.Scope {
 		case sdk.V2RunConcurrencyScopeProject:
 			concurrencyRunObjects, err = workflow_v2.LoadNewestRunJobWithProjectScopedConcurrency(ctx, db, projKey, concurrencyDef.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nil)
+		case sdk.V2RunConcurrencyScopeOrganization, sdk.V2RunConcurrencyScopeRegion:
+			concurrencyRunObjects, err = workflow_v2.LoadNewestRunJobWithSharedConcurrency(ctx, db, concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nil)
 		default:
 			concurrencyRunObjects, err = workflow_v2.LoadNewestRunJobWithWorkflowScopedConcurrency(ctx, db, projKey, vcsServer, repository, workflowName, concurrencyDef.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nil)
 		}
@@ -361,6 +398,17 @@ func retrieveRunObjectsToUnLocked(ctx context.Context, db *gorp.DbMap, projKey,
 			}
 			toUnlocked = append(toUnlocked, rjs...)
 		}
+	case sdk.V2RunConcurrencyScopeOrganization, sdk.V2RunConcurrencyScopeRegion:
+		var runObjToUnlock []workflow_v2.ConcurrencyObject
+		if ruleToApply.Order == sdk.ConcurrencyOrderOldestFirst {
+			runObjToUnlock, err = workflow_v2.LoadOldestRunJobWithSharedConcurrency(ctx, db, concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nbToUnlocked)
+		} else {
+			runObjToUnlock, err = workflow_v2.LoadNewestRunJobWithSharedConcurrency(ctx, db, concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nbToUnlocked)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		toUnlocked = append(toUnlocked, runObjToUnlock...)
 	default:
 		if ruleToApply.Order == sdk.ConcurrencyOrderOldestFirst {
 			// Load oldest
@@ -396,6 +444,8 @@ func retrieveConcurrencyObjectToCancelled(ctx context.Context, db gorp.SqlExecut
 	switch ruleToApply.Scope {
 	case sdk.V2RunConcurrencyScopeProject:
 		buildingObjects, err = workflow_v2.LoadOldestRunJobWithProjectScopedConcurrency(ctx, db, projKey, ruleToApply.Name, sdk.RunningStatusesV2WorkflowRunJob, sdk.V2WorkflowRunStatusBuilding, nbToCancelled)
+	case sdk.V2RunConcurrencyScopeOrganization, sdk.V2RunConcurrencyScopeRegion:
+		buildingObjects, err = workflow_v2.LoadOldestRunJobWithSharedConcurrency(ctx, db, ruleToApply.Scope, ruleToApply.Key, ruleToApply.Name, sdk.RunningStatusesV2WorkflowRunJob, sdk.V2WorkflowRunStatusBuilding, nbToCancelled)
 	default:
 		buildingObjects, err = workflow_v2.LoadOldestRunJobWithWorkflowScopedConcurrency(ctx, db, projKey, vcs, repo, workflow, ruleToApply.Name, sdk.RunningStatusesV2WorkflowRunJob, sdk.V2WorkflowRunStatusBuilding, nbToCancelled)
 	}
@@ -412,6 +462,8 @@ func retrieveConcurrencyObjectToCancelled(ctx context.Context, db gorp.SqlExecut
 		switch ruleToApply.Scope {
 		case sdk.V2RunConcurrencyScopeProject:
 			blockedObjects, err = workflow_v2.LoadOldestRunJobWithProjectScopedConcurrency(ctx, db, projKey, ruleToApply.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nbToCancelled)
+		case sdk.V2RunConcurrencyScopeOrganization, sdk.V2RunConcurrencyScopeRegion:
+			blockedObjects, err = workflow_v2.LoadOldestRunJobWithSharedConcurrency(ctx, db, ruleToApply.Scope, ruleToApply.Key, ruleToApply.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nbToCancelled)
 		default:
 			blockedObjects, err = workflow_v2.LoadOldestRunJobWithWorkflowScopedConcurrency(ctx, db, projKey, vcs, repo, workflow, ruleToApply.Name, []string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.V2WorkflowRunStatusBlocked, nbToCancelled)
 		}
@@ -471,6 +523,46 @@ func checkWorkflowScopedConcurrency(ctx context.Context, db gorp.SqlExecutor, pr
 	return &sdk.V2RunConcurrency{WorkflowConcurrency: ruleToApply, Scope: sdk.V2RunConcurrencyScopeWorkflow}, nbRunJobBuilding, nbBlockedRunJobs, nil
 }
 
+// Retrieve rule for an organization or a region scoped concurrency
+func checkSharedScopedConcurrency(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, currentConcurrencyDef sdk.WorkflowConcurrency) (*sdk.V2RunConcurrency, int64, int64, error) {
+	nbRunJobBuilding, err := workflow_v2.CountRunningWithSharedConcurrency(ctx, db, scope, key, currentConcurrencyDef.Name)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	nbBlockedRunJobs, err := workflow_v2.CountBlockedWithSharedConcurrency(ctx, db, scope, key, currentConcurrencyDef.Name)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Check if rules are differents between ongoing job run
+	ongoingRules, err := workflow_v2.LoadSharedConcurrencyRules(ctx, db, scope, key, currentConcurrencyDef.Name)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	ruleToApply := mergeConcurrencyRules(ongoingRules, currentConcurrencyDef)
+	return &sdk.V2RunConcurrency{WorkflowConcurrency: ruleToApply, Scope: scope, Key: key}, nbRunJobBuilding, nbBlockedRunJobs, nil
+}
+
+// sharedConcurrencyHolders returns a readable list of the runs holding an organization or a region concurrency
+func sharedConcurrencyHolders(ctx context.Context, db gorp.SqlExecutor, concurrencyDef sdk.V2RunConcurrency) (string, error) {
+	runObjects, err := workflow_v2.LoadSharedConcurrencyRunObjects(ctx, db, concurrencyDef.Scope, concurrencyDef.Key, concurrencyDef.Name)
+	if err != nil {
+		return "", err
+	}
+	holders := make([]string, 0, len(runObjects))
+	for _, o := range runObjects {
+		if o.Status == string(sdk.V2WorkflowRunJobStatusBlocked) {
+			continue
+		}
+		holder := fmt.Sprintf("%s/%s #%d", o.ProjectKey, o.WorkflowName, o.RunNumber)
+		if o.JobName != "" {
+			holder += " " + o.JobName
+		}
+		holders = append(holders, holder)
+	}
+	return strings.Join(holders, ", "), nil
+}
+
 // Merge concurrency rule between all running jobs
 // Default is ConcurrencyOrderOldestFirst + min(pool) + CancelInProgress = false
 func mergeConcurrencyRules(rulesInDB []workflow_v2.ConcurrencyRule, refRule sdk.WorkflowConcurrency) sdk.WorkflowConcurrency {
@@ -492,7 +584,13 @@ func mergeConcurrencyRules(rulesInDB []workflow_v2.ConcurrencyRule, refRule sdk.
 	return mergedRule
 }
 
-func retrieveConcurrencyDefinition(ctx context.Context, db gorp.SqlExecutor, run sdk.V2WorkflowRun, concurrencyName string) (*sdk.V2RunConcurrency, error) {
+// Retrieve a concurrency definition. region is the region of the job, it is only used for region scoped concurrencies
+func retrieveConcurrencyDefinition(ctx context.Context, db gorp.SqlExecutor, run sdk.V2WorkflowRun, concurrencyName string, region string) (*sdk.V2RunConcurrency, error) {
+	// Search concurrency on the organization or the region
+	if sharedScope, sharedName, isShared := sdk.ParseSharedConcurrencyName(concurrencyName); isShared {
+		return retrieveSharedConcurrencyDefinition(ctx, db, run.ProjectKey, sharedScope, sharedName, region)
+	}
+
 	// Search concurrency rule on workflow
 	scope := sdk.V2RunConcurrencyScopeWorkflow
 	var jobConcurrencyDef *sdk.WorkflowConcurrency
@@ -528,6 +626,48 @@ func retrieveConcurrencyDefinition(ctx context.Context, db gorp.SqlExecutor, run
 	}, nil
 }
 
+func retrieveSharedConcurrencyDefinition(ctx context.Context, db gorp.SqlExecutor, projKey string, scope sdk.V2RunJobConcurrencyScope, name string, regionName string) (*sdk.V2RunConcurrency, error) {
+	var scopeID string
+	switch scope {
+	case sdk.V2RunConcurrencyScopeOrganization:
+		proj, err := project.Load(ctx, db, projKey)
+		if err != nil {
+			return nil, err
+		}
+		org, err := organization.LoadOrganizationByName(ctx, db, proj.Organization)
+		if err != nil {
+			return nil, err
+		}
+		scopeID = org.ID
+	case sdk.V2RunConcurrencyScopeRegion:
+		if regionName == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "region concurrency %q can only be used on jobs", name)
+		}
+		reg, err := region.LoadRegionByName(ctx, db, regionName)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		scopeID = reg.ID
+	}
+
+	sharedConcurrency, err := concurrency.LoadByName(ctx, db, scope, scopeID, name)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sdk.V2RunConcurrency{
+		Scope:               scope,
+		Key:                 scopeID,
+		Timeout:             sharedConcurrency.Timeout,
+		WorkflowConcurrency: sharedConcurrency.ToWorkflowConcurrency(),
+	}, nil
+}
+
 func (api *API) cancelRunObjects(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, runObjectsToCancel map[string]workflow_v2.ConcurrencyObject) error {
 	runCancelled := make([]sdk.V2WorkflowRun, 0)
 	runJobCancelled := make([]sdk.V2WorkflowRunJob, 0)
@@ -145,31 +145,12 @@ nextjob:
 	return jobsContext, gatesContext
 }
 
-func buildContextForJob(ctx context.Context, workflow sdk.V2Workflow, runJobsContexts sdk.JobsResultContext, runContext sdk.WorkflowRunContext, stages sdk.WorkflowRunStages, jobID string) sdk.WorkflowRunJobsContext {
+func buildContextForJob(ctx context.Context, workflow sdk.V2Workflow, runJobsContexts sdk.JobsResultContext, runContext sdk.WorkflowRunContext, jobID string) sdk.WorkflowRunJobsContext {
 	jobsContext := sdk.JobsResultContext{}
-	buildAncestorJobContext(ctx, jobID, workflow, runJobsContexts, stages, jobsContext)
+	buildAncestorJobContext(ctx, jobID, workflow, runJobsContexts, jobsContext)
 
-	jobDef := workflow.Jobs[jobID]
 	needsContext := sdk.NeedsContext{}
-
-	var jobNeeds []string
-	if len(jobDef.Needs) > 0 {
-		jobNeeds = jobDef.Needs
-
-	} else if jobDef.Stage != "" {
-		jobNeeds = make([]string, 0)
-		// add all final jobs from parent stages
-		neededStages := workflow.Stages[jobDef.Stage].Needs
-		for _, n := range neededStages {
-			for jobID, jobInStage := range stages[n].Jobs {
-				if jobInStage.IsFinal {
-					jobNeeds = append(jobNeeds, jobID)
-				}
-			}
-		}
-	}
-
-	for _, n := range jobNeeds {
+	for _, n := range sdk.WorkflowJobNeeds(workflow, jobID) {
 		if j, has := jobsContext[n]; has {
 			needContext := sdk.NeedContext{
 				Result:  j.Result,
@@ -191,25 +172,10 @@ func buildContextForJob(ctx context.Context, workflow sdk.V2Workflow, runJobsCon
 	return currentJobContext
 }
 
-func buildAncestorJobContext(ctx context.Context, jobID string, workflow sdk.V2Workflow, runJobsContext sdk.JobsResultContext, stages sdk.WorkflowRunStages, currentJobContext sdk.JobsResultContext) {
-	jobDef := workflow.Jobs[jobID]
-	if len(jobDef.Needs) == 0 && jobDef.Stage != "" {
-		// add all final jobs from parent stages
-		neededStages := workflow.Stages[jobDef.Stage].Needs
-		for _, n := range neededStages {
-			for jobID, jobInStage := range stages[n].Jobs {
-				if jobInStage.IsFinal {
-					jobCtx := runJobsContext[jobID]
-					currentJobContext[jobID] = jobCtx
-					buildAncestorJobContext(ctx, jobID, workflow, runJobsContext, stages, currentJobContext)
-				}
-			}
-		}
-	}
-
-	for _, n := range jobDef.Needs {
+func buildAncestorJobContext(ctx context.Context, jobID string, workflow sdk.V2Workflow, runJobsContext sdk.JobsResultContext, currentJobContext sdk.JobsResultContext) {
+	for _, n := range sdk.WorkflowJobNeeds(workflow, jobID) {
 		jobCtx := runJobsContext[n]
 		currentJobContext[n] = jobCtx
-		buildAncestorJobContext(ctx, n, workflow, runJobsContext, stages, currentJobContext)
+		buildAncestorJobContext(ctx, n, workflow, runJobsContext, currentJobContext)
 	}
 }
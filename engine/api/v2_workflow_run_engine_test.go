@@ -94,7 +94,7 @@ func TestJobConditionSuccess(t *testing.T) {
 				Needs: tt.needs,
 			}
 			jobDef := run.WorkflowData.Workflow.Jobs["job4"]
-			currentJobContext := buildContextForJob(context.TODO(), run.WorkflowData.Workflow, jobsContext, sdk.WorkflowRunContext{}, nil, "job4")
+			currentJobContext := buildContextForJob(context.TODO(), run.WorkflowData.Workflow, jobsContext, sdk.WorkflowRunContext{}, "job4")
 			initiator := sdk.V2Initiator{
 				UserID:         admin.ID,
 				User:           admin.Initiator(),
@@ -163,7 +163,7 @@ func TestJobConditionReviewers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			jobDef := run.WorkflowData.Workflow.Jobs["job1"]
-			currentJobContext := buildContextForJob(context.TODO(), run.WorkflowData.Workflow, jobsContext, sdk.WorkflowRunContext{}, nil, "job1")
+			currentJobContext := buildContextForJob(context.TODO(), run.WorkflowData.Workflow, jobsContext, sdk.WorkflowRunContext{}, "job1")
 			initiator := sdk.V2Initiator{
 				UserID:         tt.u.ID,
 				User:           tt.u.Initiator(),
@@ -216,7 +216,7 @@ func TestBuildCurrentJobContext(t *testing.T) {
 	wf := sdk.V2Workflow{Jobs: allJobs}
 
 	currentJobContext := sdk.JobsResultContext{}
-	buildAncestorJobContext(context.TODO(), "job6", wf, jobsContext, nil, currentJobContext)
+	buildAncestorJobContext(context.TODO(), "job6", wf, jobsContext, currentJobContext)
 
 	require.Equal(t, 3, len(currentJobContext))
 	require.Equal(t, sdk.V2WorkflowRunJobStatusFail, currentJobContext["job1"].Result)
@@ -302,13 +302,13 @@ func TestBuildCurrentJobContextWithStages(t *testing.T) {
 	}
 
 	currentJobContext := sdk.JobsResultContext{}
-	buildAncestorJobContext(context.TODO(), "job6", wf, jobsContext, stages, currentJobContext)
+	buildAncestorJobContext(context.TODO(), "job6", wf, jobsContext, currentJobContext)
 
 	require.Equal(t, 5, len(currentJobContext))
 	_, has := currentJobContext["job7"]
 	require.False(t, has)
 
-	fullContext := buildContextForJob(context.TODO(), run.WorkflowData.Workflow, currentJobContext, sdk.WorkflowRunContext{}, stages, "job6")
+	fullContext := buildContextForJob(context.TODO(), run.WorkflowData.Workflow, currentJobContext, sdk.WorkflowRunContext{}, "job6")
 	require.Equal(t, 3, len(fullContext.Needs))
 	_, has = fullContext.Needs["job5"]
 	require.True(t, has)
@@ -318,39 +318,6 @@ func TestBuildCurrentJobContextWithStages(t *testing.T) {
 	require.True(t, has)
 }
 
-func TestGenerateMatrix(t *testing.T) {
-	matrix := map[string][]string{
-		"foo": {"foo1", "foo2"},
-		"bar": {"bar1", "bar2"},
-	}
-	all := make([]map[string]string, 0)
-	generateMatrix(matrix, []string{"foo", "bar"}, 0, map[string]string{}, &all)
-
-	require.Equal(t, 4, len(all))
-	foo1bar1 := false
-	foo1bar2 := false
-	foo2bar1 := false
-	foo2bar2 := false
-	for _, m := range all {
-		if m["foo"] == "foo1" && m["bar"] == "bar1" {
-			foo1bar1 = true
-		}
-		if m["foo"] == "foo1" && m["bar"] == "bar2" {
-			foo1bar2 = true
-		}
-		if m["foo"] == "foo2" && m["bar"] == "bar1" {
-			foo2bar1 = true
-		}
-		if m["foo"] == "foo2" && m["bar"] == "bar2" {
-			foo2bar2 = true
-		}
-	}
-	require.True(t, foo1bar1)
-	require.True(t, foo1bar2)
-	require.True(t, foo2bar1)
-	require.True(t, foo2bar2)
-}
-
 func TestWorkflowTrigger1Job(t *testing.T) {
 	api, db, _ := newTestAPI(t)
 
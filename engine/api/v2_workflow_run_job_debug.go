@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/event_v2"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/engine/websocket"
+	"github.com/ovh/cds/sdk"
+)
+
+// The messages of a debug session are relayed through the cache pub/sub,
+// so the worker and the user can be connected to different API instances.
+const (
+	debugSessionToWorker = "worker"
+	debugSessionToUser   = "user"
+)
+
+func debugSessionChannel(runJobID, to string) string {
+	return cache.Key("api:workflow:debug", runJobID, to)
+}
+
+// getJobRunDebugSessionHandler is called by a worker paused in debug mode
+func (api *API) getJobRunDebugSessionHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.isWorker, api.jobRunUpdate),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			jobRunID := vars["runJobID"]
+
+			runJob, err := workflow_v2.LoadRunJobByID(ctx, api.mustDB(), jobRunID)
+			if err != nil {
+				return err
+			}
+			if !runJob.Debug {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "debug mode is not enabled on job %s", runJob.JobID)
+			}
+
+			c, err := websocket.Upgrader.Upgrade(w, req, nil)
+			if err != nil {
+				service.WriteError(ctx, w, req, sdk.NewErrorWithStack(err, sdk.ErrWebsocketUpgrade))
+				return nil
+			}
+			defer c.Close() // nolint
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			wsClient := websocket.NewClient(c)
+			wsClient.OnMessage(func(m []byte) {
+				if err := api.Cache.Publish(ctx, debugSessionChannel(runJob.ID, debugSessionToUser), string(m)); err != nil {
+					log.ErrorWithStackTrace(ctx, err)
+				}
+			})
+			if err := api.relayDebugSession(ctx, wsClient, debugSessionChannel(runJob.ID, debugSessionToWorker)); err != nil {
+				return err
+			}
+			return wsClient.Listen(ctx, api.GoRoutines)
+		}
+}
+
+// getWorkflowRunJobDebugSessionHandler opens a terminal session on the worker of a job paused in debug mode
+func (api *API) getWorkflowRunJobDebugSessionHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.workflowDebug),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+			jobRunID := vars["jobRunID"]
+
+			u := getUserConsumer(ctx)
+			if u == nil {
+				return sdk.WithStack(sdk.ErrForbidden)
+			}
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), pKey, workflowRunID)
+			if err != nil {
+				return err
+			}
+			runJob, err := workflow_v2.LoadRunJobByRunIDAndID(ctx, api.mustDB(), wr.ID, jobRunID)
+			if err != nil {
+				return err
+			}
+			if !runJob.Debug {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "debug mode is not enabled on job %s, restart it in debug mode", runJob.JobID)
+			}
+			if runJob.Status != sdk.V2WorkflowRunJobStatusBuilding {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "job %s is not running", runJob.JobID)
+			}
+
+			c, err := websocket.Upgrader.Upgrade(w, req, nil)
+			if err != nil {
+				service.WriteError(ctx, w, req, sdk.NewErrorWithStack(err, sdk.ErrWebsocketUpgrade))
+				return nil
+			}
+			defer c.Close() // nolint
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			usr := *u.AuthConsumerUser.AuthentifiedUser
+			api.auditDebugSession(ctx, *runJob, usr, sdk.EventRunJobDebugSessionStarted, fmt.Sprintf("Debug session opened by %s", usr.Username))
+			defer api.auditDebugSession(context.Background(), *runJob, usr, sdk.EventRunJobDebugSessionEnded, fmt.Sprintf("Debug session closed by %s", usr.Username))
+
+			toWorker := debugSessionChannel(runJob.ID, debugSessionToWorker)
+			publishToWorker := func(ctx context.Context, msg sdk.V2JobDebugMessage) {
+				msg.Username = usr.Username
+				btes, _ := json.Marshal(msg)
+				if err := api.Cache.Publish(ctx, toWorker, string(btes)); err != nil {
+					log.ErrorWithStackTrace(ctx, err)
+				}
+			}
+
+			wsClient := websocket.NewClient(c)
+			wsClient.OnMessage(func(m []byte) {
+				var msg sdk.V2JobDebugMessage
+				if err := sdk.JSONUnmarshal(m, &msg); err != nil {
+					log.Warn(ctx, "unable to read debug session message: %v", err)
+					return
+				}
+				// Users can only write to the shell or resume the job
+				switch msg.Type {
+				case sdk.V2JobDebugMessageTypeInput, sdk.V2JobDebugMessageTypeContinue:
+					publishToWorker(ctx, msg)
+				}
+			})
+			if err := api.relayDebugSession(ctx, wsClient, debugSessionChannel(runJob.ID, debugSessionToUser)); err != nil {
+				return err
+			}
+
+			publishToWorker(ctx, sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeAttach})
+			defer publishToWorker(context.Background(), sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeDetach})
+
+			return wsClient.Listen(ctx, api.GoRoutines)
+		}
+}
+
+// relayDebugSession sends to the websocket client all the messages published on the given channel until the context is done
+func (api *API) relayDebugSession(ctx context.Context, wsClient websocket.Client, channel string) error {
+	pubSub, err := api.Cache.Subscribe(channel)
+	if err != nil {
+		return sdk.WrapError(err, "unable to subscribe to %s", channel)
+	}
+	api.GoRoutines.Exec(ctx, "relayDebugSession-"+wsClient.UUID(), func(ctx context.Context) {
+		defer pubSub.Unsubscribe(context.Background(), channel) // nolint
+		for {
+			msg, err := pubSub.GetMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warn(ctx, "relayDebugSession> cannot get message from pubsub %s: %v", channel, err)
+				continue
+			}
+			if err := wsClient.Send(json.RawMessage(msg)); err != nil {
+				log.Debug(ctx, "relayDebugSession> can't send to client %s: %v", wsClient.UUID(), err)
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// auditDebugSession keeps a trace of the session in the job infos and in the events
+func (api *API) auditDebugSession(ctx context.Context, runJob sdk.V2WorkflowRunJob, u sdk.AuthentifiedUser, eventType sdk.EventType, message string) {
+	event_v2.PublishRunJobDebugSessionEvent(ctx, api.Cache, eventType, runJob, u)
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, sdk.WithStack(err))
+		return
+	}
+	defer tx.Rollback() // nolint
+	info := sdk.V2WorkflowRunJobInfo{
+		WorkflowRunID:    runJob.WorkflowRunID,
+		WorkflowRunJobID: runJob.ID,
+		IssuedAt:         time.Now(),
+		Level:            sdk.WorkflowRunInfoLevelWarning,
+		Message:          message,
+	}
+	if err := workflow_v2.InsertRunJobInfo(ctx, tx, &info); err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		log.ErrorWithStackTrace(ctx, sdk.WithStack(err))
+	}
+}
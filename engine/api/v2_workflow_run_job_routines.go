@@ -125,6 +125,36 @@ func (api *API) StopDeadJobs(ctx context.Context) {
 	}
 }
 
+// StopSharedConcurrencyTimeouts fails the run jobs that wait too long for an organization or a region concurrency
+func (api *API) StopSharedConcurrencyTimeouts(ctx context.Context) {
+	tickSharedConcurrencyTimeouts := time.NewTicker(1 * time.Minute)
+	defer tickSharedConcurrencyTimeouts.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "%v", ctx.Err())
+			}
+			return
+		case <-tickSharedConcurrencyTimeouts.C:
+			jobs, err := workflow_v2.LoadBlockedRunJobsWithSharedConcurrencyTimeout(ctx, api.mustDB())
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				continue
+			}
+			for i := range jobs {
+				timeout, err := time.ParseDuration(jobs[i].Concurrency.Timeout)
+				if err != nil || time.Since(jobs[i].Queued) < timeout {
+					continue
+				}
+				if err := api.failSharedConcurrencyTimeoutRunJob(ctx, api.Cache, api.mustDB(), jobs[i].ID, timeout); err != nil {
+					log.ErrorWithStackTrace(ctx, err)
+				}
+			}
+		}
+	}
+}
+
 func (api *API) ReEnqueueScheduledJobs(ctx context.Context) {
 	tickScheduledJob := time.NewTicker(1 * time.Minute)
 	defer tickScheduledJob.Stop()
@@ -223,6 +253,72 @@ func (api *API) failOldWaitingRunJob(ctx context.Context, store cache.Store, db
 	return nil
 }
 
+func (api *API) failSharedConcurrencyTimeoutRunJob(ctx context.Context, store cache.Store, db *gorp.DbMap, runJobID string, timeout time.Duration) error {
+	ctx, next := telemetry.Span(ctx, "failSharedConcurrencyTimeoutRunJob")
+	defer next()
+
+	lockKey := cache.Key(jobLockKey, runJobID)
+	b, err := store.Lock(lockKey, 1*time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !b {
+		return nil
+	}
+	defer func() {
+		_ = store.Unlock(lockKey)
+	}()
+
+	runJob, err := workflow_v2.LoadRunJobByID(ctx, db, runJobID)
+	if err != nil {
+		return err
+	}
+	if runJob.Status != sdk.V2WorkflowRunJobStatusBlocked || runJob.Concurrency == nil {
+		return nil
+	}
+
+	ctx = context.WithValue(ctx, cdslog.WorkflowRunID, runJob.WorkflowRunID)
+	ctx = context.WithValue(ctx, cdslog.Workflow, runJob.WorkflowName)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	log.Info(ctx, "failSharedConcurrencyTimeoutRunJob: job %s/%s on workflow %s run %d waited more than %s for lock %s", runJob.JobID, runJob.ID, runJob.WorkflowName, runJob.RunNumber, timeout, runJob.Concurrency.Name)
+
+	runJob.Status = sdk.V2WorkflowRunJobStatusFail
+	now := time.Now()
+	runJob.Ended = &now
+	if err := workflow_v2.UpdateJobRun(ctx, tx, runJob); err != nil {
+		return err
+	}
+
+	info := sdk.V2WorkflowRunJobInfo{
+		WorkflowRunID:    runJob.WorkflowRunID,
+		IssuedAt:         time.Now(),
+		Level:            sdk.WorkflowRunInfoLevelError,
+		WorkflowRunJobID: runJob.ID,
+		Message:          fmt.Sprintf("%s lock %q not acquired after %s", runJob.Concurrency.Scope, runJob.Concurrency.Name, timeout),
+	}
+	if err := workflow_v2.InsertRunJobInfo(ctx, tx, &info); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	// Trigger workflow
+	api.EnqueueWorkflowRun(ctx, runJob.WorkflowRunID, runJob.Initiator, runJob.WorkflowName, runJob.RunNumber)
+
+	// Trigger other workflow regarding concurrency
+	api.manageEndConcurrency(runJob.ProjectKey, runJob.VCSServer, runJob.Repository, runJob.WorkflowName, runJob.WorkflowRunID, runJob.ID, runJob.Concurrency)
+
+	return nil
+}
+
 func reEnqueueScheduledJob(ctx context.Context, store cache.Store, db *gorp.DbMap, runJobID string) error {
 	ctx, next := telemetry.Span(ctx, "reEnqueueScheduledJob")
 	defer next()
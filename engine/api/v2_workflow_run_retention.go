@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rockbears/log"
 
+	"github.com/ovh/cds/engine/api/event_v2"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/purge"
 	"github.com/ovh/cds/engine/service"
@@ -153,6 +154,7 @@ func (api *API) putWorkflowRunRetentionHandler() ([]service.RbacChecker, service
 				return err
 			}
 
+			oldRetentions := retentionDB.Retentions
 			retentionDB.Retentions = projectRunRetention.Retentions
 
 			tx, err := api.mustDB().Begin()
@@ -164,9 +166,13 @@ func (api *API) putWorkflowRunRetentionHandler() ([]service.RbacChecker, service
 			if err := project.UpdateRunRetention(ctx, tx, retentionDB); err != nil {
 				return err
 			}
+			if err := recordAudit(ctx, tx, sdk.EventProjectRunRetentionUpdated, proj.Key, proj.Key, oldRetentions, retentionDB.Retentions); err != nil {
+				return err
+			}
 			if err := tx.Commit(); err != nil {
 				return err
 			}
+			event_v2.PublishProjectRunRetentionEvent(ctx, api.Cache, sdk.EventProjectRunRetentionUpdated, proj.Key, retentionDB.Retentions, *u.AuthConsumerUser.AuthentifiedUser)
 			return service.WriteJSON(w, retentionDB, http.StatusOK)
 		}
 }
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const defaultWorkflowTestCasesLimit = 20
+
+// indexRunResultTestCases stores each test case of a tests run result, to compute the history of the tests of a workflow
+func (api *API) indexRunResultTestCases(ctx context.Context, runJob sdk.V2WorkflowRunJob, runResult sdk.V2WorkflowRunResult) error {
+	if runResult.Type != sdk.V2WorkflowRunResultTypeTest {
+		return nil
+	}
+	detail, err := runResult.GetDetail()
+	if err != nil {
+		return err
+	}
+	testDetail, ok := detail.(*sdk.V2WorkflowRunResultTestDetail)
+	if !ok {
+		return nil
+	}
+
+	run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runJob.WorkflowRunID)
+	if err != nil {
+		return err
+	}
+	testCases := testDetail.TestsSuites.TestCases(sdk.V2WorkflowRunTestCase{
+		ProjectKey:       run.ProjectKey,
+		VCSServer:        run.VCSServer,
+		Repository:       run.Repository,
+		WorkflowName:     run.WorkflowName,
+		WorkflowRunID:    run.ID,
+		RunNumber:        run.RunNumber,
+		RunAttempt:       runJob.RunAttempt,
+		WorkflowRunJobID: runJob.ID,
+		JobID:            runJob.JobID,
+		GitRef:           run.Contexts.Git.Ref,
+		GitSha:           run.Contexts.Git.Sha,
+		IssuedAt:         runResult.IssuedAt,
+	})
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	if err := workflow_v2.ReplaceRunResultTestCases(ctx, tx, runResult.ID, testCases); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+func (api *API) indexRunResultTestCasesAsync(ctx context.Context, runJob sdk.V2WorkflowRunJob, runResult sdk.V2WorkflowRunResult) {
+	if runResult.Type != sdk.V2WorkflowRunResultTypeTest {
+		return
+	}
+	api.GoRoutines.Exec(ctx, "indexRunResultTestCases-"+runResult.ID, func(ctx context.Context) {
+		if err := api.indexRunResultTestCases(ctx, runJob, runResult); err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to index test cases of run result %s", runResult.ID))
+		}
+	})
+}
+
+// getWorkflowNamesFromVars returns the vcs server name, the repository name and the workflow name of the request
+func (api *API) getWorkflowNamesFromVars(ctx context.Context, vars map[string]string) (string, string, string, error) {
+	vcsIdentifier, err := url.PathUnescape(vars["vcsIdentifier"])
+	if err != nil {
+		return "", "", "", sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	repositoryIdentifier, err := url.PathUnescape(vars["repositoryIdentifier"])
+	if err != nil {
+		return "", "", "", sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	vcsProject, err := api.getVCSByIdentifier(ctx, vars["projectKey"], vcsIdentifier)
+	if err != nil {
+		return "", "", "", err
+	}
+	repo, err := api.getRepositoryByIdentifier(ctx, vcsProject.ID, repositoryIdentifier)
+	if err != nil {
+		return "", "", "", err
+	}
+	return vcsProject.Name, repo.Name, vars["workflow"], nil
+}
+
+func workflowTestCasesLimit(req *http.Request) int {
+	limit := service.FormInt(req, "limit")
+	if limit <= 0 || limit > 1000 {
+		return defaultWorkflowTestCasesLimit
+	}
+	return limit
+}
+
+func (api *API) getWorkflowRunTestCasesV2Handler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+			workflowRunID := vars["workflowRunID"]
+
+			wr, err := workflow_v2.LoadRunByProjectKeyAndID(ctx, api.mustDB(), pKey, workflowRunID)
+			if err != nil {
+				return err
+			}
+
+			attempt := wr.RunAttempt
+			if attemptS := FormString(req, "attempt"); attemptS != "" {
+				attempt, err = strconv.ParseInt(attemptS, 10, 64)
+				if err != nil {
+					return sdk.NewError(sdk.ErrWrongRequest, err)
+				}
+			}
+
+			testCases, err := workflow_v2.LoadRunTestCasesByRunID(ctx, api.mustDB(), wr.ID, attempt)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, testCases, http.StatusOK)
+		}
+}
+
+func (api *API) getWorkflowTestCaseHistoryHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			vcsName, repoName, workflowName, err := api.getWorkflowNamesFromVars(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			suite := FormString(req, "suite")
+			name := FormString(req, "name")
+			if suite == "" || name == "" {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing test suite or test name")
+			}
+
+			history, err := workflow_v2.LoadWorkflowTestCaseHistory(ctx, api.mustDB(), vars["projectKey"], vcsName, repoName, workflowName, suite, name, FormString(req, "ref"), workflowTestCasesLimit(req))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, history, http.StatusOK)
+		}
+}
+
+func (api *API) getWorkflowSlowestTestCasesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			vcsName, repoName, workflowName, err := api.getWorkflowNamesFromVars(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			stats, err := workflow_v2.LoadWorkflowSlowestTestCases(ctx, api.mustDB(), vars["projectKey"], vcsName, repoName, workflowName, workflowTestCasesLimit(req))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, stats, http.StatusOK)
+		}
+}
+
+func (api *API) getWorkflowFlakyTestCasesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			vcsName, repoName, workflowName, err := api.getWorkflowNamesFromVars(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			stats, err := workflow_v2.LoadWorkflowFlakyTestCases(ctx, api.mustDB(), vars["projectKey"], vcsName, repoName, workflowName, workflowTestCasesLimit(req))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, stats, http.StatusOK)
+		}
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+// exportWorkflowRunTrace sends the trace of the current attempt of a workflow run with the OTLP exporter.
+// The spans are rebuilt from the timings stored on the run, the jobs and their steps.
+func (api *API) exportWorkflowRunTrace(ctx context.Context, runID string) {
+	ctx = telemetry.ContextWithTelemetry(api.Router.Background, ctx)
+	if telemetry.OTLPTraceExporter(ctx) == nil {
+		return
+	}
+
+	run, err := workflow_v2.LoadRunByID(ctx, api.mustDB(), runID)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	runJobs, err := workflow_v2.LoadRunJobsByRunID(ctx, api.mustDB(), run.ID, run.RunAttempt)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	runJobIDs := make([]string, 0, len(runJobs))
+	for _, rj := range runJobs {
+		runJobIDs = append(runJobIDs, rj.ID)
+	}
+	runResults, err := workflow_v2.LoadRunResultsByRunIDAttempt(ctx, api.mustDB(), run.ID, runJobIDs, run.RunAttempt)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+
+	spans, err := workflowRunTraceSpans(*run, runJobs, runResults)
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	if err := telemetry.ExportSpans(ctx, spans); err != nil {
+		log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to export trace of workflow run %s", run.ID))
+	}
+}
+
+func workflowRunTraceSpans(run sdk.V2WorkflowRun, runJobs []sdk.V2WorkflowRunJob, runResults []sdk.V2WorkflowRunResult) ([]telemetry.ExportedSpan, error) {
+	traceID, _, err := sdk.ParseTraceParent(run.TraceParent)
+	if err != nil {
+		return nil, err
+	}
+
+	runAttributes := map[string]string{
+		"cds.project_key":  run.ProjectKey,
+		"cds.vcs_server":   run.VCSServer,
+		"cds.repository":   run.Repository,
+		"cds.workflow":     run.WorkflowName,
+		"cds.workflow_ref": run.WorkflowRef,
+		"cds.run_id":       run.ID,
+		"cds.run_number":   strconv.FormatInt(run.RunNumber, 10),
+		"cds.run_attempt":  strconv.FormatInt(run.RunAttempt, 10),
+		"cds.status":       string(run.Status),
+		"cds.event":        string(run.RunEvent.EventName),
+	}
+	runSpan := telemetry.ExportedSpan{
+		Service:      "cds-api",
+		Name:         fmt.Sprintf("%s #%d.%d", run.WorkflowName, run.RunNumber, run.RunAttempt),
+		TraceID:      traceID,
+		SpanID:       run.TraceSpanID(),
+		ParentSpanID: run.TraceParentSpanID(),
+		Start:        run.Started,
+		End:          run.LastModified,
+		Attributes:   runAttributes,
+	}
+	if run.Status == sdk.V2WorkflowRunStatusFail {
+		runSpan.Error = string(run.Status)
+	}
+	spans := []telemetry.ExportedSpan{runSpan}
+
+	// Crafting ends when the first job is queued
+	craftingEnd := run.LastModified
+	for _, rj := range runJobs {
+		if rj.Queued.Before(craftingEnd) {
+			craftingEnd = rj.Queued
+		}
+	}
+	if run.RunAttempt == 1 && craftingEnd.After(run.Started) {
+		spans = append(spans, telemetry.ExportedSpan{
+			Service:      "cds-api",
+			Name:         "crafting",
+			TraceID:      traceID,
+			SpanID:       sdk.TraceSpanID(run.ID, "crafting"),
+			ParentSpanID: runSpan.SpanID,
+			Start:        run.Started,
+			End:          craftingEnd,
+			Attributes:   runAttributes,
+		})
+	}
+
+	resultsByJob := make(map[string][]sdk.V2WorkflowRunResult)
+	for _, r := range runResults {
+		resultsByJob[r.WorkflowRunJobID] = append(resultsByJob[r.WorkflowRunJobID], r)
+	}
+
+	for _, rj := range runJobs {
+		spans = append(spans, runJobTraceSpans(traceID, runSpan.SpanID, rj, resultsByJob[rj.ID])...)
+	}
+	return spans, nil
+}
+
+func runJobTraceSpans(traceID, runSpanID string, rj sdk.V2WorkflowRunJob, runResults []sdk.V2WorkflowRunResult) []telemetry.ExportedSpan {
+	if rj.Ended == nil {
+		return nil
+	}
+	jobAttributes := map[string]string{
+		"cds.project_key":  rj.ProjectKey,
+		"cds.workflow":     rj.WorkflowName,
+		"cds.run_number":   strconv.FormatInt(rj.RunNumber, 10),
+		"cds.run_attempt":  strconv.FormatInt(rj.RunAttempt, 10),
+		"cds.job":          rj.JobID,
+		"cds.run_job_id":   rj.ID,
+		"cds.retry":        strconv.FormatInt(rj.Retry, 10),
+		"cds.status":       string(rj.Status),
+		"cds.region":       rj.Region,
+		"cds.worker_model": rj.Job.RunsOn.Model,
+		"cds.hatchery":     rj.HatcheryName,
+		"cds.worker":       rj.WorkerName,
+	}
+	for k, v := range rj.Matrix {
+		jobAttributes["cds.matrix."+k] = fmt.Sprintf("%v", v)
+	}
+
+	jobSpanID := sdk.TraceSpanID(rj.ID)
+	jobSpan := telemetry.ExportedSpan{
+		Service:      "cds-api",
+		Name:         rj.JobID,
+		TraceID:      traceID,
+		SpanID:       jobSpanID,
+		ParentSpanID: runSpanID,
+		Start:        rj.Queued,
+		End:          *rj.Ended,
+		Attributes:   jobAttributes,
+	}
+	if rj.Status == sdk.V2WorkflowRunJobStatusFail {
+		jobSpan.Error = string(rj.Status)
+	}
+	for _, r := range runResults {
+		jobSpan.Events = append(jobSpan.Events, telemetry.ExportedSpanEvent{
+			Name: "run result " + r.Name(),
+			Time: r.IssuedAt,
+			Attributes: map[string]string{
+				"cds.run_result_type":   string(r.Type),
+				"cds.run_result_status": r.Status,
+			},
+		})
+	}
+	spans := []telemetry.ExportedSpan{jobSpan}
+
+	// Waiting in the queue until a hatchery takes the job
+	queueEnd := *rj.Ended
+	if rj.Scheduled != nil {
+		queueEnd = *rj.Scheduled
+	}
+	spans = append(spans, telemetry.ExportedSpan{
+		Service:      "cds-api",
+		Name:         "queue",
+		TraceID:      traceID,
+		SpanID:       sdk.TraceSpanID(rj.ID, "queue"),
+		ParentSpanID: jobSpanID,
+		Start:        rj.Queued,
+		End:          queueEnd,
+		Attributes:   map[string]string{"cds.job": rj.JobID, "cds.region": rj.Region},
+	})
+
+	// Spawning the worker until it takes the job
+	if rj.Scheduled != nil && rj.Started != nil {
+		spans = append(spans, telemetry.ExportedSpan{
+			Service:      "cds-hatchery",
+			Name:         "spawn",
+			TraceID:      traceID,
+			SpanID:       sdk.TraceSpanID(rj.ID, "spawn"),
+			ParentSpanID: jobSpanID,
+			Start:        *rj.Scheduled,
+			End:          *rj.Started,
+			Attributes: map[string]string{
+				"cds.job":          rj.JobID,
+				"cds.hatchery":     rj.HatcheryName,
+				"cds.worker":       rj.WorkerName,
+				"cds.worker_model": rj.Job.RunsOn.Model,
+			},
+		})
+	}
+
+	stepNames := make([]string, 0, len(rj.StepsStatus))
+	for name := range rj.StepsStatus {
+		stepNames = append(stepNames, name)
+	}
+	sort.Strings(stepNames)
+	for _, name := range stepNames {
+		ss := rj.StepsStatus[name]
+		if ss.Started.IsZero() {
+			continue
+		}
+		end := ss.Ended
+		if end.IsZero() {
+			end = *rj.Ended
+		}
+		stepAttributes := map[string]string{
+			"cds.job":        rj.JobID,
+			"cds.step":       name,
+			"cds.conclusion": string(ss.Conclusion),
+			"cds.outcome":    string(ss.Outcome),
+		}
+		if ss.Metrics != nil {
+			stepAttributes["cds.cpu_seconds"] = strconv.FormatFloat(ss.Metrics.CPUSeconds, 'f', 3, 64)
+			stepAttributes["cds.memory_peak_bytes"] = strconv.FormatInt(ss.Metrics.MemoryPeak, 10)
+			stepAttributes["cds.disk_read_bytes"] = strconv.FormatInt(ss.Metrics.DiskRead, 10)
+			stepAttributes["cds.disk_write_bytes"] = strconv.FormatInt(ss.Metrics.DiskWrite, 10)
+			stepAttributes["cds.network_received_bytes"] = strconv.FormatInt(ss.Metrics.NetworkReceived, 10)
+			stepAttributes["cds.network_sent_bytes"] = strconv.FormatInt(ss.Metrics.NetworkSent, 10)
+		}
+		stepSpan := telemetry.ExportedSpan{
+			Service:      "cds-worker",
+			Name:         name,
+			TraceID:      traceID,
+			SpanID:       sdk.TraceSpanID(rj.ID, name),
+			ParentSpanID: jobSpanID,
+			Start:        ss.Started,
+			End:          end,
+			Attributes:   stepAttributes,
+		}
+		if ss.Outcome == sdk.V2WorkflowRunJobStatusFail {
+			stepSpan.Error = string(ss.Outcome)
+		}
+		spans = append(spans, stepSpan)
+	}
+	return spans
+}
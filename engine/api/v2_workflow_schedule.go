@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	defaultWorkflowScheduleCount = 5
+	maxWorkflowScheduleCount     = 100
+)
+
+// getWorkflowSchedulesHandler returns the next executions of the schedulers of a workflow, blackouts excluded
+func (api *API) getWorkflowSchedulesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			vcsName, repoName, workflowName, err := api.getWorkflowNamesFromVars(ctx, vars)
+			if err != nil {
+				return err
+			}
+
+			count := service.FormInt(req, "count")
+			if count <= 0 || count > maxWorkflowScheduleCount {
+				count = defaultWorkflowScheduleCount
+			}
+
+			hooks, err := workflow_v2.LoadHookByWorkflowAndType(ctx, api.mustDB(), vars["projectKey"], vcsName, repoName, workflowName, sdk.WorkflowHookTypeScheduler)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			executions := make([]sdk.V2WorkflowScheduleExecution, 0, count)
+			for _, h := range hooks {
+				times, err := h.Data.ScheduleNextTimes(now, count)
+				if err != nil {
+					return err
+				}
+				for _, t := range times {
+					executions = append(executions, sdk.V2WorkflowScheduleExecution{
+						HookID:   h.ID,
+						Cron:     h.Data.Cron,
+						Timezone: h.Data.CronTimeZone,
+						Branch:   h.Data.TargetBranch,
+						Jitter:   h.Data.ScheduleJitter,
+						CatchUp:  h.Data.ScheduleCatchUp,
+						Time:     t,
+					})
+				}
+			}
+			sort.SliceStable(executions, func(i, j int) bool { return executions[i].Time.Before(executions[j].Time) })
+			if len(executions) > count {
+				executions = executions[:count]
+			}
+			return service.WriteJSON(w, executions, http.StatusOK)
+		}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/gorilla/mux"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/entity"
+	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/vcs"
+	"github.com/ovh/cds/engine/api/workflow_v2"
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowTemplateFromRegexp = regexp.MustCompile(`(?m)^(from:\s*["']?)([^@"'\s]+)@([^"'\n]+?)(["']?\s*)$`)
+
+// indexWorkflowTemplateInstance keeps track of the template used by a head workflow
+func indexWorkflowTemplateInstance(ctx context.Context, tx gorpmapper.SqlExecutorWithTx, store cache.Store, ef *EntityFinder, e sdk.EntityWithObject, vcsName, repoName string) error {
+	if e.Workflow.From == "" {
+		return workflow_v2.DeleteTemplateInstance(tx, e.ProjectRepositoryID, e.Name, e.Ref)
+	}
+
+	entTemplate, msg, err := ef.searchWorkflowTemplate(ctx, tx, store, e.Workflow.From)
+	if err != nil {
+		return err
+	}
+	if msg != "" {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "%s", msg)
+	}
+
+	templateName := strings.Split(entTemplate.CompleteName, "@")[0]
+	instance := sdk.V2WorkflowTemplateInstance{
+		ProjectKey:          e.ProjectKey,
+		ProjectRepositoryID: e.ProjectRepositoryID,
+		VCSServer:           vcsName,
+		Repository:          repoName,
+		WorkflowName:        e.Name,
+		WorkflowRef:         e.Ref,
+		WorkflowPath:        e.FilePath,
+		From:                e.Workflow.From,
+		Template:            templateName,
+		TemplateRef:         entTemplate.Ref,
+		TemplateCommit:      entTemplate.Commit,
+	}
+	return workflow_v2.UpsertTemplateInstance(ctx, tx, &instance)
+}
+
+// upgradeWorkflowTemplateInstances opens a pull request on each workflow of the default branches
+// that uses a previous version of a template released with a new tag.
+func (api *API) upgradeWorkflowTemplateInstances(ctx context.Context, templateName string, templateEntity sdk.Entity) error {
+	newTag := strings.TrimPrefix(templateEntity.Ref, sdk.GitRefTagPrefix)
+	newVersion, err := semver.NewVersion(newTag)
+	if err != nil {
+		log.Debug(ctx, "upgradeWorkflowTemplateInstances> tag %s of template %s is not a version", newTag, templateName)
+		return nil
+	}
+
+	instances, err := workflow_v2.LoadTemplateInstancesByTemplate(ctx, api.mustDB(), templateName)
+	if err != nil {
+		return err
+	}
+
+	for _, inst := range instances {
+		// Workflows that follow a branch of the template are updated on their next analysis
+		if !strings.HasPrefix(inst.TemplateRef, sdk.GitRefTagPrefix) || inst.UpgradeRef == templateEntity.Ref {
+			continue
+		}
+		fromRef := inst.TemplateRef
+		if split := strings.Split(inst.From, "@"); len(split) == 2 && sdk.IsSemverConstraint(split[1]) {
+			fromRef = split[1]
+		}
+		// The workflow already uses or will use the new version
+		if sdk.TemplateVersionMatches(fromRef, templateEntity.Ref) {
+			continue
+		}
+		if err := api.upgradeWorkflowTemplateInstance(ctx, inst, newTag, newVersion); err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to upgrade workflow %s/%s/%s/%s to template %s@%s", inst.ProjectKey, inst.VCSServer, inst.Repository, inst.WorkflowName, templateName, newTag))
+		}
+	}
+	return nil
+}
+
+func (api *API) upgradeWorkflowTemplateInstance(ctx context.Context, inst sdk.V2WorkflowTemplateInstance, newTag string, newVersion *semver.Version) error {
+	client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, inst.ProjectKey, inst.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
+	if err != nil {
+		return err
+	}
+	defaultBranch, err := client.Branch(ctx, inst.Repository, sdk.VCSBranchFilters{Default: true})
+	if err != nil {
+		return err
+	}
+	// Only workflows of the default branch are upgraded
+	if inst.WorkflowRef != defaultBranch.ID {
+		return nil
+	}
+
+	wkfEntity, err := entity.LoadHeadEntityByRefTypeName(ctx, api.mustDB(), inst.ProjectRepositoryID, inst.WorkflowRef, sdk.EntityTypeWorkflow, inst.WorkflowName)
+	if err != nil {
+		return err
+	}
+
+	newRef := newTag
+	if split := strings.Split(inst.From, "@"); len(split) == 2 && sdk.IsSemverConstraint(split[1]) {
+		newRef = "^" + newVersion.String()
+	}
+	if !workflowTemplateFromRegexp.MatchString(wkfEntity.Data) {
+		return sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to find the template reference in file %s", wkfEntity.FilePath)
+	}
+	data := workflowTemplateFromRegexp.ReplaceAllString(wkfEntity.Data, "${1}${2}@"+newRef+"${4}")
+
+	proj, err := project.Load(ctx, api.mustDB(), inst.ProjectKey, project.LoadOptions.WithClearKeys)
+	if err != nil {
+		return err
+	}
+	vcsWithSecret, err := vcs.LoadVCSByProject(ctx, api.mustDB(), inst.ProjectKey, inst.VCSServer, gorpmapping.GetOptions.WithDecryption)
+	if err != nil {
+		return err
+	}
+	vcsRepo, err := client.RepoByFullname(ctx, inst.Repository)
+	if err != nil {
+		return err
+	}
+	cloneURL := vcsRepo.SSHCloneURL
+	if vcsWithSecret.Auth.SSHKeyName == "" {
+		cloneURL = vcsRepo.HTTPCloneURL
+	}
+
+	templateShortName := inst.Template[strings.LastIndex(inst.Template, "/")+1:]
+	push := sdk.OperationPush{
+		FromBranch: fmt.Sprintf("cds/upgrade-%s-%s-%s", inst.WorkflowName, templateShortName, newTag),
+		ToBranch:   defaultBranch.DisplayID,
+		Message:    fmt.Sprintf("Upgrade workflow %s to template %s %s", inst.WorkflowName, templateShortName, newTag),
+		Update:     true,
+	}
+	files := map[string][]byte{
+		strings.TrimPrefix(wkfEntity.FilePath, ".cds/"): []byte(data),
+	}
+	ope, err := operation.PushFilesOperation(ctx, api.mustDB(), *proj, *vcsWithSecret, inst.Repository, cloneURL, push, files)
+	if err != nil {
+		return err
+	}
+	ope, err = operation.Poll(ctx, api.mustDB(), ope.UUID)
+	if err != nil {
+		return err
+	}
+	if ope.Status == sdk.OperationStatusError {
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to push branch %s: %s", push.FromBranch, ope.Error.ToError())
+	}
+
+	// Try to reuse a pull request for the branch if exists else create a new one
+	var pr *sdk.VCSPullRequest
+	prs, err := client.PullRequests(ctx, inst.Repository, sdk.VCSRequestModifierWithState(sdk.VCSPullRequestStateOpen))
+	if err != nil {
+		return sdk.NewErrorFrom(err, "unable to list pull request")
+	}
+	for _, prItem := range prs {
+		if prItem.Base.Branch.DisplayID == push.ToBranch && prItem.Head.Branch.DisplayID == push.FromBranch {
+			pr = &prItem
+			break
+		}
+	}
+	if pr == nil {
+		newPR, err := client.PullRequestCreate(ctx, inst.Repository, sdk.VCSPullRequest{
+			Title: push.Message,
+			Head:  sdk.VCSPushEvent{Branch: sdk.VCSBranch{DisplayID: push.FromBranch}, Repo: inst.Repository},
+			Base:  sdk.VCSPushEvent{Branch: sdk.VCSBranch{DisplayID: push.ToBranch}, Repo: inst.Repository},
+		})
+		if err != nil {
+			return sdk.NewErrorFrom(err, "unable to create pull request")
+		}
+		pr = &newPR
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+	inst.UpgradeRef = sdk.GitRefTagPrefix + newTag
+	inst.UpgradePullRequestURL = pr.URL
+	if err := workflow_v2.UpdateTemplateInstance(ctx, tx, &inst); err != nil {
+		return err
+	}
+	return sdk.WithStack(tx.Commit())
+}
+
+func (api *API) getWorkflowTemplateInstancesHandler() ([]service.RbacChecker, service.Handler) {
+	return service.RBAC(api.projectRead),
+		func(ctx context.Context, w http.ResponseWriter, req *http.Request) error {
+			vars := mux.Vars(req)
+			pKey := vars["projectKey"]
+
+			instances, err := workflow_v2.LoadTemplateInstancesByProject(ctx, api.mustDB(), pKey, FormString(req, "template"))
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, instances, http.StatusOK)
+		}
+}
@@ -125,3 +125,8 @@ func LoadAllVCSGerrit(ctx context.Context, db gorp.SqlExecutor, opts ...gorpmapp
 	query := gorpmapping.NewQuery(`SELECT vcs_project.* FROM vcs_project WHERE vcs_project.type = 'gerrit'`)
 	return getAllVCSProject(ctx, db, query, opts...)
 }
+
+func LoadAllVCSByType(ctx context.Context, db gorp.SqlExecutor, vcsType string, opts ...gorpmapping.GetAllOptionFunc) ([]sdk.VCSProject, error) {
+	query := gorpmapping.NewQuery(`SELECT vcs_project.* FROM vcs_project WHERE vcs_project.type = $1`).Args(vcsType)
+	return getAllVCSProject(ctx, db, query, opts...)
+}
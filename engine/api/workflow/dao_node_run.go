@@ -520,7 +520,7 @@ func GetNodeRunBuildCommits(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 
 	res := []sdk.VCSCommit{}
 	//Get the RepositoriesManager Client
-	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, app.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, app.VCSServer, nil)
 	if err != nil {
 		return nil, cur, sdk.WrapError(err, "cannot get client")
 	}
@@ -1147,7 +1147,7 @@ func getVCSInfos(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store cac
 	}
 
 	//Get the RepositoriesManager Client
-	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, projectKey, applicationVCSServer)
+	client, errclient := repositoriesmanager.AuthorizedClient(ctx, db, store, projectKey, applicationVCSServer, nil)
 	if errclient != nil {
 		return nil, sdk.WrapError(errclient, "cannot get client")
 	}
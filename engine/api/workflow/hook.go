@@ -41,7 +41,7 @@ func hookUnregistration(ctx context.Context, db gorpmapper.SqlExecutorWithTx, st
 	for _, h := range hookToDelete {
 		if h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.GerritHookModelName {
 			// Call VCS to know if repository allows webhook and get the configuration fields
-			client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, h.Config["vcsServer"].Value)
+			client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, h.Config["vcsServer"].Value, nil)
 			if err == nil {
 				vcsHook := sdk.VCSHook{
 					Method: "POST",
@@ -293,7 +293,7 @@ func createVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	ctx, end := telemetry.Span(ctx, "workflow.createVCSConfiguration", telemetry.Tag("UUID", h.UUID))
 	defer end()
 	// Call VCS to know if repository allows webhook and get the configuration fields
-	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, h.Config["vcsServer"].Value)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, h.Config["vcsServer"].Value, nil)
 	if err != nil {
 		log.Debug(ctx, "createVCSConfiguration> No vcsServer found: %v", err)
 		return nil
@@ -351,7 +351,7 @@ func updateVCSConfiguration(ctx context.Context, db gorpmapper.SqlExecutorWithTx
 	ctx, end := telemetry.Span(ctx, "workflow.updateVCSConfiguration", telemetry.Tag("UUID", h.UUID))
 	defer end()
 	// Call VCS to know if repository allows webhook and get the configuration fields
-	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, h.Config["vcsServer"].Value)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, h.Config["vcsServer"].Value, nil)
 	if err != nil {
 		log.Debug(ctx, "createVCSConfiguration> No vcsServer found: %v", err)
 		return nil
@@ -400,7 +400,7 @@ func DefaultPayload(ctx context.Context, db gorpmapper.SqlExecutorWithTx, store
 
 	if app.RepositoryFullname != "" {
 		defaultBranch := "master"
-		client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, app.VCSServer)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, app.VCSServer, nil)
 		if err == nil {
 			branch, err := repositoriesmanager.DefaultBranch(ctx, client, app.RepositoryFullname)
 			if err != nil {
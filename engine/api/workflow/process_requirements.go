@@ -295,7 +295,7 @@ func processNodeJobRunRequirementsGetModelV2(ctx context.Context, store cache.St
 			}
 		} else {
 			// Get default branch
-			client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, workerProjKey, vcs.Name)
+			client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, workerProjKey, vcs.Name, nil)
 			if err != nil {
 				return nil, "", err
 			}
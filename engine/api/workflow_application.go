@@ -59,7 +59,7 @@ func (api *API) releaseApplicationWorkflowHandler() service.Handler {
 			return sdk.NewErrorFrom(sdk.ErrNoReposManager, "app.VCSServer is empty")
 		}
 
-		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, app.VCSServer)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, app.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return sdk.WrapError(err, "cannot get client got %s %s", key, app.VCSServer)
 		}
@@ -72,7 +72,7 @@ func (api *API) postWorkflowAsCodeHandler() service.Handler {
 			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot find the root application of the workflow")
 		}
 
-		client, err := repositoriesmanager.AuthorizedClient(ctx, tx1, api.Cache, key, rootApp.VCSServer)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, tx1, api.Cache, key, rootApp.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
 		if err != nil {
 			return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "postWorkflowAsCodeHandler> cannot get client got %s %s : %v", key, rootApp.VCSServer, err)
 		}
@@ -984,7 +984,7 @@ func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 			}
 
 			// Get vcs info to known if we are on the default branch or not
-			client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, p.Key, nr.VCSServer)
+			client, err := repositoriesmanager.AuthorizedClient(ctx, tx, api.Cache, p.Key, nr.VCSServer, project.LoadKeyWithPrivateContentByProjectKey)
 			if err != nil {
 				log.Error(ctx, "postWorkflowJobTestsResultsHandler> Cannot get repo client %s : %v", nr.VCSServer, err)
 				return nil
@@ -109,6 +109,11 @@ func InsertRun(ctx context.Context, db gorpmapper.SqlExecutorWithTx, wr *sdk.V2W
 	wr.LastModified = time.Now()
 	wr.RunAttempt = 1
 
+	// Without the trace context of a hook event, the run is the root of its trace
+	if _, _, err := sdk.ParseTraceParent(wr.TraceParent); err != nil {
+		wr.TraceParent = sdk.FormatTraceParent(sdk.NewTraceID(), wr.TraceSpanID())
+	}
+
 	if wr.Initiator == nil {
 		wr.Initiator = &sdk.V2Initiator{
 			UserID:         wr.DeprecatedUserID,
@@ -582,6 +587,26 @@ func LoadRunByRunNumber(ctx context.Context, db gorp.SqlExecutor, projectKey, vc
 	return getRun(ctx, db, query, opts...)
 }
 
+// LoadRunByWorkflowName loads a run from the vcs server and repository names, or the last successful run if runNumber is 0
+func LoadRunByWorkflowName(ctx context.Context, db gorp.SqlExecutor, projectKey, vcsServer, repository, wfName string, runNumber int64, opts ...gorpmapper.GetOptionFunc) (*sdk.V2WorkflowRun, error) {
+	if runNumber > 0 {
+		query := gorpmapping.NewQuery(`
+    SELECT * from v2_workflow_run
+    WHERE project_key = $1 AND vcs_server = $2
+    AND repository = $3 AND workflow_name = $4 AND run_number = $5`).
+			Args(projectKey, vcsServer, repository, wfName, runNumber)
+		return getRun(ctx, db, query, opts...)
+	}
+	query := gorpmapping.NewQuery(`
+    SELECT * from v2_workflow_run
+    WHERE project_key = $1 AND vcs_server = $2
+    AND repository = $3 AND workflow_name = $4 AND status = $5
+    ORDER BY run_number DESC
+    LIMIT 1`).
+		Args(projectKey, vcsServer, repository, wfName, sdk.V2WorkflowRunStatusSuccess)
+	return getRun(ctx, db, query, opts...)
+}
+
 func LoadCratingWorkflowRunIDs(db gorp.SqlExecutor) ([]string, error) {
 	query := `
 		SELECT id
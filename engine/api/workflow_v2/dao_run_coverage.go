@@ -0,0 +1,65 @@
+package workflow_v2
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func getAllRunCoverages(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.V2WorkflowRunCoverage, error) {
+	var dbCoverages []dbV2WorkflowRunCoverage
+	if err := gorpmapping.GetAll(ctx, db, query, &dbCoverages); err != nil {
+		return nil, err
+	}
+	coverages := make([]sdk.V2WorkflowRunCoverage, 0, len(dbCoverages))
+	for _, c := range dbCoverages {
+		coverages = append(coverages, c.V2WorkflowRunCoverage)
+	}
+	return coverages, nil
+}
+
+// ReplaceRunCoverage stores the coverage of a run attempt, replacing the previous one
+func ReplaceRunCoverage(ctx context.Context, db gorpmapper.SqlExecutorWithTx, coverage *sdk.V2WorkflowRunCoverage) error {
+	_, next := telemetry.Span(ctx, "workflow_v2.ReplaceRunCoverage")
+	defer next()
+	if _, err := db.Exec("DELETE FROM v2_workflow_run_coverage WHERE workflow_run_id = $1 AND run_attempt = $2", coverage.WorkflowRunID, coverage.RunAttempt); err != nil {
+		return sdk.WithStack(err)
+	}
+	coverage.ID = sdk.UUID()
+	dbCoverage := &dbV2WorkflowRunCoverage{V2WorkflowRunCoverage: *coverage}
+	if err := gorpmapping.Insert(db, dbCoverage); err != nil {
+		return err
+	}
+	*coverage = dbCoverage.V2WorkflowRunCoverage
+	return nil
+}
+
+func LoadRunCoverage(ctx context.Context, db gorp.SqlExecutor, runID string, attempt int64) (*sdk.V2WorkflowRunCoverage, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_coverage
+		WHERE workflow_run_id = $1 AND run_attempt = $2`).Args(runID, attempt)
+	var dbCoverage dbV2WorkflowRunCoverage
+	found, err := gorpmapping.Get(ctx, db, query, &dbCoverage)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return &dbCoverage.V2WorkflowRunCoverage, nil
+}
+
+// LoadWorkflowCoverageTrend returns the last coverages of a workflow on a git ref, the most recent first
+func LoadWorkflowCoverageTrend(ctx context.Context, db gorp.SqlExecutor, projKey, vcsName, repoName, workflowName, ref string, limit int) ([]sdk.V2WorkflowRunCoverage, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_coverage
+		WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4 AND git_ref = $5
+		ORDER BY run_number DESC, run_attempt DESC
+		LIMIT $6`).Args(projKey, vcsName, repoName, workflowName, ref, limit)
+	return getAllRunCoverages(ctx, db, query)
+}
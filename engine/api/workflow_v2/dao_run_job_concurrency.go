@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-gorp/gorp"
 	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -383,3 +385,194 @@ func LoadProjectConccurencyRunObjects(ctx context.Context, db gorp.SqlExecutor,
 	}
 	return pcr, nil
 }
+
+func CountRunningWithSharedConcurrency(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, concurrencyName string) (int64, error) {
+	q := `WITH jobs AS 
+	(
+		SELECT count(id) as nb
+		FROM v2_workflow_run_job 
+		WHERE 
+			concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = ANY($4)
+	), runs AS (
+		SELECT count(id) as nb
+		FROM v2_workflow_run
+		WHERE 
+			concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = ANY($4)
+	) 
+	SELECT jobs.nb + runs.nb
+	FROM jobs, runs`
+	nb, err := db.SelectInt(q, key, concurrencyName, scope, pq.StringArray(sdk.RunningStatusesV2WorkflowRunJob))
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	return nb, nil
+}
+
+func CountBlockedWithSharedConcurrency(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, concurrencyName string) (int64, error) {
+	q := `WITH jobs as (
+		SELECT count(id) as nb
+		FROM v2_workflow_run_job 
+		WHERE 
+			concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = $4
+	), runs as (
+		SELECT count(id) as nb
+		FROM v2_workflow_run
+		WHERE 
+			concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = $4
+	) SELECT jobs.nb + runs.nb FROM jobs, runs`
+	nb, err := db.SelectInt(q, key, concurrencyName, scope, sdk.V2WorkflowRunJobStatusBlocked)
+	if err != nil {
+		return 0, sdk.WithStack(err)
+	}
+	return nb, nil
+}
+
+func LoadSharedConcurrencyRules(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, concurrencyName string) ([]ConcurrencyRule, error) {
+	q := `SELECT concurrency->>'order' as order, concurrency->>'cancel-in-progress' as cancel, min(concurrency->>'pool') as pool
+	FROM v2_workflow_run_job 
+	WHERE 
+		concurrency->>'key' = $1 AND 
+		concurrency->>'name' = $2 AND
+		concurrency->>'scope' = $3 AND
+		status = ANY($4)
+	GROUP BY concurrency->>'order', concurrency->>'cancel-in-progress'
+	UNION
+	SELECT concurrency->>'order' as order, concurrency->>'cancel-in-progress' as cancel, min(concurrency->>'pool') as pool
+	FROM v2_workflow_run
+	WHERE 
+		concurrency->>'key' = $1 AND 
+		concurrency->>'name' = $2 AND
+		concurrency->>'scope' = $3 AND
+		status = ANY($4)
+	GROUP BY concurrency->>'order', concurrency->>'cancel-in-progress'
+	`
+
+	var rules []ConcurrencyRule
+	if _, err := db.Select(&rules, q, key, concurrencyName, scope, pq.StringArray(append([]string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.RunningStatusesV2WorkflowRunJob...))); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WithStack(err)
+	}
+	if len(rules) == 1 && (rules[0].MinPool == 0 && rules[0].Order == "") {
+		return nil, nil
+	}
+	return rules, nil
+}
+
+func LoadOldestRunJobWithSharedConcurrency(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, concurrencyName string, rjStatus []string, workflowStatus sdk.V2WorkflowRunStatus, limit int64) ([]ConcurrencyObject, error) {
+	q := `WITH jobs as (
+		SELECT id as id, queued as last_modified, 'JOB' as type 
+		FROM v2_workflow_run_job 
+		WHERE concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = ANY($4)
+		ORDER BY last_modified ASC LIMIT $6
+	), runs as (
+	    SELECT id as id, last_modified as last_modified, 'WORKFLOW' as type 
+		FROM v2_workflow_run
+		WHERE concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = $5
+		ORDER BY run_number ASC, last_modified ASC LIMIT $6
+	) SELECT id, type FROM (
+	 	SELECT * FROM jobs
+		UNION
+		SELECT * FROM runs
+	) tmp ORDER BY last_modified ASC LIMIT $6`
+	var cos []ConcurrencyObject
+	if _, err := db.Select(&cos, q, key, concurrencyName, scope, pq.StringArray(rjStatus), workflowStatus, limit); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return cos, nil
+}
+
+func LoadNewestRunJobWithSharedConcurrency(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, concurrencyName string, rjStatus []string, workflowRunStatus sdk.V2WorkflowRunStatus, limit interface{}) ([]ConcurrencyObject, error) {
+	q := `WITH jobs as (
+		SELECT id,  queued as last_modified, 'JOB' as type
+		FROM v2_workflow_run_job 
+		WHERE concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND 
+			status = ANY($4)
+			ORDER BY last_modified DESC
+			LIMIT $6
+	), runs as (
+		SELECT id, last_modified, 'WORKFLOW' as type
+		FROM v2_workflow_run 
+		WHERE concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND 
+			status = $5
+			ORDER BY run_number DESC, last_modified DESC
+			LIMIT $6
+	) SELECT id, type FROM (
+		SELECT * from jobs
+		UNION
+		SELECT * from runs 
+	) tmp ORDER BY last_modified DESC
+	LIMIT $6`
+	var cos []ConcurrencyObject
+	if _, err := db.Select(&cos, q, key, concurrencyName, scope, pq.StringArray(rjStatus), workflowRunStatus, limit); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return cos, nil
+}
+
+// LoadSharedConcurrencyRunObjects returns the holders and the waiters of an organization or a region concurrency
+func LoadSharedConcurrencyRunObjects(ctx context.Context, db gorp.SqlExecutor, scope sdk.V2RunJobConcurrencyScope, key string, concurrencyName string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	q := `WITH jobs as (
+		SELECT project_key, workflow_run_id as workflow_run_id, id as run_job_id, queued as last_modified, 'JOB' as type, workflow_name, run_number, job_id as job_name, status
+		FROM v2_workflow_run_job 
+		WHERE concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = ANY($4)
+		ORDER BY last_modified ASC
+	), runs as (
+	    SELECT project_key, id as workflow_run_id, '' as run_job_id, last_modified as last_modified, 'WORKFLOW' as type, workflow_name, run_number, '' as job_name, status
+		FROM v2_workflow_run
+		WHERE concurrency->>'key' = $1 AND 
+			concurrency->>'name' = $2 AND
+			concurrency->>'scope' = $3 AND
+			status = ANY($5)
+		ORDER BY run_number ASC, last_modified ASC
+	) SELECT * FROM (
+	 	SELECT * FROM jobs
+		UNION
+		SELECT * FROM runs
+	) tmp ORDER BY last_modified ASC`
+
+	jobStatus := append([]string{string(sdk.V2WorkflowRunJobStatusBlocked)}, sdk.RunningStatusesV2WorkflowRunJob...)
+	runStatus := []string{string(sdk.V2WorkflowRunStatusBlocked), string(sdk.V2WorkflowRunStatusBuilding)}
+	var pcr []sdk.ProjectConcurrencyRunObject
+	if _, err := db.Select(&pcr, q, key, concurrencyName, scope, pq.StringArray(jobStatus), pq.StringArray(runStatus)); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return pcr, nil
+}
+
+// LoadBlockedRunJobsWithSharedConcurrencyTimeout returns the run jobs waiting for a shared concurrency that defines a timeout
+func LoadBlockedRunJobsWithSharedConcurrencyTimeout(ctx context.Context, db gorp.SqlExecutor) ([]sdk.V2WorkflowRunJob, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_job
+		WHERE status = $1 AND
+			concurrency->>'scope' = ANY($2) AND
+			COALESCE(concurrency->>'timeout', '') <> ''
+		ORDER BY queued ASC`).Args(sdk.V2WorkflowRunJobStatusBlocked, pq.StringArray([]string{string(sdk.V2RunConcurrencyScopeOrganization), string(sdk.V2RunConcurrencyScopeRegion)}))
+	return getAllRunJobs(ctx, db, query)
+}
@@ -0,0 +1,114 @@
+package workflow_v2
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func getAllRunTestCases(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.V2WorkflowRunTestCase, error) {
+	var dbTestCases []dbV2WorkflowRunTestCase
+	if err := gorpmapping.GetAll(ctx, db, query, &dbTestCases); err != nil {
+		return nil, err
+	}
+	testCases := make([]sdk.V2WorkflowRunTestCase, 0, len(dbTestCases))
+	for _, tc := range dbTestCases {
+		testCases = append(testCases, tc.V2WorkflowRunTestCase)
+	}
+	return testCases, nil
+}
+
+// ReplaceRunResultTestCases indexes the test cases of a tests run result, replacing the previous ones
+func ReplaceRunResultTestCases(ctx context.Context, db gorpmapper.SqlExecutorWithTx, runResultID string, testCases []sdk.V2WorkflowRunTestCase) error {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.ReplaceRunResultTestCases")
+	defer next()
+	if _, err := db.Exec("DELETE FROM v2_workflow_run_test_case WHERE run_result_id = $1", runResultID); err != nil {
+		return sdk.WithStack(err)
+	}
+	for i := range testCases {
+		testCases[i].ID = sdk.UUID()
+		testCases[i].RunResultID = runResultID
+		dbTestCase := &dbV2WorkflowRunTestCase{V2WorkflowRunTestCase: testCases[i]}
+		if err := gorpmapping.Insert(db, dbTestCase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func LoadRunTestCasesByRunID(ctx context.Context, db gorp.SqlExecutor, runID string, attempt int64) ([]sdk.V2WorkflowRunTestCase, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_test_case
+		WHERE workflow_run_id = $1 AND run_attempt = $2
+		ORDER BY suite, name`).Args(runID, attempt)
+	return getAllRunTestCases(ctx, db, query)
+}
+
+// LoadWorkflowTestCaseHistory returns the last executions of a test case, on all branches if ref is empty
+func LoadWorkflowTestCaseHistory(ctx context.Context, db gorp.SqlExecutor, projKey, vcsName, repoName, workflowName, suite, name, ref string, limit int) ([]sdk.V2WorkflowRunTestCase, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_run_test_case
+		WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4
+		AND suite = $5 AND name = $6 AND ($7 = '' OR git_ref = $7)
+		ORDER BY issued_at DESC
+		LIMIT $8`).Args(projKey, vcsName, repoName, workflowName, suite, name, ref, limit)
+	return getAllRunTestCases(ctx, db, query)
+}
+
+// LoadWorkflowSlowestTestCases returns the test cases of a workflow with the highest average duration
+func LoadWorkflowSlowestTestCases(ctx context.Context, db gorp.SqlExecutor, projKey, vcsName, repoName, workflowName string, limit int) ([]sdk.V2WorkflowTestCaseStats, error) {
+	_, next := telemetry.Span(ctx, "LoadWorkflowSlowestTestCases")
+	defer next()
+	var stats []sdk.V2WorkflowTestCaseStats
+	if _, err := db.Select(&stats, `
+		SELECT suite, name, COUNT(*) AS runs,
+			SUM(CASE WHEN status = $5 THEN 1 ELSE 0 END) AS failures,
+			AVG(duration) AS avg_duration, MAX(duration) AS max_duration, 0 AS flaky_commits
+		FROM v2_workflow_run_test_case
+		WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4 AND status <> $6
+		GROUP BY suite, name
+		ORDER BY avg_duration DESC, suite, name
+		LIMIT $7
+	`, projKey, vcsName, repoName, workflowName, sdk.V2WorkflowRunTestCaseStatusFailed, sdk.V2WorkflowRunTestCaseStatusSkipped, limit); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return stats, nil
+}
+
+// LoadWorkflowFlakyTestCases returns the test cases of a workflow that both passed and failed on the same commit
+func LoadWorkflowFlakyTestCases(ctx context.Context, db gorp.SqlExecutor, projKey, vcsName, repoName, workflowName string, limit int) ([]sdk.V2WorkflowTestCaseStats, error) {
+	_, next := telemetry.Span(ctx, "LoadWorkflowFlakyTestCases")
+	defer next()
+	var stats []sdk.V2WorkflowTestCaseStats
+	if _, err := db.Select(&stats, `
+		WITH tests AS (
+			SELECT suite, name, git_sha, status, duration
+			FROM v2_workflow_run_test_case
+			WHERE project_key = $1 AND vcs_server = $2 AND repository = $3 AND workflow_name = $4 AND status <> $6
+		), flips AS (
+			SELECT suite, name, COUNT(*) AS flaky_commits
+			FROM (
+				SELECT suite, name, git_sha FROM tests
+				WHERE git_sha <> ''
+				GROUP BY suite, name, git_sha
+				HAVING COUNT(DISTINCT status) > 1
+			) flipped_commits
+			GROUP BY suite, name
+		)
+		SELECT tests.suite, tests.name, COUNT(*) AS runs,
+			SUM(CASE WHEN tests.status = $5 THEN 1 ELSE 0 END) AS failures,
+			AVG(tests.duration) AS avg_duration, MAX(tests.duration) AS max_duration, flips.flaky_commits
+		FROM tests JOIN flips ON flips.suite = tests.suite AND flips.name = tests.name
+		GROUP BY tests.suite, tests.name, flips.flaky_commits
+		ORDER BY flips.flaky_commits DESC, failures DESC, tests.suite, tests.name
+		LIMIT $7
+	`, projKey, vcsName, repoName, workflowName, sdk.V2WorkflowRunTestCaseStatusFailed, sdk.V2WorkflowRunTestCaseStatusSkipped, limit); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return stats, nil
+}
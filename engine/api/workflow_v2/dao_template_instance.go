@@ -0,0 +1,97 @@
+package workflow_v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/gorpmapper"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+func getAllTemplateInstances(ctx context.Context, db gorp.SqlExecutor, query gorpmapping.Query) ([]sdk.V2WorkflowTemplateInstance, error) {
+	var dbInstances []dbV2WorkflowTemplateInstance
+	if err := gorpmapping.GetAll(ctx, db, query, &dbInstances); err != nil {
+		return nil, err
+	}
+	instances := make([]sdk.V2WorkflowTemplateInstance, 0, len(dbInstances))
+	for _, i := range dbInstances {
+		instances = append(instances, i.V2WorkflowTemplateInstance)
+	}
+	return instances, nil
+}
+
+// UpsertTemplateInstance indexes a workflow generated from a template.
+// The upgrade pull request of an existing instance is kept until the workflow uses a new template version.
+func UpsertTemplateInstance(ctx context.Context, db gorpmapper.SqlExecutorWithTx, i *sdk.V2WorkflowTemplateInstance) error {
+	ctx, next := telemetry.Span(ctx, "workflow_v2.UpsertTemplateInstance")
+	defer next()
+
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_template_instance
+		WHERE project_repository_id = $1 AND workflow_name = $2 AND workflow_ref = $3`).
+		Args(i.ProjectRepositoryID, i.WorkflowName, i.WorkflowRef)
+	var existing dbV2WorkflowTemplateInstance
+	found, err := gorpmapping.Get(ctx, db, query, &existing)
+	if err != nil {
+		return err
+	}
+
+	i.LastModified = time.Now()
+	if !found {
+		i.ID = sdk.UUID()
+		dbData := &dbV2WorkflowTemplateInstance{V2WorkflowTemplateInstance: *i}
+		if err := gorpmapping.Insert(db, dbData); err != nil {
+			return err
+		}
+		*i = dbData.V2WorkflowTemplateInstance
+		return nil
+	}
+
+	i.ID = existing.ID
+	if existing.Template == i.Template && existing.TemplateRef == i.TemplateRef {
+		i.UpgradeRef = existing.UpgradeRef
+		i.UpgradePullRequestURL = existing.UpgradePullRequestURL
+	}
+	return UpdateTemplateInstance(ctx, db, i)
+}
+
+func UpdateTemplateInstance(ctx context.Context, db gorpmapper.SqlExecutorWithTx, i *sdk.V2WorkflowTemplateInstance) error {
+	i.LastModified = time.Now()
+	dbData := &dbV2WorkflowTemplateInstance{V2WorkflowTemplateInstance: *i}
+	if err := gorpmapping.Update(db, dbData); err != nil {
+		return err
+	}
+	*i = dbData.V2WorkflowTemplateInstance
+	return nil
+}
+
+// DeleteTemplateInstance removes the instance of a workflow that is no longer generated from a template
+func DeleteTemplateInstance(db gorpmapper.SqlExecutorWithTx, projectRepositoryID, workflowName, ref string) error {
+	_, err := db.Exec(`
+		DELETE FROM v2_workflow_template_instance
+		WHERE project_repository_id = $1 AND workflow_name = $2 AND workflow_ref = $3`,
+		projectRepositoryID, workflowName, ref)
+	return sdk.WrapError(err, "cannot delete template instance of workflow %s on repository %s for ref %s", workflowName, projectRepositoryID, ref)
+}
+
+// LoadTemplateInstancesByProject returns the workflows of a project generated from templates, optionally filtered on a template
+func LoadTemplateInstancesByProject(ctx context.Context, db gorp.SqlExecutor, projKey string, template string) ([]sdk.V2WorkflowTemplateInstance, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_template_instance
+		WHERE project_key = $1 AND ($2 = '' OR template = $2)
+		ORDER BY vcs_server, repository, workflow_name, workflow_ref`).Args(projKey, template)
+	return getAllTemplateInstances(ctx, db, query)
+}
+
+// LoadTemplateInstancesByTemplate returns all the workflows generated from a template
+func LoadTemplateInstancesByTemplate(ctx context.Context, db gorp.SqlExecutor, template string) ([]sdk.V2WorkflowTemplateInstance, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM v2_workflow_template_instance
+		WHERE template = $1
+		ORDER BY project_key, vcs_server, repository, workflow_name, workflow_ref`).Args(template)
+	return getAllTemplateInstances(ctx, db, query)
+}
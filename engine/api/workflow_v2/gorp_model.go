@@ -58,6 +58,18 @@ type dbV2WorkflowRunResult struct {
 	sdk.V2WorkflowRunResult
 }
 
+type dbV2WorkflowRunTestCase struct {
+	sdk.V2WorkflowRunTestCase
+}
+
+type dbV2WorkflowRunCoverage struct {
+	sdk.V2WorkflowRunCoverage
+}
+
+type dbV2WorkflowTemplateInstance struct {
+	sdk.V2WorkflowTemplateInstance
+}
+
 type dbV2WorkflowVersion struct {
 	sdk.V2WorkflowVersion
 }
@@ -70,4 +82,7 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(dbWorkflowHook{}, "v2_workflow_hook", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbV2WorkflowRunResult{}, "v2_workflow_run_result", false, "id"))
 	gorpmapping.Register(gorpmapping.New(dbV2WorkflowVersion{}, "v2_workflow_version", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbV2WorkflowRunTestCase{}, "v2_workflow_run_test_case", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbV2WorkflowRunCoverage{}, "v2_workflow_run_coverage", false, "id"))
+	gorpmapping.Register(gorpmapping.New(dbV2WorkflowTemplateInstance{}, "v2_workflow_template_instance", false, "id"))
 }
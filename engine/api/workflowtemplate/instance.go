@@ -73,7 +73,7 @@ func requestModifyDefaultNameAndRepositories(proj sdk.Project, repoURL string) T
 		var repoPath string
 	loopVCSServer:
 		for _, vcs := range proj.VCSServers {
-			repos, err := repositoriesmanager.GetReposForProjectVCSServer(ctx, db, store, proj, vcs.Name, repositoriesmanager.Options{})
+			repos, err := repositoriesmanager.GetReposForProjectVCSServer(ctx, db, store, proj, vcs.Name, nil, repositoriesmanager.Options{})
 			if err != nil {
 				log.Warn(ctx, "unable to list repos from %s: %v", vcs.Name, err)
 				continue
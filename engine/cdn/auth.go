@@ -139,6 +139,22 @@ func (s *Service) itemAccessCheck(ctx context.Context, req *http.Request, item s
 				artRef.RunID == signature.WorkflowRunID {
 				return nil
 			}
+			// Run results of another workflow run can be consumed if the API allows it
+			keyPermissionForJob := cache.Key(keyPermission, string(item.Type), artRef.RunID, signature.RunJobID)
+			exists, err := s.Cache.Exist(keyPermissionForJob)
+			if err != nil {
+				return sdk.NewErrorWithStack(sdk.WrapError(err, "unable to check if permission %s exists", keyPermissionForJob), sdk.ErrUnauthorized)
+			}
+			if exists {
+				return nil
+			}
+			if err := s.Client.V2QueueJobExternalRunResultAccess(ctx, signature.Region, signature.RunJobID, artRef.RunID); err != nil {
+				return sdk.NewErrorWithStack(err, sdk.ErrNotFound)
+			}
+			if err := s.Cache.SetWithTTL(keyPermissionForJob, true, 3600); err != nil {
+				return sdk.NewErrorWithStack(sdk.WrapError(err, "unable to store permission %s", keyPermissionForJob), sdk.ErrUnauthorized)
+			}
+			return nil
 		}
 		return sdk.WithStack(sdk.ErrNotFound)
 	case sdk.CDNTypeItemStepLog, sdk.CDNTypeItemServiceLog, sdk.CDNTypeItemRunResult:
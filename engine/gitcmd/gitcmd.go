@@ -0,0 +1,109 @@
+// Package gitcmd runs the git commands that go-repo does not provide. It is shared by the services that work on
+// local clones of repositories.
+package gitcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// SSHAuth contains what ssh needs to reach a git remote. The host key of the remote is always checked.
+type SSHAuth struct {
+	PrivateKey string
+	// KnownHosts is the content of a known_hosts file. The default known hosts files of the user are used if empty.
+	KnownHosts string
+}
+
+// Error is returned when a git command fails
+type Error struct {
+	Args   []string
+	Stderr string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("git %s: %v: %s", strings.Join(e.Args, " "), e.Err, e.Stderr)
+}
+
+// Stderr returns the standard error of a failing git command
+func Stderr(err error) string {
+	if e, ok := sdk.Cause(err).(*Error); ok {
+		return e.Stderr
+	}
+	return ""
+}
+
+// Run runs a git command in the given directory and returns its standard output. The ssh private key and the known
+// hosts are written in a temporary directory that is removed once the command is done.
+func Run(ctx context.Context, dir string, auth *SSHAuth, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "LANG=en_US")
+
+	if auth != nil && auth.PrivateKey != "" {
+		tmpDir, err := os.MkdirTemp("", "cds-git-ssh-")
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to create temporary directory")
+		}
+		defer os.RemoveAll(tmpDir) // nolint
+		sshCommand, err := auth.sshCommand(tmpDir)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+sshCommand)
+	}
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	log.Debug(ctx, "gitcmd: running git %s", strings.Join(args, " "))
+	if err := cmd.Run(); err != nil {
+		return nil, sdk.WithStack(&Error{Args: args, Stderr: strings.TrimSpace(stderr.String()), Err: err})
+	}
+	return stdout.Bytes(), nil
+}
+
+// sshCommand writes the private key and the known hosts in the given directory and returns the ssh command to use
+func (a SSHAuth) sshCommand(dir string) (string, error) {
+	keyFile := filepath.Join(dir, "id")
+	key := a.PrivateKey
+	if !strings.HasSuffix(key, "\n") {
+		key += "\n"
+	}
+	if err := os.WriteFile(keyFile, []byte(key), os.FileMode(0600)); err != nil {
+		return "", sdk.WrapError(err, "unable to write ssh key")
+	}
+
+	sshCommand := fmt.Sprintf("ssh -F /dev/null -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=yes", keyFile)
+	if a.KnownHosts != "" {
+		knownHostsFile := filepath.Join(dir, "known_hosts")
+		knownHosts := a.KnownHosts
+		if !strings.HasSuffix(knownHosts, "\n") {
+			knownHosts += "\n"
+		}
+		if err := os.WriteFile(knownHostsFile, []byte(knownHosts), os.FileMode(0600)); err != nil {
+			return "", sdk.WrapError(err, "unable to write ssh known hosts")
+		}
+		sshCommand += " -o UserKnownHostsFile=" + knownHostsFile
+	}
+	return sshCommand, nil
+}
+
+// ValidateRef checks that a revision given by a user can't be read as an option by git
+func ValidateRef(ref string) error {
+	if ref == "" || strings.HasPrefix(ref, "-") || strings.ContainsAny(ref, " \t\n\r\x00") || strings.Contains(ref, "..") {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid git reference %q", ref)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package gitcmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestValidateRef(t *testing.T) {
+	for _, ref := range []string{"main", "refs/heads/feat/login", "v1.0", "0123456789abcdef", "HEAD~1"} {
+		require.NoError(t, ValidateRef(ref), ref)
+	}
+	for _, ref := range []string{"", "--output=/tmp/file", "-n1", "main..other", "main other"} {
+		require.True(t, sdk.ErrorIs(ValidateRef(ref), sdk.ErrWrongRequest), ref)
+	}
+}
+
+func TestRunSSHFilesAreRemoved(t *testing.T) {
+	auth := SSHAuth{PrivateKey: "my-key", KnownHosts: "git.mycompany.com ssh-ed25519 AAAA"}
+	dir := t.TempDir()
+	cmd, err := auth.sshCommand(dir)
+	require.NoError(t, err)
+	require.Contains(t, cmd, "StrictHostKeyChecking=yes")
+	require.Contains(t, cmd, "UserKnownHostsFile="+filepath.Join(dir, "known_hosts"))
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	_, err = Run(context.TODO(), t.TempDir(), &auth, "version")
+	require.NoError(t, err)
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = Run(context.TODO(), t.TempDir(), nil, "rev-parse", "--verify", "unknown")
+	require.Error(t, err)
+	require.True(t, strings.Contains(Stderr(err), "not a git repository"))
+}
@@ -9,7 +9,7 @@ import (
 	"regexp"
 	"time"
 
-	"github.com/docker/distribution/reference"
+	"github.com/distribution/reference"
 	types "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/rockbears/log"
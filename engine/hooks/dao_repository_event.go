@@ -41,7 +41,17 @@ func (d *dao) RepositoryEventMaintenanceQueueLen() (int, error) {
 }
 
 func (d *dao) SaveRepositoryEvent(_ context.Context, e *sdk.HookRepositoryEvent) error {
-	e.LastUpdate = time.Now().UnixMilli()
+	now := time.Now()
+	e.LastUpdate = now.UnixMilli()
+	if e.TraceParent == "" {
+		e.TraceParent = sdk.FormatTraceParent(sdk.NewTraceID(), sdk.TraceSpanID(e.UUID))
+	}
+	if e.StatusTimestamps == nil {
+		e.StatusTimestamps = make(map[string]int64)
+	}
+	if _, has := e.StatusTimestamps[e.Status]; !has {
+		e.StatusTimestamps[e.Status] = now.UnixNano()
+	}
 	k := strings.ToLower(cache.Key(repositoryEventRootKey, d.GetRepositoryMemberKey(e.VCSServerName, e.RepositoryName)))
 	return d.store.SetAdd(k, e.UUID, e)
 }
@@ -24,17 +24,57 @@ func (s *Service) extractDataFromPayload(ctx context.Context, _ http.Header, vcs
 		return s.extractDataFromGiteaRequest(body, eventName)
 	case sdk.VCSTypeForgejo:
 		return s.extractDataFromForgejoRequest(ctx, body, eventName, eventType)
+	case sdk.VCSTypeAzureDevOps:
+		return s.extractDataFromAzureDevOpsRequest(body)
 	default:
 		return "", sdk.HookRepositoryEventExtractData{}, sdk.WithStack(sdk.ErrNotImplemented)
 	}
 }
 
+// extractAllDataFromPayload returns the data of each ref updated by the event.
+// Azure DevOps is the only provider that sends several ref updates in a single event.
+func (s *Service) extractAllDataFromPayload(ctx context.Context, header http.Header, vcsServerType string, body []byte, eventName, eventType string) (string, []sdk.HookRepositoryEventExtractData, error) {
+	if vcsServerType == sdk.VCSTypeAzureDevOps {
+		return s.extractAllDataFromAzureDevOpsRequest(body)
+	}
+	repoName, extractedData, err := s.extractDataFromPayload(ctx, header, vcsServerType, body, eventName, eventType)
+	if err != nil {
+		return "", nil, err
+	}
+	datas := []sdk.HookRepositoryEventExtractData{extractedData}
+	// Gitlab and Bitbucket Server have no dedicated event for branch creation, the push of a new branch also creates it
+	if (vcsServerType == sdk.VCSTypeGitlab || vcsServerType == sdk.VCSTypeBitbucketServer) && isBranchCreation(extractedData) {
+		datas = append(datas, branchCreateData(extractedData))
+	}
+	return repoName, datas, nil
+}
+
+// isBranchCreation returns true for the push of a new branch
+func isBranchCreation(data sdk.HookRepositoryEventExtractData) bool {
+	return data.CDSEventName == sdk.WorkflowHookEventNamePush && strings.HasPrefix(data.Ref, sdk.GitRefBranchPrefix) &&
+		data.CommitFrom == "" && data.Commit != "" && data.Commit != NoCommit
+}
+
+// branchCreateData returns the branch-create data of the push of a new branch
+func branchCreateData(push sdk.HookRepositoryEventExtractData) sdk.HookRepositoryEventExtractData {
+	return sdk.HookRepositoryEventExtractData{
+		CDSEventName: sdk.WorkflowHookEventNameBranchCreate,
+		Ref:          push.Ref,
+		Commit:       push.Commit,
+		Paths:        make([]string, 0),
+	}
+}
+
 func (s *Service) extractDataFromForgejoRequest(ctx context.Context, body []byte, eventName string, eventType string) (string, sdk.HookRepositoryEventExtractData, error) {
 	switch eventName {
 	case string(ForgejoEventPush):
 		return s.extractDataFromForgejoPushEvent(ctx, body)
 	case string(ForgejoEventPullRequest):
 		return s.extractDataFromForgejoPullRequestEvent(body, eventType)
+	case string(ForgejoEventCreate), string(ForgejoEventDelete):
+		return s.extractDataFromForgejoRefEvent(body, eventName)
+	case string(ForgejoEventRelease):
+		return s.extractDataFromForgejoReleaseEvent(body)
 	case string(ForgejoEventPullRequestComment): // Signle comment during a review
 		return s.extractDataFromForgejoPullRequestCommentEvent(body)
 	case string(ForgejoEventIssueComment): // Comment on a pull request or issue
@@ -51,6 +91,14 @@ func (s *Service) extractDataFromForgejoRequest(ctx context.Context, body []byte
 
 // Update file paths are not is gitea payload
 func (s *Service) extractDataFromGiteaRequest(body []byte, eventName string) (string, sdk.HookRepositoryEventExtractData, error) {
+	// Gitea and Forgejo send the same payloads for these events
+	switch eventName {
+	case string(ForgejoEventCreate), string(ForgejoEventDelete):
+		return s.extractDataFromForgejoRefEvent(body, eventName)
+	case string(ForgejoEventRelease):
+		return s.extractDataFromForgejoReleaseEvent(body)
+	}
+
 	extractedData := sdk.HookRepositoryEventExtractData{}
 	var request GiteaEventPayload
 	if err := sdk.JSONUnmarshal(body, &request); err != nil {
@@ -129,12 +177,40 @@ func (s *Service) extractDataFromGitlabRequest(body []byte, eventName string) (s
 	case "Push Hook":
 		extractedData.CDSEventName = sdk.WorkflowHookEventNamePush
 		extractedData.CDSEventType = "" // nothing here
+		// Gitlab has no dedicated event for branch deletion
+		if request.After == NoCommit && strings.HasPrefix(request.Ref, sdk.GitRefBranchPrefix) {
+			extractedData.CDSEventName = sdk.WorkflowHookEventNameBranchDelete
+			extractedData.Commit = ""
+			extractedData.CommitFrom = ""
+		}
 	case "Merge Request Hook":
 		extractedData.CDSEventName = sdk.WorkflowHookEventNamePullRequest
 		extractedData.CDSEventType = "" // nothing here
 	case "Note Hook":
 		extractedData.CDSEventName = sdk.WorkflowHookEventNamePullRequestComment
 		extractedData.CDSEventType = "" // nothing here
+	case "Release Hook":
+		extractedData.CDSEventName = sdk.WorkflowHookEventNameRelease
+		switch request.Action {
+		case "create":
+			extractedData.CDSEventType = sdk.WorkflowHookEventTypeReleasePublished
+		case "update":
+			extractedData.CDSEventType = sdk.WorkflowHookEventTypeReleaseEdited
+		case "delete":
+			extractedData.CDSEventType = sdk.WorkflowHookEventTypeReleaseDeleted
+		default:
+			extractedData.CDSEventType = sdk.WorkflowHookEventType(request.Action)
+		}
+		extractedData.Ref = sdk.GitRefTagPrefix + request.Tag
+		extractedData.Commit = ""
+		if request.ReleaseCommit != nil {
+			extractedData.Commit = request.ReleaseCommit.ID
+		}
+		extractedData.Release = &sdk.HookRepositoryEventExtractedDataRelease{
+			Tag:  request.Tag,
+			Name: request.Name,
+			URL:  request.URL,
+		}
 	default:
 		return "", extractedData, sdk.NewErrorFrom(sdk.ErrNotImplemented, "unknown event %q", eventName)
 	}
@@ -202,6 +278,28 @@ func (s *Service) extractDataFromGithubRequest(body []byte, eventName string) (s
 		if request.Comment != nil {
 			extractedData.Comment = request.Comment.Body
 		}
+	case "create", "delete":
+		if request.RefType != "branch" {
+			return "", extractedData, sdk.NewErrorFrom(sdk.ErrNotImplemented, "event %q not supported for %s", eventName, request.RefType)
+		}
+		extractedData.CDSEventName = sdk.WorkflowHookEventNameBranchCreate
+		if eventName == "delete" {
+			extractedData.CDSEventName = sdk.WorkflowHookEventNameBranchDelete
+		}
+		extractedData.Ref = sdk.GitRefBranchPrefix + request.Ref
+		extractedData.Commit = "" // not sent, resolved from the branch
+	case "release":
+		extractedData.CDSEventName = sdk.WorkflowHookEventNameRelease
+		extractedData.CDSEventType = sdk.WorkflowHookEventType(request.Action)
+		if request.Release != nil {
+			extractedData.Ref = sdk.GitRefTagPrefix + request.Release.TagName
+			extractedData.Release = &sdk.HookRepositoryEventExtractedDataRelease{
+				Tag:  request.Release.TagName,
+				Name: request.Release.Name,
+				URL:  request.Release.HTMLURL,
+			}
+		}
+		extractedData.Commit = "" // resolved from the tag
 	case "issue_comment":
 		// TODO: comment on a PR conversation; the issue_comment payload carries no
 		// head/base refs, so the PR refs must be resolved through the VCS API before
@@ -237,6 +335,12 @@ func (s *Service) extractDataFromBitbucketRequest(body []byte) (string, sdk.Hook
 		}
 		extractedData.CDSEventName = sdk.WorkflowHookEventNamePush
 		extractedData.CDSEventType = "" // no type here
+		// Bitbucket has no dedicated event for branch deletion
+		if request.Changes[0].Type == "DELETE" && strings.HasPrefix(request.Changes[0].RefID, sdk.GitRefBranchPrefix) {
+			extractedData.CDSEventName = sdk.WorkflowHookEventNameBranchDelete
+			extractedData.Commit = ""
+			extractedData.CommitFrom = ""
+		}
 	case "pr:opened":
 		extractedData.PullRequestID = int64(request.PullRequest.ID)
 		extractedData.Ref = request.PullRequest.FromRef.ID
@@ -0,0 +1,182 @@
+package hooks
+
+import (
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Azure DevOps service hooks do not send the event name in a header, it is read from the payload
+func (s *Service) extractDataFromAzureDevOpsRequest(body []byte) (string, sdk.HookRepositoryEventExtractData, error) {
+	repoName, extractedData, err := s.extractAllDataFromAzureDevOpsRequest(body)
+	if err != nil {
+		return "", sdk.HookRepositoryEventExtractData{}, err
+	}
+	return repoName, extractedData[0], nil
+}
+
+// extractAllDataFromAzureDevOpsRequest returns the data of each ref updated by the event. Always returns at least one data if there is no error.
+func (s *Service) extractAllDataFromAzureDevOpsRequest(body []byte) (string, []sdk.HookRepositoryEventExtractData, error) {
+	var event AzureDevOpsEvent
+	if err := sdk.JSONUnmarshal(body, &event); err != nil {
+		return "", nil, sdk.WrapError(err, "unable to read azuredevops request: %s", string(body))
+	}
+
+	var repoName string
+	var extractedData sdk.HookRepositoryEventExtractData
+	var err error
+	switch event.EventType {
+	case AzureDevOpsEventPush:
+		return s.extractDataFromAzureDevOpsPushEvent(body)
+	case AzureDevOpsEventPullRequestCreated, AzureDevOpsEventPullRequestUpdated, AzureDevOpsEventPullRequestMerged:
+		repoName, extractedData, err = s.extractDataFromAzureDevOpsPullRequestEvent(body)
+	case AzureDevOpsEventPullRequestComment:
+		repoName, extractedData, err = s.extractDataFromAzureDevOpsPullRequestCommentEvent(body)
+	default:
+		return "", nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "unknown event %q", event.EventType)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return repoName, []sdk.HookRepositoryEventExtractData{extractedData}, nil
+}
+
+// A push can update several refs, a data is returned for each one
+func (s *Service) extractDataFromAzureDevOpsPushEvent(body []byte) (string, []sdk.HookRepositoryEventExtractData, error) {
+	var request AzureDevOpsPushEvent
+	if err := sdk.JSONUnmarshal(body, &request); err != nil {
+		return "", nil, sdk.WrapError(err, "unable to read azuredevops push event: %s", string(body))
+	}
+
+	// The commits of the push are not linked to a ref, their changes are only used if a single ref was updated.
+	// Otherwise the changesets are computed from the commits of each ref.
+	paths := make([]string, 0)
+	if len(request.Resource.RefUpdates) == 1 {
+		paths = azureDevOpsChangedPaths(request.Resource.Commits)
+	}
+
+	extractedData := make([]sdk.HookRepositoryEventExtractData, 0, len(request.Resource.RefUpdates))
+	for _, refUpdate := range request.Resource.RefUpdates {
+		data := sdk.HookRepositoryEventExtractData{
+			CDSEventName: sdk.WorkflowHookEventNamePush,
+			CDSEventType: "", // nothing here
+			Ref:          refUpdate.Name,
+			Commit:       refUpdate.NewObjectID,
+			Paths:        paths,
+		}
+		if refUpdate.OldObjectID != NoCommit {
+			data.CommitFrom = refUpdate.OldObjectID
+		}
+		// Azure DevOps has no dedicated event for ref deletion
+		if refUpdate.NewObjectID == NoCommit {
+			if !strings.HasPrefix(refUpdate.Name, sdk.GitRefBranchPrefix) {
+				continue
+			}
+			data.CDSEventName = sdk.WorkflowHookEventNameBranchDelete
+			data.Commit = ""
+			data.CommitFrom = ""
+			data.Paths = make([]string, 0)
+		}
+		extractedData = append(extractedData, data)
+		// Nor for branch creation, the push of a new branch also creates it
+		if isBranchCreation(data) {
+			extractedData = append(extractedData, branchCreateData(data))
+		}
+	}
+	if len(extractedData) == 0 {
+		return "", nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "no ref update to process in azuredevops push event")
+	}
+
+	return request.Resource.Repository.FullName(), extractedData, nil
+}
+
+// azureDevOpsChangedPaths returns the files updated by the commits, if the changes were sent in the payload
+func azureDevOpsChangedPaths(commits []AzureDevOpsCommit) []string {
+	paths := make([]string, 0)
+	for _, c := range commits {
+		for _, change := range c.Changes {
+			if change.Item.Path != "" {
+				paths = append(paths, strings.TrimPrefix(change.Item.Path, "/"))
+			}
+			if change.OriginalPath != "" {
+				paths = append(paths, strings.TrimPrefix(change.OriginalPath, "/"))
+			}
+		}
+	}
+	return paths
+}
+
+func (s *Service) extractDataFromAzureDevOpsPullRequestEvent(body []byte) (string, sdk.HookRepositoryEventExtractData, error) {
+	extractedData := sdk.HookRepositoryEventExtractData{}
+	var request AzureDevOpsPullRequestEvent
+	if err := sdk.JSONUnmarshal(body, &request); err != nil {
+		return "", extractedData, sdk.WrapError(err, "unable to read azuredevops pull request event: %s", string(body))
+	}
+
+	extractedData.CDSEventName = sdk.WorkflowHookEventNamePullRequest
+
+	// Azure DevOps has no action field, the kind of update is deduced from the pull request status
+	switch request.EventType {
+	case AzureDevOpsEventPullRequestCreated:
+		extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestOpened
+	case AzureDevOpsEventPullRequestUpdated:
+		switch {
+		case request.Resource.Status == AzureDevOpsPullRequestStatusAbandoned, request.Resource.Status == AzureDevOpsPullRequestStatusCompleted:
+			extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestClosed
+		case strings.Contains(request.Message.Text, "reactivated"):
+			extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestReopened
+		default:
+			extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestEdited
+		}
+	case AzureDevOpsEventPullRequestMerged:
+		// Sent on each merge attempt, only a completed pull request is closed
+		if request.Resource.Status != AzureDevOpsPullRequestStatusCompleted {
+			return "", extractedData, sdk.NewErrorFrom(sdk.ErrNotImplemented, "merge attempt on an %s pull request is ignored", request.Resource.Status)
+		}
+		extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestClosed
+	}
+
+	fillAzureDevOpsPullRequestData(&extractedData, request.Resource)
+
+	if !extractedData.CDSEventType.IsValidForEventName(extractedData.CDSEventName) {
+		return "", extractedData, sdk.NewErrorFrom(sdk.ErrNotImplemented, "unknown action %q for event %q", extractedData.CDSEventType, extractedData.CDSEventName)
+	}
+
+	return request.Resource.Repository.FullName(), extractedData, nil
+}
+
+func (s *Service) extractDataFromAzureDevOpsPullRequestCommentEvent(body []byte) (string, sdk.HookRepositoryEventExtractData, error) {
+	extractedData := sdk.HookRepositoryEventExtractData{}
+	var request AzureDevOpsPullRequestCommentEvent
+	if err := sdk.JSONUnmarshal(body, &request); err != nil {
+		return "", extractedData, sdk.WrapError(err, "unable to read azuredevops pull request comment event: %s", string(body))
+	}
+
+	comment := request.Resource.Comment
+	extractedData.CDSEventName = sdk.WorkflowHookEventNamePullRequestComment
+	switch {
+	case comment.IsDeleted:
+		extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestCommentDeleted
+	case comment.LastContentUpdatedDate.After(comment.PublishedDate):
+		extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestCommentEdited
+	default:
+		extractedData.CDSEventType = sdk.WorkflowHookEventTypePullRequestCommentCreated
+	}
+	extractedData.Comment = comment.Content
+
+	fillAzureDevOpsPullRequestData(&extractedData, request.Resource.PullRequest)
+
+	return request.Resource.PullRequest.Repository.FullName(), extractedData, nil
+}
+
+func fillAzureDevOpsPullRequestData(extractedData *sdk.HookRepositoryEventExtractData, pr AzureDevOpsPullRequest) {
+	extractedData.PullRequestID = pr.PullRequestID
+	extractedData.Ref = pr.SourceRefName
+	extractedData.PullRequestRefTo = pr.TargetRefName
+	if pr.LastMergeSourceCommit != nil {
+		extractedData.Commit = pr.LastMergeSourceCommit.CommitID
+	}
+	if pr.LastMergeTargetCommit != nil {
+		extractedData.CommitFrom = pr.LastMergeTargetCommit.CommitID
+	}
+}
@@ -0,0 +1,253 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/engine/vcs/azuredevops"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+const azureDevOpsPushEvent = `{
+  "subscriptionId": "00000000-0000-0000-0000-000000000000",
+  "notificationId": 3,
+  "id": "03c164c2-8912-4d5e-8009-3707d5f83734",
+  "eventType": "git.push",
+  "publisherId": "tfs",
+  "message": {"text": "Jamal Hartnett pushed updates to fabrikam/website:main."},
+  "resource": {
+    "commits": [
+      {
+        "commitId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74",
+        "author": {"name": "Jamal Hartnett", "email": "fabrikamfiber4@hotmail.com", "date": "2015-02-25T19:01:00Z"},
+        "committer": {"name": "Jamal Hartnett", "email": "fabrikamfiber4@hotmail.com", "date": "2015-02-25T19:01:00Z"},
+        "comment": "Fixed bug in web.config file"
+      }
+    ],
+    "refUpdates": [
+      {
+        "name": "refs/heads/main",
+        "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a",
+        "newObjectId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74"
+      }
+    ],
+    "repository": {
+      "id": "278d5cd2-584d-4b63-824a-2ba458937249",
+      "name": "website",
+      "project": {"id": "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c", "name": "fabrikam"},
+      "defaultBranch": "refs/heads/main"
+    },
+    "pushedBy": {"id": "00067FFED5C7AF52@Live.com", "displayName": "Jamal Hartnett", "uniqueName": "Windows Live ID\\fabrikamfiber4@hotmail.com"},
+    "pushId": 14
+  }
+}`
+
+const azureDevOpsPullRequestEvent = `{
+  "id": "2ab4e3d3-b7a6-425e-92b1-5a9982c1269e",
+  "eventType": "git.pullrequest.updated",
+  "publisherId": "tfs",
+  "message": {"text": "Jamal Hartnett updated the source branch of pull request 1 (Dependency updates)"},
+  "resource": {
+    "repository": {
+      "id": "4bc14d40-c903-45e2-872e-0462c7748079",
+      "name": "website",
+      "project": {"id": "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c", "name": "fabrikam"}
+    },
+    "pullRequestId": 1,
+    "status": "active",
+    "title": "Dependency updates",
+    "sourceRefName": "refs/heads/feat/deps",
+    "targetRefName": "refs/heads/main",
+    "mergeStatus": "succeeded",
+    "lastMergeSourceCommit": {"commitId": "53d54ac915144006c2c9e90d2c7d3880920db49c"},
+    "lastMergeTargetCommit": {"commitId": "a511f535b1ea495ee0c903badb68fbc83772c882"}
+  }
+}`
+
+const azureDevOpsPullRequestCommentEvent = `{
+  "id": "af07be1b-f3ad-44c8-a7f1-c4835f2df06b",
+  "eventType": "ms.vss-code.git-pullrequest-comment-event",
+  "publisherId": "tfs",
+  "message": {"text": "Jamal Hartnett has edited a pull request comment"},
+  "resource": {
+    "comment": {
+      "id": 2,
+      "content": "/cds run",
+      "author": {"displayName": "Jamal Hartnett"},
+      "publishedDate": "2014-06-17T16:55:46.589Z",
+      "lastContentUpdatedDate": "2014-06-17T16:59:46.589Z"
+    },
+    "pullRequest": {
+      "repository": {
+        "name": "website",
+        "project": {"name": "fabrikam"}
+      },
+      "pullRequestId": 1,
+      "status": "active",
+      "sourceRefName": "refs/heads/feat/deps",
+      "targetRefName": "refs/heads/main",
+      "lastMergeSourceCommit": {"commitId": "53d54ac915144006c2c9e90d2c7d3880920db49c"},
+      "lastMergeTargetCommit": {"commitId": "a511f535b1ea495ee0c903badb68fbc83772c882"}
+    }
+  }
+}`
+
+func TestExtractDataFromAzureDevOpsPushEvent(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromAzureDevOpsRequest([]byte(azureDevOpsPushEvent))
+	require.NoError(t, err)
+	require.Equal(t, "fabrikam/website", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNamePush, data.CDSEventName)
+	require.Equal(t, "refs/heads/main", data.Ref)
+	require.Equal(t, "33b55f7cb7e7e245323987634f960cf4a6e6bc74", data.Commit)
+	require.Equal(t, "aad331d8d3b131fa9ae03cf5e53965b51942618a", data.CommitFrom)
+}
+
+const azureDevOpsMultiRefPushEvent = `{
+  "id": "03c164c2-8912-4d5e-8009-3707d5f83735",
+  "eventType": "git.push",
+  "publisherId": "tfs",
+  "resource": {
+    "commits": [
+      {
+        "commitId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74",
+        "comment": "Fixed bug in web.config file",
+        "changes": [{"changeType": "edit", "item": {"path": "/src/web.config"}}]
+      }
+    ],
+    "refUpdates": [
+      {"name": "refs/heads/main", "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a", "newObjectId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74"},
+      {"name": "refs/heads/feat/old", "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a", "newObjectId": "0000000000000000000000000000000000000000"},
+      {"name": "refs/tags/v1.0", "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a", "newObjectId": "0000000000000000000000000000000000000000"},
+      {"name": "refs/heads/feat/new", "oldObjectId": "0000000000000000000000000000000000000000", "newObjectId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74"}
+    ],
+    "repository": {"id": "278d5cd2-584d-4b63-824a-2ba458937249", "name": "website", "project": {"name": "fabrikam"}}
+  }
+}`
+
+func TestExtractDataFromAzureDevOpsMultiRefPushEvent(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractAllDataFromPayload(context.TODO(), nil, sdk.VCSTypeAzureDevOps, []byte(azureDevOpsMultiRefPushEvent), "", "")
+	require.NoError(t, err)
+	require.Equal(t, "fabrikam/website", repoName)
+	require.Len(t, data, 4)
+
+	require.Equal(t, sdk.WorkflowHookEventNamePush, data[0].CDSEventName)
+	require.Equal(t, "refs/heads/main", data[0].Ref)
+	require.Equal(t, "33b55f7cb7e7e245323987634f960cf4a6e6bc74", data[0].Commit)
+	// Commits are not linked to a ref, changesets are computed later
+	require.Empty(t, data[0].Paths)
+
+	require.Equal(t, sdk.WorkflowHookEventNameBranchDelete, data[1].CDSEventName)
+	require.Equal(t, "refs/heads/feat/old", data[1].Ref)
+	require.Empty(t, data[1].Commit)
+
+	require.Equal(t, sdk.WorkflowHookEventNamePush, data[2].CDSEventName)
+	require.Equal(t, "refs/heads/feat/new", data[2].Ref)
+	require.Empty(t, data[2].CommitFrom)
+	// The push of a new branch also triggers branch-create workflows
+	require.Equal(t, sdk.WorkflowHookEventNameBranchCreate, data[3].CDSEventName)
+	require.Equal(t, "refs/heads/feat/new", data[3].Ref)
+	require.Equal(t, "33b55f7cb7e7e245323987634f960cf4a6e6bc74", data[3].Commit)
+
+	// With a single ref update, paths are read from the commit changes
+	single := strings.Replace(azureDevOpsMultiRefPushEvent, `"refUpdates": [
+      {"name": "refs/heads/main", "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a", "newObjectId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74"},`, `"refUpdates": [
+      {"name": "refs/heads/main", "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a", "newObjectId": "33b55f7cb7e7e245323987634f960cf4a6e6bc74"}],
+    "ignored": [`, 1)
+	_, data, err = s.extractAllDataFromPayload(context.TODO(), nil, sdk.VCSTypeAzureDevOps, []byte(single), "", "")
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	require.Equal(t, []string{"src/web.config"}, data[0].Paths)
+
+	// Only a tag deletion
+	_, _, err = s.extractAllDataFromPayload(context.TODO(), nil, sdk.VCSTypeAzureDevOps, []byte(`{"eventType": "git.push", "resource": {"refUpdates": [{"name": "refs/tags/v1.0", "oldObjectId": "aad331d8d3b131fa9ae03cf5e53965b51942618a", "newObjectId": "0000000000000000000000000000000000000000"}]}}`), "", "")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotImplemented))
+}
+
+// The service hook created by the Azure DevOps driver must be accepted by the hooks router
+func TestAzureDevOpsDriverHookThroughRouter(t *testing.T) {
+	var sub map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /myorg/fabrikam/_apis/git/repositories/website":
+			_, _ = w.Write([]byte(`{"id": "278d5cd2-584d-4b63-824a-2ba458937249", "name": "website", "project": {"id": "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c", "name": "fabrikam"}}`))
+		case "POST /myorg/_apis/hooks/subscriptions":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sub))
+			_, _ = w.Write([]byte(`{"id": "sub-1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := mock_cdsclient.NewMockInterface(ctrl)
+	s := &Service{}
+	s.Client = mockClient
+	s.Cfg.RepositoryWebHookKey = "my-hook-key"
+
+	vars := map[string]string{"projectKey": "PROJ", "vcsServerType": sdk.VCSTypeAzureDevOps, "vcsServer": "my-azure", "uuid": "hook-uuid"}
+	secret := sdk.GenerateRepositoryWebHookSecret(s.Cfg.RepositoryWebHookKey, vars["projectKey"], vars["vcsServer"], "fabrikam/website", vars["uuid"])
+
+	consumer := azuredevops.New(srv.URL+"/myorg", "", "", "my-pat")
+	client, err := consumer.GetAuthorizedClient(context.TODO(), sdk.VCSAuth{})
+	require.NoError(t, err)
+	require.Error(t, client.CreateHook(context.TODO(), "fabrikam/website", &sdk.VCSHook{URL: "https://hooks/v2/webhook/repository", Events: []string{"git.push"}}))
+	require.NoError(t, client.CreateHook(context.TODO(), "fabrikam/website", &sdk.VCSHook{URL: "https://hooks/v2/webhook/repository", Events: []string{"git.push"}, Secret: secret}))
+	inputs := sub["consumerInputs"].(map[string]interface{})
+
+	newRequest := func(password string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v2/webhook/repository/PROJ/azuredevops/my-azure/hook-uuid", strings.NewReader(azureDevOpsPushEvent))
+		req.SetBasicAuth(inputs["basicAuthUsername"].(string), password)
+		return mux.SetURLVars(req, vars)
+	}
+	middleware := s.CheckRepositoryHmac256Signature("X-Hub-Signature-256")
+
+	mockClient.EXPECT().ProjectWebHookGet(gomock.Any(), "PROJ", "hook-uuid").Return(&sdk.ProjectWebHook{}, nil)
+	req := newRequest(inputs["basicAuthPassword"].(string))
+	_, err = middleware(context.TODO(), httptest.NewRecorder(), req, &service.HandlerConfig{})
+	require.NoError(t, err)
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, azureDevOpsPushEvent, string(body))
+
+	_, err = middleware(context.TODO(), httptest.NewRecorder(), newRequest("wrong-secret"), &service.HandlerConfig{})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+}
+
+func TestExtractDataFromAzureDevOpsPullRequestEvent(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromAzureDevOpsRequest([]byte(azureDevOpsPullRequestEvent))
+	require.NoError(t, err)
+	require.Equal(t, "fabrikam/website", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNamePullRequest, data.CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventTypePullRequestEdited, data.CDSEventType)
+	require.Equal(t, int64(1), data.PullRequestID)
+	require.Equal(t, "refs/heads/feat/deps", data.Ref)
+	require.Equal(t, "refs/heads/main", data.PullRequestRefTo)
+	require.Equal(t, "53d54ac915144006c2c9e90d2c7d3880920db49c", data.Commit)
+}
+
+func TestExtractDataFromAzureDevOpsPullRequestCommentEvent(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromAzureDevOpsRequest([]byte(azureDevOpsPullRequestCommentEvent))
+	require.NoError(t, err)
+	require.Equal(t, "fabrikam/website", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNamePullRequestComment, data.CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventTypePullRequestCommentEdited, data.CDSEventType)
+	require.Equal(t, "/cds run", data.Comment)
+	require.Equal(t, int64(1), data.PullRequestID)
+}
@@ -148,3 +148,61 @@ func (s *Service) extractDataFromForgejoIssueCommentPREvent(body []byte) (string
 
 	return repoName, extractedData, nil
 }
+
+func (s *Service) extractDataFromForgejoRefEvent(body []byte, eventName string) (string, sdk.HookRepositoryEventExtractData, error) {
+	extractedData := sdk.HookRepositoryEventExtractData{}
+	var request ForgejoRefPayload
+	if err := sdk.JSONUnmarshal(body, &request); err != nil {
+		return "", extractedData, sdk.WrapError(err, "unable ro read forgejo %s event: %s", eventName, string(body))
+	}
+	if request.RefType != "branch" {
+		return "", extractedData, sdk.NewErrorFrom(sdk.ErrNotImplemented, "event %q not supported for %s", eventName, request.RefType)
+	}
+	var repoName string
+	if request.Repository != nil {
+		repoName = request.Repository.FullName
+	}
+
+	extractedData.CDSEventName = sdk.WorkflowHookEventNameBranchCreate
+	if eventName == string(ForgejoEventDelete) {
+		extractedData.CDSEventName = sdk.WorkflowHookEventNameBranchDelete
+	} else {
+		extractedData.Commit = request.Sha
+	}
+	extractedData.Ref = sdk.GitRefBranchPrefix + request.Ref
+	return repoName, extractedData, nil
+}
+
+func (s *Service) extractDataFromForgejoReleaseEvent(body []byte) (string, sdk.HookRepositoryEventExtractData, error) {
+	extractedData := sdk.HookRepositoryEventExtractData{}
+	var request ForgejoReleasePayload
+	if err := sdk.JSONUnmarshal(body, &request); err != nil {
+		return "", extractedData, sdk.WrapError(err, "unable ro read forgejo release event: %s", string(body))
+	}
+	if request.Release == nil {
+		return "", extractedData, sdk.NewErrorFrom(sdk.ErrInvalidData, "missing release in forgejo release event")
+	}
+	var repoName string
+	if request.Repository != nil {
+		repoName = request.Repository.FullName
+	}
+
+	extractedData.CDSEventName = sdk.WorkflowHookEventNameRelease
+	switch request.Action {
+	case "updated":
+		extractedData.CDSEventType = sdk.WorkflowHookEventTypeReleaseEdited
+	default:
+		extractedData.CDSEventType = sdk.WorkflowHookEventType(request.Action)
+	}
+	extractedData.Ref = sdk.GitRefTagPrefix + request.Release.TagName
+	extractedData.Release = &sdk.HookRepositoryEventExtractedDataRelease{
+		Tag:  request.Release.TagName,
+		Name: request.Release.Name,
+		URL:  request.Release.HTMLURL,
+	}
+
+	if !extractedData.CDSEventType.IsValidForEventName(extractedData.CDSEventName) {
+		return "", extractedData, sdk.NewErrorFrom(sdk.ErrNotImplemented, "unknown action %q for event %q", extractedData.CDSEventType, extractedData.CDSEventName)
+	}
+	return repoName, extractedData, nil
+}
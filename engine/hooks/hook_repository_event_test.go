@@ -0,0 +1,187 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const githubCreateBranchEvent = `{
+  "ref": "feat/preview",
+  "ref_type": "branch",
+  "master_branch": "main",
+  "pusher_type": "user",
+  "repository": {"full_name": "ovh/cds"}
+}`
+
+const githubDeleteTagEvent = `{
+  "ref": "v1.0.0",
+  "ref_type": "tag",
+  "pusher_type": "user",
+  "repository": {"full_name": "ovh/cds"}
+}`
+
+const githubReleaseEvent = `{
+  "action": "published",
+  "release": {
+    "tag_name": "v1.2.0",
+    "name": "Release 1.2.0",
+    "html_url": "https://github.com/ovh/cds/releases/tag/v1.2.0",
+    "target_commitish": "main"
+  },
+  "repository": {"full_name": "ovh/cds"}
+}`
+
+const gitlabReleaseEvent = `{
+  "object_kind": "release",
+  "action": "update",
+  "tag": "v1.2.0",
+  "name": "Release 1.2.0",
+  "url": "https://gitlab.com/ovh/cds/-/releases/v1.2.0",
+  "project": {"path_with_namespace": "ovh/cds"},
+  "commit": {"id": "ee0c903badb68fbc83772c882a511f535b1ea495"}
+}`
+
+const gitlabDeleteBranchEvent = `{
+  "object_kind": "push",
+  "before": "a511f535b1ea495ee0c903badb68fbc83772c882",
+  "after": "0000000000000000000000000000000000000000",
+  "ref": "refs/heads/feat/preview",
+  "project": {"path_with_namespace": "ovh/cds"},
+  "commits": []
+}`
+
+const gitlabCreateBranchEvent = `{
+  "object_kind": "push",
+  "before": "0000000000000000000000000000000000000000",
+  "after": "ee0c903badb68fbc83772c882a511f535b1ea495",
+  "ref": "refs/heads/feat/preview",
+  "project": {"path_with_namespace": "ovh/cds"},
+  "commits": []
+}`
+
+const forgejoReleaseEvent = `{
+  "action": "updated",
+  "release": {
+    "tag_name": "v1.2.0",
+    "name": "Release 1.2.0",
+    "html_url": "https://codeberg.org/ovh/cds/releases/tag/v1.2.0"
+  },
+  "repository": {"full_name": "ovh/cds"}
+}`
+
+const forgejoCreateBranchEvent = `{
+  "ref": "feat/preview",
+  "ref_type": "branch",
+  "sha": "ee0c903badb68fbc83772c882a511f535b1ea495",
+  "repository": {"full_name": "ovh/cds"}
+}`
+
+func TestExtractDataFromGithubBranchEvents(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromGithubRequest([]byte(githubCreateBranchEvent), "create")
+	require.NoError(t, err)
+	require.Equal(t, "ovh/cds", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchCreate, data.CDSEventName)
+	require.Equal(t, "refs/heads/feat/preview", data.Ref)
+	require.Empty(t, data.Commit)
+
+	_, data, err = s.extractDataFromGithubRequest([]byte(githubCreateBranchEvent), "delete")
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchDelete, data.CDSEventName)
+	require.Equal(t, "refs/heads/feat/preview", data.Ref)
+
+	_, _, err = s.extractDataFromGithubRequest([]byte(githubDeleteTagEvent), "delete")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotImplemented))
+}
+
+func TestExtractDataFromGithubReleaseEvent(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromGithubRequest([]byte(githubReleaseEvent), "release")
+	require.NoError(t, err)
+	require.Equal(t, "ovh/cds", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNameRelease, data.CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventTypeReleasePublished, data.CDSEventType)
+	require.Equal(t, "refs/tags/v1.2.0", data.Ref)
+	require.Equal(t, &sdk.HookRepositoryEventExtractedDataRelease{
+		Tag:  "v1.2.0",
+		Name: "Release 1.2.0",
+		URL:  "https://github.com/ovh/cds/releases/tag/v1.2.0",
+	}, data.Release)
+
+	// Draft releases are not handled
+	_, _, err = s.extractDataFromGithubRequest([]byte(`{"action": "created", "release": {"tag_name": "v1.2.0"}}`), "release")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotImplemented))
+}
+
+func TestExtractDataFromGitlabReleaseAndBranchDeleteEvents(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromGitlabRequest([]byte(gitlabReleaseEvent), "Release Hook")
+	require.NoError(t, err)
+	require.Equal(t, "ovh/cds", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNameRelease, data.CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventTypeReleaseEdited, data.CDSEventType)
+	require.Equal(t, "refs/tags/v1.2.0", data.Ref)
+	require.Equal(t, "ee0c903badb68fbc83772c882a511f535b1ea495", data.Commit)
+	require.Equal(t, "v1.2.0", data.Release.Tag)
+
+	_, data, err = s.extractDataFromGitlabRequest([]byte(gitlabDeleteBranchEvent), "Push Hook")
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchDelete, data.CDSEventName)
+	require.Equal(t, "refs/heads/feat/preview", data.Ref)
+	require.Empty(t, data.Commit)
+	require.Empty(t, data.CommitFrom)
+
+	// The push of a new branch also triggers branch-create workflows
+	_, datas, err := s.extractAllDataFromPayload(context.TODO(), nil, sdk.VCSTypeGitlab, []byte(gitlabCreateBranchEvent), "Push Hook", "")
+	require.NoError(t, err)
+	require.Len(t, datas, 2)
+	require.Equal(t, sdk.WorkflowHookEventNamePush, datas[0].CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchCreate, datas[1].CDSEventName)
+	require.Equal(t, "refs/heads/feat/preview", datas[1].Ref)
+	require.Equal(t, "ee0c903badb68fbc83772c882a511f535b1ea495", datas[1].Commit)
+
+	_, datas, err = s.extractAllDataFromPayload(context.TODO(), nil, sdk.VCSTypeGitlab, []byte(gitlabDeleteBranchEvent), "Push Hook", "")
+	require.NoError(t, err)
+	require.Len(t, datas, 1)
+}
+
+func TestExtractDataFromForgejoReleaseAndBranchEvents(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromForgejoRequest(context.TODO(), []byte(forgejoReleaseEvent), string(ForgejoEventRelease), string(ForgejoEventTypeRelease))
+	require.NoError(t, err)
+	require.Equal(t, "ovh/cds", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNameRelease, data.CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventTypeReleaseEdited, data.CDSEventType)
+	require.Equal(t, "refs/tags/v1.2.0", data.Ref)
+
+	_, data, err = s.extractDataFromForgejoRequest(context.TODO(), []byte(forgejoCreateBranchEvent), string(ForgejoEventCreate), string(ForgejoEventTypeCreate))
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchCreate, data.CDSEventName)
+	require.Equal(t, "refs/heads/feat/preview", data.Ref)
+	require.Equal(t, "ee0c903badb68fbc83772c882a511f535b1ea495", data.Commit)
+}
+
+func TestExtractDataFromGiteaReleaseAndBranchEvents(t *testing.T) {
+	s := &Service{}
+	repoName, data, err := s.extractDataFromGiteaRequest([]byte(forgejoReleaseEvent), "release")
+	require.NoError(t, err)
+	require.Equal(t, "ovh/cds", repoName)
+	require.Equal(t, sdk.WorkflowHookEventNameRelease, data.CDSEventName)
+	require.Equal(t, sdk.WorkflowHookEventTypeReleaseEdited, data.CDSEventType)
+	require.Equal(t, "refs/tags/v1.2.0", data.Ref)
+
+	_, data, err = s.extractDataFromGiteaRequest([]byte(forgejoCreateBranchEvent), "create")
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchCreate, data.CDSEventName)
+	require.Equal(t, "refs/heads/feat/preview", data.Ref)
+	require.Equal(t, "ee0c903badb68fbc83772c882a511f535b1ea495", data.Commit)
+
+	_, data, err = s.extractDataFromGiteaRequest([]byte(forgejoCreateBranchEvent), "delete")
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowHookEventNameBranchDelete, data.CDSEventName)
+	require.Empty(t, data.Commit)
+}
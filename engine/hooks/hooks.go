@@ -192,6 +192,10 @@ func (s *Service) Serve(c context.Context) error {
 		s.GoRoutines.RunWithRestart(ctx, "schedulerv2", func(ctx context.Context) {
 			s.schedulerExecutionRoutine(ctx)
 		})
+
+		s.GoRoutines.RunWithRestart(ctx, "pollRepositoriesV2", func(ctx context.Context) {
+			s.pollRepositoriesV2(ctx)
+		})
 	}
 
 	if s.Cfg.WebhooksPublicKeySign != "" {
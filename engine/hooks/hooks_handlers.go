@@ -246,6 +246,28 @@ func (s *Service) handleManualWorkflowEvent(ctx context.Context, runRequest sdk.
 	return exec, nil
 }
 
+func (s *Service) workflowMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var req sdk.HookMergeQueueRequest
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		extractedData := sdk.HookRepositoryEventExtractData{
+			CDSEventName:     sdk.WorkflowHookEventNameMergeQueue,
+			Commit:           req.Commit,
+			Ref:              sdk.GitRefBranchPrefix + req.Branch,
+			PullRequestRefTo: sdk.GitRefBranchPrefix + req.BaseBranch,
+			HookProjectKey:   req.ProjectKey,
+		}
+		exec, err := s.handleRepositoryEvent(ctx, req.VCSServer, strings.ToLower(req.Repository), extractedData, nil)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, exec, http.StatusAccepted)
+	}
+}
+
 func (s *Service) getOutgoingHooksExecutionsByWorkflowHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -352,18 +374,22 @@ func (s *Service) repositoryHooksHandler() service.Handler {
 			return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to read body: %v", err)
 		}
 
-		repoName, extractData, err := s.extractDataFromPayload(ctx, r.Header, vcsType, body, eventName, eventType)
+		repoName, extractData, err := s.extractAllDataFromPayload(ctx, r.Header, vcsType, body, eventName, eventType)
 		if err != nil {
 			return err
 		}
 
-		exec, err := s.handleRepositoryEvent(ctx, vcsName, strings.ToLower(repoName), extractData, body)
-		if err != nil {
-			log.ErrorWithStackTrace(ctx, err)
-			return err
+		execs := make([]*sdk.HookRepositoryEvent, 0, len(extractData))
+		for _, data := range extractData {
+			exec, err := s.handleRepositoryEvent(ctx, vcsName, strings.ToLower(repoName), data, body)
+			if err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+				return err
+			}
+			execs = append(execs, exec)
 		}
 
-		return service.WriteJSON(w, exec, http.StatusAccepted)
+		return service.WriteJSON(w, execs, http.StatusAccepted)
 	}
 }
 
@@ -423,18 +449,22 @@ func (s *Service) repositoryWebHookHandler() service.Handler {
 			return err
 		}
 
-		repoName, extractedData, err := s.extractDataFromPayload(ctx, r.Header, vcsServerType, body, eventName, eventType)
+		repoName, extractedData, err := s.extractAllDataFromPayload(ctx, r.Header, vcsServerType, body, eventName, eventType)
 		if err != nil {
 			return err
 		}
-		extractedData.HookProjectKey = projKey
 
-		exec, err := s.handleRepositoryEvent(ctx, vcsServerName, strings.ToLower(repoName), extractedData, body)
-		if err != nil {
-			return err
+		execs := make([]*sdk.HookRepositoryEvent, 0, len(extractedData))
+		for _, data := range extractedData {
+			data.HookProjectKey = projKey
+			exec, err := s.handleRepositoryEvent(ctx, vcsServerName, strings.ToLower(repoName), data, body)
+			if err != nil {
+				return err
+			}
+			execs = append(execs, exec)
 		}
 
-		return service.WriteJSON(w, exec, http.StatusAccepted)
+		return service.WriteJSON(w, execs, http.StatusAccepted)
 	}
 }
 
@@ -459,6 +489,16 @@ func (s *Service) handleRepositoryEvent(ctx context.Context, vcsServerName strin
 		SignKey:        extractedData.CommitGpgKeyID,
 	}
 
+	// Temporary merge queue branches are only tested through merge-queue events
+	if extractedData.CDSEventName != sdk.WorkflowHookEventNameMergeQueue && strings.HasPrefix(extractedData.Ref, sdk.GitRefBranchPrefix+sdk.MergeQueueBranchPrefix) {
+		exec.Status = sdk.HookEventStatusSkipped
+		exec.LastError = fmt.Sprintf("Event skipped. %s on a merge queue branch.", extractedData.CDSEventName)
+		if err := s.Dao.SaveRepositoryEvent(ctx, exec); err != nil {
+			return nil, sdk.WrapError(err, "unable to create repository event %s", exec.GetFullName())
+		}
+		return exec, nil
+	}
+
 	// Save event
 	if err := s.Dao.SaveRepositoryEvent(ctx, exec); err != nil {
 		return nil, sdk.WrapError(err, "unable to create repository event %s", exec.GetFullName())
@@ -488,6 +528,9 @@ func (s *Service) extractEventFromHeader(ctx context.Context, vcsServerType stri
 		eventType = ForgejoEventTypeHeader
 	case sdk.VCSTypeGitlab:
 		headerName = GitlabHeader
+	case sdk.VCSTypeAzureDevOps:
+		// Azure DevOps service hooks send the event type in the payload
+		return "", "", nil
 	default:
 		log.Warn(ctx, "invalid vcs server of type %s", vcsServerType)
 		return "", "", sdk.WithStack(sdk.ErrNotImplemented)
@@ -167,6 +167,8 @@ func (s *Service) postRestartRepositoryHookEventHandler() service.Handler {
 		e.Analyses = nil
 		e.LastUpdate = time.Now().UnixNano()
 		e.Initiator = nil
+		e.TraceParent = ""
+		e.StatusTimestamps = nil
 
 		if err := s.Dao.SaveRepositoryEvent(ctx, e); err != nil {
 			return err
@@ -58,6 +58,7 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/v2/repository/key/{projectKey}/{vcsServer}/{repoName}", nil, r.POST(s.postGenerateRepositoryWebHookSecretHandler))
 	r.Handle("/v2/workflow/key/{projectKey}/{vcsServer}/{repoName}/{workflowName}", nil, r.POST(s.postGenerateWorkflowWebHookSecretHandler))
 	r.Handle("/v2/workflow/manual", nil, r.POST(s.workflowManualHandler))
+	r.Handle("/v2/workflow/mergequeue", nil, r.POST(s.workflowMergeQueueHandler))
 	r.Handle("/v2/workflow/outgoing", nil, r.POST(s.workflowRunOutgoingEventHandler))
 	r.Handle("/v2/workflow/outgoing/{projectKey}", nil, r.DELETE(s.deleteOutgoingEventsByProjectHandler))
 
@@ -115,16 +116,21 @@ func (s *Service) CheckWorkflowHmac256Signature(headerName string) service.Middl
 
 func (s *Service) CheckRepositoryHmac256Signature(headerName string) service.Middleware {
 	return func(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
-		signHeaderValue := req.Header.Get(headerName)
-		if signHeaderValue == "" {
-			return ctx, sdk.WithStack(sdk.ErrForbidden)
-		}
 		vars := mux.Vars(req)
 		projectKey := vars["projectKey"]
 		vcsType := vars["vcsServerType"]
 		vcsName := vars["vcsServer"]
 		uuid := vars["uuid"]
 
+		// Azure DevOps service hooks cannot sign the payload, the secret is sent as basic auth password
+		signHeaderValue := req.Header.Get(headerName)
+		if vcsType == sdk.VCSTypeAzureDevOps {
+			_, signHeaderValue, _ = req.BasicAuth()
+		}
+		if signHeaderValue == "" {
+			return ctx, sdk.WithStack(sdk.ErrForbidden)
+		}
+
 		defer req.Body.Close() // nolint
 		body, err := io.ReadAll(req.Body)
 		if err != nil {
@@ -146,7 +152,11 @@ func (s *Service) CheckRepositoryHmac256Signature(headerName string) service.Mid
 
 		// Create a new HMAC by defining the hash type and the key (as byte array)
 		hookKey := sdk.GenerateRepositoryWebHookSecret(s.Cfg.RepositoryWebHookKey, projectKey, vcsName, repoName, uuid)
-		ctx, err = s.checkHmac246Signature(ctx, hookKey, body, signHeaderValue, projectKey, uuid)
+		if vcsType == sdk.VCSTypeAzureDevOps {
+			ctx, err = s.checkBasicAuthSecret(ctx, hookKey, signHeaderValue, projectKey, uuid)
+		} else {
+			ctx, err = s.checkHmac246Signature(ctx, hookKey, body, signHeaderValue, projectKey, uuid)
+		}
 		if err != nil {
 			return ctx, err
 		}
@@ -167,7 +177,18 @@ func (s *Service) checkHmac246Signature(ctx context.Context, hookKey string, bod
 		return ctx, sdk.WithStack(sdk.ErrForbidden)
 	}
 
-	// Check uuid
+	return s.checkWebHookUUID(ctx, projectKey, uuid)
+}
+
+func (s *Service) checkBasicAuthSecret(ctx context.Context, hookKey string, secret string, projectKey, uuid string) (context.Context, error) {
+	if !hmac.Equal([]byte(hookKey), []byte(secret)) {
+		log.Error(ctx, "basic auth secret mismatch for hook %s/%s", projectKey, uuid)
+		return ctx, sdk.WithStack(sdk.ErrForbidden)
+	}
+	return s.checkWebHookUUID(ctx, projectKey, uuid)
+}
+
+func (s *Service) checkWebHookUUID(ctx context.Context, projectKey, uuid string) (context.Context, error) {
 	if _, err := s.Client.ProjectWebHookGet(ctx, projectKey, uuid); err != nil {
 		log.Error(ctx, "unable to retrieve hook %s/%s: %v", projectKey, uuid, err)
 		return ctx, sdk.WithStack(sdk.ErrForbidden)
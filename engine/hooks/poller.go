@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	dump "github.com/fsamin/go-dump"
 	"github.com/rockbears/log"
 
+	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -121,3 +123,112 @@ func (s *Service) doPollerTaskExecution(ctx context.Context, task *sdk.Task, tas
 
 	return hookEvents, nil
 }
+
+// polledRefUpdate is the body of the repository events created by the poller
+type polledRefUpdate struct {
+	Ref    string `json:"ref"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after"`
+}
+
+// pollRepositoriesV2 periodically compares the refs of the repositories hosted on vcs servers that cannot send webhooks
+func (s *Service) pollRepositoriesV2(ctx context.Context) {
+	delay := s.Cfg.RepositoryPollingDelay
+	if delay <= 0 {
+		delay = 60
+	}
+	tick := time.NewTicker(time.Duration(delay) * time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting pollRepositoriesV2: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if s.Maintenance {
+				continue
+			}
+			if err := s.pollRepositories(ctx, time.Duration(delay)*time.Second); err != nil {
+				log.ErrorWithStackTrace(ctx, err)
+			}
+		}
+	}
+}
+
+func (s *Service) pollRepositories(ctx context.Context, lockTTL time.Duration) error {
+	repos, err := s.Client.HookPollingRepositoriesList(ctx)
+	if err != nil {
+		return sdk.WrapError(err, "unable to list polling repositories")
+	}
+	for _, r := range repos {
+		// Only one hooks instance polls a repository at a time. The lock is taken per repository, its TTL only
+		// has to cover the polling of a single repository.
+		lockKey := cache.Key(repositoryPollingLockKey, r.ProjectKey, r.VCSServerName, r.RepositoryName)
+		b, err := s.Dao.store.Lock(lockKey, lockTTL, 0, 1)
+		if err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to lock repository %s/%s on %s", r.ProjectKey, r.RepositoryName, r.VCSServerName))
+			continue
+		}
+		if !b {
+			continue
+		}
+		if err := s.pollRepository(ctx, r); err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to poll repository %s/%s on %s", r.ProjectKey, r.RepositoryName, r.VCSServerName))
+		}
+		if err := s.Dao.store.Unlock(lockKey); err != nil {
+			log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to unlock repository %s/%s on %s", r.ProjectKey, r.RepositoryName, r.VCSServerName))
+		}
+	}
+	return nil
+}
+
+// pollRepository creates a push event for each new or updated ref since the last poll.
+// On the first poll, refs are only stored.
+func (s *Service) pollRepository(ctx context.Context, r sdk.HookPollingRepository) error {
+	refs, err := s.Client.HookRepositoryRefs(ctx, r.ProjectKey, r.VCSServerName, r.RepositoryName)
+	if err != nil {
+		return err
+	}
+
+	k := cache.Key(repositoryPollingRootKey, r.ProjectKey, r.VCSServerName, r.RepositoryName)
+	var previousRefs sdk.HookRepositoryRefs
+	found, err := s.Dao.store.Get(k, &previousRefs)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		refNames := make([]string, 0, len(refs))
+		for ref := range refs {
+			refNames = append(refNames, ref)
+		}
+		sort.Strings(refNames)
+
+		for _, ref := range refNames {
+			commit := refs[ref]
+			before := previousRefs[ref]
+			if before == commit {
+				continue
+			}
+			body, err := json.Marshal(polledRefUpdate{Ref: ref, Before: before, After: commit})
+			if err != nil {
+				return sdk.WithStack(err)
+			}
+			extractedData := sdk.HookRepositoryEventExtractData{
+				CDSEventName:   sdk.WorkflowHookEventNamePush,
+				Ref:            ref,
+				Commit:         commit,
+				CommitFrom:     before,
+				HookProjectKey: r.ProjectKey,
+			}
+			if _, err := s.handleRepositoryEvent(ctx, r.VCSServerName, strings.ToLower(r.RepositoryName), extractedData, body); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.Dao.store.SetWithTTL(k, refs, 0)
+}
@@ -0,0 +1,64 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rockbears/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+func TestPollRepository(t *testing.T) {
+	log.Factory = log.NewTestingWrapper(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	ctx := context.TODO()
+
+	r := sdk.HookPollingRepository{ProjectKey: "PROJ", VCSServerName: "legacy", VCSServerType: sdk.VCSTypeGit, RepositoryName: "team/my-repo.git"}
+	k := cache.Key(repositoryPollingRootKey, r.ProjectKey, r.VCSServerName, r.RepositoryName)
+	require.NoError(t, s.Dao.store.Delete(k))
+	oldEvents, err := s.Dao.ListRepositoryEvents(ctx, "legacy", "team/my-repo.git")
+	require.NoError(t, err)
+	for _, e := range oldEvents {
+		require.NoError(t, s.Dao.DeleteRepositoryEvent(ctx, "legacy", "team/my-repo.git", e.UUID))
+	}
+	require.NoError(t, s.Dao.DeleteRepository(ctx, "legacy", "team/my-repo.git"))
+
+	mockClient := s.Client.(*mock_cdsclient.MockInterface)
+	mockClient.EXPECT().HookRepositoryRefs(gomock.Any(), "PROJ", "legacy", "team/my-repo.git").Return(sdk.HookRepositoryRefs{
+		"refs/heads/main": "aaaaaaaa",
+		"refs/tags/v1.0":  "bbbbbbbb",
+	}, nil)
+	mockClient.EXPECT().HookRepositoryRefs(gomock.Any(), "PROJ", "legacy", "team/my-repo.git").Return(sdk.HookRepositoryRefs{
+		"refs/heads/main":   "cccccccc",
+		"refs/heads/feat-a": "dddddddd",
+		"refs/tags/v1.0":    "bbbbbbbb",
+	}, nil)
+
+	// First poll only stores the refs
+	require.NoError(t, s.pollRepository(ctx, r))
+	events, err := s.Dao.ListRepositoryEvents(ctx, "legacy", "team/my-repo.git")
+	require.NoError(t, err)
+	require.Len(t, events, 0)
+
+	require.NoError(t, s.pollRepository(ctx, r))
+	events, err = s.Dao.ListRepositoryEvents(ctx, "legacy", "team/my-repo.git")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	byRef := make(map[string]sdk.HookRepositoryEvent)
+	for _, e := range events {
+		byRef[e.ExtractData.Ref] = e
+	}
+	require.Equal(t, sdk.WorkflowHookEventNamePush, byRef["refs/heads/main"].EventName)
+	require.Equal(t, "cccccccc", byRef["refs/heads/main"].ExtractData.Commit)
+	require.Equal(t, "aaaaaaaa", byRef["refs/heads/main"].ExtractData.CommitFrom)
+	require.Equal(t, "PROJ", byRef["refs/heads/main"].ExtractData.HookProjectKey)
+	require.Equal(t, "dddddddd", byRef["refs/heads/feat-a"].ExtractData.Commit)
+	require.Empty(t, byRef["refs/heads/feat-a"].ExtractData.CommitFrom)
+}
@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/telemetry"
+)
+
+// exportRepositoryEventTrace sends the span of a terminated hook event, and a child span for each status it went through.
+// The workflow runs triggered by the event are children of the span of the event.
+func (s *Service) exportRepositoryEventTrace(ctx context.Context, hre sdk.HookRepositoryEvent) {
+	if telemetry.OTLPTraceExporter(ctx) == nil {
+		return
+	}
+	spans, err := repositoryEventTraceSpans(hre, time.Now())
+	if err != nil {
+		log.ErrorWithStackTrace(ctx, err)
+		return
+	}
+	if err := telemetry.ExportSpans(ctx, spans); err != nil {
+		log.ErrorWithStackTrace(ctx, sdk.WrapError(err, "unable to export trace of hook event %s", hre.UUID))
+	}
+}
+
+func repositoryEventTraceSpans(hre sdk.HookRepositoryEvent, end time.Time) ([]telemetry.ExportedSpan, error) {
+	traceID, spanID, err := sdk.ParseTraceParent(hre.TraceParent)
+	if err != nil {
+		return nil, err
+	}
+	attributes := map[string]string{
+		"cds.hook_event_id": hre.UUID,
+		"cds.event_name":    string(hre.EventName),
+		"cds.event_type":    string(hre.EventType),
+		"cds.vcs_server":    hre.VCSServerName,
+		"cds.repository":    hre.RepositoryName,
+		"cds.status":        hre.Status,
+	}
+	eventSpan := telemetry.ExportedSpan{
+		Service:    "cds-hooks",
+		Name:       "hook " + string(hre.EventName),
+		TraceID:    traceID,
+		SpanID:     spanID,
+		Start:      time.Unix(0, hre.Created),
+		End:        end,
+		Attributes: attributes,
+	}
+	if hre.Status == sdk.HookEventStatusError {
+		eventSpan.Error = hre.LastError
+	}
+	spans := []telemetry.ExportedSpan{eventSpan}
+
+	// Each status lasts until the next one is entered
+	statuses := make([]string, 0, len(hre.StatusTimestamps))
+	for status := range hre.StatusTimestamps {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return hre.StatusTimestamps[statuses[i]] < hre.StatusTimestamps[statuses[j]]
+	})
+	for i, status := range statuses {
+		if status == sdk.HookEventStatusDone || status == sdk.HookEventStatusError || status == sdk.HookEventStatusSkipped {
+			continue
+		}
+		statusEnd := end
+		if i+1 < len(statuses) {
+			statusEnd = time.Unix(0, hre.StatusTimestamps[statuses[i+1]])
+		}
+		spans = append(spans, telemetry.ExportedSpan{
+			Service:      "cds-hooks",
+			Name:         status,
+			TraceID:      traceID,
+			SpanID:       sdk.TraceSpanID(hre.UUID, status),
+			ParentSpanID: spanID,
+			Start:        time.Unix(0, hre.StatusTimestamps[status]),
+			End:          statusEnd,
+			Attributes:   map[string]string{"cds.hook_event_id": hre.UUID},
+		})
+	}
+	return spans, nil
+}
@@ -0,0 +1,46 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestRepositoryEventTraceSpans(t *testing.T) {
+	start := time.Now().Add(-time.Minute)
+	hre := sdk.HookRepositoryEvent{
+		UUID:        sdk.UUID(),
+		Created:     start.UnixNano(),
+		EventName:   sdk.WorkflowHookEventNamePush,
+		Status:      sdk.HookEventStatusDone,
+		TraceParent: sdk.FormatTraceParent(sdk.NewTraceID(), sdk.TraceSpanID("event")),
+		StatusTimestamps: map[string]int64{
+			sdk.HookEventStatusScheduled: start.UnixNano(),
+			sdk.HookEventStatusAnalysis:  start.Add(10 * time.Second).UnixNano(),
+			sdk.HookEventStatusWorkflow:  start.Add(30 * time.Second).UnixNano(),
+			sdk.HookEventStatusDone:      start.Add(40 * time.Second).UnixNano(),
+		},
+	}
+
+	spans, err := repositoryEventTraceSpans(hre, start.Add(40*time.Second))
+	require.NoError(t, err)
+	require.Len(t, spans, 4)
+
+	require.Equal(t, sdk.TraceSpanID("event"), spans[0].SpanID)
+	require.Equal(t, "", spans[0].ParentSpanID)
+	require.Equal(t, "", spans[0].Error)
+
+	require.Equal(t, sdk.HookEventStatusScheduled, spans[1].Name)
+	require.Equal(t, spans[0].SpanID, spans[1].ParentSpanID)
+	require.Equal(t, 10*time.Second, spans[1].End.Sub(spans[1].Start))
+	require.Equal(t, sdk.HookEventStatusAnalysis, spans[2].Name)
+	require.Equal(t, 20*time.Second, spans[2].End.Sub(spans[2].Start))
+	require.Equal(t, sdk.HookEventStatusWorkflow, spans[3].Name)
+	require.Equal(t, 10*time.Second, spans[3].End.Sub(spans[3].Start))
+
+	_, err = repositoryEventTraceSpans(sdk.HookRepositoryEvent{UUID: sdk.UUID()}, time.Now())
+	require.Error(t, err)
+}
@@ -144,6 +144,7 @@ func (s *Service) manageRepositoryEvent(ctx context.Context, eventKey string) er
 		if err := s.Dao.RemoveRepositoryEventFromInProgressList(ctx, hre.UUID); err != nil {
 			return sdk.WrapError(err, "maxerror > unable to remove event %s from inprogress list", hre.GetFullName())
 		}
+		s.exportRepositoryEventTrace(ctx, hre)
 		return nil
 	}
 
@@ -171,6 +172,9 @@ func (s *Service) executeEvent(ctx context.Context, hre *sdk.HookRepositoryEvent
 				log.ErrorWithStackTrace(ctx, err)
 			}
 		}
+		if hre.IsTerminated() {
+			s.exportRepositoryEventTrace(ctx, *hre)
+		}
 	}()
 
 	switch hre.Status {
@@ -3,11 +3,10 @@ package hooks
 import (
 	"context"
 	"encoding/json"
+	"math/rand"
 	"strings"
 	"time"
 
-	"github.com/gorhill/cronexpr"
-
 	"github.com/ovh/cds/engine/cache"
 	"github.com/ovh/cds/sdk"
 	"github.com/rockbears/log"
@@ -19,6 +18,9 @@ hooks:queue:schedulers: Contains the next Scheduler executions. MemberKey = whID
 hooks:v2:executions:lock:<whID>
 */
 
+// schedulerMissedTolerance is the delay after which a scheduler execution is considered as missed
+const schedulerMissedTolerance = 2 * time.Minute
+
 func (s *Service) instantiateScheduler(ctx context.Context, hooks []sdk.V2WorkflowHook) error {
 	// sort hooks by entity
 	sortedHooks := make(map[string][]sdk.V2WorkflowHook)
@@ -55,25 +57,27 @@ func (s *Service) instantiateScheduler(ctx context.Context, hooks []sdk.V2Workfl
 }
 
 func (s *Service) createSchedulerNextExecution(ctx context.Context, h sdk.V2WorkflowHook) error {
-	// Create the next execution
-	//Parse the cron expr
-	cronExpr, err := cronexpr.Parse(h.Data.Cron)
+	// Compute the next fire time, blackouts excluded
+	nextSchedules, err := h.Data.ScheduleNextTimes(time.Now(), 1)
 	if err != nil {
-		return sdk.WrapError(err, "unable to parse cron expression: %v", h.Data.Cron)
+		return err
+	}
+	if len(nextSchedules) == 0 {
+		log.Warn(ctx, "no next execution for scheduler %s %s %s, all executions are in a blackout", h.ID, h.Data.Cron, h.Data.CronTimeZone)
+		return nil
 	}
+	nextSchedule := nextSchedules[0]
 
-	confTimezone := h.Data.CronTimeZone
-	loc, err := time.LoadLocation(confTimezone)
-	if err != nil {
-		return sdk.WrapError(err, "unable to parse timezone: %v", confTimezone)
+	// Add a random delay to the execution
+	nextExecutionTime := nextSchedule
+	if jitter := h.Data.ScheduleJitterDuration(); jitter > 0 {
+		nextExecutionTime = nextExecutionTime.Add(time.Duration(rand.Int63n(int64(jitter))))
 	}
 
-	//Compute a new date
-	t0 := time.Now().In(loc)
-	nextSchedule := cronExpr.Next(t0)
 	nextExecution := sdk.SchedulerExecution{
 		SchedulerDef:      h,
-		NextExecutionTime: nextSchedule.UnixNano(),
+		NextExecutionTime: nextExecutionTime.UnixNano(),
+		ScheduledTime:     nextSchedule.UnixNano(),
 	}
 	if err := s.Dao.CreateSchedulerNextExecution(ctx, nextExecution); err != nil {
 		return err
@@ -81,6 +85,42 @@ func (s *Service) createSchedulerNextExecution(ctx context.Context, h sdk.V2Work
 	return nil
 }
 
+// schedulerFireTimes returns the fire times to trigger for a scheduler execution. If the execution is late,
+// i.e. the hooks service was down, the catch-up policy of the scheduler gives the missed executions to trigger.
+func schedulerFireTimes(e sdk.SchedulerExecution, now time.Time) ([]time.Time, error) {
+	scheduledTime := time.Unix(0, e.ScheduledTime)
+	if e.ScheduledTime == 0 {
+		scheduledTime = time.Unix(0, e.NextExecutionTime)
+	}
+	if now.Sub(time.Unix(0, e.NextExecutionTime)) <= schedulerMissedTolerance {
+		return []time.Time{scheduledTime}, nil
+	}
+
+	switch e.SchedulerDef.Data.ScheduleCatchUp {
+	case sdk.WorkflowScheduleCatchUpSkip:
+		return nil, nil
+	case sdk.WorkflowScheduleCatchUpAll:
+		missedTimes, err := e.SchedulerDef.Data.ScheduleMissedTimes(scheduledTime, now)
+		if err != nil {
+			return nil, err
+		}
+		fireTimes := append([]time.Time{scheduledTime}, missedTimes...)
+		if len(fireTimes) > sdk.WorkflowScheduleMaxCatchUp {
+			fireTimes = fireTimes[len(fireTimes)-sdk.WorkflowScheduleMaxCatchUp:]
+		}
+		return fireTimes, nil
+	default:
+		missedTimes, err := e.SchedulerDef.Data.ScheduleMissedTimes(scheduledTime, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(missedTimes) > 0 {
+			return missedTimes[len(missedTimes)-1:], nil
+		}
+		return []time.Time{scheduledTime}, nil
+	}
+}
+
 func (s *Service) removeSchedulersAndNextExecution(ctx context.Context, vcs, repo, workflow string) error {
 	keys, err := s.Dao.SchedulerKeysByWorkflow(ctx, vcs, repo, workflow)
 	if err != nil {
@@ -185,42 +225,58 @@ func (s *Service) enqueueSchedulerAsHookRepositoryEvent(ctx context.Context, e s
 		return err
 	}
 
-	// Create HookRepositoryEvent
-	bts, _ := json.Marshal(sdk.V2WorkflowScheduleEvent{Schedule: updatedExecution.SchedulerDef.Data.Cron})
-	he := &sdk.HookRepositoryEvent{
-		UUID:           sdk.UUID(),
-		Created:        time.Now().UnixNano(),
-		EventName:      sdk.WorkflowHookEventNameScheduler,
-		VCSServerName:  updatedExecution.SchedulerDef.VCSName,
-		RepositoryName: updatedExecution.SchedulerDef.RepositoryName,
-		Body:           bts,
-		ExtractData: sdk.HookRepositoryEventExtractData{
-			Commit:       updatedExecution.SchedulerDef.Commit,
-			Ref:          updatedExecution.SchedulerDef.Ref,
-			CDSEventName: sdk.WorkflowHookTypeScheduler,
-			Scheduler: &sdk.HookRepositoryEventExtractedDataScheduler{
-				TargetVCS:      updatedExecution.SchedulerDef.Data.VCSServer,
-				TargetRepo:     updatedExecution.SchedulerDef.Data.RepositoryName,
-				TargetWorkflow: updatedExecution.SchedulerDef.WorkflowName,
-				TargetProject:  updatedExecution.SchedulerDef.ProjectKey,
-				Cron:           updatedExecution.SchedulerDef.Data.Cron,
-				Timezone:       updatedExecution.SchedulerDef.Data.CronTimeZone,
-			},
-		},
-		Status:              sdk.HookEventStatusScheduled,
-		ProcessingTimestamp: time.Now().UnixNano(),
-		LastUpdate:          time.Now().UnixNano(),
-		EventType:           "", //empty for scheduler
+	fireTimes, err := schedulerFireTimes(*updatedExecution, time.Now())
+	if err != nil {
+		return err
 	}
-
-	// Save event
-	if err := s.Dao.SaveRepositoryEvent(ctx, he); err != nil {
-		return sdk.WrapError(err, "unable to create repository event %s", he.GetFullName())
+	if len(fireTimes) == 0 {
+		log.Info(ctx, "Scheduler execution %s/%s/%s/%s was missed, skip it", updatedExecution.SchedulerDef.VCSName, updatedExecution.SchedulerDef.RepositoryName, updatedExecution.SchedulerDef.WorkflowName, updatedExecution.SchedulerDef.ID)
 	}
 
-	// Enqueue event
-	if err := s.Dao.EnqueueRepositoryEvent(ctx, he); err != nil {
-		return sdk.WrapError(err, "unable to enqueue repository event %s", he.GetFullName())
+	for _, fireTime := range fireTimes {
+		// Create HookRepositoryEvent
+		bts, _ := json.Marshal(sdk.V2WorkflowScheduleEvent{
+			Schedule:      updatedExecution.SchedulerDef.Data.Cron,
+			ScheduledTime: fireTime.UTC().Format(time.RFC3339),
+		})
+		he := &sdk.HookRepositoryEvent{
+			UUID:           sdk.UUID(),
+			Created:        time.Now().UnixNano(),
+			EventName:      sdk.WorkflowHookEventNameScheduler,
+			VCSServerName:  updatedExecution.SchedulerDef.VCSName,
+			RepositoryName: updatedExecution.SchedulerDef.RepositoryName,
+			Body:           bts,
+			ExtractData: sdk.HookRepositoryEventExtractData{
+				Commit:       updatedExecution.SchedulerDef.Commit,
+				Ref:          updatedExecution.SchedulerDef.Ref,
+				CDSEventName: sdk.WorkflowHookTypeScheduler,
+				Scheduler: &sdk.HookRepositoryEventExtractedDataScheduler{
+					TargetVCS:      updatedExecution.SchedulerDef.Data.VCSServer,
+					TargetRepo:     updatedExecution.SchedulerDef.Data.RepositoryName,
+					TargetWorkflow: updatedExecution.SchedulerDef.WorkflowName,
+					TargetProject:  updatedExecution.SchedulerDef.ProjectKey,
+					Cron:           updatedExecution.SchedulerDef.Data.Cron,
+					Timezone:       updatedExecution.SchedulerDef.Data.CronTimeZone,
+					TargetBranch:   updatedExecution.SchedulerDef.Data.TargetBranch,
+					Inputs:         updatedExecution.SchedulerDef.Data.ScheduleInputs,
+					ScheduledTime:  fireTime.UnixNano(),
+				},
+			},
+			Status:              sdk.HookEventStatusScheduled,
+			ProcessingTimestamp: time.Now().UnixNano(),
+			LastUpdate:          time.Now().UnixNano(),
+			EventType:           "", //empty for scheduler
+		}
+
+		// Save event
+		if err := s.Dao.SaveRepositoryEvent(ctx, he); err != nil {
+			return sdk.WrapError(err, "unable to create repository event %s", he.GetFullName())
+		}
+
+		// Enqueue event
+		if err := s.Dao.EnqueueRepositoryEvent(ctx, he); err != nil {
+			return sdk.WrapError(err, "unable to enqueue repository event %s", he.GetFullName())
+		}
 	}
 
 	if err := s.createSchedulerNextExecution(ctx, updatedExecution.SchedulerDef); err != nil {
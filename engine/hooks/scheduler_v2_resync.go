@@ -2,6 +2,7 @@ package hooks
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"time"
 
@@ -101,7 +102,7 @@ func (s *Service) resyncLoadRedisSchedulerKeys(ctx context.Context) (map[string]
 }
 
 // resyncAddAndUpdateSchedulers adds schedulers present in DB but missing from Redis,
-// and updates those whose cron or timezone configuration has changed.
+// and updates those whose configuration has changed.
 // Returns the number of added and updated schedulers.
 func (s *Service) resyncAddAndUpdateSchedulers(ctx context.Context, dbHooksByID map[string]sdk.V2WorkflowHook, redisKeysByHookID map[string]string) (nbAdded, nbUpdated int) {
 	for id, dbHook := range dbHooksByID {
@@ -150,7 +151,7 @@ func (s *Service) resyncAddAndUpdateSchedulers(ctx context.Context, dbHooksByID
 				continue
 			}
 
-			if freshHook.Data.Cron != redisHook.Data.Cron || freshHook.Data.CronTimeZone != redisHook.Data.CronTimeZone {
+			if schedulerDefinitionChanged(redisHook.Data, freshHook.Data) {
 				log.Info(ctx, "resyncSchedulers> updating scheduler %s (%s/%s/%s cron: %s->%s tz: %s->%s)",
 					id, freshHook.VCSName, freshHook.RepositoryName, freshHook.WorkflowName,
 					redisHook.Data.Cron, freshHook.Data.Cron,
@@ -269,3 +270,14 @@ func (s *Service) resyncEnsurePendingExecutions(ctx context.Context, dbHooksByID
 		}
 	}
 }
+
+// schedulerDefinitionChanged returns true if the scheduling configuration of a hook has changed
+func schedulerDefinitionChanged(old, new sdk.V2WorkflowHookData) bool {
+	return old.Cron != new.Cron ||
+		old.CronTimeZone != new.CronTimeZone ||
+		old.TargetBranch != new.TargetBranch ||
+		old.ScheduleJitter != new.ScheduleJitter ||
+		old.ScheduleCatchUp != new.ScheduleCatchUp ||
+		!reflect.DeepEqual(old.ScheduleInputs, new.ScheduleInputs) ||
+		!reflect.DeepEqual(old.ScheduleBlackouts, new.ScheduleBlackouts)
+}
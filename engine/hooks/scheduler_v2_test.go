@@ -0,0 +1,62 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestSchedulerFireTimes(t *testing.T) {
+	hook := newTestHook("hook-fire", "github", "myorg/myrepo", "my-workflow", "0 * * * *", "UTC")
+	scheduled := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	exec := sdk.SchedulerExecution{
+		SchedulerDef:      hook,
+		ScheduledTime:     scheduled.UnixNano(),
+		NextExecutionTime: scheduled.Add(30 * time.Second).UnixNano(),
+	}
+
+	// On time: the scheduled time is triggered whatever the catch-up policy
+	for _, catchUp := range []string{"", sdk.WorkflowScheduleCatchUpSkip, sdk.WorkflowScheduleCatchUpAll} {
+		exec.SchedulerDef.Data.ScheduleCatchUp = catchUp
+		times, err := schedulerFireTimes(exec, scheduled.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, times, 1)
+		require.True(t, times[0].Equal(scheduled))
+	}
+
+	// The hooks service was down until 13:30
+	now := time.Date(2024, 6, 3, 13, 30, 0, 0, time.UTC)
+
+	exec.SchedulerDef.Data.ScheduleCatchUp = sdk.WorkflowScheduleCatchUpSkip
+	times, err := schedulerFireTimes(exec, now)
+	require.NoError(t, err)
+	require.Empty(t, times)
+
+	exec.SchedulerDef.Data.ScheduleCatchUp = ""
+	times, err = schedulerFireTimes(exec, now)
+	require.NoError(t, err)
+	require.Len(t, times, 1)
+	require.True(t, times[0].Equal(time.Date(2024, 6, 3, 13, 0, 0, 0, time.UTC)))
+
+	exec.SchedulerDef.Data.ScheduleCatchUp = sdk.WorkflowScheduleCatchUpAll
+	times, err = schedulerFireTimes(exec, now)
+	require.NoError(t, err)
+	require.Len(t, times, 4)
+	require.True(t, times[0].Equal(scheduled))
+	require.True(t, times[3].Equal(time.Date(2024, 6, 3, 13, 0, 0, 0, time.UTC)))
+
+	// Missed executions in a blackout are not triggered
+	exec.SchedulerDef.Data.ScheduleBlackouts = []sdk.WorkflowScheduleBlackout{{From: "11:00", To: "13:00"}}
+	times, err = schedulerFireTimes(exec, now)
+	require.NoError(t, err)
+	require.Len(t, times, 2)
+
+	// Down for days: only the last executions are triggered
+	exec.SchedulerDef.Data.ScheduleBlackouts = nil
+	times, err = schedulerFireTimes(exec, scheduled.Add(72*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, times, sdk.WorkflowScheduleMaxCatchUp)
+}
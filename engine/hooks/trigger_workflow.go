@@ -182,10 +182,20 @@ func (s *Service) triggerWorkflows(ctx context.Context, hre *sdk.HookRepositoryE
 					PullrequestID:      hre.ExtractData.PullRequestID,
 					PullrequestToRef:   hre.ExtractData.PullRequestRefTo,
 					Initiator:          initiator,
+					TraceParent:        hre.TraceParent,
 				}
 				if initiator != nil {
 					runRequest.DeprecatedUserID = initiator.UserID
 				}
+				runRequest.EventType = hre.EventType
+				if hre.ExtractData.Release != nil {
+					runRequest.ReleaseTag = hre.ExtractData.Release.Tag
+					runRequest.ReleaseName = hre.ExtractData.Release.Name
+					runRequest.ReleaseURL = hre.ExtractData.Release.URL
+				}
+				if hre.EventName == sdk.WorkflowHookEventNameBranchDelete {
+					runRequest.DeletedRef = hre.ExtractData.Ref
+				}
 				if wh.Data.TargetBranch != "" {
 					runRequest.Ref = sdk.GitRefBranchPrefix + wh.Data.TargetBranch
 				} else if wh.Data.TargetTag != "" {
@@ -204,6 +214,7 @@ func (s *Service) triggerWorkflows(ctx context.Context, hre *sdk.HookRepositoryE
 					runRequest.Cron = hre.ExtractData.Scheduler.Cron
 					runRequest.CronTimezone = hre.ExtractData.Scheduler.Timezone
 					runRequest.Sha = wh.TargetCommit
+					runRequest.JobInputs = hre.ExtractData.Scheduler.Inputs
 				case sdk.WorkflowHookTypeWorkflowRun:
 					runRequest.WorkflowRun = hre.ExtractData.WorkflowRun.Workflow
 					runRequest.WorkflowRunID = hre.ExtractData.WorkflowRun.WorkflowRunID
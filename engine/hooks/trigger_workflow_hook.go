@@ -115,6 +115,7 @@ func (s *Service) handleScheduler(ctx context.Context, hre *sdk.HookRepositoryEv
 			RepositoryName: hre.ExtractData.Scheduler.TargetRepo,
 			Cron:           hre.ExtractData.Scheduler.Cron,
 			CronTimeZone:   hre.ExtractData.Scheduler.Timezone,
+			TargetBranch:   hre.ExtractData.Scheduler.TargetBranch,
 		},
 	}
 	// For scheduler, retrieve the workflow entity to get userID
@@ -198,7 +199,13 @@ func (s *Service) handleWorkflowHook(ctx context.Context, hre *sdk.HookRepositor
 			Commit:               wh.Commit,
 			Data:                 wh.Data,
 		}
-		if wh.Type == sdk.WorkflowHookTypeRepository {
+		switch {
+		case wh.Type == sdk.WorkflowHookTypeRepository && hre.EventName == sdk.WorkflowHookEventNameBranchDelete:
+			// The deleted branch cannot be checked out: leave the target empty to run on the default branch
+			w.TargetCommit = ""
+			w.Data.TargetBranch = ""
+			w.Data.TargetTag = ""
+		case wh.Type == sdk.WorkflowHookTypeRepository:
 			w.TargetCommit = hre.ExtractData.Commit
 			// force target branch as we may have fallback on another workflow hook definition
 			if strings.HasPrefix(hre.ExtractData.Ref, sdk.GitRefBranchPrefix) {
@@ -0,0 +1,133 @@
+package hooks
+
+import "time"
+
+const (
+	AzureDevOpsEventPush               = "git.push"
+	AzureDevOpsEventPullRequestCreated = "git.pullrequest.created"
+	AzureDevOpsEventPullRequestUpdated = "git.pullrequest.updated"
+	AzureDevOpsEventPullRequestMerged  = "git.pullrequest.merged"
+	AzureDevOpsEventPullRequestComment = "ms.vss-code.git-pullrequest-comment-event"
+
+	AzureDevOpsPullRequestStatusActive    = "active"
+	AzureDevOpsPullRequestStatusAbandoned = "abandoned"
+	AzureDevOpsPullRequestStatusCompleted = "completed"
+)
+
+// AzureDevOpsEvent represents the payload sent by an Azure DevOps service hook
+// https://learn.microsoft.com/en-us/azure/devops/service-hooks/events
+type AzureDevOpsEvent struct {
+	SubscriptionID string                  `json:"subscriptionId"`
+	NotificationID int64                   `json:"notificationId"`
+	ID             string                  `json:"id"`
+	EventType      string                  `json:"eventType"`
+	PublisherID    string                  `json:"publisherId"`
+	Message        AzureDevOpsEventMessage `json:"message"`
+	CreatedDate    time.Time               `json:"createdDate"`
+}
+
+type AzureDevOpsEventMessage struct {
+	Text string `json:"text"`
+}
+
+// AzureDevOpsPushEvent is the payload of a git.push event
+type AzureDevOpsPushEvent struct {
+	AzureDevOpsEvent
+	Resource struct {
+		Commits    []AzureDevOpsCommit    `json:"commits"`
+		RefUpdates []AzureDevOpsRefUpdate `json:"refUpdates"`
+		Repository AzureDevOpsRepository  `json:"repository"`
+		PushedBy   AzureDevOpsIdentity    `json:"pushedBy"`
+		PushID     int64                  `json:"pushId"`
+		Date       time.Time              `json:"date"`
+	} `json:"resource"`
+}
+
+// AzureDevOpsPullRequestEvent is the payload of git.pullrequest.* events
+type AzureDevOpsPullRequestEvent struct {
+	AzureDevOpsEvent
+	Resource AzureDevOpsPullRequest `json:"resource"`
+}
+
+// AzureDevOpsPullRequestCommentEvent is the payload of a ms.vss-code.git-pullrequest-comment-event event
+type AzureDevOpsPullRequestCommentEvent struct {
+	AzureDevOpsEvent
+	Resource struct {
+		Comment     AzureDevOpsComment     `json:"comment"`
+		PullRequest AzureDevOpsPullRequest `json:"pullRequest"`
+	} `json:"resource"`
+}
+
+type AzureDevOpsRepository struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Project struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+	DefaultBranch string `json:"defaultBranch"`
+	RemoteURL     string `json:"remoteUrl"`
+}
+
+// FullName returns the repository name as known by CDS: <project>/<repository>
+func (r AzureDevOpsRepository) FullName() string {
+	return r.Project.Name + "/" + r.Name
+}
+
+type AzureDevOpsIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+type AzureDevOpsUserDate struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type AzureDevOpsCommit struct {
+	CommitID  string              `json:"commitId"`
+	Author    AzureDevOpsUserDate `json:"author"`
+	Committer AzureDevOpsUserDate `json:"committer"`
+	Comment   string              `json:"comment"`
+	URL       string              `json:"url"`
+	Changes   []AzureDevOpsChange `json:"changes"`
+}
+
+// AzureDevOpsChange is a file updated by a commit. Paths start with a slash.
+type AzureDevOpsChange struct {
+	ChangeType   string `json:"changeType"`
+	OriginalPath string `json:"originalPath"`
+	Item         struct {
+		Path string `json:"path"`
+	} `json:"item"`
+}
+
+type AzureDevOpsRefUpdate struct {
+	Name        string `json:"name"`
+	OldObjectID string `json:"oldObjectId"`
+	NewObjectID string `json:"newObjectId"`
+}
+
+type AzureDevOpsPullRequest struct {
+	Repository            AzureDevOpsRepository `json:"repository"`
+	PullRequestID         int64                 `json:"pullRequestId"`
+	Status                string                `json:"status"`
+	CreatedBy             AzureDevOpsIdentity   `json:"createdBy"`
+	Title                 string                `json:"title"`
+	SourceRefName         string                `json:"sourceRefName"`
+	TargetRefName         string                `json:"targetRefName"`
+	MergeStatus           string                `json:"mergeStatus"`
+	LastMergeSourceCommit *AzureDevOpsCommit    `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit *AzureDevOpsCommit    `json:"lastMergeTargetCommit"`
+}
+
+type AzureDevOpsComment struct {
+	ID                     int64               `json:"id"`
+	Content                string              `json:"content"`
+	Author                 AzureDevOpsIdentity `json:"author"`
+	IsDeleted              bool                `json:"isDeleted"`
+	PublishedDate          time.Time           `json:"publishedDate"`
+	LastContentUpdatedDate time.Time           `json:"lastContentUpdatedDate"`
+}
@@ -77,6 +77,30 @@ type ForgejoPushPayload struct {
 	Sender       *ForgejoUser    `json:"sender"`
 }
 
+// ForgejoRefPayload represents a Forgejo create or delete webhook event payload.
+type ForgejoRefPayload struct {
+	Ref        string       `json:"ref"` // short name of the branch or tag
+	RefType    string       `json:"ref_type"`
+	Sha        string       `json:"sha"`
+	Repository *ForgejoRepo `json:"repository"`
+	Sender     *ForgejoUser `json:"sender"`
+}
+
+// ForgejoReleasePayload represents a Forgejo release webhook event payload.
+type ForgejoReleasePayload struct {
+	Action     string          `json:"action"`
+	Release    *ForgejoRelease `json:"release"`
+	Repository *ForgejoRepo    `json:"repository"`
+	Sender     *ForgejoUser    `json:"sender"`
+}
+
+type ForgejoRelease struct {
+	TagName         string `json:"tag_name"`
+	Name            string `json:"name"`
+	HTMLURL         string `json:"html_url"`
+	TargetCommitish string `json:"target_commitish"`
+}
+
 type HookIssueAction string
 
 const (
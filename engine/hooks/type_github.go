@@ -25,6 +25,15 @@ type GithubWebHookEvent struct {
 	Sender      GithubSender       `json:"sender"`
 	PullRequest *GithubPullRequest `json:"pull_request"`
 	Comment     *GithubComment     `json:"comment"`
+	RefType     string             `json:"ref_type"` // used by create and delete events
+	Release     *GithubRelease     `json:"release"`
+}
+
+type GithubRelease struct {
+	TagName         string `json:"tag_name"`
+	Name            string `json:"name"`
+	HTMLURL         string `json:"html_url"`
+	TargetCommitish string `json:"target_commitish"`
 }
 
 type GithubPullRequest struct {
@@ -23,6 +23,12 @@ type GitlabEvent struct {
 	Repository        *GitlabRepository `json:"repository"`
 	Commits           []GitlabCommit    `json:"commits"`
 	TotalCommitsCount int               `json:"total_commits_count"`
+	// Release Hook
+	Action        string        `json:"action"`
+	Tag           string        `json:"tag"`
+	Name          string        `json:"name"`
+	URL           string        `json:"url"`
+	ReleaseCommit *GitlabCommit `json:"commit"`
 }
 
 type GitlabCommit struct {
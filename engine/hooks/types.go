@@ -35,6 +35,9 @@ const (
 	schedulerNextExecutionRootKey = "hooks:queue:schedulers"
 	scheduleDefinitionRootKey     = "hooks:v2:definition:schedulers"
 	schedulerExecutionLockRootKey = "hooks:v2:executions:lock"
+
+	repositoryPollingRootKey = "hooks:v2:polling:repository"
+	repositoryPollingLockKey = "hooks:v2:polling:lock"
 )
 
 // Service is the stuct representing a hooks µService
@@ -65,6 +68,7 @@ type Configuration struct {
 	ExecutionHistory            int                             `toml:"executionHistory" default:"10" comment:"Number of execution to keep" json:"executionHistory"`
 	RepositoryEventRetention    int                             `toml:"repositoryEventRetention" default:"30" comment:"Number of repository event to keep" json:"repositoryEventRetention"`
 	Disable                     bool                            `toml:"disable" default:"false" comment:"Disable all hooks executions" json:"disable"`
+	RepositoryPollingDelay      int64                           `toml:"repositoryPollingDelay" default:"60" comment:"Delay in seconds between two polls of the repositories hosted on plain git servers" json:"repositoryPollingDelay"`
 	API                         service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
 	Cache                       struct {
 		TTL   int           `toml:"ttl" default:"60" json:"ttl"`
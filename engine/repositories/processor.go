@@ -125,6 +125,17 @@ func (s *Service) do(ctx context.Context, op sdk.Operation) error {
 			op.Error = nil
 			op.Status = sdk.OperationStatusDone
 		}
+	// Build, land or delete a merge queue branch
+	case op.Setup.Merge.Action != "":
+		if err := s.processMerge(ctx, &op); err != nil {
+			ctx := sdk.ContextWithStacktrace(ctx, err)
+			log.Error(ctx, err.Error())
+			op.Error = sdk.ToOperationError(sdk.FromGitToHumanError(sdk.ErrUnknownError, err))
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = nil
+			op.Status = sdk.OperationStatusDone
+		}
 	default:
 		op.Error = sdk.ToOperationError(sdk.NewErrorFrom(sdk.ErrUnknownError, "unrecognized setup"))
 		op.Status = sdk.OperationStatusError
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fsamin/go-repo"
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/gitcmd"
+	"github.com/ovh/cds/sdk"
+	cdslog "github.com/ovh/cds/sdk/log"
+)
+
+const (
+	mergeQueueCommitterName  = "CDS"
+	mergeQueueCommitterEmail = "cds@localhost"
+)
+
+func (s *Service) processMerge(ctx context.Context, op *sdk.Operation) (globalErr error) {
+	ctx = context.WithValue(ctx, cdslog.Operation, op.UUID)
+	ctx = context.WithValue(ctx, cdslog.Repository, op.RepoFullName)
+
+	var missingAuth bool
+	if op.RepositoryStrategy.ConnectionType == "ssh" {
+		missingAuth = op.RepositoryStrategy.SSHKey == "" || op.RepositoryStrategy.SSHKeyContent == ""
+	} else {
+		missingAuth = op.RepositoryStrategy.User == "" || op.RepositoryStrategy.Password == ""
+	}
+	if missingAuth {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "authentication data required to push on repository %s", op.URL)
+	}
+	if op.Setup.Merge.Branch == "" || !strings.HasPrefix(op.Setup.Merge.Branch, sdk.MergeQueueBranchPrefix) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid merge queue branch %q", op.Setup.Merge.Branch)
+	}
+
+	gitRepo, path, _, err := s.processGitClone(ctx, op)
+	if err != nil {
+		return sdk.WrapError(err, "unable to process gitclone")
+	}
+
+	// In case of error, we have to clean the filesystem, to avoid pending local branches or merges in progress
+	defer func() {
+		if globalErr != nil {
+			r := s.Repo(*op)
+			if err := s.cleanFS(ctx, r); err != nil {
+				log.Error(ctx, "unable to clean FS: %v", err)
+			}
+		}
+	}()
+
+	switch op.Setup.Merge.Action {
+	case sdk.OperationMergeActionCreate:
+		return s.processMergeCreate(ctx, op, gitRepo, path)
+	case sdk.OperationMergeActionDelete:
+		if err := gitRepo.Push(ctx, "origin", ":"+sdk.GitRefBranchPrefix+op.Setup.Merge.Branch); err != nil {
+			return sdk.WrapError(err, "unable to delete branch %s", op.Setup.Merge.Branch)
+		}
+		return nil
+	}
+	return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown merge action %q", op.Setup.Merge.Action)
+}
+
+// processMergeCreate merges all the commits on top of the base branch. A commit that conflicts is skipped and reported in the operation result.
+func (s *Service) processMergeCreate(ctx context.Context, op *sdk.Operation, gitRepo repo.Repo, path string) error {
+	merge := &op.Setup.Merge
+	if err := s.resetMergeBaseBranch(ctx, op, gitRepo); err != nil {
+		return err
+	}
+	baseCommit, err := gitRepo.LatestCommit(ctx, repo.CommitOption{DisableDiffDetail: true})
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	merge.Result.BaseCommit = baseCommit.LongHash
+
+	// Always rebuild the temporary branch from the base branch
+	_, _ = gitcmd.Run(ctx, path, nil, "branch", "-D", merge.Branch)
+	if err := gitRepo.CheckoutNewBranch(ctx, merge.Branch); err != nil {
+		return sdk.WrapError(err, "cannot checkout new branch %s", merge.Branch)
+	}
+
+	for _, c := range merge.Commits {
+		if _, err := gitcmd.Run(ctx, path, nil, "-c", "user.name="+mergeQueueCommitterName, "-c", "user.email="+mergeQueueCommitterEmail,
+			"merge", "--no-ff", "-m", c.Message, "--end-of-options", c.Commit); err != nil {
+			log.Info(ctx, "processMerge> unable to merge %s on %s: %v", c.Commit, merge.BaseBranch, err)
+			merge.Result.Conflicts = append(merge.Result.Conflicts, c.Commit)
+			_, _ = gitcmd.Run(ctx, path, nil, "merge", "--abort")
+			continue
+		}
+	}
+
+	if len(merge.Result.Conflicts) < len(merge.Commits) {
+		latest, err := gitRepo.LatestCommit(ctx, repo.CommitOption{DisableDiffDetail: true})
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		merge.Result.Commit = latest.LongHash
+		if err := gitRepo.Push(ctx, "origin", merge.Branch); err != nil {
+			if strings.Contains(err.Error(), "Pushing requires write access") {
+				return sdk.NewError(sdk.ErrForbidden, err)
+			}
+			return sdk.WrapError(err, "unable to push branch %s", merge.Branch)
+		}
+	}
+
+	// Leave the temporary branch so the next operations start from a known state
+	if err := gitRepo.Checkout(ctx, merge.BaseBranch); err != nil {
+		return sdk.WithStack(err)
+	}
+	_, _ = gitcmd.Run(ctx, path, nil, "branch", "-D", merge.Branch)
+	return nil
+}
+
+func (s *Service) resetMergeBaseBranch(ctx context.Context, op *sdk.Operation, gitRepo repo.Repo) error {
+	if op.Setup.Merge.BaseBranch == "" {
+		op.Setup.Merge.BaseBranch = op.RepositoryInfo.DefaultBranch
+	}
+	if err := gitRepo.ResetHard(ctx, "HEAD"); err != nil {
+		return sdk.WithStack(err)
+	}
+	if err := gitRepo.FetchRemoteBranch(ctx, "origin", op.Setup.Merge.BaseBranch); err != nil {
+		return sdk.WrapError(err, "cannot fetch branch %s", op.Setup.Merge.BaseBranch)
+	}
+	if err := gitRepo.ResetHard(ctx, "origin/"+op.Setup.Merge.BaseBranch); err != nil {
+		return sdk.WithStack(err)
+	}
+	return nil
+}
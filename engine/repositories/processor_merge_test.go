@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rockbears/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func commitFile(t *testing.T, dir, file, content string) string {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+	runGit(t, dir, "add", file)
+	runGit(t, dir, "commit", "-m", "update "+file)
+	return runGit(t, dir, "rev-parse", "HEAD")
+}
+
+func TestProcessMerge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	log.Factory = log.NewTestingWrapper(t)
+	t.Setenv("GIT_AUTHOR_NAME", "cds")
+	t.Setenv("GIT_AUTHOR_EMAIL", "cds@localhost")
+	t.Setenv("GIT_COMMITTER_NAME", "cds")
+	t.Setenv("GIT_COMMITTER_EMAIL", "cds@localhost")
+
+	tmp := t.TempDir()
+	remote := filepath.Join(tmp, "remote.git")
+	work := filepath.Join(tmp, "work")
+	runGit(t, tmp, "init", "--bare", remote)
+	runGit(t, remote, "symbolic-ref", "HEAD", "refs/heads/main")
+	runGit(t, tmp, "clone", remote, work)
+	runGit(t, work, "checkout", "-b", "main")
+	base := commitFile(t, work, "README.md", "readme")
+	runGit(t, work, "push", "origin", "main")
+
+	// Two independent pull requests and one conflicting with the first
+	runGit(t, work, "checkout", "-b", "pr-1", base)
+	pr1 := commitFile(t, work, "a.txt", "a")
+	runGit(t, work, "checkout", "-b", "pr-2", base)
+	pr2 := commitFile(t, work, "b.txt", "b")
+	runGit(t, work, "checkout", "-b", "pr-3", base)
+	pr3 := commitFile(t, work, "a.txt", "conflict")
+	runGit(t, work, "push", "origin", "pr-1", "pr-2", "pr-3")
+
+	s := &Service{}
+	s.Cfg.Basedir = filepath.Join(tmp, "basedir")
+
+	newOp := func(merge sdk.OperationMerge) *sdk.Operation {
+		return &sdk.Operation{
+			UUID:         sdk.UUID(),
+			URL:          "file://" + remote,
+			RepoFullName: "my/repo",
+			RepositoryStrategy: sdk.RepositoryStrategy{
+				ConnectionType: "https",
+				User:           "user",
+				Password:       "password",
+			},
+			Setup: sdk.OperationSetup{Merge: merge},
+		}
+	}
+
+	ctx := context.TODO()
+	op := newOp(sdk.OperationMerge{
+		Action:     sdk.OperationMergeActionCreate,
+		BaseBranch: "main",
+		Branch:     sdk.MergeQueueBranchPrefix + "main/batch",
+		Commits: []sdk.OperationMergeCommit{
+			{Commit: pr1, Message: "Merge pull request #1"},
+			{Commit: pr2, Message: "Merge pull request #2"},
+			{Commit: pr3, Message: "Merge pull request #3"},
+		},
+	})
+	require.NoError(t, s.processMerge(ctx, op))
+	require.Equal(t, base, op.Setup.Merge.Result.BaseCommit)
+	require.Equal(t, []string{pr3}, op.Setup.Merge.Result.Conflicts)
+	require.NotEmpty(t, op.Setup.Merge.Result.Commit)
+	require.Equal(t, op.Setup.Merge.Result.Commit, runGit(t, remote, "rev-parse", "refs/heads/"+sdk.MergeQueueBranchPrefix+"main/batch"))
+
+	// The temporary branch is deleted once the batch is done, the base branch is left untouched
+	require.NoError(t, s.processMerge(ctx, newOp(sdk.OperationMerge{
+		Action:     sdk.OperationMergeActionDelete,
+		BaseBranch: "main",
+		Branch:     sdk.MergeQueueBranchPrefix + "main/batch",
+	})))
+	require.Equal(t, base, runGit(t, remote, "rev-parse", "refs/heads/main"))
+	require.Empty(t, runGit(t, remote, "branch", "--list", sdk.MergeQueueBranchPrefix+"*"))
+}
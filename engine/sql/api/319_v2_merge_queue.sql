@@ -0,0 +1,48 @@
+-- +migrate Up
+CREATE TABLE merge_queue_batch (
+    "id"              uuid PRIMARY KEY,
+    "project_key"     VARCHAR(255) NOT NULL,
+    "vcs_server"      VARCHAR(256) NOT NULL,
+    "repository"      VARCHAR(512) NOT NULL,
+    "base_branch"     VARCHAR(512) NOT NULL,
+    "base_commit"     VARCHAR(256) NOT NULL DEFAULT '',
+    "branch"          VARCHAR(1024) NOT NULL,
+    "commit"          VARCHAR(256) NOT NULL DEFAULT '',
+    "status"          VARCHAR(50) NOT NULL,
+    "operation_uuid"  VARCHAR(256) NOT NULL DEFAULT '',
+    "hook_event_uuid" VARCHAR(256) NOT NULL DEFAULT '',
+    "run_ids"         JSONB,
+    "error"           TEXT NOT NULL DEFAULT '',
+    "created"         TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    "last_modified"   TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_merge_queue_batch_project', 'merge_queue_batch', 'project', 'project_key', 'projectkey');
+SELECT create_index('merge_queue_batch', 'idx_merge_queue_batch_status', 'status');
+
+CREATE TABLE merge_queue_entry (
+    "id"                 uuid PRIMARY KEY,
+    "project_key"        VARCHAR(255) NOT NULL,
+    "vcs_server"         VARCHAR(256) NOT NULL,
+    "repository"         VARCHAR(512) NOT NULL,
+    "base_branch"        VARCHAR(512) NOT NULL,
+    "pull_request_id"    BIGINT NOT NULL,
+    "pull_request_title" TEXT NOT NULL DEFAULT '',
+    "pull_request_url"   TEXT NOT NULL DEFAULT '',
+    "head_commit"        VARCHAR(256) NOT NULL,
+    "status"             VARCHAR(50) NOT NULL,
+    "batch_id"           VARCHAR(256) NOT NULL DEFAULT '',
+    "isolated"           BOOLEAN NOT NULL DEFAULT FALSE,
+    "error"              TEXT NOT NULL DEFAULT '',
+    "username"           VARCHAR(256) NOT NULL DEFAULT '',
+    "created"            TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    "last_modified"      TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_merge_queue_entry_project', 'merge_queue_entry', 'project', 'project_key', 'projectkey');
+SELECT create_index('merge_queue_entry', 'idx_merge_queue_entry_repository', 'vcs_server,repository,status');
+
+-- A pull request can only be queued once at a time
+CREATE UNIQUE INDEX idx_unq_merge_queue_entry_active ON merge_queue_entry (project_key, vcs_server, repository, pull_request_id) WHERE status IN ('Queued', 'Testing');
+
+-- +migrate Down
+DROP TABLE merge_queue_entry;
+DROP TABLE merge_queue_batch;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE v2_workflow_run_job ADD COLUMN debug BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE v2_workflow_run_job DROP COLUMN debug;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE v2_workflow_run ADD COLUMN trace_parent VARCHAR(55) NOT NULL DEFAULT '';
+ALTER TABLE v2_workflow_run_job ADD COLUMN trace_parent VARCHAR(55) NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE v2_workflow_run DROP COLUMN trace_parent;
+ALTER TABLE v2_workflow_run_job DROP COLUMN trace_parent;
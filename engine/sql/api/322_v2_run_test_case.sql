@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_run_test_case (
+    "id"                  uuid PRIMARY KEY,
+    "project_key"         VARCHAR(255) NOT NULL,
+    "vcs_server"          VARCHAR(256) NOT NULL,
+    "repository"          VARCHAR(512) NOT NULL,
+    "workflow_name"       VARCHAR(512) NOT NULL,
+    "workflow_run_id"     uuid NOT NULL,
+    "run_number"          BIGINT NOT NULL,
+    "run_attempt"         BIGINT NOT NULL,
+    "workflow_run_job_id" uuid NOT NULL,
+    "job_id"              VARCHAR(256) NOT NULL,
+    "run_result_id"       uuid NOT NULL,
+    "git_ref"             VARCHAR(1024) NOT NULL DEFAULT '',
+    "git_sha"             VARCHAR(256) NOT NULL DEFAULT '',
+    "suite"               TEXT NOT NULL,
+    "classname"           TEXT NOT NULL DEFAULT '',
+    "name"                TEXT NOT NULL,
+    "duration"            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "status"              VARCHAR(50) NOT NULL,
+    "issued_at"           TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_test_case_run', 'v2_workflow_run_test_case', 'v2_workflow_run', 'workflow_run_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_test_case_run_result', 'v2_workflow_run_test_case', 'v2_workflow_run_result', 'run_result_id', 'id');
+SELECT create_index('v2_workflow_run_test_case', 'idx_v2_workflow_run_test_case_workflow', 'project_key,vcs_server,repository,workflow_name,suite,name');
+
+-- +migrate Down
+DROP TABLE v2_workflow_run_test_case;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_run_coverage (
+    "id"                 uuid PRIMARY KEY,
+    "project_key"        VARCHAR(255) NOT NULL,
+    "vcs_server"         VARCHAR(256) NOT NULL,
+    "repository"         VARCHAR(512) NOT NULL,
+    "workflow_name"      VARCHAR(512) NOT NULL,
+    "workflow_run_id"    uuid NOT NULL,
+    "run_number"         BIGINT NOT NULL,
+    "run_attempt"        BIGINT NOT NULL,
+    "git_ref"            VARCHAR(1024) NOT NULL DEFAULT '',
+    "git_sha"            VARCHAR(256) NOT NULL DEFAULT '',
+    "pullrequest_id"     BIGINT NOT NULL DEFAULT 0,
+    "lines_total"        BIGINT NOT NULL DEFAULT 0,
+    "lines_covered"      BIGINT NOT NULL DEFAULT 0,
+    "rate"               DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "diff_lines_total"   BIGINT NOT NULL DEFAULT 0,
+    "diff_lines_covered" BIGINT NOT NULL DEFAULT 0,
+    "diff_rate"          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    "created"            TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_run_coverage_run', 'v2_workflow_run_coverage', 'v2_workflow_run', 'workflow_run_id', 'id');
+SELECT create_unique_index('v2_workflow_run_coverage', 'idx_unq_v2_workflow_run_coverage_attempt', 'workflow_run_id,run_attempt');
+SELECT create_index('v2_workflow_run_coverage', 'idx_v2_workflow_run_coverage_workflow', 'project_key,vcs_server,repository,workflow_name,git_ref');
+
+-- +migrate Down
+DROP TABLE v2_workflow_run_coverage;
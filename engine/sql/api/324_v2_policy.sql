@@ -0,0 +1,36 @@
+-- +migrate Up
+CREATE TABLE project_policy
+(
+    "id"            uuid PRIMARY KEY,
+    "project_key"   VARCHAR(255) NOT NULL,
+    "name"          VARCHAR(255) NOT NULL,
+    "description"   TEXT NOT NULL DEFAULT '',
+    "entity_type"   VARCHAR(255) NOT NULL,
+    "language"      VARCHAR(255) NOT NULL,
+    "rule"          TEXT NOT NULL,
+    "outcome"       VARCHAR(255) NOT NULL DEFAULT '',
+    "message"       TEXT NOT NULL DEFAULT '',
+    "last_modified" TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_project_policy_project', 'project_policy', 'project', 'project_key', 'projectkey');
+SELECT create_unique_index('project_policy', 'idx_unq_project_policy', 'project_key,name');
+
+CREATE TABLE organization_policy
+(
+    "id"              uuid PRIMARY KEY,
+    "organization_id" uuid NOT NULL,
+    "name"            VARCHAR(255) NOT NULL,
+    "description"     TEXT NOT NULL DEFAULT '',
+    "entity_type"     VARCHAR(255) NOT NULL,
+    "language"        VARCHAR(255) NOT NULL,
+    "rule"            TEXT NOT NULL,
+    "outcome"         VARCHAR(255) NOT NULL DEFAULT '',
+    "message"         TEXT NOT NULL DEFAULT '',
+    "last_modified"   TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_organization_policy_organization', 'organization_policy', 'organization', 'organization_id', 'id');
+SELECT create_unique_index('organization_policy', 'idx_unq_organization_policy', 'organization_id,name');
+
+-- +migrate Down
+DROP TABLE project_policy;
+DROP TABLE organization_policy;
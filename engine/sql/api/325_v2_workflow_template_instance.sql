@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE v2_workflow_template_instance
+(
+    "id"                       uuid PRIMARY KEY,
+    "project_key"              VARCHAR(255) NOT NULL,
+    "project_repository_id"    uuid NOT NULL,
+    "vcs_server"               VARCHAR(255) NOT NULL,
+    "repository"               VARCHAR(255) NOT NULL,
+    "workflow_name"            VARCHAR(255) NOT NULL,
+    "workflow_ref"             VARCHAR(255) NOT NULL,
+    "workflow_path"            TEXT NOT NULL,
+    "from"                     TEXT NOT NULL,
+    "template"                 VARCHAR(255) NOT NULL,
+    "template_ref"             VARCHAR(255) NOT NULL,
+    "template_commit"          VARCHAR(255) NOT NULL DEFAULT '',
+    "upgrade_ref"              VARCHAR(255) NOT NULL DEFAULT '',
+    "upgrade_pull_request_url" TEXT NOT NULL DEFAULT '',
+    "last_modified"            TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_template_instance_project', 'v2_workflow_template_instance', 'project', 'project_key', 'projectkey');
+SELECT create_foreign_key_idx_cascade('FK_v2_workflow_template_instance_repository', 'v2_workflow_template_instance', 'project_repository', 'project_repository_id', 'id');
+SELECT create_unique_index('v2_workflow_template_instance', 'idx_unq_v2_workflow_template_instance', 'project_repository_id,workflow_name,workflow_ref');
+SELECT create_index('v2_workflow_template_instance', 'idx_v2_workflow_template_instance_template', 'template');
+
+-- +migrate Down
+DROP TABLE v2_workflow_template_instance;
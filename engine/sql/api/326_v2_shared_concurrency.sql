@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE TABLE organization_concurrency
+(
+    "id"                    uuid PRIMARY KEY,
+    "organization_id"       uuid NOT NULL,
+    "name"                  VARCHAR(255) NOT NULL,
+    "description"           TEXT NOT NULL DEFAULT '',
+    "order"                 VARCHAR(255) NOT NULL,
+    "pool"                  BIGINT NOT NULL DEFAULT 1,
+    "if"                    TEXT NOT NULL DEFAULT '',
+    "cancel_in_progress"    BOOLEAN NOT NULL DEFAULT false,
+    "timeout"               VARCHAR(255) NOT NULL DEFAULT '',
+    "last_modified"         TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_organization_concurrency_organization', 'organization_concurrency', 'organization', 'organization_id', 'id');
+SELECT create_unique_index('organization_concurrency', 'idx_unq_organization_concurrency', 'organization_id,name');
+
+CREATE TABLE region_concurrency
+(
+    "id"                    uuid PRIMARY KEY,
+    "region_id"             uuid NOT NULL,
+    "name"                  VARCHAR(255) NOT NULL,
+    "description"           TEXT NOT NULL DEFAULT '',
+    "order"                 VARCHAR(255) NOT NULL,
+    "pool"                  BIGINT NOT NULL DEFAULT 1,
+    "if"                    TEXT NOT NULL DEFAULT '',
+    "cancel_in_progress"    BOOLEAN NOT NULL DEFAULT false,
+    "timeout"               VARCHAR(255) NOT NULL DEFAULT '',
+    "last_modified"         TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_region_concurrency_region', 'region_concurrency', 'region', 'region_id', 'id');
+SELECT create_unique_index('region_concurrency', 'idx_unq_region_concurrency', 'region_id,name');
+
+CREATE INDEX IDX_V2_WORKFLOW_RUN_JOB_SHARED_CONCURRENCY ON v2_workflow_run_job((concurrency->>'key'), (concurrency->>'name'), (concurrency->>'scope'));
+
+-- +migrate Down
+DROP TABLE organization_concurrency;
+DROP TABLE region_concurrency;
+DROP INDEX IDX_V2_WORKFLOW_RUN_JOB_SHARED_CONCURRENCY;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE rbac_workflow ADD COLUMN "refs" JSONB;
+
+-- +migrate Down
+ALTER TABLE rbac_workflow DROP COLUMN "refs";
@@ -0,0 +1,41 @@
+-- +migrate Up
+CREATE TABLE rbac_grant
+(
+    "id"              uuid PRIMARY KEY,
+    "user_id"         character varying(36) NOT NULL,
+    "username"        VARCHAR(255) NOT NULL,
+    "scope"           VARCHAR(50) NOT NULL,
+    "project_key"     VARCHAR(255) NOT NULL,
+    "target"          VARCHAR(255) NOT NULL DEFAULT '',
+    "role"            VARCHAR(255) NOT NULL,
+    "justification"   TEXT NOT NULL,
+    "duration"        VARCHAR(50) NOT NULL,
+    "status"          VARCHAR(50) NOT NULL,
+    "created"         TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    "reviewed_by"     VARCHAR(255) NOT NULL DEFAULT '',
+    "review_comment"  TEXT NOT NULL DEFAULT '',
+    "reviewed"        TIMESTAMP WITH TIME ZONE,
+    "expires"         TIMESTAMP WITH TIME ZONE,
+    "sig"             BYTEA,
+    "signer"          TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_rbac_grant_user', 'rbac_grant', 'authentified_user', 'user_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_rbac_grant_project', 'rbac_grant', 'project', 'project_key', 'projectkey');
+SELECT create_index('rbac_grant', 'idx_rbac_grant_status', 'status');
+
+CREATE TABLE rbac_grant_audit
+(
+    "id"            BIGSERIAL PRIMARY KEY,
+    "grant_id"      uuid NOT NULL,
+    "project_key"   VARCHAR(255) NOT NULL,
+    "triggered_by"  VARCHAR(255),
+    "created"       TIMESTAMP WITH TIME ZONE,
+    "event_type"    VARCHAR(100),
+    "details"       TEXT
+);
+SELECT create_index('rbac_grant_audit', 'idx_rbac_grant_audit_grant', 'grant_id');
+SELECT create_index('rbac_grant_audit', 'idx_rbac_grant_audit_project', 'project_key');
+
+-- +migrate Down
+DROP TABLE rbac_grant_audit;
+DROP TABLE rbac_grant;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE audit_v2
+(
+    "id"            BIGSERIAL PRIMARY KEY,
+    "event_type"    VARCHAR(100) NOT NULL,
+    "created"       TIMESTAMP WITH TIME ZONE NOT NULL,
+    "triggered_by"  VARCHAR(255) NOT NULL DEFAULT '',
+    "user_id"       VARCHAR(36) NOT NULL DEFAULT '',
+    "consumer_id"   VARCHAR(36) NOT NULL DEFAULT '',
+    "ip_address"    VARCHAR(255) NOT NULL DEFAULT '',
+    "project_key"   VARCHAR(255) NOT NULL DEFAULT '',
+    "entity_type"   VARCHAR(100) NOT NULL,
+    "entity_name"   TEXT NOT NULL DEFAULT '',
+    "before"        JSONB,
+    "after"         JSONB,
+    "diff"          JSONB
+);
+SELECT create_index('audit_v2', 'idx_audit_v2_created', 'created');
+SELECT create_index('audit_v2', 'idx_audit_v2_project', 'project_key,created');
+SELECT create_index('audit_v2', 'idx_audit_v2_entity', 'entity_type,project_key,entity_name');
+SELECT create_index('audit_v2', 'idx_audit_v2_triggered_by', 'triggered_by');
+
+-- +migrate Down
+DROP TABLE audit_v2;
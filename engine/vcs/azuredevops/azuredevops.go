@@ -0,0 +1,53 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// azureDevOpsClient is an Azure DevOps Repos wrapper for CDS vcs. interface
+type azureDevOpsClient struct {
+	client   *azureDevOpsHTTPClient
+	proxyURL string
+}
+
+// azureDevOpsConsumer implements vcs.Server and it's used to instantiate an azureDevOpsClient
+type azureDevOpsConsumer struct {
+	URL      string `json:"url"` // https://dev.azure.com/<organization>
+	proxyURL string
+	username string
+	token    string
+}
+
+// getRepo splits a repository fullname. On Azure DevOps a repository belongs to a project of
+// the organization configured on the vcs server, so the fullname is <project>/<repository>
+func getRepo(fullname string) (string, string, error) {
+	t := strings.Split(fullname, "/")
+	if len(t) != 2 {
+		return "", "", sdk.WithStack(fmt.Errorf("fullname %s must be <project>/<repository>", fullname))
+	}
+	project := t[0]
+	slug := t[1]
+	return project, slug, nil
+}
+
+// New creates a new Azure DevOps Consumer
+func New(URL, proxyURL, username, token string) sdk.VCSServer {
+	return &azureDevOpsConsumer{
+		URL:      URL,
+		proxyURL: proxyURL,
+		username: username,
+		token:    token,
+	}
+}
+
+// GetAuthorizedClient returns an authorized client
+func (a *azureDevOpsConsumer) GetAuthorizedClient(_ context.Context, _ sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
+	return &azureDevOpsClient{
+		client:   newAzureDevOpsHTTPClient(a.URL, a.username, a.token),
+		proxyURL: a.proxyURL,
+	}, nil
+}
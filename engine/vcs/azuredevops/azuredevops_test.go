@@ -0,0 +1,237 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Responses recorded from the Azure DevOps REST API 7.1
+var fixtures = map[string]string{
+	"GET /myorg/fabrikam/_apis/git/repositories/website": `{
+  "id": "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+  "name": "website",
+  "url": "https://dev.azure.com/myorg/3b3ae425-0079-421f-9101-bcf15d6df041/_apis/git/repositories/5febef5a-833d-4e14-b9c0-14cb638f91e6",
+  "project": {"id": "3b3ae425-0079-421f-9101-bcf15d6df041", "name": "fabrikam"},
+  "defaultBranch": "refs/heads/main",
+  "remoteUrl": "https://myorg@dev.azure.com/myorg/fabrikam/_git/website",
+  "sshUrl": "git@ssh.dev.azure.com:v3/myorg/fabrikam/website",
+  "webUrl": "https://dev.azure.com/myorg/fabrikam/_git/website"
+}`,
+	"GET /myorg/fabrikam/_apis/git/repositories/website/refs": `{
+  "value": [
+    {"name": "refs/heads/feat/login", "objectId": "ffe9cba521f00d7f60e322845072238635edb451"},
+    {"name": "refs/heads/main", "objectId": "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4"}
+  ],
+  "count": 2
+}`,
+	"GET /myorg/fabrikam/_apis/git/repositories/website/stats/branches": `{
+  "value": [
+    {"commit": {"commitId": "ffe9cba521f00d7f60e322845072238635edb451"}, "name": "feat/login", "aheadCount": 1, "behindCount": 0, "isBaseVersion": false},
+    {"commit": {"commitId": "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4"}, "name": "main", "aheadCount": 0, "behindCount": 0, "isBaseVersion": true}
+  ],
+  "count": 2
+}`,
+	"GET /myorg/fabrikam/_apis/git/repositories/website/commits/d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4": `{
+  "commitId": "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4",
+  "author": {"name": "Jamal Hartnett", "email": "jamal.hartnett@fabrikam.com", "date": "2024-02-19T08:19:28Z"},
+  "committer": {"name": "Jamal Hartnett", "email": "jamal.hartnett@fabrikam.com", "date": "2024-02-19T08:19:28Z"},
+  "comment": "Fix login page",
+  "parents": ["ffe9cba521f00d7f60e322845072238635edb451"],
+  "remoteUrl": "https://dev.azure.com/myorg/fabrikam/_git/website/commit/d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4"
+}`,
+	"GET /myorg/fabrikam/_apis/git/repositories/website/pullrequests/22": `{
+  "repository": {
+    "id": "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+    "name": "website",
+    "project": {"id": "3b3ae425-0079-421f-9101-bcf15d6df041", "name": "fabrikam"},
+    "webUrl": "https://dev.azure.com/myorg/fabrikam/_git/website"
+  },
+  "pullRequestId": 22,
+  "status": "completed",
+  "createdBy": {"id": "d6245f20-2af8-44f4-9451-8107cb2767db", "displayName": "Normal Paulk", "uniqueName": "fabrikamfiber16@hotmail.com"},
+  "closedBy": {"id": "d6245f20-2af8-44f4-9451-8107cb2767db", "displayName": "Normal Paulk", "uniqueName": "fabrikamfiber16@hotmail.com"},
+  "title": "A new feature",
+  "sourceRefName": "refs/heads/feat/login",
+  "targetRefName": "refs/heads/main",
+  "lastMergeSourceCommit": {"commitId": "ffe9cba521f00d7f60e322845072238635edb451"},
+  "lastMergeTargetCommit": {"commitId": "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4"}
+}`,
+	"GET /myorg/fabrikam/_apis/git/repositories/website/items": `{
+  "value": [
+    {"objectId": "1", "gitObjectType": "tree", "path": "/.cds", "isFolder": true},
+    {"objectId": "2", "gitObjectType": "blob", "path": "/.cds/workflow.yml"},
+    {"objectId": "3", "gitObjectType": "tree", "path": "/.cds/actions", "isFolder": true}
+  ],
+  "count": 3
+}`,
+	"POST /myorg/fabrikam/_apis/git/repositories/website/commits/d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4/statuses": `{
+  "id": 1,
+  "state": "succeeded",
+  "description": "Success",
+  "context": {"name": "PRJ-my-workflow", "genre": "cds"}
+}`,
+}
+
+func newTestClient(t *testing.T) (sdk.VCSAuthorizedClient, *[]*http.Request, *[][]byte) {
+	var requests []*http.Request
+	var bodies [][]byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		_, token, _ := r.BasicAuth()
+		require.Equal(t, "my-pat", token)
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		if r.Header.Get("Accept") == "application/octet-stream" {
+			_, _ = w.Write([]byte("version: v1.0\nname: my-workflow\n"))
+			return
+		}
+		// A single item is requested with the path parameter
+		if r.URL.Path == "/myorg/fabrikam/_apis/git/repositories/website/items" && r.URL.Query().Get("path") != "" {
+			_, _ = w.Write([]byte(`{"objectId": "2", "gitObjectType": "blob", "path": "/.cds/workflow.yml"}`))
+			return
+		}
+		// A single branch is requested with the name parameter
+		if r.URL.Path == "/myorg/fabrikam/_apis/git/repositories/website/stats/branches" && r.URL.Query().Get("name") != "" {
+			if r.URL.Query().Get("name") != "feat/login" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"commit": {"commitId": "ffe9cba521f00d7f60e322845072238635edb451"}, "name": "feat/login", "aheadCount": 1, "behindCount": 0, "isBaseVersion": false}`))
+			return
+		}
+		f, has := fixtures[r.Method+" "+r.URL.Path]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(f))
+	}))
+	t.Cleanup(srv.Close)
+
+	consumer := New(srv.URL+"/myorg", "", "", "my-pat")
+	client, err := consumer.GetAuthorizedClient(context.TODO(), sdk.VCSAuth{})
+	require.NoError(t, err)
+	return client, &requests, &bodies
+}
+
+func TestRepoByFullname(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	repo, err := client.RepoByFullname(context.TODO(), "fabrikam/website")
+	require.NoError(t, err)
+	require.Equal(t, "fabrikam/website", repo.Fullname)
+	require.Equal(t, "git@ssh.dev.azure.com:v3/myorg/fabrikam/website", repo.SSHCloneURL)
+	require.Equal(t, "https://dev.azure.com/myorg/fabrikam/_git/website/commit/%s", repo.URLCommitFormat)
+
+	_, err = client.RepoByFullname(context.TODO(), "fabrikam/unknown")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
+
+func TestBranches(t *testing.T) {
+	client, reqs, _ := newTestClient(t)
+	branches, err := client.Branches(context.TODO(), "fabrikam/website", sdk.VCSBranchesFilter{})
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	require.Equal(t, "feat/login", branches[0].DisplayID)
+	require.False(t, branches[0].Default)
+	require.Equal(t, "refs/heads/main", branches[1].ID)
+	require.True(t, branches[1].Default)
+	require.Equal(t, "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4", branches[1].LatestCommit)
+	require.Len(t, *reqs, 1)
+
+	b, err := client.Branch(context.TODO(), "fabrikam/website", sdk.VCSBranchFilters{Default: true})
+	require.NoError(t, err)
+	require.Equal(t, "refs/heads/main", b.ID)
+	require.Equal(t, "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4", b.LatestCommit)
+	require.Len(t, *reqs, 2)
+
+	b, err = client.Branch(context.TODO(), "fabrikam/website", sdk.VCSBranchFilters{BranchName: "refs/heads/feat/login"})
+	require.NoError(t, err)
+	require.Equal(t, "feat/login", b.DisplayID)
+	require.False(t, b.Default)
+	require.Equal(t, "ffe9cba521f00d7f60e322845072238635edb451", b.LatestCommit)
+	require.Len(t, *reqs, 3)
+
+	_, err = client.Branch(context.TODO(), "fabrikam/website", sdk.VCSBranchFilters{BranchName: "unknown"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNoBranch))
+}
+
+func TestCommit(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	c, err := client.Commit(context.TODO(), "fabrikam/website", "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4")
+	require.NoError(t, err)
+	require.Equal(t, "Fix login page", c.Message)
+	require.Equal(t, "jamal.hartnett", c.Committer.Slug)
+	require.Equal(t, int64(1708330768000), c.Timestamp)
+}
+
+func TestCommitsBetweenRefs(t *testing.T) {
+	client, reqs, _ := newTestClient(t)
+	_, err := client.CommitsBetweenRefs(context.TODO(), "fabrikam/website", "refs/heads/main", "ffe9cba521f00d7f60e322845072238635edb451")
+	// No recorded response for the commit list
+	require.Error(t, err)
+	q := (*reqs)[0].URL.Query()
+	require.Equal(t, "commit", q.Get("searchCriteria.itemVersion.versionType"))
+	require.Equal(t, "branch", q.Get("searchCriteria.compareVersion.versionType"))
+	require.Equal(t, "main", q.Get("searchCriteria.compareVersion.version"))
+}
+
+func TestPullRequest(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	pr, err := client.PullRequest(context.TODO(), "fabrikam/website", "22")
+	require.NoError(t, err)
+	require.Equal(t, 22, pr.ID)
+	require.True(t, pr.Merged)
+	require.Equal(t, string(sdk.VCSPullRequestStateMerged), pr.State)
+	require.Equal(t, "feat/login", pr.Head.Branch.DisplayID)
+	require.Equal(t, "ffe9cba521f00d7f60e322845072238635edb451", pr.Head.Branch.LatestCommit)
+	require.Equal(t, "main", pr.Base.Branch.DisplayID)
+	require.Equal(t, "https://dev.azure.com/myorg/fabrikam/_git/website/pullrequest/22", pr.URL)
+}
+
+func TestListAndGetContent(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	contents, err := client.ListContent(context.TODO(), "fabrikam/website", "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4", ".cds", "0", "100")
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+	require.Equal(t, "workflow.yml", contents[0].Name)
+	require.True(t, contents[0].IsFile)
+	require.Equal(t, "actions", contents[1].Name)
+	require.True(t, contents[1].IsDirectory)
+
+	content, err := client.GetContent(context.TODO(), "fabrikam/website", "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4", ".cds/workflow.yml")
+	require.NoError(t, err)
+	btes, err := base64.StdEncoding.DecodeString(content.Content)
+	require.NoError(t, err)
+	require.Equal(t, "version: v1.0\nname: my-workflow\n", string(btes))
+}
+
+func TestSetStatus(t *testing.T) {
+	client, reqs, bodies := newTestClient(t)
+	err := client.SetStatus(context.TODO(), sdk.VCSBuildStatus{
+		Description:        "my-workflow:Success",
+		URLCDS:             "https://cds/project/PRJ/run/1",
+		Context:            "PRJ-my-workflow",
+		Status:             sdk.StatusSuccess,
+		RepositoryFullname: "fabrikam/website",
+		GitHash:            "d3d1760b2ac7b8d68b3f9ee4f9e8b1f33ae1b2a4",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, (*reqs)[0].Method)
+
+	var status GitStatus
+	require.NoError(t, json.Unmarshal((*bodies)[0], &status))
+	require.Equal(t, StatusSucceeded, status.State)
+	require.Equal(t, "Success", status.Description)
+	require.Equal(t, "PRJ-my-workflow", status.Context.Name)
+	require.Equal(t, statusGenre, status.Context.Genre)
+}
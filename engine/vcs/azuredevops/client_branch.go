@@ -0,0 +1,74 @@
+package azuredevops
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches lists the branches with their stats, the default branch is the base version of the stats
+// so we don't need to load the repository to know it.
+func (a *azureDevOpsClient) Branches(ctx context.Context, fullname string, filters sdk.VCSBranchesFilter) ([]sdk.VCSBranch, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats GitBranchesStats
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/stats/branches", nil, &stats); err != nil {
+		return nil, sdk.WrapError(err, "unable to list branches of repository %s", fullname)
+	}
+
+	branches := make([]sdk.VCSBranch, 0, len(stats.Value))
+	for _, b := range stats.Value {
+		if filters.Limit > 0 && len(branches) >= int(filters.Limit) {
+			break
+		}
+		branches = append(branches, toVCSBranch(b))
+	}
+	return branches, nil
+}
+
+func (a *azureDevOpsClient) Branch(ctx context.Context, fullname string, filters sdk.VCSBranchFilters) (*sdk.VCSBranch, error) {
+	if filters.Default {
+		branches, err := a.Branches(ctx, fullname, sdk.VCSBranchesFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range branches {
+			if b.Default {
+				return &b, nil
+			}
+		}
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "repository %s has no default branch", fullname)
+	}
+
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	branchName := strings.TrimPrefix(filters.BranchName, sdk.GitRefBranchPrefix)
+	params := url.Values{}
+	params.Set("name", branchName)
+	var stats GitBranchStats
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/stats/branches", params, &stats); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return nil, sdk.WithStack(sdk.ErrNoBranch)
+		}
+		return nil, sdk.WrapError(err, "unable to get branch %s of repository %s", branchName, fullname)
+	}
+	b := toVCSBranch(stats)
+	return &b, nil
+}
+
+func toVCSBranch(b GitBranchStats) sdk.VCSBranch {
+	return sdk.VCSBranch{
+		ID:           sdk.GitRefBranchPrefix + b.Name,
+		DisplayID:    b.Name,
+		LatestCommit: b.Commit.CommitID,
+		Default:      b.IsBaseVersion,
+	}
+}
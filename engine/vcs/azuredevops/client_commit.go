@@ -0,0 +1,123 @@
+package azuredevops
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var commitShaRegexp = regexp.MustCompile("^[0-9a-f]{40}$")
+
+func (a *azureDevOpsClient) Commits(ctx context.Context, fullname, branch, since, until string) ([]sdk.VCSCommit, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	setVersionDescriptor(params, "searchCriteria.itemVersion", branch)
+	if since != "" {
+		params.Set("searchCriteria.fromCommitId", since)
+	}
+	if until != "" {
+		params.Set("searchCriteria.toCommitId", until)
+	}
+
+	var commits GitCommitRefs
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/commits", params, &commits); err != nil {
+		return nil, sdk.WrapError(err, "cannot load commits on branch %s", branch)
+	}
+
+	res := make([]sdk.VCSCommit, 0, len(commits.Value))
+	for _, c := range commits.Value {
+		res = append(res, toVCSCommit(c))
+	}
+	return res, nil
+}
+
+func (a *azureDevOpsClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+
+	var commit GitCommitRef
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/commits/"+url.PathEscape(hash), nil, &commit); err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	return toVCSCommit(commit), nil
+}
+
+// CommitsBetweenRefs returns the commits reachable from head that are not reachable from base
+func (a *azureDevOpsClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSCommit, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	setVersionDescriptor(params, "searchCriteria.itemVersion", head)
+	setVersionDescriptor(params, "searchCriteria.compareVersion", base)
+
+	var commits GitCommitRefs
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/commits", params, &commits); err != nil {
+		return nil, sdk.WrapError(err, "cannot load commits between %s and %s", base, head)
+	}
+
+	res := make([]sdk.VCSCommit, 0, len(commits.Value))
+	for _, c := range commits.Value {
+		res = append(res, toVCSCommit(c))
+	}
+	return res, nil
+}
+
+// setVersionDescriptor sets a GitVersionDescriptor query parameter from a git reference or a commit sha
+func setVersionDescriptor(params url.Values, prefix, version string) {
+	if version == "" {
+		return
+	}
+	switch {
+	case commitShaRegexp.MatchString(version):
+		params.Set(prefix+".versionType", "commit")
+	case strings.HasPrefix(version, sdk.GitRefTagPrefix):
+		params.Set(prefix+".versionType", "tag")
+		version = strings.TrimPrefix(version, sdk.GitRefTagPrefix)
+	default:
+		params.Set(prefix+".versionType", "branch")
+		version = strings.TrimPrefix(version, sdk.GitRefBranchPrefix)
+	}
+	params.Set(prefix+".version", version)
+}
+
+func toVCSCommit(c GitCommitRef) sdk.VCSCommit {
+	return sdk.VCSCommit{
+		Hash:      c.CommitID,
+		Message:   c.Comment,
+		URL:       c.RemoteURL,
+		Timestamp: c.Author.Date.Unix() * 1000,
+		Author:    toVCSAuthor(c.Author),
+		Committer: toVCSAuthor(c.Committer),
+	}
+}
+
+// toVCSAuthor converts a commit user. Azure DevOps does not link commits to its users so the slug
+// is computed from the email address, which is the user principal name on most organizations.
+func toVCSAuthor(u GitUserDate) sdk.VCSAuthor {
+	slug := u.Email
+	if i := strings.Index(slug, "@"); i > 0 {
+		slug = slug[:i]
+	}
+	return sdk.VCSAuthor{
+		Name:        u.Name,
+		DisplayName: u.Name,
+		Email:       u.Email,
+		Slug:        slug,
+	}
+}
+
+func (a *azureDevOpsClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
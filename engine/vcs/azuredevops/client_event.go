@@ -0,0 +1,24 @@
+package azuredevops
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (a *azureDevOpsClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (a *azureDevOpsClient) PushEvents(context.Context, string, []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (a *azureDevOpsClient) CreateEvents(context.Context, string, []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (a *azureDevOpsClient) DeleteEvents(context.Context, string, []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (a *azureDevOpsClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -0,0 +1,104 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (a *azureDevOpsClient) ListContent(ctx context.Context, fullname string, commit, dir string, _, _ string) ([]sdk.VCSContent, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	scopePath := "/" + strings.Trim(dir, "/")
+	params := url.Values{}
+	params.Set("scopePath", scopePath)
+	params.Set("recursionLevel", "OneLevel")
+	setVersionDescriptor(params, "versionDescriptor", commit)
+
+	var items GitItems
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/items", params, &items); err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return []sdk.VCSContent{}, nil
+		}
+		return nil, err
+	}
+
+	resp := make([]sdk.VCSContent, 0, len(items.Value))
+	for _, i := range items.Value {
+		// The scope path itself is returned with its children
+		if i.Path == scopePath {
+			continue
+		}
+		resp = append(resp, toVCSContent(i))
+	}
+	return resp, nil
+}
+
+// GetContent returns the base64 encoded content of a file, like the other API based drivers
+func (a *azureDevOpsClient) GetContent(ctx context.Context, fullname string, commit, filePath string) (sdk.VCSContent, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSContent{}, err
+	}
+
+	params := url.Values{}
+	params.Set("path", "/"+strings.TrimPrefix(filePath, "/"))
+	setVersionDescriptor(params, "versionDescriptor", commit)
+
+	var item GitItem
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/items", params, &item); err != nil {
+		return sdk.VCSContent{}, err
+	}
+	content := toVCSContent(item)
+	if !content.IsFile {
+		return content, nil
+	}
+
+	reader, _, err := a.client.stream(ctx, repoPath(project, repoName)+"/items", params, "application/octet-stream")
+	if err != nil {
+		return sdk.VCSContent{}, err
+	}
+	defer reader.Close()
+	btes, err := io.ReadAll(reader)
+	if err != nil {
+		return sdk.VCSContent{}, sdk.WithStack(err)
+	}
+	content.Content = base64.StdEncoding.EncodeToString(btes)
+	return content, nil
+}
+
+// GetArchive downloads a directory of the repository. Azure DevOps only supports zip archives.
+func (a *azureDevOpsClient) GetArchive(ctx context.Context, fullname string, dir string, format string, commit string) (io.Reader, http.Header, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, nil, err
+	}
+	if format != "" && format != "zip" {
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "archive format %q is not supported by azuredevops, only zip is available", format)
+	}
+
+	params := url.Values{}
+	params.Set("path", "/"+strings.TrimPrefix(dir, "/"))
+	params.Set("$format", "zip")
+	params.Set("download", "true")
+	setVersionDescriptor(params, "versionDescriptor", commit)
+
+	return a.client.stream(ctx, repoPath(project, repoName)+"/items", params, "application/zip")
+}
+
+func toVCSContent(i GitItem) sdk.VCSContent {
+	return sdk.VCSContent{
+		Name:        path.Base(i.Path),
+		IsDirectory: i.IsFolder,
+		IsFile:      !i.IsFolder,
+	}
+}
@@ -0,0 +1,13 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks is not implemented: the Azure DevOps forks API is scoped to a collection ID that is not
+// known by the vcs server configuration
+func (a *azureDevOpsClient) ListForks(ctx context.Context, fullname string) ([]sdk.VCSRepo, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -0,0 +1,145 @@
+package azuredevops
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// CreateHook creates a service hook subscription for each event. Azure DevOps subscriptions are
+// bound to a single event type, so the hook ID is the list of the subscription IDs.
+// Service hooks cannot sign the payload, the hook secret is sent as basic auth password.
+func (a *azureDevOpsClient) CreateHook(ctx context.Context, fullname string, hook *sdk.VCSHook) error {
+	if hook.Secret == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "a secret is required to create an azuredevops service hook")
+	}
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return err
+	}
+
+	var repo Repository
+	if _, err := a.client.get(ctx, repoPath(project, repoName), nil, &repo); err != nil {
+		return err
+	}
+
+	if a.proxyURL != "" {
+		lastIndexSlash := strings.LastIndex(hook.URL, "/")
+		if a.proxyURL[len(a.proxyURL)-1] == '/' {
+			lastIndexSlash++
+		}
+		hook.URL = a.proxyURL + hook.URL[lastIndexSlash:]
+	}
+
+	if len(hook.Events) == 0 {
+		hook.Events = sdk.AzureDevOpsEventsDefault
+	}
+
+	ids := make([]string, 0, len(hook.Events))
+	for _, e := range hook.Events {
+		sub := Subscription{
+			PublisherID:      hookPublisherID,
+			EventType:        e,
+			ResourceVersion:  "1.0",
+			ConsumerID:       hookConsumerID,
+			ConsumerActionID: hookConsumerActionID,
+			PublisherInputs: map[string]string{
+				"projectId":  repo.Project.ID,
+				"repository": repo.ID,
+			},
+			ConsumerInputs: map[string]string{
+				"url":               hook.URL,
+				"basicAuthUsername": hookBasicAuthUsername,
+				"basicAuthPassword": hook.Secret,
+			},
+		}
+		var res Subscription
+		if _, err := a.client.post(ctx, "/_apis/hooks/subscriptions", nil, sub, &res); err != nil {
+			// Rollback already created subscriptions
+			for _, id := range ids {
+				if _, errD := a.client.delete(ctx, "/_apis/hooks/subscriptions/"+url.PathEscape(id), nil); errD != nil {
+					log.Error(ctx, "unable to delete azuredevops subscription %s: %v", id, errD)
+				}
+			}
+			return sdk.WrapError(err, "unable to create azuredevops service hook for event %s", e)
+		}
+		ids = append(ids, res.ID)
+	}
+	hook.ID = strings.Join(ids, ",")
+	return nil
+}
+
+func (a *azureDevOpsClient) getHooks(ctx context.Context, fullname string) ([]Subscription, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if _, err := a.client.get(ctx, repoPath(project, repoName), nil, &repo); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("publisherId", hookPublisherID)
+	params.Set("consumerId", hookConsumerID)
+	var subs Subscriptions
+	if _, err := a.client.get(ctx, "/_apis/hooks/subscriptions", params, &subs); err != nil {
+		return nil, sdk.WrapError(err, "unable to list azuredevops service hooks")
+	}
+
+	res := make([]Subscription, 0, len(subs.Value))
+	for _, s := range subs.Value {
+		if s.PublisherInputs["repository"] == repo.ID {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func (a *azureDevOpsClient) GetHook(ctx context.Context, fullname, webhookURL string) (sdk.VCSHook, error) {
+	subs, err := a.getHooks(ctx, fullname)
+	if err != nil {
+		return sdk.VCSHook{}, err
+	}
+
+	hook := sdk.VCSHook{URL: webhookURL}
+	var ids []string
+	for _, s := range subs {
+		if s.ConsumerInputs["url"] != webhookURL {
+			continue
+		}
+		ids = append(ids, s.ID)
+		hook.Events = append(hook.Events, s.EventType)
+		hook.Disable = hook.Disable || s.Status != "enabled"
+	}
+	if len(ids) == 0 {
+		return sdk.VCSHook{}, sdk.WithStack(sdk.ErrNotFound)
+	}
+	hook.ID = strings.Join(ids, ",")
+	return hook, nil
+}
+
+// UpdateHook replaces the subscriptions of the hook as the subscription event type cannot be updated
+func (a *azureDevOpsClient) UpdateHook(ctx context.Context, fullname string, hook *sdk.VCSHook) error {
+	if err := a.DeleteHook(ctx, fullname, *hook); err != nil {
+		return err
+	}
+	return a.CreateHook(ctx, fullname, hook)
+}
+
+func (a *azureDevOpsClient) DeleteHook(ctx context.Context, fullname string, hook sdk.VCSHook) error {
+	for _, id := range strings.Split(hook.ID, ",") {
+		if id == "" {
+			continue
+		}
+		if _, err := a.client.delete(ctx, "/_apis/hooks/subscriptions/"+url.PathEscape(id), nil); err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return sdk.WrapError(err, "unable to delete azuredevops service hook %s", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (a *azureDevOpsClient) PullRequest(ctx context.Context, fullname string, id string) (sdk.VCSPullRequest, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return sdk.VCSPullRequest{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pull-request id %q", id)
+	}
+
+	var pr GitPullRequest
+	if _, err := a.client.get(ctx, fmt.Sprintf("%s/pullrequests/%d", repoPath(project, repoName), i), nil, &pr); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "unable to get azuredevops pull-request repo:%v id:%v", fullname, id)
+	}
+	return toVCSPullRequest(pr), nil
+}
+
+func (a *azureDevOpsClient) PullRequests(ctx context.Context, fullname string, opts sdk.VCSPullRequestOptions) ([]sdk.VCSPullRequest, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, err := a.listPullRequests(ctx, project, repoName, opts.State)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get azuredevops pull-requests from repo:%v", fullname)
+	}
+
+	ret := make([]sdk.VCSPullRequest, 0, len(prs))
+	for _, pr := range prs {
+		ret = append(ret, toVCSPullRequest(pr))
+	}
+	return ret, nil
+}
+
+func (a *azureDevOpsClient) listPullRequests(ctx context.Context, project, repoName string, state sdk.VCSPullRequestState) ([]GitPullRequest, error) {
+	// Map CDS state to Azure DevOps status: active, abandoned, completed, all.
+	params := url.Values{}
+	switch state {
+	case sdk.VCSPullRequestStateOpen:
+		params.Set("searchCriteria.status", string(PullRequestStatusActive))
+	case sdk.VCSPullRequestStateClosed:
+		params.Set("searchCriteria.status", string(PullRequestStatusAbandoned))
+	case sdk.VCSPullRequestStateMerged:
+		params.Set("searchCriteria.status", string(PullRequestStatusCompleted))
+	case sdk.VCSPullRequestStateAll:
+		params.Set("searchCriteria.status", string(PullRequestStatusAll))
+	}
+	params.Set("$top", "100")
+
+	var prs GitPullRequests
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/pullrequests", params, &prs); err != nil {
+		return nil, err
+	}
+	return prs.Value, nil
+}
+
+// PullRequestComment push a new comment on a pull request. On Azure DevOps a comment is always
+// part of a thread, so a new thread is created for each comment.
+func (a *azureDevOpsClient) PullRequestComment(ctx context.Context, fullname string, prRequest sdk.VCSPullRequestCommentRequest) error {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return err
+	}
+
+	log.Debug(ctx, "PullRequestComment> trying post comment %s", prRequest.Message)
+
+	thread := CommentThread{
+		Comments: []Comment{{
+			ParentCommentID: 0,
+			Content:         prRequest.Message,
+			CommentType:     "text",
+		}},
+		Status: "active",
+	}
+	var res CommentThread
+	path := fmt.Sprintf("%s/pullRequests/%d/threads", repoPath(project, repoName), prRequest.ID)
+	if _, err := a.client.post(ctx, path, nil, thread, &res); err != nil {
+		return sdk.WrapError(err, "unable to create pull-request comment on repo:%v id:%v", fullname, prRequest.ID)
+	}
+
+	log.Debug(ctx, "PullRequestComment> thread %d created", res.ID)
+	return nil
+}
+
+func (a *azureDevOpsClient) PullRequestCreate(ctx context.Context, fullname string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+
+	opts := CreatePullRequestOption{
+		SourceRefName: sdk.GitRefBranchPrefix + strings.TrimPrefix(pr.Head.Branch.DisplayID, sdk.GitRefBranchPrefix),
+		TargetRefName: sdk.GitRefBranchPrefix + strings.TrimPrefix(pr.Base.Branch.DisplayID, sdk.GitRefBranchPrefix),
+		Title:         pr.Title,
+	}
+	var res GitPullRequest
+	if _, err := a.client.post(ctx, repoPath(project, repoName)+"/pullrequests", nil, opts, &res); err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "unable to create pull-request on repo:%v", fullname)
+	}
+	return toVCSPullRequest(res), nil
+}
+
+// PullRequestMergeStatus checks the merge status and the votes of the reviewers of a pull request. The branch
+// policies are evaluated by Azure DevOps when the pull request is completed.
+func (a *azureDevOpsClient) PullRequestMergeStatus(ctx context.Context, fullname string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, err
+	}
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pull-request id %q", id)
+	}
+
+	var pr GitPullRequest
+	if _, err := a.client.get(ctx, fmt.Sprintf("%s/pullrequests/%d", repoPath(project, repoName), i), nil, &pr); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get azuredevops pull-request repo:%v id:%v", fullname, id)
+	}
+
+	var approvals int
+	approved := true
+	for _, r := range pr.Reviewers {
+		switch {
+		case r.Vote >= 5:
+			approvals++
+		case r.Vote < 0, r.IsRequired:
+			approved = false
+		}
+	}
+
+	status := sdk.VCSPullRequestMergeStatus{
+		Mergeable: pr.Status == PullRequestStatusActive && !pr.IsDraft && pr.MergeStatus == "succeeded",
+		Approved:  approved && approvals > 0,
+	}
+	switch {
+	case pr.IsDraft:
+		status.Reason = "pull request is a draft"
+	case !status.Mergeable:
+		status.Reason = fmt.Sprintf("pull request is %s and merge status is %s", pr.Status, pr.MergeStatus)
+	case !status.Approved:
+		status.Reason = "pull request is not approved"
+	}
+	return status, nil
+}
+
+// PullRequestMerge completes a pull request, Azure DevOps refuses the completion if the source branch has moved
+func (a *azureDevOpsClient) PullRequestMerge(ctx context.Context, fullname string, req sdk.VCSPullRequestMergeRequest) error {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return err
+	}
+
+	opts := CompletePullRequestOption{
+		Status:                PullRequestStatusCompleted,
+		LastMergeSourceCommit: GitCommitRef{CommitID: req.Commit},
+	}
+	opts.CompletionOptions.MergeStrategy = "noFastForward"
+	opts.CompletionOptions.MergeCommitMessage = req.Message
+	path := fmt.Sprintf("%s/pullrequests/%d", repoPath(project, repoName), req.ID)
+	if _, err := a.client.patch(ctx, path, nil, opts, nil); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge pull request %d on %s", req.ID, fullname))
+	}
+	return nil
+}
+
+func toVCSPullRequest(pr GitPullRequest) sdk.VCSPullRequest {
+	repoFullname := pr.Repository.Project.Name + "/" + pr.Repository.Name
+	vcsPR := sdk.VCSPullRequest{
+		ID:    pr.PullRequestID,
+		Title: pr.Title,
+		URL:   pr.Repository.WebURL + "/pullrequest/" + strconv.Itoa(pr.PullRequestID),
+		User: sdk.VCSAuthor{
+			Name:        pr.CreatedBy.UniqueName,
+			DisplayName: pr.CreatedBy.DisplayName,
+			Slug:        pr.CreatedBy.UniqueName,
+			Avatar:      pr.CreatedBy.ImageURL,
+			ID:          pr.CreatedBy.ID,
+		},
+		Head: sdk.VCSPushEvent{
+			Repo:     repoFullname,
+			CloneURL: pr.Repository.RemoteURL,
+			Branch: sdk.VCSBranch{
+				ID:        pr.SourceRefName,
+				DisplayID: strings.TrimPrefix(pr.SourceRefName, sdk.GitRefBranchPrefix),
+			},
+		},
+		Base: sdk.VCSPushEvent{
+			Repo:     repoFullname,
+			CloneURL: pr.Repository.RemoteURL,
+			Branch: sdk.VCSBranch{
+				ID:        pr.TargetRefName,
+				DisplayID: strings.TrimPrefix(pr.TargetRefName, sdk.GitRefBranchPrefix),
+			},
+		},
+		Updated: pr.CreationDate,
+	}
+	if pr.LastMergeSourceCommit != nil {
+		vcsPR.Head.Branch.LatestCommit = pr.LastMergeSourceCommit.CommitID
+		vcsPR.Head.Commit.Hash = pr.LastMergeSourceCommit.CommitID
+	}
+	if pr.LastMergeTargetCommit != nil {
+		vcsPR.Base.Branch.LatestCommit = pr.LastMergeTargetCommit.CommitID
+		vcsPR.Base.Commit.Hash = pr.LastMergeTargetCommit.CommitID
+	}
+
+	switch pr.Status {
+	case PullRequestStatusActive:
+		vcsPR.State = string(sdk.VCSPullRequestStateOpen)
+	case PullRequestStatusAbandoned:
+		vcsPR.State = string(sdk.VCSPullRequestStateClosed)
+		vcsPR.Closed = true
+		vcsPR.Updated = pr.ClosedDate
+	case PullRequestStatusCompleted:
+		vcsPR.State = string(sdk.VCSPullRequestStateMerged)
+		vcsPR.Closed = true
+		vcsPR.Merged = true
+		vcsPR.Updated = pr.ClosedDate
+		if pr.ClosedBy != nil {
+			vcsPR.MergeBy = sdk.VCSAuthor{
+				Name:        pr.ClosedBy.UniqueName,
+				DisplayName: pr.ClosedBy.DisplayName,
+				Slug:        pr.ClosedBy.UniqueName,
+				Avatar:      pr.ClosedBy.ImageURL,
+				ID:          pr.ClosedBy.ID,
+			}
+		}
+	}
+	return vcsPR
+}
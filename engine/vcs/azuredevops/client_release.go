@@ -0,0 +1,16 @@
+package azuredevops
+
+import (
+	"context"
+	"io"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release is not implemented: Azure DevOps Repos has no release concept
+func (a *azureDevOpsClient) Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (a *azureDevOpsClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, fileLength int) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
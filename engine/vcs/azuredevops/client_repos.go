@@ -0,0 +1,52 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (a *azureDevOpsClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	var repos Repositories
+	if _, err := a.client.get(ctx, "/_apis/git/repositories", nil, &repos); err != nil {
+		return nil, sdk.WrapError(err, "unable to list azuredevops repositories")
+	}
+
+	repositories := make([]sdk.VCSRepo, 0, len(repos.Value))
+	for _, r := range repos.Value {
+		if r.IsDisabled {
+			continue
+		}
+		repositories = append(repositories, a.ToVCSRepo(r))
+	}
+	return repositories, nil
+}
+
+func (a *azureDevOpsClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSRepo{}, err
+	}
+
+	var repo Repository
+	if _, err := a.client.get(ctx, repoPath(project, repoName), nil, &repo); err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	return a.ToVCSRepo(repo), nil
+}
+
+func (a *azureDevOpsClient) ToVCSRepo(repo Repository) sdk.VCSRepo {
+	return sdk.VCSRepo{
+		URL:                  repo.WebURL,
+		URLCommitFormat:      repo.WebURL + "/commit/%s",
+		URLTagFormat:         repo.WebURL + "?version=GT%s",
+		URLBranchFormat:      repo.WebURL + "?version=GB%s",
+		URLPullRequestFormat: repo.WebURL + "/pullrequest/%d",
+		Name:                 repo.Name,
+		ID:                   repo.ID,
+		Fullname:             repo.Project.Name + "/" + repo.Name,
+		HTTPCloneURL:         repo.RemoteURL,
+		SSHCloneURL:          repo.SSHURL,
+		Slug:                 repo.Name,
+	}
+}
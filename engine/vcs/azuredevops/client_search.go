@@ -0,0 +1,26 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (a *azureDevOpsClient) SearchPullRequest(ctx context.Context, repoFullName, commit, state string) (*sdk.VCSPullRequest, error) {
+	project, repoName, err := getRepo(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, err := a.listPullRequests(ctx, project, repoName, sdk.VCSPullRequestState(state))
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		if pr.LastMergeSourceCommit != nil && sdk.VCSIsSameCommit(pr.LastMergeSourceCommit.CommitID, commit) {
+			vcsPR := toVCSPullRequest(pr)
+			return &vcsPR, nil
+		}
+	}
+	return nil, sdk.WithStack(sdk.ErrNotFound)
+}
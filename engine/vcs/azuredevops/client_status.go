@@ -0,0 +1,114 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const statusGenre = "cds"
+
+func (a *azureDevOpsClient) CreateInsightReport(ctx context.Context, repo string, sha string, insightKey string, vcsReport sdk.VCSInsight) error {
+	// Ignore this call on azuredevops, like github
+	return nil
+}
+
+func (a *azureDevOpsClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (a *azureDevOpsClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
+	if buildStatus.Status == "" {
+		log.Debug(ctx, "azuredevops.SetStatus> Do not process event for empty status")
+		return nil
+	}
+
+	status := GitStatus{
+		Context: GitStatusContext{
+			Name:  buildStatus.Context,
+			Genre: statusGenre,
+		},
+		TargetURL: buildStatus.URLCDS,
+	}
+
+	// Like on forgejo, remove the context from the description as it is displayed next to it
+	td := strings.Split(buildStatus.Description, ":")
+	if len(td) == 2 {
+		status.Description = td[1]
+	} else {
+		status.Description = buildStatus.Description
+	}
+
+	switch buildStatus.Status {
+	case sdk.StatusChecking, sdk.StatusPending, sdk.StatusBuilding:
+		status.State = StatusPending
+	case sdk.StatusSuccess:
+		status.State = StatusSucceeded
+	case sdk.StatusFail:
+		status.State = StatusFailed
+	case sdk.StatusSkipped, sdk.StatusDisabled:
+		status.State = StatusNotApplicable
+	case sdk.StatusStopped:
+		status.State = StatusError
+	default:
+		status.State = StatusPending
+	}
+
+	project, repoName, err := getRepo(buildStatus.RepositoryFullname)
+	if err != nil {
+		return err
+	}
+
+	apiPath := fmt.Sprintf("%s/commits/%s/statuses", repoPath(project, repoName), url.PathEscape(buildStatus.GitHash))
+	log.Debug(ctx, "SetStatus> azuredevops post on %v", apiPath)
+
+	var s GitStatus
+	if _, err := a.client.post(ctx, apiPath, nil, status, &s); err != nil {
+		return sdk.WrapError(err, "unable to post azuredevops status")
+	}
+
+	log.Debug(ctx, "SetStatus> Status %d created at %v", s.ID, s.CreationDate)
+	return nil
+}
+
+func (a *azureDevOpsClient) ListStatuses(ctx context.Context, fullname string, ref string) ([]sdk.VCSCommitStatus, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses GitStatuses
+	apiPath := fmt.Sprintf("%s/commits/%s/statuses", repoPath(project, repoName), url.PathEscape(ref))
+	if _, err := a.client.get(ctx, apiPath, nil, &statuses); err != nil {
+		return nil, err
+	}
+
+	vcsStatuses := make([]sdk.VCSCommitStatus, 0, len(statuses.Value))
+	for _, s := range statuses.Value {
+		vcsStatuses = append(vcsStatuses, sdk.VCSCommitStatus{
+			CreatedAt:  s.CreationDate,
+			Decription: s.Context.Name,
+			Ref:        ref,
+			State:      processAzureDevOpsState(s.State),
+		})
+	}
+	return vcsStatuses, nil
+}
+
+func processAzureDevOpsState(s GitStatusState) string {
+	switch s {
+	case StatusSucceeded:
+		return sdk.StatusSuccess
+	case StatusError, StatusFailed:
+		return sdk.StatusFail
+	case StatusPending:
+		return sdk.StatusBuilding
+	default:
+		return sdk.StatusDisabled
+	}
+}
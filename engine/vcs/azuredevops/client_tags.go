@@ -0,0 +1,89 @@
+package azuredevops
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags retrieve tags
+func (a *azureDevOpsClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("filter", "tags/")
+	params.Set("peelTags", "true")
+	var refs GitRefs
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/refs", params, &refs); err != nil {
+		return nil, sdk.WrapError(err, "unable to list tags of repository %s", fullname)
+	}
+
+	tags := make([]sdk.VCSTag, 0, len(refs.Value))
+	for _, r := range refs.Value {
+		tags = append(tags, toVCSTag(r))
+	}
+	return tags, nil
+}
+
+func (a *azureDevOpsClient) Tag(ctx context.Context, fullname string, tagName string) (sdk.VCSTag, error) {
+	project, repoName, err := getRepo(fullname)
+	if err != nil {
+		return sdk.VCSTag{}, err
+	}
+	tagName = strings.TrimPrefix(tagName, sdk.GitRefTagPrefix)
+
+	params := url.Values{}
+	params.Set("filter", "tags/"+tagName)
+	params.Set("peelTags", "true")
+	var refs GitRefs
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/refs", params, &refs); err != nil {
+		return sdk.VCSTag{}, sdk.WrapError(err, "unable to get tag %s of repository %s", tagName, fullname)
+	}
+
+	var ref *GitRef
+	for i := range refs.Value {
+		if refs.Value[i].Name == sdk.GitRefTagPrefix+tagName {
+			ref = &refs.Value[i]
+			break
+		}
+	}
+	if ref == nil {
+		return sdk.VCSTag{}, sdk.NewErrorFrom(sdk.ErrNotFound, "tag %s not found", tagName)
+	}
+	vcsTag := toVCSTag(*ref)
+
+	// Lightweight tag: there is no tag object, the ref points directly to the commit
+	if ref.PeeledObjectID == "" {
+		return vcsTag, nil
+	}
+
+	var annotated GitAnnotatedTag
+	if _, err := a.client.get(ctx, repoPath(project, repoName)+"/annotatedtags/"+url.PathEscape(ref.ObjectID), nil, &annotated); err != nil {
+		return sdk.VCSTag{}, sdk.WrapError(err, "unable to get annotated tag %s", tagName)
+	}
+	vcsTag.Message = annotated.Message
+	vcsTag.Tagger = sdk.VCSAuthor{
+		Name:        annotated.TaggedBy.Name,
+		DisplayName: annotated.TaggedBy.Name,
+		Email:       annotated.TaggedBy.Email,
+	}
+	return vcsTag, nil
+}
+
+func toVCSTag(r GitRef) sdk.VCSTag {
+	vcsTag := sdk.VCSTag{
+		Tag:  strings.TrimPrefix(r.Name, sdk.GitRefTagPrefix),
+		Sha:  r.ObjectID,
+		Hash: r.ObjectID,
+	}
+	// On annotated tags, the peeled object is the tagged commit
+	if r.PeeledObjectID != "" {
+		vcsTag.Hash = r.PeeledObjectID
+	}
+	return vcsTag
+}
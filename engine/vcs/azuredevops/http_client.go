@@ -0,0 +1,173 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const apiVersion = "7.1"
+
+// azureDevOpsHTTPClient is a simple HTTP client for the Azure DevOps REST API.
+type azureDevOpsHTTPClient struct {
+	baseURL    string
+	username   string
+	token      string
+	httpClient *http.Client
+}
+
+// newAzureDevOpsHTTPClient creates a new Azure DevOps HTTP client authenticated with a personal access token.
+func newAzureDevOpsHTTPClient(baseURL, username, token string) *azureDevOpsHTTPClient {
+	return &azureDevOpsHTTPClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		username:   username,
+		token:      token,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// buildURL adds the api-version query parameter mandatory on every Azure DevOps call.
+func (c *azureDevOpsHTTPClient) buildURL(path string, params url.Values) string {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("api-version", apiVersion)
+	return c.baseURL + path + "?" + params.Encode()
+}
+
+// newRequest creates an authenticated request. Azure DevOps accepts a personal access token
+// as the password of a basic authentication, the username can be empty.
+func (c *azureDevOpsHTTPClient) newRequest(ctx context.Context, method, path string, params url.Values, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.buildURL(path, params), body)
+	if err != nil {
+		return nil, sdk.WrapError(err, "azuredevops HTTP client: failed to create request")
+	}
+	req.SetBasicAuth(c.username, c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// doRequest performs an HTTP request and decodes the JSON response into result.
+func (c *azureDevOpsHTTPClient) doRequest(ctx context.Context, method, path string, params url.Values, body io.Reader, result interface{}) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, path, params, body)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug(ctx, "azuredevops HTTP client: %s %s", method, req.URL.String())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, sdk.WrapError(err, "azuredevops HTTP client: request failed")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, sdk.WrapError(err, "azuredevops HTTP client: failed to read response body")
+	}
+
+	if err := checkResponse(method, path, resp.StatusCode, respBody); err != nil {
+		return resp, err
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return resp, sdk.WrapError(err, "azuredevops HTTP client: failed to decode response")
+		}
+	}
+
+	return resp, nil
+}
+
+// checkResponse converts Azure DevOps error status into CDS errors.
+func checkResponse(method, path string, statusCode int, body []byte) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "azuredevops HTTP client: %s %s: resource not found", method, path)
+	case statusCode == http.StatusUnauthorized:
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "azuredevops HTTP client: %s %s: invalid credentials", method, path)
+	case statusCode == http.StatusForbidden:
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "azuredevops HTTP client: %s %s: forbidden", method, path)
+	case statusCode == http.StatusNonAuthoritativeInfo:
+		// Azure DevOps redirects to the sign-in page with a 203 when the token is not valid
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "azuredevops HTTP client: %s %s: invalid credentials", method, path)
+	case statusCode >= 400:
+		return sdk.WithStack(fmt.Errorf("azuredevops HTTP client: %s %s returned status %d: %s", method, path, statusCode, string(body)))
+	}
+	return nil
+}
+
+// get performs a GET request.
+func (c *azureDevOpsHTTPClient) get(ctx context.Context, path string, params url.Values, result interface{}) (*http.Response, error) {
+	return c.doRequest(ctx, http.MethodGet, path, params, nil, result)
+}
+
+// post performs a POST request with a JSON body.
+func (c *azureDevOpsHTTPClient) post(ctx context.Context, path string, params url.Values, body interface{}, result interface{}) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, path, params, body, result)
+}
+
+// put performs a PUT request with a JSON body.
+func (c *azureDevOpsHTTPClient) put(ctx context.Context, path string, params url.Values, body interface{}, result interface{}) (*http.Response, error) {
+	return c.send(ctx, http.MethodPut, path, params, body, result)
+}
+
+// patch performs a PATCH request with a JSON body.
+func (c *azureDevOpsHTTPClient) patch(ctx context.Context, path string, params url.Values, body interface{}, result interface{}) (*http.Response, error) {
+	return c.send(ctx, http.MethodPatch, path, params, body, result)
+}
+
+// delete performs a DELETE request.
+func (c *azureDevOpsHTTPClient) delete(ctx context.Context, path string, params url.Values) (*http.Response, error) {
+	return c.doRequest(ctx, http.MethodDelete, path, params, nil, nil)
+}
+
+func (c *azureDevOpsHTTPClient) send(ctx context.Context, method, path string, params url.Values, body interface{}, result interface{}) (*http.Response, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, sdk.WrapError(err, "azuredevops HTTP client: failed to marshal body")
+	}
+	return c.doRequest(ctx, method, path, params, bytes.NewReader(b), result)
+}
+
+// stream performs a GET request and returns the raw response body. The caller has to close it.
+func (c *azureDevOpsHTTPClient) stream(ctx context.Context, path string, params url.Values, accept string) (io.ReadCloser, http.Header, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "azuredevops HTTP client: request failed")
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if err := checkResponse(http.MethodGet, path, resp.StatusCode, body); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, sdk.WithStack(fmt.Errorf("azuredevops HTTP client: GET %s returned status %d", path, resp.StatusCode))
+	}
+	return resp.Body, resp.Header, nil
+}
+
+// repoPath returns the base api path of a git repository.
+func repoPath(project, repo string) string {
+	return fmt.Sprintf("/%s/_apis/git/repositories/%s", url.PathEscape(project), url.PathEscape(repo))
+}
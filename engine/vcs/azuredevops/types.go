@@ -0,0 +1,279 @@
+package azuredevops
+
+import "time"
+
+// GitStatusState holds the state of a commit status.
+// It can be "notSet", "pending", "succeeded", "failed", "error" and "notApplicable".
+type GitStatusState string
+
+const (
+	StatusNotSet        GitStatusState = "notSet"
+	StatusPending       GitStatusState = "pending"
+	StatusSucceeded     GitStatusState = "succeeded"
+	StatusFailed        GitStatusState = "failed"
+	StatusError         GitStatusState = "error"
+	StatusNotApplicable GitStatusState = "notApplicable"
+)
+
+// PullRequestStatus is the status of a pull request (active, abandoned, completed).
+type PullRequestStatus string
+
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+	PullRequestStatusAll       PullRequestStatus = "all"
+)
+
+// --- Identities ---
+
+// IdentityRef represents an Azure DevOps user.
+type IdentityRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	ImageURL    string `json:"imageUrl"`
+}
+
+// GitUserDate contains information of a user in the context of a commit.
+type GitUserDate struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// --- Repositories ---
+
+// TeamProjectReference represents the Azure DevOps project that owns a repository.
+type TeamProjectReference struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Repository represents an Azure DevOps git repository.
+type Repository struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	URL           string               `json:"url"`
+	Project       TeamProjectReference `json:"project"`
+	DefaultBranch string               `json:"defaultBranch"` // refs/heads/main
+	RemoteURL     string               `json:"remoteUrl"`
+	SSHURL        string               `json:"sshUrl"`
+	WebURL        string               `json:"webUrl"`
+	IsFork        bool                 `json:"isFork"`
+	IsDisabled    bool                 `json:"isDisabled"`
+}
+
+// Repositories is the list response of repositories.
+type Repositories struct {
+	Count int          `json:"count"`
+	Value []Repository `json:"value"`
+}
+
+// --- Refs ---
+
+// GitRef represents a branch or a tag reference.
+type GitRef struct {
+	Name           string      `json:"name"` // refs/heads/main, refs/tags/v1.0.0
+	ObjectID       string      `json:"objectId"`
+	PeeledObjectID string      `json:"peeledObjectId"` // commit sha of an annotated tag
+	Creator        IdentityRef `json:"creator"`
+	URL            string      `json:"url"`
+}
+
+// GitRefs is the list response of refs.
+type GitRefs struct {
+	Count int      `json:"count"`
+	Value []GitRef `json:"value"`
+}
+
+// GitBranchStats represents a branch compared to the base version of the repository. When no base
+// version is given, the base version is the default branch.
+type GitBranchStats struct {
+	Name          string       `json:"name"` // main
+	AheadCount    int          `json:"aheadCount"`
+	BehindCount   int          `json:"behindCount"`
+	IsBaseVersion bool         `json:"isBaseVersion"`
+	Commit        GitCommitRef `json:"commit"`
+}
+
+// GitBranchesStats is the list response of branch stats.
+type GitBranchesStats struct {
+	Count int              `json:"count"`
+	Value []GitBranchStats `json:"value"`
+}
+
+// GitAnnotatedTag represents an annotated tag object.
+type GitAnnotatedTag struct {
+	Name         string      `json:"name"`
+	ObjectID     string      `json:"objectId"`
+	Message      string      `json:"message"`
+	TaggedBy     GitUserDate `json:"taggedBy"`
+	TaggedObject struct {
+		ObjectID   string `json:"objectId"`
+		ObjectType string `json:"objectType"`
+	} `json:"taggedObject"`
+}
+
+// --- Commits ---
+
+// GitCommitRef represents a commit.
+type GitCommitRef struct {
+	CommitID  string      `json:"commitId"`
+	Author    GitUserDate `json:"author"`
+	Committer GitUserDate `json:"committer"`
+	Comment   string      `json:"comment"`
+	Parents   []string    `json:"parents"`
+	URL       string      `json:"url"`
+	RemoteURL string      `json:"remoteUrl"`
+}
+
+// GitCommitRefs is the list response of commits.
+type GitCommitRefs struct {
+	Count int            `json:"count"`
+	Value []GitCommitRef `json:"value"`
+}
+
+// --- Pull requests ---
+
+// GitPullRequest represents a pull request.
+type GitPullRequest struct {
+	PullRequestID         int               `json:"pullRequestId"`
+	Repository            Repository        `json:"repository"`
+	Status                PullRequestStatus `json:"status"`
+	CreatedBy             IdentityRef       `json:"createdBy"`
+	CreationDate          time.Time         `json:"creationDate"`
+	ClosedDate            time.Time         `json:"closedDate"`
+	ClosedBy              *IdentityRef      `json:"closedBy,omitempty"`
+	Title                 string            `json:"title"`
+	Description           string            `json:"description"`
+	SourceRefName         string            `json:"sourceRefName"`
+	TargetRefName         string            `json:"targetRefName"`
+	MergeStatus           string            `json:"mergeStatus"`
+	IsDraft               bool              `json:"isDraft"`
+	LastMergeSourceCommit *GitCommitRef     `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *GitCommitRef     `json:"lastMergeTargetCommit,omitempty"`
+	LastMergeCommit       *GitCommitRef     `json:"lastMergeCommit,omitempty"`
+	Reviewers             []ReviewerRef     `json:"reviewers,omitempty"`
+	URL                   string            `json:"url"`
+}
+
+// ReviewerRef is a reviewer of a pull request. The vote is 10 for approved, 5 for approved with suggestions,
+// 0 for no vote, -5 for waiting for author and -10 for rejected.
+type ReviewerRef struct {
+	IdentityRef
+	Vote       int  `json:"vote"`
+	IsRequired bool `json:"isRequired"`
+}
+
+// CompletePullRequestOption is the body used to complete a pull request
+type CompletePullRequestOption struct {
+	Status                PullRequestStatus `json:"status"`
+	LastMergeSourceCommit GitCommitRef      `json:"lastMergeSourceCommit"`
+	CompletionOptions     struct {
+		MergeStrategy      string `json:"mergeStrategy"`
+		MergeCommitMessage string `json:"mergeCommitMessage"`
+	} `json:"completionOptions"`
+}
+
+// GitPullRequests is the list response of pull requests.
+type GitPullRequests struct {
+	Count int              `json:"count"`
+	Value []GitPullRequest `json:"value"`
+}
+
+// CreatePullRequestOption is the body used to create a pull request.
+type CreatePullRequestOption struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description,omitempty"`
+}
+
+// Comment represents a comment in a pull request thread.
+type Comment struct {
+	ID              int         `json:"id,omitempty"`
+	ParentCommentID int         `json:"parentCommentId"`
+	Content         string      `json:"content"`
+	CommentType     string      `json:"commentType"`
+	Author          IdentityRef `json:"author,omitempty"`
+}
+
+// CommentThread represents a pull request thread.
+type CommentThread struct {
+	ID       int       `json:"id,omitempty"`
+	Comments []Comment `json:"comments"`
+	Status   string    `json:"status"`
+}
+
+// --- Statuses ---
+
+// GitStatusContext is the status context that uniquely identifies the status.
+type GitStatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre"`
+}
+
+// GitStatus represents a commit status.
+type GitStatus struct {
+	ID           int              `json:"id,omitempty"`
+	State        GitStatusState   `json:"state"`
+	Description  string           `json:"description"`
+	TargetURL    string           `json:"targetUrl,omitempty"`
+	Context      GitStatusContext `json:"context"`
+	CreationDate time.Time        `json:"creationDate,omitempty"`
+}
+
+// GitStatuses is the list response of commit statuses.
+type GitStatuses struct {
+	Count int         `json:"count"`
+	Value []GitStatus `json:"value"`
+}
+
+// --- Items ---
+
+// GitItem represents a file or a folder of a repository.
+type GitItem struct {
+	ObjectID      string `json:"objectId"`
+	GitObjectType string `json:"gitObjectType"` // blob, tree
+	CommitID      string `json:"commitId"`
+	Path          string `json:"path"`
+	IsFolder      bool   `json:"isFolder"`
+	Content       string `json:"content"`
+	URL           string `json:"url"`
+}
+
+// GitItems is the list response of items.
+type GitItems struct {
+	Count int       `json:"count"`
+	Value []GitItem `json:"value"`
+}
+
+// --- Service hooks ---
+
+const (
+	hookPublisherID      = "tfs"
+	hookConsumerID       = "webHooks"
+	hookConsumerActionID = "httpRequest"
+	// hookBasicAuthUsername is not checked by the hooks service, only the password is
+	hookBasicAuthUsername = "cds"
+)
+
+// Subscription represents a service hook subscription.
+type Subscription struct {
+	ID               string            `json:"id,omitempty"`
+	PublisherID      string            `json:"publisherId"`
+	EventType        string            `json:"eventType"`
+	ResourceVersion  string            `json:"resourceVersion,omitempty"`
+	ConsumerID       string            `json:"consumerId"`
+	ConsumerActionID string            `json:"consumerActionId"`
+	Status           string            `json:"status,omitempty"`
+	PublisherInputs  map[string]string `json:"publisherInputs"`
+	ConsumerInputs   map[string]string `json:"consumerInputs"`
+}
+
+// Subscriptions is the list response of service hook subscriptions.
+type Subscriptions struct {
+	Count int            `json:"count"`
+	Value []Subscription `json:"value"`
+}
@@ -175,3 +175,7 @@ func (client *bitbucketcloudClient) CommitsBetweenRefs(ctx context.Context, repo
 
 	return commitsResult, nil
 }
+
+func (client *bitbucketcloudClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
 
 	"github.com/rockbears/log"
 
@@ -83,6 +84,83 @@ func (client *bitbucketcloudClient) PullRequests(ctx context.Context, fullname s
 	return responsePullRequest, nil
 }
 
+// PullRequestMergeStatus checks the state and the participants of a pull request. Bitbucket Cloud does not expose
+// the result of the merge checks, they are enforced when the pull request is merged.
+func (client *bitbucketcloudClient) PullRequestMergeStatus(ctx context.Context, fullname string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	status, body, _, err := client.get(ctx, fmt.Sprintf("/repositories/%s/pullrequests/%s", fullname, id))
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, err
+	}
+	if status >= 400 {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewError(sdk.ErrNotFound, errorAPI(body))
+	}
+	var pr PullRequest
+	if err := sdk.JSONUnmarshal(body, &pr); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WithStack(err)
+	}
+
+	var approvals, changesRequested int
+	for _, p := range pr.Participants {
+		switch {
+		case p.Approved:
+			approvals++
+		case p.State == "changes_requested":
+			changesRequested++
+		}
+	}
+
+	res := sdk.VCSPullRequestMergeStatus{
+		Mergeable: pr.State == "OPEN",
+		Approved:  approvals > 0 && changesRequested == 0,
+	}
+	switch {
+	case !res.Mergeable:
+		res.Reason = fmt.Sprintf("pull request state is %s", pr.State)
+	case !res.Approved:
+		res.Reason = "pull request is not approved"
+	}
+	return res, nil
+}
+
+// PullRequestMerge merges a pull request if its head is still the given commit
+func (client *bitbucketcloudClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	status, body, _, err := client.get(ctx, fmt.Sprintf("/repositories/%s/pullrequests/%d", repo, req.ID))
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		return sdk.NewError(sdk.ErrNotFound, errorAPI(body))
+	}
+	var pr PullRequest
+	if err := sdk.JSONUnmarshal(body, &pr); err != nil {
+		return sdk.WithStack(err)
+	}
+	// Bitbucket Cloud returns short hashes
+	if pr.Source.Commit.Hash == "" || !strings.HasPrefix(req.Commit, pr.Source.Commit.Hash) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %d head has moved to %s", req.ID, pr.Source.Commit.Hash)
+	}
+
+	path := fmt.Sprintf("/repositories/%s/pullrequests/%d/merge", repo, req.ID)
+	values, _ := json.Marshal(map[string]string{
+		"message":        req.Message,
+		"merge_strategy": "merge_commit",
+	})
+	res, err := client.post(ctx, path, "application/json", bytes.NewReader(values), &postOptions{skipDefaultBaseURL: false, asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to merge pull request %d on %s", req.ID, repo)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+	if res.StatusCode != 200 && res.StatusCode != 202 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge pull request %d on %s: %s", req.ID, repo, errorAPI(resBody))
+	}
+	return nil
+}
+
 type BitbucketCloudPullRequestComment struct {
 	Content struct {
 		Raw    string `json:"raw,omitempty"`
@@ -18,6 +18,10 @@ func (b *bitbucketcloudClient) CreateInsightReport(ctx context.Context, repo str
 	return nil
 }
 
+func (b *bitbucketcloudClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
 // SetStatus Users with push access can create commit statuses for a given ref:
 func (client *bitbucketcloudClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
 	if buildStatus.Status == "" {
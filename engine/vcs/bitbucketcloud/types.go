@@ -166,6 +166,14 @@ type PullRequest struct {
 	MergeCommit  struct {
 		Hash string `json:"hash"`
 	} `json:"merge_commit"`
+	Participants []PullRequestParticipant `json:"participants"`
+}
+
+type PullRequestParticipant struct {
+	User     User   `json:"user"`
+	Role     string `json:"role"`
+	Approved bool   `json:"approved"`
+	State    string `json:"state"`
 }
 
 type PullRequests struct {
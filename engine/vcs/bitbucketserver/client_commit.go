@@ -217,3 +217,7 @@ func (b *bitbucketClient) CommitsBetweenRefs(ctx context.Context, repo, base, he
 	}
 	return commits, nil
 }
+
+func (b *bitbucketClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
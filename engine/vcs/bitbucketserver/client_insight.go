@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -45,3 +46,73 @@ func (b *bitbucketClient) CreateInsightReport(ctx context.Context, repo string,
 	path := fmt.Sprintf("/projects/%s/repos/%s/commits/%s/reports/%s", project, slug, sha, insightKey)
 	return b.do(ctx, "PUT", "insights", path, nil, values, nil, Options{})
 }
+
+// A report key is used in urls and can't be longer than 50 characters
+var insightKeyRegexp = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+const insightAnnotationsLimit = 1000
+
+// CreateAnnotations creates a code insights report with the annotations, annotations are displayed inline in pull requests
+func (b *bitbucketClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	key := insightKeyRegexp.ReplaceAllString(annotations.Key, "-")
+	if len(key) > 50 {
+		key = key[len(key)-50:]
+	}
+
+	r := InsightReport{
+		Title:    annotations.Title,
+		Detail:   annotations.Summary,
+		Result:   "PASS",
+		Reporter: "CDS",
+		Link:     annotations.URL,
+	}
+	if annotations.HasFailure() {
+		r.Result = "FAIL"
+	}
+	values, err := json.Marshal(r)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	path := fmt.Sprintf("/projects/%s/repos/%s/commits/%s/reports/%s", project, slug, sha, key)
+	if err := b.do(ctx, "PUT", "insights", path, nil, values, nil, Options{}); err != nil {
+		return err
+	}
+
+	if len(annotations.Annotations) == 0 {
+		return nil
+	}
+	as := InsightAnnotations{Annotations: make([]InsightAnnotation, 0, len(annotations.Annotations))}
+	for _, a := range annotations.Annotations {
+		if len(as.Annotations) >= insightAnnotationsLimit {
+			break
+		}
+		severity := "LOW"
+		switch a.Level {
+		case sdk.VCSAnnotationLevelWarning:
+			severity = "MEDIUM"
+		case sdk.VCSAnnotationLevelFailure:
+			severity = "HIGH"
+		}
+		message := a.Message
+		if a.Title != "" {
+			message = a.Title + ": " + a.Message
+		}
+		as.Annotations = append(as.Annotations, InsightAnnotation{
+			Path:     a.Path,
+			Line:     a.StartLine,
+			Message:  message,
+			Severity: severity,
+			Link:     annotations.URL,
+		})
+	}
+	values, err = json.Marshal(as)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	return b.do(ctx, "POST", "insights", path+"/annotations", nil, values, nil, Options{})
+}
@@ -160,6 +160,76 @@ func (b *bitbucketClient) PullRequestCreate(ctx context.Context, repo string, pr
 	return b.ToVCSPullRequest(ctx, repo, request)
 }
 
+// PullRequestMergeStatus checks the merge checks and the reviewers of a pull request
+func (b *bitbucketClient) PullRequestMergeStatus(ctx context.Context, repo string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%s", project, slug, id)
+	var pr sdk.BitbucketServerPullRequest
+	if err := b.do(ctx, "GET", "core", path, nil, nil, &pr, Options{DisableCache: true}); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get pullrequest")
+	}
+	var merge PullRequestMergeResponse
+	if err := b.do(ctx, "GET", "core", path+"/merge", nil, nil, &merge, Options{DisableCache: true}); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get pullrequest merge status")
+	}
+
+	var approvals, needsWork int
+	for _, r := range pr.Reviewers {
+		switch {
+		case r.Approved:
+			approvals++
+		case r.Status == "NEEDS_WORK":
+			needsWork++
+		}
+	}
+
+	status := sdk.VCSPullRequestMergeStatus{
+		Mergeable: pr.Open && merge.CanMerge && !merge.Conflicted,
+		Approved:  approvals > 0 && needsWork == 0,
+	}
+	switch {
+	case merge.Conflicted:
+		status.Reason = "pull request has conflicts"
+	case !status.Mergeable && len(merge.Vetoes) > 0:
+		status.Reason = merge.Vetoes[0].SummaryMessage
+	case !status.Mergeable:
+		status.Reason = fmt.Sprintf("pull request state is %s", pr.State)
+	case !status.Approved:
+		status.Reason = "pull request is not approved"
+	}
+	return status, nil
+}
+
+// PullRequestMerge merges a pull request if its head is still the given commit
+func (b *bitbucketClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d", project, slug, req.ID)
+	var pr sdk.BitbucketServerPullRequest
+	if err := b.do(ctx, "GET", "core", path, nil, nil, &pr, Options{DisableCache: true}); err != nil {
+		return sdk.WrapError(err, "unable to get pullrequest")
+	}
+	if pr.FromRef.LatestCommit != req.Commit {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %d head has moved to %s", req.ID, pr.FromRef.LatestCommit)
+	}
+
+	// The version makes the merge fail if the pull request has been updated in the meantime
+	params := url.Values{}
+	params.Set("version", strconv.Itoa(pr.Version))
+	values, _ := json.Marshal(map[string]string{"message": req.Message})
+	if err := b.do(ctx, "POST", "core", path+"/merge", params, values, nil, Options{}); err != nil {
+		return sdk.WrapError(err, "unable to merge pull request %d on %s", req.ID, repo)
+	}
+	return nil
+}
+
 func (b *bitbucketClient) ToVCSPullRequest(ctx context.Context, repo string, pullRequest sdk.BitbucketServerPullRequest) (sdk.VCSPullRequest, error) {
 	pr := sdk.VCSPullRequest{
 		ID:     pullRequest.ID,
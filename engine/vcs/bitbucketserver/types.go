@@ -179,6 +179,17 @@ type PullRequestResponse struct {
 	IsLastPage    bool                             `json:"isLastPage"`
 }
 
+// PullRequestMergeResponse is the merge status of a pull request, vetoes are the merge checks that are not met
+type PullRequestMergeResponse struct {
+	CanMerge   bool   `json:"canMerge"`
+	Conflicted bool   `json:"conflicted"`
+	Outcome    string `json:"outcome"`
+	Vetoes     []struct {
+		SummaryMessage  string `json:"summaryMessage"`
+		DetailedMessage string `json:"detailedMessage"`
+	} `json:"vetoes"`
+}
+
 type UsersPermissionResponse struct {
 	Values        []UserPermission `json:"values"`
 	Size          int              `json:"size"`
@@ -212,6 +223,18 @@ type InsightReportDataLink struct {
 	Href string `json:"href"`
 }
 
+type InsightAnnotations struct {
+	Annotations []InsightAnnotation `json:"annotations"`
+}
+
+type InsightAnnotation struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // One of: LOW, MEDIUM, HIGH
+	Link     string `json:"link,omitempty"`
+}
+
 type ListContentResponse struct {
 	Values        []string `json:"values"`
 	Size          int      `json:"size"`
@@ -102,3 +102,7 @@ func (f *forgejoClient) toVCSCommit(commit *Commit) sdk.VCSCommit {
 
 	return vcsCommit
 }
+
+func (f *forgejoClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -117,3 +117,69 @@ func (f *forgejoClient) PullRequestComment(ctx context.Context, repo string, prR
 func (f *forgejoClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, sdk.WithStack(sdk.ErrNotImplemented)
 }
+
+// PullRequestMergeStatus checks the mergeable state and the reviews of a pull request
+func (f *forgejoClient) PullRequestMergeStatus(ctx context.Context, repo string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	owner, repoName, err := getRepo(repo)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, err
+	}
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pull-request id %q", id)
+	}
+
+	var pr PullRequest
+	if _, err := f.client.get(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repoName, i), &pr); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get forgejo pull-request repo:%v id:%v", repo, id)
+	}
+	var reviews []PullReview
+	if _, err := f.client.get(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews?limit=50", owner, repoName, i), &reviews); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get forgejo pull-request reviews repo:%v id:%v", repo, id)
+	}
+	var approvals, changesRequested int
+	for _, r := range reviews {
+		if r.Dismissed || r.Stale {
+			continue
+		}
+		switch r.State {
+		case ReviewStateApproved:
+			approvals++
+		case ReviewStateRequestChanges:
+			changesRequested++
+		}
+	}
+
+	status := sdk.VCSPullRequestMergeStatus{
+		Mergeable: pr.State == StateOpen && pr.Mergeable && !pr.Draft,
+		Approved:  approvals > 0 && changesRequested == 0,
+	}
+	switch {
+	case pr.Draft:
+		status.Reason = "pull request is a draft"
+	case !status.Mergeable:
+		status.Reason = fmt.Sprintf("pull request is %s and mergeable is %t", pr.State, pr.Mergeable)
+	case !status.Approved:
+		status.Reason = "pull request is not approved"
+	}
+	return status, nil
+}
+
+// PullRequestMerge merges a pull request, Forgejo refuses the merge if the head of the pull request has moved
+func (f *forgejoClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	owner, repoName, err := getRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	opt := MergePullRequestOption{
+		Style:        "merge",
+		Title:        req.Message,
+		HeadCommitID: req.Commit,
+	}
+	apiPath := fmt.Sprintf("/repos/%s/%s/pulls/%d/merge", owner, repoName, req.ID)
+	if _, err := f.client.post(ctx, apiPath, opt, nil); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge pull request %d on %s", req.ID, repo))
+	}
+	return nil
+}
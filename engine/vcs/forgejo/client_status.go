@@ -15,6 +15,59 @@ func (f *forgejoClient) CreateInsightReport(ctx context.Context, repo string, sh
 	return nil
 }
 
+// CreateAnnotations creates a review on the open pull-request of the commit, an annotation is a review comment.
+// Forgejo has no check run API, annotations are ignored if the commit is not the head of an open pull-request.
+func (f *forgejoClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	owner, repoName, err := getRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	prs, err := f.PullRequests(ctx, repo, sdk.VCSPullRequestOptions{State: sdk.VCSPullRequestStateOpen})
+	if err != nil {
+		return err
+	}
+	var prIndex int
+	for _, pr := range prs {
+		if pr.Head.Branch.LatestCommit == sha {
+			prIndex = pr.ID
+			break
+		}
+	}
+	if prIndex == 0 {
+		log.Info(ctx, "forgejo.CreateAnnotations> no open pull-request found for commit %s on %s, annotations are ignored", sha, repo)
+		return nil
+	}
+
+	body := "**" + annotations.Title + "**"
+	if annotations.Summary != "" {
+		body += "\n\n" + annotations.Summary
+	}
+	if annotations.URL != "" {
+		body += fmt.Sprintf("\n\n[Details](%s)", annotations.URL)
+	}
+
+	opt := CreatePullReviewOptions{
+		State:    ReviewStateComment,
+		Body:     body,
+		CommitID: sha,
+		Comments: make([]CreatePullReviewComment, 0, len(annotations.Annotations)),
+	}
+	for _, a := range annotations.Annotations {
+		opt.Comments = append(opt.Comments, CreatePullReviewComment{
+			Path:       a.Path,
+			Body:       a.Markdown(),
+			NewLineNum: int64(a.StartLine),
+		})
+	}
+
+	apiPath := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews", owner, repoName, prIndex)
+	if _, err := f.client.post(ctx, apiPath, opt, nil); err != nil {
+		return sdk.WrapError(err, "unable to create forgejo pull-request review on repo:%v id:%v", repo, prIndex)
+	}
+	return nil
+}
+
 func (f *forgejoClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
 	if buildStatus.Status == "" {
 		log.Debug(ctx, "forgejo.SetStatus> Do not process event for empty status")
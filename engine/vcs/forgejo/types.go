@@ -17,9 +17,21 @@ const (
 // StateType is the state of an issue / PR (open, closed).
 type StateType string
 
+// StateOpen is the state of an open issue / PR
+const StateOpen StateType = "open"
+
 // ReviewStateType is the state of a review.
 type ReviewStateType string
 
+const (
+	// ReviewStateComment is a comment review
+	ReviewStateComment ReviewStateType = "COMMENT"
+	// ReviewStateApproved is an approving review
+	ReviewStateApproved ReviewStateType = "APPROVED"
+	// ReviewStateRequestChanges is a review asking for changes
+	ReviewStateRequestChanges ReviewStateType = "REQUEST_CHANGES"
+)
+
 // --- User ---
 
 // User represents a Forgejo user.
@@ -304,6 +316,15 @@ type CreatePullReviewOptions struct {
 	Comments []CreatePullReviewComment `json:"comments"`
 }
 
+// MergePullRequestOption are options to merge a pull request. The merge is refused if the head of the pull request
+// is not HeadCommitID.
+type MergePullRequestOption struct {
+	Style        string `json:"Do"`
+	Title        string `json:"MergeTitleField"`
+	Message      string `json:"MergeMessageField,omitempty"`
+	HeadCommitID string `json:"head_commit_id,omitempty"`
+}
+
 // CreatePullReviewComment represents a review comment for creation API.
 type CreatePullReviewComment struct {
 	Path       string `json:"path"`
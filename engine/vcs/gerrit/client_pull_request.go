@@ -85,6 +85,16 @@ func (c *gerritClient) PullRequestCreate(_ context.Context, _ string, _ sdk.VCSP
 	return sdk.VCSPullRequest{}, nil
 }
 
+// PullRequestMergeStatus is not implemented, changes are submitted by gerrit itself
+func (c *gerritClient) PullRequestMergeStatus(_ context.Context, _ string, _ string) (sdk.VCSPullRequestMergeStatus, error) {
+	return sdk.VCSPullRequestMergeStatus{}, sdk.NewErrorFrom(sdk.ErrNotImplemented, "merge queue is not supported on gerrit")
+}
+
+// PullRequestMerge is not implemented, changes are submitted by gerrit itself
+func (c *gerritClient) PullRequestMerge(_ context.Context, _ string, _ sdk.VCSPullRequestMergeRequest) error {
+	return sdk.NewErrorFrom(sdk.ErrNotImplemented, "merge queue is not supported on gerrit")
+}
+
 func (c *gerritClient) toVCSPullRequest(change gerrit.ChangeInfo) sdk.VCSPullRequest {
 	pr := sdk.VCSPullRequest{
 		ChangeID: change.ID,
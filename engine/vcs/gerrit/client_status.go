@@ -16,6 +16,10 @@ func (client *gerritClient) CreateInsightReport(ctx context.Context, repo string
 	return nil
 }
 
+func (client *gerritClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
 // SetStatus set build status on Gerrit
 func (client *gerritClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
 	if buildStatus.GerritChange == nil {
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ovh/cds/engine/gitcmd"
+	"github.com/ovh/cds/sdk"
+)
+
+// Branches returns the branches of the repository, read from the mirror refs
+func (c *gitClient) Branches(ctx context.Context, fullname string, filters sdk.VCSBranchesFilter) ([]sdk.VCSBranch, error) {
+	dir, err := c.mirror(ctx, fullname, filters.NoCache)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultBranch, err := c.defaultBranch(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.runGit(ctx, dir, "for-each-ref", "--sort=-committerdate", "--format=%(refname)%00%(objectname)", sdk.GitRefBranchPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]sdk.VCSBranch, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 2 {
+			continue
+		}
+		branches = append(branches, sdk.VCSBranch{
+			ID:           fields[0],
+			DisplayID:    strings.TrimPrefix(fields[0], sdk.GitRefBranchPrefix),
+			LatestCommit: fields[1],
+			Default:      fields[0] == defaultBranch,
+		})
+		if filters.Limit > 0 && int64(len(branches)) >= filters.Limit {
+			break
+		}
+	}
+	return branches, nil
+}
+
+// Branch returns only detail of a branch
+func (c *gitClient) Branch(ctx context.Context, fullname string, filters sdk.VCSBranchFilters) (*sdk.VCSBranch, error) {
+	dir, err := c.mirror(ctx, fullname, filters.NoCache)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultBranch, err := c.defaultBranch(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := defaultBranch
+	if !filters.Default {
+		ref = sdk.GitRefBranchPrefix + strings.TrimPrefix(filters.BranchName, sdk.GitRefBranchPrefix)
+	}
+
+	if err := gitcmd.ValidateRef(ref); err != nil {
+		return nil, err
+	}
+	out, err := c.runGit(ctx, dir, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "git driver: branch %s not found", ref)
+	}
+
+	return &sdk.VCSBranch{
+		ID:           ref,
+		DisplayID:    strings.TrimPrefix(ref, sdk.GitRefBranchPrefix),
+		LatestCommit: strings.TrimSpace(string(out)),
+		Default:      ref == defaultBranch,
+	}, nil
+}
+
+// defaultBranch returns the full ref of the branch pointed by HEAD on the remote
+func (c *gitClient) defaultBranch(ctx context.Context, dir string) (string, error) {
+	out, err := c.runGit(ctx, dir, "symbolic-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,172 @@
+package git
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/engine/gitcmd"
+	"github.com/ovh/cds/sdk"
+)
+
+// Fields are separated by a NUL character and commits by a record separator, the message is the last field
+const commitFormat = "--format=%H%x00%an%x00%ae%x00%at%x00%cn%x00%ce%x00%B%x1e"
+
+func (c *gitClient) Commits(ctx context.Context, fullname, branch, since, until string) ([]sdk.VCSCommit, error) {
+	dir, err := c.mirror(ctx, fullname, false)
+	if err != nil {
+		return nil, err
+	}
+
+	head := sdk.GitRefBranchPrefix + strings.TrimPrefix(branch, sdk.GitRefBranchPrefix)
+	if until != "" {
+		head = until
+	}
+	if err := gitcmd.ValidateRef(head); err != nil {
+		return nil, err
+	}
+	revRange := head
+	if since != "" {
+		if err := gitcmd.ValidateRef(since); err != nil {
+			return nil, err
+		}
+		revRange = since + ".." + head
+	}
+	return c.log(ctx, dir, revRange)
+}
+
+func (c *gitClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
+	dir, err := c.mirrorWithRevision(ctx, fullname, hash)
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	commits, err := c.log(ctx, dir, hash+"^!")
+	if err != nil {
+		return sdk.VCSCommit{}, err
+	}
+	if len(commits) == 0 {
+		return sdk.VCSCommit{}, sdk.NewErrorFrom(sdk.ErrNotFound, "git driver: commit %s not found", hash)
+	}
+	return commits[0], nil
+}
+
+// CommitsBetweenRefs returns the commits reachable from head that are not reachable from base
+func (c *gitClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSCommit, error) {
+	if err := gitcmd.ValidateRef(base); err != nil {
+		return nil, err
+	}
+	dir, err := c.mirrorWithRevision(ctx, fullname, head)
+	if err != nil {
+		return nil, err
+	}
+	return c.log(ctx, dir, base+".."+head)
+}
+
+// DiffBetweenRefs returns the patch of each file changed on head since its merge base with base
+func (c *gitClient) DiffBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSFileDiff, error) {
+	if err := gitcmd.ValidateRef(base); err != nil {
+		return nil, err
+	}
+	dir, err := c.mirrorWithRevision(ctx, fullname, head)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.runGit(ctx, dir, "diff", "--no-color", "--no-ext-diff", "--no-renames", "--end-of-options", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiff(string(out)), nil
+}
+
+// parseDiff splits the output of git diff by file
+func parseDiff(out string) []sdk.VCSFileDiff {
+	files := make([]sdk.VCSFileDiff, 0)
+	for _, section := range strings.Split(out, "\ndiff --git ") {
+		if strings.TrimSpace(section) == "" {
+			continue
+		}
+		f := sdk.VCSFileDiff{Status: "modified"}
+		var patch []string
+		for _, l := range strings.Split(section, "\n") {
+			switch {
+			case len(patch) > 0:
+				patch = append(patch, l)
+			case strings.HasPrefix(l, "new file mode"):
+				f.Status = "added"
+			case strings.HasPrefix(l, "deleted file mode"):
+				f.Status = "removed"
+			case strings.HasPrefix(l, "--- a/") && f.Filename == "":
+				f.Filename = strings.TrimPrefix(l, "--- a/")
+			case strings.HasPrefix(l, "+++ b/"):
+				f.Filename = strings.TrimPrefix(l, "+++ b/")
+			case strings.HasPrefix(l, "@@"):
+				patch = append(patch, l)
+			}
+		}
+		if f.Filename == "" {
+			continue
+		}
+		f.Patch = strings.Join(patch, "\n")
+		files = append(files, f)
+	}
+	return files
+}
+
+// mirrorWithRevision returns the mirror of the repository, fetched again if the revision is not known yet
+func (c *gitClient) mirrorWithRevision(ctx context.Context, fullname, rev string) (string, error) {
+	if err := gitcmd.ValidateRef(rev); err != nil {
+		return "", err
+	}
+	dir, err := c.mirror(ctx, fullname, false)
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.runGit(ctx, dir, "cat-file", "-e", "--end-of-options", rev+"^{commit}"); err == nil {
+		return dir, nil
+	}
+	return c.mirror(ctx, fullname, true)
+}
+
+// log lists the commits of the given revisions, they can't be read as options
+func (c *gitClient) log(ctx context.Context, dir string, revisions ...string) ([]sdk.VCSCommit, error) {
+	out, err := c.runGit(ctx, dir, append([]string{"log", commitFormat, "--end-of-options"}, revisions...)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(string(out)), nil
+}
+
+func parseCommits(out string) []sdk.VCSCommit {
+	commits := make([]sdk.VCSCommit, 0)
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		fields := strings.SplitN(record, "\x00", 7)
+		if len(fields) != 7 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(fields[3], 10, 64)
+		commits = append(commits, sdk.VCSCommit{
+			Hash:      fields[0],
+			Author:    newAuthor(fields[1], fields[2]),
+			Committer: newAuthor(fields[4], fields[5]),
+			Timestamp: ts * 1000,
+			Message:   strings.TrimSpace(fields[6]),
+		})
+	}
+	return commits
+}
+
+// newAuthor returns a VCSAuthor, without user accounts on the server the slug is the local part of the email
+func newAuthor(name, email string) sdk.VCSAuthor {
+	slug := email
+	if i := strings.Index(email, "@"); i > 0 {
+		slug = email[:i]
+	}
+	return sdk.VCSAuthor{
+		Name:        name,
+		DisplayName: name,
+		Email:       email,
+		Slug:        slug,
+		ID:          email,
+	}
+}
@@ -0,0 +1,24 @@
+package git
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *gitClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (c *gitClient) PushEvents(context.Context, string, []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (c *gitClient) CreateEvents(context.Context, string, []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (c *gitClient) DeleteEvents(context.Context, string, []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (c *gitClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -0,0 +1,113 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListContent returns the first level of a directory. A missing directory returns an empty list.
+func (c *gitClient) ListContent(ctx context.Context, fullname string, commit, dir string, _, _ string) ([]sdk.VCSContent, error) {
+	mirrorDir, err := c.mirrorWithRevision(ctx, fullname, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	treePath := strings.Trim(dir, "/")
+	if treePath != "" {
+		treePath += "/"
+	}
+	out, err := c.runGit(ctx, mirrorDir, "ls-tree", "-z", commit, "--", treePath)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]sdk.VCSContent, 0)
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// <mode> SP <type> SP <object> TAB <file>
+		meta, file, found := strings.Cut(entry, "\t")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 {
+			continue
+		}
+		contents = append(contents, sdk.VCSContent{
+			Name:        path.Base(file),
+			IsDirectory: fields[1] == "tree",
+			IsFile:      fields[1] == "blob",
+		})
+	}
+	return contents, nil
+}
+
+// GetContent returns the base64 encoded content of a file, like the API based drivers
+func (c *gitClient) GetContent(ctx context.Context, fullname string, commit, filePath string) (sdk.VCSContent, error) {
+	mirrorDir, err := c.mirrorWithRevision(ctx, fullname, commit)
+	if err != nil {
+		return sdk.VCSContent{}, err
+	}
+
+	object := commit + ":" + strings.TrimPrefix(filePath, "/")
+	out, err := c.runGit(ctx, mirrorDir, "cat-file", "-t", object)
+	if err != nil {
+		return sdk.VCSContent{}, sdk.NewErrorFrom(sdk.ErrNotFound, "git driver: file %s not found on %s", filePath, commit)
+	}
+
+	content := sdk.VCSContent{
+		Name:        path.Base(filePath),
+		IsDirectory: strings.TrimSpace(string(out)) == "tree",
+		IsFile:      strings.TrimSpace(string(out)) == "blob",
+	}
+	if !content.IsFile {
+		return content, nil
+	}
+
+	btes, err := c.runGit(ctx, mirrorDir, "cat-file", "blob", object)
+	if err != nil {
+		return sdk.VCSContent{}, err
+	}
+	content.Content = base64.StdEncoding.EncodeToString(btes)
+	return content, nil
+}
+
+// GetArchive returns an archive of a directory with git archive: tar, tar.gz and zip formats are supported
+func (c *gitClient) GetArchive(ctx context.Context, fullname string, dir string, format string, commit string) (io.Reader, http.Header, error) {
+	var contentType string
+	switch format {
+	case "tar":
+		contentType = "application/x-tar"
+	case "tar.gz", "tgz":
+		format = "tar.gz"
+		contentType = "application/gzip"
+	case "zip":
+		contentType = "application/zip"
+	default:
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "git driver: unsupported archive format %s", format)
+	}
+
+	mirrorDir, err := c.mirrorWithRevision(ctx, fullname, commit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := []string{"archive", "--format=" + format, commit}
+	if d := strings.Trim(dir, "/"); d != "" {
+		args = append(args, "--", d)
+	}
+	btes, err := c.runGit(ctx, mirrorDir, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	return bytes.NewReader(btes), headers, nil
+}
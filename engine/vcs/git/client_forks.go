@@ -0,0 +1,11 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *gitClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	return []sdk.VCSRepo{}, nil
+}
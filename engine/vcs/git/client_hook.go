@@ -0,0 +1,25 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// A plain git server cannot send webhooks, its refs are polled by the hooks service
+
+func (c *gitClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) UpdateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) GetHook(ctx context.Context, repo, url string) (sdk.VCSHook, error) {
+	return sdk.VCSHook{}, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
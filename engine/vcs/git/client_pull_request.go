@@ -0,0 +1,33 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Pull requests are a forge feature, they do not exist on a plain git server
+
+func (c *gitClient) PullRequest(ctx context.Context, repo string, id string) (sdk.VCSPullRequest, error) {
+	return sdk.VCSPullRequest{}, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) PullRequests(ctx context.Context, repo string, opts sdk.VCSPullRequestOptions) ([]sdk.VCSPullRequest, error) {
+	return []sdk.VCSPullRequest{}, nil
+}
+
+func (c *gitClient) PullRequestComment(ctx context.Context, repo string, prRequest sdk.VCSPullRequestCommentRequest) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
+	return sdk.VCSPullRequest{}, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) PullRequestMergeStatus(ctx context.Context, repo string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	return sdk.VCSPullRequestMergeStatus{}, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+func (c *gitClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
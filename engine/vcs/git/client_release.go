@@ -0,0 +1,16 @@
+package git
+
+import (
+	"context"
+	"io"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release is not implemented: a plain git server has no release concept
+func (c *gitClient) Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*sdk.VCSRelease, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+func (c *gitClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, fileLength int) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
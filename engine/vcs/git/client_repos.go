@@ -0,0 +1,27 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Repos returns an empty list: a plain git server does not expose the list of its repositories
+func (c *gitClient) Repos(_ context.Context) ([]sdk.VCSRepo, error) {
+	return []sdk.VCSRepo{}, nil
+}
+
+// RepoByFullname checks that the repository can be cloned and returns its clone urls
+func (c *gitClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	if _, err := c.mirror(ctx, fullname, false); err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	return sdk.VCSRepo{
+		ID:           fullname,
+		Name:         repoName(fullname),
+		Slug:         repoName(fullname),
+		Fullname:     fullname,
+		HTTPCloneURL: c.consumer.httpCloneURL(fullname),
+		SSHCloneURL:  c.consumer.sshCloneURL(fullname),
+	}, nil
+}
@@ -0,0 +1,11 @@
+package git
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *gitClient) SearchPullRequest(ctx context.Context, repoFullName, commit, state string) (*sdk.VCSPullRequest, error) {
+	return nil, sdk.WithStack(sdk.ErrNotFound)
+}
@@ -0,0 +1,29 @@
+package git
+
+import (
+	"context"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// SetStatus is a no-op: a plain git server has no commit status
+func (c *gitClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
+	log.Debug(ctx, "git.SetStatus> ignoring status %s on %s@%s", buildStatus.Status, buildStatus.RepositoryFullname, buildStatus.GitHash)
+	return nil
+}
+
+func (c *gitClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	return []sdk.VCSCommitStatus{}, nil
+}
+
+func (c *gitClient) CreateInsightReport(ctx context.Context, repo string, sha string, insightKey string, vcsReport sdk.VCSInsight) error {
+	// Ignore this call, like statuses
+	return nil
+}
+
+func (c *gitClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	// A plain git server cannot display annotations
+	return nil
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const tagFormat = "--format=%(refname)%00%(objectname)%00%(*objectname)%00%(contents:subject)%00%(taggername)%00%(taggeremail)"
+
+// Tags returns the tags of the repository
+func (c *gitClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	dir, err := c.mirror(ctx, fullname, false)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.runGit(ctx, dir, "for-each-ref", "--sort=-creatordate", tagFormat, sdk.GitRefTagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]sdk.VCSTag, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if t, ok := parseTag(line); ok {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
+
+// Tag returns a single tag
+func (c *gitClient) Tag(ctx context.Context, fullname string, tagName string) (sdk.VCSTag, error) {
+	dir, err := c.mirror(ctx, fullname, false)
+	if err != nil {
+		return sdk.VCSTag{}, err
+	}
+	ref := sdk.GitRefTagPrefix + strings.TrimPrefix(tagName, sdk.GitRefTagPrefix)
+	out, err := c.runGit(ctx, dir, "for-each-ref", tagFormat, ref)
+	if err != nil {
+		return sdk.VCSTag{}, err
+	}
+	t, ok := parseTag(strings.TrimSpace(string(out)))
+	if !ok {
+		return sdk.VCSTag{}, sdk.NewErrorFrom(sdk.ErrNotFound, "git driver: tag %s not found", tagName)
+	}
+	return t, nil
+}
+
+// parseTag reads a line produced with tagFormat. For an annotated tag the commit is the peeled object.
+func parseTag(line string) (sdk.VCSTag, bool) {
+	fields := strings.Split(line, "\x00")
+	if len(fields) != 6 || fields[0] == "" {
+		return sdk.VCSTag{}, false
+	}
+	t := sdk.VCSTag{
+		Tag:  strings.TrimPrefix(fields[0], sdk.GitRefTagPrefix),
+		Sha:  fields[1],
+		Hash: fields[1],
+	}
+	if fields[2] != "" {
+		t.Hash = fields[2]
+		t.Message = fields[3]
+		t.Tagger = newAuthor(fields[4], strings.Trim(fields[5], "<>"))
+	}
+	return t, true
+}
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// gitClient is a wrapper for CDS vcs. interface over a plain git server: there is no forge API,
+// every information is read from a local mirror of the repository
+type gitClient struct {
+	consumer *gitConsumer
+}
+
+// gitConsumer implements vcs.Server and it's used to instantiate a gitClient
+type gitConsumer struct {
+	URL           string `json:"url"` // https://git.mycompany.com/git or ssh://git.mycompany.com/srv/git
+	basedir       string
+	allowFileURL  bool
+	username      string
+	token         string
+	sshUsername   string
+	sshPort       int
+	sshPrivateKey string
+	sshKnownHosts string
+}
+
+// New creates a new plain git Consumer. Repositories on the local filesystem of the vcs service (file:// urls)
+// are only allowed with allowFileURL.
+func New(URL, basedir string, allowFileURL bool, username, token, sshUsername string, sshPort int, sshPrivateKey, sshKnownHosts string) sdk.VCSServer {
+	return &gitConsumer{
+		URL:           strings.TrimSuffix(URL, "/"),
+		basedir:       basedir,
+		allowFileURL:  allowFileURL,
+		username:      username,
+		token:         token,
+		sshUsername:   sshUsername,
+		sshPort:       sshPort,
+		sshPrivateKey: sshPrivateKey,
+		sshKnownHosts: sshKnownHosts,
+	}
+}
+
+// GetAuthorizedClient returns an authorized client
+func (g *gitConsumer) GetAuthorizedClient(_ context.Context, _ sdk.VCSAuth) (sdk.VCSAuthorizedClient, error) {
+	if g.basedir == "" {
+		return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "git driver: missing basedir in vcs service configuration")
+	}
+	return &gitClient{consumer: g}, nil
+}
+
+// httpCloneURL returns the http(s) clone url of a repository, empty if the server is only reachable over ssh
+func (g *gitConsumer) httpCloneURL(fullname string) string {
+	u, err := url.Parse(g.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	u.Path = path.Join(u.Path, fullname)
+	return u.String()
+}
+
+// sshCloneURL returns the ssh clone url of a repository, empty if no ssh user is configured
+func (g *gitConsumer) sshCloneURL(fullname string) string {
+	if g.sshUsername == "" {
+		return ""
+	}
+	u, err := url.Parse(g.URL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	port := g.sshPort
+	if port == 0 {
+		port = 22
+	}
+	// The path of an http url is most of the time a prefix added by the web server, it's only kept for ssh urls
+	repoPath := "/" + fullname
+	if u.Scheme == "ssh" {
+		repoPath = path.Join(u.Path, fullname)
+	}
+	return fmt.Sprintf("ssh://%s@%s:%d%s", g.sshUsername, u.Hostname(), port, repoPath)
+}
+
+// remoteURL returns the url used to fetch the repository: ssh when a private key is available, http otherwise
+func (g *gitConsumer) remoteURL(fullname string) (string, error) {
+	// Repositories reachable on the local filesystem of the vcs service, mostly used for tests
+	if strings.HasPrefix(g.URL, "file://") {
+		if !g.allowFileURL {
+			return "", sdk.NewErrorFrom(sdk.ErrForbidden, "git driver: file urls are not allowed by the vcs service configuration")
+		}
+		return g.URL + "/" + fullname, nil
+	}
+	if u, err := url.Parse(g.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "ssh") {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "git driver: unsupported url %s", g.URL)
+	}
+	if g.sshPrivateKey != "" {
+		if u := g.sshCloneURL(fullname); u != "" {
+			return u, nil
+		}
+	}
+	u := g.httpCloneURL(fullname)
+	if u == "" {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "git driver: unable to compute a clone url for repository %s", fullname)
+	}
+	if g.username != "" && g.token != "" {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return "", sdk.WithStack(err)
+		}
+		parsed.User = url.UserPassword(g.username, g.token)
+		u = parsed.String()
+	}
+	return u, nil
+}
+
+// repoName returns the last part of the fullname, without the .git suffix
+func repoName(fullname string) string {
+	return strings.TrimSuffix(path.Base(fullname), ".git")
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/gitcmd"
+	"github.com/ovh/cds/sdk"
+)
+
+// The mirror is fetched at most once per fetchInterval, the analysis of a single event calls the driver several times
+const fetchInterval = 10 * time.Second
+
+var (
+	mirrorLocks   sync.Map // path -> *sync.Mutex
+	mirrorFetches sync.Map // path -> time.Time
+)
+
+// runGit runs a git command in the given directory and returns its standard output
+func (c *gitClient) runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	var auth *gitcmd.SSHAuth
+	if c.consumer.sshPrivateKey != "" {
+		auth = &gitcmd.SSHAuth{
+			PrivateKey: c.consumer.sshPrivateKey,
+			KnownHosts: c.consumer.sshKnownHosts,
+		}
+	}
+	out, err := gitcmd.Run(ctx, dir, auth, args...)
+	if err != nil {
+		return nil, gitError(gitcmd.Stderr(err), err)
+	}
+	return out, nil
+}
+
+// gitError converts the output of a failing git command into a CDS error
+func gitError(stderr string, err error) error {
+	switch {
+	case strings.Contains(stderr, "not found"),
+		strings.Contains(stderr, "does not appear to be a git repository"),
+		strings.Contains(stderr, "unknown revision"),
+		strings.Contains(stderr, "Not a valid object name"),
+		strings.Contains(stderr, "bad revision"),
+		strings.Contains(stderr, "not a tree object"):
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "git driver: %s", stderr)
+	case strings.Contains(stderr, "Permission denied"),
+		strings.Contains(stderr, "Authentication failed"),
+		strings.Contains(stderr, "could not read Username"),
+		strings.Contains(stderr, "Host key verification failed"):
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "git driver: %s", stderr)
+	}
+	return sdk.WrapError(err, "git driver: %s", stderr)
+}
+
+// mirror returns the path of an up to date mirror of the repository. The mirror is cloned on first use, then fetched.
+func (c *gitClient) mirror(ctx context.Context, fullname string, forceFetch bool) (string, error) {
+	remote, err := c.consumer.remoteURL(fullname)
+	if err != nil {
+		return "", err
+	}
+
+	// The directory name must not depend on credentials
+	id := fmt.Sprintf("%x", sha256.Sum256([]byte(c.consumer.URL+"/"+fullname)))
+	dir := filepath.Join(c.consumer.basedir, "mirrors", id)
+
+	l, _ := mirrorLocks.LoadOrStore(dir, new(sync.Mutex))
+	lock := l.(*sync.Mutex)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(dir), os.FileMode(0700)); err != nil {
+			return "", sdk.WrapError(err, "unable to create directory %q", filepath.Dir(dir))
+		}
+		log.Info(ctx, "git driver: cloning %s into %s", fullname, dir)
+		if _, err := c.runGit(ctx, filepath.Dir(dir), "init", "--bare", "--quiet", dir); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", err
+		}
+		if err := c.fetch(ctx, dir, remote); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", err
+		}
+		mirrorFetches.Store(dir, time.Now())
+		return dir, nil
+	}
+
+	if last, has := mirrorFetches.Load(dir); !forceFetch && has && time.Since(last.(time.Time)) < fetchInterval {
+		return dir, nil
+	}
+
+	if err := c.fetch(ctx, dir, remote); err != nil {
+		return "", err
+	}
+	mirrorFetches.Store(dir, time.Now())
+	return dir, nil
+}
+
+// fetch updates all the refs of the mirror. The remote url is given on the command line, credentials are never
+// written in the mirror configuration.
+func (c *gitClient) fetch(ctx context.Context, dir, remote string) error {
+	if _, err := c.runGit(ctx, dir, "fetch", "--prune", "--quiet", "--", remote, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+		return err
+	}
+	// HEAD of a bare repository is not updated by a fetch, it is read from the remote to find the default branch
+	out, err := c.runGit(ctx, dir, "ls-remote", "--symref", "--", remote, "HEAD")
+	if err != nil {
+		return err
+	}
+	for _, l := range strings.Split(string(out), "\n") {
+		if ref, found := strings.CutPrefix(l, "ref: "); found {
+			ref, _, _ = strings.Cut(ref, "\t")
+			if _, err := c.runGit(ctx, dir, "symbolic-ref", "HEAD", ref); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
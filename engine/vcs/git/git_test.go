@@ -0,0 +1,157 @@
+package git
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// newTestRepository creates a bare repository team/my-repo.git with a main branch, a feature branch and an annotated tag
+func newTestRepository(t *testing.T) (string, map[string]string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not found")
+	}
+	root := t.TempDir()
+	work := filepath.Join(root, "work")
+	bare := filepath.Join(root, "server", "team", "my-repo.git")
+
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=John Doe", "GIT_AUTHOR_EMAIL=john.doe@example.com", "GIT_AUTHOR_DATE=1708330768 +0000",
+			"GIT_COMMITTER_NAME=John Doe", "GIT_COMMITTER_EMAIL=john.doe@example.com", "GIT_COMMITTER_DATE=1708330768 +0000")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(work, ".cds"), os.FileMode(0755)))
+	git(work, "init", "--quiet", "--initial-branch=main")
+	require.NoError(t, os.WriteFile(filepath.Join(work, ".cds", "workflow.yml"), []byte("name: my-workflow\n"), os.FileMode(0644)))
+	git(work, "add", ".")
+	git(work, "commit", "--quiet", "-m", "Add workflow")
+	git(work, "tag", "-a", "v1.0", "-m", "First release")
+	git(work, "checkout", "--quiet", "-b", "feat/login")
+	require.NoError(t, os.WriteFile(filepath.Join(work, "README.md"), []byte("# my-repo\n"), os.FileMode(0644)))
+	git(work, "add", ".")
+	git(work, "commit", "--quiet", "-m", "Add readme")
+
+	commits := map[string]string{
+		"main":       strings.TrimSpace(git(work, "rev-parse", "main")),
+		"feat/login": strings.TrimSpace(git(work, "rev-parse", "feat/login")),
+		"v1.0":       strings.TrimSpace(git(work, "rev-parse", "v1.0")),
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(bare), os.FileMode(0755)))
+	git(root, "clone", "--quiet", "--bare", work, bare)
+	git(bare, "symbolic-ref", "HEAD", "refs/heads/main")
+	return "file://" + filepath.Join(root, "server"), commits
+}
+
+func newTestClient(t *testing.T) (sdk.VCSAuthorizedClient, map[string]string) {
+	url, commits := newTestRepository(t)
+	consumer := New(url, t.TempDir(), true, "", "", "", 0, "", "")
+	client, err := consumer.GetAuthorizedClient(context.TODO(), sdk.VCSAuth{})
+	require.NoError(t, err)
+	return client, commits
+}
+
+func TestBranches(t *testing.T) {
+	client, commits := newTestClient(t)
+	branches, err := client.Branches(context.TODO(), "team/my-repo.git", sdk.VCSBranchesFilter{})
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+
+	b, err := client.Branch(context.TODO(), "team/my-repo.git", sdk.VCSBranchFilters{Default: true})
+	require.NoError(t, err)
+	require.Equal(t, "refs/heads/main", b.ID)
+	require.Equal(t, commits["main"], b.LatestCommit)
+
+	b, err = client.Branch(context.TODO(), "team/my-repo.git", sdk.VCSBranchFilters{BranchName: "feat/login"})
+	require.NoError(t, err)
+	require.Equal(t, commits["feat/login"], b.LatestCommit)
+	require.False(t, b.Default)
+
+	_, err = client.Branch(context.TODO(), "team/my-repo.git", sdk.VCSBranchFilters{BranchName: "unknown"})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
+
+func TestTag(t *testing.T) {
+	client, commits := newTestClient(t)
+	tag, err := client.Tag(context.TODO(), "team/my-repo.git", "v1.0")
+	require.NoError(t, err)
+	require.Equal(t, commits["main"], tag.Hash)
+	require.Equal(t, commits["v1.0"], tag.Sha)
+	require.Equal(t, "First release", tag.Message)
+}
+
+func TestCommit(t *testing.T) {
+	client, commits := newTestClient(t)
+	c, err := client.Commit(context.TODO(), "team/my-repo.git", commits["feat/login"])
+	require.NoError(t, err)
+	require.Equal(t, "Add readme", c.Message)
+	require.Equal(t, "john.doe", c.Committer.Slug)
+	require.Equal(t, int64(1708330768000), c.Timestamp)
+
+	between, err := client.CommitsBetweenRefs(context.TODO(), "team/my-repo.git", "refs/heads/main", "refs/heads/feat/login")
+	require.NoError(t, err)
+	require.Len(t, between, 1)
+	require.Equal(t, commits["feat/login"], between[0].Hash)
+
+	diff, err := client.DiffBetweenRefs(context.TODO(), "team/my-repo.git", "refs/heads/main", "refs/heads/feat/login")
+	require.NoError(t, err)
+	require.Len(t, diff, 1)
+	require.Equal(t, "README.md", diff[0].Filename)
+	require.Equal(t, "added", diff[0].Status)
+	require.Equal(t, []int{1}, diff[0].AddedLines())
+
+	_, err = client.CommitsBetweenRefs(context.TODO(), "team/my-repo.git", "--output=/tmp/file", "refs/heads/feat/login")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+	_, err = client.Commits(context.TODO(), "team/my-repo.git", "main", "", "--all")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+}
+
+func TestFileURLNotAllowed(t *testing.T) {
+	url, _ := newTestRepository(t)
+	client, err := New(url, t.TempDir(), false, "", "", "", 0, "", "").GetAuthorizedClient(context.TODO(), sdk.VCSAuth{})
+	require.NoError(t, err)
+	_, err = client.Branches(context.TODO(), "team/my-repo.git", sdk.VCSBranchesFilter{})
+	require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+}
+
+func TestListAndGetContent(t *testing.T) {
+	client, commits := newTestClient(t)
+	contents, err := client.ListContent(context.TODO(), "team/my-repo.git", commits["main"], ".cds", "0", "100")
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	require.Equal(t, "workflow.yml", contents[0].Name)
+	require.True(t, contents[0].IsFile)
+
+	content, err := client.GetContent(context.TODO(), "team/my-repo.git", commits["main"], ".cds/workflow.yml")
+	require.NoError(t, err)
+	btes, err := base64.StdEncoding.DecodeString(content.Content)
+	require.NoError(t, err)
+	require.Equal(t, "name: my-workflow\n", string(btes))
+
+	_, err = client.GetContent(context.TODO(), "team/my-repo.git", commits["main"], "README.md")
+	require.True(t, sdk.ErrorIs(err, sdk.ErrNotFound))
+}
+
+func TestSSHCloneURL(t *testing.T) {
+	g := New("https://git.mycompany.com/git", "/tmp", false, "", "", "cds", 2222, "", "").(*gitConsumer)
+	require.Equal(t, "ssh://cds@git.mycompany.com:2222/team/my-repo.git", g.sshCloneURL("team/my-repo.git"))
+	require.Equal(t, "https://git.mycompany.com/git/team/my-repo.git", g.httpCloneURL("team/my-repo.git"))
+
+	g = New("ssh://git.mycompany.com/srv/git", "/tmp", false, "", "", "cds", 0, "", "").(*gitConsumer)
+	require.Equal(t, "ssh://cds@git.mycompany.com:22/srv/git/team/my-repo.git", g.sshCloneURL("team/my-repo.git"))
+	require.Empty(t, g.httpCloneURL("team/my-repo.git"))
+}
@@ -100,3 +100,7 @@ func (g *giteaClient) toVCSCommit(commit *gitea.Commit) sdk.VCSCommit {
 	}
 	return vcsCommit
 }
+
+func (g *giteaClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
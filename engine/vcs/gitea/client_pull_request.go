@@ -90,3 +90,76 @@ func (g *giteaClient) PullRequestComment(ctx context.Context, repo string, prReq
 func (g *giteaClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, sdk.WithStack(sdk.ErrNotImplemented)
 }
+
+// PullRequestMergeStatus checks the mergeable state and the reviews of a pull request
+func (g *giteaClient) PullRequestMergeStatus(ctx context.Context, repo string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	t := strings.Split(repo, "/")
+	if len(t) != 2 {
+		return sdk.VCSPullRequestMergeStatus{}, fmt.Errorf("invalid repo gitea: %s", repo)
+	}
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pull-request id %q", id)
+	}
+
+	pr, _, err := g.client.GetPullRequest(t[0], t[1], i)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get gitea pull-request repo:%v id:%v", repo, id)
+	}
+	reviews, _, err := g.client.ListPullReviews(t[0], t[1], i, gitea.ListPullReviewsOptions{})
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get gitea pull-request reviews repo:%v id:%v", repo, id)
+	}
+	var approvals, changesRequested int
+	for _, r := range reviews {
+		if r.Dismissed || r.Stale {
+			continue
+		}
+		switch r.State {
+		case gitea.ReviewStateApproved:
+			approvals++
+		case gitea.ReviewStateRequestChanges:
+			changesRequested++
+		}
+	}
+
+	status := sdk.VCSPullRequestMergeStatus{
+		Mergeable: pr.State == gitea.StateOpen && pr.Mergeable,
+		Approved:  approvals > 0 && changesRequested == 0,
+	}
+	switch {
+	case !status.Mergeable:
+		status.Reason = fmt.Sprintf("pull request is %s and mergeable is %t", pr.State, pr.Mergeable)
+	case !status.Approved:
+		status.Reason = "pull request is not approved"
+	}
+	return status, nil
+}
+
+// PullRequestMerge merges a pull request if its head is still the given commit
+func (g *giteaClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	t := strings.Split(repo, "/")
+	if len(t) != 2 {
+		return fmt.Errorf("invalid repo gitea: %s", repo)
+	}
+
+	pr, _, err := g.client.GetPullRequest(t[0], t[1], int64(req.ID))
+	if err != nil {
+		return sdk.WrapError(err, "unable to get gitea pull-request repo:%v id:%v", repo, req.ID)
+	}
+	if pr.Head == nil || pr.Head.Sha != req.Commit {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %d head has moved", req.ID)
+	}
+
+	merged, _, err := g.client.MergePullRequest(t[0], t[1], int64(req.ID), gitea.MergePullRequestOption{
+		Style: gitea.MergeStyleMerge,
+		Title: req.Message,
+	})
+	if err != nil {
+		return sdk.WrapError(err, "unable to merge gitea pull-request repo:%v id:%v", repo, req.ID)
+	}
+	if !merged {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %d on %s has not been merged", req.ID, repo)
+	}
+	return nil
+}
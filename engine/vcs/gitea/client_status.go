@@ -16,6 +16,60 @@ func (client *giteaClient) CreateInsightReport(ctx context.Context, repo string,
 	return nil
 }
 
+// CreateAnnotations creates a review on the open pull-request of the commit, an annotation is a review comment.
+// Gitea has no check run API, annotations are ignored if the commit is not the head of an open pull-request.
+func (client *giteaClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	t := strings.Split(repo, "/")
+	if len(t) != 2 {
+		return fmt.Errorf("invalid repo gitea: %s", repo)
+	}
+
+	prs, err := client.PullRequests(ctx, repo, sdk.VCSPullRequestOptions{State: sdk.VCSPullRequestStateOpen})
+	if err != nil {
+		return err
+	}
+	var prIndex int64
+	for _, pr := range prs {
+		if pr.Head.Branch.LatestCommit == sha {
+			prIndex = int64(pr.ID)
+			break
+		}
+	}
+	if prIndex == 0 {
+		log.Info(ctx, "gitea.CreateAnnotations> no open pull-request found for commit %s on %s, annotations are ignored", sha, repo)
+		return nil
+	}
+
+	opt := gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		Body:     annotationsReviewBody(annotations),
+		CommitID: sha,
+		Comments: make([]gitea.CreatePullReviewComment, 0, len(annotations.Annotations)),
+	}
+	for _, a := range annotations.Annotations {
+		opt.Comments = append(opt.Comments, gitea.CreatePullReviewComment{
+			Path:       a.Path,
+			Body:       a.Markdown(),
+			NewLineNum: int64(a.StartLine),
+		})
+	}
+	if _, _, err := client.client.CreatePullReview(t[0], t[1], prIndex, opt); err != nil {
+		return sdk.WrapError(err, "unable to create gitea pull-request review on repo:%v id:%v", repo, prIndex)
+	}
+	return nil
+}
+
+func annotationsReviewBody(annotations sdk.VCSAnnotations) string {
+	body := "**" + annotations.Title + "**"
+	if annotations.Summary != "" {
+		body += "\n\n" + annotations.Summary
+	}
+	if annotations.URL != "" {
+		body += fmt.Sprintf("\n\n[Details](%s)", annotations.URL)
+	}
+	return body
+}
+
 func (client *giteaClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
 
 	// POST /repos/{owner}/{repo}/statuses/{sha}
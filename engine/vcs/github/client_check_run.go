@@ -0,0 +1,118 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// GitHub accepts at most 50 annotations per check run request
+const checkRunAnnotationsLimit = 50
+
+// CreateAnnotations creates a completed check run with the annotations, the following annotations are added by updating the check run
+// https://docs.github.com/en/rest/checks/runs#create-a-check-run
+func (g *githubClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	summary := annotations.Summary
+	if summary == "" {
+		summary = fmt.Sprintf("%d annotation(s)", len(annotations.Annotations))
+	}
+	conclusion := "neutral"
+	if annotations.HasFailure() {
+		conclusion = "failure"
+	} else if len(annotations.Annotations) == 0 {
+		conclusion = "success"
+	}
+
+	checkRunAnnotations := make([]CheckRunAnnotation, 0, len(annotations.Annotations))
+	for _, a := range annotations.Annotations {
+		checkRunAnnotations = append(checkRunAnnotations, CheckRunAnnotation{
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: string(a.Level),
+			Title:           a.Title,
+			Message:         a.Message,
+		})
+	}
+
+	var checkRun CheckRun
+	for i := 0; i == 0 || i < len(checkRunAnnotations); i += checkRunAnnotationsLimit {
+		end := i + checkRunAnnotationsLimit
+		if end > len(checkRunAnnotations) {
+			end = len(checkRunAnnotations)
+		}
+		output := CheckRunOutput{
+			Title:       annotations.Title,
+			Summary:     summary,
+			Annotations: checkRunAnnotations[i:end],
+		}
+
+		if i == 0 {
+			req := CheckRun{
+				Name:       annotations.Key,
+				HeadSha:    sha,
+				Status:     "completed",
+				Conclusion: conclusion,
+				DetailsURL: annotations.URL,
+				Output:     output,
+			}
+			if err := g.doCheckRunRequest(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/check-runs", repo), req, &checkRun); err != nil {
+				return err
+			}
+			continue
+		}
+
+		req := CheckRun{Output: output}
+		if err := g.doCheckRunRequest(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/check-runs/%d", repo, checkRun.ID), req, nil); err != nil {
+			return err
+		}
+	}
+
+	log.Debug(ctx, "CreateAnnotations> check run %d created on %s with %d annotation(s)", checkRun.ID, repo, len(checkRunAnnotations))
+	return nil
+}
+
+func (g *githubClient) doCheckRunRequest(ctx context.Context, method, path string, in CheckRun, out *CheckRun) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal github check run")
+	}
+
+	var res *http.Response
+	if method == http.MethodPost {
+		res, err = g.post(ctx, path, "application/json", bytes.NewBuffer(b), nil, nil)
+	} else {
+		res, err = g.patch(ctx, path, "application/json", bytes.NewBuffer(b), nil)
+	}
+	if err != nil {
+		return sdk.WrapError(err, "unable to send check run")
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+
+	// Check runs can only be created with a GitHub App token
+	if res.StatusCode == http.StatusForbidden {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "unable to create check run on github: %s", errorAPI(body))
+	}
+	if res.StatusCode >= 400 {
+		return sdk.WithStack(fmt.Errorf("unable to create check run on github. Status code : %d - Body: %s", res.StatusCode, body))
+	}
+
+	if out != nil {
+		if err := sdk.JSONUnmarshal(body, out); err != nil {
+			return sdk.WrapError(err, "unable to unmarshal body")
+		}
+	}
+	return nil
+}
@@ -338,3 +338,45 @@ func (g *githubClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return commits, nil
 }
+
+// DiffBetweenRefs returns the patch of each file changed between base and head
+func (g *githubClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	var files []sdk.VCSFileDiff
+	url := fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head)
+	status, body, _, err := g.get(ctx, url)
+	if err != nil {
+		log.Warn(ctx, "githubClient.DiffBetweenRefs> Error %s", err)
+		return files, err
+	}
+	if status >= 400 {
+		return files, sdk.NewError(sdk.ErrRepoNotFound, errorAPI(body))
+	}
+
+	k := cache.Key("vcs", "github", "filediff", sdk.Hash512(g.OAuthToken+g.username), url)
+	//Github may return 304 status because we are using conditional request with ETag based headers
+	if status == http.StatusNotModified {
+		if _, err := g.Cache.Get(k, &files); err != nil {
+			log.Error(ctx, "cannot get from cache %s: %v", k, err)
+		}
+		return files, nil
+	}
+
+	var diff DiffCommits
+	if err := sdk.JSONUnmarshal(body, &diff); err != nil {
+		log.Warn(ctx, "githubClient.DiffBetweenRefs> Unable to parse github compare: %s", err)
+		return files, err
+	}
+	files = make([]sdk.VCSFileDiff, len(diff.Files))
+	for i, f := range diff.Files {
+		files[i] = sdk.VCSFileDiff{
+			Filename: f.Filename,
+			Status:   f.Status,
+			Patch:    f.Patch,
+		}
+	}
+	//Put the body on cache for one hour and one minute
+	if err := g.Cache.SetWithTTL(k, &files, 61*60); err != nil {
+		log.Error(ctx, "cannot SetWithTTL: %s: %v", k, err)
+	}
+	return files, nil
+}
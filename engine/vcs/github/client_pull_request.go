@@ -187,6 +187,88 @@ func (g *githubClient) PullRequestComment(ctx context.Context, repo string, prRe
 	return nil
 }
 
+// PullRequestMergeStatus checks the mergeable state and the reviews of a pull request. The mergeable state
+// includes the branch protection rules, like required reviews and status checks.
+func (g *githubClient) PullRequestMergeStatus(ctx context.Context, fullname string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	// Github computes the mergeable state in background, the pull request must not be read from the cache
+	status, body, _, err := g.get(ctx, fmt.Sprintf("/repos/%s/pulls/%s", fullname, id), withoutETag)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, err
+	}
+	if status >= 400 {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewError(sdk.ErrNotFound, errorAPI(body))
+	}
+	var pr PullRequest
+	if err := sdk.JSONUnmarshal(body, &pr); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WithStack(err)
+	}
+
+	status, body, _, err = g.get(ctx, fmt.Sprintf("/repos/%s/pulls/%s/reviews?per_page=100", fullname, id), withoutETag)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, err
+	}
+	if status >= 400 {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+	}
+	var reviews []PullRequestReview
+	if err := sdk.JSONUnmarshal(body, &reviews); err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WithStack(err)
+	}
+	// Only the latest review of each user counts, reviews are sorted chronologically
+	lastStates := make(map[string]string)
+	for _, r := range reviews {
+		if r.State == "APPROVED" || r.State == "CHANGES_REQUESTED" || r.State == "DISMISSED" {
+			lastStates[r.User.Login] = r.State
+		}
+	}
+	var approvals, changesRequested int
+	for _, state := range lastStates {
+		switch state {
+		case "APPROVED":
+			approvals++
+		case "CHANGES_REQUESTED":
+			changesRequested++
+		}
+	}
+
+	res := sdk.VCSPullRequestMergeStatus{
+		Mergeable: pr.State == "open" && pr.Mergeable && (pr.MergeableState == "clean" || pr.MergeableState == "unstable" || pr.MergeableState == "has_hooks"),
+		Approved:  approvals > 0 && changesRequested == 0,
+	}
+	switch {
+	case !res.Mergeable:
+		res.Reason = fmt.Sprintf("pull request mergeable state is %s", pr.MergeableState)
+	case !res.Approved:
+		res.Reason = "pull request is not approved"
+	}
+	return res, nil
+}
+
+// PullRequestMerge merges a pull request, Github refuses the merge if the head of the pull request has moved
+func (g *githubClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	path := fmt.Sprintf("/repos/%s/pulls/%d/merge", repo, req.ID)
+	payload := map[string]string{
+		"sha":          req.Commit,
+		"commit_title": req.Message,
+		"merge_method": "merge",
+	}
+	values, _ := json.Marshal(payload)
+	res, err := g.put(ctx, path, "application/json", bytes.NewReader(values), &postOptions{skipDefaultBaseURL: false, asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to merge pull request %d on %s", req.ID, repo)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+	if res.StatusCode != http.StatusOK {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge pull request %d on %s: %s", req.ID, repo, errorAPI(body))
+	}
+	return nil
+}
+
 func (g *githubClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	path := fmt.Sprintf("/repos/%s/pulls", repo)
 	payload := map[string]string{
@@ -161,6 +161,36 @@ func (c *githubClient) patch(ctx context.Context, path string, bodyType string,
 	return httpClient.Do(req)
 }
 
+func (c *githubClient) put(ctx context.Context, path string, bodyType string, body io.Reader, opts *postOptions) (*http.Response, error) {
+	if opts == nil {
+		opts = new(postOptions)
+	}
+	if !opts.skipDefaultBaseURL && !strings.HasPrefix(path, c.GitHubAPIURL) {
+		path = c.GitHubAPIURL + path
+	}
+
+	req, err := http.NewRequest(http.MethodPut, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if bodyType != "" {
+		req.Header.Set("Content-Type", bodyType)
+	}
+	req.Header.Set("User-Agent", "CDS-gh_client_id="+c.ClientID)
+	req.Header.Add("Accept", "application/json")
+
+	if err := c.setAuth(ctx, req, opts); err != nil {
+		return nil, err
+	}
+
+	res, err := httpClient.Do(req)
+	if res != nil {
+		c.manageRateLimit(ctx, http.MethodPut, path, res.Header, res.StatusCode)
+	}
+	return res, err
+}
+
 func (c *githubClient) setAuth(ctx context.Context, req *http.Request, opts *postOptions) error {
 	if opts != nil && opts.asUser && c.username != "" && c.token != "" {
 		req.SetBasicAuth(c.username, c.token)
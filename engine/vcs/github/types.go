@@ -412,6 +412,34 @@ type CreateStatus struct {
 	Context     string `json:"context"`
 }
 
+// CheckRun represents the check run API payload
+type CheckRun struct {
+	ID         int64          `json:"id,omitempty"`
+	Name       string         `json:"name,omitempty"`
+	HeadSha    string         `json:"head_sha,omitempty"`
+	Status     string         `json:"status,omitempty"`
+	Conclusion string         `json:"conclusion,omitempty"`
+	DetailsURL string         `json:"details_url,omitempty"`
+	Output     CheckRunOutput `json:"output"`
+}
+
+// CheckRunOutput represents the output of a check run
+type CheckRunOutput struct {
+	Title       string               `json:"title"`
+	Summary     string               `json:"summary"`
+	Annotations []CheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+// CheckRunAnnotation represents an annotation of a check run
+type CheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
 // Status represents Create a Status from API
 type Status struct {
 	CreatedAt   time.Time `json:"created_at"`
@@ -517,6 +545,13 @@ type PullRequest struct {
 	MergedBy            *User     `json:"merged_by"`
 }
 
+// PullRequestReview represents a review of a pull request
+type PullRequestReview struct {
+	ID    int    `json:"id"`
+	User  User   `json:"user"`
+	State string `json:"state"`
+}
+
 // ReleaseRequest Request sent to Github to create a release
 type ReleaseRequest struct {
 	TagName string `json:"tag_name"`
@@ -127,3 +127,38 @@ func (c *gitlabClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return vcscommits, nil
 }
+
+// DiffBetweenRefs returns the patch of each file changed between base and head
+func (c *gitlabClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	opt := &gitlab.CompareOptions{
+		From: &base,
+		To:   &head,
+	}
+
+	compare, _, err := c.client.Repositories.Compare(repo, opt)
+	if err != nil {
+		return nil, err
+	}
+	if compare == nil {
+		return nil, nil
+	}
+
+	files := make([]sdk.VCSFileDiff, 0, len(compare.Diffs))
+	for _, d := range compare.Diffs {
+		status := "modified"
+		switch {
+		case d.NewFile:
+			status = "added"
+		case d.DeletedFile:
+			status = "removed"
+		case d.RenamedFile:
+			status = "renamed"
+		}
+		files = append(files, sdk.VCSFileDiff{
+			Filename: d.NewPath,
+			Status:   status,
+			Patch:    d.Diff,
+		})
+	}
+	return files, nil
+}
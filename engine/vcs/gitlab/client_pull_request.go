@@ -80,6 +80,49 @@ func (c *gitlabClient) PullRequestCreate(ctx context.Context, repo string, pr sd
 	return toSDKPullRequest(repo, *mr), nil
 }
 
+// PullRequestMergeStatus checks the merge status and the approvals of a merge request
+func (c *gitlabClient) PullRequestMergeStatus(ctx context.Context, repo string, id string) (sdk.VCSPullRequestMergeStatus, error) {
+	gitlabPRID, err := strconv.Atoi(id)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(sdk.ErrWrongRequest, "invalid merge request identifier: %s", id)
+	}
+	mr, _, err := c.client.MergeRequests.GetMergeRequest(repo, gitlabPRID, nil)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound,
+			"cannot found a merge request for repo %s with id %d", repo, gitlabPRID))
+	}
+	approvals, _, err := c.client.MergeRequests.GetMergeRequestApprovals(repo, gitlabPRID)
+	if err != nil {
+		return sdk.VCSPullRequestMergeStatus{}, sdk.WrapError(err, "unable to get merge request approvals - repo:%s id:%d", repo, gitlabPRID)
+	}
+
+	status := sdk.VCSPullRequestMergeStatus{
+		Mergeable: mr.State == "opened" && !mr.WorkInProgress && mr.MergeStatus == "can_be_merged",
+		Approved:  approvals.ApprovalsLeft == 0 && (approvals.ApprovalsRequired > 0 || len(approvals.ApprovedBy) > 0),
+	}
+	switch {
+	case mr.WorkInProgress:
+		status.Reason = "merge request is a draft"
+	case !status.Mergeable:
+		status.Reason = fmt.Sprintf("merge request status is %s", mr.MergeStatus)
+	case !status.Approved:
+		status.Reason = fmt.Sprintf("merge request needs %d more approval(s)", approvals.ApprovalsLeft)
+	}
+	return status, nil
+}
+
+// PullRequestMerge accepts a merge request, Gitlab refuses the merge if the source branch has moved
+func (c *gitlabClient) PullRequestMerge(ctx context.Context, repo string, req sdk.VCSPullRequestMergeRequest) error {
+	opt := &gitlab.AcceptMergeRequestOptions{
+		MergeCommitMessage: &req.Message,
+		SHA:                &req.Commit,
+	}
+	if _, _, err := c.client.MergeRequests.AcceptMergeRequest(repo, req.ID, opt); err != nil {
+		return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to merge merge request %d on %s: %v", req.ID, repo, err))
+	}
+	return nil
+}
+
 func toSDKPullRequest(repo string, mr gitlab.MergeRequest) sdk.VCSPullRequest {
 	pr := sdk.VCSPullRequest{
 		ID: mr.IID,
@@ -2,6 +2,7 @@ package gitlab
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/rockbears/log"
@@ -49,6 +50,107 @@ func (c *gitlabClient) CreateInsightReport(ctx context.Context, repo string, sha
 	return nil
 }
 
+// Maximum number of line comments created for a set of annotations
+const annotationsCommentsLimit = 50
+
+// annotationMarker is a hidden line added to the comment of an annotation, it is used to find the comment on the next runs
+func annotationMarker(key, path string, line int) string {
+	return fmt.Sprintf("<!-- cds-annotation:%s:%s:%d -->", key, path, line)
+}
+
+// annotationNote is a comment posted on a commit for an annotation
+type annotationNote struct {
+	discussionID string
+	noteID       int
+	body         string
+}
+
+// CreateAnnotations adds a line comment on the commit for each annotation, they are displayed inline in merge requests.
+// Code quality reports can only be uploaded as artifacts of a Gitlab CI job. The comments of a previous run with the
+// same key are updated, and removed when their annotation is gone.
+func (c *gitlabClient) CreateAnnotations(ctx context.Context, repo string, sha string, annotations sdk.VCSAnnotations) error {
+	previous, err := c.annotationNotes(repo, sha, annotations.Key)
+	if err != nil {
+		return err
+	}
+
+	lineType := "new"
+	for i, a := range annotations.Annotations {
+		if i >= annotationsCommentsLimit {
+			log.Warn(ctx, "gitlabClient.CreateAnnotations> %d annotation(s) ignored on %s for commit %s", len(annotations.Annotations)-i, repo, sha)
+			break
+		}
+
+		marker := annotationMarker(annotations.Key, a.Path, a.StartLine)
+		note := a.Markdown()
+		if annotations.URL != "" {
+			note += fmt.Sprintf("\n\n[Details](%s)", annotations.URL)
+		}
+		note += "\n\n" + marker
+
+		if notes := previous[marker]; len(notes) > 0 {
+			p := notes[0]
+			previous[marker] = notes[1:]
+			if p.body == note {
+				continue
+			}
+			if _, _, err := c.client.Discussions.UpdateCommitDiscussionNote(repo, sha, p.discussionID, p.noteID, &gitlab.UpdateCommitDiscussionNoteOptions{Body: &note}); err != nil {
+				return sdk.WrapError(err, "unable to update commit comment - repo:%s hash:%s", repo, sha)
+			}
+			continue
+		}
+
+		path := a.Path
+		line := a.StartLine
+		opt := &gitlab.PostCommitCommentOptions{
+			Note:     &note,
+			Path:     &path,
+			Line:     &line,
+			LineType: &lineType,
+		}
+		if _, _, err := c.client.Commits.PostCommitComment(repo, sha, opt); err != nil {
+			return sdk.WrapError(err, "unable to post commit comment - repo:%s hash:%s", repo, sha)
+		}
+	}
+
+	// Annotations fixed since the previous run are removed
+	for _, notes := range previous {
+		for _, p := range notes {
+			if _, err := c.client.Discussions.DeleteCommitDiscussionNote(repo, sha, p.discussionID, p.noteID); err != nil {
+				return sdk.WrapError(err, "unable to delete commit comment - repo:%s hash:%s", repo, sha)
+			}
+		}
+	}
+	return nil
+}
+
+// annotationNotes returns the comments created for the annotations with the given key on a commit, by marker
+func (c *gitlabClient) annotationNotes(repo, sha, key string) (map[string][]annotationNote, error) {
+	prefix := fmt.Sprintf("<!-- cds-annotation:%s:", key)
+	notes := make(map[string][]annotationNote)
+	opt := &gitlab.ListCommitDiscussionsOptions{Page: 1, PerPage: 100}
+	for {
+		discussions, resp, err := c.client.Discussions.ListCommitDiscussions(repo, sha, opt)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to list commit comments - repo:%s hash:%s", repo, sha)
+		}
+		for _, d := range discussions {
+			for _, n := range d.Notes {
+				i := strings.LastIndex(n.Body, "\n")
+				marker := n.Body[i+1:]
+				if !strings.HasPrefix(marker, prefix) {
+					continue
+				}
+				notes[marker] = append(notes[marker], annotationNote{discussionID: d.ID, noteID: n.ID, body: n.Body})
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return notes, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
 // SetStatus set build status on Gitlab
 func (c *gitlabClient) SetStatus(ctx context.Context, buildStatus sdk.VCSBuildStatus) error {
 	if c.disableStatus {
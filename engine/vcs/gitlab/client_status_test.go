@@ -0,0 +1,67 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestCreateAnnotationsUpdatesPreviousComments(t *testing.T) {
+	var mutex sync.Mutex
+	var posted, updated, deleted []string
+	previousBody := "old\n\n" + annotationMarker("lint", "main.go", 12)
+
+	// The project path is escaped in the url, the handler works on the decoded path
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/team/repo/repository/commits/sha1/discussions":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "d1", "notes": []map[string]interface{}{{"id": 1, "body": previousBody}}},
+				{"id": "d2", "notes": []map[string]interface{}{{"id": 2, "body": "fixed\n\n" + annotationMarker("lint", "old.go", 1)}}},
+				{"id": "d3", "notes": []map[string]interface{}{{"id": 3, "body": "a comment from a user"}}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects/team/repo/repository/commits/sha1/comments":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			posted = append(posted, body["path"].(string))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+		case r.Method == http.MethodPut:
+			updated = append(updated, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client := gitlab.NewClient(srv.Client(), "token")
+	require.NoError(t, client.SetBaseURL(srv.URL+"/api/v4"))
+	c := &gitlabClient{client: client}
+
+	err := c.CreateAnnotations(context.TODO(), "team/repo", "sha1", sdk.VCSAnnotations{
+		Key:   "lint",
+		Title: "Lint",
+		Annotations: []sdk.VCSAnnotation{
+			{Path: "main.go", StartLine: 12, EndLine: 12, Level: sdk.VCSAnnotationLevelWarning, Message: "unused variable"},
+			{Path: "new.go", StartLine: 3, EndLine: 3, Level: sdk.VCSAnnotationLevelFailure, Message: "missing return"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"new.go"}, posted)
+	require.Equal(t, []string{"/api/v4/projects/team/repo/repository/commits/sha1/discussions/d1/notes/1"}, updated)
+	require.Equal(t, []string{"/api/v4/projects/team/repo/repository/commits/sha1/discussions/d2/notes/2"}, deleted)
+}
@@ -30,5 +30,7 @@ type Configuration struct {
 		TTL   int           `toml:"ttl" default:"60" json:"ttl"`
 		Redis sdk.RedisConf `toml:"redis" json:"redis"`
 	} `toml:"cache" comment:"######################\n CDS VCS Cache Settings \n######################" json:"cache"`
-	ProxyWebhook string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
+	GitBasedir      string `toml:"gitBasedir" default:"/tmp/cds/vcs/git" comment:"Root directory where the mirrors of the repositories hosted on plain git servers are stored" json:"git_basedir"`
+	GitAllowFileURL bool   `toml:"gitAllowFileURL" default:"false" commented:"true" comment:"Allow plain git servers with a file:// url, read on the filesystem of the vcs service. For tests only" json:"git_allow_file_url"`
+	ProxyWebhook    string `toml:"proxyWebhook" default:"" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
 }
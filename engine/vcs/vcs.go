@@ -12,10 +12,12 @@ import (
 
 	"github.com/ovh/cds/engine/api"
 	"github.com/ovh/cds/engine/cache"
+	"github.com/ovh/cds/engine/vcs/azuredevops"
 	"github.com/ovh/cds/engine/vcs/bitbucketcloud"
 	"github.com/ovh/cds/engine/vcs/bitbucketserver"
 	"github.com/ovh/cds/engine/vcs/forgejo"
 	"github.com/ovh/cds/engine/vcs/gerrit"
+	"github.com/ovh/cds/engine/vcs/git"
 	"github.com/ovh/cds/engine/vcs/gitea"
 	"github.com/ovh/cds/engine/vcs/github"
 	"github.com/ovh/cds/engine/vcs/gitlab"
@@ -85,6 +87,23 @@ func (s *Service) CheckConfiguration(config interface{}) error {
 
 func (s *Service) getConsumer(vcsAuth sdk.VCSAuth) (sdk.VCSServer, error) {
 	switch vcsAuth.Type {
+	case sdk.VCSTypeAzureDevOps:
+		return azuredevops.New(strings.TrimSuffix(vcsAuth.URL, "/"),
+			s.Cfg.ProxyWebhook,
+			vcsAuth.Username,
+			vcsAuth.Token,
+		), nil
+	case sdk.VCSTypeGit:
+		return git.New(vcsAuth.URL,
+			s.Cfg.GitBasedir,
+			s.Cfg.GitAllowFileURL,
+			vcsAuth.Username,
+			vcsAuth.Token,
+			vcsAuth.SSHUsername,
+			vcsAuth.SSHPort,
+			vcsAuth.SSHPrivateKey,
+			vcsAuth.SSHKnownHosts,
+		), nil
 	case sdk.VCSTypeForgejo:
 		return forgejo.New(strings.TrimSuffix(vcsAuth.URL, "/"),
 			vcsAuth.Username,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"net/http"
+	"strconv"
 
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -18,6 +19,11 @@ var (
 	contextKeyVCSType     contextKey = "vcs-type"
 	contextKeyVCSUsername contextKey = "vcs-username"
 	contextKeyVCSToken    contextKey = "vcs-token"
+
+	contextKeyVCSSSHUsername   contextKey = "vcs-ssh-username"
+	contextKeyVCSSSHPort       contextKey = "vcs-ssh-port"
+	contextKeyVCSSSHPrivateKey contextKey = "vcs-ssh-private-key"
+	contextKeyVCSSSHKnownHosts contextKey = "vcs-ssh-known-hosts"
 )
 
 func (s *Service) authMiddleware(ctx context.Context, w http.ResponseWriter, req *http.Request, rc *service.HandlerConfig) (context.Context, error) {
@@ -41,12 +47,32 @@ func (s *Service) authMiddleware(ctx context.Context, w http.ResponseWriter, req
 	if err != nil {
 		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSToken")
 	}
+	vcsSSHUsername, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHUsername))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHUsername")
+	}
+	vcsSSHPort, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHPort))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHPort")
+	}
+	vcsSSHPrivateKey, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHPrivateKey))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHPrivateKey")
+	}
+	vcsSSHKnownHosts, err := base64.StdEncoding.DecodeString(req.Header.Get(sdk.HeaderXVCSSSHKnownHosts))
+	if err != nil {
+		return nil, sdk.WrapError(err, "bad header syntax for HeaderXVCSSSHKnownHosts")
+	}
 	if string(vcsType) != "" {
 		ctx = context.WithValue(ctx, contextKeyVCSURL, string(vcsURL))
 		ctx = context.WithValue(ctx, contextKeyVCSURLApi, string(vcsURLApi))
 		ctx = context.WithValue(ctx, contextKeyVCSType, string(vcsType))
 		ctx = context.WithValue(ctx, contextKeyVCSUsername, string(vcsUsername))
 		ctx = context.WithValue(ctx, contextKeyVCSToken, string(vcsToken))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHUsername, string(vcsSSHUsername))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHPort, string(vcsSSHPort))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHPrivateKey, string(vcsSSHPrivateKey))
+		ctx = context.WithValue(ctx, contextKeyVCSSSHKnownHosts, string(vcsSSHKnownHosts))
 		return ctx, nil
 	}
 
@@ -74,5 +100,17 @@ func getVCSAuth(ctx context.Context) (sdk.VCSAuth, error) {
 	token, _ := ctx.Value(contextKeyVCSToken).(string)
 	vcsAuth.Token = token
 
+	sshUsername, _ := ctx.Value(contextKeyVCSSSHUsername).(string)
+	vcsAuth.SSHUsername = sshUsername
+
+	sshPort, _ := ctx.Value(contextKeyVCSSSHPort).(string)
+	vcsAuth.SSHPort, _ = strconv.Atoi(sshPort)
+
+	sshPrivateKey, _ := ctx.Value(contextKeyVCSSSHPrivateKey).(string)
+	vcsAuth.SSHPrivateKey = sshPrivateKey
+
+	sshKnownHosts, _ := ctx.Value(contextKeyVCSSSHKnownHosts).(string)
+	vcsAuth.SSHKnownHosts = sshKnownHosts
+
 	return vcsAuth, nil
 }
@@ -296,6 +296,37 @@ func (s *Service) getCommitsBetweenRefsHandler() service.Handler {
 	}
 }
 
+func (s *Service) getDiffBetweenRefsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		base := r.URL.Query().Get("base")
+		head := r.URL.Query().Get("head")
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+
+		files, err := client.DiffBetweenRefs(ctx, fmt.Sprintf("%s/%s", owner, repo), base, head)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get diff of %s/%s between %s and %s", owner, repo, base, head)
+		}
+		return service.WriteJSON(w, files, http.StatusOK)
+	}
+}
+
 func (s *Service) getCommitHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -362,6 +393,44 @@ func (s *Service) postInsightHandler() service.Handler {
 	}
 }
 
+func (s *Service) postAnnotationsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		commit := muxVar(r, "commit")
+
+		var annotations sdk.VCSAnnotations
+		if err := service.UnmarshalBody(r, &annotations); err != nil {
+			return sdk.WrapError(err, "Unable to read body")
+		}
+		if err := annotations.Lint(); err != nil {
+			return err
+		}
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+
+		if err := client.CreateAnnotations(ctx, fmt.Sprintf("%s/%s", owner, repo), commit, annotations); err != nil {
+			return sdk.WrapError(err, "Unable to create annotations on commit %s on %s/%s", commit, owner, repo)
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
 func (s *Service) getCommitStatusHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -423,6 +492,73 @@ func (s *Service) getPullRequestHandler() service.Handler {
 	}
 }
 
+func (s *Service) getPullRequestMergeStatusHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		id := muxVar(r, "id")
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+
+		status, err := client.PullRequestMergeStatus(ctx, fmt.Sprintf("%s/%s", owner, repo), id)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get pull request %s merge status on %s/%s", id, owner, repo)
+		}
+		return service.WriteJSON(w, status, http.StatusOK)
+	}
+}
+
+func (s *Service) postPullRequestMergeHandler() service.Handler {
+	return func(ctx context.Context, _ http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		id := muxVar(r, "id")
+
+		var body sdk.VCSPullRequestMergeRequest
+		if err := service.UnmarshalBody(r, &body); err != nil {
+			return sdk.WithStack(err)
+		}
+		if strconv.Itoa(body.ID) != id {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request id %d doesn't match %s", body.ID, id)
+		}
+
+		vcsAuth, err := getVCSAuth(ctx)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrUnauthorized, "unable to get access token header")
+		}
+
+		consumer, err := s.getConsumer(vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, vcsAuth)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+
+		if err := client.PullRequestMerge(ctx, fmt.Sprintf("%s/%s", owner, repo), body); err != nil {
+			return sdk.WrapError(err, "Unable to merge pull request %s on %s/%s", id, owner, repo)
+		}
+		return nil
+	}
+}
+
 func (s *Service) getPullRequestsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
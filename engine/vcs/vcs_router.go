@@ -33,15 +33,18 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/tags", nil, r.GET(s.getTagsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/tags/{tagName}", nil, r.GET(s.getTagHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits", nil, r.GET(s.getCommitsBetweenRefsHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/diff", nil, r.GET(s.getDiffBetweenRefsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits/{commit}", nil, r.GET(s.getCommitHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits/{commit}/statuses", nil, r.GET(s.getCommitStatusHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits/{commit}/insight/{insightKey}", nil, r.POST(s.postInsightHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/commits/{commit}/annotations", nil, r.POST(s.postAnnotationsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/contents/{filePath}", nil, r.GET(s.getListContentsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/content/{filePath}", nil, r.GET(s.getFileContentHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/archive", nil, r.POST(s.archiveHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests", nil, r.GET(s.getPullRequestsHandler), r.POST(s.postPullRequestsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests/comments", nil, r.POST(s.postPullRequestCommentHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests/{id}", nil, r.GET(s.getPullRequestHandler))
+	r.Handle("/vcs/{name}/repos/{owner}/{repo}/pullrequests/{id}/merge", nil, r.GET(s.getPullRequestMergeStatusHandler), r.POST(s.postPullRequestMergeHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/events", nil, r.GET(s.getEventsHandler), r.POST(s.postFilterEventsHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/hooks", nil, r.GET(s.getHookHandler), r.POST(s.postHookHandler), r.PUT(s.putHookHandler), r.DELETE(s.deleteHookHandler))
 	r.Handle("/vcs/{name}/repos/{owner}/{repo}/releases", nil, r.POST(s.postReleaseHandler))
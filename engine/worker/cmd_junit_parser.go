@@ -1,14 +1,13 @@
 package main
 
 import (
-	"encoding/xml"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/ovh/cds/engine/worker/internal/action"
 	"github.com/ovh/cds/sdk"
 )
 
@@ -17,10 +16,15 @@ func cmdJunitParser() *cobra.Command {
 		Use:   "junit-parser",
 		Short: "worker junit-parser",
 		Long: `
-worker junit-parser command helps you to parse junit files and print a summary.
+worker junit-parser command helps you to parse tests reports and print a summary.
+
+JUnit, TAP, Go test2json (go test -json) and TRX (Visual Studio) reports are supported, the format is detected from the content of each file.
 
 It displays the number of tests, the number of passed tests, the number of failed tests and the number of skipped tests.
 
+With the --annotate flag, failed tests are sent as annotations to the vcs server. The location of a failure is read from
+the file and line attributes of the test case, or from the failure output. Only available with workflows as code.
+
 Examples:
 	$ ls
 	result1.xml		result2.xml
@@ -28,12 +32,21 @@ Examples:
 	10 10 0 0
 	$ worker junit-parser *.xml
 	20 20 0 0
+	$ worker junit-parser --annotate --path-prefix engine/api result1.xml
+	10 9 1 0
 `,
 		RunE: junitParserCmd(),
 	}
+	c.Flags().BoolVar(&junitParserAnnotate, "annotate", false, "Send failed tests as annotations")
+	c.Flags().StringVar(&junitParserPathPrefix, "path-prefix", "", "Directory of the test files, relative to the repository root")
 	return c
 }
 
+var (
+	junitParserAnnotate   bool
+	junitParserPathPrefix string
+)
+
 func junitParserCmd() func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		var filepaths []string
@@ -51,12 +64,11 @@ func junitParserCmd() func(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("junit parser: cannot read file %s (%s)", f, err)
 			}
-			var ftests sdk.JUnitTestsSuites
-			if err := xml.Unmarshal(data, &ftests); err != nil {
-				// Check if file contains testsuite only (and no testsuites)
-				if s, ok := action.ParseTestsuiteAlone(data); ok {
-					ftests.TestSuites = append(ftests.TestSuites, s)
-				}
+			_, fileName := filepath.Split(f)
+			ftests, _, err := sdk.ParseTestsReport(strings.TrimSuffix(fileName, filepath.Ext(fileName)), data)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "junit parser: ignoring file %s (%s)\n", f, err)
+				continue
 			}
 			tests.TestSuites = append(tests.TestSuites, ftests.TestSuites...)
 		}
@@ -66,6 +78,16 @@ func junitParserCmd() func(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(stats.Total, stats.TotalOK, stats.TotalKO, stats.TotalSkipped)
 
+		if junitParserAnnotate {
+			if isLegacyMode() {
+				return fmt.Errorf("junit parser: annotations are only available with workflows as code")
+			}
+			summary := fmt.Sprintf("%d tests, %d passed, %d failed, %d skipped", stats.Total, stats.TotalOK, stats.TotalKO, stats.TotalSkipped)
+			if err := sendAnnotations("junit", "Tests", summary, junitParserPathPrefix, tests.Annotations()); err != nil {
+				return fmt.Errorf("junit parser: unable to send annotations (%v)", err)
+			}
+		}
+
 		return nil
 	}
 }
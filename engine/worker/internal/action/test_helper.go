@@ -35,6 +35,10 @@ func (*TestWorker) V2GetProjectKey(ctx context.Context, keyName string, clear bo
 	panic("unimplemented")
 }
 
+func (*TestWorker) V2DebugBreakpoint(ctx context.Context) error {
+	panic("unimplemented")
+}
+
 func (*TestWorker) V2GetCacheSignature(ctx context.Context, cacheKey string) (*workerruntime.CDNSignature, error) {
 	panic("unimplemented")
 }
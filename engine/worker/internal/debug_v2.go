@@ -0,0 +1,224 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+// V2DebugBreakpoint pauses the job until a user resumes it, only when the job runs in debug mode
+func (w *CurrentWorker) V2DebugBreakpoint(ctx context.Context) error {
+	if !w.currentJobV2.runJob.Debug {
+		w.SendLog(ctx, workerruntime.LevelWarn, "Breakpoint ignored: the job is not running in debug mode")
+		return nil
+	}
+
+	// Stop the session when the job is stopped or when the breakpoint command is interrupted
+	jobCtx, cancel := context.WithCancel(w.currentJobV2.context)
+	defer cancel()
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	return w.v2DebugSession(jobCtx, fmt.Sprintf("breakpoint in step %s", w.currentJobV2.currentStepNameForLog))
+}
+
+// v2DebugSession keeps the worker alive and serves a shell in the job workspace to the users attached
+// with cdsctl, until one of them resumes the job or the session timeout expires.
+func (w *CurrentWorker) v2DebugSession(ctx context.Context, reason string) error {
+	runJob := w.currentJobV2.runJob
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	timeout := time.NewTimer(sdk.V2JobDebugSessionTimeout)
+	defer timeout.Stop()
+
+	msg := fmt.Sprintf("Job paused for debugging (%s) during %s, attach with: cdsctl experimental workflow jobs attach %s %s %s", reason, sdk.V2JobDebugSessionTimeout, runJob.ProjectKey, runJob.WorkflowRunID, runJob.ID)
+	w.SendLog(ctx, workerruntime.LevelWarn, msg)
+	w.gelfLogger.flush()
+	info := sdk.V2SendJobRunInfo{
+		Level:   sdk.WorkflowRunInfoLevelWarning,
+		Message: msg,
+		Time:    time.Now(),
+	}
+	if err := w.ClientV2().V2QueuePushJobInfo(ctx, runJob.Region, runJob.ID, info); err != nil {
+		log.Error(ctx, "unable to send debug session info: %v", err)
+	}
+
+	toSend := make(chan json.RawMessage, 100)
+	received := make(chan json.RawMessage, 100)
+	errs := make(chan error, 10)
+	send := func(m sdk.V2JobDebugMessage) {
+		btes, _ := json.Marshal(m)
+		select {
+		case toSend <- btes:
+		case <-ctx.Done():
+		}
+	}
+	// resume notifies the attached users and lets the last messages be sent before closing the websocket
+	resume := func(m sdk.V2JobDebugMessage) {
+		send(m)
+		for i := 0; i < 20 && len(toSend) > 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	goRoutines := sdk.NewGoRoutines(ctx)
+	goRoutines.Exec(ctx, "v2DebugSession-websocket", func(ctx context.Context) {
+		for ctx.Err() == nil {
+			if err := w.ClientV2().V2QueueJobDebugSession(ctx, goRoutines, runJob.Region, runJob.ID, toSend, received, errs); err != nil && ctx.Err() == nil {
+				log.Warn(ctx, "debug session websocket error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	})
+
+	var shell *debugShell
+	defer func() {
+		if shell != nil {
+			cancel()
+			shell.close()
+		}
+	}()
+	ensureShell := func() bool {
+		if shell != nil {
+			return true
+		}
+		s, err := w.startDebugShell(ctx, send)
+		if err != nil {
+			send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeOutput, Data: err.Error() + "\n"})
+			return false
+		}
+		shell = s
+		return true
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout.C:
+			w.SendLog(ctx, workerruntime.LevelWarn, "Debug session expired, resuming the job")
+			resume(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeContinue, Data: "debug session expired"})
+			return nil
+		case err := <-errs:
+			log.Warn(ctx, "debug session: %v", err)
+		case <-shellExited(shell):
+			send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeExit})
+			shell = nil
+		case m := <-received:
+			var in sdk.V2JobDebugMessage
+			if err := sdk.JSONUnmarshal(m, &in); err != nil {
+				log.Warn(ctx, "unable to read debug session message: %v", err)
+				continue
+			}
+			switch in.Type {
+			case sdk.V2JobDebugMessageTypeAttach:
+				w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Debug session opened by %s", in.Username))
+				send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypePaused, Data: reason})
+				ensureShell()
+			case sdk.V2JobDebugMessageTypeDetach:
+				w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Debug session closed by %s", in.Username))
+			case sdk.V2JobDebugMessageTypeInput:
+				if !ensureShell() {
+					continue
+				}
+				if _, err := io.WriteString(shell.stdin, in.Data); err != nil {
+					log.Warn(ctx, "unable to write to debug shell: %v", err)
+				}
+			case sdk.V2JobDebugMessageTypeContinue:
+				w.SendLog(ctx, workerruntime.LevelWarn, fmt.Sprintf("Job resumed by %s", in.Username))
+				resume(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeContinue, Username: in.Username})
+				return nil
+			}
+		}
+	}
+}
+
+type debugShell struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	exited chan struct{}
+}
+
+func (s *debugShell) close() {
+	_ = s.stdin.Close()
+	if s.cmd.Process != nil {
+		_ = s.cmd.Process.Kill()
+	}
+	<-s.exited
+}
+
+// shellExited returns a nil channel, that blocks forever, when there is no shell
+func shellExited(s *debugShell) <-chan struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.exited
+}
+
+// startDebugShell runs an interactive shell in the job workspace, with the job environment.
+// The shell is not attached to a terminal: its standard input and outputs are streamed through the debug session.
+func (w *CurrentWorker) startDebugShell(ctx context.Context, send func(sdk.V2JobDebugMessage)) (*debugShell, error) {
+	env, err := w.GetEnvVariable(ctx, w.currentJobV2.runJobContext)
+	if err != nil {
+		return nil, err
+	}
+
+	shell := "sh"
+	if _, err := exec.LookPath("bash"); err == nil {
+		shell = "bash"
+	}
+	cmd := exec.Command(shell, "-i")
+	cmd.Dir = w.workingDirAbs
+	// Processes started from the shell can keep its outputs open
+	cmd.WaitDelay = time.Second
+	cmd.Env = w.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	outReader, outWriter := io.Pipe()
+	cmd.Stdout = outWriter
+	cmd.Stderr = outWriter
+	if err := cmd.Start(); err != nil {
+		return nil, sdk.WrapError(err, "unable to start %s", shell)
+	}
+
+	s := &debugShell{cmd: cmd, stdin: stdin, exited: make(chan struct{})}
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := outReader.Read(buf)
+			if n > 0 {
+				send(sdk.V2JobDebugMessage{Type: sdk.V2JobDebugMessageTypeOutput, Data: w.blur.String(string(buf[:n]))})
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.Info(ctx, "debug shell exited: %v", err)
+		}
+		_ = outWriter.Close()
+		close(s.exited)
+	}()
+	return s, nil
+}
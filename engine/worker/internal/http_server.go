@@ -71,6 +71,8 @@ func (w *CurrentWorker) Serve(c context.Context) error {
 	r.HandleFunc("/v2/context", LogMiddleware(workerruntime.V2_contextHandler(c, w)))
 	r.HandleFunc("/v2/result", LogMiddleware(workerruntime.V2_runResultHandler(c, w)))
 	r.HandleFunc("/v2/result/synchronize", LogMiddleware(workerruntime.V2_runResultsSynchronizeHandler(c, w)))
+	r.HandleFunc("/v2/annotations", LogMiddleware(workerruntime.V2_annotationsHandler(c, w)))
+	r.HandleFunc("/v2/breakpoint", LogMiddleware(workerruntime.V2_breakpointHandler(c, w)))
 
 	srv := &http.Server{
 		Handler: r,
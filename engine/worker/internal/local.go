@@ -0,0 +1,270 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+	"github.com/ovh/cds/sdk/jws"
+)
+
+const localRegion = "local"
+
+// LocalJob describes a job to execute on the current host, without any CDS API.
+type LocalJob struct {
+	JobID     string
+	Job       sdk.V2Job
+	Context   sdk.WorkflowRunJobsContext
+	Actions   map[string]sdk.V2Action
+	Workspace string
+	// Image is the docker image used to run script steps. Steps are run as local processes if empty.
+	Image   string
+	Secrets []string
+	Output  io.Writer
+}
+
+// RunLocalJob executes a job with the worker logic. API calls are stubbed: step status
+// updates are printed on the output and job outputs are kept in memory.
+func RunLocalJob(ctx context.Context, j LocalJob) (sdk.V2WorkflowRunJobResult, sdk.JobResultOutput, error) {
+	if len(j.Job.Services) > 0 {
+		return sdk.V2WorkflowRunJobResult{}, nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "job %s: services are not supported by the local runner", j.JobID)
+	}
+
+	w := new(CurrentWorker)
+	w.cfg = &workerruntime.WorkerConfig{Name: "local", Region: localRegion}
+	w.status.Name = w.cfg.Name
+	w.basedir = afero.NewBasePathFs(afero.NewOsFs(), j.Workspace)
+	w.workingDirAbs = j.Workspace
+	w.actions = j.Actions
+	w.actionPlugin = make(map[string]*sdk.GRPCPlugin)
+	for _, name := range localPlugins {
+		w.actionPlugin[name] = &sdk.GRPCPlugin{Name: name}
+	}
+	w.pluginFactory = &localPluginFactory{workspace: j.Workspace, image: j.Image}
+	w.clientV2 = &localClient{jobID: j.JobID, out: j.Output, started: map[string]bool{}, ended: map[string]bool{}}
+
+	pk, err := jws.NewRandomRSAKey()
+	if err != nil {
+		return sdk.V2WorkflowRunJobResult{}, nil, err
+	}
+	w.signer, err = jws.NewSigner(pk)
+	if err != nil {
+		return sdk.V2WorkflowRunJobResult{}, nil, err
+	}
+	w.currentJobV2.sensitiveDatas = j.Secrets
+	w.blur, err = sdk.NewBlur(j.Secrets)
+	if err != nil {
+		return sdk.V2WorkflowRunJobResult{}, nil, err
+	}
+
+	l := logrus.New()
+	l.SetOutput(j.Output)
+	l.SetFormatter(&localLogFormatter{prefix: j.JobID})
+	w.gelfLogger = &logger{logger: l}
+
+	w.currentJobV2.runJob = &sdk.V2WorkflowRunJob{
+		ID:           sdk.UUID(),
+		JobID:        j.JobID,
+		Job:          j.Job,
+		Status:       sdk.V2WorkflowRunJobStatusBuilding,
+		Region:       localRegion,
+		ProjectKey:   j.Context.CDS.ProjectKey,
+		WorkflowName: j.Context.CDS.Workflow,
+	}
+	w.currentJobV2.runJobContext = j.Context
+	w.currentJobV2.runJobContext.CDS.Workspace = j.Workspace
+	if w.currentJobV2.runJobContext.Jobs == nil {
+		w.currentJobV2.runJobContext.Jobs = sdk.JobsResultContext{}
+	}
+	if w.currentJobV2.runJobContext.Integrations == nil {
+		w.currentJobV2.runJobContext.Integrations = &sdk.JobIntegrationsContexts{}
+	}
+
+	ctx = workerruntime.SetRunJobID(ctx, w.currentJobV2.runJob.ID)
+	w.currentJobV2.context = ctx
+
+	res := w.runJobAsCode(ctx)
+	return res, w.currentJobV2.runJobContext.Jobs[j.JobID].Outputs, nil
+}
+
+// localClient replaces the worker API client. Only the calls made while running steps are implemented,
+// the other ones return a not implemented error.
+type localClient struct {
+	jobID   string
+	out     io.Writer
+	started map[string]bool
+	ended   map[string]bool
+}
+
+func (c *localClient) V2QueueJobStepUpdate(_ context.Context, _ string, _ string, stepsStatus sdk.JobStepsStatus) error {
+	names := make([]string, 0, len(stepsStatus))
+	for name := range stepsStatus {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return stepsStatus[names[i]].Started.Before(stepsStatus[names[j]].Started) })
+	for _, name := range names {
+		s := stepsStatus[name]
+		if !c.started[name] {
+			c.started[name] = true
+			fmt.Fprintf(c.out, "[%s] > step %s\n", c.jobID, name)
+		}
+		if !s.Ended.IsZero() && !c.ended[name] {
+			c.ended[name] = true
+			fmt.Fprintf(c.out, "[%s] < step %s: %s (%s)\n", c.jobID, name, s.Conclusion, sdk.Round(s.Ended.Sub(s.Started), time.Millisecond))
+		}
+	}
+	return nil
+}
+
+func (c *localClient) V2QueuePushJobInfo(_ context.Context, _ string, _ string, msg sdk.V2SendJobRunInfo) error {
+	fmt.Fprintf(c.out, "[%s] %s: %s\n", c.jobID, msg.Level, msg.Message)
+	return nil
+}
+
+func (c *localClient) V2QueuePushRunInfo(_ context.Context, _ string, _ string, msg sdk.V2WorkflowRunInfo) error {
+	fmt.Fprintf(c.out, "[%s] %s: %s\n", c.jobID, msg.Level, msg.Message)
+	return nil
+}
+
+func (c *localClient) V2QueueJobRunResultCreate(_ context.Context, _ string, _ string, result *sdk.V2WorkflowRunResult) error {
+	if result.ID == "" {
+		result.ID = sdk.UUID()
+	}
+	return nil
+}
+
+func (c *localClient) PluginsGet(name string) (*sdk.GRPCPlugin, error) {
+	return nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "plugin %s is not available with the local runner", name)
+}
+
+var _ cdsclient.V2WorkerInterface = new(localClient)
+
+func errLocalNotImplemented(feature string) error {
+	return sdk.NewErrorFrom(sdk.ErrNotImplemented, "%s is not available with the local runner", feature)
+}
+
+func (c *localClient) V2WorkerRegister(_ context.Context, _ string, _ sdk.WorkerRegistrationForm, _, _ string) (*sdk.V2Worker, error) {
+	return nil, errLocalNotImplemented("worker registration")
+}
+
+func (c *localClient) V2WorkerUnregister(_ context.Context, _, _ string) error {
+	return errLocalNotImplemented("worker unregistration")
+}
+
+func (c *localClient) V2WorkerRefresh(_ context.Context, _, _ string) error {
+	return errLocalNotImplemented("worker refresh")
+}
+
+func (c *localClient) V2WorkerProjectGetKey(_ context.Context, _, _, keyName string, _ bool) (*sdk.ProjectKey, error) {
+	return nil, errLocalNotImplemented(fmt.Sprintf("project key %s", keyName))
+}
+
+func (c *localClient) V2QueueGetJobRun(_ context.Context, _, _ string) (*sdk.V2QueueJobInfo, error) {
+	return nil, errLocalNotImplemented("job run loading")
+}
+
+func (c *localClient) V2QueuePolling(_ context.Context, _ string, _ []string, _ *sdk.GoRoutines, _ *sdk.HatcheryMetrics, _ *sdk.HatcheryPendingWorkerCreation, _ chan<- string, _ chan<- error, _ time.Duration, _ ...cdsclient.RequestModifier) error {
+	return errLocalNotImplemented("queue polling")
+}
+
+func (c *localClient) V2QueueJobResult(_ context.Context, _, _ string, _ sdk.V2WorkflowRunJobResult) error {
+	return errLocalNotImplemented("job result sending")
+}
+
+func (c *localClient) V2QueueJobRunResultGet(_ context.Context, _, _, runResultID string) (*sdk.V2WorkflowRunResult, error) {
+	return nil, errLocalNotImplemented(fmt.Sprintf("run result %s", runResultID))
+}
+
+func (c *localClient) V2QueueJobRunResultsGet(_ context.Context, _, _ string) ([]sdk.V2WorkflowRunResult, error) {
+	return nil, errLocalNotImplemented("run results listing")
+}
+
+func (c *localClient) V2QueueJobRunResultsSynchronize(_ context.Context, _, _ string) error {
+	return errLocalNotImplemented("run results synchronization")
+}
+
+func (c *localClient) V2QueueJobExternalRunResultsGet(_ context.Context, _, _, runRef string) ([]sdk.V2WorkflowRunResult, error) {
+	return nil, errLocalNotImplemented(fmt.Sprintf("run results of %s", runRef))
+}
+
+func (c *localClient) V2QueueJobExternalRunResultAccess(_ context.Context, _, _, workflowRunID string) error {
+	return errLocalNotImplemented(fmt.Sprintf("run results of workflow run %s", workflowRunID))
+}
+
+func (c *localClient) V2QueueJobRunResultUpdate(_ context.Context, _, _ string, result *sdk.V2WorkflowRunResult) error {
+	return errLocalNotImplemented(fmt.Sprintf("run result %s update", result.Name()))
+}
+
+func (c *localClient) V2QueuePushJobAnnotations(_ context.Context, _, _ string, _ sdk.VCSAnnotations) error {
+	return errLocalNotImplemented("job annotations")
+}
+
+func (c *localClient) V2QueueJobDebugSession(_ context.Context, _ *sdk.GoRoutines, _, _ string, _ <-chan json.RawMessage, _ chan<- json.RawMessage, _ chan<- error) error {
+	return errLocalNotImplemented("debug session")
+}
+
+func (c *localClient) V2QueueWorkerTakeJob(_ context.Context, _, _ string) (*sdk.V2TakeJobResponse, error) {
+	return nil, errLocalNotImplemented("job taking")
+}
+
+func (c *localClient) V2QueueGetCacheLinks(_ context.Context, _, _, cacheKey string) (*sdk.CDNItemLinks, error) {
+	return nil, errLocalNotImplemented(fmt.Sprintf("cache %s", cacheKey))
+}
+
+func (c *localClient) ProjectV2IntegrationWorkerHookGet(_, integrationName string) (*sdk.WorkerHookProjectIntegrationModel, error) {
+	return nil, errLocalNotImplemented(fmt.Sprintf("worker hook of integration %s", integrationName))
+}
+
+func (c *localClient) PluginsList() ([]sdk.GRPCPlugin, error) {
+	return nil, errLocalNotImplemented("plugins listing")
+}
+
+func (c *localClient) PluginAdd(_ *sdk.GRPCPlugin) error {
+	return errLocalNotImplemented("plugin management")
+}
+
+func (c *localClient) PluginUpdate(_ *sdk.GRPCPlugin) error {
+	return errLocalNotImplemented("plugin management")
+}
+
+func (c *localClient) PluginDelete(_ string) error {
+	return errLocalNotImplemented("plugin management")
+}
+
+func (c *localClient) PluginAddBinary(_ *sdk.GRPCPlugin, _ *sdk.GRPCPluginBinary) error {
+	return errLocalNotImplemented("plugin management")
+}
+
+func (c *localClient) PluginDeleteBinary(_, _, _ string) error {
+	return errLocalNotImplemented("plugin management")
+}
+
+func (c *localClient) PluginGetBinary(name, _, _ string, _ io.Writer) error {
+	return errLocalNotImplemented(fmt.Sprintf("plugin %s", name))
+}
+
+func (c *localClient) PluginGetBinaryInfos(name, _, _ string) (*sdk.GRPCPluginBinary, error) {
+	return nil, errLocalNotImplemented(fmt.Sprintf("plugin %s", name))
+}
+
+type localLogFormatter struct {
+	prefix string
+}
+
+func (f *localLogFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	msg := strings.TrimRight(entry.Message, "\r\n")
+	if msg == "" {
+		return nil, nil
+	}
+	return []byte(fmt.Sprintf("[%s] %s\n", f.prefix, msg)), nil
+}
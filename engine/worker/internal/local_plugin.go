@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ovh/cds/engine/worker/internal/plugin"
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+// localPlugins are the action plugins handled by the local runner.
+var localPlugins = []string{"script", "checkout"}
+
+type localPluginFactory struct {
+	workspace string
+	image     string
+}
+
+func (pf *localPluginFactory) NewClient(_ context.Context, wk workerruntime.Runtime, _ string, pluginName string, _ string, env map[string]string) (plugin.Client, error) {
+	switch pluginName {
+	case "script", "checkout":
+		return &localPluginClient{name: pluginName, wk: wk, workspace: pf.workspace, image: pf.image, env: env}, nil
+	}
+	return nil, sdk.NewErrorFrom(sdk.ErrNotImplemented, "plugin %s is not available with the local runner", pluginName)
+}
+
+type localPluginClient struct {
+	name      string
+	wk        workerruntime.Runtime
+	workspace string
+	image     string
+	env       map[string]string
+}
+
+func (c *localPluginClient) Close(_ context.Context) {}
+
+func (c *localPluginClient) GetPostAction() *sdk.PluginPost { return nil }
+
+func (c *localPluginClient) Run(ctx context.Context, opts map[string]string) *plugin.Result {
+	if c.name == "checkout" {
+		c.wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("checkout skipped: using local workspace %s", c.workspace))
+		return &plugin.Result{Status: sdk.StatusSuccess}
+	}
+	if err := c.runScript(ctx, opts["content"]); err != nil {
+		return &plugin.Result{Status: sdk.StatusFail, Details: err.Error()}
+	}
+	return &plugin.Result{Status: sdk.StatusSuccess}
+}
+
+func (c *localPluginClient) runScript(ctx context.Context, content string) error {
+	shell, opts := "/bin/sh", []string{"-e"}
+	if strings.HasPrefix(content, "#!") { // If user wants a specific shell, use it
+		t := strings.SplitN(content, "\n", 2)
+		shebang := strings.Fields(strings.TrimPrefix(t[0], "#!"))
+		if len(shebang) > 0 {
+			shell, opts = shebang[0], shebang[1:]
+		}
+		// if it's a shell, we add set -e to failed job when a command is failed
+		if len(opts) == 0 && isLocalShell(shell) {
+			opts = []string{"-e"}
+		}
+		content = ""
+		if len(t) > 1 {
+			content = t[1]
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "cds-local-")
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer os.RemoveAll(tmpDir) // nolint
+	scriptPath := filepath.Join(tmpDir, "script")
+	if err := os.WriteFile(scriptPath, []byte(content), 0700); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	var cmd *exec.Cmd
+	env := []string{"CI=1"}
+	if c.image == "" {
+		cmd = exec.CommandContext(ctx, shell, append(opts, scriptPath)...)
+		cmd.Dir = c.workspace
+		env = append(c.wk.Environ(), env...)
+	} else {
+		args := []string{"run", "--rm",
+			"-v", c.workspace + ":" + c.workspace, "-w", c.workspace,
+			"-v", tmpDir + ":" + tmpDir + ":ro",
+			"-e", "CI",
+		}
+		// Values are read from the docker client environment to keep them out of the process list
+		for k := range c.env {
+			args = append(args, "-e", k)
+		}
+		args = append(args, c.image, shell)
+		args = append(args, opts...)
+		args = append(args, scriptPath)
+		cmd = exec.CommandContext(ctx, "docker", args...)
+		env = append(os.Environ(), env...)
+	}
+	for k, v := range c.env {
+		env = append(env, k+"="+v)
+	}
+	cmd.Env = env
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reader := bufio.NewReader(pr)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				c.wk.SendLog(ctx, workerruntime.LevelInfo, line)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	errRun := cmd.Run()
+	_ = pw.Close()
+	<-done
+	if errRun != nil {
+		return fmt.Errorf("command failure: %v", errRun)
+	}
+	return nil
+}
+
+func isLocalShell(in string) bool {
+	for _, v := range []string{"ksh", "bash", "sh", "zsh"} {
+		if strings.HasSuffix(in, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestRunLocalJob(t *testing.T) {
+	var out bytes.Buffer
+	res, outputs, err := RunLocalJob(context.TODO(), LocalJob{
+		JobID: "build",
+		Job: sdk.V2Job{
+			Steps: []sdk.ActionStep{
+				{ID: "hello", Run: "echo hello $NAME $TOKEN"},
+				{ID: "skipped", Run: "exit 1", If: "${{ env.NAME == 'nobody' }}"},
+			},
+			Outputs: map[string]sdk.ActionOutput{
+				"name": {Value: "${{ env.NAME }}"},
+			},
+		},
+		Context: sdk.WorkflowRunJobsContext{
+			WorkflowRunContext: sdk.WorkflowRunContext{
+				Env: map[string]string{"NAME": "cds", "TOKEN": "mysecret"},
+			},
+		},
+		Workspace: t.TempDir(),
+		Secrets:   []string{"mysecret"},
+		Output:    &out,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sdk.V2WorkflowRunJobStatusSuccess, res.Status)
+	require.Equal(t, "cds", outputs["name"])
+	require.Contains(t, out.String(), "[build] > step hello")
+	require.Contains(t, out.String(), "[build] hello cds **********")
+	require.Contains(t, out.String(), "[build] < step skipped: Skipped")
+	require.NotContains(t, out.String(), "mysecret")
+
+	out.Reset()
+	res, _, err = RunLocalJob(context.TODO(), LocalJob{
+		JobID: "fail",
+		Job: sdk.V2Job{
+			Steps: []sdk.ActionStep{{ID: "ko", Run: "exit 3"}},
+		},
+		Workspace: t.TempDir(),
+		Output:    &out,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sdk.V2WorkflowRunJobStatusFail, res.Status)
+	require.Contains(t, out.String(), "[fail] < step ko: Fail")
+}
@@ -103,6 +103,13 @@ func (w *CurrentWorker) V2ProcessJob() (res sdk.V2WorkflowRunJobResult) {
 	}
 	res = w.runJobAsCode(ctx)
 
+	// In debug mode, keep the workspace of a failed job until the end of the debug session
+	if w.currentJobV2.runJob.Debug && res.Status == sdk.V2WorkflowRunJobStatusFail && ctx.Err() == nil {
+		if err := w.v2DebugSession(ctx, "job failed"); err != nil {
+			log.ErrorWithStackTrace(ctx, err)
+		}
+	}
+
 	// Delete hooks directory
 	if err := teardownDirectory(w.basedir, hdFile.Name()); err != nil {
 		log.Error(ctx, "Cannot remove hooks directory: %s", err)
@@ -262,7 +269,7 @@ func (w *CurrentWorker) runJobAsCode(ctx context.Context) sdk.V2WorkflowRunJobRe
 	}
 
 	defer func() {
-		w.gelfLogger.hook.Flush()
+		w.gelfLogger.flush()
 		log.Info(ctx, "runJob> end of job %s (%s)", w.currentJobV2.runJob.JobID, w.currentJobV2.runJob.ID)
 	}()
 
@@ -301,18 +308,21 @@ func (w *CurrentWorker) runJobAsCode(ctx context.Context) sdk.V2WorkflowRunJobRe
 			return w.failJob(ctx, err.Error())
 		}
 
+		sampler := startStepMetrics(ctx)
 		stepRes, pa := w.runActionStep(ctx, step, w.currentJobV2.currentStepNameForLog, *currentStepContext)
+		stepMetrics := sampler.stop()
 
 		// If job is already failed, display error in job logs
 		if jobResult.Status == sdk.V2WorkflowRunJobStatusFail && stepRes.Status == sdk.V2WorkflowRunJobStatusFail {
 			w.SendLog(ctx, workerruntime.LevelError, stepRes.Error)
 		}
 		w.SendTerminatedStepLog(ctx, workerruntime.LevelInfo, "")
-		w.gelfLogger.hook.Flush()
+		w.gelfLogger.flush()
 		if pa != nil {
 			postActionsJob = append(postActionsJob, *pa)
 		}
 		w.updateStepResult(&jobResult, stepRes, step.ContinueOnError, w.currentJobV2.currentStepNameForLog)
+		w.setStepMetrics(w.currentJobV2.currentStepNameForLog, stepMetrics)
 		w.currentJobV2.runJobContext.Steps = w.currentJobV2.runJob.StepsStatus.ToStepContext()
 
 		if err := w.ClientV2().V2QueueJobStepUpdate(ctx, w.currentJobV2.runJob.Region, w.currentJobV2.runJob.ID, w.currentJobV2.runJob.StepsStatus); err != nil {
@@ -363,10 +373,13 @@ func (w *CurrentWorker) runJobAsCode(ctx context.Context) sdk.V2WorkflowRunJobRe
 
 			w.createStepStatus(w.currentJobV2.currentStepNameForLog)
 
+			sampler := startStepMetrics(ctx)
 			postActionResult := w.runPostAction(ctx, post, w.currentJobV2.runJobContext)
+			stepMetrics := sampler.stop()
 			w.updateStepResult(&jobResult, postActionResult, post.ContinueOnError, w.currentJobV2.currentStepNameForLog)
+			w.setStepMetrics(w.currentJobV2.currentStepNameForLog, stepMetrics)
 			w.SendTerminatedStepLog(ctx, workerruntime.LevelInfo, "")
-			w.gelfLogger.hook.Flush()
+			w.gelfLogger.flush()
 			w.currentJobV2.runJobContext.Steps = w.currentJobV2.runJob.StepsStatus.ToStepContext()
 
 			if err := w.ClientV2().V2QueueJobStepUpdate(ctx, w.currentJobV2.runJob.Region, w.currentJobV2.runJob.ID, w.currentJobV2.runJob.StepsStatus); err != nil {
@@ -375,6 +388,8 @@ func (w *CurrentWorker) runJobAsCode(ctx context.Context) sdk.V2WorkflowRunJobRe
 		}
 	}
 
+	w.sendJobMetricsInfo(ctx)
+
 	return jobResult
 }
 
@@ -951,6 +966,13 @@ func (w *CurrentWorker) GetEnvVariable(ctx context.Context, contexts sdk.Workflo
 		}
 	}
 
+	// Trace context of the current step, so the tools run by the step can attach their spans to the workflow run trace
+	if w.currentJobV2.runJob != nil {
+		if tp := w.currentJobV2.runJob.StepTraceParent(w.currentJobV2.currentStepNameForLog); tp != "" {
+			newEnvVar[sdk.EnvTraceParent] = tp
+		}
+	}
+
 	pathList := sdk.StringSlice{}
 	// Retrieve path step contexts (path that comes from parent)
 	pathList = append(pathList, contexts.ParentPaths...)
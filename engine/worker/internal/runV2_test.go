@@ -95,6 +95,8 @@ func TestRunJobContinueOnError(t *testing.T) {
 		ctrl.Finish()
 	})
 	mockClient.EXPECT().V2QueueJobStepUpdate(gomock.Any(), "build", w.currentJobV2.runJob.ID, gomock.Any()).MaxTimes(4)
+	// Resources info, sent when the worker runs in a cgroup
+	mockClient.EXPECT().V2QueuePushJobInfo(gomock.Any(), "build", w.currentJobV2.runJob.ID, gomock.Any()).MaxTimes(1)
 
 	result := w.runJobAsCode(ctx)
 
@@ -148,6 +150,8 @@ func TestRunJobContinueAlways(t *testing.T) {
 		ctrl.Finish()
 	})
 	mockClient.EXPECT().V2QueueJobStepUpdate(gomock.Any(), "build", w.currentJobV2.runJob.ID, gomock.Any()).MaxTimes(4)
+	// Resources info, sent when the worker runs in a cgroup
+	mockClient.EXPECT().V2QueuePushJobInfo(gomock.Any(), "build", w.currentJobV2.runJob.ID, gomock.Any()).MaxTimes(1)
 
 	result := w.runJobAsCode(ctx)
 
@@ -235,7 +235,13 @@ func (wk *CurrentWorker) V2RunResultsSynchronize(ctx context.Context) error {
 func (wk *CurrentWorker) V2GetRunResult(ctx context.Context, filter workerruntime.V2FilterRunResult) (*workerruntime.V2GetResultResponse, error) {
 	ctx = workerruntime.SetRunJobID(ctx, wk.currentJobV2.runJob.ID)
 
-	resp, err := wk.clientV2.V2QueueJobRunResultsGet(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID)
+	var resp []sdk.V2WorkflowRunResult
+	var err error
+	if strings.TrimSpace(filter.Run) != "" {
+		resp, err = wk.clientV2.V2QueueJobExternalRunResultsGet(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID, filter.Run)
+	} else {
+		resp, err = wk.clientV2.V2QueueJobRunResultsGet(ctx, wk.currentJobV2.runJob.Region, wk.currentJobV2.runJob.ID)
+	}
 	if err != nil {
 		return nil, err
 	}
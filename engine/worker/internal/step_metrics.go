@@ -0,0 +1,301 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rockbears/log"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	// cgroupRoot and procNetDev can be overridden in tests
+	cgroupRoot = "/sys/fs/cgroup"
+	procNetDev = "/proc/net/dev"
+
+	stepMetricsSamplingInterval = 2 * time.Second
+)
+
+// resourceCounters is a snapshot of the cumulative counters of the worker cgroup
+type resourceCounters struct {
+	cpuSeconds      float64
+	memory          int64
+	diskRead        int64
+	diskWrite       int64
+	networkReceived int64
+	networkSent     int64
+}
+
+// stepMetricsSampler computes the resources used during a step from the worker cgroup.
+// CPU, disk and network are the difference of the counters between the start and the end of the step,
+// the memory peak is sampled periodically.
+type stepMetricsSampler struct {
+	start      resourceCounters
+	memoryPeak int64
+	mutex      sync.Mutex
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// startStepMetrics returns nil when the worker does not run in a cgroup, i.e. when it is not running on Linux
+func startStepMetrics(ctx context.Context) *stepMetricsSampler {
+	start, ok := readResourceCounters()
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s := &stepMetricsSampler{
+		start:      start,
+		memoryPeak: start.memory,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(stepMetricsSamplingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if memory, err := readCgroupMemory(); err == nil {
+					s.sampleMemory(memory)
+				}
+			}
+		}
+	}()
+	return s
+}
+
+func (s *stepMetricsSampler) sampleMemory(memory int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if memory > s.memoryPeak {
+		s.memoryPeak = memory
+	}
+}
+
+// stop ends the sampling and returns the resources used since the start of the step
+func (s *stepMetricsSampler) stop() *sdk.JobStepMetrics {
+	if s == nil {
+		return nil
+	}
+	s.cancel()
+	<-s.done
+
+	end, ok := readResourceCounters()
+	if !ok {
+		return nil
+	}
+	s.sampleMemory(end.memory)
+	return &sdk.JobStepMetrics{
+		CPUSeconds:      end.cpuSeconds - s.start.cpuSeconds,
+		MemoryPeak:      s.memoryPeak,
+		DiskRead:        end.diskRead - s.start.diskRead,
+		DiskWrite:       end.diskWrite - s.start.diskWrite,
+		NetworkReceived: end.networkReceived - s.start.networkReceived,
+		NetworkSent:     end.networkSent - s.start.networkSent,
+	}
+}
+
+func readResourceCounters() (resourceCounters, bool) {
+	var c resourceCounters
+	var err error
+	if c.cpuSeconds, err = readCgroupCPU(); err != nil {
+		return c, false
+	}
+	if c.memory, err = readCgroupMemory(); err != nil {
+		return c, false
+	}
+	// Disk and network counters are not available on all the hosts
+	c.diskRead, c.diskWrite, _ = readCgroupIO()
+	c.networkReceived, c.networkSent, _ = readNetworkCounters()
+	return c, true
+}
+
+// readCgroupCPU returns the CPU time consumed by the cgroup, with cgroup v2 or v1
+func readCgroupCPU() (float64, error) {
+	if stats, err := readKeyValueFile(filepath.Join(cgroupRoot, "cpu.stat")); err == nil {
+		return float64(stats["usage_usec"]) / 1e6, nil
+	}
+	ns, err := readIntFile(filepath.Join(cgroupRoot, "cpuacct", "cpuacct.usage"))
+	if err != nil {
+		return 0, err
+	}
+	return float64(ns) / 1e9, nil
+}
+
+// readCgroupMemory returns the memory currently used by the cgroup, with cgroup v2 or v1
+func readCgroupMemory() (int64, error) {
+	if v, err := readIntFile(filepath.Join(cgroupRoot, "memory.current")); err == nil {
+		return v, nil
+	}
+	return readIntFile(filepath.Join(cgroupRoot, "memory", "memory.usage_in_bytes"))
+}
+
+// readCgroupIO returns the bytes read and written on all the block devices, with cgroup v2 or v1
+func readCgroupIO() (int64, int64, error) {
+	var read, write int64
+	if lines, err := readLines(filepath.Join(cgroupRoot, "io.stat")); err == nil {
+		// 8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+		for _, l := range lines {
+			for _, f := range strings.Fields(l) {
+				k, v, ok := strings.Cut(f, "=")
+				if !ok {
+					continue
+				}
+				n, _ := strconv.ParseInt(v, 10, 64)
+				switch k {
+				case "rbytes":
+					read += n
+				case "wbytes":
+					write += n
+				}
+			}
+		}
+		return read, write, nil
+	}
+
+	lines, err := readLines(filepath.Join(cgroupRoot, "blkio", "blkio.throttle.io_service_bytes"))
+	if err != nil {
+		return 0, 0, err
+	}
+	// 8:0 Read 1459200
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) != 3 {
+			continue
+		}
+		n, _ := strconv.ParseInt(fields[2], 10, 64)
+		switch fields[1] {
+		case "Read":
+			read += n
+		case "Write":
+			write += n
+		}
+	}
+	return read, write, nil
+}
+
+// readNetworkCounters returns the bytes received and sent on all the interfaces except the loopback
+func readNetworkCounters() (int64, int64, error) {
+	lines, err := readLines(procNetDev)
+	if err != nil {
+		return 0, 0, err
+	}
+	var received, sent int64
+	// eth0: 1024 10 0 0 0 0 0 0 2048 20 0 0 0 0 0 0
+	for _, l := range lines {
+		iface, data, ok := strings.Cut(l, ":")
+		if !ok || strings.TrimSpace(iface) == "lo" {
+			continue
+		}
+		fields := strings.Fields(data)
+		if len(fields) < 9 {
+			continue
+		}
+		rx, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		tx, _ := strconv.ParseInt(fields[8], 10, 64)
+		received += rx
+		sent += tx
+	}
+	return received, sent, nil
+}
+
+func readIntFile(path string) (int64, error) {
+	btes, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(string(btes)), 10, 64)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to read %s", path)
+	}
+	return v, nil
+}
+
+func readKeyValueFile(path string) (map[string]int64, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]int64, len(lines))
+	for _, l := range lines {
+		fields := strings.Fields(l)
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		res[fields[0]] = v
+	}
+	return res, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, sdk.WithStack(scanner.Err())
+}
+
+func (w *CurrentWorker) setStepMetrics(stepName string, m *sdk.JobStepMetrics) {
+	if m == nil {
+		return
+	}
+	stepsStatus := w.GetCurrentStepsStatus()
+	stepStatus := stepsStatus[stepName]
+	stepStatus.Metrics = m
+	stepsStatus[stepName] = stepStatus
+}
+
+// sendJobMetricsInfo adds a job info with the resources used by all the steps of the job
+func (w *CurrentWorker) sendJobMetricsInfo(ctx context.Context) {
+	var total sdk.JobStepMetrics
+	var found bool
+	for _, ss := range w.currentJobV2.runJob.StepsStatus {
+		if ss.Metrics == nil {
+			continue
+		}
+		found = true
+		total.CPUSeconds += ss.Metrics.CPUSeconds
+		if ss.Metrics.MemoryPeak > total.MemoryPeak {
+			total.MemoryPeak = ss.Metrics.MemoryPeak
+		}
+		total.DiskRead += ss.Metrics.DiskRead
+		total.DiskWrite += ss.Metrics.DiskWrite
+		total.NetworkReceived += ss.Metrics.NetworkReceived
+		total.NetworkSent += ss.Metrics.NetworkSent
+	}
+	if !found {
+		return
+	}
+	info := sdk.V2SendJobRunInfo{
+		Level:   sdk.WorkflowRunInfoLevelInfo,
+		Message: "Resources used by the job - " + total.String(),
+		Time:    time.Now(),
+	}
+	if err := w.ClientV2().V2QueuePushJobInfo(ctx, w.currentJobV2.runJob.Region, w.currentJobV2.runJob.ID, info); err != nil {
+		log.Error(ctx, "unable to send job metrics info: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupFakeCgroup(t *testing.T, files map[string]string) {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	oldRoot, oldNet := cgroupRoot, procNetDev
+	cgroupRoot = dir
+	procNetDev = filepath.Join(dir, "net_dev")
+	t.Cleanup(func() {
+		cgroupRoot, procNetDev = oldRoot, oldNet
+	})
+}
+
+const testNetDev = `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo: 5000 10 0 0 0 0 0 0 5000 10 0 0 0 0 0 0
+  eth0: %s 10 0 0 0 0 0 0 %s 20 0 0 0 0 0 0
+`
+
+func TestStepMetricsCgroupV2(t *testing.T) {
+	setupFakeCgroup(t, map[string]string{
+		"cpu.stat":       "usage_usec 1000000\nuser_usec 800000\nsystem_usec 200000\n",
+		"memory.current": "104857600\n",
+		"io.stat":        "8:0 rbytes=1000 wbytes=2000 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=10 wbytes=20 rios=1 wios=2 dbytes=0 dios=0\n",
+		"net_dev":        sprintfNetDev("1024", "2048"),
+	})
+
+	s := startStepMetrics(context.TODO())
+	require.NotNil(t, s)
+
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cpu.stat"), []byte("usage_usec 3500000\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "io.stat"), []byte("8:0 rbytes=5000 wbytes=8000\n8:16 rbytes=10 wbytes=20\n"), 0644))
+	require.NoError(t, os.WriteFile(procNetDev, []byte(sprintfNetDev("4096", "3072")), 0644))
+	s.sampleMemory(524288000)
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "memory.current"), []byte("209715200\n"), 0644))
+
+	m := s.stop()
+	require.NotNil(t, m)
+	require.InDelta(t, 2.5, m.CPUSeconds, 0.001)
+	require.Equal(t, int64(524288000), m.MemoryPeak)
+	require.Equal(t, int64(4000), m.DiskRead)
+	require.Equal(t, int64(6000), m.DiskWrite)
+	require.Equal(t, int64(3072), m.NetworkReceived)
+	require.Equal(t, int64(1024), m.NetworkSent)
+}
+
+func TestStepMetricsCgroupV1(t *testing.T) {
+	setupFakeCgroup(t, map[string]string{
+		"cpuacct/cpuacct.usage":                 "1000000000\n",
+		"memory/memory.usage_in_bytes":          "1048576\n",
+		"blkio/blkio.throttle.io_service_bytes": "8:0 Read 100\n8:0 Write 200\n8:0 Total 300\nTotal 300\n",
+	})
+
+	s := startStepMetrics(context.TODO())
+	require.NotNil(t, s)
+
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "cpuacct", "cpuacct.usage"), []byte("3000000000\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "blkio", "blkio.throttle.io_service_bytes"), []byte("8:0 Read 150\n8:0 Write 600\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(cgroupRoot, "memory", "memory.usage_in_bytes"), []byte("2097152\n"), 0644))
+
+	m := s.stop()
+	require.NotNil(t, m)
+	require.InDelta(t, 2.0, m.CPUSeconds, 0.001)
+	require.Equal(t, int64(2097152), m.MemoryPeak)
+	require.Equal(t, int64(50), m.DiskRead)
+	require.Equal(t, int64(400), m.DiskWrite)
+	require.Equal(t, int64(0), m.NetworkReceived)
+}
+
+func TestStepMetricsWithoutCgroup(t *testing.T) {
+	setupFakeCgroup(t, nil)
+	s := startStepMetrics(context.TODO())
+	require.Nil(t, s)
+	require.Nil(t, s.stop())
+}
+
+func sprintfNetDev(rx, tx string) string {
+	return fmt.Sprintf(testNetDev, rx, tx)
+}
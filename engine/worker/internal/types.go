@@ -39,6 +39,13 @@ type logger struct {
 	logger *logrus.Logger
 }
 
+// flush drains the graylog hook if any. Local runs don't have one.
+func (l *logger) flush() {
+	if l.hook != nil {
+		l.hook.Flush()
+	}
+}
+
 type CurrentJobV2 struct {
 	runJob                 *sdk.V2WorkflowRunJob
 	runJobContext          sdk.WorkflowRunJobsContext
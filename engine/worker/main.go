@@ -27,6 +27,9 @@ func main() {
 		} else {
 			cmd.AddCommand(CmdResult())
 			cmd.AddCommand(CmdOutput())
+			cmd.AddCommand(CmdAnnotate())
+			cmd.AddCommand(CmdBreakpoint())
+			cmd.AddCommand(cmdJunitParser())
 		}
 	} else {
 		cmd.AddCommand(cmdRegister())
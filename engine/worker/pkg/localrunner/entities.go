@@ -0,0 +1,247 @@
+package localrunner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rockbears/yaml"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Actions are given to the worker with a complete name: <project>/<vcs>/<repo_owner>/<repo_name>/<action>
+const localActionPrefix = "LOCAL/local/local/repository/"
+
+// repository gives access to the entities defined in the .cds directory of a local repository.
+type repository struct {
+	dir string
+}
+
+func (r repository) entityDirectory(entityType string) string {
+	switch entityType {
+	case sdk.EntityTypeAction:
+		return ".cds/actions"
+	case sdk.EntityTypeWorkerModel:
+		return ".cds/worker-models"
+	case sdk.EntityTypeWorkflowTemplate:
+		return ".cds/workflow-templates"
+	}
+	return ".cds/workflows"
+}
+
+// readEntity loads an entity from its path (.cds/actions/my-action.yml) or from its name.
+func (r repository) readEntity(entityType string, name string, v interface{}) error {
+	name = strings.Split(name, "@")[0]
+	if strings.HasPrefix(name, ".cds/") {
+		bts, err := os.ReadFile(filepath.Join(r.dir, name))
+		if err != nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to read %s %s: %v", entityType, name, err)
+		}
+		if err := yaml.Unmarshal(bts, v); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read %s %s: %v", entityType, name, err)
+		}
+		return nil
+	}
+	if strings.Contains(name, "/") {
+		return sdk.NewErrorFrom(sdk.ErrNotImplemented, "%s %s is not defined in the local repository", entityType, name)
+	}
+
+	dir := r.entityDirectory(entityType)
+	files, err := os.ReadDir(filepath.Join(r.dir, dir))
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find %s %s: %v", entityType, name, err)
+	}
+	for _, f := range files {
+		if f.IsDir() || !(strings.HasSuffix(f.Name(), ".yml") || strings.HasSuffix(f.Name(), ".yaml")) {
+			continue
+		}
+		bts, err := os.ReadFile(filepath.Join(r.dir, dir, f.Name()))
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		var e struct {
+			Name string `json:"name"`
+		}
+		if err := yaml.Unmarshal(bts, &e); err != nil || e.Name != name {
+			continue
+		}
+		if err := yaml.Unmarshal(bts, v); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read %s %s: %v", entityType, name, err)
+		}
+		return nil
+	}
+	return sdk.NewErrorFrom(sdk.ErrNotFound, "unable to find %s %s in %s", entityType, name, dir)
+}
+
+// ReadWorkflow loads a workflow file and resolves its template if any.
+func ReadWorkflow(ctx context.Context, dir string, path string) (*sdk.V2Workflow, error) {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var wf sdk.V2Workflow
+	if err := yaml.Unmarshal(bts, &wf); err != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read workflow %s: %v", path, err)
+	}
+	if wf.From != "" {
+		r := repository{dir: dir}
+		var tpl sdk.V2WorkflowTemplate
+		if err := r.readEntity(sdk.EntityTypeWorkflowTemplate, wf.From, &tpl); err != nil {
+			return nil, err
+		}
+		if _, err := tpl.Resolve(ctx, &wf); err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to resolve workflow template %s: %v", wf.From, err)
+		}
+	}
+	return &wf, nil
+}
+
+// resolveJobTemplates replaces jobs using a template by the jobs of the template, the same way
+// the API does when crafting a workflow run.
+func (r repository) resolveJobTemplates(ctx context.Context, wf *sdk.V2Workflow) error {
+	jobIDs := make([]string, 0, len(wf.Jobs))
+	for jobID := range wf.Jobs {
+		jobIDs = append(jobIDs, jobID)
+	}
+	sort.Strings(jobIDs)
+
+	for _, jobID := range jobIDs {
+		j := wf.Jobs[jobID]
+		if j.From == "" {
+			continue
+		}
+		if j.Strategy != nil && len(j.Strategy.Matrix) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrNotImplemented, "job %s: matrix on a templated job is not supported by the local runner", jobID)
+		}
+		var tpl sdk.V2WorkflowTemplate
+		if err := r.readEntity(sdk.EntityTypeWorkflowTemplate, j.From, &tpl); err != nil {
+			return err
+		}
+		tmpWorkflow := sdk.V2Workflow{Name: "tmp", Parameters: j.Parameters}
+		if _, err := tpl.Resolve(ctx, &tmpWorkflow); err != nil {
+			return sdk.NewErrorFrom(sdk.ErrInvalidData, "job %s: unable to resolve workflow template %s: %v", jobID, j.From, err)
+		}
+
+		rootJobs := make([]string, 0)
+		for subJobID, subJob := range tmpWorkflow.Jobs {
+			if _, exist := wf.Jobs[subJobID]; exist {
+				return sdk.NewErrorFrom(sdk.ErrInvalidData, "job %s: job %s defined in template %s already exist in the parent workflow", jobID, subJobID, j.From)
+			}
+			// If no stage on templated job but a stage on parent job, apply stage on templated job
+			if subJob.Stage == "" && j.Stage != "" {
+				subJob.Stage = j.Stage
+				tmpWorkflow.Jobs[subJobID] = subJob
+			}
+			if len(subJob.Needs) == 0 {
+				rootJobs = append(rootJobs, subJobID)
+			}
+		}
+
+		finalJobs := make([]string, 0)
+	loop:
+		for subJobID := range tmpWorkflow.Jobs {
+			for _, jobDef := range tmpWorkflow.Jobs {
+				if slices.Contains(jobDef.Needs, subJobID) {
+					continue loop
+				}
+			}
+			finalJobs = append(finalJobs, subJobID)
+		}
+		sort.Strings(finalJobs)
+
+		// Set needs on templated root job
+		for _, id := range rootJobs {
+			jobDef := tmpWorkflow.Jobs[id]
+			if jobDef.Stage == j.Stage {
+				jobDef.Needs = append(jobDef.Needs, j.Needs...)
+				tmpWorkflow.Jobs[id] = jobDef
+			}
+		}
+
+		// Update needs in parent workflow with job ID from template
+		for id, jobDef := range wf.Jobs {
+			if i := slices.Index(jobDef.Needs, jobID); i >= 0 {
+				jobDef.Needs = slices.Delete(jobDef.Needs, i, i+1)
+				jobDef.Needs = append(jobDef.Needs, finalJobs...)
+				wf.Jobs[id] = jobDef
+			}
+		}
+
+		if wf.Stages == nil && len(tmpWorkflow.Stages) > 0 {
+			wf.Stages = make(map[string]sdk.WorkflowStage)
+		}
+		for k, v := range tmpWorkflow.Stages {
+			if _, has := wf.Stages[k]; !has {
+				wf.Stages[k] = v
+			}
+		}
+		if wf.Gates == nil && len(tmpWorkflow.Gates) > 0 {
+			wf.Gates = make(map[string]sdk.V2JobGate)
+		}
+		for k, v := range tmpWorkflow.Gates {
+			if _, has := wf.Gates[k]; !has {
+				wf.Gates[k] = v
+			}
+		}
+
+		delete(wf.Jobs, jobID)
+		for k, v := range tmpWorkflow.Jobs {
+			wf.Jobs[k] = v
+		}
+	}
+	return nil
+}
+
+// resolveActions loads the actions used by the given steps from the local repository. Steps are
+// updated to reference the actions with the complete name expected by the worker.
+func (r repository) resolveActions(steps []sdk.ActionStep, actions map[string]sdk.V2Action) error {
+	for i := range steps {
+		uses := steps[i].Uses
+		if uses == "" {
+			continue
+		}
+		// Plugins
+		if strings.HasPrefix(uses, "actions/") && len(strings.Split(strings.TrimPrefix(uses, "actions/"), "/")) == 1 {
+			continue
+		}
+
+		var act sdk.V2Action
+		if err := r.readEntity(sdk.EntityTypeAction, strings.TrimPrefix(uses, "actions/"), &act); err != nil {
+			return err
+		}
+		completeName := localActionPrefix + act.Name
+		steps[i].Uses = "actions/" + completeName
+		if _, has := actions[completeName]; has {
+			continue
+		}
+		actions[completeName] = act
+		if err := r.resolveActions(act.Runs.Steps, actions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// workerModelImage returns the docker image of the worker model used by a job.
+func (r repository) workerModelImage(runsOn sdk.V2JobRunsOn) (string, error) {
+	if runsOn.Model == "" {
+		return "", sdk.NewErrorFrom(sdk.ErrInvalidData, "missing worker model")
+	}
+	var model sdk.V2WorkerModel
+	if err := r.readEntity(sdk.EntityTypeWorkerModel, runsOn.Model, &model); err != nil {
+		return "", err
+	}
+	if model.Type != sdk.WorkerModelTypeDocker {
+		return "", sdk.NewErrorFrom(sdk.ErrNotImplemented, "worker model %s of type %s can't be run locally", model.Name, model.Type)
+	}
+	var spec sdk.V2WorkerModelDockerSpec
+	if err := json.Unmarshal(model.Spec, &spec); err != nil {
+		return "", sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to read worker model %s: %v", model.Name, err)
+	}
+	return spec.Image, nil
+}
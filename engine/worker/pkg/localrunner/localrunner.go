@@ -0,0 +1,325 @@
+// Package localrunner executes a workflow as code on the current host, without any CDS API.
+// Actions, job templates and worker models are resolved from the .cds directory of the repository
+// and jobs are run with the worker logic.
+package localrunner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+// Options of a local workflow execution.
+type Options struct {
+	// Directory is the root of the repository that contains the .cds directory.
+	Directory string
+	// Jobs to run. All the jobs of the workflow are run if empty.
+	Jobs   []string
+	Inputs map[string]interface{}
+	// Secrets are set in the vars context. Keys are formatted as <variable_set>.<item>.
+	Secrets map[string]string
+	// Container runs script steps in a docker container using the image of the job worker model.
+	Container bool
+	// Image overrides the image of the worker models. It implies Container.
+	Image      string
+	ProjectKey string
+	Git        sdk.GitContext
+	Output     io.Writer
+}
+
+// JobResult is the result of a job, or of a matrix permutation of a job.
+type JobResult struct {
+	JobID  string                     `json:"job_id" cli:"job_id"`
+	Matrix string                     `json:"matrix,omitempty" cli:"matrix"`
+	Status sdk.V2WorkflowRunJobStatus `json:"status" cli:"status"`
+	Error  string                     `json:"error,omitempty" cli:"error"`
+}
+
+// Run executes the jobs of the workflow, following their dependencies.
+func Run(ctx context.Context, wf sdk.V2Workflow, opts Options) ([]JobResult, error) {
+	// Templates and actions resolution updates jobs, keep the given workflow untouched
+	jobs := make(map[string]sdk.V2Job, len(wf.Jobs))
+	for jobID, j := range wf.Jobs {
+		j.Steps = slices.Clone(j.Steps)
+		jobs[jobID] = j
+	}
+	wf.Jobs = jobs
+	wf.Stages = maps.Clone(wf.Stages)
+	wf.Gates = maps.Clone(wf.Gates)
+
+	r := repository{dir: opts.Directory}
+	if err := r.resolveJobTemplates(ctx, &wf); err != nil {
+		return nil, err
+	}
+	actions := make(map[string]sdk.V2Action)
+	for jobID, j := range wf.Jobs {
+		if err := r.resolveActions(j.Steps, actions); err != nil {
+			return nil, sdk.NewErrorFrom(err, "job %s: %v", jobID, err)
+		}
+	}
+
+	for _, jobID := range opts.Jobs {
+		if _, has := wf.Jobs[jobID]; !has {
+			return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "job %s not found in workflow %s", jobID, wf.Name)
+		}
+	}
+
+	jobIDs, err := sortJobs(wf)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]string, 0, len(opts.Secrets))
+	vars := make(map[string]interface{})
+	for k, v := range opts.Secrets {
+		secrets = append(secrets, v)
+		setName, item, found := strings.Cut(k, ".")
+		if !found {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid secret %s: expected <variable_set>.<item>", k)
+		}
+		set, _ := vars[setName].(map[string]interface{})
+		if set == nil {
+			set = make(map[string]interface{})
+			vars[setName] = set
+		}
+		set[item] = v
+	}
+
+	runContext := sdk.WorkflowRunContext{
+		CDS: sdk.CDSContext{
+			EventName:   sdk.WorkflowHookEventNameManual,
+			ProjectKey:  opts.ProjectKey,
+			RunNumber:   1,
+			RunAttempt:  1,
+			Workflow:    wf.Name,
+			WorkflowRef: opts.Git.Ref,
+			WorkflowSha: opts.Git.Sha,
+		},
+		Git: opts.Git,
+	}
+
+	results := make([]JobResult, 0, len(jobIDs))
+	jobsContext := sdk.JobsResultContext{}
+	for _, jobID := range jobIDs {
+		if len(opts.Jobs) > 0 && !slices.Contains(opts.Jobs, jobID) {
+			continue
+		}
+		jobDef := wf.Jobs[jobID]
+
+		jobContext := buildJobContext(wf, jobID, jobsContext, runContext)
+		jobContext.Inputs = opts.Inputs
+		jobContext.Vars = vars
+		jobContext.Gate = gateDefaultInputs(wf, jobDef)
+		jobContext.CDS.Job = jobID
+		jobContext.CDS.Stage = jobDef.Stage
+		jobContext.Env = make(map[string]string)
+		for k, v := range wf.Env {
+			jobContext.Env[k] = v
+		}
+		for k, v := range jobDef.Env {
+			jobContext.Env[k] = v
+		}
+
+		canRun, err := checkCondition(ctx, jobDef.If, jobContext)
+		if err != nil {
+			return results, sdk.NewErrorFrom(err, "job %s: %v", jobID, err)
+		}
+		if !canRun {
+			fmt.Fprintf(opts.Output, "[%s] job skipped\n", jobID)
+			results = append(results, JobResult{JobID: jobID, Status: sdk.V2WorkflowRunJobStatusSkipped})
+			jobsContext[jobID] = sdk.JobResultContext{Result: sdk.V2WorkflowRunJobStatusSkipped, Outputs: sdk.JobResultOutput{}}
+			continue
+		}
+
+		image := opts.Image
+		if image == "" && opts.Container {
+			image, err = r.workerModelImage(jobDef.RunsOn)
+			if err != nil {
+				return results, sdk.NewErrorFrom(err, "job %s: %v", jobID, err)
+			}
+		}
+
+		permutations, _, err := sdk.GenerateMatrixPermutation(ctx, jobContext, jobDef.Strategy)
+		if err != nil {
+			return results, sdk.NewErrorFrom(err, "job %s: %v", jobID, err)
+		}
+		if len(permutations) == 0 {
+			permutations = []map[string]string{nil}
+		}
+
+		finalResult := sdk.JobResultContext{Outputs: sdk.JobResultOutput{}}
+		for _, matrix := range permutations {
+			jobContext.Matrix = matrix
+			matrixName := matrixString(matrix)
+			name := jobID
+			if matrixName != "" {
+				name = jobID + "(" + matrixName + ")"
+			}
+			fmt.Fprintf(opts.Output, "[%s] job started\n", name)
+			res, outputs, err := internal.RunLocalJob(ctx, internal.LocalJob{
+				JobID:     name,
+				Job:       jobDef,
+				Context:   jobContext,
+				Actions:   actions,
+				Workspace: opts.Directory,
+				Image:     image,
+				Secrets:   secrets,
+				Output:    opts.Output,
+			})
+			if err != nil {
+				return results, err
+			}
+			fmt.Fprintf(opts.Output, "[%s] job ended: %s\n", name, res.Status)
+			results = append(results, JobResult{JobID: jobID, Matrix: matrixName, Status: res.Status, Error: res.Error})
+
+			for k, v := range outputs {
+				finalResult.Outputs[k] = v
+			}
+			if finalResult.Result == "" || res.Status == sdk.V2WorkflowRunJobStatusFail {
+				finalResult.Result = res.Status
+			}
+		}
+		jobsContext[jobID] = finalResult
+	}
+	return results, nil
+}
+
+// sortJobs returns the job identifiers ordered by their dependencies.
+func sortJobs(wf sdk.V2Workflow) ([]string, error) {
+	needs := make(map[string][]string, len(wf.Jobs))
+	for jobID := range wf.Jobs {
+		needs[jobID] = sdk.WorkflowJobNeeds(wf, jobID)
+		for _, n := range needs[jobID] {
+			if _, has := wf.Jobs[n]; !has {
+				return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "job %s: needed job %s not found", jobID, n)
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(wf.Jobs))
+	done := make(map[string]bool, len(wf.Jobs))
+	for len(sorted) < len(wf.Jobs) {
+		ready := make([]string, 0)
+	nextJob:
+		for jobID, jobNeeds := range needs {
+			if done[jobID] {
+				continue
+			}
+			for _, n := range jobNeeds {
+				if !done[n] {
+					continue nextJob
+				}
+			}
+			ready = append(ready, jobID)
+		}
+		if len(ready) == 0 {
+			return nil, sdk.NewErrorFrom(sdk.ErrInvalidData, "cycle detected in jobs dependencies")
+		}
+		sort.Strings(ready)
+		for _, jobID := range ready {
+			done[jobID] = true
+		}
+		sorted = append(sorted, ready...)
+	}
+	return sorted, nil
+}
+
+func buildJobContext(wf sdk.V2Workflow, jobID string, jobsContext sdk.JobsResultContext, runContext sdk.WorkflowRunContext) sdk.WorkflowRunJobsContext {
+	ancestors := sdk.JobsResultContext{}
+	var addAncestors func(id string)
+	addAncestors = func(id string) {
+		for _, n := range sdk.WorkflowJobNeeds(wf, id) {
+			if _, has := ancestors[n]; has {
+				continue
+			}
+			if jobCtx, has := jobsContext[n]; has {
+				ancestors[n] = jobCtx
+			}
+			addAncestors(n)
+		}
+	}
+	addAncestors(jobID)
+
+	needsContext := sdk.NeedsContext{}
+	for _, n := range sdk.WorkflowJobNeeds(wf, jobID) {
+		j, has := jobsContext[n]
+		if !has {
+			continue
+		}
+		needContext := sdk.NeedContext{Result: j.Result, Outputs: j.Outputs}
+		// override result if job has continue-on-error
+		if wf.Jobs[n].ContinueOnError && j.Result == sdk.V2WorkflowRunJobStatusFail {
+			needContext.Result = sdk.V2WorkflowRunJobStatusSuccess
+		}
+		needsContext[n] = needContext
+	}
+
+	return sdk.WorkflowRunJobsContext{
+		WorkflowRunContext: runContext,
+		Jobs:               ancestors,
+		Needs:              needsContext,
+	}
+}
+
+// gateDefaultInputs fills the gate context with the default values of the gate inputs.
+func gateDefaultInputs(wf sdk.V2Workflow, jobDef sdk.V2Job) map[string]interface{} {
+	inputs := make(map[string]interface{})
+	if jobDef.Gate == "" {
+		return inputs
+	}
+	for k, v := range wf.Gates[jobDef.Gate].Inputs {
+		inputs[k] = v.Default
+	}
+	return inputs
+}
+
+func checkCondition(ctx context.Context, condition string, jobContext sdk.WorkflowRunJobsContext) (bool, error) {
+	if condition == "" {
+		condition = "${{success()}}"
+	}
+	if !strings.HasPrefix(condition, "${{") {
+		condition = fmt.Sprintf("${{ %s }}", condition)
+	}
+	ap, err := newActionParser(jobContext)
+	if err != nil {
+		return false, err
+	}
+	booleanResult, err := ap.InterpolateToBool(ctx, condition)
+	if err != nil {
+		return false, sdk.NewErrorFrom(sdk.ErrInvalidData, "unable to parse statement %s into a boolean: %v", condition, err)
+	}
+	return booleanResult, nil
+}
+
+func matrixString(matrix map[string]string) string {
+	keys := make([]string, 0, len(matrix))
+	for k := range matrix {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, k+":"+matrix[k])
+	}
+	return strings.Join(values, ",")
+}
+
+func newActionParser(jobContext sdk.WorkflowRunJobsContext) (*sdk.ActionParser, error) {
+	bts, err := json.Marshal(jobContext)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	var mapContexts map[string]interface{}
+	if err := json.Unmarshal(bts, &mapContexts); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return sdk.NewActionParser(mapContexts, sdk.DefaultFuncs), nil
+}
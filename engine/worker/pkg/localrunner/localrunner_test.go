@@ -0,0 +1,107 @@
+package localrunner
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func writeFile(t *testing.T, dir, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, ".cds/actions/greet.yml", `name: greet
+inputs:
+  who:
+    default: world
+runs:
+  steps:
+  - run: echo "hello ${{ inputs.who }}"
+`)
+	writeFile(t, dir, ".cds/workflow-templates/tests.yml", `name: tests
+spec: |-
+  jobs:
+    unit:
+      steps:
+      - run: echo unit
+    integration:
+      needs: [unit]
+      steps:
+      - run: echo integration
+`)
+	writeFile(t, dir, ".cds/workflows/build.yml", `name: build
+jobs:
+  compile:
+    strategy:
+      matrix:
+        os: [linux, darwin]
+    steps:
+    - run: echo "compile ${{ matrix.os }}"
+    outputs:
+      version:
+        value: "1.0.${{ vars.myset.build }}"
+  tests:
+    needs: [compile]
+    from: tests
+  publish:
+    needs: [tests]
+    if: needs.integration.result == 'Success'
+    steps:
+    - uses: .cds/actions/greet.yml
+      with:
+        who: ${{ jobs.compile.outputs.version }}
+  skipped:
+    if: cds.workflow == 'other'
+    steps:
+    - run: exit 1
+`)
+
+	ctx := context.TODO()
+	wf, err := ReadWorkflow(ctx, dir, filepath.Join(dir, ".cds/workflows/build.yml"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	results, err := Run(ctx, *wf, Options{
+		Directory: dir,
+		Secrets:   map[string]string{"myset.build": "424242"},
+		Output:    &out,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []JobResult{
+		{JobID: "compile", Matrix: "os:linux", Status: sdk.V2WorkflowRunJobStatusSuccess},
+		{JobID: "compile", Matrix: "os:darwin", Status: sdk.V2WorkflowRunJobStatusSuccess},
+		{JobID: "skipped", Status: sdk.V2WorkflowRunJobStatusSkipped},
+		{JobID: "unit", Status: sdk.V2WorkflowRunJobStatusSuccess},
+		{JobID: "integration", Status: sdk.V2WorkflowRunJobStatusSuccess},
+		{JobID: "publish", Status: sdk.V2WorkflowRunJobStatusSuccess},
+	}, results)
+	require.Contains(t, out.String(), "[compile(os:darwin)] compile darwin")
+	require.Contains(t, out.String(), "[publish] hello 1.0.**********")
+
+	// Run only selected jobs
+	out.Reset()
+	results, err = Run(ctx, *wf, Options{Directory: dir, Jobs: []string{"unit"}, Output: &out})
+	require.NoError(t, err)
+	require.Equal(t, []JobResult{{JobID: "unit", Status: sdk.V2WorkflowRunJobStatusSuccess}}, results)
+
+	_, err = Run(ctx, *wf, Options{Directory: dir, Jobs: []string{"unknown"}, Output: &out})
+	require.Error(t, err)
+}
+
+func TestSortJobsCycle(t *testing.T) {
+	_, err := sortJobs(sdk.V2Workflow{Jobs: map[string]sdk.V2Job{
+		"a": {Needs: []string{"b"}},
+		"b": {Needs: []string{"a"}},
+	}})
+	require.Error(t, err)
+}
@@ -142,6 +142,55 @@ func V2_contextHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
 	}
 }
 
+func V2_annotationsHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, r, sdk.ErrMethodNotAllowed)
+			return
+		}
+		btes, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+		defer r.Body.Close()
+
+		var annotations sdk.VCSAnnotations
+		if err := sdk.JSONUnmarshal(btes, &annotations); err != nil {
+			writeError(w, r, sdk.NewError(sdk.ErrWrongRequest, err))
+			return
+		}
+
+		jobRun := wk.V2GetJobRun(r.Context())
+		if err := wk.ClientV2().V2QueuePushJobAnnotations(ctx, jobRun.Region, jobRun.ID, annotations); err != nil {
+			// Annotations must not break a job when the vcs server can't display them
+			if sdk.ErrorIs(err, sdk.ErrNotImplemented) {
+				wk.SendLog(ctx, LevelWarn, sdk.ExtractHTTPError(err).Error())
+				writeJSON(w, nil, http.StatusNoContent)
+				return
+			}
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, nil, http.StatusNoContent)
+	}
+}
+
+func V2_breakpointHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, r, sdk.ErrMethodNotAllowed)
+			return
+		}
+		// Blocks until the end of the debug session
+		if err := wk.V2DebugBreakpoint(r.Context()); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, nil, http.StatusNoContent)
+	}
+}
+
 func V2_runResultHandler(ctx context.Context, wk Runtime) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
@@ -404,6 +404,20 @@ func (mr *MockRuntimeMockRecorder) V2AddRunResult(ctx, req interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2AddRunResult", reflect.TypeOf((*MockRuntime)(nil).V2AddRunResult), ctx, req)
 }
 
+// V2DebugBreakpoint mocks base method.
+func (m *MockRuntime) V2DebugBreakpoint(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2DebugBreakpoint", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2DebugBreakpoint indicates an expected call of V2DebugBreakpoint.
+func (mr *MockRuntimeMockRecorder) V2DebugBreakpoint(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2DebugBreakpoint", reflect.TypeOf((*MockRuntime)(nil).V2DebugBreakpoint), ctx)
+}
+
 // V2GetCacheLink mocks base method.
 func (m *MockRuntime) V2GetCacheLink(ctx context.Context, cacheKey string) (*sdk.CDNItemLinks, error) {
 	m.ctrl.T.Helper()
@@ -38,6 +38,7 @@ type V2UpdateResultResponse struct {
 type V2FilterRunResult struct {
 	Pattern string
 	Type    []sdk.V2WorkflowRunResultType
+	Run     string // Optional reference to another workflow run: trigger or [[[project/]vcs/]org/repo/]workflow[:number]
 }
 
 type V2WorkerConfig struct {
@@ -173,6 +174,7 @@ type Runtime interface {
 	V2GetCacheSignature(ctx context.Context, cacheKey string) (*CDNSignature, error)
 	V2GetCacheLink(ctx context.Context, cacheKey string) (*sdk.CDNItemLinks, error)
 	V2GetProjectKey(ctx context.Context, keyName string, clear bool) (*sdk.ProjectKey, error)
+	V2DebugBreakpoint(ctx context.Context) error
 }
 
 func JobID(ctx context.Context) (int64, error) {
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	annotateName       string
+	annotateTitle      string
+	annotateSummary    string
+	annotateFormat     string
+	annotatePathPrefix string
+	annotateFile       string
+	annotateLine       int
+	annotateEndLine    int
+	annotateLevel      string
+)
+
+func CmdAnnotate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "annotate",
+		Short: "worker annotate [--format line|checkstyle|junit] [<file>...] | --file <path> --line <line> <message>",
+		Long: `
+Inside a job, attach messages to lines of the repository files. They are displayed inline by the vcs server:
+check runs on GitHub, commit comments on GitLab, pull-request reviews on Gitea and Forgejo, code insights on Bitbucket Server.
+
+Annotations can be read from linter or test reports:
+
+	# golangci-lint run --out-format checkstyle > lint.xml
+	worker annotate --name lint --format checkstyle lint.xml
+
+	# go vet ./... 2>&1 | worker annotate --name vet --format line
+
+Or created one by one:
+
+	worker annotate --file src/main.go --line 12 --level failure "this function is deprecated"
+`,
+		RunE: annotateCmd,
+	}
+	c.Flags().StringVar(&annotateName, "name", "annotations", "Name of the annotations set, sending a set with the same name replaces the previous one when the vcs server allows it")
+	c.Flags().StringVar(&annotateTitle, "title", "", "Title of the annotations set")
+	c.Flags().StringVar(&annotateSummary, "summary", "", "Summary of the annotations set")
+	c.Flags().StringVar(&annotateFormat, "format", sdk.AnnotationsFormatLine, "Format of the reports: line, checkstyle or junit")
+	c.Flags().StringVar(&annotatePathPrefix, "path-prefix", "", "Directory of the reported files, relative to the repository root")
+	c.Flags().StringVar(&annotateFile, "file", "", "File of a single annotation")
+	c.Flags().IntVar(&annotateLine, "line", 1, "Line of a single annotation")
+	c.Flags().IntVar(&annotateEndLine, "end-line", 0, "End line of a single annotation")
+	c.Flags().StringVar(&annotateLevel, "level", string(sdk.VCSAnnotationLevelWarning), "Level of a single annotation: notice, warning or failure")
+	return c
+}
+
+func annotateCmd(cmd *cobra.Command, args []string) error {
+	var annotations []sdk.VCSAnnotation
+
+	if annotateFile != "" {
+		if len(args) != 1 {
+			sdk.Exit("wrong usage: worker annotate --file <path> --line <line> <message>")
+		}
+		annotations = append(annotations, sdk.VCSAnnotation{
+			Path:      annotateFile,
+			StartLine: annotateLine,
+			EndLine:   annotateEndLine,
+			Level:     sdk.VCSAnnotationLevel(annotateLevel),
+			Message:   args[0],
+		})
+	} else if len(args) == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			sdk.Exit("unable to read stdin: %v", err)
+		}
+		as, err := sdk.ParseAnnotations(annotateFormat, data)
+		if err != nil {
+			sdk.Exit("%v", err)
+		}
+		annotations = append(annotations, as...)
+	} else {
+		for _, arg := range args {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return err
+			}
+			for _, f := range matches {
+				data, err := os.ReadFile(f)
+				if err != nil {
+					sdk.Exit("unable to read file %s: %v", f, err)
+				}
+				as, err := sdk.ParseAnnotations(annotateFormat, data)
+				if err != nil {
+					sdk.Exit("file %s: %v", f, err)
+				}
+				annotations = append(annotations, as...)
+			}
+		}
+	}
+
+	if err := sendAnnotations(annotateName, annotateTitle, annotateSummary, annotatePathPrefix, annotations); err != nil {
+		sdk.Exit("%v", err)
+	}
+	fmt.Printf("%d annotation(s) sent\n", len(annotations))
+	return nil
+}
+
+// sendAnnotations sends the annotations to the worker, paths are made relative to the repository root
+func sendAnnotations(name, title, summary, pathPrefix string, annotations []sdk.VCSAnnotation) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	as := sdk.VCSAnnotations{
+		Key:         name,
+		Title:       title,
+		Summary:     summary,
+		Annotations: make([]sdk.VCSAnnotation, 0, len(annotations)),
+	}
+	for _, a := range annotations {
+		if filepath.IsAbs(a.Path) {
+			rel, err := filepath.Rel(wd, a.Path)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+			a.Path = rel
+		}
+		if pathPrefix != "" {
+			a.Path = filepath.Join(pathPrefix, a.Path)
+		}
+		a.Path = filepath.ToSlash(a.Path)
+		as.Annotations = append(as.Annotations, a)
+	}
+	if err := as.Lint(); err != nil {
+		return err
+	}
+
+	req := MustNewWorkerHTTPRequest(http.MethodPost, "/v2/annotations", as)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	return DoHTTPRequest(ctx, req, nil)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func CmdBreakpoint() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "breakpoint",
+		Short: "worker breakpoint",
+		Long: `Inside a job restarted in debug mode, pause the job until a user resumes it.
+
+While the job is paused, a shell in the job workspace can be opened with:
+
+	cdsctl experimental workflow jobs attach <proj_key> <workflow_run_id> <job_run_id>
+
+The command does nothing when the job is not running in debug mode.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := MustNewWorkerHTTPRequest(http.MethodPost, "/v2/breakpoint", nil)
+			// The request lasts until the end of the debug session
+			if err := DoHTTPRequest(context.Background(), req, nil); err != nil {
+				sdk.Exit(err.Error())
+			}
+			return nil
+		},
+	}
+	return c
+}
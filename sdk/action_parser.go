@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -122,8 +123,8 @@ func (a *ActionParser) parse(ctx context.Context, elt string) (interface{}, erro
 
 	for i := 0; i < tree.GetChildCount(); i++ {
 		switch t := tree.GetChild(i).(type) {
-		case *parser.OrExpressionContext:
-			return a.parseOrExpressionContext(ctx, t)
+		case *parser.TernaryExpressionContext:
+			return a.parseTernaryExpressionContext(ctx, t)
 		case *parser.ExpressionStartContext, *parser.ExpressionEndContext:
 			continue
 		default:
@@ -133,6 +134,30 @@ func (a *ActionParser) parse(ctx context.Context, elt string) (interface{}, erro
 	return "", NewErrorFrom(ErrInvalidData, "No expression found. Gor [%s]", elt)
 }
 
+func (a *ActionParser) parseTernaryExpressionContext(ctx context.Context, exp *parser.TernaryExpressionContext) (interface{}, error) {
+	log.Debug(ctx, "Ternary expression detected: %s", exp.GetText())
+	condition, err := a.parseOrExpressionContext(ctx, exp.OrExpression().(*parser.OrExpressionContext))
+	if err != nil {
+		return nil, err
+	}
+	branches := exp.AllTernaryExpression()
+	if exp.QUESTION() == nil {
+		return condition, nil
+	}
+	if len(branches) != 2 {
+		return nil, NewErrorFrom(ErrInvalidData, "invalid ternary expression: %s", exp.GetText())
+	}
+	conditionB, ok := condition.(bool)
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "condition [%s] of a ternary expression must return a boolean value. Got [%v]", exp.OrExpression().GetText(), condition)
+	}
+	// Only the selected branch is evaluated
+	if conditionB {
+		return a.parseTernaryExpressionContext(ctx, branches[0].(*parser.TernaryExpressionContext))
+	}
+	return a.parseTernaryExpressionContext(ctx, branches[1].(*parser.TernaryExpressionContext))
+}
+
 func (a *ActionParser) parseOrExpressionContext(ctx context.Context, exp *parser.OrExpressionContext) (interface{}, error) {
 	log.Debug(ctx, "Or expression detected: %s", exp.GetText())
 	operands := make([]interface{}, 0, exp.GetChildCount())
@@ -223,8 +248,8 @@ func (a *ActionParser) parseEqualityExpressionContext(ctx context.Context, exp *
 	var operator string
 	for i := 0; i < exp.GetChildCount(); i++ {
 		switch t := exp.GetChild(i).(type) {
-		case *parser.PrimaryExpressionContext:
-			result, err := a.parsePrimaryExpressionContext(ctx, t)
+		case *parser.AdditiveExpressionContext:
+			result, err := a.parseAdditiveExpressionContext(ctx, t)
 			if err != nil {
 				return "", err
 			}
@@ -245,6 +270,62 @@ func (a *ActionParser) parseEqualityExpressionContext(ctx context.Context, exp *
 	return "", NewErrorFrom(ErrInvalidData, "wrong equality expression. Got [%s]", exp.GetText())
 }
 
+func (a *ActionParser) parseAdditiveExpressionContext(ctx context.Context, exp *parser.AdditiveExpressionContext) (interface{}, error) {
+	log.Debug(ctx, "Additive expression detected: %s", exp.GetText())
+	var result interface{}
+	var operator string
+	for i := 0; i < exp.GetChildCount(); i++ {
+		switch t := exp.GetChild(i).(type) {
+		case *parser.MultiplicativeExpressionContext:
+			operand, err := a.parseMultiplicativeExpressionContext(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+			if operator == "" {
+				result = operand
+				continue
+			}
+			result, err = a.arithmetic(result, operand, operator)
+			if err != nil {
+				return nil, err
+			}
+		case *parser.AdditiveOperatorContext:
+			operator = t.GetText()
+		default:
+			return nil, NewErrorFrom(ErrInvalidData, "unknown type %T in AdditiveExpression", t)
+		}
+	}
+	return result, nil
+}
+
+func (a *ActionParser) parseMultiplicativeExpressionContext(ctx context.Context, exp *parser.MultiplicativeExpressionContext) (interface{}, error) {
+	log.Debug(ctx, "Multiplicative expression detected: %s", exp.GetText())
+	var result interface{}
+	var operator string
+	for i := 0; i < exp.GetChildCount(); i++ {
+		switch t := exp.GetChild(i).(type) {
+		case *parser.PrimaryExpressionContext:
+			operand, err := a.parsePrimaryExpressionContext(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+			if operator == "" {
+				result = operand
+				continue
+			}
+			result, err = a.arithmetic(result, operand, operator)
+			if err != nil {
+				return nil, err
+			}
+		case *parser.MultiplicativeOperatorContext:
+			operator = t.GetText()
+		default:
+			return nil, NewErrorFrom(ErrInvalidData, "unknown type %T in MultiplicativeExpression", t)
+		}
+	}
+	return result, nil
+}
+
 func (a *ActionParser) parsePrimaryExpressionContext(ctx context.Context, exp *parser.PrimaryExpressionContext) (interface{}, error) {
 	log.Debug(ctx, "Primary expression detected: %s", exp.GetText())
 	if exp.GetChildCount() != 1 {
@@ -264,6 +345,8 @@ func (a *ActionParser) parsePrimaryExpressionContext(ctx context.Context, exp *p
 		return a.parseTermExpressionContext(ctx, t)
 	case *parser.NotExpressionContext:
 		return a.parseNotExpression(ctx, t)
+	case *parser.BooleanExpressionContext:
+		return a.parseBooleanExpression(ctx, t)
 	default:
 		return nil, NewErrorFrom(ErrInvalidData, "unknown type %T in PrimaryExpression", t)
 	}
@@ -348,29 +431,11 @@ func (a *ActionParser) parseFunctionCallArgumentsContext(ctx context.Context, ex
 	}
 
 	switch t := exp.GetChild(0).(type) {
-	case *parser.VariableContextContext:
-		result, err := a.parseVariableContextContext(ctx, t)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *parser.FunctionCallContext:
-		return a.parseFunctionCallContext(ctx, t)
-	case *parser.StringExpressionContext:
-		return a.trimString(t.GetText()), nil
-	case *parser.NumberExpressionContext:
-		return a.parseNumberExpression(ctx, t)
-	case *parser.BooleanExpressionContext:
-		s := t.GetText()
-		b, err := strconv.ParseBool(s)
-		if err != nil {
-			return nil, NewErrorFrom(ErrInvalidData, "unable to parse boolean %s", s)
-		}
-		return b, nil
+	case *parser.TernaryExpressionContext:
+		return a.parseTernaryExpressionContext(ctx, t)
 	default:
 		return nil, NewErrorFrom(ErrInvalidData, "unknown type %T in FunctionCall Arguments", t)
 	}
-
 }
 
 func (a *ActionParser) parseNumberExpression(ctx context.Context, t *parser.NumberExpressionContext) (interface{}, error) {
@@ -386,26 +451,27 @@ func (a *ActionParser) parseNumberExpression(ctx context.Context, t *parser.Numb
 	return floatValue, nil
 }
 
-func (a *ActionParser) parseTermExpressionContext(ctx context.Context, exp *parser.TermExpressionContext) (bool, error) {
+func (a *ActionParser) parseBooleanExpression(ctx context.Context, t *parser.BooleanExpressionContext) (bool, error) {
+	log.Debug(ctx, "Boolean expression detected: %s", t.GetText())
+	b, err := strconv.ParseBool(t.GetText())
+	if err != nil {
+		return false, NewErrorFrom(ErrInvalidData, "unable to parse boolean %s", t.GetText())
+	}
+	return b, nil
+}
+
+func (a *ActionParser) parseTermExpressionContext(ctx context.Context, exp *parser.TermExpressionContext) (interface{}, error) {
 	log.Debug(ctx, "Term expression detected: %s", exp.GetText())
 	for i := 0; i < exp.GetChildCount(); i++ {
 		switch t := exp.GetChild(i).(type) {
 		case *antlr.TerminalNodeImpl:
-		case *parser.OrExpressionContext:
-			result, err := a.parseOrExpressionContext(ctx, t)
-			if err != nil {
-				return false, err
-			}
-			b, err := strconv.ParseBool(fmt.Sprintf("%v", result))
-			if err != nil {
-				return false, NewErrorFrom(ErrInvalidData, "%s is not a boolean", result)
-			}
-			return b, nil
+		case *parser.TernaryExpressionContext:
+			return a.parseTernaryExpressionContext(ctx, t)
 		default:
-			return false, NewErrorFrom(ErrInvalidData, "unknown type %T in TermExpression", t)
+			return nil, NewErrorFrom(ErrInvalidData, "unknown type %T in TermExpression", t)
 		}
 	}
-	return false, nil
+	return nil, NewErrorFrom(ErrInvalidData, "invalid empty term expression. Got [%s]", exp.GetText())
 }
 
 func (a *ActionParser) parseVariableContextContext(ctx context.Context, exp *parser.VariableContextContext) (interface{}, error) {
@@ -664,6 +730,97 @@ func (a ActionParser) getPrimitiveType(operand interface{}) interface{} {
 	}
 }
 
+// toNumber returns the int or float64 value of the given operand, numeric strings are converted
+func (a *ActionParser) toNumber(operand interface{}) (interface{}, error) {
+	switch n := operand.(type) {
+	case int, float64:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return int(i), nil
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, NewErrorFrom(ErrInvalidData, "unable to cast %s into a number", n)
+		}
+		return f, nil
+	case string:
+		if i, err := strconv.Atoi(n); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return nil, NewErrorFrom(ErrInvalidData, "%q must be a float or an int", n)
+		}
+		return f, nil
+	default:
+		return nil, NewErrorFrom(ErrInvalidData, "%v must be a float or an int, got [%T]", operand, operand)
+	}
+}
+
+func (a *ActionParser) arithmetic(operand0, operand1 interface{}, operator string) (interface{}, error) {
+	n0, err := a.toNumber(operand0)
+	if err != nil {
+		return nil, err
+	}
+	n1, err := a.toNumber(operand1)
+	if err != nil {
+		return nil, err
+	}
+
+	i0, isInt0 := n0.(int)
+	i1, isInt1 := n1.(int)
+	if isInt0 && isInt1 {
+		switch operator {
+		case "+":
+			return i0 + i1, nil
+		case "-":
+			return i0 - i1, nil
+		case "*":
+			return i0 * i1, nil
+		case "/", "%":
+			if i1 == 0 {
+				return nil, NewErrorFrom(ErrInvalidData, "division by zero")
+			}
+			if operator == "/" {
+				return i0 / i1, nil
+			}
+			return i0 % i1, nil
+		default:
+			return nil, NewErrorFrom(ErrInvalidData, "unknown arithmetic operator %s", operator)
+		}
+	}
+
+	f0, f1 := a.toFloat(n0), a.toFloat(n1)
+	switch operator {
+	case "+":
+		return f0 + f1, nil
+	case "-":
+		return f0 - f1, nil
+	case "*":
+		return f0 * f1, nil
+	case "/", "%":
+		if f1 == 0 {
+			return nil, NewErrorFrom(ErrInvalidData, "division by zero")
+		}
+		if operator == "/" {
+			return f0 / f1, nil
+		}
+		return math.Mod(f0, f1), nil
+	default:
+		return nil, NewErrorFrom(ErrInvalidData, "unknown arithmetic operator %s", operator)
+	}
+}
+
+func (a *ActionParser) toFloat(n interface{}) float64 {
+	if i, ok := n.(int); ok {
+		return float64(i)
+	}
+	return n.(float64)
+}
+
 func (a *ActionParser) and(operands []interface{}) (bool, error) {
 	if len(operands) == 0 {
 		return false, nil
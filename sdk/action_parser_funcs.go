@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/ovh/cds/sdk/glob"
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
@@ -25,37 +26,46 @@ import (
 
 var (
 	DefaultFuncs = map[string]ActionFunc{
-		"contains":     contains,
-		"startsWith":   startsWith,
-		"endsWith":     endsWith,
-		"format":       format,
-		"join":         join,
-		"toJSON":       toJSON,
-		"fromJSON":     fromJSON,
-		"hashFiles":    hashFiles,
-		"success":      success,
-		"always":       always,
-		"cancelled":    cancelled,
-		"stopped":      stopped,
-		"failure":      failure,
-		"result":       result,
-		"toLower":      newStringActionFunc("toLower", nilerr(strings.ToLower)),
-		"toUpper":      newStringActionFunc("toUpper", nilerr(strings.ToUpper)),
-		"toTitle":      newStringActionFunc("toTitle", nilerr(strings.ToTitle)),
-		"title":        newStringActionFunc("title", nilerr(strings.Title)),
-		"b64enc":       newStringActionFunc("b64enc", nilerr(base64encode)),
-		"b64dec":       newStringActionFunc("b64dec", base64decode),
-		"b32enc":       newStringActionFunc("b32enc", nilerr(base32encode)),
-		"b32dec":       newStringActionFunc("b32dec", base32decode),
-		"trimAll":      newStringStringActionFunc("trimAll", strings.Trim),
-		"trimPrefix":   newStringStringActionFunc("trimPrefix", strings.TrimPrefix),
-		"trimSuffix":   newStringStringActionFunc("trimSuffix", strings.TrimSuffix),
-		"toArray":      toArray,
-		"match":        match,
-		"replace":      replace,
-		"contextValue": contextValue,
-		"default":      dfault,
-		"coalesce":     coalesce,
+		"contains":      contains,
+		"startsWith":    startsWith,
+		"endsWith":      endsWith,
+		"format":        format,
+		"join":          join,
+		"toJSON":        toJSON,
+		"fromJSON":      fromJSON,
+		"hashFiles":     hashFiles,
+		"success":       success,
+		"always":        always,
+		"cancelled":     cancelled,
+		"stopped":       stopped,
+		"failure":       failure,
+		"result":        result,
+		"toLower":       newStringActionFunc("toLower", nilerr(strings.ToLower)),
+		"toUpper":       newStringActionFunc("toUpper", nilerr(strings.ToUpper)),
+		"toTitle":       newStringActionFunc("toTitle", nilerr(strings.ToTitle)),
+		"title":         newStringActionFunc("title", nilerr(strings.Title)),
+		"b64enc":        newStringActionFunc("b64enc", nilerr(base64encode)),
+		"b64dec":        newStringActionFunc("b64dec", base64decode),
+		"b32enc":        newStringActionFunc("b32enc", nilerr(base32encode)),
+		"b32dec":        newStringActionFunc("b32dec", base32decode),
+		"trimAll":       newStringStringActionFunc("trimAll", strings.Trim),
+		"trimPrefix":    newStringStringActionFunc("trimPrefix", strings.TrimPrefix),
+		"trimSuffix":    newStringStringActionFunc("trimSuffix", strings.TrimSuffix),
+		"toArray":       toArray,
+		"match":         match,
+		"replace":       replace,
+		"contextValue":  contextValue,
+		"default":       dfault,
+		"coalesce":      coalesce,
+		"if":            iif,
+		"filter":        filter,
+		"map":           mapProperty,
+		"length":        length,
+		"keys":          keys,
+		"unique":        unique,
+		"sort":          sortArray,
+		"semverCompare": semverCompare,
+		"semverBump":    semverBump,
 	}
 )
 
@@ -87,6 +97,237 @@ func coalesce(_ context.Context, _ *ActionParser, inputs ...interface{}) (interf
 	return nil, nil
 }
 
+// if(condition, valueIfTrue, valueIfFalse)
+func iif(_ context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
+	if len(inputs) != 3 {
+		return nil, NewErrorFrom(ErrInvalidData, "if: wrong number of arguments to call if(condition, valueIfTrue, valueIfFalse)")
+	}
+	condition, ok := inputs[0].(bool)
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "if: condition argument must be a boolean")
+	}
+	if condition {
+		return inputs[1], nil
+	}
+	return inputs[2], nil
+}
+
+// toSlice returns the items of an array or a slice.
+func toSlice(input interface{}) ([]interface{}, bool) {
+	if items, ok := input.([]interface{}); ok {
+		return items, true
+	}
+	val := reflect.ValueOf(input)
+	if val.Kind() != reflect.Array && val.Kind() != reflect.Slice {
+		return nil, false
+	}
+	items := make([]interface{}, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		items = append(items, val.Index(i).Interface())
+	}
+	return items, true
+}
+
+// filter(array, value) or filter(array, property, value)
+func filter(ctx context.Context, a *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: filter with args: %v", inputs)
+	if len(inputs) != 2 && len(inputs) != 3 {
+		return nil, NewErrorFrom(ErrInvalidData, "filter: wrong number of arguments to call filter(array, value) or filter(array, property, value)")
+	}
+	items, ok := toSlice(inputs[0])
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "filter: first argument must be an array")
+	}
+	var property string
+	value := inputs[1]
+	if len(inputs) == 3 {
+		property, ok = inputs[1].(string)
+		if !ok {
+			return nil, NewErrorFrom(ErrInvalidData, "filter: property argument must be a string")
+		}
+		value = inputs[2]
+	}
+
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		itemValue := item
+		if property != "" {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			itemValue = m[property]
+		}
+		if a.getPrimitiveType(itemValue) == a.getPrimitiveType(value) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// map(array, property)
+func mapProperty(ctx context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: map with args: %v", inputs)
+	if len(inputs) != 2 {
+		return nil, NewErrorFrom(ErrInvalidData, "map: wrong number of arguments to call map(array, property)")
+	}
+	items, ok := toSlice(inputs[0])
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "map: first argument must be an array")
+	}
+	property, ok := inputs[1].(string)
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "map: property argument must be a string")
+	}
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, NewErrorFrom(ErrInvalidData, "map: array items must be objects, got %T", item)
+		}
+		result = append(result, m[property])
+	}
+	return result, nil
+}
+
+func length(ctx context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: length with args: %v", inputs)
+	if len(inputs) != 1 {
+		return nil, NewErrorFrom(ErrInvalidData, "length: you must have one argument")
+	}
+	if inputs[0] == nil {
+		return 0, nil
+	}
+	val := reflect.ValueOf(inputs[0])
+	switch val.Kind() {
+	case reflect.String, reflect.Array, reflect.Slice, reflect.Map:
+		return val.Len(), nil
+	default:
+		return nil, NewErrorFrom(ErrInvalidData, "length: argument must be a string, an array or an object")
+	}
+}
+
+func keys(ctx context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: keys with args: %v", inputs)
+	if len(inputs) != 1 {
+		return nil, NewErrorFrom(ErrInvalidData, "keys: you must have one argument")
+	}
+	val := reflect.ValueOf(inputs[0])
+	if val.Kind() != reflect.Map {
+		return nil, NewErrorFrom(ErrInvalidData, "keys: argument must be an object")
+	}
+	mapKeys := make([]string, 0, val.Len())
+	for _, k := range val.MapKeys() {
+		mapKeys = append(mapKeys, fmt.Sprintf("%v", k.Interface()))
+	}
+	sort.Strings(mapKeys)
+	result := make([]interface{}, 0, len(mapKeys))
+	for _, k := range mapKeys {
+		result = append(result, k)
+	}
+	return result, nil
+}
+
+// unique(array) removes duplicated items, keeping the first occurrence.
+func unique(ctx context.Context, a *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: unique with args: %v", inputs)
+	if len(inputs) != 1 {
+		return nil, NewErrorFrom(ErrInvalidData, "unique: you must have one argument")
+	}
+	items, ok := toSlice(inputs[0])
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "unique: argument must be an array")
+	}
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		found := false
+		for _, r := range result {
+			if reflect.DeepEqual(a.getPrimitiveType(r), a.getPrimitiveType(item)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// sort(array) sorts numbers in numeric order and other values in lexical order.
+func sortArray(ctx context.Context, a *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: sort with args: %v", inputs)
+	if len(inputs) != 1 {
+		return nil, NewErrorFrom(ErrInvalidData, "sort: you must have one argument")
+	}
+	items, ok := toSlice(inputs[0])
+	if !ok {
+		return nil, NewErrorFrom(ErrInvalidData, "sort: argument must be an array")
+	}
+	result := make([]interface{}, len(items))
+	copy(result, items)
+
+	numbers := true
+	for _, item := range result {
+		if _, isFloat := a.getPrimitiveType(item).(float64); !isFloat {
+			numbers = false
+			break
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if numbers {
+			return a.getPrimitiveType(result[i]).(float64) < a.getPrimitiveType(result[j]).(float64)
+		}
+		return fmt.Sprintf("%v", result[i]) < fmt.Sprintf("%v", result[j])
+	})
+	return result, nil
+}
+
+// semverCompare(version1, version2) returns -1, 0 or 1
+func semverCompare(ctx context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: semverCompare with args: %v", inputs)
+	if len(inputs) != 2 {
+		return nil, NewErrorFrom(ErrInvalidData, "semverCompare: wrong number of arguments to call semverCompare(version1, version2)")
+	}
+	v1, err := semver.NewVersion(fmt.Sprintf("%v", inputs[0]))
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "semverCompare: invalid version %v: %v", inputs[0], err)
+	}
+	v2, err := semver.NewVersion(fmt.Sprintf("%v", inputs[1]))
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "semverCompare: invalid version %v: %v", inputs[1], err)
+	}
+	return v1.Compare(v2), nil
+}
+
+// semverBump(version, 'major'|'minor'|'patch')
+func semverBump(ctx context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
+	log.Debug(ctx, "function: semverBump with args: %v", inputs)
+	if len(inputs) != 2 {
+		return nil, NewErrorFrom(ErrInvalidData, "semverBump: wrong number of arguments to call semverBump(version, level)")
+	}
+	version := fmt.Sprintf("%v", inputs[0])
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, NewErrorFrom(ErrInvalidData, "semverBump: invalid version %s: %v", version, err)
+	}
+	var bumped semver.Version
+	switch inputs[1] {
+	case "major":
+		bumped = v.IncMajor()
+	case "minor":
+		bumped = v.IncMinor()
+	case "patch":
+		bumped = v.IncPatch()
+	default:
+		return nil, NewErrorFrom(ErrInvalidData, "semverBump: level must be major, minor or patch, got %v", inputs[1])
+	}
+	if strings.HasPrefix(version, "v") {
+		return "v" + bumped.String(), nil
+	}
+	return bumped.String(), nil
+}
+
 func replace(_ context.Context, _ *ActionParser, inputs ...interface{}) (interface{}, error) {
 	if len(inputs) != 3 && len(inputs) != 4 {
 		return nil, NewErrorFrom(ErrInvalidData, "replace: wrong number of arguments")
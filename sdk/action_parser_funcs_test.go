@@ -485,3 +485,78 @@ func Test_Coalesce(t *testing.T) {
 		}
 	})
 }
+
+func TestListFuncs(t *testing.T) {
+	ap := NewActionParser(map[string]interface{}{
+		"matrix": map[string]interface{}{
+			"os":   []interface{}{"linux", "windows", "linux", "darwin"},
+			"nums": []interface{}{10, 2, 33, 2},
+		},
+		"jobs": map[string]interface{}{
+			"build": map[string]interface{}{"result": "Success"},
+			"test":  map[string]interface{}{"result": "Failure"},
+			"lint":  map[string]interface{}{"result": "Success"},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "enabled": true},
+			map[string]interface{}{"name": "b", "enabled": false},
+			map[string]interface{}{"name": "c", "enabled": true},
+		},
+	}, DefaultFuncs)
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{input: "${{ length(matrix.os) }}", expected: 4},
+		{input: "${{ length('foo') }}", expected: 3},
+		{input: "${{ unique(matrix.os) }}", expected: []interface{}{"linux", "windows", "darwin"}},
+		{input: "${{ sort(unique(matrix.os)) }}", expected: []interface{}{"darwin", "linux", "windows"}},
+		{input: "${{ sort(matrix.nums) }}", expected: []interface{}{2, 2, 10, 33}},
+		{input: "${{ keys(jobs) }}", expected: []interface{}{"build", "lint", "test"}},
+		{input: "${{ filter(matrix.os, 'linux') }}", expected: []interface{}{"linux", "linux"}},
+		{input: "${{ map(filter(items, 'enabled', true), 'name') }}", expected: []interface{}{"a", "c"}},
+		{input: "${{ length(filter(items.*.enabled, true)) == length(items) }}", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ap.Interpolate(context.TODO(), tt.input)
+			require.NoError(t, err)
+			if s, ok := tt.expected.([]interface{}); ok {
+				require.Equal(t, s, result)
+				return
+			}
+			require.Equal(t, fmt.Sprintf("%v", tt.expected), result)
+		})
+	}
+}
+
+func TestSemverFuncs(t *testing.T) {
+	tests := []struct {
+		input        string
+		expected     string
+		containError string
+	}{
+		{input: "${{ semverCompare('1.2.3', '1.10.0') }}", expected: "-1"},
+		{input: "${{ semverCompare('v2.0.0', '2.0.0') }}", expected: "0"},
+		{input: "${{ semverCompare('2.0.0', '2.0.0-beta.1') > 0 }}", expected: "true"},
+		{input: "${{ semverBump('1.2.3', 'major') }}", expected: "2.0.0"},
+		{input: "${{ semverBump('v1.2.3', 'minor') }}", expected: "v1.3.0"},
+		{input: "${{ semverBump('1.2.3', 'patch') }}", expected: "1.2.4"},
+		{input: "${{ semverBump('1.2.3', 'foo') }}", containError: "level must be major, minor or patch"},
+		{input: "${{ semverCompare('foo', '1.0.0') }}", containError: "invalid version foo"},
+	}
+	ap := NewActionParser(nil, DefaultFuncs)
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ap.InterpolateToString(context.TODO(), tt.input)
+			if tt.containError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.containError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
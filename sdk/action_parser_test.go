@@ -290,6 +290,176 @@ func TestParserOperations(t *testing.T) {
 	}
 }
 
+func TestParserArithmetic(t *testing.T) {
+	log.Factory = log.NewTestingWrapper(t)
+	log.UnregisterField(log.FieldCaller, log.FieldSourceFile, log.FieldSourceLine)
+	tests := []struct {
+		name         string
+		context      map[string]interface{}
+		input        string
+		result       interface{}
+		containError string
+	}{
+		{
+			name:   "operators precedence",
+			input:  "${{ 1 + 2 * 3 - 4 }}",
+			result: 3,
+		},
+		{
+			name:   "parenthesis",
+			input:  "${{ (1 + 2) * 3 }}",
+			result: 9,
+		},
+		{
+			name:   "integer division and modulo",
+			input:  "${{ 7 / 2 + 7 % 2 }}",
+			result: 4,
+		},
+		{
+			name:   "float operation",
+			input:  "${{ 1.5 * 2 + 0.25 }}",
+			result: 3.25,
+		},
+		{
+			name:   "variables",
+			input:  "${{ job.num - 1 }}",
+			result: 4,
+			context: map[string]interface{}{
+				"job": map[string]interface{}{
+					"num": 5,
+				},
+			},
+		},
+		{
+			name:   "identifier with a dash is not a subtraction",
+			input:  "${{ vars.my-var }}",
+			result: "foo",
+			context: map[string]interface{}{
+				"vars": map[string]interface{}{
+					"my-var": "foo",
+				},
+			},
+		},
+		{
+			name:   "numeric string",
+			input:  "${{ git.run_number + 1 }}",
+			result: 43,
+			context: map[string]interface{}{
+				"git": map[string]interface{}{
+					"run_number": "42",
+				},
+			},
+		},
+		{
+			name:   "comparison with an operation",
+			input:  "${{ job.num * 2 > 9 && length(job.items) - 1 == 2 }}",
+			result: true,
+			context: map[string]interface{}{
+				"job": map[string]interface{}{
+					"num":   5,
+					"items": []interface{}{"a", "b", "c"},
+				},
+			},
+		},
+		{
+			name:         "division by zero",
+			input:        "${{ 1 / 0 }}",
+			containError: "division by zero",
+		},
+		{
+			name:         "not a number",
+			input:        "${{ 'foo' + 1 }}",
+			containError: "must be a float or an int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap := NewActionParser(tt.context, DefaultFuncs)
+			result, err := ap.parse(context.TODO(), tt.input)
+			if tt.containError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.containError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.result, result)
+			}
+		})
+	}
+}
+
+func TestParserTernary(t *testing.T) {
+	log.Factory = log.NewTestingWrapper(t)
+	log.UnregisterField(log.FieldCaller, log.FieldSourceFile, log.FieldSourceLine)
+	tests := []struct {
+		name         string
+		context      map[string]interface{}
+		input        string
+		result       interface{}
+		containError string
+	}{
+		{
+			name:   "true condition",
+			input:  "${{ git.branch == 'master' ? 'prod' : 'dev' }}",
+			result: "prod",
+			context: map[string]interface{}{
+				"git": map[string]interface{}{
+					"branch": "master",
+				},
+			},
+		},
+		{
+			name:   "nested ternary",
+			input:  "${{ git.branch == 'master' ? 'prod' : git.branch == 'develop' ? 'preprod' : 'dev' }}",
+			result: "preprod",
+			context: map[string]interface{}{
+				"git": map[string]interface{}{
+					"branch": "develop",
+				},
+			},
+		},
+		{
+			name:   "only the selected branch is evaluated",
+			input:  "${{ true ? 1 : 1 / 0 }}",
+			result: 1,
+		},
+		{
+			name:   "ternary in function arguments",
+			input:  "${{ format('{0}-{1}', false ? 'a' : 'b', 1 + 1) }}",
+			result: "b-2",
+		},
+		{
+			name:   "if function",
+			input:  "${{ if(job.num > 1, 'many', 'one') }}",
+			result: "many",
+			context: map[string]interface{}{
+				"job": map[string]interface{}{
+					"num": 5,
+				},
+			},
+		},
+		{
+			name:         "non boolean condition",
+			input:        "${{ 'foo' ? 1 : 2 }}",
+			containError: "must return a boolean value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap := NewActionParser(tt.context, DefaultFuncs)
+			result, err := ap.parse(context.TODO(), tt.input)
+			if tt.containError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.containError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.result, result)
+			}
+		})
+	}
+}
+
 func TestParserBooleanExpression(t *testing.T) {
 	log.Factory = log.NewTestingWrapper(t)
 	log.UnregisterField(log.FieldCaller, log.FieldSourceFile, log.FieldSourceLine)
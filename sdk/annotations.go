@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Annotation messages are truncated, vcs servers limit their size
+const annotationMessageMaxLength = 4000
+
+// Formats supported to produce annotations from a file
+const (
+	AnnotationsFormatLine       = "line"
+	AnnotationsFormatCheckstyle = "checkstyle"
+	AnnotationsFormatJUnit      = "junit"
+)
+
+// file.go:12:5: message, as printed by compilers and most linters
+var annotationLineRegexp = regexp.MustCompile(`^([^\s:][^:]*):(\d+)(?::(\d+))?:\s*(?:(error|warning|warn|note|info)\s*:?\s+)?(.+)$`)
+
+// Location of a failure in a test output, like "file_test.go:42" or "File.java:42"
+var annotationLocationRegexp = regexp.MustCompile(`([\w./\\-]+\.[a-zA-Z0-9]+):(\d+)`)
+
+func truncateAnnotationMessage(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > annotationMessageMaxLength {
+		return s[:annotationMessageMaxLength] + "..."
+	}
+	return s
+}
+
+// ParseAnnotations reads annotations from a file content in the given format
+func ParseAnnotations(format string, data []byte) ([]VCSAnnotation, error) {
+	switch format {
+	case AnnotationsFormatLine:
+		return ParseLineAnnotations(data), nil
+	case AnnotationsFormatCheckstyle:
+		return ParseCheckstyleAnnotations(data)
+	case AnnotationsFormatJUnit:
+		var tests JUnitTestsSuites
+		if err := xml.Unmarshal(data, &tests); err != nil {
+			var suite JUnitTestSuite
+			if err := xml.Unmarshal(data, &suite); err != nil {
+				return nil, NewErrorFrom(ErrWrongRequest, "unable to read junit file: %v", err)
+			}
+			tests.TestSuites = append(tests.TestSuites, suite)
+		}
+		return tests.Annotations(), nil
+	}
+	return nil, NewErrorFrom(ErrWrongRequest, "unsupported annotations format %q", format)
+}
+
+// ParseLineAnnotations reads lines formatted as "path:line[:column]: [level:] message", other lines are ignored
+func ParseLineAnnotations(data []byte) []VCSAnnotation {
+	annotations := make([]VCSAnnotation, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		matches := annotationLineRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if matches == nil {
+			continue
+		}
+		line, _ := strconv.Atoi(matches[2])
+		level := VCSAnnotationLevelWarning
+		switch matches[4] {
+		case "error":
+			level = VCSAnnotationLevelFailure
+		case "note", "info":
+			level = VCSAnnotationLevelNotice
+		}
+		annotations = append(annotations, VCSAnnotation{
+			Path:      filepath.ToSlash(filepath.Clean(matches[1])),
+			StartLine: line,
+			EndLine:   line,
+			Level:     level,
+			Message:   truncateAnnotationMessage(matches[5]),
+		})
+	}
+	return annotations
+}
+
+type checkstyleResult struct {
+	Files []struct {
+		Name   string `xml:"name,attr"`
+		Errors []struct {
+			Line     int    `xml:"line,attr"`
+			Severity string `xml:"severity,attr"`
+			Message  string `xml:"message,attr"`
+			Source   string `xml:"source,attr"`
+		} `xml:"error"`
+	} `xml:"file"`
+}
+
+// ParseCheckstyleAnnotations reads a checkstyle xml report, a format supported by most linters
+func ParseCheckstyleAnnotations(data []byte) ([]VCSAnnotation, error) {
+	var result checkstyleResult
+	if err := xml.Unmarshal(data, &result); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "unable to read checkstyle file: %v", err)
+	}
+	annotations := make([]VCSAnnotation, 0)
+	for _, f := range result.Files {
+		for _, e := range f.Errors {
+			level := VCSAnnotationLevelWarning
+			switch e.Severity {
+			case "error":
+				level = VCSAnnotationLevelFailure
+			case "info", "ignore":
+				level = VCSAnnotationLevelNotice
+			}
+			annotations = append(annotations, VCSAnnotation{
+				Path:      filepath.ToSlash(filepath.Clean(f.Name)),
+				StartLine: e.Line,
+				EndLine:   e.Line,
+				Level:     level,
+				Title:     e.Source,
+				Message:   truncateAnnotationMessage(e.Message),
+			})
+		}
+	}
+	return annotations, nil
+}
+
+// Annotations returns a failure annotation for each failed test case whose location is known.
+// The location is read from the file and line attributes, or from the failure output.
+func (s JUnitTestsSuites) Annotations() []VCSAnnotation {
+	annotations := make([]VCSAnnotation, 0)
+	for _, ts := range s.TestSuites {
+		for _, tc := range ts.TestCases {
+			failures := append(append([]JUnitTestFailure{}, tc.Failures...), tc.Errors...)
+			for _, f := range failures {
+				message := strings.TrimSpace(f.Message + "\n" + f.Value)
+				path, line := tc.File, tc.Line
+				if path == "" || line == 0 {
+					matches := annotationLocationRegexp.FindStringSubmatch(message)
+					if matches == nil {
+						continue
+					}
+					if path == "" {
+						path = matches[1]
+					}
+					line, _ = strconv.Atoi(matches[2])
+				}
+				title := tc.Name
+				if ts.Name != "" {
+					title = ts.Name + " / " + tc.Name
+				}
+				annotations = append(annotations, VCSAnnotation{
+					Path:      filepath.ToSlash(filepath.Clean(path)),
+					StartLine: line,
+					EndLine:   line,
+					Level:     VCSAnnotationLevelFailure,
+					Title:     title,
+					Message:   truncateAnnotationMessage(message),
+				})
+			}
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLineAnnotations(t *testing.T) {
+	output := `# github.com/ovh/cds/engine/api
+engine/api/api.go:12:5: error: undefined: foo
+./sdk/vcs.go:42: warning: unused variable bar
+sdk/error.go:7:1: exported function should have comment
+not an annotation
+`
+	annotations := ParseLineAnnotations([]byte(output))
+	require.Len(t, annotations, 3)
+	require.Equal(t, VCSAnnotation{Path: "engine/api/api.go", StartLine: 12, EndLine: 12, Level: VCSAnnotationLevelFailure, Message: "undefined: foo"}, annotations[0])
+	require.Equal(t, "sdk/vcs.go", annotations[1].Path)
+	require.Equal(t, VCSAnnotationLevelWarning, annotations[1].Level)
+	require.Equal(t, "unused variable bar", annotations[1].Message)
+	require.Equal(t, "exported function should have comment", annotations[2].Message)
+}
+
+func TestParseCheckstyleAnnotations(t *testing.T) {
+	report := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+  <file name="engine/api/api.go">
+    <error column="2" line="31" message="ineffectual assignment to err" severity="error" source="ineffassign"></error>
+    <error column="1" line="45" message="line is too long" severity="warning" source="lll"></error>
+  </file>
+</checkstyle>`
+	annotations, err := ParseCheckstyleAnnotations([]byte(report))
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+	require.Equal(t, "engine/api/api.go", annotations[0].Path)
+	require.Equal(t, 31, annotations[0].StartLine)
+	require.Equal(t, VCSAnnotationLevelFailure, annotations[0].Level)
+	require.Equal(t, "ineffassign", annotations[0].Title)
+	require.Equal(t, VCSAnnotationLevelWarning, annotations[1].Level)
+}
+
+func TestJUnitAnnotations(t *testing.T) {
+	report := `<testsuites>
+  <testsuite name="github.com/ovh/cds/sdk" tests="3" failures="2">
+    <testcase classname="sdk" name="TestOK"></testcase>
+    <testcase classname="sdk" name="TestKO">
+      <failure message="Failed" type=""><![CDATA[    vcs_test.go:18: 
+        	Error Trace:	/go/src/sdk/vcs_test.go:18
+        	Error:      	Not equal]]></failure>
+    </testcase>
+    <testcase classname="sdk" name="TestWithLocation" file="sdk/tests_test.go" line="7">
+      <failure message="expected true"></failure>
+    </testcase>
+    <testcase classname="sdk" name="TestWithoutLocation">
+      <failure message="timeout"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>`
+	annotations, err := ParseAnnotations(AnnotationsFormatJUnit, []byte(report))
+	require.NoError(t, err)
+	require.Len(t, annotations, 2)
+	require.Equal(t, "vcs_test.go", annotations[0].Path)
+	require.Equal(t, 18, annotations[0].StartLine)
+	require.Equal(t, VCSAnnotationLevelFailure, annotations[0].Level)
+	require.Equal(t, "github.com/ovh/cds/sdk / TestKO", annotations[0].Title)
+	require.Equal(t, "sdk/tests_test.go", annotations[1].Path)
+	require.Equal(t, 7, annotations[1].StartLine)
+}
+
+func TestVCSAnnotationsLint(t *testing.T) {
+	as := VCSAnnotations{
+		Key: "lint",
+		Annotations: []VCSAnnotation{
+			{Path: "main.go", Message: "message"},
+		},
+	}
+	require.NoError(t, as.Lint())
+	require.Equal(t, 1, as.Annotations[0].StartLine)
+	require.Equal(t, 1, as.Annotations[0].EndLine)
+	require.Equal(t, VCSAnnotationLevelWarning, as.Annotations[0].Level)
+	require.False(t, as.HasFailure())
+
+	as.Annotations[0].Level = "critical"
+	require.Error(t, as.Lint())
+
+	as.Annotations[0].Level = VCSAnnotationLevelFailure
+	require.True(t, as.HasFailure())
+	require.Equal(t, "**FAILURE** message", as.Annotations[0].Markdown())
+
+	require.Error(t, (&VCSAnnotations{}).Lint())
+}
@@ -17,9 +17,9 @@ const (
 // AuditCommon contains basic stuff for audits.
 type AuditCommon struct {
 	ID          int64     `json:"id" db:"id"`
-	TriggeredBy string    `json:"triggered_by" db:"triggered_by"`
-	Created     time.Time `json:"created" db:"created" mapstructure:"-"`
-	EventType   string    `json:"event_type" db:"event_type"`
+	TriggeredBy string    `json:"triggered_by" db:"triggered_by" cli:"triggered_by"`
+	Created     time.Time `json:"created" db:"created" mapstructure:"-" cli:"created"`
+	EventType   string    `json:"event_type" db:"event_type" cli:"event_type"`
 }
 
 // AuditWorkflow represents an audit data on a workflow.
@@ -63,3 +63,11 @@ type AuditAction struct {
 	DataBefore string `json:"data_before" db:"data_before"`
 	DataAfter  string `json:"data_after" db:"data_after"`
 }
+
+// AuditRBACGrant represents an audit data on a temporary permission grant: request, review, expiration and each use.
+type AuditRBACGrant struct {
+	AuditCommon
+	GrantID    string `json:"grant_id" db:"grant_id"`
+	ProjectKey string `json:"project_key" db:"project_key"`
+	Details    string `json:"details" db:"details" cli:"details"`
+}
@@ -0,0 +1,21 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) AuditV2List(ctx context.Context, mods ...RequestModifier) ([]sdk.AuditV2, error) {
+	var audits []sdk.AuditV2
+	_, err := c.GetJSON(ctx, "/v2/audit", &audits, mods...)
+	return audits, err
+}
+
+func (c *client) ProjectAuditV2List(ctx context.Context, pKey string, mods ...RequestModifier) ([]sdk.AuditV2, error) {
+	var audits []sdk.AuditV2
+	path := fmt.Sprintf("/v2/project/%s/audit", pKey)
+	_, err := c.GetJSON(ctx, path, &audits, mods...)
+	return audits, err
+}
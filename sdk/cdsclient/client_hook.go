@@ -35,6 +35,19 @@ func (c *client) HookRepositoriesList(ctx context.Context, vcsServer, repoName s
 	return repos, err
 }
 
+func (c *client) HookPollingRepositoriesList(ctx context.Context) ([]sdk.HookPollingRepository, error) {
+	var repos []sdk.HookPollingRepository
+	_, err := c.GetJSON(ctx, "/v2/hooks/repositories/polling", &repos)
+	return repos, err
+}
+
+func (c *client) HookRepositoryRefs(ctx context.Context, projKey, vcsServer, repoName string) (sdk.HookRepositoryRefs, error) {
+	path := fmt.Sprintf("/v2/hooks/project/%s/vcs/%s/repository/%s/refs", projKey, url.PathEscape(vcsServer), url.PathEscape(repoName))
+	var refs sdk.HookRepositoryRefs
+	_, err := c.GetJSON(ctx, path, &refs)
+	return refs, err
+}
+
 func (c *client) ListWorkflowToTrigger(ctx context.Context, req sdk.HookListWorkflowRequest) ([]sdk.V2WorkflowHook, error) {
 	var workflowHooks []sdk.V2WorkflowHook
 	_, err := c.PostJSON(ctx, "/v2/hooks/workflows", &req, &workflowHooks)
@@ -0,0 +1,75 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectPolicyCreate(ctx context.Context, pKey string, p *sdk.Policy) error {
+	path := fmt.Sprintf("/v2/project/%s/policy", pKey)
+	_, err := c.PostJSON(ctx, path, p, p)
+	return err
+}
+
+func (c *client) ProjectPolicyGet(ctx context.Context, pKey string, name string) (*sdk.Policy, error) {
+	var p sdk.Policy
+	path := fmt.Sprintf("/v2/project/%s/policy/%s", pKey, name)
+	_, err := c.GetJSON(ctx, path, &p)
+	return &p, err
+}
+
+func (c *client) ProjectPolicyList(ctx context.Context, pKey string, withOrganization bool) ([]sdk.Policy, error) {
+	var ps []sdk.Policy
+	path := fmt.Sprintf("/v2/project/%s/policy", pKey)
+	if withOrganization {
+		path += "?withOrganization=true"
+	}
+	_, err := c.GetJSON(ctx, path, &ps)
+	return ps, err
+}
+
+func (c *client) ProjectPolicyUpdate(ctx context.Context, pKey string, p *sdk.Policy) error {
+	path := fmt.Sprintf("/v2/project/%s/policy/%s", pKey, p.Name)
+	_, err := c.PutJSON(ctx, path, p, p)
+	return err
+}
+
+func (c *client) ProjectPolicyDelete(ctx context.Context, pKey string, name string) error {
+	path := fmt.Sprintf("/v2/project/%s/policy/%s", pKey, name)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
+
+func (c *client) OrganizationPolicyCreate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error {
+	path := fmt.Sprintf("/v2/organization/%s/policy", organizationIdentifier)
+	_, err := c.PostJSON(ctx, path, p, p)
+	return err
+}
+
+func (c *client) OrganizationPolicyGet(ctx context.Context, organizationIdentifier string, name string) (*sdk.Policy, error) {
+	var p sdk.Policy
+	path := fmt.Sprintf("/v2/organization/%s/policy/%s", organizationIdentifier, name)
+	_, err := c.GetJSON(ctx, path, &p)
+	return &p, err
+}
+
+func (c *client) OrganizationPolicyList(ctx context.Context, organizationIdentifier string) ([]sdk.Policy, error) {
+	var ps []sdk.Policy
+	path := fmt.Sprintf("/v2/organization/%s/policy", organizationIdentifier)
+	_, err := c.GetJSON(ctx, path, &ps)
+	return ps, err
+}
+
+func (c *client) OrganizationPolicyUpdate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error {
+	path := fmt.Sprintf("/v2/organization/%s/policy/%s", organizationIdentifier, p.Name)
+	_, err := c.PutJSON(ctx, path, p, p)
+	return err
+}
+
+func (c *client) OrganizationPolicyDelete(ctx context.Context, organizationIdentifier string, name string) error {
+	path := fmt.Sprintf("/v2/organization/%s/policy/%s", organizationIdentifier, name)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
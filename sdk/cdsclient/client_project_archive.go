@@ -0,0 +1,28 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectArchiveKey(ctx context.Context) (string, error) {
+	var key string
+	_, err := c.GetJSON(ctx, "/v2/project/archive/key", &key)
+	return key, err
+}
+
+func (c *client) ProjectExport(ctx context.Context, pKey string, req sdk.ProjectExportRequest) (*sdk.SignedProjectArchive, error) {
+	var archive sdk.SignedProjectArchive
+	path := fmt.Sprintf("/v2/project/%s/export", pKey)
+	_, err := c.PostJSON(ctx, path, req, &archive)
+	return &archive, err
+}
+
+func (c *client) ProjectImport(ctx context.Context, pKey string, archive sdk.SignedProjectArchive, mods ...RequestModifier) ([]sdk.ProjectImportReportLine, error) {
+	var report []sdk.ProjectImportReportLine
+	path := fmt.Sprintf("/v2/project/%s/import", pKey)
+	_, err := c.PostJSON(ctx, path, archive, &report, mods...)
+	return report, err
+}
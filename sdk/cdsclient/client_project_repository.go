@@ -65,3 +65,23 @@ func (c *client) ProjectRepositoryEvent(ctx context.Context, projectKey, vcsName
 	_, err := c.GetJSON(ctx, path, &event)
 	return &event, err
 }
+
+func (c *client) ProjectRepositoryMergeQueueList(ctx context.Context, projectKey, vcsName, repoName string) ([]sdk.MergeQueueEntry, error) {
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/mergequeue", projectKey, url.PathEscape(vcsName), url.PathEscape(repoName))
+	var entries []sdk.MergeQueueEntry
+	_, err := c.GetJSON(ctx, path, &entries)
+	return entries, err
+}
+
+func (c *client) ProjectRepositoryMergeQueueAdd(ctx context.Context, projectKey, vcsName, repoName string, pullRequestID int64) (*sdk.MergeQueueEntry, error) {
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/mergequeue", projectKey, url.PathEscape(vcsName), url.PathEscape(repoName))
+	var entry sdk.MergeQueueEntry
+	_, err := c.PostJSON(ctx, path, sdk.MergeQueueEnqueueRequest{PullRequestID: pullRequestID}, &entry)
+	return &entry, err
+}
+
+func (c *client) ProjectRepositoryMergeQueueDelete(ctx context.Context, projectKey, vcsName, repoName, entryID string) error {
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/mergequeue/%s", projectKey, url.PathEscape(vcsName), url.PathEscape(repoName), entryID)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
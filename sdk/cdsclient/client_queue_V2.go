@@ -46,6 +46,19 @@ func (c *client) V2QueuePushJobInfo(ctx context.Context, regionName string, jobR
 	return nil
 }
 
+func (c *client) V2QueuePushJobAnnotations(ctx context.Context, regionName string, jobRunID string, annotations sdk.VCSAnnotations) error {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/annotations", regionName, jobRunID)
+	if _, err := c.PostJSON(ctx, path, annotations, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) V2QueueJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, regionName string, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/debug", regionName, jobRunID)
+	return c.RequestWebsocket(ctx, goRoutines, path, msgToSend, msgReceived, errorReceived)
+}
+
 func (c *client) V2QueueJobResult(ctx context.Context, regionName string, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	path := fmt.Sprintf("/v2/queue/%s/job/%s/result", regionName, jobRunID)
 	if _, err := c.PostJSON(ctx, path, result, nil); err != nil {
@@ -72,6 +85,23 @@ func (c *client) V2QueueJobRunResultsGet(ctx context.Context, regionName string,
 	return result, nil
 }
 
+func (c *client) V2QueueJobExternalRunResultsGet(ctx context.Context, regionName string, jobRunID string, runRef string) ([]sdk.V2WorkflowRunResult, error) {
+	var result []sdk.V2WorkflowRunResult
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/runresult/external?run=%s", regionName, jobRunID, url.QueryEscape(runRef))
+	if _, err := c.GetJSON(ctx, path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *client) V2QueueJobExternalRunResultAccess(ctx context.Context, regionName string, jobRunID string, workflowRunID string) error {
+	path := fmt.Sprintf("/v2/queue/%s/job/%s/runresult/external/%s/access", regionName, jobRunID, workflowRunID)
+	if _, err := c.GetJSON(ctx, path, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *client) V2QueueJobRunResultsSynchronize(ctx context.Context, regionName string, jobRunID string) error {
 	path := fmt.Sprintf("/v2/queue/%s/job/%s/runresult/synchronize", regionName, jobRunID)
 	if _, err := c.PutJSON(ctx, path, nil, nil); err != nil {
@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -45,3 +46,45 @@ func (c *client) RBACGroupPermission(ctx context.Context, groupName string) (sdk
 	_, err := c.GetJSON(ctx, path, &summary)
 	return summary, err
 }
+
+func (c *client) RBACWorkflowCheck(ctx context.Context, projectKey string, checkRequest sdk.RBACWorkflowCheckRequest) (*sdk.RBACWorkflowCheck, error) {
+	path := "/v2/project/" + projectKey + "/rbac/workflow/check"
+	var check sdk.RBACWorkflowCheck
+	_, err := c.PostJSON(ctx, path, &checkRequest, &check)
+	return &check, err
+}
+
+func (c *client) RBACGrantRequest(ctx context.Context, grantRequest sdk.RBACGrantRequest) (sdk.RBACGrant, error) {
+	var grant sdk.RBACGrant
+	_, err := c.PostJSON(ctx, "/v2/rbac/grant", &grantRequest, &grant)
+	return grant, err
+}
+
+func (c *client) RBACGrantList(ctx context.Context, status ...string) ([]sdk.RBACGrant, error) {
+	path := "/v2/rbac/grant"
+	if len(status) > 0 {
+		path += "?" + url.Values{"status": status}.Encode()
+	}
+	var grants []sdk.RBACGrant
+	_, err := c.GetJSON(ctx, path, &grants)
+	return grants, err
+}
+
+func (c *client) RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrant, error) {
+	var grant sdk.RBACGrant
+	_, err := c.GetJSON(ctx, "/v2/rbac/grant/"+grantID, &grant)
+	return grant, err
+}
+
+func (c *client) RBACGrantAudits(ctx context.Context, grantID string) ([]sdk.AuditRBACGrant, error) {
+	var audits []sdk.AuditRBACGrant
+	_, err := c.GetJSON(ctx, "/v2/rbac/grant/"+grantID+"/audit", &audits)
+	return audits, err
+}
+
+// RBACGrantReview approves, rejects or revokes a temporary grant. Action must be approve, reject or revoke.
+func (c *client) RBACGrantReview(ctx context.Context, grantID string, action string, review sdk.RBACGrantReview) (sdk.RBACGrant, error) {
+	var grant sdk.RBACGrant
+	_, err := c.PostJSON(ctx, "/v2/rbac/grant/"+grantID+"/"+action, &review, &grant)
+	return grant, err
+}
@@ -0,0 +1,59 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func sharedConcurrencyPath(scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string) string {
+	if scope == sdk.V2RunConcurrencyScopeRegion {
+		return fmt.Sprintf("/v2/region/%s/concurrency", scopeIdentifier)
+	}
+	return fmt.Sprintf("/v2/organization/%s/concurrency", scopeIdentifier)
+}
+
+func (c *client) SharedConcurrencyCreate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, concu *sdk.SharedConcurrency) error {
+	_, err := c.PostJSON(ctx, sharedConcurrencyPath(scope, scopeIdentifier), concu, concu)
+	return err
+}
+
+func (c *client) SharedConcurrencyGet(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) (*sdk.SharedConcurrency, error) {
+	var sc sdk.SharedConcurrency
+	path := fmt.Sprintf("%s/%s", sharedConcurrencyPath(scope, scopeIdentifier), name)
+	_, err := c.GetJSON(ctx, path, &sc)
+	return &sc, err
+}
+
+func (c *client) SharedConcurrencyList(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string) ([]sdk.SharedConcurrency, error) {
+	var scs []sdk.SharedConcurrency
+	_, err := c.GetJSON(ctx, sharedConcurrencyPath(scope, scopeIdentifier), &scs)
+	return scs, err
+}
+
+func (c *client) SharedConcurrencyUpdate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, concu *sdk.SharedConcurrency) error {
+	path := fmt.Sprintf("%s/%s", sharedConcurrencyPath(scope, scopeIdentifier), concu.Name)
+	_, err := c.PutJSON(ctx, path, concu, concu)
+	return err
+}
+
+func (c *client) SharedConcurrencyDelete(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) error {
+	path := fmt.Sprintf("%s/%s", sharedConcurrencyPath(scope, scopeIdentifier), name)
+	_, err := c.DeleteJSON(ctx, path, nil)
+	return err
+}
+
+func (c *client) SharedConcurrencyListRuns(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	var runObjects []sdk.ProjectConcurrencyRunObject
+	path := fmt.Sprintf("%s/%s/runs", sharedConcurrencyPath(scope, scopeIdentifier), name)
+	_, err := c.GetJSON(ctx, path, &runObjects)
+	return runObjects, err
+}
+
+func (c *client) SharedConcurrencyRelease(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	var runObjects []sdk.ProjectConcurrencyRunObject
+	path := fmt.Sprintf("%s/%s/release", sharedConcurrencyPath(scope, scopeIdentifier), name)
+	_, err := c.PostJSON(ctx, path, nil, &runObjects)
+	return runObjects, err
+}
@@ -2,6 +2,8 @@ package cdsclient
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -14,3 +16,13 @@ func (c *client) TemplateGenerateWorkflowFromFile(ctx context.Context, req sdk.V
 
 	return &resp, nil
 }
+
+func (c *client) TemplateV2InstanceList(ctx context.Context, pKey string, template string) ([]sdk.V2WorkflowTemplateInstance, error) {
+	var instances []sdk.V2WorkflowTemplateInstance
+	path := fmt.Sprintf("/v2/project/%s/template/instance", pKey)
+	if template != "" {
+		path += "?template=" + url.QueryEscape(template)
+	}
+	_, err := c.GetJSON(ctx, path, &instances)
+	return instances, err
+}
@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -159,6 +160,11 @@ func (c *client) WorkflowV2RunJobLogLinks(ctx context.Context, projKey, workflow
 	return logsLinks, nil
 }
 
+func (c *client) WorkflowV2RunJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	path := fmt.Sprintf("/v2/project/%s/run/%s/job/%s/debug", projKey, workflowRunID, jobRunID)
+	return c.RequestWebsocket(ctx, goRoutines, path, msgToSend, msgReceived, errorReceived)
+}
+
 func (c *client) WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error {
 	path := fmt.Sprintf("/v2/project/%s/run/%s/stop", projKey, workflowRunID)
 	if _, _, _, err := c.RequestJSON(ctx, http.MethodPost, path, nil, nil); err != nil {
@@ -219,3 +225,68 @@ func (c *client) WorkflowV2RunJobRetries(ctx context.Context, projectKey, workfl
 	}
 	return runJobs, nil
 }
+
+func (c *client) WorkflowV2RunTestCaseList(ctx context.Context, projKey, workflowRunID string, mods ...RequestModifier) ([]sdk.V2WorkflowRunTestCase, error) {
+	var testCases []sdk.V2WorkflowRunTestCase
+	path := fmt.Sprintf("/v2/project/%s/run/%s/test", projKey, workflowRunID)
+	if _, err := c.GetJSON(ctx, path, &testCases, mods...); err != nil {
+		return nil, err
+	}
+	return testCases, nil
+}
+
+func (c *client) WorkflowV2TestCaseHistory(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name string, mods ...RequestModifier) ([]sdk.V2WorkflowRunTestCase, error) {
+	var history []sdk.V2WorkflowRunTestCase
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/workflow/%s/test/history", projKey, url.PathEscape(vcsIdentifier), url.PathEscape(repoIdentifier), wkfName)
+	mods = append(mods, WithQueryParameter("suite", suite), WithQueryParameter("name", name))
+	if _, err := c.GetJSON(ctx, path, &history, mods...); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (c *client) WorkflowV2SlowestTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error) {
+	var stats []sdk.V2WorkflowTestCaseStats
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/workflow/%s/test/slowest", projKey, url.PathEscape(vcsIdentifier), url.PathEscape(repoIdentifier), wkfName)
+	if _, err := c.GetJSON(ctx, path, &stats, mods...); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (c *client) WorkflowV2FlakyTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error) {
+	var stats []sdk.V2WorkflowTestCaseStats
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/workflow/%s/test/flaky", projKey, url.PathEscape(vcsIdentifier), url.PathEscape(repoIdentifier), wkfName)
+	if _, err := c.GetJSON(ctx, path, &stats, mods...); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (c *client) WorkflowV2RunCoverage(ctx context.Context, projKey, workflowRunID string, mods ...RequestModifier) (*sdk.V2WorkflowRunCoverage, error) {
+	var coverage sdk.V2WorkflowRunCoverage
+	path := fmt.Sprintf("/v2/project/%s/run/%s/coverage", projKey, workflowRunID)
+	if _, err := c.GetJSON(ctx, path, &coverage, mods...); err != nil {
+		return nil, err
+	}
+	return &coverage, nil
+}
+
+func (c *client) WorkflowV2CoverageTrend(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, ref string, mods ...RequestModifier) ([]sdk.V2WorkflowRunCoverage, error) {
+	var trend []sdk.V2WorkflowRunCoverage
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/workflow/%s/coverage", projKey, url.PathEscape(vcsIdentifier), url.PathEscape(repoIdentifier), wkfName)
+	mods = append(mods, WithQueryParameter("ref", ref))
+	if _, err := c.GetJSON(ctx, path, &trend, mods...); err != nil {
+		return nil, err
+	}
+	return trend, nil
+}
+
+func (c *client) WorkflowV2ScheduleList(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...RequestModifier) ([]sdk.V2WorkflowScheduleExecution, error) {
+	var executions []sdk.V2WorkflowScheduleExecution
+	path := fmt.Sprintf("/v2/project/%s/vcs/%s/repository/%s/workflow/%s/schedule", projKey, url.PathEscape(vcsIdentifier), url.PathEscape(repoIdentifier), wkfName)
+	if _, err := c.GetJSON(ctx, path, &executions, mods...); err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
@@ -37,6 +37,7 @@ type TemplateClient interface {
 
 type TemplateV2Client interface {
 	TemplateGenerateWorkflowFromFile(ctx context.Context, req sdk.V2WorkflowTemplateGenerateRequest) (*sdk.V2WorkflowTemplateGenerateResponse, error)
+	TemplateV2InstanceList(ctx context.Context, pKey string, template string) ([]sdk.V2WorkflowTemplateInstance, error)
 }
 
 // Admin expose all function to CDS administration
@@ -215,6 +216,11 @@ type OrganizationClient interface {
 	OrganizationGet(ctx context.Context, organizationIdentifier string) (sdk.Organization, error)
 	OrganizationList(ctx context.Context) ([]sdk.Organization, error)
 	OrganizationDelete(ctx context.Context, organizationIdentifier string) error
+	OrganizationPolicyCreate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error
+	OrganizationPolicyGet(ctx context.Context, organizationIdentifier string, name string) (*sdk.Policy, error)
+	OrganizationPolicyList(ctx context.Context, organizationIdentifier string) ([]sdk.Policy, error)
+	OrganizationPolicyUpdate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error
+	OrganizationPolicyDelete(ctx context.Context, organizationIdentifier string, name string) error
 }
 
 type RegionClient interface {
@@ -224,6 +230,17 @@ type RegionClient interface {
 	RegionDelete(ctx context.Context, regionIdentifier string) error
 }
 
+// SharedConcurrencyClient manages the concurrencies shared by the projects of an organization or a region
+type SharedConcurrencyClient interface {
+	SharedConcurrencyCreate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, c *sdk.SharedConcurrency) error
+	SharedConcurrencyGet(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) (*sdk.SharedConcurrency, error)
+	SharedConcurrencyList(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string) ([]sdk.SharedConcurrency, error)
+	SharedConcurrencyUpdate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, c *sdk.SharedConcurrency) error
+	SharedConcurrencyDelete(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) error
+	SharedConcurrencyListRuns(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) ([]sdk.ProjectConcurrencyRunObject, error)
+	SharedConcurrencyRelease(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, name string) ([]sdk.ProjectConcurrencyRunObject, error)
+}
+
 type HatcheryClient interface {
 	HatcheryAdd(ctx context.Context, h *sdk.Hatchery) (*sdk.HatcheryGetResponse, error)
 	HatcheryGet(ctx context.Context, hatcheryIdentifier string) (sdk.HatcheryGetResponse, error)
@@ -273,9 +290,18 @@ type ProjectClientV2 interface {
 	ProjectConcurrencyUpdate(ctx context.Context, pKey string, c *sdk.ProjectConcurrency) error
 	ProjectConcurrencyDelete(ctx context.Context, pKey string, name string) error
 	ProjectConcurrencyListRuns(ctx context.Context, pKey string, name string) ([]sdk.ProjectConcurrencyRunObject, error)
+	ProjectPolicyCreate(ctx context.Context, pKey string, p *sdk.Policy) error
+	ProjectPolicyGet(ctx context.Context, pKey string, name string) (*sdk.Policy, error)
+	ProjectPolicyList(ctx context.Context, pKey string, withOrganization bool) ([]sdk.Policy, error)
+	ProjectPolicyUpdate(ctx context.Context, pKey string, p *sdk.Policy) error
+	ProjectPolicyDelete(ctx context.Context, pKey string, name string) error
 
 	ProjectV2Access(ctx context.Context, projectKey, sessionID string, itemType sdk.CDNItemType) error
 
+	ProjectArchiveKey(ctx context.Context) (string, error)
+	ProjectExport(ctx context.Context, pKey string, req sdk.ProjectExportRequest) (*sdk.SignedProjectArchive, error)
+	ProjectImport(ctx context.Context, pKey string, archive sdk.SignedProjectArchive, mods ...RequestModifier) ([]sdk.ProjectImportReportLine, error)
+
 	ProjectWebHookAdd(ctx context.Context, projectKey string, r sdk.PostProjectWebHook) (*sdk.HookAccessData, error)
 	ProjectWebHookList(ctx context.Context, projectKey string) ([]sdk.ProjectWebHook, error)
 	ProjectWebHookGet(ctx context.Context, projectKey string, uuid string) (*sdk.ProjectWebHook, error)
@@ -318,6 +344,14 @@ type ProjectClient interface {
 	ProjectRepositoryAnalysisGet(ctx context.Context, projectKey string, vcsIdentifier string, repositoryIdentifier string, analysisID string) (sdk.ProjectRepositoryAnalysis, error)
 	ProjectRepositoryEvents(ctx context.Context, projectKey, vcsName, repoName string) ([]sdk.HookRepositoryEvent, error)
 	ProjectRepositoryEvent(ctx context.Context, projectKey, vcsName, repoName, eventID string) (*sdk.HookRepositoryEvent, error)
+	ProjectRepositoryMergeQueueList(ctx context.Context, projectKey, vcsName, repoName string) ([]sdk.MergeQueueEntry, error)
+	ProjectRepositoryMergeQueueAdd(ctx context.Context, projectKey, vcsName, repoName string, pullRequestID int64) (*sdk.MergeQueueEntry, error)
+	ProjectRepositoryMergeQueueDelete(ctx context.Context, projectKey, vcsName, repoName, entryID string) error
+}
+
+type AuditV2Client interface {
+	AuditV2List(ctx context.Context, mods ...RequestModifier) ([]sdk.AuditV2, error)
+	ProjectAuditV2List(ctx context.Context, pKey string, mods ...RequestModifier) ([]sdk.AuditV2, error)
 }
 
 type RBACClient interface {
@@ -327,6 +361,12 @@ type RBACClient interface {
 	RBACList(ctx context.Context) ([]sdk.RBAC, error)
 	RBACUserPermission(ctx context.Context, username string) (sdk.PermissionSummary, error)
 	RBACGroupPermission(ctx context.Context, groupName string) (sdk.PermissionSummary, error)
+	RBACWorkflowCheck(ctx context.Context, projectKey string, checkRequest sdk.RBACWorkflowCheckRequest) (*sdk.RBACWorkflowCheck, error)
+	RBACGrantRequest(ctx context.Context, grantRequest sdk.RBACGrantRequest) (sdk.RBACGrant, error)
+	RBACGrantList(ctx context.Context, status ...string) ([]sdk.RBACGrant, error)
+	RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrant, error)
+	RBACGrantAudits(ctx context.Context, grantID string) ([]sdk.AuditRBACGrant, error)
+	RBACGrantReview(ctx context.Context, grantID string, action string, review sdk.RBACGrantReview) (sdk.RBACGrant, error)
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -357,10 +397,14 @@ type V2QueueClient interface {
 	V2QueueJobRunResultGet(ctx context.Context, regionName string, jobRunID string, runResultID string) (*sdk.V2WorkflowRunResult, error)
 	V2QueueJobRunResultsGet(ctx context.Context, regionName string, jobRunID string) ([]sdk.V2WorkflowRunResult, error)
 	V2QueueJobRunResultsSynchronize(ctx context.Context, regionName string, jobRunID string) error
+	V2QueueJobExternalRunResultsGet(ctx context.Context, regionName string, jobRunID string, runRef string) ([]sdk.V2WorkflowRunResult, error)
+	V2QueueJobExternalRunResultAccess(ctx context.Context, regionName string, jobRunID string, workflowRunID string) error
 	V2QueueJobRunResultCreate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error
 	V2QueueJobRunResultUpdate(ctx context.Context, regionName string, jobRunID string, result *sdk.V2WorkflowRunResult) error
 	V2QueuePushRunInfo(ctx context.Context, regionName string, jobRunID string, msg sdk.V2WorkflowRunInfo) error
 	V2QueuePushJobInfo(ctx context.Context, regionName string, jobRunID string, msg sdk.V2SendJobRunInfo) error
+	V2QueuePushJobAnnotations(ctx context.Context, regionName string, jobRunID string, annotations sdk.VCSAnnotations) error
+	V2QueueJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, regionName string, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error
 	V2QueueWorkerTakeJob(ctx context.Context, region, runJobID string) (*sdk.V2TakeJobResponse, error)
 	V2QueueJobStepUpdate(ctx context.Context, regionName string, id string, stepsStatus sdk.JobStepsStatus) error
 	V2QueueGetCacheLinks(ctx context.Context, regionName string, id string, cacheKey string) (*sdk.CDNItemLinks, error)
@@ -451,6 +495,8 @@ type HookClient interface {
 	HookGetWorkflowHook(ctx context.Context, hookID string) (*sdk.V2WorkflowHook, error)
 	HookListAllSchedulerHooks(ctx context.Context) ([]sdk.V2WorkflowHook, error)
 	HookRepositoriesList(ctx context.Context, vcsServer, repoName string) ([]sdk.ProjectRepository, error)
+	HookPollingRepositoriesList(ctx context.Context) ([]sdk.HookPollingRepository, error)
+	HookRepositoryRefs(ctx context.Context, projKey, vcsServer, repoName string) (sdk.HookRepositoryRefs, error)
 	ListWorkflowToTrigger(ctx context.Context, req sdk.HookListWorkflowRequest) ([]sdk.V2WorkflowHook, error)
 	RetrieveHookEventSigningKey(ctx context.Context, req sdk.HookRetrieveSignKeyRequest) (sdk.Operation, error)
 	RetrieveHookEventSigningKeyOperation(ctx context.Context, operationUUID string) (sdk.Operation, error)
@@ -479,6 +525,7 @@ type WorkflowV2Client interface {
 	WorkflowV2RunJob(ctx context.Context, projKey, workflowRunID, jobRunID string) (*sdk.V2WorkflowRunJob, error)
 	WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobInfo, error)
 	WorkflowV2RunJobLogLinks(ctx context.Context, projKey, workflowRunID, jobRunID string) (sdk.CDNLogLinks, error)
+	WorkflowV2RunJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error
 	WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error
 	WorkflowV2StopJob(ctx context.Context, projKey, workflowRunID, jobIdentifier string) error
 	WorkflowV2RunResultList(ctx context.Context, projKey, runIdentifier string) ([]sdk.V2WorkflowRunResult, error)
@@ -486,6 +533,13 @@ type WorkflowV2Client interface {
 	WorkflowV2VersionGet(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, version string) (*sdk.V2WorkflowVersion, error)
 	WorkflowV2VersionDelete(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, version string) error
 	WorkflowV2RunJobRetries(ctx context.Context, projectKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJob, error)
+	WorkflowV2RunTestCaseList(ctx context.Context, projKey, workflowRunID string, mods ...RequestModifier) ([]sdk.V2WorkflowRunTestCase, error)
+	WorkflowV2TestCaseHistory(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name string, mods ...RequestModifier) ([]sdk.V2WorkflowRunTestCase, error)
+	WorkflowV2SlowestTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error)
+	WorkflowV2FlakyTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error)
+	WorkflowV2RunCoverage(ctx context.Context, projKey, workflowRunID string, mods ...RequestModifier) (*sdk.V2WorkflowRunCoverage, error)
+	WorkflowV2CoverageTrend(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, ref string, mods ...RequestModifier) ([]sdk.V2WorkflowRunCoverage, error)
+	WorkflowV2ScheduleList(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...RequestModifier) ([]sdk.V2WorkflowScheduleExecution, error)
 }
 
 // WorkflowClient exposes workflows functions
@@ -550,6 +604,7 @@ type Interface interface {
 	ActionClient
 	Admin
 	APIURL() string
+	AuditV2Client
 	CDNURL() (string, error)
 	ApplicationClient
 	ConfigUser() (sdk.ConfigUser, error)
@@ -573,6 +628,7 @@ type Interface interface {
 	RBACClient
 	OrganizationClient
 	RegionClient
+	SharedConcurrencyClient
 	QueueClient
 	Requirements() ([]sdk.Requirement, error)
 	RepositoriesManagerInterface
@@ -242,6 +242,21 @@ func (mr *MockTemplateV2ClientMockRecorder) TemplateGenerateWorkflowFromFile(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateGenerateWorkflowFromFile", reflect.TypeOf((*MockTemplateV2Client)(nil).TemplateGenerateWorkflowFromFile), ctx, req)
 }
 
+// TemplateV2InstanceList mocks base method.
+func (m *MockTemplateV2Client) TemplateV2InstanceList(ctx context.Context, pKey, template string) ([]sdk.V2WorkflowTemplateInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateV2InstanceList", ctx, pKey, template)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTemplateInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateV2InstanceList indicates an expected call of TemplateV2InstanceList.
+func (mr *MockTemplateV2ClientMockRecorder) TemplateV2InstanceList(ctx, pKey, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateV2InstanceList", reflect.TypeOf((*MockTemplateV2Client)(nil).TemplateV2InstanceList), ctx, pKey, template)
+}
+
 // MockAdmin is a mock of Admin interface.
 type MockAdmin struct {
 	ctrl     *gomock.Controller
@@ -507,20 +522,6 @@ func (mr *MockAdminMockRecorder) AdminUserCreate(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserCreate", reflect.TypeOf((*MockAdmin)(nil).AdminUserCreate), ctx, user)
 }
 
-// AdminUserSetContact mocks base method.
-func (m *MockAdmin) AdminUserSetContact(ctx context.Context, username string, contact sdk.UserContact) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminUserSetContact", ctx, username, contact)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// AdminUserSetContact indicates an expected call of AdminUserSetContact.
-func (mr *MockAdminMockRecorder) AdminUserSetContact(ctx, username, contact any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserSetContact", reflect.TypeOf((*MockAdmin)(nil).AdminUserSetContact), ctx, username, contact)
-}
-
 // AdminUserLinkCreate mocks base method.
 func (m *MockAdmin) AdminUserLinkCreate(ctx context.Context, username string, link sdk.UserLink) error {
 	m.ctrl.T.Helper()
@@ -549,6 +550,20 @@ func (mr *MockAdminMockRecorder) AdminUserLinkDelete(ctx, username, link any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserLinkDelete", reflect.TypeOf((*MockAdmin)(nil).AdminUserLinkDelete), ctx, username, link)
 }
 
+// AdminUserSetContact mocks base method.
+func (m *MockAdmin) AdminUserSetContact(ctx context.Context, username string, contact sdk.UserContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminUserSetContact", ctx, username, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminUserSetContact indicates an expected call of AdminUserSetContact.
+func (mr *MockAdminMockRecorder) AdminUserSetContact(ctx, username, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserSetContact", reflect.TypeOf((*MockAdmin)(nil).AdminUserSetContact), ctx, username, contact)
+}
+
 // AdminWorkflowUpdateMaxRuns mocks base method.
 func (m *MockAdmin) AdminWorkflowUpdateMaxRuns(projectKey, workflowName string, maxRuns int64) error {
 	m.ctrl.T.Helper()
@@ -2566,6 +2581,78 @@ func (mr *MockOrganizationClientMockRecorder) OrganizationList(ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationList", reflect.TypeOf((*MockOrganizationClient)(nil).OrganizationList), ctx)
 }
 
+// OrganizationPolicyCreate mocks base method.
+func (m *MockOrganizationClient) OrganizationPolicyCreate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyCreate", ctx, organizationIdentifier, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrganizationPolicyCreate indicates an expected call of OrganizationPolicyCreate.
+func (mr *MockOrganizationClientMockRecorder) OrganizationPolicyCreate(ctx, organizationIdentifier, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyCreate", reflect.TypeOf((*MockOrganizationClient)(nil).OrganizationPolicyCreate), ctx, organizationIdentifier, p)
+}
+
+// OrganizationPolicyDelete mocks base method.
+func (m *MockOrganizationClient) OrganizationPolicyDelete(ctx context.Context, organizationIdentifier, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyDelete", ctx, organizationIdentifier, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrganizationPolicyDelete indicates an expected call of OrganizationPolicyDelete.
+func (mr *MockOrganizationClientMockRecorder) OrganizationPolicyDelete(ctx, organizationIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyDelete", reflect.TypeOf((*MockOrganizationClient)(nil).OrganizationPolicyDelete), ctx, organizationIdentifier, name)
+}
+
+// OrganizationPolicyGet mocks base method.
+func (m *MockOrganizationClient) OrganizationPolicyGet(ctx context.Context, organizationIdentifier, name string) (*sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyGet", ctx, organizationIdentifier, name)
+	ret0, _ := ret[0].(*sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrganizationPolicyGet indicates an expected call of OrganizationPolicyGet.
+func (mr *MockOrganizationClientMockRecorder) OrganizationPolicyGet(ctx, organizationIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyGet", reflect.TypeOf((*MockOrganizationClient)(nil).OrganizationPolicyGet), ctx, organizationIdentifier, name)
+}
+
+// OrganizationPolicyList mocks base method.
+func (m *MockOrganizationClient) OrganizationPolicyList(ctx context.Context, organizationIdentifier string) ([]sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyList", ctx, organizationIdentifier)
+	ret0, _ := ret[0].([]sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrganizationPolicyList indicates an expected call of OrganizationPolicyList.
+func (mr *MockOrganizationClientMockRecorder) OrganizationPolicyList(ctx, organizationIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyList", reflect.TypeOf((*MockOrganizationClient)(nil).OrganizationPolicyList), ctx, organizationIdentifier)
+}
+
+// OrganizationPolicyUpdate mocks base method.
+func (m *MockOrganizationClient) OrganizationPolicyUpdate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyUpdate", ctx, organizationIdentifier, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrganizationPolicyUpdate indicates an expected call of OrganizationPolicyUpdate.
+func (mr *MockOrganizationClientMockRecorder) OrganizationPolicyUpdate(ctx, organizationIdentifier, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyUpdate", reflect.TypeOf((*MockOrganizationClient)(nil).OrganizationPolicyUpdate), ctx, organizationIdentifier, p)
+}
+
 // MockRegionClient is a mock of RegionClient interface.
 type MockRegionClient struct {
 	ctrl     *gomock.Controller
@@ -2648,6 +2735,132 @@ func (mr *MockRegionClientMockRecorder) RegionList(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegionList", reflect.TypeOf((*MockRegionClient)(nil).RegionList), ctx)
 }
 
+// MockSharedConcurrencyClient is a mock of SharedConcurrencyClient interface.
+type MockSharedConcurrencyClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSharedConcurrencyClientMockRecorder
+	isgomock struct{}
+}
+
+// MockSharedConcurrencyClientMockRecorder is the mock recorder for MockSharedConcurrencyClient.
+type MockSharedConcurrencyClientMockRecorder struct {
+	mock *MockSharedConcurrencyClient
+}
+
+// NewMockSharedConcurrencyClient creates a new mock instance.
+func NewMockSharedConcurrencyClient(ctrl *gomock.Controller) *MockSharedConcurrencyClient {
+	mock := &MockSharedConcurrencyClient{ctrl: ctrl}
+	mock.recorder = &MockSharedConcurrencyClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSharedConcurrencyClient) EXPECT() *MockSharedConcurrencyClientMockRecorder {
+	return m.recorder
+}
+
+// SharedConcurrencyCreate mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyCreate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, c *sdk.SharedConcurrency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyCreate", ctx, scope, scopeIdentifier, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SharedConcurrencyCreate indicates an expected call of SharedConcurrencyCreate.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyCreate(ctx, scope, scopeIdentifier, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyCreate", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyCreate), ctx, scope, scopeIdentifier, c)
+}
+
+// SharedConcurrencyDelete mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyDelete(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyDelete", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SharedConcurrencyDelete indicates an expected call of SharedConcurrencyDelete.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyDelete(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyDelete", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyDelete), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyGet mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyGet(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) (*sdk.SharedConcurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyGet", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].(*sdk.SharedConcurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyGet indicates an expected call of SharedConcurrencyGet.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyGet(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyGet", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyGet), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyList mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyList(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string) ([]sdk.SharedConcurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyList", ctx, scope, scopeIdentifier)
+	ret0, _ := ret[0].([]sdk.SharedConcurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyList indicates an expected call of SharedConcurrencyList.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyList(ctx, scope, scopeIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyList", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyList), ctx, scope, scopeIdentifier)
+}
+
+// SharedConcurrencyListRuns mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyListRuns(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyListRuns", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].([]sdk.ProjectConcurrencyRunObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyListRuns indicates an expected call of SharedConcurrencyListRuns.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyListRuns(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyListRuns", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyListRuns), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyRelease mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyRelease(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyRelease", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].([]sdk.ProjectConcurrencyRunObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyRelease indicates an expected call of SharedConcurrencyRelease.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyRelease(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyRelease", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyRelease), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyUpdate mocks base method.
+func (m *MockSharedConcurrencyClient) SharedConcurrencyUpdate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, c *sdk.SharedConcurrency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyUpdate", ctx, scope, scopeIdentifier, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SharedConcurrencyUpdate indicates an expected call of SharedConcurrencyUpdate.
+func (mr *MockSharedConcurrencyClientMockRecorder) SharedConcurrencyUpdate(ctx, scope, scopeIdentifier, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyUpdate", reflect.TypeOf((*MockSharedConcurrencyClient)(nil).SharedConcurrencyUpdate), ctx, scope, scopeIdentifier, c)
+}
+
 // MockHatcheryClient is a mock of HatcheryClient interface.
 type MockHatcheryClient struct {
 	ctrl     *gomock.Controller
@@ -2898,6 +3111,49 @@ func (mr *MockHatcheryServiceClientMockRecorder) V2QueueGetJobRun(ctx, regionNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueJobDebugSession mocks base method.
+func (m *MockHatcheryServiceClient) V2QueueJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebugSession", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebugSession indicates an expected call of V2QueueJobDebugSession.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueJobDebugSession(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebugSession", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueJobDebugSession), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
+// V2QueueJobExternalRunResultAccess mocks base method.
+func (m *MockHatcheryServiceClient) V2QueueJobExternalRunResultAccess(ctx context.Context, regionName, jobRunID, workflowRunID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultAccess", ctx, regionName, jobRunID, workflowRunID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobExternalRunResultAccess indicates an expected call of V2QueueJobExternalRunResultAccess.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueJobExternalRunResultAccess(ctx, regionName, jobRunID, workflowRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultAccess", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueJobExternalRunResultAccess), ctx, regionName, jobRunID, workflowRunID)
+}
+
+// V2QueueJobExternalRunResultsGet mocks base method.
+func (m *MockHatcheryServiceClient) V2QueueJobExternalRunResultsGet(ctx context.Context, regionName, jobRunID, runRef string) ([]sdk.V2WorkflowRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultsGet", ctx, regionName, jobRunID, runRef)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobExternalRunResultsGet indicates an expected call of V2QueueJobExternalRunResultsGet.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueueJobExternalRunResultsGet(ctx, regionName, jobRunID, runRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultsGet", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueueJobExternalRunResultsGet), ctx, regionName, jobRunID, runRef)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockHatcheryServiceClient) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -3017,6 +3273,20 @@ func (mr *MockHatcheryServiceClientMockRecorder) V2QueuePolling(ctx, region, osa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePolling", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueuePolling), varargs...)
 }
 
+// V2QueuePushJobAnnotations mocks base method.
+func (m *MockHatcheryServiceClient) V2QueuePushJobAnnotations(ctx context.Context, regionName, jobRunID string, annotations sdk.VCSAnnotations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobAnnotations", ctx, regionName, jobRunID, annotations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobAnnotations indicates an expected call of V2QueuePushJobAnnotations.
+func (mr *MockHatcheryServiceClientMockRecorder) V2QueuePushJobAnnotations(ctx, regionName, jobRunID, annotations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobAnnotations", reflect.TypeOf((*MockHatcheryServiceClient)(nil).V2QueuePushJobAnnotations), ctx, regionName, jobRunID, annotations)
+}
+
 // V2QueuePushJobInfo mocks base method.
 func (m *MockHatcheryServiceClient) V2QueuePushJobInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2SendJobRunInfo) error {
 	m.ctrl.T.Helper()
@@ -3099,6 +3369,21 @@ func (m *MockProjectClientV2) EXPECT() *MockProjectClientV2MockRecorder {
 	return m.recorder
 }
 
+// ProjectArchiveKey mocks base method.
+func (m *MockProjectClientV2) ProjectArchiveKey(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectArchiveKey", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectArchiveKey indicates an expected call of ProjectArchiveKey.
+func (mr *MockProjectClientV2MockRecorder) ProjectArchiveKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectArchiveKey", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectArchiveKey), ctx)
+}
+
 // ProjectConcurrencyCreate mocks base method.
 func (m *MockProjectClientV2) ProjectConcurrencyCreate(ctx context.Context, pKey string, c *sdk.ProjectConcurrency) error {
 	m.ctrl.T.Helper()
@@ -3186,6 +3471,41 @@ func (mr *MockProjectClientV2MockRecorder) ProjectConcurrencyUpdate(ctx, pKey, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectConcurrencyUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectConcurrencyUpdate), ctx, pKey, c)
 }
 
+// ProjectExport mocks base method.
+func (m *MockProjectClientV2) ProjectExport(ctx context.Context, pKey string, req sdk.ProjectExportRequest) (*sdk.SignedProjectArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectExport", ctx, pKey, req)
+	ret0, _ := ret[0].(*sdk.SignedProjectArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectExport indicates an expected call of ProjectExport.
+func (mr *MockProjectClientV2MockRecorder) ProjectExport(ctx, pKey, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectExport", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectExport), ctx, pKey, req)
+}
+
+// ProjectImport mocks base method.
+func (m *MockProjectClientV2) ProjectImport(ctx context.Context, pKey string, archive sdk.SignedProjectArchive, mods ...cdsclient.RequestModifier) ([]sdk.ProjectImportReportLine, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey, archive}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectImport", varargs...)
+	ret0, _ := ret[0].([]sdk.ProjectImportReportLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectImport indicates an expected call of ProjectImport.
+func (mr *MockProjectClientV2MockRecorder) ProjectImport(ctx, pKey, archive any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey, archive}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectImport", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectImport), varargs...)
+}
+
 // ProjectNotificationCreate mocks base method.
 func (m *MockProjectClientV2) ProjectNotificationCreate(ctx context.Context, pKey string, notif *sdk.ProjectNotification) error {
 	m.ctrl.T.Helper()
@@ -3258,6 +3578,78 @@ func (mr *MockProjectClientV2MockRecorder) ProjectNotificationUpdate(ctx, pKey,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectNotificationUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectNotificationUpdate), ctx, pKey, notif)
 }
 
+// ProjectPolicyCreate mocks base method.
+func (m *MockProjectClientV2) ProjectPolicyCreate(ctx context.Context, pKey string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyCreate", ctx, pKey, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectPolicyCreate indicates an expected call of ProjectPolicyCreate.
+func (mr *MockProjectClientV2MockRecorder) ProjectPolicyCreate(ctx, pKey, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyCreate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectPolicyCreate), ctx, pKey, p)
+}
+
+// ProjectPolicyDelete mocks base method.
+func (m *MockProjectClientV2) ProjectPolicyDelete(ctx context.Context, pKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyDelete", ctx, pKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectPolicyDelete indicates an expected call of ProjectPolicyDelete.
+func (mr *MockProjectClientV2MockRecorder) ProjectPolicyDelete(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyDelete", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectPolicyDelete), ctx, pKey, name)
+}
+
+// ProjectPolicyGet mocks base method.
+func (m *MockProjectClientV2) ProjectPolicyGet(ctx context.Context, pKey, name string) (*sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyGet", ctx, pKey, name)
+	ret0, _ := ret[0].(*sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectPolicyGet indicates an expected call of ProjectPolicyGet.
+func (mr *MockProjectClientV2MockRecorder) ProjectPolicyGet(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyGet", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectPolicyGet), ctx, pKey, name)
+}
+
+// ProjectPolicyList mocks base method.
+func (m *MockProjectClientV2) ProjectPolicyList(ctx context.Context, pKey string, withOrganization bool) ([]sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyList", ctx, pKey, withOrganization)
+	ret0, _ := ret[0].([]sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectPolicyList indicates an expected call of ProjectPolicyList.
+func (mr *MockProjectClientV2MockRecorder) ProjectPolicyList(ctx, pKey, withOrganization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyList", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectPolicyList), ctx, pKey, withOrganization)
+}
+
+// ProjectPolicyUpdate mocks base method.
+func (m *MockProjectClientV2) ProjectPolicyUpdate(ctx context.Context, pKey string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyUpdate", ctx, pKey, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectPolicyUpdate indicates an expected call of ProjectPolicyUpdate.
+func (mr *MockProjectClientV2MockRecorder) ProjectPolicyUpdate(ctx, pKey, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyUpdate", reflect.TypeOf((*MockProjectClientV2)(nil).ProjectPolicyUpdate), ctx, pKey, p)
+}
+
 // ProjectRunPurge mocks base method.
 func (m *MockProjectClientV2) ProjectRunPurge(ctx context.Context, projectKey string) error {
 	m.ctrl.T.Helper()
@@ -3981,15 +4373,59 @@ func (mr *MockProjectClientMockRecorder) ProjectRepositoryEvents(ctx, projectKey
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryEvents", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryEvents), ctx, projectKey, vcsName, repoName)
 }
 
-// ProjectUpdate mocks base method.
-func (m *MockProjectClient) ProjectUpdate(key string, project *sdk.Project) error {
+// ProjectRepositoryMergeQueueAdd mocks base method.
+func (m *MockProjectClient) ProjectRepositoryMergeQueueAdd(ctx context.Context, projectKey, vcsName, repoName string, pullRequestID int64) (*sdk.MergeQueueEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectUpdate", key, project)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectRepositoryMergeQueueAdd", ctx, projectKey, vcsName, repoName, pullRequestID)
+	ret0, _ := ret[0].(*sdk.MergeQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ProjectUpdate indicates an expected call of ProjectUpdate.
+// ProjectRepositoryMergeQueueAdd indicates an expected call of ProjectRepositoryMergeQueueAdd.
+func (mr *MockProjectClientMockRecorder) ProjectRepositoryMergeQueueAdd(ctx, projectKey, vcsName, repoName, pullRequestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryMergeQueueAdd", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryMergeQueueAdd), ctx, projectKey, vcsName, repoName, pullRequestID)
+}
+
+// ProjectRepositoryMergeQueueDelete mocks base method.
+func (m *MockProjectClient) ProjectRepositoryMergeQueueDelete(ctx context.Context, projectKey, vcsName, repoName, entryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryMergeQueueDelete", ctx, projectKey, vcsName, repoName, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRepositoryMergeQueueDelete indicates an expected call of ProjectRepositoryMergeQueueDelete.
+func (mr *MockProjectClientMockRecorder) ProjectRepositoryMergeQueueDelete(ctx, projectKey, vcsName, repoName, entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryMergeQueueDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryMergeQueueDelete), ctx, projectKey, vcsName, repoName, entryID)
+}
+
+// ProjectRepositoryMergeQueueList mocks base method.
+func (m *MockProjectClient) ProjectRepositoryMergeQueueList(ctx context.Context, projectKey, vcsName, repoName string) ([]sdk.MergeQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryMergeQueueList", ctx, projectKey, vcsName, repoName)
+	ret0, _ := ret[0].([]sdk.MergeQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRepositoryMergeQueueList indicates an expected call of ProjectRepositoryMergeQueueList.
+func (mr *MockProjectClientMockRecorder) ProjectRepositoryMergeQueueList(ctx, projectKey, vcsName, repoName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryMergeQueueList", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryMergeQueueList), ctx, projectKey, vcsName, repoName)
+}
+
+// ProjectUpdate mocks base method.
+func (m *MockProjectClient) ProjectUpdate(key string, project *sdk.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectUpdate", key, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectUpdate indicates an expected call of ProjectUpdate.
 func (mr *MockProjectClientMockRecorder) ProjectUpdate(key, project any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectUpdate", reflect.TypeOf((*MockProjectClient)(nil).ProjectUpdate), key, project)
@@ -4204,6 +4640,70 @@ func (mr *MockProjectClientMockRecorder) VariableListEncrypt(projectKey any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariableListEncrypt", reflect.TypeOf((*MockProjectClient)(nil).VariableListEncrypt), projectKey)
 }
 
+// MockAuditV2Client is a mock of AuditV2Client interface.
+type MockAuditV2Client struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditV2ClientMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditV2ClientMockRecorder is the mock recorder for MockAuditV2Client.
+type MockAuditV2ClientMockRecorder struct {
+	mock *MockAuditV2Client
+}
+
+// NewMockAuditV2Client creates a new mock instance.
+func NewMockAuditV2Client(ctrl *gomock.Controller) *MockAuditV2Client {
+	mock := &MockAuditV2Client{ctrl: ctrl}
+	mock.recorder = &MockAuditV2ClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditV2Client) EXPECT() *MockAuditV2ClientMockRecorder {
+	return m.recorder
+}
+
+// AuditV2List mocks base method.
+func (m *MockAuditV2Client) AuditV2List(ctx context.Context, mods ...cdsclient.RequestModifier) ([]sdk.AuditV2, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AuditV2List", varargs...)
+	ret0, _ := ret[0].([]sdk.AuditV2)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditV2List indicates an expected call of AuditV2List.
+func (mr *MockAuditV2ClientMockRecorder) AuditV2List(ctx any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditV2List", reflect.TypeOf((*MockAuditV2Client)(nil).AuditV2List), varargs...)
+}
+
+// ProjectAuditV2List mocks base method.
+func (m *MockAuditV2Client) ProjectAuditV2List(ctx context.Context, pKey string, mods ...cdsclient.RequestModifier) ([]sdk.AuditV2, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectAuditV2List", varargs...)
+	ret0, _ := ret[0].([]sdk.AuditV2)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectAuditV2List indicates an expected call of ProjectAuditV2List.
+func (mr *MockAuditV2ClientMockRecorder) ProjectAuditV2List(ctx, pKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectAuditV2List", reflect.TypeOf((*MockAuditV2Client)(nil).ProjectAuditV2List), varargs...)
+}
+
 // MockRBACClient is a mock of RBACClient interface.
 type MockRBACClient struct {
 	ctrl     *gomock.Controller
@@ -4257,6 +4757,86 @@ func (mr *MockRBACClientMockRecorder) RBACGet(ctx, permissionIdentifier any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGet", reflect.TypeOf((*MockRBACClient)(nil).RBACGet), ctx, permissionIdentifier)
 }
 
+// RBACGrantAudits mocks base method.
+func (m *MockRBACClient) RBACGrantAudits(ctx context.Context, grantID string) ([]sdk.AuditRBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantAudits", ctx, grantID)
+	ret0, _ := ret[0].([]sdk.AuditRBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantAudits indicates an expected call of RBACGrantAudits.
+func (mr *MockRBACClientMockRecorder) RBACGrantAudits(ctx, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantAudits", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantAudits), ctx, grantID)
+}
+
+// RBACGrantGet mocks base method.
+func (m *MockRBACClient) RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantGet", ctx, grantID)
+	ret0, _ := ret[0].(sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantGet indicates an expected call of RBACGrantGet.
+func (mr *MockRBACClientMockRecorder) RBACGrantGet(ctx, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantGet", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantGet), ctx, grantID)
+}
+
+// RBACGrantList mocks base method.
+func (m *MockRBACClient) RBACGrantList(ctx context.Context, status ...string) ([]sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RBACGrantList", varargs...)
+	ret0, _ := ret[0].([]sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantList indicates an expected call of RBACGrantList.
+func (mr *MockRBACClientMockRecorder) RBACGrantList(ctx any, status ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantList", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantList), varargs...)
+}
+
+// RBACGrantRequest mocks base method.
+func (m *MockRBACClient) RBACGrantRequest(ctx context.Context, grantRequest sdk.RBACGrantRequest) (sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantRequest", ctx, grantRequest)
+	ret0, _ := ret[0].(sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantRequest indicates an expected call of RBACGrantRequest.
+func (mr *MockRBACClientMockRecorder) RBACGrantRequest(ctx, grantRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantRequest", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantRequest), ctx, grantRequest)
+}
+
+// RBACGrantReview mocks base method.
+func (m *MockRBACClient) RBACGrantReview(ctx context.Context, grantID, action string, review sdk.RBACGrantReview) (sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantReview", ctx, grantID, action, review)
+	ret0, _ := ret[0].(sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantReview indicates an expected call of RBACGrantReview.
+func (mr *MockRBACClientMockRecorder) RBACGrantReview(ctx, grantID, action, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantReview", reflect.TypeOf((*MockRBACClient)(nil).RBACGrantReview), ctx, grantID, action, review)
+}
+
 // RBACGroupPermission mocks base method.
 func (m *MockRBACClient) RBACGroupPermission(ctx context.Context, groupName string) (sdk.PermissionSummary, error) {
 	m.ctrl.T.Helper()
@@ -4322,6 +4902,21 @@ func (mr *MockRBACClientMockRecorder) RBACUserPermission(ctx, username any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermission", reflect.TypeOf((*MockRBACClient)(nil).RBACUserPermission), ctx, username)
 }
 
+// RBACWorkflowCheck mocks base method.
+func (m *MockRBACClient) RBACWorkflowCheck(ctx context.Context, projectKey string, checkRequest sdk.RBACWorkflowCheckRequest) (*sdk.RBACWorkflowCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACWorkflowCheck", ctx, projectKey, checkRequest)
+	ret0, _ := ret[0].(*sdk.RBACWorkflowCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACWorkflowCheck indicates an expected call of RBACWorkflowCheck.
+func (mr *MockRBACClientMockRecorder) RBACWorkflowCheck(ctx, projectKey, checkRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACWorkflowCheck", reflect.TypeOf((*MockRBACClient)(nil).RBACWorkflowCheck), ctx, projectKey, checkRequest)
+}
+
 // MockProjectKeysClient is a mock of ProjectKeysClient interface.
 type MockProjectKeysClient struct {
 	ctrl     *gomock.Controller
@@ -4611,6 +5206,49 @@ func (mr *MockV2QueueClientMockRecorder) V2QueueGetJobRun(ctx, regionName, id an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueJobDebugSession mocks base method.
+func (m *MockV2QueueClient) V2QueueJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebugSession", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebugSession indicates an expected call of V2QueueJobDebugSession.
+func (mr *MockV2QueueClientMockRecorder) V2QueueJobDebugSession(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebugSession", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueJobDebugSession), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
+// V2QueueJobExternalRunResultAccess mocks base method.
+func (m *MockV2QueueClient) V2QueueJobExternalRunResultAccess(ctx context.Context, regionName, jobRunID, workflowRunID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultAccess", ctx, regionName, jobRunID, workflowRunID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobExternalRunResultAccess indicates an expected call of V2QueueJobExternalRunResultAccess.
+func (mr *MockV2QueueClientMockRecorder) V2QueueJobExternalRunResultAccess(ctx, regionName, jobRunID, workflowRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultAccess", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueJobExternalRunResultAccess), ctx, regionName, jobRunID, workflowRunID)
+}
+
+// V2QueueJobExternalRunResultsGet mocks base method.
+func (m *MockV2QueueClient) V2QueueJobExternalRunResultsGet(ctx context.Context, regionName, jobRunID, runRef string) ([]sdk.V2WorkflowRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultsGet", ctx, regionName, jobRunID, runRef)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobExternalRunResultsGet indicates an expected call of V2QueueJobExternalRunResultsGet.
+func (mr *MockV2QueueClientMockRecorder) V2QueueJobExternalRunResultsGet(ctx, regionName, jobRunID, runRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultsGet", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueueJobExternalRunResultsGet), ctx, regionName, jobRunID, runRef)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockV2QueueClient) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -4730,6 +5368,20 @@ func (mr *MockV2QueueClientMockRecorder) V2QueuePolling(ctx, region, osarch, goR
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePolling", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueuePolling), varargs...)
 }
 
+// V2QueuePushJobAnnotations mocks base method.
+func (m *MockV2QueueClient) V2QueuePushJobAnnotations(ctx context.Context, regionName, jobRunID string, annotations sdk.VCSAnnotations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobAnnotations", ctx, regionName, jobRunID, annotations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobAnnotations indicates an expected call of V2QueuePushJobAnnotations.
+func (mr *MockV2QueueClientMockRecorder) V2QueuePushJobAnnotations(ctx, regionName, jobRunID, annotations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobAnnotations", reflect.TypeOf((*MockV2QueueClient)(nil).V2QueuePushJobAnnotations), ctx, regionName, jobRunID, annotations)
+}
+
 // V2QueuePushJobInfo mocks base method.
 func (m *MockV2QueueClient) V2QueuePushJobInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2SendJobRunInfo) error {
 	m.ctrl.T.Helper()
@@ -5876,6 +6528,21 @@ func (mr *MockHookClientMockRecorder) HookListAllSchedulerHooks(ctx any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookListAllSchedulerHooks", reflect.TypeOf((*MockHookClient)(nil).HookListAllSchedulerHooks), ctx)
 }
 
+// HookPollingRepositoriesList mocks base method.
+func (m *MockHookClient) HookPollingRepositoriesList(ctx context.Context) ([]sdk.HookPollingRepository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HookPollingRepositoriesList", ctx)
+	ret0, _ := ret[0].([]sdk.HookPollingRepository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HookPollingRepositoriesList indicates an expected call of HookPollingRepositoriesList.
+func (mr *MockHookClientMockRecorder) HookPollingRepositoriesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookPollingRepositoriesList", reflect.TypeOf((*MockHookClient)(nil).HookPollingRepositoriesList), ctx)
+}
+
 // HookRepositoriesList mocks base method.
 func (m *MockHookClient) HookRepositoriesList(ctx context.Context, vcsServer, repoName string) ([]sdk.ProjectRepository, error) {
 	m.ctrl.T.Helper()
@@ -5891,6 +6558,21 @@ func (mr *MockHookClientMockRecorder) HookRepositoriesList(ctx, vcsServer, repoN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookRepositoriesList", reflect.TypeOf((*MockHookClient)(nil).HookRepositoriesList), ctx, vcsServer, repoName)
 }
 
+// HookRepositoryRefs mocks base method.
+func (m *MockHookClient) HookRepositoryRefs(ctx context.Context, projKey, vcsServer, repoName string) (sdk.HookRepositoryRefs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HookRepositoryRefs", ctx, projKey, vcsServer, repoName)
+	ret0, _ := ret[0].(sdk.HookRepositoryRefs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HookRepositoryRefs indicates an expected call of HookRepositoryRefs.
+func (mr *MockHookClientMockRecorder) HookRepositoryRefs(ctx, projKey, vcsServer, repoName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookRepositoryRefs", reflect.TypeOf((*MockHookClient)(nil).HookRepositoryRefs), ctx, projKey, vcsServer, repoName)
+}
+
 // ListWorkflowToTrigger mocks base method.
 func (m *MockHookClient) ListWorkflowToTrigger(ctx context.Context, req sdk.HookListWorkflowRequest) ([]sdk.V2WorkflowHook, error) {
 	m.ctrl.T.Helper()
@@ -6045,6 +6727,46 @@ func (m *MockWorkflowV2Client) EXPECT() *MockWorkflowV2ClientMockRecorder {
 	return m.recorder
 }
 
+// WorkflowV2CoverageTrend mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2CoverageTrend(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, ref string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunCoverage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, ref}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2CoverageTrend", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2CoverageTrend indicates an expected call of WorkflowV2CoverageTrend.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2CoverageTrend(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, ref any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, ref}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2CoverageTrend", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2CoverageTrend), varargs...)
+}
+
+// WorkflowV2FlakyTestCases mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2FlakyTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2FlakyTestCases", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestCaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2FlakyTestCases indicates an expected call of WorkflowV2FlakyTestCases.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2FlakyTestCases(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2FlakyTestCases", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2FlakyTestCases), varargs...)
+}
+
 // WorkflowV2JobStart mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2JobStart(ctx context.Context, projectKey, workflowRunID, jobIdentifier string, payload map[string]any, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -6125,6 +6847,26 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2Run(ctx, projectKey, vcsId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2Run", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2Run), varargs...)
 }
 
+// WorkflowV2RunCoverage mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunCoverage(ctx context.Context, projKey, workflowRunID string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRunCoverage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, workflowRunID}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunCoverage", varargs...)
+	ret0, _ := ret[0].(*sdk.V2WorkflowRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunCoverage indicates an expected call of WorkflowV2RunCoverage.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunCoverage(ctx, projKey, workflowRunID any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, workflowRunID}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunCoverage", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunCoverage), varargs...)
+}
+
 // WorkflowV2RunDelete mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2RunDelete(ctx context.Context, projectKey, runIdentifier string) error {
 	m.ctrl.T.Helper()
@@ -6194,6 +6936,20 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJob(ctx, projKey, workf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJob", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJob), ctx, projKey, workflowRunID, jobRunID)
 }
 
+// WorkflowV2RunJobDebugSession mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobDebugSession", ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowV2RunJobDebugSession indicates an expected call of WorkflowV2RunJobDebugSession.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunJobDebugSession(ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobDebugSession", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunJobDebugSession), ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
 // WorkflowV2RunJobInfoList mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobInfo, error) {
 	m.ctrl.T.Helper()
@@ -6324,6 +7080,66 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunStatus(ctx, projectKey,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunStatus", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunStatus), ctx, projectKey, workflowRunID)
 }
 
+// WorkflowV2RunTestCaseList mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2RunTestCaseList(ctx context.Context, projKey, workflowRunID string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunTestCase, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, workflowRunID}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunTestCaseList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunTestCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunTestCaseList indicates an expected call of WorkflowV2RunTestCaseList.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2RunTestCaseList(ctx, projKey, workflowRunID any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, workflowRunID}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunTestCaseList", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2RunTestCaseList), varargs...)
+}
+
+// WorkflowV2ScheduleList mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2ScheduleList(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowScheduleExecution, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2ScheduleList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowScheduleExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2ScheduleList indicates an expected call of WorkflowV2ScheduleList.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2ScheduleList(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2ScheduleList", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2ScheduleList), varargs...)
+}
+
+// WorkflowV2SlowestTestCases mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2SlowestTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2SlowestTestCases", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestCaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2SlowestTestCases indicates an expected call of WorkflowV2SlowestTestCases.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2SlowestTestCases(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2SlowestTestCases", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2SlowestTestCases), varargs...)
+}
+
 // WorkflowV2Stop mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error {
 	m.ctrl.T.Helper()
@@ -6352,6 +7168,26 @@ func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2StopJob(ctx, projKey, work
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2StopJob", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2StopJob), ctx, projKey, workflowRunID, jobIdentifier)
 }
 
+// WorkflowV2TestCaseHistory mocks base method.
+func (m *MockWorkflowV2Client) WorkflowV2TestCaseHistory(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunTestCase, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestCaseHistory", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunTestCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestCaseHistory indicates an expected call of WorkflowV2TestCaseHistory.
+func (mr *MockWorkflowV2ClientMockRecorder) WorkflowV2TestCaseHistory(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestCaseHistory", reflect.TypeOf((*MockWorkflowV2Client)(nil).WorkflowV2TestCaseHistory), varargs...)
+}
+
 // WorkflowV2VersionDelete mocks base method.
 func (m *MockWorkflowV2Client) WorkflowV2VersionDelete(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, version string) error {
 	m.ctrl.T.Helper()
@@ -7515,24 +8351,10 @@ func (mr *MockInterfaceMockRecorder) AdminUserCreate(ctx, user any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserCreate", reflect.TypeOf((*MockInterface)(nil).AdminUserCreate), ctx, user)
 }
 
-// AdminUserSetContact mocks base method.
-func (m *MockInterface) AdminUserSetContact(ctx context.Context, username string, contact sdk.UserContact) error {
+// AdminUserLinkCreate mocks base method.
+func (m *MockInterface) AdminUserLinkCreate(ctx context.Context, username string, link sdk.UserLink) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminUserSetContact", ctx, username, contact)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// AdminUserSetContact indicates an expected call of AdminUserSetContact.
-func (mr *MockInterfaceMockRecorder) AdminUserSetContact(ctx, username, contact any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserSetContact", reflect.TypeOf((*MockInterface)(nil).AdminUserSetContact), ctx, username, contact)
-}
-
-// AdminUserLinkCreate mocks base method.
-func (m *MockInterface) AdminUserLinkCreate(ctx context.Context, username string, link sdk.UserLink) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AdminUserLinkCreate", ctx, username, link)
+	ret := m.ctrl.Call(m, "AdminUserLinkCreate", ctx, username, link)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
@@ -7557,6 +8379,20 @@ func (mr *MockInterfaceMockRecorder) AdminUserLinkDelete(ctx, username, link any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserLinkDelete", reflect.TypeOf((*MockInterface)(nil).AdminUserLinkDelete), ctx, username, link)
 }
 
+// AdminUserSetContact mocks base method.
+func (m *MockInterface) AdminUserSetContact(ctx context.Context, username string, contact sdk.UserContact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminUserSetContact", ctx, username, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdminUserSetContact indicates an expected call of AdminUserSetContact.
+func (mr *MockInterfaceMockRecorder) AdminUserSetContact(ctx, username, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminUserSetContact", reflect.TypeOf((*MockInterface)(nil).AdminUserSetContact), ctx, username, contact)
+}
+
 // AdminWorkflowUpdateMaxRuns mocks base method.
 func (m *MockInterface) AdminWorkflowUpdateMaxRuns(projectKey, workflowName string, maxRuns int64) error {
 	m.ctrl.T.Helper()
@@ -7817,6 +8653,26 @@ func (mr *MockInterfaceMockRecorder) ApplicationVariablesList(projectKey, appNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationVariablesList", reflect.TypeOf((*MockInterface)(nil).ApplicationVariablesList), projectKey, appName)
 }
 
+// AuditV2List mocks base method.
+func (m *MockInterface) AuditV2List(ctx context.Context, mods ...cdsclient.RequestModifier) ([]sdk.AuditV2, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AuditV2List", varargs...)
+	ret0, _ := ret[0].([]sdk.AuditV2)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditV2List indicates an expected call of AuditV2List.
+func (mr *MockInterfaceMockRecorder) AuditV2List(ctx any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditV2List", reflect.TypeOf((*MockInterface)(nil).AuditV2List), varargs...)
+}
+
 // AuthConsumerCreateForUser mocks base method.
 func (m *MockInterface) AuthConsumerCreateForUser(username string, request sdk.AuthUserConsumer) (sdk.AuthConsumerCreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -8875,6 +9731,21 @@ func (mr *MockInterfaceMockRecorder) HookListAllSchedulerHooks(ctx any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookListAllSchedulerHooks", reflect.TypeOf((*MockInterface)(nil).HookListAllSchedulerHooks), ctx)
 }
 
+// HookPollingRepositoriesList mocks base method.
+func (m *MockInterface) HookPollingRepositoriesList(ctx context.Context) ([]sdk.HookPollingRepository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HookPollingRepositoriesList", ctx)
+	ret0, _ := ret[0].([]sdk.HookPollingRepository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HookPollingRepositoriesList indicates an expected call of HookPollingRepositoriesList.
+func (mr *MockInterfaceMockRecorder) HookPollingRepositoriesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookPollingRepositoriesList", reflect.TypeOf((*MockInterface)(nil).HookPollingRepositoriesList), ctx)
+}
+
 // HookRepositoriesList mocks base method.
 func (m *MockInterface) HookRepositoriesList(ctx context.Context, vcsServer, repoName string) ([]sdk.ProjectRepository, error) {
 	m.ctrl.T.Helper()
@@ -8890,6 +9761,21 @@ func (mr *MockInterfaceMockRecorder) HookRepositoriesList(ctx, vcsServer, repoNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookRepositoriesList", reflect.TypeOf((*MockInterface)(nil).HookRepositoriesList), ctx, vcsServer, repoName)
 }
 
+// HookRepositoryRefs mocks base method.
+func (m *MockInterface) HookRepositoryRefs(ctx context.Context, projKey, vcsServer, repoName string) (sdk.HookRepositoryRefs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HookRepositoryRefs", ctx, projKey, vcsServer, repoName)
+	ret0, _ := ret[0].(sdk.HookRepositoryRefs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HookRepositoryRefs indicates an expected call of HookRepositoryRefs.
+func (mr *MockInterfaceMockRecorder) HookRepositoryRefs(ctx, projKey, vcsServer, repoName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HookRepositoryRefs", reflect.TypeOf((*MockInterface)(nil).HookRepositoryRefs), ctx, projKey, vcsServer, repoName)
+}
+
 // IntegrationModelAdd mocks base method.
 func (m_2 *MockInterface) IntegrationModelAdd(m *sdk.IntegrationModel) error {
 	m_2.ctrl.T.Helper()
@@ -9094,6 +9980,78 @@ func (mr *MockInterfaceMockRecorder) OrganizationList(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationList", reflect.TypeOf((*MockInterface)(nil).OrganizationList), ctx)
 }
 
+// OrganizationPolicyCreate mocks base method.
+func (m *MockInterface) OrganizationPolicyCreate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyCreate", ctx, organizationIdentifier, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrganizationPolicyCreate indicates an expected call of OrganizationPolicyCreate.
+func (mr *MockInterfaceMockRecorder) OrganizationPolicyCreate(ctx, organizationIdentifier, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyCreate", reflect.TypeOf((*MockInterface)(nil).OrganizationPolicyCreate), ctx, organizationIdentifier, p)
+}
+
+// OrganizationPolicyDelete mocks base method.
+func (m *MockInterface) OrganizationPolicyDelete(ctx context.Context, organizationIdentifier, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyDelete", ctx, organizationIdentifier, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrganizationPolicyDelete indicates an expected call of OrganizationPolicyDelete.
+func (mr *MockInterfaceMockRecorder) OrganizationPolicyDelete(ctx, organizationIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyDelete", reflect.TypeOf((*MockInterface)(nil).OrganizationPolicyDelete), ctx, organizationIdentifier, name)
+}
+
+// OrganizationPolicyGet mocks base method.
+func (m *MockInterface) OrganizationPolicyGet(ctx context.Context, organizationIdentifier, name string) (*sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyGet", ctx, organizationIdentifier, name)
+	ret0, _ := ret[0].(*sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrganizationPolicyGet indicates an expected call of OrganizationPolicyGet.
+func (mr *MockInterfaceMockRecorder) OrganizationPolicyGet(ctx, organizationIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyGet", reflect.TypeOf((*MockInterface)(nil).OrganizationPolicyGet), ctx, organizationIdentifier, name)
+}
+
+// OrganizationPolicyList mocks base method.
+func (m *MockInterface) OrganizationPolicyList(ctx context.Context, organizationIdentifier string) ([]sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyList", ctx, organizationIdentifier)
+	ret0, _ := ret[0].([]sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrganizationPolicyList indicates an expected call of OrganizationPolicyList.
+func (mr *MockInterfaceMockRecorder) OrganizationPolicyList(ctx, organizationIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyList", reflect.TypeOf((*MockInterface)(nil).OrganizationPolicyList), ctx, organizationIdentifier)
+}
+
+// OrganizationPolicyUpdate mocks base method.
+func (m *MockInterface) OrganizationPolicyUpdate(ctx context.Context, organizationIdentifier string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationPolicyUpdate", ctx, organizationIdentifier, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OrganizationPolicyUpdate indicates an expected call of OrganizationPolicyUpdate.
+func (mr *MockInterfaceMockRecorder) OrganizationPolicyUpdate(ctx, organizationIdentifier, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationPolicyUpdate", reflect.TypeOf((*MockInterface)(nil).OrganizationPolicyUpdate), ctx, organizationIdentifier, p)
+}
+
 // PipelineCreate mocks base method.
 func (m *MockInterface) PipelineCreate(projectKey string, pip *sdk.Pipeline) error {
 	m.ctrl.T.Helper()
@@ -9395,6 +10353,41 @@ func (mr *MockInterfaceMockRecorder) ProjectAccess(ctx, projectKey, sessionID, i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectAccess", reflect.TypeOf((*MockInterface)(nil).ProjectAccess), ctx, projectKey, sessionID, itemType)
 }
 
+// ProjectArchiveKey mocks base method.
+func (m *MockInterface) ProjectArchiveKey(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectArchiveKey", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectArchiveKey indicates an expected call of ProjectArchiveKey.
+func (mr *MockInterfaceMockRecorder) ProjectArchiveKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectArchiveKey", reflect.TypeOf((*MockInterface)(nil).ProjectArchiveKey), ctx)
+}
+
+// ProjectAuditV2List mocks base method.
+func (m *MockInterface) ProjectAuditV2List(ctx context.Context, pKey string, mods ...cdsclient.RequestModifier) ([]sdk.AuditV2, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectAuditV2List", varargs...)
+	ret0, _ := ret[0].([]sdk.AuditV2)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectAuditV2List indicates an expected call of ProjectAuditV2List.
+func (mr *MockInterfaceMockRecorder) ProjectAuditV2List(ctx, pKey any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectAuditV2List", reflect.TypeOf((*MockInterface)(nil).ProjectAuditV2List), varargs...)
+}
+
 // ProjectConcurrencyCreate mocks base method.
 func (m *MockInterface) ProjectConcurrencyCreate(ctx context.Context, pKey string, c *sdk.ProjectConcurrency) error {
 	m.ctrl.T.Helper()
@@ -9510,6 +10503,21 @@ func (mr *MockInterfaceMockRecorder) ProjectDelete(projectKey any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDelete", reflect.TypeOf((*MockInterface)(nil).ProjectDelete), projectKey)
 }
 
+// ProjectExport mocks base method.
+func (m *MockInterface) ProjectExport(ctx context.Context, pKey string, req sdk.ProjectExportRequest) (*sdk.SignedProjectArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectExport", ctx, pKey, req)
+	ret0, _ := ret[0].(*sdk.SignedProjectArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectExport indicates an expected call of ProjectExport.
+func (mr *MockInterfaceMockRecorder) ProjectExport(ctx, pKey, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectExport", reflect.TypeOf((*MockInterface)(nil).ProjectExport), ctx, pKey, req)
+}
+
 // ProjectGet mocks base method.
 func (m *MockInterface) ProjectGet(projectKey string, opts ...cdsclient.RequestModifier) (*sdk.Project, error) {
 	m.ctrl.T.Helper()
@@ -9558,6 +10566,26 @@ func (mr *MockInterfaceMockRecorder) ProjectGroupDelete(projectKey, groupName an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGroupDelete", reflect.TypeOf((*MockInterface)(nil).ProjectGroupDelete), projectKey, groupName)
 }
 
+// ProjectImport mocks base method.
+func (m *MockInterface) ProjectImport(ctx context.Context, pKey string, archive sdk.SignedProjectArchive, mods ...cdsclient.RequestModifier) ([]sdk.ProjectImportReportLine, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, pKey, archive}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProjectImport", varargs...)
+	ret0, _ := ret[0].([]sdk.ProjectImportReportLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectImport indicates an expected call of ProjectImport.
+func (mr *MockInterfaceMockRecorder) ProjectImport(ctx, pKey, archive any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, pKey, archive}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectImport", reflect.TypeOf((*MockInterface)(nil).ProjectImport), varargs...)
+}
+
 // ProjectIntegrationDelete mocks base method.
 func (m *MockInterface) ProjectIntegrationDelete(projectKey, integrationName string) error {
 	m.ctrl.T.Helper()
@@ -9814,6 +10842,78 @@ func (mr *MockInterfaceMockRecorder) ProjectNotificationUpdate(ctx, pKey, notif
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectNotificationUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectNotificationUpdate), ctx, pKey, notif)
 }
 
+// ProjectPolicyCreate mocks base method.
+func (m *MockInterface) ProjectPolicyCreate(ctx context.Context, pKey string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyCreate", ctx, pKey, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectPolicyCreate indicates an expected call of ProjectPolicyCreate.
+func (mr *MockInterfaceMockRecorder) ProjectPolicyCreate(ctx, pKey, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyCreate", reflect.TypeOf((*MockInterface)(nil).ProjectPolicyCreate), ctx, pKey, p)
+}
+
+// ProjectPolicyDelete mocks base method.
+func (m *MockInterface) ProjectPolicyDelete(ctx context.Context, pKey, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyDelete", ctx, pKey, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectPolicyDelete indicates an expected call of ProjectPolicyDelete.
+func (mr *MockInterfaceMockRecorder) ProjectPolicyDelete(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyDelete", reflect.TypeOf((*MockInterface)(nil).ProjectPolicyDelete), ctx, pKey, name)
+}
+
+// ProjectPolicyGet mocks base method.
+func (m *MockInterface) ProjectPolicyGet(ctx context.Context, pKey, name string) (*sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyGet", ctx, pKey, name)
+	ret0, _ := ret[0].(*sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectPolicyGet indicates an expected call of ProjectPolicyGet.
+func (mr *MockInterfaceMockRecorder) ProjectPolicyGet(ctx, pKey, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyGet", reflect.TypeOf((*MockInterface)(nil).ProjectPolicyGet), ctx, pKey, name)
+}
+
+// ProjectPolicyList mocks base method.
+func (m *MockInterface) ProjectPolicyList(ctx context.Context, pKey string, withOrganization bool) ([]sdk.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyList", ctx, pKey, withOrganization)
+	ret0, _ := ret[0].([]sdk.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectPolicyList indicates an expected call of ProjectPolicyList.
+func (mr *MockInterfaceMockRecorder) ProjectPolicyList(ctx, pKey, withOrganization any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyList", reflect.TypeOf((*MockInterface)(nil).ProjectPolicyList), ctx, pKey, withOrganization)
+}
+
+// ProjectPolicyUpdate mocks base method.
+func (m *MockInterface) ProjectPolicyUpdate(ctx context.Context, pKey string, p *sdk.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectPolicyUpdate", ctx, pKey, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectPolicyUpdate indicates an expected call of ProjectPolicyUpdate.
+func (mr *MockInterfaceMockRecorder) ProjectPolicyUpdate(ctx, pKey, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectPolicyUpdate", reflect.TypeOf((*MockInterface)(nil).ProjectPolicyUpdate), ctx, pKey, p)
+}
+
 // ProjectRepositoryAnalysis mocks base method.
 func (m *MockInterface) ProjectRepositoryAnalysis(ctx context.Context, analysis sdk.AnalysisRequest) (sdk.AnalysisResponse, error) {
 	m.ctrl.T.Helper()
@@ -9903,6 +11003,50 @@ func (mr *MockInterfaceMockRecorder) ProjectRepositoryEvents(ctx, projectKey, vc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryEvents", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryEvents), ctx, projectKey, vcsName, repoName)
 }
 
+// ProjectRepositoryMergeQueueAdd mocks base method.
+func (m *MockInterface) ProjectRepositoryMergeQueueAdd(ctx context.Context, projectKey, vcsName, repoName string, pullRequestID int64) (*sdk.MergeQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryMergeQueueAdd", ctx, projectKey, vcsName, repoName, pullRequestID)
+	ret0, _ := ret[0].(*sdk.MergeQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRepositoryMergeQueueAdd indicates an expected call of ProjectRepositoryMergeQueueAdd.
+func (mr *MockInterfaceMockRecorder) ProjectRepositoryMergeQueueAdd(ctx, projectKey, vcsName, repoName, pullRequestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryMergeQueueAdd", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryMergeQueueAdd), ctx, projectKey, vcsName, repoName, pullRequestID)
+}
+
+// ProjectRepositoryMergeQueueDelete mocks base method.
+func (m *MockInterface) ProjectRepositoryMergeQueueDelete(ctx context.Context, projectKey, vcsName, repoName, entryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryMergeQueueDelete", ctx, projectKey, vcsName, repoName, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProjectRepositoryMergeQueueDelete indicates an expected call of ProjectRepositoryMergeQueueDelete.
+func (mr *MockInterfaceMockRecorder) ProjectRepositoryMergeQueueDelete(ctx, projectKey, vcsName, repoName, entryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryMergeQueueDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryMergeQueueDelete), ctx, projectKey, vcsName, repoName, entryID)
+}
+
+// ProjectRepositoryMergeQueueList mocks base method.
+func (m *MockInterface) ProjectRepositoryMergeQueueList(ctx context.Context, projectKey, vcsName, repoName string) ([]sdk.MergeQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectRepositoryMergeQueueList", ctx, projectKey, vcsName, repoName)
+	ret0, _ := ret[0].([]sdk.MergeQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectRepositoryMergeQueueList indicates an expected call of ProjectRepositoryMergeQueueList.
+func (mr *MockInterfaceMockRecorder) ProjectRepositoryMergeQueueList(ctx, projectKey, vcsName, repoName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryMergeQueueList", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryMergeQueueList), ctx, projectKey, vcsName, repoName)
+}
+
 // ProjectRunPurge mocks base method.
 func (m *MockInterface) ProjectRunPurge(ctx context.Context, projectKey string) error {
 	m.ctrl.T.Helper()
@@ -10735,6 +11879,86 @@ func (mr *MockInterfaceMockRecorder) RBACGet(ctx, permissionIdentifier any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGet", reflect.TypeOf((*MockInterface)(nil).RBACGet), ctx, permissionIdentifier)
 }
 
+// RBACGrantAudits mocks base method.
+func (m *MockInterface) RBACGrantAudits(ctx context.Context, grantID string) ([]sdk.AuditRBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantAudits", ctx, grantID)
+	ret0, _ := ret[0].([]sdk.AuditRBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantAudits indicates an expected call of RBACGrantAudits.
+func (mr *MockInterfaceMockRecorder) RBACGrantAudits(ctx, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantAudits", reflect.TypeOf((*MockInterface)(nil).RBACGrantAudits), ctx, grantID)
+}
+
+// RBACGrantGet mocks base method.
+func (m *MockInterface) RBACGrantGet(ctx context.Context, grantID string) (sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantGet", ctx, grantID)
+	ret0, _ := ret[0].(sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantGet indicates an expected call of RBACGrantGet.
+func (mr *MockInterfaceMockRecorder) RBACGrantGet(ctx, grantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantGet", reflect.TypeOf((*MockInterface)(nil).RBACGrantGet), ctx, grantID)
+}
+
+// RBACGrantList mocks base method.
+func (m *MockInterface) RBACGrantList(ctx context.Context, status ...string) ([]sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RBACGrantList", varargs...)
+	ret0, _ := ret[0].([]sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantList indicates an expected call of RBACGrantList.
+func (mr *MockInterfaceMockRecorder) RBACGrantList(ctx any, status ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantList", reflect.TypeOf((*MockInterface)(nil).RBACGrantList), varargs...)
+}
+
+// RBACGrantRequest mocks base method.
+func (m *MockInterface) RBACGrantRequest(ctx context.Context, grantRequest sdk.RBACGrantRequest) (sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantRequest", ctx, grantRequest)
+	ret0, _ := ret[0].(sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantRequest indicates an expected call of RBACGrantRequest.
+func (mr *MockInterfaceMockRecorder) RBACGrantRequest(ctx, grantRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantRequest", reflect.TypeOf((*MockInterface)(nil).RBACGrantRequest), ctx, grantRequest)
+}
+
+// RBACGrantReview mocks base method.
+func (m *MockInterface) RBACGrantReview(ctx context.Context, grantID, action string, review sdk.RBACGrantReview) (sdk.RBACGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACGrantReview", ctx, grantID, action, review)
+	ret0, _ := ret[0].(sdk.RBACGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACGrantReview indicates an expected call of RBACGrantReview.
+func (mr *MockInterfaceMockRecorder) RBACGrantReview(ctx, grantID, action, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACGrantReview", reflect.TypeOf((*MockInterface)(nil).RBACGrantReview), ctx, grantID, action, review)
+}
+
 // RBACGroupPermission mocks base method.
 func (m *MockInterface) RBACGroupPermission(ctx context.Context, groupName string) (sdk.PermissionSummary, error) {
 	m.ctrl.T.Helper()
@@ -10800,6 +12024,21 @@ func (mr *MockInterfaceMockRecorder) RBACUserPermission(ctx, username any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACUserPermission", reflect.TypeOf((*MockInterface)(nil).RBACUserPermission), ctx, username)
 }
 
+// RBACWorkflowCheck mocks base method.
+func (m *MockInterface) RBACWorkflowCheck(ctx context.Context, projectKey string, checkRequest sdk.RBACWorkflowCheckRequest) (*sdk.RBACWorkflowCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RBACWorkflowCheck", ctx, projectKey, checkRequest)
+	ret0, _ := ret[0].(*sdk.RBACWorkflowCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RBACWorkflowCheck indicates an expected call of RBACWorkflowCheck.
+func (mr *MockInterfaceMockRecorder) RBACWorkflowCheck(ctx, projectKey, checkRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RBACWorkflowCheck", reflect.TypeOf((*MockInterface)(nil).RBACWorkflowCheck), ctx, projectKey, checkRequest)
+}
+
 // RegionAdd mocks base method.
 func (m *MockInterface) RegionAdd(ctx context.Context, region sdk.Region) error {
 	m.ctrl.T.Helper()
@@ -11153,6 +12392,108 @@ func (mr *MockInterfaceMockRecorder) ServicesByType(stype any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServicesByType", reflect.TypeOf((*MockInterface)(nil).ServicesByType), stype)
 }
 
+// SharedConcurrencyCreate mocks base method.
+func (m *MockInterface) SharedConcurrencyCreate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, c *sdk.SharedConcurrency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyCreate", ctx, scope, scopeIdentifier, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SharedConcurrencyCreate indicates an expected call of SharedConcurrencyCreate.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyCreate(ctx, scope, scopeIdentifier, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyCreate", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyCreate), ctx, scope, scopeIdentifier, c)
+}
+
+// SharedConcurrencyDelete mocks base method.
+func (m *MockInterface) SharedConcurrencyDelete(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyDelete", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SharedConcurrencyDelete indicates an expected call of SharedConcurrencyDelete.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyDelete(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyDelete", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyDelete), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyGet mocks base method.
+func (m *MockInterface) SharedConcurrencyGet(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) (*sdk.SharedConcurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyGet", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].(*sdk.SharedConcurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyGet indicates an expected call of SharedConcurrencyGet.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyGet(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyGet", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyGet), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyList mocks base method.
+func (m *MockInterface) SharedConcurrencyList(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string) ([]sdk.SharedConcurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyList", ctx, scope, scopeIdentifier)
+	ret0, _ := ret[0].([]sdk.SharedConcurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyList indicates an expected call of SharedConcurrencyList.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyList(ctx, scope, scopeIdentifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyList", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyList), ctx, scope, scopeIdentifier)
+}
+
+// SharedConcurrencyListRuns mocks base method.
+func (m *MockInterface) SharedConcurrencyListRuns(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyListRuns", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].([]sdk.ProjectConcurrencyRunObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyListRuns indicates an expected call of SharedConcurrencyListRuns.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyListRuns(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyListRuns", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyListRuns), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyRelease mocks base method.
+func (m *MockInterface) SharedConcurrencyRelease(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier, name string) ([]sdk.ProjectConcurrencyRunObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyRelease", ctx, scope, scopeIdentifier, name)
+	ret0, _ := ret[0].([]sdk.ProjectConcurrencyRunObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedConcurrencyRelease indicates an expected call of SharedConcurrencyRelease.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyRelease(ctx, scope, scopeIdentifier, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyRelease", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyRelease), ctx, scope, scopeIdentifier, name)
+}
+
+// SharedConcurrencyUpdate mocks base method.
+func (m *MockInterface) SharedConcurrencyUpdate(ctx context.Context, scope sdk.V2RunJobConcurrencyScope, scopeIdentifier string, c *sdk.SharedConcurrency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedConcurrencyUpdate", ctx, scope, scopeIdentifier, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SharedConcurrencyUpdate indicates an expected call of SharedConcurrencyUpdate.
+func (mr *MockInterfaceMockRecorder) SharedConcurrencyUpdate(ctx, scope, scopeIdentifier, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedConcurrencyUpdate", reflect.TypeOf((*MockInterface)(nil).SharedConcurrencyUpdate), ctx, scope, scopeIdentifier, c)
+}
+
 // Stream mocks base method.
 func (m *MockInterface) Stream(ctx context.Context, httpClient cdsclient.HTTPClient, method, path string, body io.Reader, mods ...cdsclient.RequestModifier) (io.ReadCloser, http.Header, int, error) {
 	m.ctrl.T.Helper()
@@ -11344,6 +12685,21 @@ func (mr *MockInterfaceMockRecorder) TemplatePush(tarContent any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatePush", reflect.TypeOf((*MockInterface)(nil).TemplatePush), tarContent)
 }
 
+// TemplateV2InstanceList mocks base method.
+func (m *MockInterface) TemplateV2InstanceList(ctx context.Context, pKey, template string) ([]sdk.V2WorkflowTemplateInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateV2InstanceList", ctx, pKey, template)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTemplateInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplateV2InstanceList indicates an expected call of TemplateV2InstanceList.
+func (mr *MockInterfaceMockRecorder) TemplateV2InstanceList(ctx, pKey, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateV2InstanceList", reflect.TypeOf((*MockInterface)(nil).TemplateV2InstanceList), ctx, pKey, template)
+}
+
 // UserContacts mocks base method.
 func (m *MockInterface) UserContacts(ctx context.Context, username string) ([]sdk.UserContact, error) {
 	m.ctrl.T.Helper()
@@ -11596,6 +12952,49 @@ func (mr *MockInterfaceMockRecorder) V2QueueGetJobRun(ctx, regionName, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockInterface)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueJobDebugSession mocks base method.
+func (m *MockInterface) V2QueueJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebugSession", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebugSession indicates an expected call of V2QueueJobDebugSession.
+func (mr *MockInterfaceMockRecorder) V2QueueJobDebugSession(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebugSession", reflect.TypeOf((*MockInterface)(nil).V2QueueJobDebugSession), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
+// V2QueueJobExternalRunResultAccess mocks base method.
+func (m *MockInterface) V2QueueJobExternalRunResultAccess(ctx context.Context, regionName, jobRunID, workflowRunID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultAccess", ctx, regionName, jobRunID, workflowRunID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobExternalRunResultAccess indicates an expected call of V2QueueJobExternalRunResultAccess.
+func (mr *MockInterfaceMockRecorder) V2QueueJobExternalRunResultAccess(ctx, regionName, jobRunID, workflowRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultAccess", reflect.TypeOf((*MockInterface)(nil).V2QueueJobExternalRunResultAccess), ctx, regionName, jobRunID, workflowRunID)
+}
+
+// V2QueueJobExternalRunResultsGet mocks base method.
+func (m *MockInterface) V2QueueJobExternalRunResultsGet(ctx context.Context, regionName, jobRunID, runRef string) ([]sdk.V2WorkflowRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultsGet", ctx, regionName, jobRunID, runRef)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobExternalRunResultsGet indicates an expected call of V2QueueJobExternalRunResultsGet.
+func (mr *MockInterfaceMockRecorder) V2QueueJobExternalRunResultsGet(ctx, regionName, jobRunID, runRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultsGet", reflect.TypeOf((*MockInterface)(nil).V2QueueJobExternalRunResultsGet), ctx, regionName, jobRunID, runRef)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockInterface) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -11715,6 +13114,20 @@ func (mr *MockInterfaceMockRecorder) V2QueuePolling(ctx, region, osarch, goRouti
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePolling", reflect.TypeOf((*MockInterface)(nil).V2QueuePolling), varargs...)
 }
 
+// V2QueuePushJobAnnotations mocks base method.
+func (m *MockInterface) V2QueuePushJobAnnotations(ctx context.Context, regionName, jobRunID string, annotations sdk.VCSAnnotations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobAnnotations", ctx, regionName, jobRunID, annotations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobAnnotations indicates an expected call of V2QueuePushJobAnnotations.
+func (mr *MockInterfaceMockRecorder) V2QueuePushJobAnnotations(ctx, regionName, jobRunID, annotations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobAnnotations", reflect.TypeOf((*MockInterface)(nil).V2QueuePushJobAnnotations), ctx, regionName, jobRunID, annotations)
+}
+
 // V2QueuePushJobInfo mocks base method.
 func (m *MockInterface) V2QueuePushJobInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2SendJobRunInfo) error {
 	m.ctrl.T.Helper()
@@ -12807,6 +14220,46 @@ func (mr *MockInterfaceMockRecorder) WorkflowUpdate(projectKey, name, wf any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowUpdate", reflect.TypeOf((*MockInterface)(nil).WorkflowUpdate), projectKey, name, wf)
 }
 
+// WorkflowV2CoverageTrend mocks base method.
+func (m *MockInterface) WorkflowV2CoverageTrend(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, ref string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunCoverage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, ref}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2CoverageTrend", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2CoverageTrend indicates an expected call of WorkflowV2CoverageTrend.
+func (mr *MockInterfaceMockRecorder) WorkflowV2CoverageTrend(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, ref any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, ref}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2CoverageTrend", reflect.TypeOf((*MockInterface)(nil).WorkflowV2CoverageTrend), varargs...)
+}
+
+// WorkflowV2FlakyTestCases mocks base method.
+func (m *MockInterface) WorkflowV2FlakyTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2FlakyTestCases", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestCaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2FlakyTestCases indicates an expected call of WorkflowV2FlakyTestCases.
+func (mr *MockInterfaceMockRecorder) WorkflowV2FlakyTestCases(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2FlakyTestCases", reflect.TypeOf((*MockInterface)(nil).WorkflowV2FlakyTestCases), varargs...)
+}
+
 // WorkflowV2JobStart mocks base method.
 func (m *MockInterface) WorkflowV2JobStart(ctx context.Context, projectKey, workflowRunID, jobIdentifier string, payload map[string]any, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRun, error) {
 	m.ctrl.T.Helper()
@@ -12887,6 +14340,26 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2Run(ctx, projectKey, vcsIdentifie
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2Run", reflect.TypeOf((*MockInterface)(nil).WorkflowV2Run), varargs...)
 }
 
+// WorkflowV2RunCoverage mocks base method.
+func (m *MockInterface) WorkflowV2RunCoverage(ctx context.Context, projKey, workflowRunID string, mods ...cdsclient.RequestModifier) (*sdk.V2WorkflowRunCoverage, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, workflowRunID}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunCoverage", varargs...)
+	ret0, _ := ret[0].(*sdk.V2WorkflowRunCoverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunCoverage indicates an expected call of WorkflowV2RunCoverage.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunCoverage(ctx, projKey, workflowRunID any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, workflowRunID}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunCoverage", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunCoverage), varargs...)
+}
+
 // WorkflowV2RunDelete mocks base method.
 func (m *MockInterface) WorkflowV2RunDelete(ctx context.Context, projectKey, runIdentifier string) error {
 	m.ctrl.T.Helper()
@@ -12956,6 +14429,20 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2RunJob(ctx, projKey, workflowRunI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJob", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJob), ctx, projKey, workflowRunID, jobRunID)
 }
 
+// WorkflowV2RunJobDebugSession mocks base method.
+func (m *MockInterface) WorkflowV2RunJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, projKey, workflowRunID, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowV2RunJobDebugSession", ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowV2RunJobDebugSession indicates an expected call of WorkflowV2RunJobDebugSession.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunJobDebugSession(ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunJobDebugSession", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunJobDebugSession), ctx, goRoutines, projKey, workflowRunID, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
 // WorkflowV2RunJobInfoList mocks base method.
 func (m *MockInterface) WorkflowV2RunJobInfoList(ctx context.Context, projKey, workflowRunID, jobRunID string) ([]sdk.V2WorkflowRunJobInfo, error) {
 	m.ctrl.T.Helper()
@@ -13086,6 +14573,66 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2RunStatus(ctx, projectKey, workfl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunStatus", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunStatus), ctx, projectKey, workflowRunID)
 }
 
+// WorkflowV2RunTestCaseList mocks base method.
+func (m *MockInterface) WorkflowV2RunTestCaseList(ctx context.Context, projKey, workflowRunID string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunTestCase, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, workflowRunID}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2RunTestCaseList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunTestCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2RunTestCaseList indicates an expected call of WorkflowV2RunTestCaseList.
+func (mr *MockInterfaceMockRecorder) WorkflowV2RunTestCaseList(ctx, projKey, workflowRunID any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, workflowRunID}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2RunTestCaseList", reflect.TypeOf((*MockInterface)(nil).WorkflowV2RunTestCaseList), varargs...)
+}
+
+// WorkflowV2ScheduleList mocks base method.
+func (m *MockInterface) WorkflowV2ScheduleList(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowScheduleExecution, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2ScheduleList", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowScheduleExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2ScheduleList indicates an expected call of WorkflowV2ScheduleList.
+func (mr *MockInterfaceMockRecorder) WorkflowV2ScheduleList(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2ScheduleList", reflect.TypeOf((*MockInterface)(nil).WorkflowV2ScheduleList), varargs...)
+}
+
+// WorkflowV2SlowestTestCases mocks base method.
+func (m *MockInterface) WorkflowV2SlowestTestCases(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowTestCaseStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2SlowestTestCases", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowTestCaseStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2SlowestTestCases indicates an expected call of WorkflowV2SlowestTestCases.
+func (mr *MockInterfaceMockRecorder) WorkflowV2SlowestTestCases(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2SlowestTestCases", reflect.TypeOf((*MockInterface)(nil).WorkflowV2SlowestTestCases), varargs...)
+}
+
 // WorkflowV2Stop mocks base method.
 func (m *MockInterface) WorkflowV2Stop(ctx context.Context, projKey, workflowRunID string) error {
 	m.ctrl.T.Helper()
@@ -13114,6 +14661,26 @@ func (mr *MockInterfaceMockRecorder) WorkflowV2StopJob(ctx, projKey, workflowRun
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2StopJob", reflect.TypeOf((*MockInterface)(nil).WorkflowV2StopJob), ctx, projKey, workflowRunID, jobIdentifier)
 }
 
+// WorkflowV2TestCaseHistory mocks base method.
+func (m *MockInterface) WorkflowV2TestCaseHistory(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name string, mods ...cdsclient.RequestModifier) ([]sdk.V2WorkflowRunTestCase, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WorkflowV2TestCaseHistory", varargs...)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunTestCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowV2TestCaseHistory indicates an expected call of WorkflowV2TestCaseHistory.
+func (mr *MockInterfaceMockRecorder) WorkflowV2TestCaseHistory(ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name any, mods ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, projKey, vcsIdentifier, repoIdentifier, wkfName, suite, name}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowV2TestCaseHistory", reflect.TypeOf((*MockInterface)(nil).WorkflowV2TestCaseHistory), varargs...)
+}
+
 // WorkflowV2VersionDelete mocks base method.
 func (m *MockInterface) WorkflowV2VersionDelete(ctx context.Context, projKey, vcsIdentifier, repoIdentifier, wkfName, version string) error {
 	m.ctrl.T.Helper()
@@ -13356,6 +14923,49 @@ func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueGetJobRun(ctx, regionName, i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueGetJobRun", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueGetJobRun), ctx, regionName, id)
 }
 
+// V2QueueJobDebugSession mocks base method.
+func (m *MockV2WorkerInterface) V2QueueJobDebugSession(ctx context.Context, goRoutines *sdk.GoRoutines, regionName, jobRunID string, msgToSend <-chan json.RawMessage, msgReceived chan<- json.RawMessage, errorReceived chan<- error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobDebugSession", ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobDebugSession indicates an expected call of V2QueueJobDebugSession.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueJobDebugSession(ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobDebugSession", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueJobDebugSession), ctx, goRoutines, regionName, jobRunID, msgToSend, msgReceived, errorReceived)
+}
+
+// V2QueueJobExternalRunResultAccess mocks base method.
+func (m *MockV2WorkerInterface) V2QueueJobExternalRunResultAccess(ctx context.Context, regionName, jobRunID, workflowRunID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultAccess", ctx, regionName, jobRunID, workflowRunID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueueJobExternalRunResultAccess indicates an expected call of V2QueueJobExternalRunResultAccess.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueJobExternalRunResultAccess(ctx, regionName, jobRunID, workflowRunID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultAccess", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueJobExternalRunResultAccess), ctx, regionName, jobRunID, workflowRunID)
+}
+
+// V2QueueJobExternalRunResultsGet mocks base method.
+func (m *MockV2WorkerInterface) V2QueueJobExternalRunResultsGet(ctx context.Context, regionName, jobRunID, runRef string) ([]sdk.V2WorkflowRunResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueueJobExternalRunResultsGet", ctx, regionName, jobRunID, runRef)
+	ret0, _ := ret[0].([]sdk.V2WorkflowRunResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// V2QueueJobExternalRunResultsGet indicates an expected call of V2QueueJobExternalRunResultsGet.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueueJobExternalRunResultsGet(ctx, regionName, jobRunID, runRef any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueueJobExternalRunResultsGet", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueueJobExternalRunResultsGet), ctx, regionName, jobRunID, runRef)
+}
+
 // V2QueueJobResult mocks base method.
 func (m *MockV2WorkerInterface) V2QueueJobResult(ctx context.Context, region, jobRunID string, result sdk.V2WorkflowRunJobResult) error {
 	m.ctrl.T.Helper()
@@ -13475,6 +15085,20 @@ func (mr *MockV2WorkerInterfaceMockRecorder) V2QueuePolling(ctx, region, osarch,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePolling", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueuePolling), varargs...)
 }
 
+// V2QueuePushJobAnnotations mocks base method.
+func (m *MockV2WorkerInterface) V2QueuePushJobAnnotations(ctx context.Context, regionName, jobRunID string, annotations sdk.VCSAnnotations) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "V2QueuePushJobAnnotations", ctx, regionName, jobRunID, annotations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// V2QueuePushJobAnnotations indicates an expected call of V2QueuePushJobAnnotations.
+func (mr *MockV2WorkerInterfaceMockRecorder) V2QueuePushJobAnnotations(ctx, regionName, jobRunID, annotations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "V2QueuePushJobAnnotations", reflect.TypeOf((*MockV2WorkerInterface)(nil).V2QueuePushJobAnnotations), ctx, regionName, jobRunID, annotations)
+}
+
 // V2QueuePushJobInfo mocks base method.
 func (m *MockV2WorkerInterface) V2QueuePushJobInfo(ctx context.Context, regionName, jobRunID string, msg sdk.V2SendJobRunInfo) error {
 	m.ctrl.T.Helper()
@@ -124,6 +124,12 @@ type GitContext struct {
 	PullRequestWebURL    string   `json:"pullrequest_web_url,omitempty" jsonschema:"example=https://github.com/ovh/cds/pull/123" jsonschema_description:"Web URL of the pull request"`
 	GPGKey               string   `json:"gpg_key,omitempty" jsonschema_description:"GPG private key for signing commits"`
 	Email                string   `json:"email,omitempty" jsonschema:"example=git-user@example.com" jsonschema_description:"Git email for commits"`
+	EventType            string   `json:"event_type,omitempty" jsonschema:"example=published" jsonschema_description:"Type of the repository event (pull-request and release actions)"`
+	DeletedRef           string   `json:"deleted_ref,omitempty" jsonschema:"example=refs/heads/feature" jsonschema_description:"Reference deleted by a branch-delete event"`
+	DeletedRefName       string   `json:"deleted_ref_name,omitempty" jsonschema:"example=feature" jsonschema_description:"Branch name deleted by a branch-delete event"`
+	ReleaseTag           string   `json:"release_tag,omitempty" jsonschema:"example=v1.2.0" jsonschema_description:"Tag of the release that triggered the workflow"`
+	ReleaseName          string   `json:"release_name,omitempty" jsonschema:"example=Release 1.2.0" jsonschema_description:"Name of the release that triggered the workflow"`
+	ReleaseWebURL        string   `json:"release_web_url,omitempty" jsonschema:"example=https://github.com/ovh/cds/releases/tag/v1.2.0" jsonschema_description:"Web URL of the release that triggered the workflow"`
 }
 
 type JobContext struct {
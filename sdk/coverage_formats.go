@@ -0,0 +1,366 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type CoverageReportFormat string
+
+const (
+	CoverageReportFormatCobertura CoverageReportFormat = "cobertura"
+	CoverageReportFormatLCOV      CoverageReportFormat = "lcov"
+	CoverageReportFormatJaCoCo    CoverageReportFormat = "jacoco"
+	CoverageReportFormatGoCover   CoverageReportFormat = "gocover"
+)
+
+// CoverageReportMaxSize is the maximum size of a coverage report sent with a run result
+const CoverageReportMaxSize = 10 * 1024 * 1024
+
+// CoverageReport is a compact coverage report: for each file, the covered and uncovered lines as ranges like "1-4,8"
+type CoverageReport struct {
+	Format       CoverageReportFormat `json:"format" mapstructure:"format"`
+	LinesTotal   int64                `json:"lines_total" mapstructure:"lines_total"`
+	LinesCovered int64                `json:"lines_covered" mapstructure:"lines_covered"`
+	Files        []CoverageReportFile `json:"files" mapstructure:"files"`
+}
+
+type CoverageReportFile struct {
+	Path      string `json:"path" mapstructure:"path"`
+	Covered   string `json:"covered,omitempty" mapstructure:"covered"`
+	Uncovered string `json:"uncovered,omitempty" mapstructure:"uncovered"`
+}
+
+// Rate returns the percentage of covered lines
+func (r CoverageReport) Rate() float64 {
+	return CoverageRate(r.LinesCovered, r.LinesTotal)
+}
+
+// CoverageRate returns the percentage of covered lines, rounded to two decimals
+func CoverageRate(covered, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered*10000/total) / 100
+}
+
+// coverageLines maps each executable line of each file to its coverage
+type coverageLines map[string]map[int]bool
+
+func (c coverageLines) set(file string, line int, covered bool) {
+	if file == "" || line <= 0 {
+		return
+	}
+	if _, ok := c[file]; !ok {
+		c[file] = make(map[int]bool)
+	}
+	c[file][line] = c[file][line] || covered
+}
+
+func (c coverageLines) report(format CoverageReportFormat) CoverageReport {
+	r := CoverageReport{Format: format, Files: make([]CoverageReportFile, 0, len(c))}
+	for file, lines := range c {
+		var covered, uncovered []int
+		for l, ok := range lines {
+			if ok {
+				covered = append(covered, l)
+			} else {
+				uncovered = append(uncovered, l)
+			}
+		}
+		r.LinesTotal += int64(len(lines))
+		r.LinesCovered += int64(len(covered))
+		r.Files = append(r.Files, CoverageReportFile{
+			Path:      file,
+			Covered:   formatLineRanges(covered),
+			Uncovered: formatLineRanges(uncovered),
+		})
+	}
+	sort.Slice(r.Files, func(i, j int) bool { return r.Files[i].Path < r.Files[j].Path })
+	return r
+}
+
+func (r CoverageReport) lines() coverageLines {
+	c := make(coverageLines)
+	for _, f := range r.Files {
+		for _, l := range parseLineRanges(f.Uncovered) {
+			c.set(f.Path, l, false)
+		}
+		for _, l := range parseLineRanges(f.Covered) {
+			c.set(f.Path, l, true)
+		}
+	}
+	return c
+}
+
+// MergeCoverageReports merges several reports, a line is covered if it is covered in one of the reports
+func MergeCoverageReports(reports ...CoverageReport) CoverageReport {
+	c := make(coverageLines)
+	var format CoverageReportFormat
+	for i, r := range reports {
+		if i == 0 {
+			format = r.Format
+		} else if r.Format != format {
+			format = ""
+		}
+		for file, lines := range r.lines() {
+			for l, covered := range lines {
+				c.set(file, l, covered)
+			}
+		}
+	}
+	return c.report(format)
+}
+
+// DiffCoverage returns the number of executable lines and covered lines among the given changed lines.
+// The paths of the changed files are relative to the repository, a report file matches if its path ends with it.
+func (r CoverageReport) DiffCoverage(changedLines map[string][]int) (int64, int64) {
+	var total, covered int64
+	c := r.lines()
+	for file, changed := range changedLines {
+		lines := c.lookup(file)
+		if lines == nil {
+			continue
+		}
+		for _, l := range changed {
+			isCovered, executable := lines[l]
+			if !executable {
+				continue
+			}
+			total++
+			if isCovered {
+				covered++
+			}
+		}
+	}
+	return total, covered
+}
+
+func (c coverageLines) lookup(file string) map[int]bool {
+	file = strings.TrimPrefix(path.Clean("/"+file), "/")
+	if lines, ok := c[file]; ok {
+		return lines
+	}
+	var match string
+	for f := range c {
+		if strings.HasSuffix(f, "/"+file) && (match == "" || len(f) < len(match)) {
+			match = f
+		}
+	}
+	if match == "" {
+		return nil
+	}
+	return c[match]
+}
+
+func formatLineRanges(lines []int) string {
+	sort.Ints(lines)
+	var ranges []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(lines[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
+
+func parseLineRanges(s string) []int {
+	var lines []int
+	for _, r := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(r, "-")
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(to); err != nil {
+				continue
+			}
+		}
+		for l := start; l <= end; l++ {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// DetectCoverageReportFormat returns the format of a coverage report: Cobertura or JaCoCo for XML files,
+// Go cover profile if the file starts with the mode and LCOV otherwise.
+func DetectCoverageReportFormat(data []byte) CoverageReportFormat {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("mode:")) {
+		return CoverageReportFormatGoCover
+	}
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		dec := xml.NewDecoder(bytes.NewReader(trimmed))
+		dec.Strict = false
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return CoverageReportFormatCobertura
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				if start.Name.Local == "report" {
+					return CoverageReportFormatJaCoCo
+				}
+				return CoverageReportFormatCobertura
+			}
+		}
+	}
+	return CoverageReportFormatLCOV
+}
+
+// ParseCoverageReport reads a Cobertura, LCOV, JaCoCo or Go cover profile report
+func ParseCoverageReport(data []byte) (CoverageReport, error) {
+	format := DetectCoverageReportFormat(data)
+	var lines coverageLines
+	var err error
+	switch format {
+	case CoverageReportFormatGoCover:
+		lines, err = parseGoCoverProfile(data)
+	case CoverageReportFormatJaCoCo:
+		lines, err = parseJaCoCoReport(data)
+	case CoverageReportFormatCobertura:
+		lines, err = parseCoberturaReport(data)
+	default:
+		lines, err = parseLCOVReport(data)
+	}
+	if err != nil {
+		return CoverageReport{}, NewErrorFrom(ErrWrongRequest, "unable to read %s coverage report: %v", format, err)
+	}
+	if len(lines) == 0 {
+		return CoverageReport{}, NewErrorFrom(ErrWrongRequest, "no file found in %s coverage report", format)
+	}
+	return lines.report(format), nil
+}
+
+func parseGoCoverProfile(data []byte) (coverageLines, error) {
+	lines := make(coverageLines)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l := strings.TrimSpace(scanner.Text())
+		if l == "" || strings.HasPrefix(l, "mode:") {
+			continue
+		}
+		// name.go:line.column,line.column numberOfStatements count
+		colon := strings.LastIndex(l, ":")
+		fields := strings.Fields(l[colon+1:])
+		if colon < 0 || len(fields) != 3 {
+			return nil, fmt.Errorf("invalid line %q", l)
+		}
+		from, to, _ := strings.Cut(fields[0], ",")
+		startLine, err1 := strconv.Atoi(strings.Split(from, ".")[0])
+		endLine, err2 := strconv.Atoi(strings.Split(to, ".")[0])
+		count, err3 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("invalid line %q", l)
+		}
+		for i := startLine; i <= endLine; i++ {
+			lines.set(l[:colon], i, count > 0)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func parseLCOVReport(data []byte) (coverageLines, error) {
+	lines := make(coverageLines)
+	var file string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(l, "SF:"):
+			file = strings.TrimPrefix(l, "SF:")
+		case strings.HasPrefix(l, "DA:"):
+			// DA:line,count[,checksum]
+			fields := strings.Split(strings.TrimPrefix(l, "DA:"), ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("invalid line %q", l)
+			}
+			line, err1 := strconv.Atoi(fields[0])
+			count, err2 := strconv.ParseInt(fields[1], 10, 64)
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid line %q", l)
+			}
+			lines.set(file, line, count > 0)
+		case l == "end_of_record":
+			file = ""
+		}
+	}
+	return lines, scanner.Err()
+}
+
+type coberturaReport struct {
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int   `xml:"number,attr"`
+				Hits   int64 `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+func parseCoberturaReport(data []byte) (coverageLines, error) {
+	var report coberturaReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	lines := make(coverageLines)
+	for _, p := range report.Packages {
+		for _, c := range p.Classes {
+			for _, l := range c.Lines {
+				lines.set(c.Filename, l.Number, l.Hits > 0)
+			}
+		}
+	}
+	return lines, nil
+}
+
+type jacocoReport struct {
+	Packages []struct {
+		Name        string `xml:"name,attr"`
+		SourceFiles []struct {
+			Name  string `xml:"name,attr"`
+			Lines []struct {
+				Number           int   `xml:"nr,attr"`
+				CoveredInstructs int64 `xml:"ci,attr"`
+			} `xml:"line"`
+		} `xml:"sourcefile"`
+	} `xml:"package"`
+}
+
+func parseJaCoCoReport(data []byte) (coverageLines, error) {
+	var report jacocoReport
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	if err := dec.Decode(&report); err != nil {
+		return nil, err
+	}
+	lines := make(coverageLines)
+	for _, p := range report.Packages {
+		for _, f := range p.SourceFiles {
+			for _, l := range f.Lines {
+				lines.set(path.Join(p.Name, f.Name), l.Number, l.CoveredInstructs > 0)
+			}
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCoverageReportGoCover(t *testing.T) {
+	report, err := ParseCoverageReport([]byte(`mode: set
+github.com/ovh/cds/sdk/foo.go:3.20,5.2 2 1
+github.com/ovh/cds/sdk/foo.go:7.20,9.2 1 0
+github.com/ovh/cds/sdk/bar.go:1.1,1.10 1 1
+`))
+	require.NoError(t, err)
+	require.Equal(t, CoverageReportFormatGoCover, report.Format)
+	require.Equal(t, int64(7), report.LinesTotal)
+	require.Equal(t, int64(4), report.LinesCovered)
+	require.Equal(t, 57.14, report.Rate())
+	require.Equal(t, []CoverageReportFile{
+		{Path: "github.com/ovh/cds/sdk/bar.go", Covered: "1"},
+		{Path: "github.com/ovh/cds/sdk/foo.go", Covered: "3-5", Uncovered: "7-9"},
+	}, report.Files)
+}
+
+func TestParseCoverageReportLCOV(t *testing.T) {
+	report, err := ParseCoverageReport([]byte(`TN:
+SF:src/index.js
+DA:1,1
+DA:2,0
+DA:3,4
+end_of_record
+`))
+	require.NoError(t, err)
+	require.Equal(t, CoverageReportFormatLCOV, report.Format)
+	require.Equal(t, []CoverageReportFile{{Path: "src/index.js", Covered: "1,3", Uncovered: "2"}}, report.Files)
+}
+
+func TestParseCoverageReportCobertura(t *testing.T) {
+	report, err := ParseCoverageReport([]byte(`<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.5">
+  <packages>
+    <package name="app">
+      <classes>
+        <class name="main" filename="app/main.py">
+          <lines>
+            <line number="1" hits="1"/>
+            <line number="2" hits="0"/>
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`))
+	require.NoError(t, err)
+	require.Equal(t, CoverageReportFormatCobertura, report.Format)
+	require.Equal(t, []CoverageReportFile{{Path: "app/main.py", Covered: "1", Uncovered: "2"}}, report.Files)
+}
+
+func TestParseCoverageReportJaCoCo(t *testing.T) {
+	report, err := ParseCoverageReport([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="calculator">
+  <package name="com/ovh/calc">
+    <class name="com/ovh/calc/Calc" sourcefilename="Calc.java"/>
+    <sourcefile name="Calc.java">
+      <line nr="3" mi="0" ci="3" mb="0" cb="0"/>
+      <line nr="5" mi="2" ci="0" mb="0" cb="0"/>
+      <line nr="6" mi="0" ci="1" mb="1" cb="1"/>
+    </sourcefile>
+  </package>
+</report>`))
+	require.NoError(t, err)
+	require.Equal(t, CoverageReportFormatJaCoCo, report.Format)
+	require.Equal(t, []CoverageReportFile{{Path: "com/ovh/calc/Calc.java", Covered: "3,6", Uncovered: "5"}}, report.Files)
+
+	_, err = ParseCoverageReport([]byte(`<report name="empty"></report>`))
+	require.Error(t, err)
+}
+
+func TestMergeCoverageReportsAndDiffCoverage(t *testing.T) {
+	report := MergeCoverageReports(
+		CoverageReport{Format: CoverageReportFormatGoCover, Files: []CoverageReportFile{{Path: "github.com/ovh/cds/sdk/foo.go", Covered: "3-5", Uncovered: "7-9"}}},
+		CoverageReport{Format: CoverageReportFormatGoCover, Files: []CoverageReportFile{{Path: "github.com/ovh/cds/sdk/foo.go", Covered: "8", Uncovered: "3-5"}}},
+	)
+	require.Equal(t, CoverageReportFormatGoCover, report.Format)
+	require.Equal(t, int64(6), report.LinesTotal)
+	require.Equal(t, int64(4), report.LinesCovered)
+	require.Equal(t, "3-5,8", report.Files[0].Covered)
+	require.Equal(t, "7,9", report.Files[0].Uncovered)
+
+	// Line 6 is not executable and other.go is not in the report
+	total, covered := report.DiffCoverage(map[string][]int{
+		"sdk/foo.go":   {4, 6, 7, 8},
+		"sdk/other.go": {1, 2},
+	})
+	require.Equal(t, int64(3), total)
+	require.Equal(t, int64(2), covered)
+}
+
+func TestVCSFileDiffAddedLines(t *testing.T) {
+	d := VCSFileDiff{Patch: `@@ -1,4 +1,5 @@
+ package sdk
+-var a = 1
++var a = 2
++var b = 3
+
+ func foo() {}
+@@ -10,2 +11,3 @@ func bar() {
+ 	return
++	// end
+ }`}
+	require.Equal(t, []int{2, 3, 12}, d.AddedLines())
+}
@@ -23,6 +23,9 @@ const (
 	EventRunJobRunResultUpdated EventType = "RunJobRunResultUpdated"
 	EventRunJobEnded            EventType = "RunJobEnded"
 
+	EventRunJobDebugSessionStarted EventType = "RunJobDebugSessionStarted"
+	EventRunJobDebugSessionEnded   EventType = "RunJobDebugSessionEnded"
+
 	EventRunCrafted  EventType = "RunCrafted"
 	EventRunBuilding EventType = "RunBuilding"
 	EventRunEnded    EventType = "RunEnded"
@@ -55,6 +58,12 @@ const (
 	EventPermissionUpdated EventType = "PermissionUpdated"
 	EventPermissionDeleted EventType = "PermissionDeleted"
 
+	EventPermissionGrantRequested EventType = "PermissionGrantRequested"
+	EventPermissionGrantApproved  EventType = "PermissionGrantApproved"
+	EventPermissionGrantRejected  EventType = "PermissionGrantRejected"
+	EventPermissionGrantRevoked   EventType = "PermissionGrantRevoked"
+	EventPermissionGrantExpired   EventType = "PermissionGrantExpired"
+
 	EventUserCreated       EventType = "UserCreated"
 	EventUserUpdated       EventType = "UserUpdated"
 	EventUserDeleted       EventType = "UserDeleted"
@@ -92,6 +101,24 @@ const (
 	EventConcurrencyCreated EventType = "ConcurrencyCreated"
 	EventConcurrencyUpdated EventType = "ConcurrencyUpdated"
 	EventConcurrencyDeleted EventType = "ConcurrencyDeleted"
+
+	EventSharedConcurrencyCreated EventType = "SharedConcurrencyCreated"
+	EventSharedConcurrencyUpdated EventType = "SharedConcurrencyUpdated"
+	EventSharedConcurrencyDeleted EventType = "SharedConcurrencyDeleted"
+
+	EventProjectKeyCreated EventType = "ProjectKeyCreated"
+	EventProjectKeyUpdated EventType = "ProjectKeyUpdated"
+	EventProjectKeyDeleted EventType = "ProjectKeyDeleted"
+
+	EventProjectRunFilterCreated EventType = "ProjectRunFilterCreated"
+	EventProjectRunFilterUpdated EventType = "ProjectRunFilterUpdated"
+	EventProjectRunFilterDeleted EventType = "ProjectRunFilterDeleted"
+
+	EventProjectRunRetentionUpdated EventType = "ProjectRunRetentionUpdated"
+
+	EventPolicyCreated EventType = "PolicyCreated"
+	EventPolicyUpdated EventType = "PolicyUpdated"
+	EventPolicyDeleted EventType = "PolicyDeleted"
 )
 
 // FullEventV2 uses to process event
@@ -133,6 +160,10 @@ type FullEventV2 struct {
 	VariableSet      string          `json:"variable_set,omitempty"`
 	Item             string          `json:"item,omitempty"`
 	Concurrency      string          `json:"concurrency"`
+	Policy           string          `json:"policy,omitempty"`
+	RunFilter        string          `json:"run_filter,omitempty"`
+	ConsumerID       string          `json:"consumer_id,omitempty"`
+	IPAddress        string          `json:"ip_address,omitempty"`
 	Timestamp        time.Time       `json:"timestamp"`
 }
 
@@ -219,6 +250,24 @@ type ConcurrencyEvent struct {
 	Username    string `json:"username"`
 }
 
+type SharedConcurrencyEvent struct {
+	GlobalEventV2
+	Organization string `json:"organization,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Concurrency  string `json:"concurrency"`
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+}
+
+type PolicyEvent struct {
+	GlobalEventV2
+	ProjectEventV2
+	Organization string `json:"organization,omitempty"`
+	Policy       string `json:"policy"`
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+}
+
 type VCSEvent struct {
 	GlobalEventV2
 	ProjectEventV2
@@ -244,6 +293,14 @@ type VariableEvent struct {
 	Username string `json:"username"`
 }
 
+type RunFilterEvent struct {
+	GlobalEventV2
+	ProjectEventV2
+	RunFilter string `json:"run_filter"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+}
+
 type IntegrationModelEvent struct {
 	GlobalEventV2
 	IntegrationModel string `json:"integration_model"`
@@ -334,6 +391,22 @@ type WorkflowRunJobEvent struct {
 	Username      string                 `json:"username"`
 }
 
+type WorkflowRunJobDebugSessionEvent struct {
+	GlobalEventV2
+	ProjectEventV2
+	VCSName       string `json:"vcs_name"`
+	Repository    string `json:"repository"`
+	Workflow      string `json:"workflow"`
+	WorkflowRunID string `json:"workflow_run_id"`
+	RunJobID      string `json:"run_job_id"`
+	RunNumber     int64  `json:"run_number"`
+	RunAttempt    int64  `json:"run_attempt"`
+	Region        string `json:"region"`
+	JobID         string `json:"job_id"`
+	UserID        string `json:"user_id"`
+	Username      string `json:"username"`
+}
+
 type WorkflowRunJobManualEvent struct {
 	GlobalEventV2
 	ProjectEventV2
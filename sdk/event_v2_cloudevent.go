@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	CloudEventSpecVersion = "1.0"
+	CloudEventContentType = "application/cloudevents+json"
+	CloudEventTypePrefix  = "com.ovhcloud.cds.v2."
+)
+
+// CloudEventV2 is a v2 event encoded with the CloudEvents 1.0 JSON format.
+// The CDS event type is prefixed with CloudEventTypePrefix and the project key is given in the cdsproject extension.
+type CloudEventV2 struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+	CDSProject      string          `json:"cdsproject,omitempty"`
+}
+
+// EventType returns the CDS type of the event
+func (c CloudEventV2) EventType() EventType {
+	return EventType(strings.TrimPrefix(c.Type, CloudEventTypePrefix))
+}
+
+// NewCloudEventV2 encodes the event as a CloudEvent emitted by the given CDS instance URL.
+// Secret values of the payload are redacted and the IP address of the actor is removed.
+func NewCloudEventV2(e FullEventV2, cdsURL string) (CloudEventV2, error) {
+	source := strings.TrimSuffix(cdsURL, "/")
+	if e.ProjectKey != "" {
+		source += "/project/" + e.ProjectKey
+	}
+	if source == "" {
+		source = "cds"
+	}
+
+	if len(e.Payload) > 0 && string(e.Payload) != "null" {
+		var v interface{}
+		if err := JSONUnmarshal(e.Payload, &v); err != nil {
+			return CloudEventV2{}, WrapError(err, "unable to read payload of event %s", e.ID)
+		}
+		bts, err := json.Marshal(redactAuditV2Value(v, false))
+		if err != nil {
+			return CloudEventV2{}, WithStack(err)
+		}
+		e.Payload = bts
+	}
+	e.IPAddress = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return CloudEventV2{}, WithStack(err)
+	}
+
+	return CloudEventV2{
+		SpecVersion:     CloudEventSpecVersion,
+		ID:              e.ID,
+		Source:          source,
+		Type:            CloudEventTypePrefix + string(e.Type),
+		Subject:         e.cloudEventSubject(),
+		Time:            e.Timestamp,
+		DataContentType: "application/json",
+		Data:            data,
+		CDSProject:      e.ProjectKey,
+	}, nil
+}
+
+// cloudEventSubject returns the entity concerned by the event, relative to the source
+func (e FullEventV2) cloudEventSubject() string {
+	switch {
+	case e.RunJobID != "":
+		return fmt.Sprintf("run/%s/job/%s", e.WorkflowRunID, e.RunJobID)
+	case e.WorkflowRunID != "":
+		return "run/" + e.WorkflowRunID
+	}
+	if entityType, name := e.AuditEntity(); entityType != "" {
+		return entityType + "/" + name
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCloudEventV2(t *testing.T) {
+	now := time.Now().UTC()
+	item := ProjectVariableSetItem{Name: "password", Type: ProjectVariableTypeSecret, Value: "my-secret"}
+	bts, _ := json.Marshal(item)
+	e := FullEventV2{
+		ID:          "event-id",
+		Type:        EventVariableSetItemCreated,
+		Payload:     bts,
+		ProjectKey:  "PROJ",
+		VariableSet: "vs",
+		Item:        item.Name,
+		Username:    "john",
+		IPAddress:   "127.0.0.1",
+		Timestamp:   now,
+	}
+
+	ce, err := NewCloudEventV2(e, "https://cds.local/")
+	require.NoError(t, err)
+	require.Equal(t, "1.0", ce.SpecVersion)
+	require.Equal(t, "event-id", ce.ID)
+	require.Equal(t, "https://cds.local/project/PROJ", ce.Source)
+	require.Equal(t, "com.ovhcloud.cds.v2.VariableSetItemCreated", ce.Type)
+	require.Equal(t, EventVariableSetItemCreated, ce.EventType())
+	require.Equal(t, "variableset-item/vs/password", ce.Subject)
+	require.Equal(t, now, ce.Time)
+	require.Equal(t, "PROJ", ce.CDSProject)
+
+	var data FullEventV2
+	require.NoError(t, json.Unmarshal(ce.Data, &data))
+	require.Equal(t, "john", data.Username)
+	require.Empty(t, data.IPAddress)
+	var payload ProjectVariableSetItem
+	require.NoError(t, json.Unmarshal(data.Payload, &payload))
+	require.Equal(t, PasswordPlaceholder, payload.Value)
+
+	ce, err = NewCloudEventV2(FullEventV2{ID: "run-event", Type: EventRunJobEnded, ProjectKey: "PROJ", WorkflowRunID: "run-id", RunJobID: "job-id"}, "")
+	require.NoError(t, err)
+	require.Equal(t, "/project/PROJ", ce.Source)
+	require.Equal(t, "run/run-id/job/job-id", ce.Subject)
+
+	ce, err = NewCloudEventV2(FullEventV2{ID: "global", Type: EventUserCreated, Username: "john"}, "")
+	require.NoError(t, err)
+	require.Equal(t, "cds", ce.Source)
+}
@@ -22,6 +22,11 @@ func (t WorkflowHookEventType) IsValidForEventName(n WorkflowHookEventName) bool
 		case WorkflowHookEventTypePullRequestOpened, WorkflowHookEventTypePullRequestReopened, WorkflowHookEventTypePullRequestClosed, WorkflowHookEventTypePullRequestEdited:
 			return true
 		}
+	case WorkflowHookEventNameRelease:
+		switch t {
+		case WorkflowHookEventTypeReleasePublished, WorkflowHookEventTypeReleaseEdited, WorkflowHookEventTypeReleaseDeleted:
+			return true
+		}
 	case WorkflowHookEventNamePullRequestComment:
 		switch t {
 		case WorkflowHookEventTypePullRequestCommentCreated, WorkflowHookEventTypePullRequestCommentDeleted, WorkflowHookEventTypePullRequestCommentEdited:
@@ -33,6 +38,16 @@ func (t WorkflowHookEventType) IsValidForEventName(n WorkflowHookEventName) bool
 	return false
 }
 
+// IsSupportedByVCS returns false for the repository events that a vcs server of the given type never sends
+func (n WorkflowHookEventName) IsSupportedByVCS(vcsType string) bool {
+	switch n {
+	case WorkflowHookEventNameRelease:
+		// There is no release on Bitbucket Server and Azure DevOps repositories
+		return vcsType != VCSTypeBitbucketServer && vcsType != VCSTypeAzureDevOps
+	}
+	return true
+}
+
 const (
 	SignHeaderVCSName   = "X-Cds-Hooks-Vcs-Name"
 	SignHeaderRepoName  = "X-Cds-Hooks-Repo-Name"
@@ -47,6 +62,9 @@ const (
 	WorkflowHookEventNameWebHook        WorkflowHookEventName = "webhook"
 	WorkflowHookEventNameWorkflowRun    WorkflowHookEventName = "workflow-run"
 	WorkflowHookEventNameScheduler      WorkflowHookEventName = "scheduler"
+	WorkflowHookEventNameMergeQueue     WorkflowHookEventName = "merge-queue"
+	WorkflowHookEventNameBranchCreate   WorkflowHookEventName = "branch-create"
+	WorkflowHookEventNameBranchDelete   WorkflowHookEventName = "branch-delete"
 
 	WorkflowHookEventNamePullRequest         WorkflowHookEventName = "pull-request"
 	WorkflowHookEventTypePullRequestOpened   WorkflowHookEventType = "opened"
@@ -59,6 +77,11 @@ const (
 	WorkflowHookEventTypePullRequestCommentDeleted WorkflowHookEventType = "deleted"
 	WorkflowHookEventTypePullRequestCommentEdited  WorkflowHookEventType = "edited"
 
+	WorkflowHookEventNameRelease          WorkflowHookEventName = "release"
+	WorkflowHookEventTypeReleasePublished WorkflowHookEventType = "published"
+	WorkflowHookEventTypeReleaseEdited    WorkflowHookEventType = "edited"
+	WorkflowHookEventTypeReleaseDeleted   WorkflowHookEventType = "deleted"
+
 	RepoEventPush = "push"
 
 	HookEventStatusScheduled     = "Scheduled"
@@ -107,6 +130,17 @@ type HookRepository struct {
 	Stopped        bool   `json:"stopped" cli:"stopped"`
 }
 
+// HookPollingRepository is a repository whose refs are polled by the hooks service, its vcs server cannot send webhooks
+type HookPollingRepository struct {
+	ProjectKey     string `json:"project_key"`
+	VCSServerName  string `json:"vcs_server_name"`
+	VCSServerType  string `json:"vcs_server_type"`
+	RepositoryName string `json:"repository_name"`
+}
+
+// HookRepositoryRefs contains the latest commit of each branch and tag of a repository, indexed by full ref name
+type HookRepositoryRefs map[string]string
+
 type HookWorkflowRunOutgoingEvent struct {
 	UUID                string               `json:"uuid"`
 	Created             int64                `json:"created"`
@@ -154,6 +188,8 @@ type HookRepositoryEvent struct {
 	DeprecatedUsername  string                         `json:"username"` // Deprecated
 	SignKey             string                         `json:"sign_key"`
 	Initiator           *V2Initiator                   `json:"initiator"`
+	TraceParent         string                         `json:"trace_parent,omitempty"`
+	StatusTimestamps    map[string]int64               `json:"status_timestamps,omitempty"` // When the event entered each status, in nanoseconds
 }
 
 func (h *HookRepositoryEvent) IsTerminated() bool {
@@ -326,6 +362,13 @@ type HookRepositoryEventExtractData struct {
 	HookProjectKey     string                                       `json:"hook_project_key,omitempty"` // force the hook to only trigger from the given CDS project
 	CommitVerified     bool                                         `json:"commit_verified,omitempty"`
 	CommitGpgKeyID     string                                       `json:"commit_gpg_key_id,omitempty"`
+	Release            *HookRepositoryEventExtractedDataRelease     `json:"release,omitempty"`
+}
+
+type HookRepositoryEventExtractedDataRelease struct {
+	Tag  string `json:"tag"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
 }
 
 type HookRepositoryEventExtractedDataWebHook struct {
@@ -357,12 +400,15 @@ type HookRepositoryEventExtractedDataWorkflowRun struct {
 }
 
 type HookRepositoryEventExtractedDataScheduler struct {
-	TargetVCS      string `json:"target_vcs"`
-	TargetRepo     string `json:"target_repo"`
-	TargetWorkflow string `json:"target_workflow"`
-	TargetProject  string `json:"target_project"`
-	Cron           string `json:"cron"`
-	Timezone       string `json:"timezone"`
+	TargetVCS      string                 `json:"target_vcs"`
+	TargetRepo     string                 `json:"target_repo"`
+	TargetWorkflow string                 `json:"target_workflow"`
+	TargetProject  string                 `json:"target_project"`
+	Cron           string                 `json:"cron"`
+	Timezone       string                 `json:"timezone"`
+	TargetBranch   string                 `json:"target_branch,omitempty"`
+	Inputs         V2WorkflowRunJobInputs `json:"inputs,omitempty"`
+	ScheduledTime  int64                  `json:"scheduled_time,omitempty"`
 }
 
 type GeneratedWebhook struct {
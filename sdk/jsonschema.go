@@ -132,10 +132,14 @@ func GetWorkflowJsonSchema(publicActionNames, regionNames, workerModelNames []st
 	reflector := jsonschema.Reflector{Anonymous: false}
 	workflowSchema := reflector.Reflect(&V2Workflow{})
 	workflowOn := reflector.Reflect(&WorkflowOn{
+		BranchCreate:       &WorkflowOnBranchCreate{},
+		BranchDelete:       &WorkflowOnBranchDelete{},
+		MergeQueue:         &WorkflowOnMergeQueue{},
 		ModelUpdate:        &WorkflowOnModelUpdate{},
 		PullRequest:        &WorkflowOnPullRequest{},
 		PullRequestComment: &WorkflowOnPullRequestComment{},
 		Push:               &WorkflowOnPush{},
+		Release:            &WorkflowOnRelease{},
 		WorkflowUpdate:     &WorkflowOnWorkflowUpdate{},
 	})
 
@@ -163,9 +167,16 @@ func GetWorkflowJsonSchema(publicActionNames, regionNames, workerModelNames []st
 	workflowSchema.Definitions["WorkflowOnPush"] = workflowOn.Definitions["WorkflowOnPush"]
 	workflowSchema.Definitions["WorkflowOnPullRequest"] = workflowOn.Definitions["WorkflowOnPullRequest"]
 	workflowSchema.Definitions["WorkflowOnPullRequestComment"] = workflowOn.Definitions["WorkflowOnPullRequestComment"]
+	workflowSchema.Definitions["WorkflowOnMergeQueue"] = workflowOn.Definitions["WorkflowOnMergeQueue"]
+	workflowSchema.Definitions["WorkflowOnRelease"] = workflowOn.Definitions["WorkflowOnRelease"]
+	workflowSchema.Definitions["WorkflowOnBranchCreate"] = workflowOn.Definitions["WorkflowOnBranchCreate"]
+	workflowSchema.Definitions["WorkflowOnBranchDelete"] = workflowOn.Definitions["WorkflowOnBranchDelete"]
 	workflowSchema.Definitions["WorkflowOnModelUpdate"] = workflowOn.Definitions["WorkflowOnModelUpdate"]
 	workflowSchema.Definitions["WorkflowOnWorkflowUpdate"] = workflowOn.Definitions["WorkflowOnWorkflowUpdate"]
 	workflowSchema.Definitions["WorkflowOnSchedule"] = workflowOn.Definitions["WorkflowOnSchedule"]
+	workflowSchema.Definitions["WorkflowScheduleBlackout"] = workflowOn.Definitions["WorkflowScheduleBlackout"]
+	workflowSchema.Definitions["V2WorkflowRunJobInputs"] = workflowOn.Definitions["V2WorkflowRunJobInputs"]
+	workflowSchema.Definitions["GateInputs"] = workflowOn.Definitions["GateInputs"]
 	workflowSchema.Definitions["WorkflowOnRun"] = workflowOn.Definitions["WorkflowOnRun"]
 
 	// Prop On - Get existing schema to preserve description and order from jsonschema_extras
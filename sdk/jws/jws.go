@@ -46,9 +46,14 @@ func ExportPrivateKey(pk *rsa.PrivateKey) ([]byte, error) {
 }
 
 func ExportPublicKey(pk *rsa.PrivateKey) ([]byte, error) {
+	return MarshalPublicKey(&pk.PublicKey)
+}
+
+// MarshalPublicKey returns the PEM encoding of the public key
+func MarshalPublicKey(pub *rsa.PublicKey) ([]byte, error) {
 	var pemPublicBlock = &pem.Block{
 		Type:  "RSA PUBLIC KEY",
-		Bytes: x509.MarshalPKCS1PublicKey(&pk.PublicKey),
+		Bytes: x509.MarshalPKCS1PublicKey(pub),
 	}
 	buffer := new(bytes.Buffer)
 	if err := pem.Encode(buffer, pemPublicBlock); err != nil {
@@ -70,6 +75,19 @@ func NewPublicKeyFromPEM(pk []byte) (*rsa.PublicKey, error) {
 	return pub, nil
 }
 
+func NewPrivateKeyFromPEM(pk []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pk)
+	if block == nil || block.Type != "RSA PRIVATE KEY" {
+		return nil, errors.New("failed to decode PEM block containing private key")
+	}
+
+	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return priv, nil
+}
+
 // NewSigner instantiate a signer using RSASSA-PSS (SHA512) with the given private key.
 func NewSigner(privateKey *rsa.PrivateKey) (jose.Signer, error) {
 	// Instantiate a signer using RSASSA-PSS (SHA512) with the given private key.
@@ -127,3 +145,34 @@ func UnsafeParse(s string, i interface{}) error {
 	output := object.UnsafePayloadWithoutVerification()
 	return sdk.WithStack(sdk.JSONUnmarshal(output, i))
 }
+
+// Encrypt encrypts the content for the owner of the given public key using RSA-OAEP-256 and AES-256-GCM,
+// and returns a JWE object using the compact serialization format.
+func Encrypt(publicKey *rsa.PublicKey, content []byte) (string, error) {
+	encrypter, err := jose.NewEncrypter(jose.A256GCM, jose.Recipient{Algorithm: jose.RSA_OAEP_256, Key: publicKey}, nil)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	object, err := encrypter.Encrypt(content)
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	compact, err := object.CompactSerialize()
+	if err != nil {
+		return "", sdk.WithStack(err)
+	}
+	return compact, nil
+}
+
+// Decrypt parses the serialized JWE object and decrypts it with the given private key
+func Decrypt(privateKey *rsa.PrivateKey, s string) ([]byte, error) {
+	object, err := jose.ParseEncrypted(s)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	content, err := object.Decrypt(privateKey)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return content, nil
+}
@@ -12,6 +12,10 @@ func TestNewRandomRSAKey(t *testing.T) {
 	btes, err := ExportPrivateKey(k)
 	require.NoError(t, err)
 	t.Log(string(btes))
+
+	parsed, err := NewPrivateKeyFromPEM(btes)
+	require.NoError(t, err)
+	require.True(t, k.Equal(parsed))
 }
 
 func TestHMacSignAndVerify(t *testing.T) {
@@ -29,3 +33,22 @@ func TestHMacSignAndVerify(t *testing.T) {
 	require.NoError(t, Verify(secret, messageSigned, &unsigned))
 	require.Equal(t, message, unsigned)
 }
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	k, err := NewRandomRSAKey()
+	require.NoError(t, err)
+
+	message := "my secret"
+	encrypted, err := Encrypt(&k.PublicKey, []byte(message))
+	require.NoError(t, err)
+	require.NotContains(t, encrypted, message)
+
+	decrypted, err := Decrypt(k, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, message, string(decrypted))
+
+	other, err := NewRandomRSAKey()
+	require.NoError(t, err)
+	_, err = Decrypt(other, encrypted)
+	require.Error(t, err)
+}
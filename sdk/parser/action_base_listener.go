@@ -188,3 +188,33 @@ func (s *BaseActionListener) EnterFilterExpression(ctx *FilterExpressionContext)
 
 // ExitFilterExpression is called when production filterExpression is exited.
 func (s *BaseActionListener) ExitFilterExpression(ctx *FilterExpressionContext) {}
+
+// EnterTernaryExpression is called when production ternaryExpression is entered.
+func (s *BaseActionListener) EnterTernaryExpression(ctx *TernaryExpressionContext) {}
+
+// ExitTernaryExpression is called when production ternaryExpression is exited.
+func (s *BaseActionListener) ExitTernaryExpression(ctx *TernaryExpressionContext) {}
+
+// EnterAdditiveExpression is called when production additiveExpression is entered.
+func (s *BaseActionListener) EnterAdditiveExpression(ctx *AdditiveExpressionContext) {}
+
+// ExitAdditiveExpression is called when production additiveExpression is exited.
+func (s *BaseActionListener) ExitAdditiveExpression(ctx *AdditiveExpressionContext) {}
+
+// EnterMultiplicativeExpression is called when production multiplicativeExpression is entered.
+func (s *BaseActionListener) EnterMultiplicativeExpression(ctx *MultiplicativeExpressionContext) {}
+
+// ExitMultiplicativeExpression is called when production multiplicativeExpression is exited.
+func (s *BaseActionListener) ExitMultiplicativeExpression(ctx *MultiplicativeExpressionContext) {}
+
+// EnterAdditiveOperator is called when production additiveOperator is entered.
+func (s *BaseActionListener) EnterAdditiveOperator(ctx *AdditiveOperatorContext) {}
+
+// ExitAdditiveOperator is called when production additiveOperator is exited.
+func (s *BaseActionListener) ExitAdditiveOperator(ctx *AdditiveOperatorContext) {}
+
+// EnterMultiplicativeOperator is called when production multiplicativeOperator is entered.
+func (s *BaseActionListener) EnterMultiplicativeOperator(ctx *MultiplicativeOperatorContext) {}
+
+// ExitMultiplicativeOperator is called when production multiplicativeOperator is exited.
+func (s *BaseActionListener) ExitMultiplicativeOperator(ctx *MultiplicativeOperatorContext) {}
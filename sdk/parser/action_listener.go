@@ -92,6 +92,21 @@ type ActionListener interface {
 	// EnterFilterExpression is called when entering the filterExpression production.
 	EnterFilterExpression(c *FilterExpressionContext)
 
+	// EnterTernaryExpression is called when entering the ternaryExpression production.
+	EnterTernaryExpression(c *TernaryExpressionContext)
+
+	// EnterAdditiveExpression is called when entering the additiveExpression production.
+	EnterAdditiveExpression(c *AdditiveExpressionContext)
+
+	// EnterMultiplicativeExpression is called when entering the multiplicativeExpression production.
+	EnterMultiplicativeExpression(c *MultiplicativeExpressionContext)
+
+	// EnterAdditiveOperator is called when entering the additiveOperator production.
+	EnterAdditiveOperator(c *AdditiveOperatorContext)
+
+	// EnterMultiplicativeOperator is called when entering the multiplicativeOperator production.
+	EnterMultiplicativeOperator(c *MultiplicativeOperatorContext)
+
 	// ExitStart is called when exiting the start production.
 	ExitStart(c *StartContext)
 
@@ -175,4 +190,19 @@ type ActionListener interface {
 
 	// ExitFilterExpression is called when exiting the filterExpression production.
 	ExitFilterExpression(c *FilterExpressionContext)
+
+	// ExitTernaryExpression is called when exiting the ternaryExpression production.
+	ExitTernaryExpression(c *TernaryExpressionContext)
+
+	// ExitAdditiveExpression is called when exiting the additiveExpression production.
+	ExitAdditiveExpression(c *AdditiveExpressionContext)
+
+	// ExitMultiplicativeExpression is called when exiting the multiplicativeExpression production.
+	ExitMultiplicativeExpression(c *MultiplicativeExpressionContext)
+
+	// ExitAdditiveOperator is called when exiting the additiveOperator production.
+	ExitAdditiveOperator(c *AdditiveOperatorContext)
+
+	// ExitMultiplicativeOperator is called when exiting the multiplicativeOperator production.
+	ExitMultiplicativeOperator(c *MultiplicativeOperatorContext)
 }
@@ -35,12 +35,13 @@ func actionParserInit() {
 	staticData.literalNames = []string{
 		"", "','", "'['", "']'", "", "", "'null'", "'${{'", "'}}'", "", "'=='",
 		"'!='", "'>'", "'<'", "'>='", "'<='", "", "'('", "')'", "'!'", "'||'",
-		"'&&'", "'.'", "'*'",
+		"'&&'", "'.'", "'*'", "", "'+'", "'-'", "'/'", "'%'", "'?'", "':'",
 	}
 	staticData.symbolicNames = []string{
 		"", "", "", "", "STRING_INSIDE_EXPRESSION", "BOOLEAN", "NULL", "EXP_START",
 		"EXP_END", "NUMBER", "EQ", "NEQ", "GT", "LT", "GTE", "LTE", "ID", "LPAREN",
-		"RPAREN", "NOT", "OR", "AND", "DOT", "STAR", "WS",
+		"RPAREN", "NOT", "OR", "AND", "DOT", "STAR", "WS", "PLUS", "MINUS", "SLASH",
+		"PERCENT", "QUESTION", "COLON",
 	}
 	staticData.ruleNames = []string{
 		"start", "expression", "orExpression", "andExpression", "comparisonExpression",
@@ -49,81 +50,100 @@ func actionParserInit() {
 		"notExpression", "notOperator", "functionCall", "functionName", "functionCallArguments",
 		"array", "arrayIndex", "andOperator", "orOperator", "comparisonOperator",
 		"equalityOperator", "booleanExpression", "expressionStart", "expressionEnd",
-		"filterExpression",
+		"filterExpression", "ternaryExpression", "additiveExpression", "multiplicativeExpression",
+		"additiveOperator", "multiplicativeOperator",
 	}
 	staticData.predictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 24, 180, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 30, 221, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
-		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
-		2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7, 20, 2,
-		21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7, 25, 2, 26,
-		7, 26, 2, 27, 7, 27, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 1,
-		2, 1, 2, 1, 2, 5, 2, 68, 8, 2, 10, 2, 12, 2, 71, 9, 2, 1, 3, 1, 3, 1, 3,
-		1, 3, 5, 3, 77, 8, 3, 10, 3, 12, 3, 80, 9, 3, 1, 4, 1, 4, 1, 4, 1, 4, 3,
-		4, 86, 8, 4, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 92, 8, 5, 1, 6, 1, 6, 1, 6,
-		1, 6, 1, 6, 1, 6, 3, 6, 100, 8, 6, 1, 7, 1, 7, 5, 7, 104, 8, 7, 10, 7,
-		12, 7, 107, 9, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 3, 8, 114, 8, 8, 1, 9,
-		1, 9, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12, 1, 13, 1,
-		13, 1, 13, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 5, 15, 136,
-		8, 15, 10, 15, 12, 15, 139, 9, 15, 1, 15, 1, 15, 5, 15, 143, 8, 15, 10,
-		15, 12, 15, 146, 9, 15, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 17, 1, 17,
-		1, 17, 3, 17, 156, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 19, 1, 19, 1,
-		20, 1, 20, 1, 21, 1, 21, 1, 22, 1, 22, 1, 23, 1, 23, 1, 24, 1, 24, 1, 25,
-		1, 25, 1, 26, 1, 26, 1, 27, 1, 27, 1, 27, 0, 0, 28, 0, 2, 4, 6, 8, 10,
-		12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46,
-		48, 50, 52, 54, 0, 2, 1, 0, 12, 15, 1, 0, 10, 11, 170, 0, 56, 1, 0, 0,
-		0, 2, 59, 1, 0, 0, 0, 4, 63, 1, 0, 0, 0, 6, 72, 1, 0, 0, 0, 8, 81, 1, 0,
-		0, 0, 10, 87, 1, 0, 0, 0, 12, 99, 1, 0, 0, 0, 14, 101, 1, 0, 0, 0, 16,
-		113, 1, 0, 0, 0, 18, 115, 1, 0, 0, 0, 20, 117, 1, 0, 0, 0, 22, 119, 1,
-		0, 0, 0, 24, 121, 1, 0, 0, 0, 26, 125, 1, 0, 0, 0, 28, 128, 1, 0, 0, 0,
-		30, 130, 1, 0, 0, 0, 32, 147, 1, 0, 0, 0, 34, 155, 1, 0, 0, 0, 36, 157,
-		1, 0, 0, 0, 38, 161, 1, 0, 0, 0, 40, 163, 1, 0, 0, 0, 42, 165, 1, 0, 0,
-		0, 44, 167, 1, 0, 0, 0, 46, 169, 1, 0, 0, 0, 48, 171, 1, 0, 0, 0, 50, 173,
-		1, 0, 0, 0, 52, 175, 1, 0, 0, 0, 54, 177, 1, 0, 0, 0, 56, 57, 3, 2, 1,
-		0, 57, 58, 5, 0, 0, 1, 58, 1, 1, 0, 0, 0, 59, 60, 3, 50, 25, 0, 60, 61,
-		3, 4, 2, 0, 61, 62, 3, 52, 26, 0, 62, 3, 1, 0, 0, 0, 63, 69, 3, 6, 3, 0,
-		64, 65, 3, 42, 21, 0, 65, 66, 3, 6, 3, 0, 66, 68, 1, 0, 0, 0, 67, 64, 1,
-		0, 0, 0, 68, 71, 1, 0, 0, 0, 69, 67, 1, 0, 0, 0, 69, 70, 1, 0, 0, 0, 70,
-		5, 1, 0, 0, 0, 71, 69, 1, 0, 0, 0, 72, 78, 3, 8, 4, 0, 73, 74, 3, 40, 20,
-		0, 74, 75, 3, 8, 4, 0, 75, 77, 1, 0, 0, 0, 76, 73, 1, 0, 0, 0, 77, 80,
-		1, 0, 0, 0, 78, 76, 1, 0, 0, 0, 78, 79, 1, 0, 0, 0, 79, 7, 1, 0, 0, 0,
-		80, 78, 1, 0, 0, 0, 81, 85, 3, 10, 5, 0, 82, 83, 3, 44, 22, 0, 83, 84,
-		3, 10, 5, 0, 84, 86, 1, 0, 0, 0, 85, 82, 1, 0, 0, 0, 85, 86, 1, 0, 0, 0,
-		86, 9, 1, 0, 0, 0, 87, 91, 3, 12, 6, 0, 88, 89, 3, 46, 23, 0, 89, 90, 3,
-		12, 6, 0, 90, 92, 1, 0, 0, 0, 91, 88, 1, 0, 0, 0, 91, 92, 1, 0, 0, 0, 92,
-		11, 1, 0, 0, 0, 93, 100, 3, 14, 7, 0, 94, 100, 3, 20, 10, 0, 95, 100, 3,
-		30, 15, 0, 96, 100, 3, 22, 11, 0, 97, 100, 3, 24, 12, 0, 98, 100, 3, 26,
-		13, 0, 99, 93, 1, 0, 0, 0, 99, 94, 1, 0, 0, 0, 99, 95, 1, 0, 0, 0, 99,
-		96, 1, 0, 0, 0, 99, 97, 1, 0, 0, 0, 99, 98, 1, 0, 0, 0, 100, 13, 1, 0,
-		0, 0, 101, 105, 3, 18, 9, 0, 102, 104, 3, 16, 8, 0, 103, 102, 1, 0, 0,
-		0, 104, 107, 1, 0, 0, 0, 105, 103, 1, 0, 0, 0, 105, 106, 1, 0, 0, 0, 106,
-		15, 1, 0, 0, 0, 107, 105, 1, 0, 0, 0, 108, 109, 5, 22, 0, 0, 109, 114,
-		3, 18, 9, 0, 110, 114, 3, 36, 18, 0, 111, 112, 5, 22, 0, 0, 112, 114, 3,
-		54, 27, 0, 113, 108, 1, 0, 0, 0, 113, 110, 1, 0, 0, 0, 113, 111, 1, 0,
-		0, 0, 114, 17, 1, 0, 0, 0, 115, 116, 5, 16, 0, 0, 116, 19, 1, 0, 0, 0,
-		117, 118, 5, 9, 0, 0, 118, 21, 1, 0, 0, 0, 119, 120, 5, 4, 0, 0, 120, 23,
-		1, 0, 0, 0, 121, 122, 5, 17, 0, 0, 122, 123, 3, 4, 2, 0, 123, 124, 5, 18,
-		0, 0, 124, 25, 1, 0, 0, 0, 125, 126, 3, 28, 14, 0, 126, 127, 3, 12, 6,
-		0, 127, 27, 1, 0, 0, 0, 128, 129, 5, 19, 0, 0, 129, 29, 1, 0, 0, 0, 130,
-		131, 3, 32, 16, 0, 131, 132, 5, 17, 0, 0, 132, 137, 3, 34, 17, 0, 133,
-		134, 5, 1, 0, 0, 134, 136, 3, 34, 17, 0, 135, 133, 1, 0, 0, 0, 136, 139,
-		1, 0, 0, 0, 137, 135, 1, 0, 0, 0, 137, 138, 1, 0, 0, 0, 138, 140, 1, 0,
-		0, 0, 139, 137, 1, 0, 0, 0, 140, 144, 5, 18, 0, 0, 141, 143, 3, 16, 8,
-		0, 142, 141, 1, 0, 0, 0, 143, 146, 1, 0, 0, 0, 144, 142, 1, 0, 0, 0, 144,
-		145, 1, 0, 0, 0, 145, 31, 1, 0, 0, 0, 146, 144, 1, 0, 0, 0, 147, 148, 5,
-		16, 0, 0, 148, 33, 1, 0, 0, 0, 149, 156, 1, 0, 0, 0, 150, 156, 3, 14, 7,
-		0, 151, 156, 3, 22, 11, 0, 152, 156, 3, 20, 10, 0, 153, 156, 3, 48, 24,
-		0, 154, 156, 3, 30, 15, 0, 155, 149, 1, 0, 0, 0, 155, 150, 1, 0, 0, 0,
-		155, 151, 1, 0, 0, 0, 155, 152, 1, 0, 0, 0, 155, 153, 1, 0, 0, 0, 155,
-		154, 1, 0, 0, 0, 156, 35, 1, 0, 0, 0, 157, 158, 5, 2, 0, 0, 158, 159, 3,
-		38, 19, 0, 159, 160, 5, 3, 0, 0, 160, 37, 1, 0, 0, 0, 161, 162, 3, 12,
-		6, 0, 162, 39, 1, 0, 0, 0, 163, 164, 5, 21, 0, 0, 164, 41, 1, 0, 0, 0,
-		165, 166, 5, 20, 0, 0, 166, 43, 1, 0, 0, 0, 167, 168, 7, 0, 0, 0, 168,
-		45, 1, 0, 0, 0, 169, 170, 7, 1, 0, 0, 170, 47, 1, 0, 0, 0, 171, 172, 5,
-		5, 0, 0, 172, 49, 1, 0, 0, 0, 173, 174, 5, 7, 0, 0, 174, 51, 1, 0, 0, 0,
-		175, 176, 5, 8, 0, 0, 176, 53, 1, 0, 0, 0, 177, 178, 5, 23, 0, 0, 178,
-		55, 1, 0, 0, 0, 10, 69, 78, 85, 91, 99, 105, 113, 137, 144, 155,
+		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7,
+		15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
+		20, 2, 21, 7, 21, 2, 22, 7, 22, 2, 23, 7, 23, 2, 24, 7, 24, 2, 25, 7,
+		25, 2, 26, 7, 26, 2, 27, 7, 27, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 2, 1, 2, 1, 2, 1, 2, 5, 2, 68, 8, 2, 10, 2, 12, 2, 71, 9, 2, 1, 3,
+		1, 3, 1, 3, 1, 3, 5, 3, 77, 8, 3, 10, 3, 12, 3, 80, 9, 3, 1, 4, 1, 4, 1,
+		4, 1, 4, 3, 4, 86, 8, 4, 1, 5, 1, 5, 1, 5, 1, 5, 3, 5, 92, 8, 5, 1, 6,
+		1, 6, 1, 6, 1, 6, 1, 6, 1, 6, 3, 6, 100, 8, 6, 1, 7, 1, 7, 5, 7, 104, 8,
+		7, 10, 7, 12, 7, 107, 9, 7, 1, 8, 1, 8, 1, 8, 1, 8, 1, 8, 3, 8, 114, 8,
+		8, 1, 9, 1, 9, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12,
+		1, 13, 1, 13, 1, 13, 1, 14, 1, 14, 1, 15, 1, 15, 1, 15, 1, 15, 1, 15, 5,
+		15, 136, 8, 15, 10, 15, 12, 15, 139, 9, 15, 1, 15, 1, 15, 5, 15, 143, 8,
+		15, 10, 15, 12, 15, 146, 9, 15, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1,
+		17, 1, 17, 1, 17, 3, 17, 156, 8, 17, 1, 18, 1, 18, 1, 18, 1, 18, 1, 19,
+		1, 19, 1, 20, 1, 20, 1, 21, 1, 21, 1, 22, 1, 22, 1, 23, 1, 23, 1, 24, 1,
+		24, 1, 25, 1, 25, 1, 26, 1, 26, 1, 27, 1, 27, 1, 27, 2, 28, 7, 28, 2,
+		29, 7, 29, 2, 30, 7, 30, 2, 31, 7, 31, 2, 32, 7, 32, 1, 28, 1, 28, 1,
+		28, 1, 28, 1, 28, 1, 28, 3, 28, 197, 8, 28, 1, 29, 1, 29, 1, 29, 1, 29,
+		5, 29, 203, 8, 29, 10, 29, 12, 29, 206, 9, 29, 1, 30, 1, 30, 1, 30, 1,
+		30, 5, 30, 212, 8, 30, 10, 30, 12, 30, 215, 9, 30, 1, 31, 1, 31, 1, 32,
+		1, 32, 1, 6, 0, 0, 33, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24,
+		26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 180, 182,
+		184, 186, 188, 0, 4, 1, 0, 12, 15, 1, 0, 10, 11, 1, 0, 25, 26, 2, 0, 23,
+		23, 27, 28, 206, 0, 56, 1, 0, 0, 0, 2, 59, 1, 0, 0, 0, 4, 63, 1, 0, 0,
+		0, 6, 72, 1, 0, 0, 0, 8, 81, 1, 0, 0, 0, 10, 87, 1, 0, 0, 0, 12, 99, 1,
+		0, 0, 0, 14, 101, 1, 0, 0, 0, 16, 113, 1, 0, 0, 0, 18, 115, 1, 0, 0, 0,
+		20, 117, 1, 0, 0, 0, 22, 119, 1, 0, 0, 0, 24, 121, 1, 0, 0, 0, 26, 125,
+		1, 0, 0, 0, 28, 128, 1, 0, 0, 0, 30, 130, 1, 0, 0, 0, 32, 147, 1, 0, 0,
+		0, 34, 155, 1, 0, 0, 0, 36, 157, 1, 0, 0, 0, 38, 161, 1, 0, 0, 0, 40,
+		163, 1, 0, 0, 0, 42, 165, 1, 0, 0, 0, 44, 167, 1, 0, 0, 0, 46, 169, 1,
+		0, 0, 0, 48, 171, 1, 0, 0, 0, 50, 173, 1, 0, 0, 0, 52, 175, 1, 0, 0, 0,
+		54, 177, 1, 0, 0, 0, 56, 57, 3, 2, 1, 0, 57, 58, 5, 0, 0, 1, 58, 1, 1,
+		0, 0, 0, 59, 60, 3, 50, 25, 0, 60, 61, 3, 180, 28, 0, 61, 62, 3, 52, 26,
+		0, 62, 3, 1, 0, 0, 0, 63, 69, 3, 6, 3, 0, 64, 65, 3, 42, 21, 0, 65, 66,
+		3, 6, 3, 0, 66, 68, 1, 0, 0, 0, 67, 64, 1, 0, 0, 0, 68, 71, 1, 0, 0, 0,
+		69, 67, 1, 0, 0, 0, 69, 70, 1, 0, 0, 0, 70, 5, 1, 0, 0, 0, 71, 69, 1, 0,
+		0, 0, 72, 78, 3, 8, 4, 0, 73, 74, 3, 40, 20, 0, 74, 75, 3, 8, 4, 0, 75,
+		77, 1, 0, 0, 0, 76, 73, 1, 0, 0, 0, 77, 80, 1, 0, 0, 0, 78, 76, 1, 0, 0,
+		0, 78, 79, 1, 0, 0, 0, 79, 7, 1, 0, 0, 0, 80, 78, 1, 0, 0, 0, 81, 85, 3,
+		10, 5, 0, 82, 83, 3, 44, 22, 0, 83, 84, 3, 10, 5, 0, 84, 86, 1, 0, 0, 0,
+		85, 82, 1, 0, 0, 0, 85, 86, 1, 0, 0, 0, 86, 9, 1, 0, 0, 0, 87, 91, 3,
+		182, 29, 0, 88, 89, 3, 46, 23, 0, 89, 90, 3, 182, 29, 0, 90, 92, 1, 0,
+		0, 0, 91, 88, 1, 0, 0, 0, 91, 92, 1, 0, 0, 0, 92, 11, 1, 0, 0, 0, 93,
+		100, 3, 14, 7, 0, 94, 100, 3, 20, 10, 0, 95, 100, 3, 30, 15, 0, 96, 100,
+		3, 22, 11, 0, 97, 100, 3, 24, 12, 0, 98, 100, 3, 26, 13, 0, 99, 93, 1,
+		0, 0, 0, 99, 94, 1, 0, 0, 0, 99, 95, 1, 0, 0, 0, 99, 96, 1, 0, 0, 0, 99,
+		97, 1, 0, 0, 0, 99, 98, 1, 0, 0, 0, 100, 13, 1, 0, 0, 0, 101, 105, 3,
+		18, 9, 0, 102, 104, 3, 16, 8, 0, 103, 102, 1, 0, 0, 0, 104, 107, 1, 0,
+		0, 0, 105, 103, 1, 0, 0, 0, 105, 106, 1, 0, 0, 0, 106, 15, 1, 0, 0, 0,
+		107, 105, 1, 0, 0, 0, 108, 109, 5, 22, 0, 0, 109, 114, 3, 18, 9, 0, 110,
+		114, 3, 36, 18, 0, 111, 112, 5, 22, 0, 0, 112, 114, 3, 54, 27, 0, 113,
+		108, 1, 0, 0, 0, 113, 110, 1, 0, 0, 0, 113, 111, 1, 0, 0, 0, 114, 17, 1,
+		0, 0, 0, 115, 116, 5, 16, 0, 0, 116, 19, 1, 0, 0, 0, 117, 118, 5, 9, 0,
+		0, 118, 21, 1, 0, 0, 0, 119, 120, 5, 4, 0, 0, 120, 23, 1, 0, 0, 0, 121,
+		122, 5, 17, 0, 0, 122, 123, 3, 180, 28, 0, 123, 124, 5, 18, 0, 0, 124,
+		25, 1, 0, 0, 0, 125, 126, 3, 28, 14, 0, 126, 127, 3, 12, 6, 0, 127, 27,
+		1, 0, 0, 0, 128, 129, 5, 19, 0, 0, 129, 29, 1, 0, 0, 0, 130, 131, 3, 32,
+		16, 0, 131, 132, 5, 17, 0, 0, 132, 137, 3, 34, 17, 0, 133, 134, 5, 1, 0,
+		0, 134, 136, 3, 34, 17, 0, 135, 133, 1, 0, 0, 0, 136, 139, 1, 0, 0, 0,
+		137, 135, 1, 0, 0, 0, 137, 138, 1, 0, 0, 0, 138, 140, 1, 0, 0, 0, 139,
+		137, 1, 0, 0, 0, 140, 144, 5, 18, 0, 0, 141, 143, 3, 16, 8, 0, 142, 141,
+		1, 0, 0, 0, 143, 146, 1, 0, 0, 0, 144, 142, 1, 0, 0, 0, 144, 145, 1, 0,
+		0, 0, 145, 31, 1, 0, 0, 0, 146, 144, 1, 0, 0, 0, 147, 148, 5, 16, 0, 0,
+		148, 33, 1, 0, 0, 0, 149, 156, 1, 0, 0, 0, 150, 156, 3, 180, 28, 0, 151,
+		156, 3, 22, 11, 0, 152, 156, 3, 20, 10, 0, 153, 156, 3, 48, 24, 0, 154,
+		156, 3, 30, 15, 0, 155, 149, 1, 0, 0, 0, 155, 150, 1, 0, 0, 0, 156, 35,
+		1, 0, 0, 0, 157, 158, 5, 2, 0, 0, 158, 159, 3, 38, 19, 0, 159, 160, 5,
+		3, 0, 0, 160, 37, 1, 0, 0, 0, 161, 162, 3, 12, 6, 0, 162, 39, 1, 0, 0,
+		0, 163, 164, 5, 21, 0, 0, 164, 41, 1, 0, 0, 0, 165, 166, 5, 20, 0, 0,
+		166, 43, 1, 0, 0, 0, 167, 168, 7, 0, 0, 0, 168, 45, 1, 0, 0, 0, 169,
+		170, 7, 1, 0, 0, 170, 47, 1, 0, 0, 0, 171, 172, 5, 5, 0, 0, 172, 49, 1,
+		0, 0, 0, 173, 174, 5, 7, 0, 0, 174, 51, 1, 0, 0, 0, 175, 176, 5, 8, 0,
+		0, 176, 53, 1, 0, 0, 0, 177, 178, 5, 23, 0, 0, 178, 55, 1, 0, 0, 0, 180,
+		190, 1, 0, 0, 0, 190, 196, 3, 4, 2, 0, 191, 192, 5, 29, 0, 0, 192, 193,
+		3, 180, 28, 0, 193, 194, 5, 30, 0, 0, 194, 195, 3, 180, 28, 0, 195, 197,
+		1, 0, 0, 0, 196, 191, 1, 0, 0, 0, 196, 197, 1, 0, 0, 0, 197, 181, 1, 0,
+		0, 0, 182, 198, 1, 0, 0, 0, 198, 204, 3, 184, 30, 0, 199, 200, 3, 186,
+		31, 0, 200, 201, 3, 184, 30, 0, 201, 203, 1, 0, 0, 0, 202, 199, 1, 0, 0,
+		0, 203, 206, 1, 0, 0, 0, 204, 202, 1, 0, 0, 0, 204, 205, 1, 0, 0, 0,
+		205, 183, 1, 0, 0, 0, 206, 204, 1, 0, 0, 0, 184, 207, 1, 0, 0, 0, 207,
+		213, 3, 12, 6, 0, 208, 209, 3, 188, 32, 0, 209, 210, 3, 12, 6, 0, 210,
+		212, 1, 0, 0, 0, 211, 208, 1, 0, 0, 0, 212, 215, 1, 0, 0, 0, 213, 211,
+		1, 0, 0, 0, 213, 214, 1, 0, 0, 0, 214, 185, 1, 0, 0, 0, 215, 213, 1, 0,
+		0, 0, 186, 216, 1, 0, 0, 0, 216, 217, 7, 2, 0, 0, 217, 187, 1, 0, 0, 0,
+		188, 218, 1, 0, 0, 0, 218, 219, 7, 3, 0, 0, 219, 189, 1, 0, 0, 0, 220,
+		100, 3, 48, 24, 0, 99, 220, 1, 0, 0, 0, 13, 69, 78, 85, 91, 99, 105,
+		113, 137, 144, 155, 196, 204, 213,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -186,38 +206,49 @@ const (
 	ActionParserDOT                      = 22
 	ActionParserSTAR                     = 23
 	ActionParserWS                       = 24
+	ActionParserPLUS                     = 25
+	ActionParserMINUS                    = 26
+	ActionParserSLASH                    = 27
+	ActionParserPERCENT                  = 28
+	ActionParserQUESTION                 = 29
+	ActionParserCOLON                    = 30
 )
 
 // ActionParser rules.
 const (
-	ActionParserRULE_start                 = 0
-	ActionParserRULE_expression            = 1
-	ActionParserRULE_orExpression          = 2
-	ActionParserRULE_andExpression         = 3
-	ActionParserRULE_comparisonExpression  = 4
-	ActionParserRULE_equalityExpression    = 5
-	ActionParserRULE_primaryExpression     = 6
-	ActionParserRULE_variableContext       = 7
-	ActionParserRULE_variablePath          = 8
-	ActionParserRULE_variableIdentifier    = 9
-	ActionParserRULE_numberExpression      = 10
-	ActionParserRULE_stringExpression      = 11
-	ActionParserRULE_termExpression        = 12
-	ActionParserRULE_notExpression         = 13
-	ActionParserRULE_notOperator           = 14
-	ActionParserRULE_functionCall          = 15
-	ActionParserRULE_functionName          = 16
-	ActionParserRULE_functionCallArguments = 17
-	ActionParserRULE_array                 = 18
-	ActionParserRULE_arrayIndex            = 19
-	ActionParserRULE_andOperator           = 20
-	ActionParserRULE_orOperator            = 21
-	ActionParserRULE_comparisonOperator    = 22
-	ActionParserRULE_equalityOperator      = 23
-	ActionParserRULE_booleanExpression     = 24
-	ActionParserRULE_expressionStart       = 25
-	ActionParserRULE_expressionEnd         = 26
-	ActionParserRULE_filterExpression      = 27
+	ActionParserRULE_start                    = 0
+	ActionParserRULE_expression               = 1
+	ActionParserRULE_orExpression             = 2
+	ActionParserRULE_andExpression            = 3
+	ActionParserRULE_comparisonExpression     = 4
+	ActionParserRULE_equalityExpression       = 5
+	ActionParserRULE_primaryExpression        = 6
+	ActionParserRULE_variableContext          = 7
+	ActionParserRULE_variablePath             = 8
+	ActionParserRULE_variableIdentifier       = 9
+	ActionParserRULE_numberExpression         = 10
+	ActionParserRULE_stringExpression         = 11
+	ActionParserRULE_termExpression           = 12
+	ActionParserRULE_notExpression            = 13
+	ActionParserRULE_notOperator              = 14
+	ActionParserRULE_functionCall             = 15
+	ActionParserRULE_functionName             = 16
+	ActionParserRULE_functionCallArguments    = 17
+	ActionParserRULE_array                    = 18
+	ActionParserRULE_arrayIndex               = 19
+	ActionParserRULE_andOperator              = 20
+	ActionParserRULE_orOperator               = 21
+	ActionParserRULE_comparisonOperator       = 22
+	ActionParserRULE_equalityOperator         = 23
+	ActionParserRULE_booleanExpression        = 24
+	ActionParserRULE_expressionStart          = 25
+	ActionParserRULE_expressionEnd            = 26
+	ActionParserRULE_filterExpression         = 27
+	ActionParserRULE_ternaryExpression        = 28
+	ActionParserRULE_additiveExpression       = 29
+	ActionParserRULE_multiplicativeExpression = 30
+	ActionParserRULE_additiveOperator         = 31
+	ActionParserRULE_multiplicativeOperator   = 32
 )
 
 // IStartContext is an interface to support dynamic dispatch.
@@ -347,7 +378,7 @@ type IExpressionContext interface {
 
 	// Getter signatures
 	ExpressionStart() IExpressionStartContext
-	OrExpression() IOrExpressionContext
+	TernaryExpression() ITernaryExpressionContext
 	ExpressionEnd() IExpressionEndContext
 
 	// IsExpressionContext differentiates from other interfaces.
@@ -397,10 +428,10 @@ func (s *ExpressionContext) ExpressionStart() IExpressionStartContext {
 	return t.(IExpressionStartContext)
 }
 
-func (s *ExpressionContext) OrExpression() IOrExpressionContext {
+func (s *ExpressionContext) TernaryExpression() ITernaryExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IOrExpressionContext); ok {
+		if _, ok := ctx.(ITernaryExpressionContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -410,7 +441,7 @@ func (s *ExpressionContext) OrExpression() IOrExpressionContext {
 		return nil
 	}
 
-	return t.(IOrExpressionContext)
+	return t.(ITernaryExpressionContext)
 }
 
 func (s *ExpressionContext) ExpressionEnd() IExpressionEndContext {
@@ -479,7 +510,7 @@ func (p *ActionParser) Expression() (localctx IExpressionContext) {
 	}
 	{
 		p.SetState(60)
-		p.OrExpression()
+		p.TernaryExpression()
 	}
 	{
 		p.SetState(61)
@@ -1059,8 +1090,8 @@ type IEqualityExpressionContext interface {
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	AllPrimaryExpression() []IPrimaryExpressionContext
-	PrimaryExpression(i int) IPrimaryExpressionContext
+	AllAdditiveExpression() []IAdditiveExpressionContext
+	AdditiveExpression(i int) IAdditiveExpressionContext
 	EqualityOperator() IEqualityOperatorContext
 
 	// IsEqualityExpressionContext differentiates from other interfaces.
@@ -1094,20 +1125,20 @@ func NewEqualityExpressionContext(parser antlr.Parser, parent antlr.ParserRuleCo
 
 func (s *EqualityExpressionContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *EqualityExpressionContext) AllPrimaryExpression() []IPrimaryExpressionContext {
+func (s *EqualityExpressionContext) AllAdditiveExpression() []IAdditiveExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(IPrimaryExpressionContext); ok {
+		if _, ok := ctx.(IAdditiveExpressionContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]IPrimaryExpressionContext, len)
+	tst := make([]IAdditiveExpressionContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(IPrimaryExpressionContext); ok {
-			tst[i] = t.(IPrimaryExpressionContext)
+		if t, ok := ctx.(IAdditiveExpressionContext); ok {
+			tst[i] = t.(IAdditiveExpressionContext)
 			i++
 		}
 	}
@@ -1115,11 +1146,11 @@ func (s *EqualityExpressionContext) AllPrimaryExpression() []IPrimaryExpressionC
 	return tst
 }
 
-func (s *EqualityExpressionContext) PrimaryExpression(i int) IPrimaryExpressionContext {
+func (s *EqualityExpressionContext) AdditiveExpression(i int) IAdditiveExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IPrimaryExpressionContext); ok {
+		if _, ok := ctx.(IAdditiveExpressionContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -1132,7 +1163,7 @@ func (s *EqualityExpressionContext) PrimaryExpression(i int) IPrimaryExpressionC
 		return nil
 	}
 
-	return t.(IPrimaryExpressionContext)
+	return t.(IAdditiveExpressionContext)
 }
 
 func (s *EqualityExpressionContext) EqualityOperator() IEqualityOperatorContext {
@@ -1198,7 +1229,7 @@ func (p *ActionParser) EqualityExpression() (localctx IEqualityExpressionContext
 	p.EnterOuterAlt(localctx, 1)
 	{
 		p.SetState(87)
-		p.PrimaryExpression()
+		p.AdditiveExpression()
 	}
 	p.SetState(91)
 	p.GetErrorHandler().Sync(p)
@@ -1211,7 +1242,7 @@ func (p *ActionParser) EqualityExpression() (localctx IEqualityExpressionContext
 		}
 		{
 			p.SetState(89)
-			p.PrimaryExpression()
+			p.AdditiveExpression()
 		}
 
 	}
@@ -1233,6 +1264,7 @@ type IPrimaryExpressionContext interface {
 	StringExpression() IStringExpressionContext
 	TermExpression() ITermExpressionContext
 	NotExpression() INotExpressionContext
+	BooleanExpression() IBooleanExpressionContext
 
 	// IsPrimaryExpressionContext differentiates from other interfaces.
 	IsPrimaryExpressionContext()
@@ -1361,6 +1393,22 @@ func (s *PrimaryExpressionContext) NotExpression() INotExpressionContext {
 	return t.(INotExpressionContext)
 }
 
+func (s *PrimaryExpressionContext) BooleanExpression() IBooleanExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IBooleanExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IBooleanExpressionContext)
+}
+
 func (s *PrimaryExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -1449,6 +1497,13 @@ func (p *ActionParser) PrimaryExpression() (localctx IPrimaryExpressionContext)
 			p.NotExpression()
 		}
 
+	case 7:
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(220)
+			p.BooleanExpression()
+		}
+
 	}
 
 	return localctx
@@ -2095,7 +2150,7 @@ type ITermExpressionContext interface {
 
 	// Getter signatures
 	LPAREN() antlr.TerminalNode
-	OrExpression() IOrExpressionContext
+	TernaryExpression() ITernaryExpressionContext
 	RPAREN() antlr.TerminalNode
 
 	// IsTermExpressionContext differentiates from other interfaces.
@@ -2133,10 +2188,10 @@ func (s *TermExpressionContext) LPAREN() antlr.TerminalNode {
 	return s.GetToken(ActionParserLPAREN, 0)
 }
 
-func (s *TermExpressionContext) OrExpression() IOrExpressionContext {
+func (s *TermExpressionContext) TernaryExpression() ITernaryExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IOrExpressionContext); ok {
+		if _, ok := ctx.(ITernaryExpressionContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -2146,7 +2201,7 @@ func (s *TermExpressionContext) OrExpression() IOrExpressionContext {
 		return nil
 	}
 
-	return t.(IOrExpressionContext)
+	return t.(ITernaryExpressionContext)
 }
 
 func (s *TermExpressionContext) RPAREN() antlr.TerminalNode {
@@ -2203,7 +2258,7 @@ func (p *ActionParser) TermExpression() (localctx ITermExpressionContext) {
 	}
 	{
 		p.SetState(122)
-		p.OrExpression()
+		p.TernaryExpression()
 	}
 	{
 		p.SetState(123)
@@ -2795,11 +2850,7 @@ type IFunctionCallArgumentsContext interface {
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	VariableContext() IVariableContextContext
-	StringExpression() IStringExpressionContext
-	NumberExpression() INumberExpressionContext
-	BooleanExpression() IBooleanExpressionContext
-	FunctionCall() IFunctionCallContext
+	TernaryExpression() ITernaryExpressionContext
 
 	// IsFunctionCallArgumentsContext differentiates from other interfaces.
 	IsFunctionCallArgumentsContext()
@@ -2832,74 +2883,10 @@ func NewFunctionCallArgumentsContext(parser antlr.Parser, parent antlr.ParserRul
 
 func (s *FunctionCallArgumentsContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *FunctionCallArgumentsContext) VariableContext() IVariableContextContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IVariableContextContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IVariableContextContext)
-}
-
-func (s *FunctionCallArgumentsContext) StringExpression() IStringExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IStringExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IStringExpressionContext)
-}
-
-func (s *FunctionCallArgumentsContext) NumberExpression() INumberExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(INumberExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(INumberExpressionContext)
-}
-
-func (s *FunctionCallArgumentsContext) BooleanExpression() IBooleanExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IBooleanExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IBooleanExpressionContext)
-}
-
-func (s *FunctionCallArgumentsContext) FunctionCall() IFunctionCallContext {
+func (s *FunctionCallArgumentsContext) TernaryExpression() ITernaryExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IFunctionCallContext); ok {
+		if _, ok := ctx.(ITernaryExpressionContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -2909,7 +2896,7 @@ func (s *FunctionCallArgumentsContext) FunctionCall() IFunctionCallContext {
 		return nil
 	}
 
-	return t.(IFunctionCallContext)
+	return t.(ITernaryExpressionContext)
 }
 
 func (s *FunctionCallArgumentsContext) GetRuleContext() antlr.RuleContext {
@@ -2965,35 +2952,7 @@ func (p *ActionParser) FunctionCallArguments() (localctx IFunctionCallArgumentsC
 		p.EnterOuterAlt(localctx, 2)
 		{
 			p.SetState(150)
-			p.VariableContext()
-		}
-
-	case 3:
-		p.EnterOuterAlt(localctx, 3)
-		{
-			p.SetState(151)
-			p.StringExpression()
-		}
-
-	case 4:
-		p.EnterOuterAlt(localctx, 4)
-		{
-			p.SetState(152)
-			p.NumberExpression()
-		}
-
-	case 5:
-		p.EnterOuterAlt(localctx, 5)
-		{
-			p.SetState(153)
-			p.BooleanExpression()
-		}
-
-	case 6:
-		p.EnterOuterAlt(localctx, 6)
-		{
-			p.SetState(154)
-			p.FunctionCall()
+			p.TernaryExpression()
 		}
 
 	}
@@ -4038,3 +3997,808 @@ func (p *ActionParser) FilterExpression() (localctx IFilterExpressionContext) {
 
 	return localctx
 }
+
+// ITernaryExpressionContext is an interface to support dynamic dispatch.
+type ITernaryExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	OrExpression() IOrExpressionContext
+	QUESTION() antlr.TerminalNode
+	AllTernaryExpression() []ITernaryExpressionContext
+	TernaryExpression(i int) ITernaryExpressionContext
+	COLON() antlr.TerminalNode
+
+	// IsTernaryExpressionContext differentiates from other interfaces.
+	IsTernaryExpressionContext()
+}
+
+type TernaryExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTernaryExpressionContext() *TernaryExpressionContext {
+	var p = new(TernaryExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ActionParserRULE_ternaryExpression
+	return p
+}
+
+func (*TernaryExpressionContext) IsTernaryExpressionContext() {}
+
+func NewTernaryExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TernaryExpressionContext {
+	var p = new(TernaryExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ActionParserRULE_ternaryExpression
+
+	return p
+}
+
+func (s *TernaryExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TernaryExpressionContext) OrExpression() IOrExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IOrExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IOrExpressionContext)
+}
+
+func (s *TernaryExpressionContext) QUESTION() antlr.TerminalNode {
+	return s.GetToken(ActionParserQUESTION, 0)
+}
+
+func (s *TernaryExpressionContext) AllTernaryExpression() []ITernaryExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(ITernaryExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]ITernaryExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(ITernaryExpressionContext); ok {
+			tst[i] = t.(ITernaryExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *TernaryExpressionContext) TernaryExpression(i int) ITernaryExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITernaryExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITernaryExpressionContext)
+}
+
+func (s *TernaryExpressionContext) COLON() antlr.TerminalNode {
+	return s.GetToken(ActionParserCOLON, 0)
+}
+
+func (s *TernaryExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TernaryExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *TernaryExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.EnterTernaryExpression(s)
+	}
+}
+
+func (s *TernaryExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.ExitTernaryExpression(s)
+	}
+}
+
+func (p *ActionParser) TernaryExpression() (localctx ITernaryExpressionContext) {
+	this := p
+	_ = this
+
+	localctx = NewTernaryExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 180, ActionParserRULE_ternaryExpression)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(190)
+		p.OrExpression()
+	}
+	p.SetState(196)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	if _la == ActionParserQUESTION {
+		{
+			p.SetState(191)
+			p.Match(ActionParserQUESTION)
+		}
+		{
+			p.SetState(192)
+			p.TernaryExpression()
+		}
+		{
+			p.SetState(193)
+			p.Match(ActionParserCOLON)
+		}
+		{
+			p.SetState(194)
+			p.TernaryExpression()
+		}
+
+	}
+
+	return localctx
+}
+
+// IAdditiveExpressionContext is an interface to support dynamic dispatch.
+type IAdditiveExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllMultiplicativeExpression() []IMultiplicativeExpressionContext
+	MultiplicativeExpression(i int) IMultiplicativeExpressionContext
+	AllAdditiveOperator() []IAdditiveOperatorContext
+	AdditiveOperator(i int) IAdditiveOperatorContext
+
+	// IsAdditiveExpressionContext differentiates from other interfaces.
+	IsAdditiveExpressionContext()
+}
+
+type AdditiveExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAdditiveExpressionContext() *AdditiveExpressionContext {
+	var p = new(AdditiveExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ActionParserRULE_additiveExpression
+	return p
+}
+
+func (*AdditiveExpressionContext) IsAdditiveExpressionContext() {}
+
+func NewAdditiveExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AdditiveExpressionContext {
+	var p = new(AdditiveExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ActionParserRULE_additiveExpression
+
+	return p
+}
+
+func (s *AdditiveExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AdditiveExpressionContext) AllMultiplicativeExpression() []IMultiplicativeExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IMultiplicativeExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IMultiplicativeExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IMultiplicativeExpressionContext); ok {
+			tst[i] = t.(IMultiplicativeExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *AdditiveExpressionContext) MultiplicativeExpression(i int) IMultiplicativeExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMultiplicativeExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMultiplicativeExpressionContext)
+}
+
+func (s *AdditiveExpressionContext) AllAdditiveOperator() []IAdditiveOperatorContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IAdditiveOperatorContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IAdditiveOperatorContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IAdditiveOperatorContext); ok {
+			tst[i] = t.(IAdditiveOperatorContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *AdditiveExpressionContext) AdditiveOperator(i int) IAdditiveOperatorContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IAdditiveOperatorContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IAdditiveOperatorContext)
+}
+
+func (s *AdditiveExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AdditiveExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *AdditiveExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.EnterAdditiveExpression(s)
+	}
+}
+
+func (s *AdditiveExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.ExitAdditiveExpression(s)
+	}
+}
+
+func (p *ActionParser) AdditiveExpression() (localctx IAdditiveExpressionContext) {
+	this := p
+	_ = this
+
+	localctx = NewAdditiveExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 182, ActionParserRULE_additiveExpression)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(198)
+		p.MultiplicativeExpression()
+	}
+	p.SetState(204)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == ActionParserPLUS || _la == ActionParserMINUS {
+		{
+			p.SetState(199)
+			p.AdditiveOperator()
+		}
+		{
+			p.SetState(200)
+			p.MultiplicativeExpression()
+		}
+
+		p.SetState(206)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IMultiplicativeExpressionContext is an interface to support dynamic dispatch.
+type IMultiplicativeExpressionContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllPrimaryExpression() []IPrimaryExpressionContext
+	PrimaryExpression(i int) IPrimaryExpressionContext
+	AllMultiplicativeOperator() []IMultiplicativeOperatorContext
+	MultiplicativeOperator(i int) IMultiplicativeOperatorContext
+
+	// IsMultiplicativeExpressionContext differentiates from other interfaces.
+	IsMultiplicativeExpressionContext()
+}
+
+type MultiplicativeExpressionContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMultiplicativeExpressionContext() *MultiplicativeExpressionContext {
+	var p = new(MultiplicativeExpressionContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ActionParserRULE_multiplicativeExpression
+	return p
+}
+
+func (*MultiplicativeExpressionContext) IsMultiplicativeExpressionContext() {}
+
+func NewMultiplicativeExpressionContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MultiplicativeExpressionContext {
+	var p = new(MultiplicativeExpressionContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ActionParserRULE_multiplicativeExpression
+
+	return p
+}
+
+func (s *MultiplicativeExpressionContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MultiplicativeExpressionContext) AllPrimaryExpression() []IPrimaryExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IPrimaryExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IPrimaryExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IPrimaryExpressionContext); ok {
+			tst[i] = t.(IPrimaryExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *MultiplicativeExpressionContext) PrimaryExpression(i int) IPrimaryExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IPrimaryExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IPrimaryExpressionContext)
+}
+
+func (s *MultiplicativeExpressionContext) AllMultiplicativeOperator() []IMultiplicativeOperatorContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IMultiplicativeOperatorContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IMultiplicativeOperatorContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IMultiplicativeOperatorContext); ok {
+			tst[i] = t.(IMultiplicativeOperatorContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *MultiplicativeExpressionContext) MultiplicativeOperator(i int) IMultiplicativeOperatorContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IMultiplicativeOperatorContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IMultiplicativeOperatorContext)
+}
+
+func (s *MultiplicativeExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MultiplicativeExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MultiplicativeExpressionContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.EnterMultiplicativeExpression(s)
+	}
+}
+
+func (s *MultiplicativeExpressionContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.ExitMultiplicativeExpression(s)
+	}
+}
+
+func (p *ActionParser) MultiplicativeExpression() (localctx IMultiplicativeExpressionContext) {
+	this := p
+	_ = this
+
+	localctx = NewMultiplicativeExpressionContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 184, ActionParserRULE_multiplicativeExpression)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(207)
+		p.PrimaryExpression()
+	}
+	p.SetState(213)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+	for (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&411041792) != 0 {
+		{
+			p.SetState(208)
+			p.MultiplicativeOperator()
+		}
+		{
+			p.SetState(209)
+			p.PrimaryExpression()
+		}
+
+		p.SetState(215)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+	}
+
+	return localctx
+}
+
+// IAdditiveOperatorContext is an interface to support dynamic dispatch.
+type IAdditiveOperatorContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	PLUS() antlr.TerminalNode
+	MINUS() antlr.TerminalNode
+
+	// IsAdditiveOperatorContext differentiates from other interfaces.
+	IsAdditiveOperatorContext()
+}
+
+type AdditiveOperatorContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyAdditiveOperatorContext() *AdditiveOperatorContext {
+	var p = new(AdditiveOperatorContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ActionParserRULE_additiveOperator
+	return p
+}
+
+func (*AdditiveOperatorContext) IsAdditiveOperatorContext() {}
+
+func NewAdditiveOperatorContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *AdditiveOperatorContext {
+	var p = new(AdditiveOperatorContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ActionParserRULE_additiveOperator
+
+	return p
+}
+
+func (s *AdditiveOperatorContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *AdditiveOperatorContext) PLUS() antlr.TerminalNode {
+	return s.GetToken(ActionParserPLUS, 0)
+}
+
+func (s *AdditiveOperatorContext) MINUS() antlr.TerminalNode {
+	return s.GetToken(ActionParserMINUS, 0)
+}
+
+func (s *AdditiveOperatorContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *AdditiveOperatorContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *AdditiveOperatorContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.EnterAdditiveOperator(s)
+	}
+}
+
+func (s *AdditiveOperatorContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.ExitAdditiveOperator(s)
+	}
+}
+
+func (p *ActionParser) AdditiveOperator() (localctx IAdditiveOperatorContext) {
+	this := p
+	_ = this
+
+	localctx = NewAdditiveOperatorContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 186, ActionParserRULE_additiveOperator)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(216)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&100663296) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
+
+// IMultiplicativeOperatorContext is an interface to support dynamic dispatch.
+type IMultiplicativeOperatorContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	STAR() antlr.TerminalNode
+	SLASH() antlr.TerminalNode
+	PERCENT() antlr.TerminalNode
+
+	// IsMultiplicativeOperatorContext differentiates from other interfaces.
+	IsMultiplicativeOperatorContext()
+}
+
+type MultiplicativeOperatorContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyMultiplicativeOperatorContext() *MultiplicativeOperatorContext {
+	var p = new(MultiplicativeOperatorContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = ActionParserRULE_multiplicativeOperator
+	return p
+}
+
+func (*MultiplicativeOperatorContext) IsMultiplicativeOperatorContext() {}
+
+func NewMultiplicativeOperatorContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *MultiplicativeOperatorContext {
+	var p = new(MultiplicativeOperatorContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = ActionParserRULE_multiplicativeOperator
+
+	return p
+}
+
+func (s *MultiplicativeOperatorContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *MultiplicativeOperatorContext) STAR() antlr.TerminalNode {
+	return s.GetToken(ActionParserSTAR, 0)
+}
+
+func (s *MultiplicativeOperatorContext) SLASH() antlr.TerminalNode {
+	return s.GetToken(ActionParserSLASH, 0)
+}
+
+func (s *MultiplicativeOperatorContext) PERCENT() antlr.TerminalNode {
+	return s.GetToken(ActionParserPERCENT, 0)
+}
+
+func (s *MultiplicativeOperatorContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *MultiplicativeOperatorContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (s *MultiplicativeOperatorContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.EnterMultiplicativeOperator(s)
+	}
+}
+
+func (s *MultiplicativeOperatorContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(ActionListener); ok {
+		listenerT.ExitMultiplicativeOperator(s)
+	}
+}
+
+func (p *ActionParser) MultiplicativeOperator() (localctx IMultiplicativeOperatorContext) {
+	this := p
+	_ = this
+
+	localctx = NewMultiplicativeOperatorContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 188, ActionParserRULE_multiplicativeOperator)
+	var _la int
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(218)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&411041792) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+	return localctx
+}
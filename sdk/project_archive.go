@@ -0,0 +1,226 @@
+package sdk
+
+import (
+	"time"
+)
+
+const (
+	ProjectArchiveVersion = "1"
+
+	ProjectArchiveEntityVCS          = "vcs"
+	ProjectArchiveEntityRepository   = "repository"
+	ProjectArchiveEntityVariableSet  = "variableset"
+	ProjectArchiveEntityIntegration  = "integration"
+	ProjectArchiveEntityNotification = "notification"
+	ProjectArchiveEntityConcurrency  = "concurrency"
+	ProjectArchiveEntityRunFilter    = "run-filter"
+	ProjectArchiveEntityRunRetention = "run-retention"
+	ProjectArchiveEntityKey          = "key"
+	ProjectArchiveEntityPermission   = "permission"
+
+	ProjectImportActionCreated = "created"
+	ProjectImportActionUpdated = "updated"
+	ProjectImportActionSkipped = "skipped"
+)
+
+// ProjectArchive contains the configuration of a v2 project that is not stored as code.
+// Secret values are encrypted for the CDS instance whose public key is RecipientKey.
+type ProjectArchive struct {
+	Version       string                `json:"version"`
+	ExportedAt    time.Time             `json:"exported_at"`
+	ExportedBy    string                `json:"exported_by"`
+	Source        string                `json:"source"`
+	RecipientKey  string                `json:"recipient_key"`
+	Project       ProjectArchiveProject `json:"project"`
+	VCSServers    []ProjectArchiveVCS   `json:"vcs_servers,omitempty"`
+	VariableSets  []ProjectVariableSet  `json:"variable_sets,omitempty"`
+	Integrations  []ProjectIntegration  `json:"integrations,omitempty"`
+	Notifications []ProjectNotification `json:"notifications,omitempty"`
+	Concurrencies []ProjectConcurrency  `json:"concurrencies,omitempty"`
+	RunFilters    []ProjectRunFilter    `json:"run_filters,omitempty"`
+	RunRetention  *Retentions           `json:"run_retention,omitempty"`
+	Keys          []ProjectKey          `json:"keys,omitempty"`
+	Permissions   []RBAC                `json:"permissions,omitempty"`
+}
+
+type ProjectArchiveProject struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type ProjectArchiveVCS struct {
+	VCSProject
+	Repositories []ProjectRepository `json:"repositories,omitempty"`
+}
+
+// SignedProjectArchive is the file produced by a project export: a JWS of the archive and the public key of the exporting instance.
+type SignedProjectArchive struct {
+	SignerKey string `json:"signer_key"`
+	Archive   string `json:"archive"`
+}
+
+type ProjectExportRequest struct {
+	// Target is the name of a trusted CDS instance, secrets are encrypted for this instance. Empty means the current instance.
+	Target string `json:"target,omitempty"`
+}
+
+type ProjectImportReportLine struct {
+	Type    string `json:"type" cli:"type"`
+	Name    string `json:"name" cli:"name"`
+	Action  string `json:"action" cli:"action"`
+	Message string `json:"message,omitempty" cli:"message"`
+}
+
+// TransformSecrets applies f on each non empty secret value of the archive.
+// It is used to encrypt the secrets for the target instance on export and to decrypt them on import.
+func (a *ProjectArchive) TransformSecrets(f func(string) (string, error)) error {
+	transform := func(s *string) error {
+		if *s == "" {
+			return nil
+		}
+		v, err := f(*s)
+		if err != nil {
+			return err
+		}
+		*s = v
+		return nil
+	}
+
+	for i := range a.VCSServers {
+		auth := &a.VCSServers[i].Auth
+		for _, s := range []*string{&auth.Token, &auth.SSHPrivateKey} {
+			if err := transform(s); err != nil {
+				return WrapError(err, "vcs %s", a.VCSServers[i].Name)
+			}
+		}
+	}
+	for i := range a.VariableSets {
+		for j := range a.VariableSets[i].Items {
+			item := &a.VariableSets[i].Items[j]
+			if item.Type != ProjectVariableTypeSecret {
+				continue
+			}
+			if err := transform(&item.Value); err != nil {
+				return WrapError(err, "variable set %s item %s", a.VariableSets[i].Name, item.Name)
+			}
+		}
+	}
+	for i := range a.Integrations {
+		for k, v := range a.Integrations[i].Config {
+			if v.Type != IntegrationConfigTypePassword {
+				continue
+			}
+			if err := transform(&v.Value); err != nil {
+				return WrapError(err, "integration %s config %s", a.Integrations[i].Name, k)
+			}
+			a.Integrations[i].Config[k] = v
+		}
+	}
+	for i := range a.Notifications {
+		for k, v := range a.Notifications[i].Auth.Headers {
+			if err := transform(&v); err != nil {
+				return WrapError(err, "notification %s header %s", a.Notifications[i].Name, k)
+			}
+			a.Notifications[i].Auth.Headers[k] = v
+		}
+	}
+	for i := range a.Keys {
+		if err := transform(&a.Keys[i].Private); err != nil {
+			return WrapError(err, "key %s", a.Keys[i].Name)
+		}
+	}
+	return nil
+}
+
+// SetProjectKey moves all the entities of the archive to the given project key.
+func (a *ProjectArchive) SetProjectKey(projectKey string) {
+	oldKey := a.Project.Key
+	a.Project.Key = projectKey
+	for i := range a.VCSServers {
+		for j := range a.VCSServers[i].Repositories {
+			a.VCSServers[i].Repositories[j].ProjectKey = projectKey
+		}
+	}
+	for i := range a.VariableSets {
+		a.VariableSets[i].ProjectKey = projectKey
+	}
+	for i := range a.Notifications {
+		a.Notifications[i].ProjectKey = projectKey
+	}
+	for i := range a.Concurrencies {
+		a.Concurrencies[i].ProjectKey = projectKey
+	}
+	for i := range a.RunFilters {
+		a.RunFilters[i].ProjectKey = projectKey
+	}
+	for i := range a.Permissions {
+		p := &a.Permissions[i]
+		for j := range p.Projects {
+			for k := range p.Projects[j].RBACProjectKeys {
+				if p.Projects[j].RBACProjectKeys[k] == oldKey {
+					p.Projects[j].RBACProjectKeys[k] = projectKey
+				}
+			}
+		}
+		for j := range p.Workflows {
+			p.Workflows[j].ProjectKey = projectKey
+		}
+		for j := range p.VariableSets {
+			p.VariableSets[j].ProjectKey = projectKey
+		}
+	}
+}
+
+// ProjectPermissions returns a copy of the rule that only contains the permissions given on the project.
+func (rbac RBAC) ProjectPermissions(projectKey string) RBAC {
+	r := RBAC{Name: rbac.Name}
+	for _, p := range rbac.Projects {
+		if IsInArray(projectKey, p.RBACProjectKeys) {
+			p.RBACProjectKeys = []string{projectKey}
+			r.Projects = append(r.Projects, p)
+		}
+	}
+	for _, w := range rbac.Workflows {
+		if w.ProjectKey == projectKey {
+			r.Workflows = append(r.Workflows, w)
+		}
+	}
+	for _, vs := range rbac.VariableSets {
+		if vs.ProjectKey == projectKey {
+			r.VariableSets = append(r.VariableSets, vs)
+		}
+	}
+	return r
+}
+
+// WithoutProjectPermissions returns a copy of the rule without the permissions given on the project.
+func (rbac RBAC) WithoutProjectPermissions(projectKey string) RBAC {
+	r := rbac
+	r.Projects = nil
+	for _, p := range rbac.Projects {
+		keys := make([]string, 0, len(p.RBACProjectKeys))
+		for _, k := range p.RBACProjectKeys {
+			if k != projectKey {
+				keys = append(keys, k)
+			}
+		}
+		if len(keys) > 0 {
+			p.RBACProjectKeys = keys
+			r.Projects = append(r.Projects, p)
+		}
+	}
+	r.Workflows = nil
+	for _, w := range rbac.Workflows {
+		if w.ProjectKey != projectKey {
+			r.Workflows = append(r.Workflows, w)
+		}
+	}
+	r.VariableSets = nil
+	for _, vs := range rbac.VariableSets {
+		if vs.ProjectKey != projectKey {
+			r.VariableSets = append(r.VariableSets, vs)
+		}
+	}
+	return r
+}
@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectArchiveTransformSecrets(t *testing.T) {
+	a := ProjectArchive{
+		Project: ProjectArchiveProject{Key: "PROJ"},
+		VCSServers: []ProjectArchiveVCS{{
+			VCSProject: VCSProject{Name: "github", Auth: VCSAuthProject{Username: "bot", Token: "token"}},
+		}},
+		VariableSets: []ProjectVariableSet{{
+			Name: "vs",
+			Items: []ProjectVariableSetItem{
+				{Name: "text", Type: ProjectVariableTypeString, Value: "text"},
+				{Name: "secret", Type: ProjectVariableTypeSecret, Value: "secret"},
+			},
+		}},
+		Integrations: []ProjectIntegration{{
+			Name: "artifactory",
+			Config: IntegrationConfig{
+				"url":   IntegrationConfigValue{Type: IntegrationConfigTypeString, Value: "url"},
+				"token": IntegrationConfigValue{Type: IntegrationConfigTypePassword, Value: "token"},
+			},
+		}},
+		Notifications: []ProjectNotification{{
+			Name: "webhook",
+			Auth: ProjectNotificationAuth{Headers: map[string]string{"Authorization": "bearer"}},
+		}},
+		Keys: []ProjectKey{{Name: "ssh", Public: "public", Private: "private"}},
+	}
+
+	require.NoError(t, a.TransformSecrets(func(s string) (string, error) { return strings.ToUpper(s), nil }))
+
+	require.Equal(t, "bot", a.VCSServers[0].Auth.Username)
+	require.Equal(t, "TOKEN", a.VCSServers[0].Auth.Token)
+	require.Equal(t, "", a.VCSServers[0].Auth.SSHPrivateKey)
+	require.Equal(t, "text", a.VariableSets[0].Items[0].Value)
+	require.Equal(t, "SECRET", a.VariableSets[0].Items[1].Value)
+	require.Equal(t, "url", a.Integrations[0].Config["url"].Value)
+	require.Equal(t, "TOKEN", a.Integrations[0].Config["token"].Value)
+	require.Equal(t, "BEARER", a.Notifications[0].Auth.Headers["Authorization"])
+	require.Equal(t, "public", a.Keys[0].Public)
+	require.Equal(t, "PRIVATE", a.Keys[0].Private)
+}
+
+func TestRBACProjectPermissions(t *testing.T) {
+	r := RBAC{
+		Name:   "perm",
+		Global: []RBACGlobal{{Role: GlobalRoleManagePermission}},
+		Projects: []RBACProject{
+			{Role: ProjectRoleRead, RBACProjectKeys: []string{"PROJ", "OTHER"}},
+			{Role: ProjectRoleManage, RBACProjectKeys: []string{"OTHER"}},
+		},
+		Workflows: []RBACWorkflow{
+			{Role: WorkflowRoleTrigger, ProjectKey: "PROJ"},
+			{Role: WorkflowRoleTrigger, ProjectKey: "OTHER"},
+		},
+		VariableSets: []RBACVariableSet{{Role: VariableSetRoleUse, ProjectKey: "PROJ"}},
+	}
+
+	projectPart := r.ProjectPermissions("PROJ")
+	require.Equal(t, "perm", projectPart.Name)
+	require.Empty(t, projectPart.Global)
+	require.Len(t, projectPart.Projects, 1)
+	require.Equal(t, []string{"PROJ"}, projectPart.Projects[0].RBACProjectKeys)
+	require.Len(t, projectPart.Workflows, 1)
+	require.Len(t, projectPart.VariableSets, 1)
+
+	others := r.WithoutProjectPermissions("PROJ")
+	require.Len(t, others.Global, 1)
+	require.Len(t, others.Projects, 2)
+	require.Equal(t, []string{"OTHER"}, others.Projects[0].RBACProjectKeys)
+	require.Len(t, others.Workflows, 1)
+	require.Equal(t, "OTHER", others.Workflows[0].ProjectKey)
+	require.Empty(t, others.VariableSets)
+	// The original rule is unchanged
+	require.Equal(t, []string{"PROJ", "OTHER"}, r.Projects[0].RBACProjectKeys)
+
+	a := ProjectArchive{Project: ProjectArchiveProject{Key: "PROJ"}, Permissions: []RBAC{projectPart}}
+	a.SetProjectKey("NEWPROJ")
+	require.Equal(t, []string{"NEWPROJ"}, a.Permissions[0].Projects[0].RBACProjectKeys)
+	require.Equal(t, "NEWPROJ", a.Permissions[0].Workflows[0].ProjectKey)
+	require.Equal(t, "NEWPROJ", a.Permissions[0].VariableSets[0].ProjectKey)
+}
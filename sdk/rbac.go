@@ -25,6 +25,7 @@ const (
 	ProjectRoleManageWorkflow         = "manage-workflow"
 	ProjectRoleManageWorkflowTemplate = "manage-workflow-template"
 	ProjectRoleManageVariableSet      = "manage-variableset"
+	ProjectRolePublishRunResult       = "publish-run-result"
 
 	// Hatchery Role
 	HatcheryRoleSpawn = "start-worker"
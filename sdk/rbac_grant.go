@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	RBACGrantScopeProject     = "project"
+	RBACGrantScopeVariableSet = "variableset"
+	RBACGrantScopeWorkflow    = "workflow"
+
+	RBACGrantStatusRequested = "requested"
+	RBACGrantStatusApproved  = "approved"
+	RBACGrantStatusRejected  = "rejected"
+	RBACGrantStatusRevoked   = "revoked"
+	RBACGrantStatusExpired   = "expired"
+
+	// Audit event types on temporary grants
+	AuditRBACGrantRequest = "request"
+	AuditRBACGrantApprove = "approve"
+	AuditRBACGrantReject  = "reject"
+	AuditRBACGrantRevoke  = "revoke"
+	AuditRBACGrantExpire  = "expire"
+	AuditRBACGrantUse     = "use"
+)
+
+// RBACGrant is a temporary permission requested by a user. Once approved, it gives a role to the user until it expires.
+type RBACGrant struct {
+	ID            string     `json:"id" db:"id" cli:"id,key"`
+	UserID        string     `json:"user_id" db:"user_id"`
+	Username      string     `json:"username" db:"username" cli:"username"`
+	Scope         string     `json:"scope" db:"scope" cli:"scope"`
+	ProjectKey    string     `json:"project_key" db:"project_key" cli:"project_key"`
+	Target        string     `json:"target,omitempty" db:"target" cli:"target"`
+	Role          string     `json:"role" db:"role" cli:"role"`
+	Justification string     `json:"justification" db:"justification" cli:"justification"`
+	Duration      string     `json:"duration" db:"duration" cli:"duration"`
+	Status        string     `json:"status" db:"status" cli:"status"`
+	Created       time.Time  `json:"created" db:"created" cli:"created"`
+	ReviewedBy    string     `json:"reviewed_by,omitempty" db:"reviewed_by" cli:"reviewed_by"`
+	ReviewComment string     `json:"review_comment,omitempty" db:"review_comment"`
+	Reviewed      *time.Time `json:"reviewed,omitempty" db:"reviewed"`
+	Expires       *time.Time `json:"expires,omitempty" db:"expires" cli:"expires"`
+}
+
+// RBACGrantRequest is the payload sent by a user to request a temporary grant.
+type RBACGrantRequest struct {
+	Scope         string `json:"scope"`
+	ProjectKey    string `json:"project_key"`
+	Target        string `json:"target,omitempty"`
+	Role          string `json:"role"`
+	Justification string `json:"justification"`
+	Duration      string `json:"duration"`
+}
+
+// RBACGrantReview is the payload sent by an approver to approve, reject or revoke a grant.
+type RBACGrantReview struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// IsValid checks the request against the given maximum duration.
+func (r RBACGrantRequest) IsValid(maxDuration time.Duration) error {
+	if r.ProjectKey == "" {
+		return NewErrorFrom(ErrWrongRequest, "missing project key")
+	}
+	if r.Justification == "" {
+		return NewErrorFrom(ErrWrongRequest, "a justification is mandatory")
+	}
+	switch r.Scope {
+	case RBACGrantScopeProject:
+		if !IsInArray(r.Role, ProjectRoles) {
+			return NewErrorFrom(ErrWrongRequest, "role %s is not a project role", r.Role)
+		}
+		if r.Target != "" {
+			return NewErrorFrom(ErrWrongRequest, "a project grant can't have a target")
+		}
+	case RBACGrantScopeVariableSet:
+		if !IsInArray(r.Role, VariableSetRoles) {
+			return NewErrorFrom(ErrWrongRequest, "role %s is not a variable set role", r.Role)
+		}
+		if r.Target == "" {
+			return NewErrorFrom(ErrWrongRequest, "missing variable set name")
+		}
+	case RBACGrantScopeWorkflow:
+		if !IsInArray(r.Role, WorkflowRoles) {
+			return NewErrorFrom(ErrWrongRequest, "role %s is not a workflow role", r.Role)
+		}
+		if r.Target == "" {
+			return NewErrorFrom(ErrWrongRequest, "missing workflow, expected <vcs>/<repository>/<workflow>")
+		}
+	default:
+		return NewErrorFrom(ErrWrongRequest, "invalid scope %q, expected %s, %s or %s", r.Scope, RBACGrantScopeProject, RBACGrantScopeVariableSet, RBACGrantScopeWorkflow)
+	}
+	d, err := time.ParseDuration(r.Duration)
+	if err != nil {
+		return NewErrorFrom(ErrWrongRequest, "invalid duration %q: %v", r.Duration, err)
+	}
+	if d <= 0 {
+		return NewErrorFrom(ErrWrongRequest, "duration must be positive")
+	}
+	if maxDuration > 0 && d > maxDuration {
+		return NewErrorFrom(ErrWrongRequest, "duration %s exceeds the maximum allowed duration %s", d, maxDuration)
+	}
+	return nil
+}
+
+// IsActive returns true if the grant is approved and not expired.
+func (g RBACGrant) IsActive(now time.Time) bool {
+	return g.Status == RBACGrantStatusApproved && g.Expires != nil && now.Before(*g.Expires)
+}
+
+// String returns a short description of the granted permission.
+func (g RBACGrant) String() string {
+	if g.Target == "" {
+		return fmt.Sprintf("%s on %s %s", g.Role, g.Scope, g.ProjectKey)
+	}
+	return fmt.Sprintf("%s on %s %s/%s", g.Role, g.Scope, g.ProjectKey, g.Target)
+}
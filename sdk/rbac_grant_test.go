@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRBACGrantRequestIsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request RBACGrantRequest
+		wantErr bool
+	}{
+		{
+			name:    "project grant",
+			request: RBACGrantRequest{Scope: RBACGrantScopeProject, ProjectKey: "PROJ", Role: ProjectRoleManage, Justification: "incident", Duration: "4h"},
+		},
+		{
+			name:    "variable set grant",
+			request: RBACGrantRequest{Scope: RBACGrantScopeVariableSet, ProjectKey: "PROJ", Target: "prod", Role: VariableSetRoleManageItem, Justification: "incident", Duration: "30m"},
+		},
+		{
+			name:    "missing justification",
+			request: RBACGrantRequest{Scope: RBACGrantScopeProject, ProjectKey: "PROJ", Role: ProjectRoleManage, Duration: "4h"},
+			wantErr: true,
+		},
+		{
+			name:    "project role on a variable set",
+			request: RBACGrantRequest{Scope: RBACGrantScopeVariableSet, ProjectKey: "PROJ", Target: "prod", Role: ProjectRoleManage, Justification: "incident", Duration: "4h"},
+			wantErr: true,
+		},
+		{
+			name:    "missing workflow",
+			request: RBACGrantRequest{Scope: RBACGrantScopeWorkflow, ProjectKey: "PROJ", Role: WorkflowRoleTrigger, Justification: "incident", Duration: "4h"},
+			wantErr: true,
+		},
+		{
+			name:    "duration too long",
+			request: RBACGrantRequest{Scope: RBACGrantScopeProject, ProjectKey: "PROJ", Role: ProjectRoleManage, Justification: "incident", Duration: "48h"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			request: RBACGrantRequest{Scope: RBACGrantScopeProject, ProjectKey: "PROJ", Role: ProjectRoleManage, Justification: "incident", Duration: "4 hours"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.IsValid(24 * time.Hour)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package sdk
 
 var (
-	ProjectRoles = []string{ProjectRoleRead, ProjectRoleManage, ProjectRoleManageNotification, ProjectRoleManageWorkerModel, ProjectRoleManageAction, ProjectRoleManageWorkflow, ProjectRoleManageWorkflowTemplate, ProjectRoleManageVariableSet}
+	ProjectRoles = []string{ProjectRoleRead, ProjectRoleManage, ProjectRoleManageNotification, ProjectRoleManageWorkerModel, ProjectRoleManageAction, ProjectRoleManageWorkflow, ProjectRoleManageWorkflowTemplate, ProjectRoleManageVariableSet, ProjectRolePublishRunResult}
 )
 
 type RBACProject struct {
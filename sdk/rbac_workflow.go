@@ -7,8 +7,14 @@ import (
 )
 
 var (
-	WorkflowRoleTrigger = "trigger"
-	WorkflowRoles       = []string{WorkflowRoleTrigger}
+	WorkflowRoleTrigger   = "trigger"
+	WorkflowRoleDebug     = "debug"
+	WorkflowRoleStop      = "stop"
+	WorkflowRoleRestart   = "restart"
+	WorkflowRoleApprove   = "approve"
+	WorkflowRoleReadLogs  = "read-logs"
+	WorkflowRoleDeleteRun = "delete-run"
+	WorkflowRoles         = []string{WorkflowRoleTrigger, WorkflowRoleDebug, WorkflowRoleStop, WorkflowRoleRestart, WorkflowRoleApprove, WorkflowRoleReadLogs, WorkflowRoleDeleteRun}
 )
 
 type RBACWorkflow struct {
@@ -20,11 +26,58 @@ type RBACWorkflow struct {
 	RBACWorkflowsNames RBACWorkflowNames `json:"workflows,omitempty" db:"workflows"`
 	AllWorkflows       bool              `json:"all_workflows" db:"all_workflows"`
 	RBACVCSUsers       RBACVCSUsers      `json:"vcs_users,omitempty" db:"vcs_users"`
+	RBACRefs           RBACWorkflowRefs  `json:"refs,omitempty" db:"refs"`
 
 	RBACUsersIDs  []string `json:"-" db:"-"`
 	RBACGroupsIDs []int64  `json:"-" db:"-"`
 }
 
+// RBACWorkflowCheck explains why a role on a workflow is granted or denied
+type RBACWorkflowCheck struct {
+	Role     string                  `json:"role"`
+	Workflow string                  `json:"workflow"`
+	Ref      string                  `json:"ref,omitempty"`
+	Granted  bool                    `json:"granted"`
+	Rules    []RBACWorkflowRuleCheck `json:"rules"`
+}
+
+// RBACWorkflowRuleCheck is the result of the evaluation of one rule
+type RBACWorkflowRuleCheck struct {
+	RBACName string `json:"rbac_name" cli:"rbac_name"`
+	Role     string `json:"role" cli:"role"`
+	Granted  bool   `json:"granted" cli:"granted"`
+	Reason   string `json:"reason" cli:"reason"`
+}
+
+// RBACWorkflowCheckRequest is the request used to explain a role on a workflow
+type RBACWorkflowCheckRequest struct {
+	Role       string `json:"role"`
+	VCSServer  string `json:"vcs_server"`
+	Repository string `json:"repository"`
+	Workflow   string `json:"workflow"`
+	Ref        string `json:"ref,omitempty"`
+	Username   string `json:"username,omitempty"`
+}
+
+type RBACWorkflowRefs []string
+
+func (rwr RBACWorkflowRefs) Value() (driver.Value, error) {
+	refs, err := json.Marshal(rwr)
+	return refs, WrapError(err, "cannot marshal RBACWorkflowRefs")
+}
+
+// Scan action.
+func (rwr *RBACWorkflowRefs) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(JSONUnmarshal(source, rwr), "cannot unmarshal RBACWorkflowRefs")
+}
+
 type RBACVCSUsers []RBACVCSUser
 
 func (rwn RBACVCSUsers) Value() (driver.Value, error) {
@@ -1,6 +1,10 @@
 package sdk
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -69,6 +73,38 @@ type VCSCommit struct {
 	KeyID     string    `json:"key_id"`
 }
 
+// VCSFileDiff represents the unified diff of a file between two refs
+type VCSFileDiff struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status,omitempty"`
+	Patch    string `json:"patch,omitempty"`
+}
+
+var unifiedDiffHunkRegexp = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// AddedLines returns the numbers of the lines added or modified by the patch, in the new version of the file
+func (d VCSFileDiff) AddedLines() []int {
+	var lines []int
+	current := 0
+	for _, l := range strings.Split(d.Patch, "\n") {
+		if m := unifiedDiffHunkRegexp.FindStringSubmatch(l); m != nil {
+			current, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if current == 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(l, "+"):
+			lines = append(lines, current)
+			current++
+		case strings.HasPrefix(l, " "):
+			current++
+		}
+	}
+	return lines
+}
+
 // VCSRemote represents remotes known by the repositories manager
 type VCSRemote struct {
 	Name string `json:"name"`
@@ -111,6 +147,82 @@ type VCSInsightData struct {
 	Href  string `json:"href"`
 }
 
+// VCSAnnotationLevel is the severity of an annotation
+type VCSAnnotationLevel string
+
+const (
+	VCSAnnotationLevelNotice  VCSAnnotationLevel = "notice"
+	VCSAnnotationLevelWarning VCSAnnotationLevel = "warning"
+	VCSAnnotationLevelFailure VCSAnnotationLevel = "failure"
+)
+
+// VCSAnnotation is a message attached to lines of a file, displayed inline by the vcs server
+type VCSAnnotation struct {
+	Path      string             `json:"path"`
+	StartLine int                `json:"start_line"`
+	EndLine   int                `json:"end_line,omitempty"`
+	Level     VCSAnnotationLevel `json:"level"`
+	Title     string             `json:"title,omitempty"`
+	Message   string             `json:"message"`
+}
+
+// VCSAnnotations is a set of annotations on a commit, sent by a producer (test results, linter...)
+type VCSAnnotations struct {
+	Key         string          `json:"key"` // identify the producer, annotations with the same key replace each other when the vcs server allows it
+	Title       string          `json:"title"`
+	Summary     string          `json:"summary,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Annotations []VCSAnnotation `json:"annotations"`
+}
+
+// Lint checks the annotations and sets default values
+func (a *VCSAnnotations) Lint() error {
+	if a.Key == "" {
+		return NewErrorFrom(ErrInvalidData, "missing annotations key")
+	}
+	for i := range a.Annotations {
+		an := &a.Annotations[i]
+		if an.Path == "" {
+			return NewErrorFrom(ErrInvalidData, "missing path on annotation %d", i)
+		}
+		if an.Message == "" {
+			return NewErrorFrom(ErrInvalidData, "missing message on annotation %d", i)
+		}
+		if an.StartLine < 1 {
+			an.StartLine = 1
+		}
+		if an.EndLine < an.StartLine {
+			an.EndLine = an.StartLine
+		}
+		switch an.Level {
+		case VCSAnnotationLevelNotice, VCSAnnotationLevelWarning, VCSAnnotationLevelFailure:
+		case "":
+			an.Level = VCSAnnotationLevelWarning
+		default:
+			return NewErrorFrom(ErrInvalidData, "invalid level %q on annotation %d", an.Level, i)
+		}
+	}
+	return nil
+}
+
+// Markdown returns the annotation as a markdown comment, for vcs servers that display annotations as review comments
+func (a VCSAnnotation) Markdown() string {
+	if a.Title != "" {
+		return fmt.Sprintf("**%s** %s: %s", strings.ToUpper(string(a.Level)), a.Title, a.Message)
+	}
+	return fmt.Sprintf("**%s** %s", strings.ToUpper(string(a.Level)), a.Message)
+}
+
+// HasFailure returns true if at least one annotation has the failure level
+func (a VCSAnnotations) HasFailure() bool {
+	for _, an := range a.Annotations {
+		if an.Level == VCSAnnotationLevelFailure {
+			return true
+		}
+	}
+	return false
+}
+
 // VCSPullRequest represents a pull request
 type VCSPullRequest struct {
 	ID       int          `json:"id"`
@@ -163,6 +275,20 @@ type VCSPullRequestCommentRequest struct {
 	Message  string `json:"message"`
 }
 
+// VCSPullRequestMergeStatus tells if a pull request can be merged on the vcs server
+type VCSPullRequestMergeStatus struct {
+	Mergeable bool   `json:"mergeable"`
+	Approved  bool   `json:"approved"`
+	Reason    string `json:"reason,omitempty"` // why the pull request can't be merged
+}
+
+// VCSPullRequestMergeRequest merges a pull request on the vcs server, only if its head is still the given commit
+type VCSPullRequestMergeRequest struct {
+	ID      int    `json:"id"`
+	Commit  string `json:"commit"`
+	Message string `json:"message"`
+}
+
 // VCSPushEvent represents a push events for polling
 type VCSPushEvent struct {
 	Repo     string    `json:"repo"`
@@ -201,6 +327,7 @@ type VCSHook struct {
 	Body        string   `json:"body"`
 	Disable     bool     `json:"disable"`
 	InsecureSSL bool     `json:"insecure_ssl"`
+	Secret      string   `json:"secret,omitempty"`
 }
 
 // VCSCommitStatus represents a status on a VCS repository
@@ -71,6 +71,7 @@ func (opError *OperationError) ToError() error {
 type OperationSetup struct {
 	Checkout OperationCheckout `json:"checkout,omitempty"`
 	Push     OperationPush     `json:"push,omitempty"`
+	Merge    OperationMerge    `json:"merge,omitempty"`
 }
 
 // OperationRepositoryInfo represents global information about the repository
@@ -126,6 +127,34 @@ type OperationPush struct {
 	Update     bool   `json:"update,omitempty"`
 }
 
+type OperationMergeAction string
+
+const (
+	// OperationMergeActionCreate merges commits on top of the base branch then pushes the result on a temporary branch
+	OperationMergeActionCreate OperationMergeAction = "create"
+	// OperationMergeActionDelete deletes the temporary branch
+	OperationMergeActionDelete OperationMergeAction = "delete"
+)
+
+// OperationMerge represents a merge queue operation on a temporary branch
+type OperationMerge struct {
+	Action     OperationMergeAction   `json:"action,omitempty"`
+	BaseBranch string                 `json:"base_branch,omitempty"`
+	Branch     string                 `json:"branch,omitempty"`
+	Commits    []OperationMergeCommit `json:"commits,omitempty"`
+	Result     struct {
+		BaseCommit string   `json:"base_commit,omitempty"`
+		Commit     string   `json:"commit,omitempty"`
+		Conflicts  []string `json:"conflicts,omitempty"`
+	} `json:"result"`
+}
+
+// OperationMergeCommit is a commit to merge on the temporary branch with its merge commit message
+type OperationMergeCommit struct {
+	Commit  string `json:"commit"`
+	Message string `json:"message"`
+}
+
 // OperationStatus is the status of an operation
 type OperationStatus int
 
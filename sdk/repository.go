@@ -52,6 +52,7 @@ type ProjectRepositoryData struct {
 	DeprecatedCDSUserID       string                        `json:"cds_username_id"` // Deprecated
 	DeprecatedCDSAdminWithMFA bool                          `json:"cds_admin_mfa"`   // Deprecated
 	Error                     string                        `json:"error"`
+	Warnings                  []string                      `json:"warnings,omitempty"`
 	Entities                  []ProjectRepositoryDataEntity `json:"entities"`
 	Initiator                 *V2Initiator                  `json:"initiator"`
 }
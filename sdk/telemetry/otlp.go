@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// ExportedSpan is a span whose identifiers and timings are already known.
+// It is used to export traces rebuilt from the data stored by CDS, like the trace of a workflow run.
+type ExportedSpan struct {
+	Service      string
+	Name         string
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Start        time.Time
+	End          time.Time
+	Attributes   map[string]string
+	Events       []ExportedSpanEvent
+	Error        string
+}
+
+type ExportedSpanEvent struct {
+	Name       string
+	Time       time.Time
+	Attributes map[string]string
+}
+
+func newOTLPTraceExporter(ctx context.Context, cfg Configuration) (sdktrace.SpanExporter, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Exporters.OTLP.Endpoint)}
+	if cfg.Exporters.OTLP.URLPath != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(cfg.Exporters.OTLP.URLPath))
+	}
+	if cfg.Exporters.OTLP.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Exporters.OTLP.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Exporters.OTLP.Headers))
+	}
+	e, err := otlptracehttp.New(ctx, opts...)
+	return e, errors.WithStack(err)
+}
+
+// OTLPTraceExporter returns the OTLP exporter, nil if it is not configured
+func OTLPTraceExporter(ctx context.Context) sdktrace.SpanExporter {
+	i := ctx.Value(contextOTLPTraceExporter)
+	exp, ok := i.(sdktrace.SpanExporter)
+	if ok {
+		return exp
+	}
+	return nil
+}
+
+// ExportSpans sends the spans with the OTLP exporter, it does nothing if the exporter is not configured
+func ExportSpans(ctx context.Context, spans []ExportedSpan) error {
+	exp := OTLPTraceExporter(ctx)
+	if exp == nil || len(spans) == 0 {
+		return nil
+	}
+	roSpans := make([]sdktrace.ReadOnlySpan, 0, len(spans))
+	for _, s := range spans {
+		ro, err := s.readOnlySpan()
+		if err != nil {
+			return err
+		}
+		roSpans = append(roSpans, ro)
+	}
+	return errors.WithStack(exp.ExportSpans(ctx, roSpans))
+}
+
+func (s ExportedSpan) readOnlySpan() (sdktrace.ReadOnlySpan, error) {
+	traceID, err := oteltrace.TraceIDFromHex(s.TraceID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid trace id %q", s.TraceID)
+	}
+	spanID, err := oteltrace.SpanIDFromHex(s.SpanID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid span id %q", s.SpanID)
+	}
+	stub := tracetest.SpanStub{
+		Name: s.Name,
+		SpanContext: oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: oteltrace.FlagsSampled,
+		}),
+		SpanKind:   oteltrace.SpanKindInternal,
+		StartTime:  s.Start,
+		EndTime:    s.End,
+		Attributes: attributes(s.Attributes),
+		Resource:   resource.NewSchemaless(attribute.String("service.name", s.Service)),
+	}
+	if s.ParentSpanID != "" {
+		parentID, err := oteltrace.SpanIDFromHex(s.ParentSpanID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid parent span id %q", s.ParentSpanID)
+		}
+		stub.Parent = oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     parentID,
+			TraceFlags: oteltrace.FlagsSampled,
+			Remote:     true,
+		})
+	}
+	if s.Error != "" {
+		stub.Status = sdktrace.Status{Code: codes.Error, Description: s.Error}
+	} else {
+		stub.Status = sdktrace.Status{Code: codes.Ok}
+	}
+	for _, e := range s.Events {
+		stub.Events = append(stub.Events, sdktrace.Event{
+			Name:       e.Name,
+			Time:       e.Time,
+			Attributes: attributes(e.Attributes),
+		})
+	}
+	return stub.Snapshot(), nil
+}
+
+func attributes(m map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(m))
+	for k, v := range m {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
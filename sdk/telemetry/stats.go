@@ -126,3 +126,14 @@ func NewViewCount(name string, s *stats.Int64Measure, tags []tag.Key) *view.View
 		TagKeys:     tags,
 	}
 }
+
+// NewViewDistribution creates a new view via the given distribution aggregation
+func NewViewDistribution(name string, s stats.Measure, tags []tag.Key, distribution *view.Aggregation) *view.View {
+	return &view.View{
+		Name:        name,
+		Description: s.Description(),
+		Measure:     s,
+		Aggregation: distribution,
+		TagKeys:     tags,
+	}
+}
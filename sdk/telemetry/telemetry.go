@@ -39,12 +39,16 @@ func TraceExporter(ctx context.Context) trace.Exporter {
 func ContextWithTelemetry(from, to context.Context) context.Context {
 	se := StatsExporter(from)
 	te := TraceExporter(from)
+	oe := OTLPTraceExporter(from)
 	if se != nil {
 		to = context.WithValue(to, contextStatsExporter, se)
 	}
 	if te != nil {
 		to = context.WithValue(to, contextTraceExporter, te)
 	}
+	if oe != nil {
+		to = context.WithValue(to, contextOTLPTraceExporter, oe)
+	}
 	return to
 }
 
@@ -77,6 +81,15 @@ func Init(ctx context.Context, cfg Configuration, s Service) (context.Context, e
 		ctx = context.WithValue(ctx, contextTraceExporter, e)
 	}
 
+	if cfg.Exporters.OTLP.Endpoint != "" {
+		log.Info(ctx, "observability> initializing otlp exporter on %q", cfg.Exporters.OTLP.Endpoint)
+		e, err := newOTLPTraceExporter(ctx, cfg)
+		if err != nil {
+			return ctx, err
+		}
+		ctx = context.WithValue(ctx, contextOTLPTraceExporter, e)
+	}
+
 	if cfg.Exporters.Prometheus.ReporteringPeriod == 0 {
 		cfg.Exporters.Prometheus.ReporteringPeriod = 10
 	}
@@ -120,6 +133,8 @@ const (
 	TagType               = "type"
 	TagWorker             = "worker"
 	TagWorkerModel        = "worker_model"
+	TagWorkerFlavor       = "worker_flavor"
+	TagWorkerMemory       = "worker_memory"
 	TagWorkflow           = "workflow"
 	TagWorkflowNode       = "workflow_node"
 	TagWorkflowNodeJobRun = "workflow_node_job_run"
@@ -34,6 +34,12 @@ type Configuration struct {
 		Prometheus struct {
 			ReporteringPeriod int `toml:"ReporteringPeriod" default:"10" json:"reporteringPeriod"`
 		} `json:"prometheus"`
+		OTLP struct {
+			Endpoint string            `toml:"endpoint" default:"" json:"endpoint" comment:"OTLP/HTTP collector endpoint (host:port) used to export the traces of the workflow runs, disabled if empty"`
+			URLPath  string            `toml:"urlPath" default:"/v1/traces" json:"urlPath"`
+			Insecure bool              `toml:"insecure" json:"insecure" comment:"Use HTTP instead of HTTPS"`
+			Headers  map[string]string `toml:"headers" json:"-" comment:"Headers sent to the collector, ex: authentication"`
+		} `json:"otlp"`
 	} `json:"exporter"`
 }
 
@@ -42,6 +48,12 @@ var (
 	DefaultSizeDistribution = view.Distribution(25*1024, 100*1024, 250*1024, 500*1024, 1024*1024, 1.5*1024*1024, 5*1024*1024, 10*1024*1024)
 	// DefaultLatencyDistribution 100ms, ...
 	DefaultLatencyDistribution = view.Distribution(100, 200, 300, 400, 500, 750, 1000, 2000, 5000)
+	// DefaultDurationDistribution 1s, 5s, 10s, 30s, 1m, 2m, 5m, 10m, 20m, 30m, 1h, 2h
+	DefaultDurationDistribution = view.Distribution(1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200)
+	// DefaultMemoryDistribution 128M, 256M, 512M, 1G, 2G, 4G, 8G, 16G, 32G
+	DefaultMemoryDistribution = view.Distribution(128<<20, 256<<20, 512<<20, 1<<30, 2<<30, 4<<30, 8<<30, 16<<30, 32<<30)
+	// DefaultTransferDistribution 1M, 10M, 100M, 1G, 10G, 100G
+	DefaultTransferDistribution = view.Distribution(1<<20, 10<<20, 100<<20, 1<<30, 10<<30, 100<<30)
 )
 
 const (
@@ -80,6 +92,7 @@ type contextKey int
 const (
 	contextTraceExporter contextKey = iota
 	contextStatsExporter
+	contextOTLPTraceExporter
 )
 
 type Service interface {
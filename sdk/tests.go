@@ -171,6 +171,8 @@ type JUnitTestCase struct {
 	Classname string             `xml:"classname,attr,omitempty" json:"classname,omitempty" mapstructure:"classname"`
 	Errors    []JUnitTestFailure `xml:"error,omitempty" json:"errors,omitempty" mapstructure:"errors"`
 	Failures  []JUnitTestFailure `xml:"failure,omitempty" json:"failures,omitempty" mapstructure:"failures"`
+	File      string             `xml:"file,attr,omitempty" json:"file,omitempty" mapstructure:"file"`
+	Line      int                `xml:"line,attr,omitempty" json:"line,omitempty" mapstructure:"line"`
 	Name      string             `xml:"name,attr" json:"name,omitempty" mapstructure:"name"`
 	Skipped   []JUnitTestSkipped `xml:"skipped,omitempty" json:"skipped,omitempty" mapstructure:"skipped"`
 	Status    string             `xml:"status,attr,omitempty" json:"status,omitempty" mapstructure:"status"`
@@ -0,0 +1,327 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TestsReportFormat string
+
+const (
+	TestsReportFormatJUnit     TestsReportFormat = "junit"
+	TestsReportFormatTAP       TestsReportFormat = "tap"
+	TestsReportFormatTest2JSON TestsReportFormat = "test2json"
+	TestsReportFormatTRX       TestsReportFormat = "trx"
+)
+
+// DetectTestsReportFormat returns the format of a tests report: JUnit or TRX for XML files,
+// Go test2json for JSON lines and TAP otherwise.
+func DetectTestsReportFormat(data []byte) TestsReportFormat {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		dec := xml.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			tok, err := dec.Token()
+			if err != nil {
+				return TestsReportFormatJUnit
+			}
+			if start, ok := tok.(xml.StartElement); ok {
+				if start.Name.Local == "TestRun" {
+					return TestsReportFormatTRX
+				}
+				return TestsReportFormatJUnit
+			}
+		}
+	}
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return TestsReportFormatTest2JSON
+	}
+	return TestsReportFormatTAP
+}
+
+// ParseTestsReport reads a JUnit, TAP, Go test2json or TRX report. Test cases of formats without
+// test suites are grouped in a suite with the given default name.
+func ParseTestsReport(defaultSuiteName string, data []byte) (JUnitTestsSuites, TestsReportFormat, error) {
+	format := DetectTestsReportFormat(data)
+	var tests JUnitTestsSuites
+	var err error
+	switch format {
+	case TestsReportFormatTRX:
+		tests, err = parseTRXReport(data)
+	case TestsReportFormatTest2JSON:
+		tests, err = parseTest2JSONReport(data)
+	case TestsReportFormatTAP:
+		tests, err = parseTAPReport(defaultSuiteName, data)
+	default:
+		tests, err = parseJUnitReport(data)
+	}
+	if err != nil {
+		return tests, format, NewErrorFrom(ErrWrongRequest, "unable to read %s tests report: %v", format, err)
+	}
+	return tests, format, nil
+}
+
+func parseJUnitReport(data []byte) (JUnitTestsSuites, error) {
+	var tests JUnitTestsSuites
+	if err := xml.Unmarshal(data, &tests); err != nil {
+		// The file can contain a testsuite without testsuites
+		var s JUnitTestSuite
+		if err := xml.Unmarshal(data, &s); err != nil {
+			return tests, err
+		}
+		tests.TestSuites = append(tests.TestSuites, s)
+	}
+	return tests, nil
+}
+
+var (
+	tapTestLineRegexp = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?([^#]*?)\s*(?:#\s*(\w+)\s*(.*))?$`)
+	tapDurationRegexp = regexp.MustCompile(`^\s*duration_ms:\s*([0-9.]+)`)
+)
+
+// parseTAPReport reads a TAP report (https://testanything.org/). The YAML diagnostic block that
+// follows a failed test is kept as the failure output.
+func parseTAPReport(suiteName string, data []byte) (JUnitTestsSuites, error) {
+	suite := JUnitTestSuite{Name: suiteName}
+	var current *JUnitTestCase
+	var diagnostic []string
+	inDiagnostic := false
+
+	flushDiagnostic := func() {
+		if current == nil || len(diagnostic) == 0 {
+			diagnostic = nil
+			return
+		}
+		for _, l := range diagnostic {
+			if m := tapDurationRegexp.FindStringSubmatch(l); m != nil {
+				if ms, err := strconv.ParseFloat(m[1], 64); err == nil {
+					current.Time = strconv.FormatFloat(ms/1000, 'f', 3, 64)
+				}
+			}
+		}
+		if len(current.Failures) > 0 {
+			current.Failures[0].Value = strings.Join(diagnostic, "\n")
+		}
+		diagnostic = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if inDiagnostic {
+			if trimmed == "..." {
+				inDiagnostic = false
+				flushDiagnostic()
+			} else {
+				diagnostic = append(diagnostic, trimmed)
+			}
+			continue
+		}
+		if trimmed == "---" && current != nil {
+			inDiagnostic = true
+			continue
+		}
+		// Only top level tests are kept, subtests are reported by their parent
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		m := tapTestLineRegexp.FindStringSubmatch(trimmed)
+		if m == nil {
+			if strings.HasPrefix(trimmed, "Bail out!") {
+				return JUnitTestsSuites{}, fmt.Errorf("%s", trimmed)
+			}
+			continue
+		}
+		suite.TestCases = append(suite.TestCases, JUnitTestCase{Name: m[3]})
+		current = &suite.TestCases[len(suite.TestCases)-1]
+		if current.Name == "" {
+			current.Name = "test " + m[2]
+		}
+		directive := strings.ToUpper(m[4])
+		switch {
+		case directive == "SKIP":
+			current.Skipped = append(current.Skipped, JUnitTestSkipped{Message: m[5]})
+		case directive == "TODO":
+			// A failing TODO test is not a failure
+			current.Skipped = append(current.Skipped, JUnitTestSkipped{Message: "TODO " + m[5]})
+		case m[1] == "not ok":
+			current.Failures = append(current.Failures, JUnitTestFailure{Message: "not ok"})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return JUnitTestsSuites{}, err
+	}
+	flushDiagnostic()
+
+	return JUnitTestsSuites{TestSuites: []JUnitTestSuite{suite}}, nil
+}
+
+type test2JSONEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+// parseTest2JSONReport reads the output of go test -json, with a test suite by package.
+func parseTest2JSONReport(data []byte) (JUnitTestsSuites, error) {
+	type testKey struct{ pkg, test string }
+	outputs := make(map[testKey]*strings.Builder)
+	suites := make(map[string]*JUnitTestSuite)
+	var packages []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e test2JSONEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			// go test can print build errors that are not json
+			continue
+		}
+		if e.Test == "" {
+			continue
+		}
+		k := testKey{e.Package, e.Test}
+		switch e.Action {
+		case "output":
+			if outputs[k] == nil {
+				outputs[k] = new(strings.Builder)
+			}
+			outputs[k].WriteString(e.Output)
+		case "pass", "fail", "skip":
+			s, has := suites[e.Package]
+			if !has {
+				s = &JUnitTestSuite{Name: e.Package, Package: e.Package}
+				suites[e.Package] = s
+				packages = append(packages, e.Package)
+			}
+			tc := JUnitTestCase{
+				Classname: e.Package,
+				Name:      e.Test,
+				Time:      strconv.FormatFloat(e.Elapsed, 'f', 3, 64),
+			}
+			var output string
+			if outputs[k] != nil {
+				output = outputs[k].String()
+			}
+			switch e.Action {
+			case "fail":
+				tc.Failures = append(tc.Failures, JUnitTestFailure{Message: "Failed", Value: output})
+			case "skip":
+				tc.Skipped = append(tc.Skipped, JUnitTestSkipped{Value: output})
+			default:
+				tc.Systemout.Value = output
+			}
+			s.TestCases = append(s.TestCases, tc)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return JUnitTestsSuites{}, err
+	}
+
+	sort.Strings(packages)
+	var tests JUnitTestsSuites
+	for _, p := range packages {
+		tests.TestSuites = append(tests.TestSuites, *suites[p])
+	}
+	return tests, nil
+}
+
+type trxTestRun struct {
+	Name    string `xml:"name,attr"`
+	Results []struct {
+		TestID   string `xml:"testId,attr"`
+		TestName string `xml:"testName,attr"`
+		Duration string `xml:"duration,attr"`
+		Outcome  string `xml:"outcome,attr"`
+		StdOut   string `xml:"Output>StdOut"`
+		StdErr   string `xml:"Output>StdErr"`
+		Message  string `xml:"Output>ErrorInfo>Message"`
+		Stack    string `xml:"Output>ErrorInfo>StackTrace"`
+	} `xml:"Results>UnitTestResult"`
+	Definitions []struct {
+		ID     string `xml:"id,attr"`
+		Method struct {
+			ClassName string `xml:"className,attr"`
+			Name      string `xml:"name,attr"`
+		} `xml:"TestMethod"`
+	} `xml:"TestDefinitions>UnitTest"`
+}
+
+// parseTRXReport reads a Visual Studio test results file, with a test suite by test class.
+func parseTRXReport(data []byte) (JUnitTestsSuites, error) {
+	var run trxTestRun
+	if err := xml.Unmarshal(data, &run); err != nil {
+		return JUnitTestsSuites{}, err
+	}
+	classNames := make(map[string]string, len(run.Definitions))
+	for _, d := range run.Definitions {
+		classNames[d.ID] = d.Method.ClassName
+	}
+
+	suites := make(map[string]*JUnitTestSuite)
+	var suiteNames []string
+	for _, r := range run.Results {
+		suiteName := classNames[r.TestID]
+		if suiteName == "" {
+			suiteName = run.Name
+		}
+		s, has := suites[suiteName]
+		if !has {
+			s = &JUnitTestSuite{Name: suiteName}
+			suites[suiteName] = s
+			suiteNames = append(suiteNames, suiteName)
+		}
+		tc := JUnitTestCase{
+			Classname: classNames[r.TestID],
+			Name:      r.TestName,
+			Time:      strconv.FormatFloat(parseTRXDuration(r.Duration).Seconds(), 'f', 3, 64),
+			Systemout: JUnitInnerResult{Value: r.StdOut},
+			Systemerr: JUnitInnerResult{Value: r.StdErr},
+		}
+		switch strings.ToLower(r.Outcome) {
+		case "passed", "passedbutrunaborted", "warning":
+		case "notexecuted", "inconclusive", "pending", "disconnected", "notrunnable":
+			tc.Skipped = append(tc.Skipped, JUnitTestSkipped{Message: r.Outcome, Value: r.Message})
+		case "error", "timeout", "aborted":
+			tc.Errors = append(tc.Errors, JUnitTestFailure{Message: r.Message, Type: r.Outcome, Value: r.Stack})
+		default:
+			tc.Failures = append(tc.Failures, JUnitTestFailure{Message: r.Message, Type: r.Outcome, Value: r.Stack})
+		}
+		s.TestCases = append(s.TestCases, tc)
+	}
+
+	var tests JUnitTestsSuites
+	for _, n := range suiteNames {
+		tests.TestSuites = append(tests.TestSuites, *suites[n])
+	}
+	return tests, nil
+}
+
+// parseTRXDuration reads a duration formatted as hh:mm:ss.fffffff
+func parseTRXDuration(s string) time.Duration {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	h, _ := strconv.Atoi(parts[0])
+	m, _ := strconv.Atoi(parts[1])
+	sec, _ := strconv.ParseFloat(parts[2], 64)
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec*float64(time.Second))
+}
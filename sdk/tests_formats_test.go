@@ -0,0 +1,141 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTestsReportJUnit(t *testing.T) {
+	tests, format, err := ParseTestsReport("report", []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="mysuite" tests="2">
+  <testcase name="TestA" time="0.5"></testcase>
+  <testcase name="TestB" time="1.5"><failure message="boom">stack</failure></testcase>
+</testsuite>`))
+	require.NoError(t, err)
+	require.Equal(t, TestsReportFormatJUnit, format)
+	require.Len(t, tests.TestSuites, 1)
+	require.Equal(t, "mysuite", tests.TestSuites[0].Name)
+	require.Len(t, tests.TestSuites[0].TestCases, 2)
+}
+
+func TestParseTestsReportTAP(t *testing.T) {
+	tests, format, err := ParseTestsReport("report", []byte(`TAP version 13
+1..4
+ok 1 - first test
+not ok 2 - second test
+  ---
+  message: 'expected 1, got 2'
+  duration_ms: 1500
+  ...
+ok 3 - third test # SKIP not on linux
+    ok 1 - a subtest
+not ok 4 # TODO not implemented
+`))
+	require.NoError(t, err)
+	require.Equal(t, TestsReportFormatTAP, format)
+	require.Len(t, tests.TestSuites, 1)
+	require.Equal(t, "report", tests.TestSuites[0].Name)
+
+	tcs := tests.TestSuites[0].TestCases
+	require.Len(t, tcs, 4)
+	require.Equal(t, "first test", tcs[0].Name)
+	require.Empty(t, tcs[0].Failures)
+	require.Equal(t, "second test", tcs[1].Name)
+	require.Len(t, tcs[1].Failures, 1)
+	require.Contains(t, tcs[1].Failures[0].Value, "expected 1, got 2")
+	require.Equal(t, "1.500", tcs[1].Time)
+	require.Len(t, tcs[2].Skipped, 1)
+	require.Equal(t, "not on linux", tcs[2].Skipped[0].Message)
+	require.Equal(t, "test 4", tcs[3].Name)
+	require.Len(t, tcs[3].Skipped, 1)
+	require.Empty(t, tcs[3].Failures)
+
+	stats := tests.EnsureData().ComputeStats()
+	require.Equal(t, TestsStats{Total: 4, TotalOK: 1, TotalKO: 1, TotalSkipped: 2}, stats)
+
+	_, _, err = ParseTestsReport("report", []byte("1..2\nBail out! database is down\n"))
+	require.Error(t, err)
+}
+
+func TestParseTestsReportTest2JSON(t *testing.T) {
+	tests, format, err := ParseTestsReport("report", []byte(`{"Time":"2024-01-01T10:00:00Z","Action":"start","Package":"github.com/ovh/cds/sdk"}
+{"Time":"2024-01-01T10:00:00Z","Action":"run","Package":"github.com/ovh/cds/sdk","Test":"TestA"}
+{"Time":"2024-01-01T10:00:00Z","Action":"output","Package":"github.com/ovh/cds/sdk","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Time":"2024-01-01T10:00:01Z","Action":"pass","Package":"github.com/ovh/cds/sdk","Test":"TestA","Elapsed":1.2}
+{"Time":"2024-01-01T10:00:01Z","Action":"run","Package":"github.com/ovh/cds/sdk","Test":"TestB"}
+{"Time":"2024-01-01T10:00:01Z","Action":"output","Package":"github.com/ovh/cds/sdk","Test":"TestB","Output":"    a_test.go:12: expected true\n"}
+{"Time":"2024-01-01T10:00:02Z","Action":"fail","Package":"github.com/ovh/cds/sdk","Test":"TestB","Elapsed":0.3}
+{"Time":"2024-01-01T10:00:02Z","Action":"skip","Package":"github.com/ovh/cds/cli","Test":"TestC","Elapsed":0}
+{"Time":"2024-01-01T10:00:02Z","Action":"fail","Package":"github.com/ovh/cds/sdk","Elapsed":2.1}
+`))
+	require.NoError(t, err)
+	require.Equal(t, TestsReportFormatTest2JSON, format)
+	require.Len(t, tests.TestSuites, 2)
+	require.Equal(t, "github.com/ovh/cds/cli", tests.TestSuites[0].Name)
+	require.Len(t, tests.TestSuites[0].TestCases[0].Skipped, 1)
+
+	sdkSuite := tests.TestSuites[1]
+	require.Equal(t, "github.com/ovh/cds/sdk", sdkSuite.Name)
+	require.Len(t, sdkSuite.TestCases, 2)
+	require.Equal(t, "1.200", sdkSuite.TestCases[0].Time)
+	require.Empty(t, sdkSuite.TestCases[0].Failures)
+	require.Len(t, sdkSuite.TestCases[1].Failures, 1)
+	require.Contains(t, sdkSuite.TestCases[1].Failures[0].Value, "expected true")
+}
+
+func TestParseTestsReportTRX(t *testing.T) {
+	tests, format, err := ParseTestsReport("report", []byte(`<?xml version="1.0" encoding="utf-8"?>
+<TestRun id="1" name="run@host" xmlns="http://microsoft.com/schemas/VisualStudio/TeamTest/2010">
+  <Results>
+    <UnitTestResult testId="t1" testName="AddWorks" duration="00:00:01.5000000" outcome="Passed" />
+    <UnitTestResult testId="t2" testName="DivideByZero" duration="00:00:00.2500000" outcome="Failed">
+      <Output>
+        <ErrorInfo>
+          <Message>Assert.AreEqual failed</Message>
+          <StackTrace>at Calculator.Tests.DivideByZero()</StackTrace>
+        </ErrorInfo>
+      </Output>
+    </UnitTestResult>
+    <UnitTestResult testId="t3" testName="Ignored" duration="00:00:00" outcome="NotExecuted" />
+  </Results>
+  <TestDefinitions>
+    <UnitTest name="AddWorks" id="t1"><TestMethod className="Calculator.Tests" name="AddWorks" /></UnitTest>
+    <UnitTest name="DivideByZero" id="t2"><TestMethod className="Calculator.Tests" name="DivideByZero" /></UnitTest>
+  </TestDefinitions>
+</TestRun>`))
+	require.NoError(t, err)
+	require.Equal(t, TestsReportFormatTRX, format)
+	require.Len(t, tests.TestSuites, 2)
+
+	require.Equal(t, "Calculator.Tests", tests.TestSuites[0].Name)
+	tcs := tests.TestSuites[0].TestCases
+	require.Len(t, tcs, 2)
+	require.Equal(t, "1.500", tcs[0].Time)
+	require.Len(t, tcs[1].Failures, 1)
+	require.Equal(t, "Assert.AreEqual failed", tcs[1].Failures[0].Message)
+
+	require.Equal(t, "run@host", tests.TestSuites[1].Name)
+	require.Len(t, tests.TestSuites[1].TestCases[0].Skipped, 1)
+}
+
+func TestJUnitTestsSuitesTestCases(t *testing.T) {
+	tests := JUnitTestsSuites{TestSuites: []JUnitTestSuite{{
+		Name: "suite",
+		TestCases: []JUnitTestCase{
+			{Name: "ok", Time: "0.250"},
+			{Name: "ko", Errors: []JUnitTestFailure{{Message: "error"}}},
+			{Name: "skipped", Skipped: []JUnitTestSkipped{{}}},
+		},
+	}}}
+
+	tcs := tests.TestCases(V2WorkflowRunTestCase{WorkflowName: "my-workflow", GitSha: "abcdef"})
+	require.Len(t, tcs, 3)
+	require.Equal(t, "my-workflow", tcs[0].WorkflowName)
+	require.Equal(t, "abcdef", tcs[2].GitSha)
+	require.Equal(t, "suite", tcs[0].Suite)
+	require.Equal(t, 0.25, tcs[0].Duration)
+	require.Equal(t, V2WorkflowRunTestCaseStatusPassed, tcs[0].Status)
+	require.Equal(t, V2WorkflowRunTestCaseStatusFailed, tcs[1].Status)
+	require.Equal(t, V2WorkflowRunTestCaseStatusSkipped, tcs[2].Status)
+}
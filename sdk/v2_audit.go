@@ -0,0 +1,388 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AuditV2EntityProject           = "project"
+	AuditV2EntityVCS               = "vcs"
+	AuditV2EntityRepository        = "repository"
+	AuditV2EntityEntity            = "entity"
+	AuditV2EntityHatchery          = "hatchery"
+	AuditV2EntityOrganization      = "organization"
+	AuditV2EntityRegion            = "region"
+	AuditV2EntityPermission        = "permission"
+	AuditV2EntityPermissionGrant   = "permission-grant"
+	AuditV2EntityUser              = "user"
+	AuditV2EntityUserGPGKey        = "user-gpg-key"
+	AuditV2EntityPlugin            = "plugin"
+	AuditV2EntityIntegrationModel  = "integration-model"
+	AuditV2EntityIntegration       = "integration"
+	AuditV2EntityNotification      = "notification"
+	AuditV2EntityVariableSet       = "variableset"
+	AuditV2EntityVariableSetItem   = "variableset-item"
+	AuditV2EntityConcurrency       = "concurrency"
+	AuditV2EntitySharedConcurrency = "shared-concurrency"
+	AuditV2EntityPolicy            = "policy"
+	AuditV2EntityKey               = "key"
+	AuditV2EntityRunFilter         = "run-filter"
+	AuditV2EntityRunRetention      = "run-retention"
+)
+
+// auditV2EntityTypes gives the type of the audited entity for each event type.
+// Events that are not listed here (analysis, runs, jobs, purges) are not audited.
+var auditV2EntityTypes = map[EventType]string{
+	EventProjectCreated: AuditV2EntityProject,
+	EventProjectUpdated: AuditV2EntityProject,
+	EventProjectDeleted: AuditV2EntityProject,
+
+	EventVCSCreated: AuditV2EntityVCS,
+	EventVCSUpdated: AuditV2EntityVCS,
+	EventVCSDeleted: AuditV2EntityVCS,
+
+	EventRepositoryCreated: AuditV2EntityRepository,
+	EventRepositoryDeleted: AuditV2EntityRepository,
+
+	EventEntityCreated: AuditV2EntityEntity,
+	EventEntityUpdated: AuditV2EntityEntity,
+	EventEntityDeleted: AuditV2EntityEntity,
+
+	EventHatcheryCreated:    AuditV2EntityHatchery,
+	EventHatcheryUpdated:    AuditV2EntityHatchery,
+	EventHatcheryTokenRegen: AuditV2EntityHatchery,
+	EventHatcheryDeleted:    AuditV2EntityHatchery,
+
+	EventOrganizationCreated: AuditV2EntityOrganization,
+	EventOrganizationDeleted: AuditV2EntityOrganization,
+
+	EventRegionCreated: AuditV2EntityRegion,
+	EventRegionDeleted: AuditV2EntityRegion,
+
+	EventPermissionCreated: AuditV2EntityPermission,
+	EventPermissionUpdated: AuditV2EntityPermission,
+	EventPermissionDeleted: AuditV2EntityPermission,
+
+	EventPermissionGrantRequested: AuditV2EntityPermissionGrant,
+	EventPermissionGrantApproved:  AuditV2EntityPermissionGrant,
+	EventPermissionGrantRejected:  AuditV2EntityPermissionGrant,
+	EventPermissionGrantRevoked:   AuditV2EntityPermissionGrant,
+	EventPermissionGrantExpired:   AuditV2EntityPermissionGrant,
+
+	EventUserCreated:       AuditV2EntityUser,
+	EventUserUpdated:       AuditV2EntityUser,
+	EventUserDeleted:       AuditV2EntityUser,
+	EventUserGPGKeyCreated: AuditV2EntityUserGPGKey,
+	EventUserGPGKeyDeleted: AuditV2EntityUserGPGKey,
+
+	EventPluginCreated: AuditV2EntityPlugin,
+	EventPluginUpdated: AuditV2EntityPlugin,
+	EventPluginDeleted: AuditV2EntityPlugin,
+
+	EventIntegrationModelCreated: AuditV2EntityIntegrationModel,
+	EventIntegrationModelUpdated: AuditV2EntityIntegrationModel,
+	EventIntegrationModelDeleted: AuditV2EntityIntegrationModel,
+
+	EventIntegrationCreated: AuditV2EntityIntegration,
+	EventIntegrationUpdated: AuditV2EntityIntegration,
+	EventIntegrationDeleted: AuditV2EntityIntegration,
+
+	EventNotificationCreated: AuditV2EntityNotification,
+	EventNotificationUpdated: AuditV2EntityNotification,
+	EventNotificationDeleted: AuditV2EntityNotification,
+
+	EventVariableSetCreated:     AuditV2EntityVariableSet,
+	EventVariableSetDeleted:     AuditV2EntityVariableSet,
+	EventVariableSetItemCreated: AuditV2EntityVariableSetItem,
+	EventVariableSetItemUpdated: AuditV2EntityVariableSetItem,
+	EventVariableSetItemDeleted: AuditV2EntityVariableSetItem,
+
+	EventConcurrencyCreated: AuditV2EntityConcurrency,
+	EventConcurrencyUpdated: AuditV2EntityConcurrency,
+	EventConcurrencyDeleted: AuditV2EntityConcurrency,
+
+	EventSharedConcurrencyCreated: AuditV2EntitySharedConcurrency,
+	EventSharedConcurrencyUpdated: AuditV2EntitySharedConcurrency,
+	EventSharedConcurrencyDeleted: AuditV2EntitySharedConcurrency,
+
+	EventPolicyCreated: AuditV2EntityPolicy,
+	EventPolicyUpdated: AuditV2EntityPolicy,
+	EventPolicyDeleted: AuditV2EntityPolicy,
+
+	EventProjectKeyCreated: AuditV2EntityKey,
+	EventProjectKeyUpdated: AuditV2EntityKey,
+	EventProjectKeyDeleted: AuditV2EntityKey,
+
+	EventProjectRunFilterCreated: AuditV2EntityRunFilter,
+	EventProjectRunFilterUpdated: AuditV2EntityRunFilter,
+	EventProjectRunFilterDeleted: AuditV2EntityRunFilter,
+
+	EventProjectRunRetentionUpdated: AuditV2EntityRunRetention,
+}
+
+// auditV2SecretKeys are the parts of a field name that identify a secret value
+var auditV2SecretKeys = []string{"password", "secret", "token", "private"}
+
+// AuditV2 represents an audit data on a v2 entity, it is stored with the change of the entity.
+// TriggeredBy contains the username of the actor.
+type AuditV2 struct {
+	AuditCommon
+	UserID     string      `json:"user_id,omitempty" db:"user_id"`
+	ConsumerID string      `json:"consumer_id,omitempty" db:"consumer_id" cli:"consumer_id"`
+	IPAddress  string      `json:"ip_address,omitempty" db:"ip_address" cli:"ip_address"`
+	ProjectKey string      `json:"project_key,omitempty" db:"project_key" cli:"project_key"`
+	EntityType string      `json:"entity_type" db:"entity_type" cli:"entity_type"`
+	EntityName string      `json:"entity_name" db:"entity_name" cli:"entity_name"`
+	Before     AuditV2Data `json:"before,omitempty" db:"before"`
+	After      AuditV2Data `json:"after,omitempty" db:"after"`
+	Diff       AuditV2Diff `json:"diff,omitempty" db:"diff"`
+}
+
+// AuditV2Filter contains the criteria used to search audits. Empty values are ignored.
+type AuditV2Filter struct {
+	ProjectKey string
+	Username   string
+	ConsumerID string
+	EntityType string
+	EntityName string
+	EventType  string
+	Since      time.Time
+	Until      time.Time
+	Offset     int64
+	Limit      int64
+}
+
+// AuditV2Data is the redacted payload of an audited entity
+type AuditV2Data map[string]interface{}
+
+func (d AuditV2Data) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+	j, err := json.Marshal(d)
+	return j, WrapError(err, "cannot marshal AuditV2Data")
+}
+
+func (d *AuditV2Data) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(JSONUnmarshal(source, d), "cannot unmarshal AuditV2Data")
+}
+
+// AuditV2Change is a field that changed between two versions of an entity
+type AuditV2Change struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+type AuditV2Diff []AuditV2Change
+
+func (d AuditV2Diff) Value() (driver.Value, error) {
+	j, err := json.Marshal(d)
+	return j, WrapError(err, "cannot marshal AuditV2Diff")
+}
+
+func (d *AuditV2Diff) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(JSONUnmarshal(source, d), "cannot unmarshal AuditV2Diff")
+}
+
+// AuditEntity returns the type and the name of the entity concerned by the event.
+// The entity type is empty if the event must not be audited.
+func (e FullEventV2) AuditEntity() (string, string) {
+	entityType := e.Type.AuditEntityType()
+	if entityType == "" {
+		return "", ""
+	}
+	var name string
+	switch entityType {
+	case AuditV2EntityProject, AuditV2EntityRunRetention:
+		name = e.ProjectKey
+	case AuditV2EntityVCS:
+		name = e.VCSName
+	case AuditV2EntityRepository:
+		name = e.VCSName + "/" + e.Repository
+	case AuditV2EntityEntity:
+		name = e.VCSName + "/" + e.Repository + "/" + e.Entity
+	case AuditV2EntityHatchery:
+		name = e.Hatchery
+	case AuditV2EntityOrganization:
+		name = e.Organization
+	case AuditV2EntityRegion:
+		name = e.Region
+	case AuditV2EntityPermission, AuditV2EntityPermissionGrant:
+		name = e.Permission
+	case AuditV2EntityUser:
+		name = e.Username
+	case AuditV2EntityUserGPGKey:
+		name = e.Username + "/" + e.GPGKey
+	case AuditV2EntityPlugin:
+		name = e.Plugin
+	case AuditV2EntityIntegrationModel:
+		name = e.IntegrationModel
+	case AuditV2EntityIntegration:
+		name = e.Integration
+	case AuditV2EntityNotification:
+		name = e.Notification
+	case AuditV2EntityVariableSet:
+		name = e.VariableSet
+	case AuditV2EntityVariableSetItem:
+		name = e.VariableSet + "/" + e.Item
+	case AuditV2EntityConcurrency:
+		name = e.Concurrency
+	case AuditV2EntitySharedConcurrency:
+		if e.Region != "" {
+			name = "region/" + e.Region + "/" + e.Concurrency
+		} else {
+			name = "organization/" + e.Organization + "/" + e.Concurrency
+		}
+	case AuditV2EntityPolicy:
+		name = e.Policy
+	case AuditV2EntityKey:
+		name = e.KeyName
+	case AuditV2EntityRunFilter:
+		name = e.RunFilter
+	}
+	return entityType, name
+}
+
+// AuditEntityType returns the type of the entity audited for the event type, or an empty string if it is not audited
+func (t EventType) AuditEntityType() string {
+	return auditV2EntityTypes[t]
+}
+
+// IsDeletion returns true if the event is sent when an entity is deleted
+func (t EventType) IsDeletion() bool {
+	return strings.HasSuffix(string(t), "Deleted")
+}
+
+// NewAuditV2Data returns the payload of an event with all the secret values redacted.
+// Payloads that are not JSON objects are stored under the "payload" key.
+func NewAuditV2Data(payload json.RawMessage) (AuditV2Data, error) {
+	if len(payload) == 0 || string(payload) == "null" {
+		return nil, nil
+	}
+	var v interface{}
+	if err := JSONUnmarshal(payload, &v); err != nil {
+		return nil, WrapError(err, "unable to read event payload")
+	}
+	data, ok := redactAuditV2Value(v, false).(map[string]interface{})
+	if !ok {
+		return AuditV2Data{"payload": redactAuditV2Value(v, false)}, nil
+	}
+	return data, nil
+}
+
+func isAuditV2SecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range auditV2SecretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactAuditV2Value replaces all the secret values by a placeholder. A value is secret if its key looks like a secret,
+// if it is a header or if it is the value of a typed field whose type is secret or password.
+func redactAuditV2Value(v interface{}, secret bool) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		valueIsSecret := false
+		if t, ok := x["type"].(string); ok {
+			valueIsSecret = t == ProjectVariableTypeSecret || t == IntegrationConfigTypePassword
+		}
+		res := make(map[string]interface{}, len(x))
+		for k, sub := range x {
+			subSecret := secret || isAuditV2SecretKey(k) || strings.EqualFold(k, "headers") || (valueIsSecret && k == "value")
+			res[k] = redactAuditV2Value(sub, subSecret)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(x))
+		for i := range x {
+			res[i] = redactAuditV2Value(x[i], secret)
+		}
+		return res
+	case string:
+		if secret && x != "" {
+			return PasswordPlaceholder
+		}
+		return x
+	default:
+		return v
+	}
+}
+
+// ComputeAuditV2Diff returns the list of the fields that are different between two versions of an entity.
+// Fields named last_modified are ignored.
+func ComputeAuditV2Diff(before, after AuditV2Data) AuditV2Diff {
+	beforeFields := make(map[string]interface{})
+	flattenAuditV2Value("", map[string]interface{}(before), beforeFields)
+	afterFields := make(map[string]interface{})
+	flattenAuditV2Value("", map[string]interface{}(after), afterFields)
+
+	paths := make([]string, 0, len(beforeFields)+len(afterFields))
+	for p := range beforeFields {
+		paths = append(paths, p)
+	}
+	for p := range afterFields {
+		if _, has := beforeFields[p]; !has {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	diff := AuditV2Diff{}
+	for _, p := range paths {
+		b, a := beforeFields[p], afterFields[p]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		diff = append(diff, AuditV2Change{Path: p, Before: b, After: a})
+	}
+	return diff
+}
+
+func flattenAuditV2Value(path string, v interface{}, fields map[string]interface{}) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, sub := range x {
+			if k == "last_modified" {
+				continue
+			}
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			flattenAuditV2Value(p, sub, fields)
+		}
+	case []interface{}:
+		for i := range x {
+			flattenAuditV2Value(path+"["+strconv.Itoa(i)+"]", x[i], fields)
+		}
+	default:
+		if path != "" && v != nil {
+			fields[path] = v
+		}
+	}
+}
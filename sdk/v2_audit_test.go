@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditV2Data(t *testing.T) {
+	vcs := VCSProject{Name: "github", Auth: VCSAuthProject{Username: "bot", Token: "my-token", SSHKeyName: "proj-ssh"}}
+	bts, _ := json.Marshal(vcs)
+	data, err := NewAuditV2Data(bts)
+	require.NoError(t, err)
+	auth := data["auth"].(map[string]interface{})
+	require.Equal(t, "bot", auth["username"])
+	require.Equal(t, PasswordPlaceholder, auth["token"])
+	require.Equal(t, "proj-ssh", auth["sshKeyName"])
+
+	item := ProjectVariableSetItem{Name: "password", Type: ProjectVariableTypeSecret, Value: "my-secret"}
+	bts, _ = json.Marshal(item)
+	data, err = NewAuditV2Data(bts)
+	require.NoError(t, err)
+	require.Equal(t, "password", data["name"])
+	require.Equal(t, PasswordPlaceholder, data["value"])
+
+	item = ProjectVariableSetItem{Name: "url", Type: ProjectVariableTypeString, Value: "https://example.com"}
+	bts, _ = json.Marshal(item)
+	data, err = NewAuditV2Data(bts)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com", data["value"])
+
+	integ := ProjectIntegration{
+		Name: "artifactory",
+		Config: IntegrationConfig{
+			"url":        IntegrationConfigValue{Type: IntegrationConfigTypeString, Value: "https://artifactory"},
+			"credential": IntegrationConfigValue{Type: IntegrationConfigTypePassword, Value: "my-password"},
+		},
+	}
+	bts, _ = json.Marshal(integ)
+	data, err = NewAuditV2Data(bts)
+	require.NoError(t, err)
+	config := data["config"].(map[string]interface{})
+	require.Equal(t, "https://artifactory", config["url"].(map[string]interface{})["value"])
+	require.Equal(t, PasswordPlaceholder, config["credential"].(map[string]interface{})["value"])
+
+	notif := ProjectNotification{Name: "webhook", WebHookURL: "https://hook", Auth: ProjectNotificationAuth{Headers: map[string]string{"Authorization": "Bearer xxx"}}}
+	bts, _ = json.Marshal(notif)
+	data, err = NewAuditV2Data(bts)
+	require.NoError(t, err)
+	require.Equal(t, "https://hook", data["webhook_url"])
+	headers := data["auth"].(map[string]interface{})["headers"].(map[string]interface{})
+	require.Equal(t, PasswordPlaceholder, headers["Authorization"])
+
+	data, err = NewAuditV2Data(json.RawMessage(`["a","b"]`))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", "b"}, data["payload"])
+
+	data, err = NewAuditV2Data(nil)
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestComputeAuditV2Diff(t *testing.T) {
+	before := AuditV2Data{
+		"name":          "my-concurrency",
+		"pool":          json.Number("1"),
+		"last_modified": "2024-01-01",
+		"tags":          []interface{}{"a", "b"},
+		"auth":          map[string]interface{}{"username": "foo", "token": PasswordPlaceholder},
+	}
+	after := AuditV2Data{
+		"name":          "my-concurrency",
+		"pool":          json.Number("2"),
+		"last_modified": "2024-01-02",
+		"tags":          []interface{}{"a"},
+		"auth":          map[string]interface{}{"username": "bar", "token": PasswordPlaceholder},
+		"description":   "new",
+	}
+
+	diff := ComputeAuditV2Diff(before, after)
+	require.Equal(t, AuditV2Diff{
+		{Path: "auth.username", Before: "foo", After: "bar"},
+		{Path: "description", After: "new"},
+		{Path: "pool", Before: json.Number("1"), After: json.Number("2")},
+		{Path: "tags[1]", Before: "b"},
+	}, diff)
+
+	diff = ComputeAuditV2Diff(nil, AuditV2Data{"name": "foo"})
+	require.Equal(t, AuditV2Diff{{Path: "name", After: "foo"}}, diff)
+
+	require.Empty(t, ComputeAuditV2Diff(before, before))
+}
+
+func TestFullEventV2AuditEntity(t *testing.T) {
+	entityType, name := FullEventV2{Type: EventVariableSetItemUpdated, ProjectKey: "PROJ", VariableSet: "vs", Item: "item"}.AuditEntity()
+	require.Equal(t, AuditV2EntityVariableSetItem, entityType)
+	require.Equal(t, "vs/item", name)
+
+	entityType, name = FullEventV2{Type: EventSharedConcurrencyCreated, Region: "eu", Concurrency: "deploy"}.AuditEntity()
+	require.Equal(t, AuditV2EntitySharedConcurrency, entityType)
+	require.Equal(t, "region/eu/deploy", name)
+
+	entityType, _ = FullEventV2{Type: EventRunJobEnded, ProjectKey: "PROJ"}.AuditEntity()
+	require.Empty(t, entityType)
+
+	require.True(t, EventVCSDeleted.IsDeletion())
+	require.False(t, EventPermissionGrantRevoked.IsDeletion())
+}
@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MergeQueueBranchPrefix prefixes the temporary branches built by the merge queue
+	MergeQueueBranchPrefix = "cds-merge-queue/"
+
+	// MergeQueueDefaultBatchSize is the number of pull requests tested together when no workflow sets it
+	MergeQueueDefaultBatchSize = 5
+)
+
+type MergeQueueEntryStatus string
+
+const (
+	MergeQueueEntryStatusQueued  MergeQueueEntryStatus = "Queued"
+	MergeQueueEntryStatusTesting MergeQueueEntryStatus = "Testing"
+	MergeQueueEntryStatusMerged  MergeQueueEntryStatus = "Merged"
+	MergeQueueEntryStatusEjected MergeQueueEntryStatus = "Ejected"
+)
+
+type MergeQueueBatchStatus string
+
+const (
+	MergeQueueBatchStatusMerging MergeQueueBatchStatus = "Merging"
+	MergeQueueBatchStatusTesting MergeQueueBatchStatus = "Testing"
+	MergeQueueBatchStatusLanding MergeQueueBatchStatus = "Landing"
+	MergeQueueBatchStatusSuccess MergeQueueBatchStatus = "Success"
+	MergeQueueBatchStatusFail    MergeQueueBatchStatus = "Fail"
+)
+
+func (s MergeQueueBatchStatus) IsTerminated() bool {
+	return s == MergeQueueBatchStatusSuccess || s == MergeQueueBatchStatusFail
+}
+
+// MergeQueueEntry is a pull request waiting in the merge queue of a repository
+type MergeQueueEntry struct {
+	ID               string                `json:"id" db:"id" cli:"id"`
+	ProjectKey       string                `json:"project_key" db:"project_key"`
+	VCSServer        string                `json:"vcs_server" db:"vcs_server"`
+	Repository       string                `json:"repository" db:"repository"`
+	BaseBranch       string                `json:"base_branch" db:"base_branch" cli:"base_branch"`
+	PullRequestID    int64                 `json:"pull_request_id" db:"pull_request_id" cli:"pull_request"`
+	PullRequestTitle string                `json:"pull_request_title" db:"pull_request_title" cli:"title"`
+	PullRequestURL   string                `json:"pull_request_url" db:"pull_request_url"`
+	HeadCommit       string                `json:"head_commit" db:"head_commit" cli:"head_commit"`
+	Status           MergeQueueEntryStatus `json:"status" db:"status" cli:"status"`
+	BatchID          string                `json:"batch_id,omitempty" db:"batch_id" cli:"batch_id"`
+	Isolated         bool                  `json:"isolated" db:"isolated"`
+	Error            string                `json:"error,omitempty" db:"error" cli:"error"`
+	Username         string                `json:"username" db:"username" cli:"username"`
+	Created          time.Time             `json:"created" db:"created" cli:"created"`
+	LastModified     time.Time             `json:"last_modified" db:"last_modified"`
+}
+
+// MergeQueueBatch is a set of entries merged together on a temporary branch and tested by merge-queue workflows
+type MergeQueueBatch struct {
+	ID            string                `json:"id" db:"id"`
+	ProjectKey    string                `json:"project_key" db:"project_key"`
+	VCSServer     string                `json:"vcs_server" db:"vcs_server"`
+	Repository    string                `json:"repository" db:"repository"`
+	BaseBranch    string                `json:"base_branch" db:"base_branch"`
+	BaseCommit    string                `json:"base_commit" db:"base_commit"`
+	Branch        string                `json:"branch" db:"branch"`
+	Commit        string                `json:"commit" db:"commit"`
+	Status        MergeQueueBatchStatus `json:"status" db:"status"`
+	OperationUUID string                `json:"operation_uuid" db:"operation_uuid"`
+	HookEventUUID string                `json:"hook_event_uuid" db:"hook_event_uuid"`
+	RunIDs        StringSlice           `json:"run_ids" db:"run_ids"`
+	Error         string                `json:"error,omitempty" db:"error"`
+	Created       time.Time             `json:"created" db:"created"`
+	LastModified  time.Time             `json:"last_modified" db:"last_modified"`
+}
+
+// BranchName returns the name of the temporary branch of the batch
+func (b MergeQueueBatch) BranchName() string {
+	return fmt.Sprintf("%s%s/%s", MergeQueueBranchPrefix, b.BaseBranch, b.ID)
+}
+
+// MergeQueueEnqueueRequest asks to add a pull request in the merge queue of a repository
+type MergeQueueEnqueueRequest struct {
+	PullRequestID int64 `json:"pull_request_id"`
+}
+
+// HookMergeQueueRequest asks the hooks service to trigger merge-queue workflows on a batch
+type HookMergeQueueRequest struct {
+	ProjectKey string `json:"project_key"`
+	VCSServer  string `json:"vcs_server"`
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Commit     string `json:"commit"`
+	BaseBranch string `json:"base_branch"`
+}
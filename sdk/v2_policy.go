@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type PolicyLanguage string
+
+const (
+	PolicyLanguageExpression PolicyLanguage = "expression"
+	PolicyLanguageRego       PolicyLanguage = "rego"
+)
+
+func (l PolicyLanguage) IsValid() bool {
+	return l == PolicyLanguageExpression || l == PolicyLanguageRego
+}
+
+type PolicyOutcome string
+
+const (
+	PolicyOutcomeDeny PolicyOutcome = "deny"
+	PolicyOutcomeWarn PolicyOutcome = "warn"
+)
+
+func (o PolicyOutcome) IsValid() bool {
+	return o == PolicyOutcomeDeny || o == PolicyOutcomeWarn
+}
+
+// Policy is a rule checked on as-code entities during the repository analysis, and on workflows when a run is crafted.
+// A policy belongs either to a project or to an organization, organization policies apply to all its projects.
+//
+// With the expression language, the rule is a CDS expression: when it is true, the outcome is raised with the message.
+// With the rego language, the rule is a Rego module: each message of its "deny" and "warn" rules raises the matching outcome.
+type Policy struct {
+	ID             string         `json:"id" db:"id" cli:"id"`
+	ProjectKey     string         `json:"project_key,omitempty" db:"-"`
+	OrganizationID string         `json:"organization_id,omitempty" db:"-"`
+	Name           string         `json:"name" db:"name" cli:"name"`
+	Description    string         `json:"description" db:"description" cli:"description"`
+	EntityType     string         `json:"entity_type" db:"entity_type" cli:"entity_type"`
+	Language       PolicyLanguage `json:"language" db:"language" cli:"language"`
+	Rule           string         `json:"rule" db:"rule"`
+	Outcome        PolicyOutcome  `json:"outcome,omitempty" db:"outcome" cli:"outcome"`
+	Message        string         `json:"message,omitempty" db:"message"`
+	LastModified   time.Time      `json:"last_modified" db:"last_modified" cli:"last_modified"`
+}
+
+func (p *Policy) Check() error {
+	namePattern, err := regexp.Compile(EntityNamePattern)
+	if err != nil {
+		return WrapError(err, "unable to compile regexp %s", namePattern)
+	}
+	if !namePattern.MatchString(p.Name) {
+		return NewErrorFrom(ErrInvalidData, "name %s doesn't match %s", p.Name, EntityNamePattern)
+	}
+
+	switch p.EntityType {
+	case EntityTypeWorkflow, EntityTypeWorkerModel, EntityTypeAction, EntityTypeWorkflowTemplate:
+	default:
+		return NewErrorFrom(ErrInvalidData, "invalid entity type, got %q want %s | %s | %s | %s", p.EntityType, EntityTypeWorkflow, EntityTypeWorkerModel, EntityTypeAction, EntityTypeWorkflowTemplate)
+	}
+
+	if strings.TrimSpace(p.Rule) == "" {
+		return NewErrorFrom(ErrInvalidData, "missing rule")
+	}
+
+	switch p.Language {
+	case PolicyLanguageExpression:
+		if !p.Outcome.IsValid() {
+			return NewErrorFrom(ErrInvalidData, "invalid outcome, got %q want %s | %s", p.Outcome, PolicyOutcomeDeny, PolicyOutcomeWarn)
+		}
+		if p.Message == "" {
+			return NewErrorFrom(ErrInvalidData, "missing message")
+		}
+		if err := NewActionParser(nil, DefaultFuncs).Validate(context.Background(), p.ExpressionRule()); err != nil {
+			return err
+		}
+	case PolicyLanguageRego:
+		// Outcomes and messages are given by the rules of the module
+		p.Outcome = ""
+		p.Message = ""
+	default:
+		return NewErrorFrom(ErrInvalidData, "invalid language, got %q want %s | %s", p.Language, PolicyLanguageExpression, PolicyLanguageRego)
+	}
+	return nil
+}
+
+// ExpressionRule returns the rule of an expression policy enclosed in ${{ }}
+func (p Policy) ExpressionRule() string {
+	rule := strings.TrimSpace(p.Rule)
+	if strings.HasPrefix(rule, "${{") {
+		return rule
+	}
+	return "${{ " + rule + " }}"
+}
+
+// PolicyViolation is a deny or warn outcome raised by a policy on an entity
+type PolicyViolation struct {
+	Policy  string        `json:"policy"`
+	Outcome PolicyOutcome `json:"outcome"`
+	Message string        `json:"message"`
+}
+
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("policy %s: %s", v.Policy, v.Message)
+}
+
+// PolicyStage is the moment where policies are evaluated
+type PolicyStage string
+
+const (
+	PolicyStageAnalysis PolicyStage = "analysis"
+	PolicyStageCrafting PolicyStage = "crafting"
+)
+
+// PolicyInput is the document given to policies: available as contexts in expressions and as input in Rego modules
+type PolicyInput struct {
+	Stage      PolicyStage `json:"stage"`
+	ProjectKey string      `json:"project_key"`
+	VCSServer  string      `json:"vcs_server"`
+	Repository string      `json:"repository"`
+	Ref        string      `json:"ref"`
+	Commit     string      `json:"commit"`
+	EntityType string      `json:"entity_type"`
+	EntityName string      `json:"entity_name"`
+	FilePath   string      `json:"file_path,omitempty"`
+	// Entity is the content of the entity, as written in its yaml file
+	Entity interface{} `json:"entity"`
+}
@@ -59,6 +59,7 @@ func (pc *ProjectConcurrency) Check() error {
 }
 
 type ProjectConcurrencyRunObject struct {
+	ProjectKey    string    `json:"project_key,omitempty" db:"project_key" cli:"project_key"`
 	WorkflowRunID string    `json:"workflow_run_id" db:"workflow_run_id" cli:"workflow_run_id"`
 	LastModified  time.Time `json:"last_modified" db:"last_modified" cli:"last_modified"`
 	Type          string    `json:"type" db:"type" cli:"type"`
@@ -66,4 +67,5 @@ type ProjectConcurrencyRunObject struct {
 	JobName       string    `json:"job_name" db:"job_name" cli:"job_name"`
 	Status        string    `json:"status" db:"status" cli:"status"`
 	RunNumber     int64     `json:"run_number" db:"run_number" cli:"run_number"`
+	RunJobID      string    `json:"run_job_id,omitempty" db:"run_job_id"`
 }
@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	SharedConcurrencyOrganizationPrefix = "org:"
+	SharedConcurrencyRegionPrefix       = "region:"
+)
+
+// SharedConcurrency is a named lock or semaphore shared by all the projects of an organization or a region
+type SharedConcurrency struct {
+	ID               string                   `json:"id" db:"id" cli:"id"`
+	Scope            V2RunJobConcurrencyScope `json:"scope" db:"-" cli:"scope"`
+	ScopeID          string                   `json:"scope_id" db:"-"`
+	Name             string                   `json:"name" db:"name" cli:"name"`
+	Description      string                   `json:"description" db:"description" cli:"description"`
+	Order            ConcurrencyOrder         `json:"order" db:"order" cli:"order"`
+	Pool             int64                    `json:"pool" db:"pool" cli:"pool"`
+	If               string                   `json:"if" db:"if" cli:"if"`
+	CancelInProgress bool                     `json:"cancel_in_progress,omitempty" db:"cancel_in_progress" cli:"cancel_in_progress"`
+	Timeout          string                   `json:"timeout,omitempty" db:"timeout" cli:"timeout"`
+	LastModified     time.Time                `json:"last_modified" db:"last_modified" cli:"last_modified"`
+}
+
+func (sc *SharedConcurrency) ToWorkflowConcurrency() WorkflowConcurrency {
+	return WorkflowConcurrency{
+		Name:             sc.Name,
+		Order:            sc.Order,
+		Pool:             sc.Pool,
+		CancelInProgress: sc.CancelInProgress,
+		If:               sc.If,
+	}
+}
+
+func (sc *SharedConcurrency) Check() error {
+	if !sc.Scope.IsShared() {
+		return NewErrorFrom(ErrInvalidData, "invalid scope, got %q want %s | %s", sc.Scope, V2RunConcurrencyScopeOrganization, V2RunConcurrencyScopeRegion)
+	}
+	if sc.Pool <= 0 {
+		sc.Pool = 1
+	}
+	if !sc.Order.IsValid() {
+		return NewErrorFrom(ErrInvalidData, "invalid order, got %q want %s | %s", sc.Order, ConcurrencyOrderOldestFirst, ConcurrencyOrderNewestFirst)
+	}
+	if sc.Timeout != "" {
+		d, err := time.ParseDuration(sc.Timeout)
+		if err != nil || d <= 0 {
+			return NewErrorFrom(ErrInvalidData, "invalid timeout %q, it must be a positive duration (ex: 30m)", sc.Timeout)
+		}
+	}
+
+	namePattern, err := regexp.Compile(EntityNamePattern)
+	if err != nil {
+		return WrapError(err, "unable to compile regexp %s", namePattern)
+	}
+
+	if !namePattern.MatchString(sc.Name) {
+		return NewErrorFrom(ErrInvalidData, "name %s doesn't match %s", sc.Name, EntityNamePattern)
+	}
+
+	return nil
+}
+
+// ParseSharedConcurrencyName returns the scope and the name of a concurrency reference like org:my-lock or region:my-lock
+func ParseSharedConcurrencyName(ref string) (V2RunJobConcurrencyScope, string, bool) {
+	switch {
+	case strings.HasPrefix(ref, SharedConcurrencyOrganizationPrefix):
+		return V2RunConcurrencyScopeOrganization, strings.TrimPrefix(ref, SharedConcurrencyOrganizationPrefix), true
+	case strings.HasPrefix(ref, SharedConcurrencyRegionPrefix):
+		return V2RunConcurrencyScopeRegion, strings.TrimPrefix(ref, SharedConcurrencyRegionPrefix), true
+	}
+	return "", "", false
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSharedConcurrencyName(t *testing.T) {
+	scope, name, isShared := ParseSharedConcurrencyName("org:db-cluster-1")
+	require.True(t, isShared)
+	require.Equal(t, V2RunConcurrencyScopeOrganization, scope)
+	require.Equal(t, "db-cluster-1", name)
+
+	scope, name, isShared = ParseSharedConcurrencyName("region:db-cluster-1")
+	require.True(t, isShared)
+	require.Equal(t, V2RunConcurrencyScopeRegion, scope)
+	require.Equal(t, "db-cluster-1", name)
+
+	_, _, isShared = ParseSharedConcurrencyName("db-cluster-1")
+	require.False(t, isShared)
+}
+
+func TestSharedConcurrencyCheck(t *testing.T) {
+	sc := SharedConcurrency{
+		Scope: V2RunConcurrencyScopeOrganization,
+		Name:  "db-cluster-1",
+		Order: ConcurrencyOrderOldestFirst,
+	}
+	require.NoError(t, sc.Check())
+	require.Equal(t, int64(1), sc.Pool)
+
+	sc.Timeout = "30m"
+	require.NoError(t, sc.Check())
+
+	sc.Timeout = "thirty minutes"
+	require.Error(t, sc.Check())
+
+	sc.Timeout = ""
+	sc.Scope = V2RunConcurrencyScopeProject
+	require.Error(t, sc.Check())
+}
@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gorhill/cronexpr"
 	"github.com/rockbears/yaml"
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -107,6 +106,10 @@ type WorkflowOn struct {
 	WorkflowUpdate     *WorkflowOnWorkflowUpdate     `json:"workflow-update,omitempty" jsonschema_description:"Trigger the workflow when updated (for distant workflow only)"`
 	Schedule           []WorkflowOnSchedule          `json:"schedule,omitempty" jsonschema_description:"Trigger the workflow regarding a cron scheduler"`
 	WorkflowRun        []WorkflowOnRun               `json:"workflow-run,omitempty" jsonschema_description:"Trigger the workflow at the end of another workflow run"`
+	MergeQueue         *WorkflowOnMergeQueue         `json:"merge-queue,omitempty" jsonschema_description:"Trigger the workflow on the temporary branches built by the project merge queue"`
+	Release            *WorkflowOnRelease            `json:"release,omitempty" jsonschema_description:"Trigger the workflow when a release is published, edited or deleted"`
+	BranchCreate       *WorkflowOnBranchCreate       `json:"branch-create,omitempty" jsonschema_description:"Trigger the workflow when a branch is created"`
+	BranchDelete       *WorkflowOnBranchDelete       `json:"branch-delete,omitempty" jsonschema_description:"Trigger the workflow when a branch is deleted. The workflow runs on the default branch"`
 }
 
 type WorkflowOnRun struct {
@@ -117,8 +120,13 @@ type WorkflowOnRun struct {
 }
 
 type WorkflowOnSchedule struct {
-	Cron     string `json:"cron" jsonschema:"example=0 */2 * * *" jsonschema_description:"Cron expression defining the schedule"`
-	Timezone string `json:"timezone" jsonschema:"example=UTC" jsonschema_description:"Timezone for the cron expression"`
+	Cron      string                     `json:"cron" jsonschema:"example=0 */2 * * *" jsonschema_description:"Cron expression defining the schedule"`
+	Timezone  string                     `json:"timezone" jsonschema:"example=UTC" jsonschema_description:"Timezone for the cron expression"`
+	Branch    string                     `json:"branch,omitempty" jsonschema_description:"Git branch of the run, the default branch is used if empty"`
+	Inputs    V2WorkflowRunJobInputs     `json:"inputs,omitempty" jsonschema_description:"Inputs given to the gates of the root jobs, by job"`
+	Blackouts []WorkflowScheduleBlackout `json:"blackouts,omitempty" jsonschema_description:"Periods during which the scheduler does not trigger the workflow"`
+	Jitter    string                     `json:"jitter,omitempty" jsonschema:"example=10m" jsonschema_description:"Maximum random delay added to each execution"`
+	CatchUp   string                     `json:"catch-up,omitempty" jsonschema:"enum=skip,enum=last,enum=all" jsonschema_description:"Executions to trigger when some were missed: skip them, trigger the last one (default) or trigger all of them"`
 }
 
 type WorkflowOnPush struct {
@@ -143,6 +151,24 @@ type WorkflowOnPullRequestComment struct {
 	Types         []WorkflowHookEventType `json:"types,omitempty" jsonschema_description:"Pull request event types that will trigger the workflow"`
 }
 
+type WorkflowOnMergeQueue struct {
+	Branches  []string `json:"branches,omitempty" jsonschema_description:"Destination branches protected by the merge queue"`
+	BatchSize int64    `json:"batch-size,omitempty" jsonschema:"example=5" jsonschema_description:"Maximum number of pull requests tested together (default 5)"`
+}
+
+type WorkflowOnRelease struct {
+	Tags  []string                `json:"tags,omitempty" jsonschema_description:"Release tags that will trigger the workflow"`
+	Types []WorkflowHookEventType `json:"types,omitempty" jsonschema_description:"Release event types that will trigger the workflow: published, edited, deleted"`
+}
+
+type WorkflowOnBranchCreate struct {
+	Branches []string `json:"branches,omitempty" jsonschema_description:"Created branches that will trigger the workflow"`
+}
+
+type WorkflowOnBranchDelete struct {
+	Branches []string `json:"branches,omitempty" jsonschema_description:"Deleted branches that will trigger the workflow"`
+}
+
 type WorkflowOnModelUpdate struct {
 	Models       []string `json:"models,omitempty" jsonschema_description:"Worker model names that will trigger the workflow"`
 	TargetBranch string   `json:"target_branch,omitempty" jsonschema_description:"Git branch that will be used to trigger the workflow"`
@@ -200,6 +226,30 @@ func IsDefaultHooks(on *WorkflowOn) []WorkflowHookEventName {
 			return nil
 		}
 	}
+	if on.MergeQueue != nil {
+		hookKeys = append(hookKeys, WorkflowHookEventNameMergeQueue)
+		if len(on.MergeQueue.Branches) > 0 || on.MergeQueue.BatchSize > 0 {
+			return nil
+		}
+	}
+	if on.Release != nil {
+		hookKeys = append(hookKeys, WorkflowHookEventNameRelease)
+		if len(on.Release.Tags) > 0 || len(on.Release.Types) > 0 {
+			return nil
+		}
+	}
+	if on.BranchCreate != nil {
+		hookKeys = append(hookKeys, WorkflowHookEventNameBranchCreate)
+		if len(on.BranchCreate.Branches) > 0 {
+			return nil
+		}
+	}
+	if on.BranchDelete != nil {
+		hookKeys = append(hookKeys, WorkflowHookEventNameBranchDelete)
+		if len(on.BranchDelete.Branches) > 0 {
+			return nil
+		}
+	}
 	if on.WorkflowUpdate != nil {
 		hookKeys = append(hookKeys, WorkflowHookEventNameWorkflowUpdate)
 		if on.WorkflowUpdate.TargetBranch != "" {
@@ -273,6 +323,22 @@ func (w *V2Workflow) UnmarshalJSON(data []byte) error {
 					Branches: []string{},
 					Paths:    []string{},
 				}
+			case WorkflowHookEventNameMergeQueue:
+				workflowAlias.On.MergeQueue = &WorkflowOnMergeQueue{
+					Branches: []string{},
+				}
+			case WorkflowHookEventNameRelease:
+				workflowAlias.On.Release = &WorkflowOnRelease{
+					Tags: []string{},
+				}
+			case WorkflowHookEventNameBranchCreate:
+				workflowAlias.On.BranchCreate = &WorkflowOnBranchCreate{
+					Branches: []string{},
+				}
+			case WorkflowHookEventNameBranchDelete:
+				workflowAlias.On.BranchDelete = &WorkflowOnBranchDelete{
+					Branches: []string{},
+				}
 			}
 		}
 	}
@@ -493,28 +559,36 @@ type V2WorkflowHookShort struct {
 	WorkflowName   string `json:"workflow_name"`
 }
 
+// V2WorkflowScheduleEvent is the payload of a run triggered by a scheduler, available in the event context.
+// ScheduledTime is the fire time of the execution given by the cron expression, before the jitter, in UTC with the RFC3339 format.
 type V2WorkflowScheduleEvent struct {
-	Schedule string `json:"schedule"`
+	Schedule      string `json:"schedule"`
+	ScheduledTime string `json:"scheduled_time,omitempty"`
 }
 
 type V2WorkflowHookData struct {
-	VCSServer                   string                  `json:"vcs_server,omitempty"`
-	RepositoryName              string                  `json:"repository_name,omitempty"`
-	RepositoryEvent             WorkflowHookEventName   `json:"repository_event,omitempty"`
-	Model                       string                  `json:"model,omitempty"`
-	CommitFilter                string                  `json:"commit_filter,omitempty"`
-	CommentFilter               string                  `json:"comment_filter,omitempty"`
-	BranchFilter                []string                `json:"branch_filter,omitempty"`
-	TagFilter                   []string                `json:"tag_filter,omitempty"`
-	PathFilter                  []string                `json:"path_filter,omitempty"`
-	TypesFilter                 []WorkflowHookEventType `json:"types_filter,omitempty"`
-	TargetBranch                string                  `json:"target_branch,omitempty"`
-	TargetTag                   string                  `json:"target_tag,omitempty"`
-	Cron                        string                  `json:"cron,omitempty"`
-	CronTimeZone                string                  `json:"cron_timezone,omitempty"`
-	WorkflowRunName             string                  `json:"workflow_run_name"`
-	WorkflowRunStatus           []string                `json:"workflow_run_status"`
-	InsecureSkipSignatureVerify bool                    `json:"insecure_skip_signature_verify"`
+	VCSServer                   string                     `json:"vcs_server,omitempty"`
+	RepositoryName              string                     `json:"repository_name,omitempty"`
+	RepositoryEvent             WorkflowHookEventName      `json:"repository_event,omitempty"`
+	Model                       string                     `json:"model,omitempty"`
+	CommitFilter                string                     `json:"commit_filter,omitempty"`
+	CommentFilter               string                     `json:"comment_filter,omitempty"`
+	BranchFilter                []string                   `json:"branch_filter,omitempty"`
+	TagFilter                   []string                   `json:"tag_filter,omitempty"`
+	PathFilter                  []string                   `json:"path_filter,omitempty"`
+	TypesFilter                 []WorkflowHookEventType    `json:"types_filter,omitempty"`
+	TargetBranch                string                     `json:"target_branch,omitempty"`
+	TargetTag                   string                     `json:"target_tag,omitempty"`
+	Cron                        string                     `json:"cron,omitempty"`
+	CronTimeZone                string                     `json:"cron_timezone,omitempty"`
+	ScheduleInputs              V2WorkflowRunJobInputs     `json:"schedule_inputs,omitempty"`
+	ScheduleBlackouts           []WorkflowScheduleBlackout `json:"schedule_blackouts,omitempty"`
+	ScheduleJitter              string                     `json:"schedule_jitter,omitempty"`
+	ScheduleCatchUp             string                     `json:"schedule_catch_up,omitempty"`
+	MergeQueueBatchSize         int64                      `json:"merge_queue_batch_size,omitempty"`
+	WorkflowRunName             string                     `json:"workflow_run_name"`
+	WorkflowRunStatus           []string                   `json:"workflow_run_status"`
+	InsecureSkipSignatureVerify bool                       `json:"insecure_skip_signature_verify"`
 }
 
 func (d V2WorkflowHookData) ValidateRef(ctx context.Context, ref string) bool {
@@ -611,13 +685,7 @@ func (w V2Workflow) Lint() []error {
 	}
 	documentLoader := gojsonschema.NewStringLoader(string(modelJson))
 
-	if w.On != nil {
-		for _, s := range w.On.Schedule {
-			if _, err := cronexpr.Parse(s.Cron); err != nil {
-				errs = append(errs, NewErrorFrom(err, "workflow %s: unable to parse cron expression: %s", w.Name, s.Cron))
-			}
-		}
-	}
+	errs = append(errs, w.CheckSchedules()...)
 
 	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
 	if err != nil {
@@ -766,6 +834,32 @@ func WorkflowJobParents(w V2Workflow, jobID string) []string {
 	return needsParents
 }
 
+// WorkflowJobNeeds returns the jobs needed by the given job. Without explicit needs, a job in a stage
+// needs the final jobs of the stages its stage depends on.
+func WorkflowJobNeeds(w V2Workflow, jobID string) []string {
+	jobDef := w.Jobs[jobID]
+	if len(jobDef.Needs) > 0 || jobDef.Stage == "" {
+		return jobDef.Needs
+	}
+	needs := make([]string, 0)
+	for _, stage := range w.Stages[jobDef.Stage].Needs {
+	nextJob:
+		for id, j := range w.Jobs {
+			if j.Stage != stage {
+				continue
+			}
+			for _, other := range w.Jobs {
+				if other.Stage == stage && slices.Contains(other.Needs, id) {
+					continue nextJob
+				}
+			}
+			needs = append(needs, id)
+		}
+	}
+	slices.Sort(needs)
+	return needs
+}
+
 func WorkflowStageParentsNeeds(w V2Workflow, currentStage string) []string {
 	parents := make([]string, 0)
 	stage := w.Stages[currentStage]
@@ -802,6 +896,7 @@ type V2WorkflowRunManualRequest struct {
 
 type V2WorkflowRunTriggerJobsRequest struct {
 	JobInputs V2WorkflowRunJobInputs `json:"job_inputs,omitempty"`
+	Debug     bool                   `json:"debug,omitempty"`
 }
 
 type V2WorkflowRunManualResponse struct {
@@ -812,4 +907,83 @@ type V2WorkflowRunManualResponse struct {
 type SchedulerExecution struct {
 	SchedulerDef      V2WorkflowHook
 	NextExecutionTime int64
+	// ScheduledTime is the fire time given by the cron expression, before the jitter
+	ScheduledTime int64
+}
+
+// GenerateMatrixPermutation interpolates the values of the matrix strategy with the given job context and returns
+// all the permutations of the matrix, with the interpolated values of each key.
+func GenerateMatrixPermutation(ctx context.Context, jobContext WorkflowRunJobsContext, strategy *V2JobStrategy) ([]map[string]string, map[string][]string, error) {
+	alls := make([]map[string]string, 0)
+	if strategy == nil || len(strategy.Matrix) == 0 {
+		return alls, nil, nil
+	}
+
+	bts, err := json.Marshal(jobContext)
+	if err != nil {
+		return nil, nil, WithStack(err)
+	}
+	var mapContexts map[string]interface{}
+	if err := json.Unmarshal(bts, &mapContexts); err != nil {
+		return nil, nil, WithStack(err)
+	}
+	ap := NewActionParser(mapContexts, DefaultFuncs)
+
+	keys := make([]string, 0, len(strategy.Matrix))
+	interpolatedMatrix := make(map[string][]string, len(strategy.Matrix))
+	for k, v := range strategy.Matrix {
+		keys = append(keys, k)
+		matrixValues := make([]string, 0)
+		switch value := v.(type) {
+		case []interface{}:
+			for _, sliceValue := range value {
+				valueString, ok := sliceValue.(string)
+				if !ok {
+					return nil, nil, NewErrorFrom(ErrInvalidData, "matrix value %v is not a string", sliceValue)
+				}
+				interpolatedValue, err := ap.InterpolateToString(ctx, valueString)
+				if err != nil {
+					return nil, nil, NewErrorFrom(ErrInvalidData, "unable to interpolate matrix value %s: %v", valueString, err)
+				}
+				matrixValues = append(matrixValues, interpolatedValue)
+			}
+		case string:
+			interpolatedValue, err := ap.Interpolate(ctx, value)
+			if err != nil {
+				return nil, nil, NewErrorFrom(ErrInvalidData, "unable to interpolate %s: %v", value, err)
+			}
+			interpolatedSlice, ok := interpolatedValue.([]interface{})
+			if !ok {
+				return nil, nil, NewErrorFrom(ErrInvalidData, "interpolated matrix is not a string slice, got %T", interpolatedValue)
+			}
+			for _, vle := range interpolatedSlice {
+				matrixValues = append(matrixValues, fmt.Sprintf("%v", vle))
+			}
+		default:
+			return nil, nil, NewErrorFrom(ErrInvalidData, "unable to use matrix key %s of type %T", k, v)
+		}
+		interpolatedMatrix[k] = matrixValues
+	}
+	slices.Sort(keys)
+
+	generateMatrix(interpolatedMatrix, keys, 0, make(map[string]string), &alls)
+	return alls, interpolatedMatrix, nil
+}
+
+func generateMatrix(matrix map[string][]string, keys []string, keyIndex int, current map[string]string, alls *[]map[string]string) {
+	if len(current) == len(keys) {
+		combinationCopy := make(map[string]string, len(current))
+		for k, v := range current {
+			combinationCopy[k] = v
+		}
+		*alls = append(*alls, combinationCopy)
+		return
+	}
+
+	key := keys[keyIndex]
+	for _, value := range matrix[key] {
+		current[key] = value
+		generateMatrix(matrix, keys, keyIndex+1, current, alls)
+		delete(current, key)
+	}
 }
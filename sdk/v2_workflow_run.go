@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 	"github.com/rockbears/log"
 	"github.com/rockbears/yaml"
@@ -21,10 +23,17 @@ const (
 	GitCommitManualPayload = "git.commit"
 	GitTagManualPayload    = "git.tag"
 
-	V2RunConcurrencyScopeProject  V2RunJobConcurrencyScope = "project"
-	V2RunConcurrencyScopeWorkflow V2RunJobConcurrencyScope = "workflow"
+	V2RunConcurrencyScopeProject      V2RunJobConcurrencyScope = "project"
+	V2RunConcurrencyScopeWorkflow     V2RunJobConcurrencyScope = "workflow"
+	V2RunConcurrencyScopeOrganization V2RunJobConcurrencyScope = "organization"
+	V2RunConcurrencyScopeRegion       V2RunJobConcurrencyScope = "region"
 )
 
+// IsShared returns true if the concurrency can be shared between several projects
+func (s V2RunJobConcurrencyScope) IsShared() bool {
+	return s == V2RunConcurrencyScopeOrganization || s == V2RunConcurrencyScopeRegion
+}
+
 type V2WorkflowRunHookRequest struct {
 	HookEventID        string                 `json:"hook_event_id"`
 	DeprecatedUserID   string                 `json:"user_id"` // Deprecated
@@ -51,6 +60,12 @@ type V2WorkflowRunHookRequest struct {
 	Initiator          *V2Initiator           `json:"initiator"`
 	TargetRepository   string                 `json:"target_repository"`
 	JobInputs          map[string]GateInputs  `json:"job_inputs,omitempty"`
+	EventType          WorkflowHookEventType  `json:"event_type,omitempty"`
+	DeletedRef         string                 `json:"deleted_ref,omitempty"`
+	ReleaseTag         string                 `json:"release_tag,omitempty"`
+	ReleaseName        string                 `json:"release_name,omitempty"`
+	ReleaseURL         string                 `json:"release_url,omitempty"`
+	TraceParent        string                 `json:"trace_parent,omitempty"`
 }
 
 type V2WorkflowRun struct {
@@ -79,6 +94,7 @@ type V2WorkflowRun struct {
 	Annotations        WorkflowRunAnnotations `json:"annotations,omitempty" db:"annotations" cli:"-"`
 	Initiator          *V2Initiator           `json:"initiator,omitempty" db:"initiator" cli:"-"`
 	Concurrency        *V2RunConcurrency      `json:"concurrency,omitempty" db:"concurrency" cli:"-"`
+	TraceParent        string                 `json:"trace_parent,omitempty" db:"trace_parent" cli:"-"`
 }
 
 type V2WorkflowRunStatus string
@@ -203,10 +219,21 @@ type V2WorkflowRunJobEvent struct {
 	JobID      string                 `json:"job_id"`
 	Inputs     map[string]interface{} `json:"inputs"`
 	RunAttempt int64                  `json:"run_attempt"`
+	Debug      bool                   `json:"debug,omitempty"`
 }
 
 type V2WorkflowRunJobEvents []V2WorkflowRunJobEvent
 
+// Debug returns true if the debug mode was requested for the given job on the given run attempt
+func (w V2WorkflowRunJobEvents) Debug(runAttempt int64, jobID string) bool {
+	for _, e := range w {
+		if e.RunAttempt == runAttempt && e.JobID == jobID && e.Debug {
+			return true
+		}
+	}
+	return false
+}
+
 func (w V2WorkflowRunJobEvents) Value() (driver.Value, error) {
 	j, err := json.Marshal(w)
 	return j, WrapError(err, "cannot marshal V2WorkflowRunJobEvents")
@@ -245,6 +272,11 @@ type V2WorkflowRunEvent struct {
 	WorkflowRunID     string                 `json:"workflow_run_id"`
 	WebHookID         string                 `json:"webhook_id"`
 	HookEventID       string                 `json:"hook_event_id,omitempty"`
+	EventType         WorkflowHookEventType  `json:"event_type,omitempty"`
+	DeletedRef        string                 `json:"deleted_ref,omitempty"`
+	ReleaseTag        string                 `json:"release_tag,omitempty"`
+	ReleaseName       string                 `json:"release_name,omitempty"`
+	ReleaseURL        string                 `json:"release_url,omitempty"`
 }
 
 func (w V2WorkflowRunEvent) Value() (driver.Value, error) {
@@ -294,11 +326,16 @@ type V2WorkflowRunJob struct {
 	GateInputs         GateInputs             `json:"gate_inputs,omitempty" db:"gate_inputs"`
 	Initiator          V2Initiator            `json:"initiator,omitempty" db:"initiator"`
 	Concurrency        *V2RunConcurrency      `json:"concurrency,omitempty" db:"concurrency"`
+	Debug              bool                   `json:"debug,omitempty" db:"debug"`
+	TraceParent        string                 `json:"trace_parent,omitempty" db:"trace_parent"`
 }
 
 type V2RunConcurrency struct {
 	WorkflowConcurrency
 	Scope V2RunJobConcurrencyScope `json:"scope"`
+	// Key is the identifier of the organization or the region for shared concurrencies
+	Key     string `json:"key,omitempty"`
+	Timeout string `json:"timeout,omitempty"`
 }
 
 type V2WorkflowRunJobStatus string
@@ -413,11 +450,29 @@ type JobStepStatus struct {
 	Outputs    JobResultOutput        `json:"outputs"`
 	Started    time.Time              `json:"started"`
 	Ended      time.Time              `json:"ended"`
+	Metrics    *JobStepMetrics        `json:"metrics,omitempty"`
 
 	// Path Outputs
 	PathOutputs StringSlice `json:"-"`
 }
 
+// JobStepMetrics contains the resources used by the worker during a step, sampled from its cgroup
+type JobStepMetrics struct {
+	CPUSeconds      float64 `json:"cpu_seconds"`
+	MemoryPeak      int64   `json:"memory_peak_bytes"`
+	DiskRead        int64   `json:"disk_read_bytes"`
+	DiskWrite       int64   `json:"disk_write_bytes"`
+	NetworkReceived int64   `json:"network_received_bytes"`
+	NetworkSent     int64   `json:"network_sent_bytes"`
+}
+
+func (m JobStepMetrics) String() string {
+	return fmt.Sprintf("CPU: %.1fs - Memory peak: %s - Disk read/write: %s/%s - Network received/sent: %s/%s",
+		m.CPUSeconds, humanize.IBytes(uint64(m.MemoryPeak)),
+		humanize.IBytes(uint64(m.DiskRead)), humanize.IBytes(uint64(m.DiskWrite)),
+		humanize.IBytes(uint64(m.NetworkReceived)), humanize.IBytes(uint64(m.NetworkSent)))
+}
+
 type GateInputs map[string]interface{}
 
 func (gi GateInputs) Value() (driver.Value, error) {
@@ -732,7 +787,7 @@ func (r *V2WorkflowRunResult) GetDetail() (V2WorkflowRunResultDetailInterface, e
 	}
 }
 
-// GetDetailLightForContext returns the detail without heavy data (e.g. JUnit test suites)
+// GetDetailLightForContext returns the detail without heavy data (e.g. JUnit test suites or coverage files)
 // suitable for inclusion in the interpolation context without causing OOM.
 func (r *V2WorkflowRunResult) GetDetailLightForContext() (V2WorkflowRunResultDetailInterface, error) {
 	detail, err := r.GetDetail()
@@ -744,6 +799,13 @@ func (r *V2WorkflowRunResult) GetDetailLightForContext() (V2WorkflowRunResultDet
 		lightCopy.TestsSuites = JUnitTestsSuites{}
 		return &lightCopy, nil
 	}
+	if genericDetail, ok := detail.(*V2WorkflowRunResultGenericDetail); ok && genericDetail.Coverage != nil {
+		lightCopy := *genericDetail
+		lightCoverage := *genericDetail.Coverage
+		lightCoverage.Files = nil
+		lightCopy.Coverage = &lightCoverage
+		return &lightCopy, nil
+	}
 	return detail, nil
 }
 
@@ -771,6 +833,45 @@ const (
 	V2WorkflowRunResultStatusCancelled = "CANCELLED"
 )
 
+// V2WorkflowRunResultRefTrigger references the workflow run that triggered the current run with on.workflow-run
+const V2WorkflowRunResultRefTrigger = "trigger"
+
+// V2WorkflowRunResultRef references the workflow run from which a job downloads run results
+type V2WorkflowRunResultRef struct {
+	Trigger   bool
+	Workflow  string // [[[project/]vcs/]org/repo/]workflow
+	RunNumber int64  // 0 means the last successful run
+}
+
+// ParseV2WorkflowRunResultRef parses a run reference like trigger, my-workflow, my-workflow:12 or PROJ/vcs/org/repo/my-workflow:12
+func ParseV2WorkflowRunResultRef(ref string) (V2WorkflowRunResultRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == V2WorkflowRunResultRefTrigger {
+		return V2WorkflowRunResultRef{Trigger: true}, nil
+	}
+	var r V2WorkflowRunResultRef
+	workflow, number, hasNumber := strings.Cut(ref, ":")
+	if hasNumber {
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n <= 0 {
+			return r, NewErrorFrom(ErrWrongRequest, "invalid run number %q in run reference %q", number, ref)
+		}
+		r.RunNumber = n
+	}
+	switch len(strings.Split(workflow, "/")) {
+	case 1, 3, 4, 5:
+	default:
+		return r, NewErrorFrom(ErrWrongRequest, "invalid run reference %q, it must be %s or [[[project/]vcs/]org/repo/]workflow[:number]", ref, V2WorkflowRunResultRefTrigger)
+	}
+	for _, s := range strings.Split(workflow, "/") {
+		if s == "" {
+			return r, NewErrorFrom(ErrWrongRequest, "invalid run reference %q, it must be %s or [[[project/]vcs/]org/repo/]workflow[:number]", ref, V2WorkflowRunResultRefTrigger)
+		}
+	}
+	r.Workflow = workflow
+	return r, nil
+}
+
 type V2WorkflowRunResultArtifactManagerMetadata map[string]string
 
 func (m *V2WorkflowRunResultArtifactManagerMetadata) Set(k, v string) {
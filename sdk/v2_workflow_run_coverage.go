@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// V2WorkflowRunCoverage is the coverage of a workflow run attempt, computed from its coverage run results.
+// On pull requests, the diff coverage is the coverage of the lines changed by the pull request.
+type V2WorkflowRunCoverage struct {
+	ID               string    `json:"id" db:"id" cli:"-"`
+	ProjectKey       string    `json:"project_key" db:"project_key" cli:"-"`
+	VCSServer        string    `json:"vcs_server" db:"vcs_server" cli:"-"`
+	Repository       string    `json:"repository" db:"repository" cli:"-"`
+	WorkflowName     string    `json:"workflow_name" db:"workflow_name" cli:"-"`
+	WorkflowRunID    string    `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	RunNumber        int64     `json:"run_number" db:"run_number" cli:"run_number"`
+	RunAttempt       int64     `json:"run_attempt" db:"run_attempt" cli:"run_attempt"`
+	GitRef           string    `json:"git_ref" db:"git_ref" cli:"ref"`
+	GitSha           string    `json:"git_sha" db:"git_sha" cli:"sha"`
+	PullRequestID    int64     `json:"pullrequest_id,omitempty" db:"pullrequest_id" cli:"-"`
+	LinesTotal       int64     `json:"lines_total" db:"lines_total" cli:"lines"`
+	LinesCovered     int64     `json:"lines_covered" db:"lines_covered" cli:"covered"`
+	Rate             float64   `json:"rate" db:"rate" cli:"coverage"`
+	DiffLinesTotal   int64     `json:"diff_lines_total" db:"diff_lines_total" cli:"diff_lines"`
+	DiffLinesCovered int64     `json:"diff_lines_covered" db:"diff_lines_covered" cli:"diff_covered"`
+	DiffRate         float64   `json:"diff_rate" db:"diff_rate" cli:"diff_coverage"`
+	Created          time.Time `json:"created" db:"created" cli:"date"`
+}
+
+// Summary returns a one line summary of the coverage, compared to the given base coverage if any
+func (c V2WorkflowRunCoverage) Summary(base *V2WorkflowRunCoverage) string {
+	s := fmt.Sprintf("Coverage %.2f%%", c.Rate)
+	if base != nil {
+		s += fmt.Sprintf(" (%+.2f%%)", c.Rate-base.Rate)
+	}
+	if c.DiffLinesTotal > 0 {
+		s += fmt.Sprintf(", diff coverage %.2f%% (%d/%d lines)", c.DiffRate, c.DiffLinesCovered, c.DiffLinesTotal)
+	}
+	return s
+}
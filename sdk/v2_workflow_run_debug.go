@@ -0,0 +1,32 @@
+package sdk
+
+import "time"
+
+// V2JobDebugSessionTimeout is the maximum duration a worker stays paused when a debug session is opened
+const V2JobDebugSessionTimeout = 1 * time.Hour
+
+type V2JobDebugMessageType string
+
+const (
+	// V2JobDebugMessageTypeInput is sent by the user, its data is written to the shell standard input
+	V2JobDebugMessageTypeInput V2JobDebugMessageType = "input"
+	// V2JobDebugMessageTypeOutput is sent by the worker with the shell standard and error outputs
+	V2JobDebugMessageTypeOutput V2JobDebugMessageType = "output"
+	// V2JobDebugMessageTypeAttach is sent by the API to the worker when a user opens a session
+	V2JobDebugMessageTypeAttach V2JobDebugMessageType = "attach"
+	// V2JobDebugMessageTypeDetach is sent by the API to the worker when a user closes a session
+	V2JobDebugMessageTypeDetach V2JobDebugMessageType = "detach"
+	// V2JobDebugMessageTypeContinue is sent by the user to resume the job
+	V2JobDebugMessageTypeContinue V2JobDebugMessageType = "continue"
+	// V2JobDebugMessageTypeExit is sent by the worker when the shell exited
+	V2JobDebugMessageTypeExit V2JobDebugMessageType = "exit"
+	// V2JobDebugMessageTypePaused is sent by the worker to describe why the job is paused
+	V2JobDebugMessageTypePaused V2JobDebugMessageType = "paused"
+)
+
+// V2JobDebugMessage is the message exchanged on the debug session websockets
+type V2JobDebugMessage struct {
+	Type     V2JobDebugMessageType `json:"type"`
+	Data     string                `json:"data,omitempty"`
+	Username string                `json:"username,omitempty"`
+}
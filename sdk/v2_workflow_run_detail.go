@@ -341,6 +341,10 @@ type V2WorkflowRunResultGenericDetail struct {
 	MD5    string      `json:"md5" mapstructure:"md5"`
 	SHA1   string      `json:"sha1" mapstructure:"sha1"`
 	SHA256 string      `json:"sha256" mapstructure:"sha256"`
+	// Coverage is set by the API on coverage run results, from the report sent by the worker
+	Coverage *CoverageReport `json:"coverage,omitempty" mapstructure:"coverage"`
+	// CoverageReportContent is the raw coverage report sent by the worker, it is parsed and dropped by the API
+	CoverageReportContent string `json:"coverage_report_content,omitempty" mapstructure:"coverage_report_content"`
 }
 
 // GetLabel implements V2WorkflowRunResultDetailInterface.
@@ -121,3 +121,26 @@ func TestJobIntegrationsContext_GetValue(t *testing.T) {
 
 	require.Equal(t, "value_of_token", got)
 }
+
+func TestParseV2WorkflowRunResultRef(t *testing.T) {
+	r, err := ParseV2WorkflowRunResultRef("trigger")
+	require.NoError(t, err)
+	require.True(t, r.Trigger)
+
+	r, err = ParseV2WorkflowRunResultRef("my-workflow")
+	require.NoError(t, err)
+	require.Equal(t, V2WorkflowRunResultRef{Workflow: "my-workflow"}, r)
+
+	r, err = ParseV2WorkflowRunResultRef("PROJ/github/ovh/cds/my-workflow:12")
+	require.NoError(t, err)
+	require.Equal(t, V2WorkflowRunResultRef{Workflow: "PROJ/github/ovh/cds/my-workflow", RunNumber: 12}, r)
+
+	_, err = ParseV2WorkflowRunResultRef("my-workflow:latest")
+	require.Error(t, err)
+
+	_, err = ParseV2WorkflowRunResultRef("cds/my-workflow")
+	require.Error(t, err)
+
+	_, err = ParseV2WorkflowRunResultRef("PROJ/github//cds/my-workflow")
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	V2WorkflowRunTestCaseStatusPassed  = "passed"
+	V2WorkflowRunTestCaseStatusFailed  = "failed"
+	V2WorkflowRunTestCaseStatusSkipped = "skipped"
+)
+
+// V2WorkflowRunTestCase is a test case of a tests run result, indexed to follow a test across workflow runs
+type V2WorkflowRunTestCase struct {
+	ID               string    `json:"id" db:"id" cli:"-"`
+	ProjectKey       string    `json:"project_key" db:"project_key" cli:"-"`
+	VCSServer        string    `json:"vcs_server" db:"vcs_server" cli:"-"`
+	Repository       string    `json:"repository" db:"repository" cli:"-"`
+	WorkflowName     string    `json:"workflow_name" db:"workflow_name" cli:"-"`
+	WorkflowRunID    string    `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	RunNumber        int64     `json:"run_number" db:"run_number" cli:"run_number"`
+	RunAttempt       int64     `json:"run_attempt" db:"run_attempt" cli:"run_attempt"`
+	WorkflowRunJobID string    `json:"workflow_run_job_id" db:"workflow_run_job_id" cli:"-"`
+	JobID            string    `json:"job_id" db:"job_id" cli:"job"`
+	RunResultID      string    `json:"run_result_id" db:"run_result_id" cli:"-"`
+	GitRef           string    `json:"git_ref" db:"git_ref" cli:"ref"`
+	GitSha           string    `json:"git_sha" db:"git_sha" cli:"sha"`
+	Suite            string    `json:"suite" db:"suite" cli:"suite"`
+	Classname        string    `json:"classname,omitempty" db:"classname" cli:"-"`
+	Name             string    `json:"name" db:"name" cli:"name"`
+	Duration         float64   `json:"duration" db:"duration" cli:"duration"`
+	Status           string    `json:"status" db:"status" cli:"status"`
+	IssuedAt         time.Time `json:"issued_at" db:"issued_at" cli:"date"`
+}
+
+// V2WorkflowTestCaseStats are the statistics of a test case over the runs of a workflow.
+// FlakyCommits is the number of commits on which the test both passed and failed.
+type V2WorkflowTestCaseStats struct {
+	Suite        string  `json:"suite" db:"suite" cli:"suite"`
+	Name         string  `json:"name" db:"name" cli:"name"`
+	Runs         int64   `json:"runs" db:"runs" cli:"runs"`
+	Failures     int64   `json:"failures" db:"failures" cli:"failures"`
+	AvgDuration  float64 `json:"avg_duration" db:"avg_duration" cli:"avg_duration"`
+	MaxDuration  float64 `json:"max_duration" db:"max_duration" cli:"max_duration"`
+	FlakyCommits int64   `json:"flaky_commits" db:"flaky_commits" cli:"flaky_commits"`
+}
+
+// TestCases returns a test case for each test of the report, filled with the given run information
+func (s JUnitTestsSuites) TestCases(base V2WorkflowRunTestCase) []V2WorkflowRunTestCase {
+	var tcs []V2WorkflowRunTestCase
+	for _, ts := range s.TestSuites {
+		for _, tc := range ts.TestCases {
+			c := base
+			c.Suite = ts.Name
+			c.Classname = tc.Classname
+			c.Name = tc.Name
+			c.Duration, _ = strconv.ParseFloat(tc.Time, 64)
+			switch {
+			case len(tc.Errors) > 0 || len(tc.Failures) > 0:
+				c.Status = V2WorkflowRunTestCaseStatusFailed
+			case len(tc.Skipped) > 0:
+				c.Status = V2WorkflowRunTestCaseStatusSkipped
+			default:
+				c.Status = V2WorkflowRunTestCaseStatusPassed
+			}
+			tcs = append(tcs, c)
+		}
+	}
+	return tcs
+}
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The trace of a workflow run uses the W3C trace context format (https://www.w3.org/TR/trace-context/).
+// Span IDs are computed from the CDS identifiers (hook event, run, run job, step), so the hooks service,
+// the API and the workers refer to the same spans without sharing any state, and the trace can be
+// exported when the run ends.
+
+// EnvTraceParent is the environment variable set in the steps with the trace context of the step
+const EnvTraceParent = "TRACEPARENT"
+
+var traceParentRegexp = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// NewTraceID returns a random trace ID
+func NewTraceID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// TraceSpanID returns the span ID identified by the given parts
+func TraceSpanID(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "/")))
+	return hex.EncodeToString(h[:8])
+}
+
+// FormatTraceParent returns a sampled traceparent header value
+func FormatTraceParent(traceID, spanID string) string {
+	return fmt.Sprintf("00-%s-%s-01", traceID, spanID)
+}
+
+// ParseTraceParent returns the trace ID and the span ID of a traceparent header value
+func ParseTraceParent(traceParent string) (string, string, error) {
+	m := traceParentRegexp.FindStringSubmatch(traceParent)
+	if m == nil || strings.Trim(m[1], "0") == "" || strings.Trim(m[2], "0") == "" {
+		return "", "", NewErrorFrom(ErrWrongRequest, "invalid traceparent %q", traceParent)
+	}
+	return m[1], m[2], nil
+}
+
+// TraceParentWithSpan returns the traceparent of the given span in the same trace, or an empty string if the traceparent is invalid
+func TraceParentWithSpan(traceParent, spanID string) string {
+	traceID, _, err := ParseTraceParent(traceParent)
+	if err != nil {
+		return ""
+	}
+	return FormatTraceParent(traceID, spanID)
+}
+
+// TraceSpanID returns the ID of the span of the workflow run
+func (wr V2WorkflowRun) TraceSpanID() string {
+	return TraceSpanID(wr.ID)
+}
+
+// TraceParentSpanID returns the span that started the workflow run, i.e. the span of the hook event,
+// or an empty string if the run is the root of its trace
+func (wr V2WorkflowRun) TraceParentSpanID() string {
+	_, spanID, err := ParseTraceParent(wr.TraceParent)
+	if err != nil || spanID == wr.TraceSpanID() {
+		return ""
+	}
+	return spanID
+}
+
+// JobTraceParent returns the traceparent of a job of the workflow run
+func (wr V2WorkflowRun) JobTraceParent(runJobID string) string {
+	return TraceParentWithSpan(wr.TraceParent, TraceSpanID(runJobID))
+}
+
+// StepTraceParent returns the traceparent of a step of the job
+func (rj V2WorkflowRunJob) StepTraceParent(stepName string) string {
+	return TraceParentWithSpan(rj.TraceParent, TraceSpanID(rj.ID, stepName))
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	traceID, spanID, err := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.NoError(t, err)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	require.Equal(t, "00f067aa0ba902b7", spanID)
+
+	for _, tp := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+	} {
+		_, _, err := ParseTraceParent(tp)
+		require.Error(t, err, tp)
+	}
+}
+
+func TestV2WorkflowRunTraceParent(t *testing.T) {
+	traceID := NewTraceID()
+	require.Len(t, traceID, 32)
+
+	// Run started by a hook event: the span of the event is the parent of the run
+	run := V2WorkflowRun{ID: UUID(), TraceParent: FormatTraceParent(traceID, TraceSpanID("hook-event-uuid"))}
+	require.Equal(t, TraceSpanID("hook-event-uuid"), run.TraceParentSpanID())
+
+	// Root run
+	rootRun := V2WorkflowRun{ID: UUID()}
+	rootRun.TraceParent = FormatTraceParent(traceID, rootRun.TraceSpanID())
+	require.Equal(t, "", rootRun.TraceParentSpanID())
+
+	rj := V2WorkflowRunJob{ID: "job-uuid", TraceParent: run.JobTraceParent("job-uuid")}
+	require.Equal(t, FormatTraceParent(traceID, TraceSpanID("job-uuid")), rj.TraceParent)
+	require.Equal(t, FormatTraceParent(traceID, TraceSpanID("job-uuid", "step-0")), rj.StepTraceParent("step-0"))
+
+	require.Equal(t, "", V2WorkflowRunJob{ID: "job-uuid"}.StepTraceParent("step-0"))
+}
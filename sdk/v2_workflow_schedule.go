@@ -0,0 +1,247 @@
+package sdk
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+const (
+	WorkflowScheduleCatchUpSkip = "skip"
+	WorkflowScheduleCatchUpLast = "last"
+	WorkflowScheduleCatchUpAll  = "all"
+
+	// WorkflowScheduleMaxCatchUp is the maximum number of missed executions triggered with the catch-up policy "all"
+	WorkflowScheduleMaxCatchUp = 10
+
+	// workflowScheduleMaxIterations limits the number of cron occurrences browsed while skipping blackouts
+	workflowScheduleMaxIterations = 100000
+)
+
+// V2WorkflowScheduleExecution is a future execution of a workflow scheduler
+type V2WorkflowScheduleExecution struct {
+	HookID   string    `json:"hook_id" cli:"-"`
+	Cron     string    `json:"cron" cli:"cron"`
+	Timezone string    `json:"timezone" cli:"timezone"`
+	Branch   string    `json:"branch,omitempty" cli:"branch"`
+	Jitter   string    `json:"jitter,omitempty" cli:"jitter"`
+	CatchUp  string    `json:"catch_up,omitempty" cli:"catch_up"`
+	Time     time.Time `json:"time" cli:"time"`
+}
+
+// WorkflowScheduleBlackout is a period during which a scheduler must not trigger the workflow.
+// A time is in the blackout if it matches all the given criteria. Times are in the timezone of the scheduler.
+type WorkflowScheduleBlackout struct {
+	Days  []string `json:"days,omitempty" jsonschema:"example=saturday" jsonschema_description:"Days of the week, e.g. saturday or sat"`
+	Dates []string `json:"dates,omitempty" jsonschema:"example=2024-12-25" jsonschema_description:"Dates with the format YYYY-MM-DD, or MM-DD for a date repeated each year"`
+	From  string   `json:"from,omitempty" jsonschema:"example=18:00" jsonschema_description:"Start time of the blackout with the format HH:MM"`
+	To    string   `json:"to,omitempty" jsonschema:"example=08:00" jsonschema_description:"End time of the blackout with the format HH:MM, excluded"`
+}
+
+// Check returns an error if the blackout is empty or has invalid values
+func (b WorkflowScheduleBlackout) Check() error {
+	if len(b.Days) == 0 && len(b.Dates) == 0 && b.From == "" && b.To == "" {
+		return NewErrorFrom(ErrInvalidData, "empty blackout")
+	}
+	for _, d := range b.Days {
+		if _, ok := parseWeekday(d); !ok {
+			return NewErrorFrom(ErrInvalidData, "invalid blackout day %q", d)
+		}
+	}
+	for _, d := range b.Dates {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			if _, err := time.Parse("01-02", d); err != nil {
+				return NewErrorFrom(ErrInvalidData, "invalid blackout date %q, expected YYYY-MM-DD or MM-DD", d)
+			}
+		}
+	}
+	for _, t := range []string{b.From, b.To} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", t); err != nil {
+			return NewErrorFrom(ErrInvalidData, "invalid blackout time %q, expected HH:MM", t)
+		}
+	}
+	return nil
+}
+
+// Match returns true if the time is in the blackout
+func (b WorkflowScheduleBlackout) Match(t time.Time) bool {
+	if len(b.Days) > 0 {
+		found := false
+		for _, d := range b.Days {
+			if wd, ok := parseWeekday(d); ok && wd == t.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(b.Dates) > 0 {
+		found := false
+		for _, d := range b.Dates {
+			if d == t.Format("2006-01-02") || d == t.Format("01-02") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if b.From == "" && b.To == "" {
+		return true
+	}
+	// Times with the format HH:MM can be compared as strings
+	clock := t.Format("15:04")
+	from, to := b.From, b.To
+	if from == "" {
+		from = "00:00"
+	}
+	if to == "" {
+		to = "24:00"
+	}
+	if from <= to {
+		return clock >= from && clock < to
+	}
+	// The blackout spans midnight
+	return clock >= from || clock < to
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func (d V2WorkflowHookData) scheduleCron() (*cronexpr.Expression, *time.Location, error) {
+	cronExpr, err := cronexpr.Parse(d.Cron)
+	if err != nil {
+		return nil, nil, NewErrorFrom(ErrInvalidData, "unable to parse cron expression %q: %v", d.Cron, err)
+	}
+	loc, err := time.LoadLocation(d.CronTimeZone)
+	if err != nil {
+		return nil, nil, NewErrorFrom(ErrInvalidData, "unable to parse timezone %q: %v", d.CronTimeZone, err)
+	}
+	return cronExpr, loc, nil
+}
+
+// IsScheduleBlackedOut returns true if the time is in one of the blackouts of the scheduler
+func (d V2WorkflowHookData) IsScheduleBlackedOut(t time.Time) bool {
+	for _, b := range d.ScheduleBlackouts {
+		if b.Match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduleNextTimes returns the next n fire times of the scheduler after the given time, blackouts excluded.
+// The jitter is not applied.
+func (d V2WorkflowHookData) ScheduleNextTimes(from time.Time, n int) ([]time.Time, error) {
+	cronExpr, loc, err := d.scheduleCron()
+	if err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, 0, n)
+	t := from.In(loc)
+	for i := 0; i < workflowScheduleMaxIterations && len(times) < n; i++ {
+		t = cronExpr.Next(t)
+		if t.IsZero() {
+			break
+		}
+		if d.IsScheduleBlackedOut(t) {
+			continue
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// ScheduleMissedTimes returns the fire times of the scheduler after from and until to (included), blackouts excluded.
+// Only the last WorkflowScheduleMaxCatchUp times are returned.
+func (d V2WorkflowHookData) ScheduleMissedTimes(from, to time.Time) ([]time.Time, error) {
+	cronExpr, loc, err := d.scheduleCron()
+	if err != nil {
+		return nil, err
+	}
+	var times []time.Time
+	t := from.In(loc)
+	for i := 0; i < workflowScheduleMaxIterations; i++ {
+		t = cronExpr.Next(t)
+		if t.IsZero() || t.After(to) {
+			break
+		}
+		if d.IsScheduleBlackedOut(t) {
+			continue
+		}
+		times = append(times, t)
+	}
+	if len(times) > WorkflowScheduleMaxCatchUp {
+		times = times[len(times)-WorkflowScheduleMaxCatchUp:]
+	}
+	return times, nil
+}
+
+// ScheduleJitterDuration returns the maximum random delay to add to each execution
+func (d V2WorkflowHookData) ScheduleJitterDuration() time.Duration {
+	if d.ScheduleJitter == "" {
+		return 0
+	}
+	jitter, err := time.ParseDuration(d.ScheduleJitter)
+	if err != nil || jitter < 0 {
+		return 0
+	}
+	return jitter
+}
+
+// CheckSchedules checks the schedulers of the workflow
+func (w V2Workflow) CheckSchedules() []error {
+	if w.On == nil {
+		return nil
+	}
+	var errs []error
+	for _, s := range w.On.Schedule {
+		if _, err := cronexpr.Parse(s.Cron); err != nil {
+			errs = append(errs, NewErrorFrom(err, "workflow %s: unable to parse cron expression: %s", w.Name, s.Cron))
+		}
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s: unable to parse timezone %q of schedule %s", w.Name, s.Timezone, s.Cron))
+		}
+		if s.Jitter != "" {
+			if jitter, err := time.ParseDuration(s.Jitter); err != nil || jitter < 0 {
+				errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s: invalid jitter %q of schedule %s", w.Name, s.Jitter, s.Cron))
+			}
+		}
+		switch s.CatchUp {
+		case "", WorkflowScheduleCatchUpSkip, WorkflowScheduleCatchUpLast, WorkflowScheduleCatchUpAll:
+		default:
+			errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s: invalid catch-up %q of schedule %s, expected skip, last or all", w.Name, s.CatchUp, s.Cron))
+		}
+		for _, b := range s.Blackouts {
+			if err := b.Check(); err != nil {
+				errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s: schedule %s: %v", w.Name, s.Cron, err))
+			}
+		}
+		for jobID, inputs := range s.Inputs {
+			if err := CheckJobInputWithGate(w, jobID, inputs); err != nil {
+				errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s: schedule %s: %v", w.Name, s.Cron, err))
+				continue
+			}
+			job := w.Jobs[jobID]
+			if len(job.Needs) > 0 || (job.Stage != "" && len(w.Stages[job.Stage].Needs) > 0) {
+				errs = append(errs, NewErrorFrom(ErrInvalidData, "workflow %s: schedule %s: unable to send input to a non root job %q", w.Name, s.Cron, jobID))
+			}
+		}
+	}
+	return errs
+}
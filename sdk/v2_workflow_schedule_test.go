@@ -0,0 +1,122 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowScheduleBlackoutMatch(t *testing.T) {
+	weekend := WorkflowScheduleBlackout{Days: []string{"saturday", "Sun"}}
+	require.NoError(t, weekend.Check())
+	require.True(t, weekend.Match(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)))  // Saturday
+	require.True(t, weekend.Match(time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC)))  // Sunday
+	require.False(t, weekend.Match(time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC))) // Monday
+
+	holidays := WorkflowScheduleBlackout{Dates: []string{"12-25", "2024-05-01"}}
+	require.NoError(t, holidays.Check())
+	require.True(t, holidays.Match(time.Date(2030, 12, 25, 10, 0, 0, 0, time.UTC)))
+	require.True(t, holidays.Match(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+	require.False(t, holidays.Match(time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)))
+
+	night := WorkflowScheduleBlackout{From: "20:00", To: "06:00"}
+	require.NoError(t, night.Check())
+	require.True(t, night.Match(time.Date(2024, 6, 3, 22, 0, 0, 0, time.UTC)))
+	require.True(t, night.Match(time.Date(2024, 6, 3, 5, 59, 0, 0, time.UTC)))
+	require.False(t, night.Match(time.Date(2024, 6, 3, 6, 0, 0, 0, time.UTC)))
+
+	fridayEvening := WorkflowScheduleBlackout{Days: []string{"fri"}, From: "16:00"}
+	require.True(t, fridayEvening.Match(time.Date(2024, 6, 7, 17, 0, 0, 0, time.UTC)))
+	require.False(t, fridayEvening.Match(time.Date(2024, 6, 7, 15, 0, 0, 0, time.UTC)))
+	require.False(t, fridayEvening.Match(time.Date(2024, 6, 6, 17, 0, 0, 0, time.UTC)))
+
+	require.Error(t, WorkflowScheduleBlackout{}.Check())
+	require.Error(t, WorkflowScheduleBlackout{Days: []string{"someday"}}.Check())
+	require.Error(t, WorkflowScheduleBlackout{Dates: []string{"25/12"}}.Check())
+	require.Error(t, WorkflowScheduleBlackout{From: "8h"}.Check())
+}
+
+func TestV2WorkflowHookDataScheduleTimes(t *testing.T) {
+	d := V2WorkflowHookData{
+		Cron:              "0 10 * * *",
+		CronTimeZone:      "Europe/Paris",
+		ScheduleBlackouts: []WorkflowScheduleBlackout{{Days: []string{"saturday", "sunday"}}},
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	// Friday 2024-06-07 12:00 in Paris
+	from := time.Date(2024, 6, 7, 12, 0, 0, 0, paris)
+	times, err := d.ScheduleNextTimes(from, 3)
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{
+		time.Date(2024, 6, 10, 10, 0, 0, 0, paris),
+		time.Date(2024, 6, 11, 10, 0, 0, 0, paris),
+		time.Date(2024, 6, 12, 10, 0, 0, 0, paris),
+	}, times)
+
+	// From Friday 09:00 to Tuesday 11:00, the weekend is skipped
+	missed, err := d.ScheduleMissedTimes(time.Date(2024, 6, 7, 9, 0, 0, 0, paris), time.Date(2024, 6, 11, 11, 0, 0, 0, paris))
+	require.NoError(t, err)
+	require.Equal(t, []time.Time{
+		time.Date(2024, 6, 7, 10, 0, 0, 0, paris),
+		time.Date(2024, 6, 10, 10, 0, 0, 0, paris),
+		time.Date(2024, 6, 11, 10, 0, 0, 0, paris),
+	}, missed)
+
+	// Only the last executions are caught up
+	d.ScheduleBlackouts = nil
+	missed, err = d.ScheduleMissedTimes(time.Date(2024, 5, 1, 0, 0, 0, 0, paris), time.Date(2024, 6, 1, 0, 0, 0, 0, paris))
+	require.NoError(t, err)
+	require.Len(t, missed, WorkflowScheduleMaxCatchUp)
+	require.Equal(t, time.Date(2024, 5, 31, 10, 0, 0, 0, paris), missed[len(missed)-1])
+
+	// A blackout that covers all the executions
+	d.ScheduleBlackouts = []WorkflowScheduleBlackout{{From: "09:00", To: "11:00"}}
+	times, err = d.ScheduleNextTimes(from, 1)
+	require.NoError(t, err)
+	require.Empty(t, times)
+
+	require.Equal(t, time.Duration(0), d.ScheduleJitterDuration())
+	d.ScheduleJitter = "10m"
+	require.Equal(t, 10*time.Minute, d.ScheduleJitterDuration())
+
+	_, err = V2WorkflowHookData{Cron: "0 10 * * *", CronTimeZone: "Mars/Olympus"}.ScheduleNextTimes(from, 1)
+	require.Error(t, err)
+}
+
+func TestV2WorkflowCheckSchedules(t *testing.T) {
+	w := V2Workflow{
+		Name: "my-workflow",
+		Gates: map[string]V2JobGate{
+			"deploy": {Inputs: map[string]V2JobGateInput{"env": {Type: "string"}}},
+		},
+		Jobs: map[string]V2Job{
+			"deploy": {Gate: "deploy"},
+			"notify": {Needs: []string{"deploy"}, Gate: "deploy"},
+		},
+		On: &WorkflowOn{
+			Schedule: []WorkflowOnSchedule{{
+				Cron:      "0 10 * * 1-5",
+				Timezone:  "UTC",
+				Branch:    "main",
+				Inputs:    V2WorkflowRunJobInputs{"deploy": GateInputs{"env": "prod"}},
+				Blackouts: []WorkflowScheduleBlackout{{Dates: []string{"12-25"}}},
+				Jitter:    "5m",
+				CatchUp:   WorkflowScheduleCatchUpAll,
+			}},
+		},
+	}
+	require.Empty(t, w.CheckSchedules())
+
+	w.On.Schedule[0] = WorkflowOnSchedule{
+		Cron:      "0 10 * * 1-5",
+		Timezone:  "Mars/Olympus",
+		Inputs:    V2WorkflowRunJobInputs{"deploy": GateInputs{"unknown": "prod"}, "notify": GateInputs{"env": "prod"}},
+		Blackouts: []WorkflowScheduleBlackout{{}},
+		Jitter:    "-5m",
+		CatchUp:   "first",
+	}
+	require.Len(t, w.CheckSchedules(), 6)
+}
@@ -5,7 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
 
 	"github.com/ovh/cds/sdk/interpolate"
 	"github.com/rockbears/yaml"
@@ -17,8 +23,12 @@ var _ Lintable = V2WorkflowTemplate{}
 type V2WorkflowTemplateParamType string
 
 const (
-	V2WorkflowTemplateParamTypeString V2WorkflowTemplateParamType = "string"
-	V2WorkflowTemplateParamTypeJson   V2WorkflowTemplateParamType = "json"
+	V2WorkflowTemplateParamTypeString  V2WorkflowTemplateParamType = "string"
+	V2WorkflowTemplateParamTypeJson    V2WorkflowTemplateParamType = "json"
+	V2WorkflowTemplateParamTypeBoolean V2WorkflowTemplateParamType = "boolean"
+	V2WorkflowTemplateParamTypeNumber  V2WorkflowTemplateParamType = "number"
+	V2WorkflowTemplateParamTypeEnum    V2WorkflowTemplateParamType = "enum"
+	V2WorkflowTemplateParamTypeList    V2WorkflowTemplateParamType = "list"
 )
 
 type V2WorkflowTemplate struct {
@@ -33,6 +43,168 @@ type V2WorkflowTemplateParameter struct {
 	Type     V2WorkflowTemplateParamType `json:"type,omitempty" jsonschema_extras:"order=2" jsonschema_description:"Type of the parameter"`
 	Required bool                        `json:"required,omitempty" jsonschema_extras:"order=3" jsonschema_description:"Indicate if the parameter is mandatory"`
 	Default  *string                     `json:"default,omitempty" jsonschema_extras:"order=4" jsonschema_description:"Default value"`
+	Values   []string                    `json:"values,omitempty" jsonschema_extras:"order=5" jsonschema_description:"Allowed values of an enum parameter"`
+}
+
+// Value converts the raw value of the parameter according to its type.
+// An empty value of a boolean, number or enum parameter is considered as not set.
+func (p V2WorkflowTemplateParameter) Value(raw string) (interface{}, error) {
+	switch p.Type {
+	case V2WorkflowTemplateParamTypeBoolean, V2WorkflowTemplateParamTypeNumber, V2WorkflowTemplateParamTypeEnum:
+		if raw == "" {
+			return nil, nil
+		}
+	}
+	switch p.Type {
+	case V2WorkflowTemplateParamTypeJson:
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("parameter %s: unable to unmarshal %s", p.Key, raw)
+		}
+		return value, nil
+	case V2WorkflowTemplateParamTypeBoolean:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %q is not a boolean", p.Key, raw)
+		}
+		return value, nil
+	case V2WorkflowTemplateParamTypeNumber:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %q is not a number", p.Key, raw)
+		}
+		return value, nil
+	case V2WorkflowTemplateParamTypeEnum:
+		if !IsInArray(raw, p.Values) {
+			return nil, fmt.Errorf("parameter %s: %q is not one of %s", p.Key, raw, strings.Join(p.Values, ", "))
+		}
+		return raw, nil
+	case V2WorkflowTemplateParamTypeList:
+		// A list can be given as a JSON array or as comma separated values
+		var values []interface{}
+		if strings.HasPrefix(strings.TrimSpace(raw), "[") {
+			if err := json.Unmarshal([]byte(raw), &values); err != nil {
+				return nil, fmt.Errorf("parameter %s: %q is not a list", p.Key, raw)
+			}
+			return values, nil
+		}
+		values = []interface{}{}
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	default:
+		return raw, nil
+	}
+}
+
+// CheckParameters checks that all required parameters are given and that all values match the parameter types
+func (wt V2WorkflowTemplate) CheckParameters(params map[string]string) []error {
+	var errs []error
+	for _, p := range wt.Parameters {
+		if p.Type == V2WorkflowTemplateParamTypeEnum && len(p.Values) == 0 {
+			errs = append(errs, fmt.Errorf("enum parameter %q of template %s must define its values", p.Key, wt.Name))
+			continue
+		}
+		raw, has := params[p.Key]
+		if !has || raw == "" {
+			if p.Required {
+				errs = append(errs, fmt.Errorf("required template parameter %q is missing or empty", p.Key))
+			}
+			continue
+		}
+		if _, err := p.Value(raw); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// IsSemverConstraint returns true if the given template reference is a version constraint (ie: ^1.2, ~1.2.0, >=1.0.0 <2.0.0, 1.x)
+func IsSemverConstraint(ref string) bool {
+	if ref == "" || strings.HasPrefix(ref, "refs/") {
+		return false
+	}
+	if strings.ContainsAny(ref[:1], "^~><=!") {
+		return true
+	}
+	if _, err := semver.StrictNewVersion(strings.TrimPrefix(ref, "v")); err == nil {
+		return false
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "v"), ".") {
+		if part == "x" || part == "X" || part == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSemverConstraint returns the highest tag matching the given constraint
+func ResolveSemverConstraint(constraint string, tags []string) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", NewErrorFrom(ErrWrongRequest, "invalid version constraint %q: %v", constraint, err)
+	}
+	var best *semver.Version
+	var bestTag string
+	for _, t := range tags {
+		v, err := semver.NewVersion(strings.TrimPrefix(t, "refs/tags/"))
+		if err != nil {
+			continue
+		}
+		if !c.Check(v) {
+			continue
+		}
+		if best == nil || v.GreaterThan(best) {
+			best = v
+			bestTag = t
+		}
+	}
+	if best == nil {
+		return "", NewErrorFrom(ErrNotFound, "no version matches %s", constraint)
+	}
+	return bestTag, nil
+}
+
+// TemplateVersionMatches returns true if the given template reference allows the given version
+func TemplateVersionMatches(ref string, version string) bool {
+	v, err := semver.NewVersion(strings.TrimPrefix(version, "refs/tags/"))
+	if err != nil {
+		return false
+	}
+	if IsSemverConstraint(ref) {
+		c, err := semver.NewConstraint(ref)
+		if err != nil {
+			return false
+		}
+		return c.Check(v)
+	}
+	current, err := semver.NewVersion(strings.TrimPrefix(ref, "refs/tags/"))
+	if err != nil {
+		return false
+	}
+	return !current.LessThan(v)
+}
+
+// V2WorkflowTemplateInstance references a workflow generated from a workflow template
+type V2WorkflowTemplateInstance struct {
+	ID                    string    `json:"id" db:"id" cli:"-"`
+	ProjectKey            string    `json:"project_key" db:"project_key" cli:"-"`
+	ProjectRepositoryID   string    `json:"project_repository_id" db:"project_repository_id" cli:"-"`
+	VCSServer             string    `json:"vcs_server" db:"vcs_server" cli:"vcs_server"`
+	Repository            string    `json:"repository" db:"repository" cli:"repository"`
+	WorkflowName          string    `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	WorkflowRef           string    `json:"workflow_ref" db:"workflow_ref" cli:"workflow_ref"`
+	WorkflowPath          string    `json:"workflow_path" db:"workflow_path" cli:"-"`
+	From                  string    `json:"from" db:"from" cli:"from"`
+	Template              string    `json:"template" db:"template" cli:"template"`
+	TemplateRef           string    `json:"template_ref" db:"template_ref" cli:"template_ref"`
+	TemplateCommit        string    `json:"template_commit" db:"template_commit" cli:"-"`
+	UpgradeRef            string    `json:"upgrade_ref,omitempty" db:"upgrade_ref" cli:"upgrade_ref"`
+	UpgradePullRequestURL string    `json:"upgrade_pull_request_url,omitempty" db:"upgrade_pull_request_url" cli:"upgrade_pull_request"`
+	LastModified          time.Time `json:"last_modified" db:"last_modified" cli:"last_modified"`
 }
 
 type V2WorkflowTemplateGenerateRequest struct {
@@ -96,42 +268,31 @@ func (wt V2WorkflowTemplate) Resolve(_ context.Context, w *V2Workflow) (string,
 		return "", errors.New("uninitialized workflow spec")
 	}
 
-	paramsDef := make(map[string]V2WorkflowTemplateParamType)
+	paramsDef := make(map[string]V2WorkflowTemplateParameter)
 	for _, v := range wt.Parameters {
-		paramsDef[v.Key] = v.Type
+		paramsDef[v.Key] = v
 	}
 
 	params := make(map[string]interface{})
 	for k, v := range w.Parameters {
-		paramType, has := paramsDef[k]
+		paramDef, has := paramsDef[k]
 		if has {
-			switch paramType {
-			case V2WorkflowTemplateParamTypeJson:
-				var value interface{}
-				if err := json.Unmarshal([]byte(v), &value); err != nil {
-					return "", NewErrorFrom(ErrWrongRequest, "unable to unmarshal %s", v)
-				}
-				params[k] = value
-			default:
-				params[k] = v
+			value, err := paramDef.Value(v)
+			if err != nil {
+				return "", NewErrorFrom(ErrWrongRequest, "%v", err)
 			}
+			params[k] = value
 		}
 	}
 
 	// Add parameter with default value
 	for _, v := range wt.Parameters {
 		if _, has := params[v.Key]; !has && v.Default != nil {
-			switch v.Type {
-			case V2WorkflowTemplateParamTypeJson:
-				var value interface{}
-				if err := json.Unmarshal([]byte(*v.Default), &value); err != nil {
-					return "", NewErrorFrom(ErrWrongRequest, "unable to unmarshal %s", value)
-				}
-				params[v.Key] = value
-			default:
-				params[v.Key] = *v.Default
+			value, err := v.Value(*v.Default)
+			if err != nil {
+				return "", NewErrorFrom(ErrWrongRequest, "%v", err)
 			}
-
+			params[v.Key] = value
 		}
 	}
 
@@ -244,3 +244,94 @@ spec: |-
 	require.Equal(t, 1, len(work.On.Push.Branches))
 	require.Equal(t, "master", work.On.Push.Branches[0])
 }
+
+func TestWorkflowTemplateTypedParameters(t *testing.T) {
+	tmpl := `name: myTemplate
+parameters:
+- key: env
+  type: enum
+  values: [dev, prod]
+  required: true
+- key: debug
+  type: boolean
+  default: "false"
+- key: replicas
+  type: number
+- key: regions
+  type: list
+spec: |-
+  jobs:
+    [[- range .params.regions ]]
+    deploy-[[.]]:
+      runs-on: [[ $.params.env ]]-model
+      [[- if $.params.debug ]]
+      env:
+        DEBUG: "true"
+      [[- end ]]
+      [[- if gt $.params.replicas 2.0 ]]
+      strategy:
+        matrix:
+          replica: [a, b, c]
+      [[- end ]]
+    [[- end ]]`
+
+	var template V2WorkflowTemplate
+	require.NoError(t, yaml.Unmarshal([]byte(tmpl), &template))
+
+	require.Len(t, template.CheckParameters(map[string]string{"env": "prod", "debug": "true", "replicas": "3", "regions": "eu, us"}), 0)
+	require.Len(t, template.CheckParameters(map[string]string{"env": "prod", "regions": `["eu"]`}), 0)
+
+	errs := template.CheckParameters(map[string]string{"env": "staging", "debug": "maybe", "replicas": "three", "regions": "[eu"})
+	require.Len(t, errs, 4)
+	require.Contains(t, errs[0].Error(), `"staging" is not one of dev, prod`)
+	require.Contains(t, errs[1].Error(), `"maybe" is not a boolean`)
+	require.Contains(t, errs[2].Error(), `"three" is not a number`)
+	require.Contains(t, errs[3].Error(), `"[eu" is not a list`)
+
+	errs = template.CheckParameters(map[string]string{})
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), `required template parameter "env" is missing or empty`)
+
+	work := V2Workflow{Name: "myworkflow", Parameters: map[string]string{"env": "prod", "debug": "true", "replicas": "3", "regions": "eu,us"}}
+	_, err := template.Resolve(context.TODO(), &work)
+	require.NoError(t, err)
+	require.Len(t, work.Jobs, 2)
+	require.Equal(t, "prod-model", work.Jobs["deploy-eu"].RunsOn.Model)
+	require.Equal(t, "true", work.Jobs["deploy-us"].Env["DEBUG"])
+	require.NotNil(t, work.Jobs["deploy-us"].Strategy)
+
+	work = V2Workflow{Name: "myworkflow", Parameters: map[string]string{"env": "dev", "replicas": "1", "regions": "eu"}}
+	_, err = template.Resolve(context.TODO(), &work)
+	require.NoError(t, err)
+	require.Len(t, work.Jobs, 1)
+	require.Empty(t, work.Jobs["deploy-eu"].Env)
+	require.Nil(t, work.Jobs["deploy-eu"].Strategy)
+}
+
+func TestWorkflowTemplateSemverConstraint(t *testing.T) {
+	require.True(t, IsSemverConstraint("^1.2"))
+	require.True(t, IsSemverConstraint("~1.2.0"))
+	require.True(t, IsSemverConstraint(">=1.0.0 <2.0.0"))
+	require.True(t, IsSemverConstraint("1.x"))
+	require.False(t, IsSemverConstraint("1.2.3"))
+	require.False(t, IsSemverConstraint("v1.2.3"))
+	require.False(t, IsSemverConstraint("main"))
+	require.False(t, IsSemverConstraint("refs/tags/1.2.3"))
+
+	tags := []string{"refs/tags/v1.1.0", "refs/tags/v1.2.0", "refs/tags/v1.10.1", "refs/tags/v2.0.0", "refs/tags/latest"}
+	tag, err := ResolveSemverConstraint("^1.2", tags)
+	require.NoError(t, err)
+	require.Equal(t, "refs/tags/v1.10.1", tag)
+
+	tag, err = ResolveSemverConstraint("~1.1", tags)
+	require.NoError(t, err)
+	require.Equal(t, "refs/tags/v1.1.0", tag)
+
+	_, err = ResolveSemverConstraint("^3", tags)
+	require.Error(t, err)
+
+	require.True(t, TemplateVersionMatches("^1.2", "refs/tags/v1.3.0"))
+	require.False(t, TemplateVersionMatches("^1.2", "refs/tags/v2.0.0"))
+	require.True(t, TemplateVersionMatches("v1.3.0", "refs/tags/v1.3.0"))
+	require.False(t, TemplateVersionMatches("refs/tags/v1.2.0", "refs/tags/v1.3.0"))
+}
@@ -104,6 +104,52 @@ name: MyDistantWorkflow
 	require.Equal(t, src, string(bts))
 }
 
+func TestUnmarshalV2WorkflowHooksReleaseAndBranches(t *testing.T) {
+	src := `jobs:
+  myFirstJob:
+    runs-on: docker-debian
+    steps:
+      - run: 'echo "Release: ${{git.release_tag}}"'
+name: MyWorkflow
+"on":
+  - push
+  - release
+  - branch-create
+  - branch-delete
+`
+	var w V2Workflow
+	require.NoError(t, yaml.Unmarshal([]byte(src), &w))
+	require.NotNil(t, w.On.Release)
+	require.NotNil(t, w.On.BranchCreate)
+	require.NotNil(t, w.On.BranchDelete)
+	bts, err := yaml.Marshal(w)
+	require.NoError(t, err)
+	require.Equal(t, src, string(bts))
+
+	src = `jobs:
+  myFirstJob:
+    runs-on: docker-debian
+    steps:
+      - run: 'echo "Deleted: ${{git.deleted_ref_name}}"'
+name: MyWorkflow
+"on":
+  branch-delete:
+    branches:
+      - feat/*
+  release:
+    tags:
+      - v*
+    types:
+      - published
+`
+	w = V2Workflow{}
+	require.NoError(t, yaml.Unmarshal([]byte(src), &w))
+	require.Equal(t, []WorkflowHookEventType{WorkflowHookEventTypeReleasePublished}, w.On.Release.Types)
+	bts, err = yaml.Marshal(w)
+	require.NoError(t, err)
+	require.Equal(t, src, string(bts))
+}
+
 func TestAncestor(t *testing.T) {
 	w := V2Workflow{
 		Stages: map[string]WorkflowStage{
@@ -184,3 +230,36 @@ func TestAncestor(t *testing.T) {
 	require.True(t, slices.Contains(parents, "job333"))
 	require.Len(t, parents, 9)
 }
+
+func TestGenerateMatrix(t *testing.T) {
+	matrix := map[string][]string{
+		"foo": {"foo1", "foo2"},
+		"bar": {"bar1", "bar2"},
+	}
+	all := make([]map[string]string, 0)
+	generateMatrix(matrix, []string{"foo", "bar"}, 0, map[string]string{}, &all)
+
+	require.Equal(t, 4, len(all))
+	foo1bar1 := false
+	foo1bar2 := false
+	foo2bar1 := false
+	foo2bar2 := false
+	for _, m := range all {
+		if m["foo"] == "foo1" && m["bar"] == "bar1" {
+			foo1bar1 = true
+		}
+		if m["foo"] == "foo1" && m["bar"] == "bar2" {
+			foo1bar2 = true
+		}
+		if m["foo"] == "foo2" && m["bar"] == "bar1" {
+			foo2bar1 = true
+		}
+		if m["foo"] == "foo2" && m["bar"] == "bar2" {
+			foo2bar2 = true
+		}
+	}
+	require.True(t, foo1bar1)
+	require.True(t, foo1bar2)
+	require.True(t, foo2bar1)
+	require.True(t, foo2bar2)
+}
@@ -22,6 +22,7 @@ const (
 	HeaderXVCSSSHUsername   = "X-CDS-VCS-SSH-USERNAME"
 	HeaderXVCSSSHPort       = "X-CDS-VCS-SSH-PORT"
 	HeaderXVCSSSHPrivateKey = "X-CDS-VCS-SSH-PRIVATE-KEY"
+	HeaderXVCSSSHKnownHosts = "X-CDS-VCS-SSH-KNOWN-HOSTS"
 
 	VCSTypeGitea           = "gitea"
 	VCSTypeForgejo         = "forgejo"
@@ -30,6 +31,8 @@ const (
 	VCSTypeBitbucketServer = "bitbucketserver"
 	VCSTypeBitbucketCloud  = "bitbucketcloud"
 	VCSTypeGithub          = "github"
+	VCSTypeAzureDevOps     = "azuredevops"
+	VCSTypeGit             = "git" // plain git server, without forge API
 )
 
 var (
@@ -135,6 +138,18 @@ var (
 		"push",
 	}
 
+	AzureDevOpsEvents = []string{
+		"git.push", // position is important here
+		"git.pullrequest.created",
+		"git.pullrequest.updated",
+		"git.pullrequest.merged",
+		"ms.vss-code.git-pullrequest-comment-event",
+	}
+
+	AzureDevOpsEventsDefault = []string{
+		"git.push",
+	}
+
 	GitlabEventsDefault = []string{
 		"Push Hook",
 		"Tag Push Hook",
@@ -221,6 +236,8 @@ type VCSAuthProject struct {
 	SSHUsername   string `json:"sshUsername,omitempty" db:"-"`
 	SSHPort       int    `json:"sshPort,omitempty" db:"-"`
 	SSHPrivateKey string `json:"sshPrivateKey,omitempty" db:"-"`
+	// Content of a known_hosts file checked by a plain git driver reached over ssh
+	SSHKnownHosts string `json:"sshKnownHosts,omitempty" db:"-"`
 }
 
 type VCSUserGPGKey struct {
@@ -251,6 +268,11 @@ func (v VCSProject) Lint(prj Project) error {
 		}
 	}
 
+	// A plain git server reached over ssh needs a key, there is no API token
+	if v.Type == VCSTypeGit && v.Auth.SSHUsername != "" && v.Auth.SSHKeyName == "" && v.Auth.SSHPrivateKey == "" {
+		return NewErrorFrom(ErrInvalidData, "missing ssh key")
+	}
+
 	if v.Auth.SSHKeyName != "" {
 		found := false
 		for _, k := range prj.Keys {
@@ -304,8 +326,10 @@ type VCSAuth struct {
 	Username string
 	Token    string
 
-	SSHUsername string
-	SSHPort     int
+	SSHUsername   string
+	SSHPort       int
+	SSHPrivateKey string
+	SSHKnownHosts string
 }
 
 // VCSServer is an interface for a OAuth VCS Server. The goal of this interface is to return a VCSAuthorizedClient.
@@ -348,11 +372,14 @@ type VCSAuthorizedClientCommon interface {
 	Commits(ctx context.Context, repo, branch, since, until string) ([]VCSCommit, error)
 	Commit(ctx context.Context, repo, hash string) (VCSCommit, error)
 	CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]VCSCommit, error)
+	DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]VCSFileDiff, error)
 
 	// PullRequests
 	PullRequest(ctx context.Context, repo string, id string) (VCSPullRequest, error)
 	PullRequestComment(ctx context.Context, repo string, c VCSPullRequestCommentRequest) error
 	PullRequestCreate(ctx context.Context, repo string, pr VCSPullRequest) (VCSPullRequest, error)
+	PullRequestMergeStatus(ctx context.Context, repo string, id string) (VCSPullRequestMergeStatus, error)
+	PullRequestMerge(ctx context.Context, repo string, req VCSPullRequestMergeRequest) error
 
 	//Hooks
 	CreateHook(ctx context.Context, repo string, hook *VCSHook) error
@@ -374,6 +401,9 @@ type VCSAuthorizedClientCommon interface {
 	// Insight
 	CreateInsightReport(ctx context.Context, repo string, sha string, insightKey string, report VCSInsight) error
 
+	// Annotations
+	CreateAnnotations(ctx context.Context, repo string, sha string, annotations VCSAnnotations) error
+
 	// Release
 	Release(ctx context.Context, repo, tagName, releaseTitle, releaseDescription string) (*VCSRelease, error)
 	UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.Reader, length int) error
@@ -12,10 +12,12 @@ import (
 
 // Those are icon for hooks
 const (
-	GitlabIcon    = "Gitlab"
-	GitHubIcon    = "Github"
-	BitbucketIcon = "Bitbucket"
-	GerritIcon    = "git"
+	GitlabIcon      = "Gitlab"
+	GitHubIcon      = "Github"
+	BitbucketIcon   = "Bitbucket"
+	GerritIcon      = "git"
+	AzureDevOpsIcon = "git"
+	GitIcon         = "git"
 )
 
 //NodeHook represents a hook which cann trigger the workflow from a given node